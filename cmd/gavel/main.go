@@ -0,0 +1,54 @@
+// Command gavel runs evaluation graphs from the command line.
+//
+// Usage:
+//
+//	gavel run -graph graph.yaml [-input input.json] [-dry-run] [-trace debug]
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "gavel: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run dispatches to the requested subcommand.
+// Streams are injected so subcommands can be exercised in tests.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return fmt.Errorf("missing subcommand")
+	}
+
+	switch args[0] {
+	case "run":
+		return runCommand(ctx, args[1:], stdin, stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		printUsage(stdout)
+		return nil
+	default:
+		printUsage(stderr)
+		return fmt.Errorf("unknown subcommand %q", args[0])
+	}
+}
+
+// printUsage writes the top-level command summary.
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: gavel <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	fmt.Fprintln(w, "  run    Execute an evaluation graph against a question and answers")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'gavel <command> -h' for command flags.")
+}
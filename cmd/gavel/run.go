@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/ahrav/go-gavel/infrastructure/llm"
+	"github.com/ahrav/go-gavel/infrastructure/units"
+	"github.com/ahrav/go-gavel/internal/application"
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/latency"
+)
+
+// Trace levels accepted by the -trace flag.
+const (
+	traceLevelInfo  = "info"
+	traceLevelDebug = "debug"
+)
+
+// Backoff of the provider call retries enabled with -call-retries.
+const (
+	callRetryBaseDelay = 500 * time.Millisecond
+	callRetryMaxDelay  = 10 * time.Second
+)
+
+// evaluationInput is the JSON document read by the run command.
+// Answers may be omitted when the graph generates them (e.g., via an answerer unit).
+type evaluationInput struct {
+	ID              string          `json:"id,omitempty"`
+	Question        string          `json:"question"`
+	Answers         []domain.Answer `json:"answers,omitempty"`
+	ReferenceAnswer string          `json:"reference_answer,omitempty"`
+}
+
+// runOptions holds the parsed flags for the run command.
+type runOptions struct {
+	graphPath  string
+	promptPath string
+	inputPath  string
+	dryRun     bool
+	traceLevel string
+	provider   string
+	timeout    time.Duration
+	retries    int
+	backoff    time.Duration
+	latency    bool
+
+	callRetries     int
+	callRetryTokens int64
+}
+
+// runCommand implements "gavel run". It loads a graph YAML, reads the
+// evaluation input from a file or stdin, executes the graph, and writes the
+// resulting Verdict to stdout as JSON. With -dry-run the graph is only
+// loaded and validated.
+func runCommand(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	opts, err := parseRunFlags(args, stderr)
+	if err != nil {
+		return err
+	}
+
+	debugf := func(format string, a ...any) {
+		if opts.traceLevel == traceLevelDebug {
+			fmt.Fprintf(stderr, "[debug] "+format+"\n", a...)
+		}
+	}
+
+	registryConfig := llm.RegistryConfig{
+		Providers:       llm.DefaultProviders,
+		DefaultProvider: opts.provider,
+		DefaultTimeout:  opts.timeout,
+		Retry: llm.RetryPolicy{
+			MaxRetries:     opts.callRetries,
+			BaseDelay:      callRetryBaseDelay,
+			MaxDelay:       callRetryMaxDelay,
+			MaxRetryTokens: opts.callRetryTokens,
+		},
+	}
+	// Debug tracing also logs provider requests, with content redacted, and
+	// structured unit events.
+	if opts.traceLevel == traceLevelDebug {
+		logger := slog.New(slog.NewTextHandler(stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		registryConfig.DebugLogger = logger
+		registryConfig.DefaultMiddleware = append(registryConfig.DefaultMiddleware, llm.CallObserverMiddleware(units.LogLLMCall))
+		units.SetEventLogger(logger)
+		defer units.SetEventLogger(nil)
+	}
+	var providerLatency *latency.Recorder
+	if opts.latency {
+		providerLatency = latency.NewRecorder(0)
+		registryConfig.DefaultMiddleware = append(registryConfig.DefaultMiddleware, llm.LatencyMiddleware(providerLatency))
+	}
+	providers, err := llm.NewRegistry(registryConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create provider registry: %w", err)
+	}
+
+	// Deterministic graphs need no LLM client, so a missing API key is only
+	// reported here and surfaces later if an LLM unit actually requires it.
+	llmClient, err := providers.GetDefaultClient()
+	if err != nil {
+		debugf("default LLM client unavailable: %v", err)
+		llmClient = nil
+	}
+
+	unitRegistry := application.NewRegistry(llmClient)
+	unitRegistry.RegisterBuiltinUnits()
+
+	loader, err := application.NewGraphLoader(unitRegistry, providers)
+	if err != nil {
+		return fmt.Errorf("failed to create graph loader: %w", err)
+	}
+	if opts.promptPath != "" {
+		prompts, err := application.LoadPromptLibraryFile(opts.promptPath)
+		if err != nil {
+			return err
+		}
+		loader.SetPromptLibrary(prompts)
+	}
+
+	graph, err := loader.LoadFromFile(ctx, opts.graphPath)
+	if err != nil {
+		return fmt.Errorf("failed to load graph %s: %w", opts.graphPath, err)
+	}
+
+	order, err := graph.TopologicalSort()
+	if err != nil {
+		return fmt.Errorf("invalid graph %s: %w", opts.graphPath, err)
+	}
+	for i, node := range order {
+		debugf("node %d: %s", i+1, node.ID())
+	}
+
+	if opts.dryRun {
+		fmt.Fprintf(stdout, "graph %s is valid (%d nodes, fingerprint %s)\n", opts.graphPath, len(order), graph.Fingerprint())
+		return nil
+	}
+
+	input, err := readInput(opts.inputPath, stdin)
+	if err != nil {
+		return err
+	}
+
+	state, err := domain.NewEvaluationState(input.Question, input.Answers,
+		domain.WithItemID(input.ID),
+		domain.WithReferenceAnswer(input.ReferenceAnswer),
+		domain.WithTraceLevel(opts.traceLevel),
+	)
+	if err != nil {
+		return fmt.Errorf("invalid input: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+
+	start := time.Now()
+	finalState, err := graph.ExecuteWithRetry(ctx, state, application.ItemRetryPolicy{
+		MaxAttempts: opts.retries + 1,
+		Backoff:     opts.backoff,
+	})
+	if err != nil {
+		return fmt.Errorf("evaluation failed: %w", err)
+	}
+	debugf("graph executed in %s", time.Since(start))
+	if opts.callRetries > 0 {
+		debugf("provider retries: %+v", providers.RetryStats())
+	}
+	if providerLatency != nil {
+		fmt.Fprintf(stderr, "provider latency: %v\n", providerLatency.Summary())
+	}
+
+	verdict, ok := finalState.GetVerdict()
+	if !ok || verdict == nil {
+		return fmt.Errorf("graph %s did not produce a verdict", opts.graphPath)
+	}
+	if verificationTrace, ok := domain.Get(finalState, domain.KeyVerificationTrace); ok {
+		debugf("verification trace: %s", verificationTrace)
+	}
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(verdict); err != nil {
+		return fmt.Errorf("failed to encode verdict: %w", err)
+	}
+	return nil
+}
+
+// parseRunFlags parses and validates the run command's flags.
+func parseRunFlags(args []string, stderr io.Writer) (runOptions, error) {
+	var opts runOptions
+
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.graphPath, "graph", "", "Path to the graph YAML file (required)")
+	fs.StringVar(&opts.promptPath, "prompts", "", "Path to a prompt library YAML file resolving units' prompt_ref parameters")
+	fs.StringVar(&opts.inputPath, "input", "-", "Path to the question/answers JSON file, or - for stdin")
+	fs.BoolVar(&opts.dryRun, "dry-run", false, "Load and validate the graph without executing it")
+	fs.StringVar(&opts.traceLevel, "trace", "", "Trace level: info or debug (debug logs execution details to stderr)")
+	fs.StringVar(&opts.provider, "provider", "openai", "Default LLM provider for units without an explicit model")
+	fs.DurationVar(&opts.timeout, "timeout", 5*time.Minute, "Overall evaluation timeout")
+	fs.IntVar(&opts.retries, "retries", 0, "Times to re-run the whole graph after a transient failure")
+	fs.DurationVar(&opts.backoff, "retry-backoff", time.Second, "Delay between whole-graph retries")
+	fs.BoolVar(&opts.latency, "latency", false, "Report p50/p95/p99 provider call latency to stderr")
+	fs.IntVar(&opts.callRetries, "call-retries", 0, "Times to retry a failed provider call; retried calls are charged to the budget")
+	fs.Int64Var(&opts.callRetryTokens, "call-retry-tokens", 0, "Tokens the retries of all provider calls may consume together (0 = unlimited)")
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if fs.NArg() > 0 {
+		return opts, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	if opts.graphPath == "" {
+		return opts, errors.New("-graph is required")
+	}
+	switch opts.traceLevel {
+	case "", traceLevelInfo, traceLevelDebug:
+	default:
+		return opts, fmt.Errorf("invalid -trace level %q: must be %q or %q", opts.traceLevel, traceLevelInfo, traceLevelDebug)
+	}
+	if opts.timeout <= 0 {
+		return opts, errors.New("-timeout must be positive")
+	}
+	if opts.retries < 0 {
+		return opts, errors.New("-retries must not be negative")
+	}
+	if opts.backoff < 0 {
+		return opts, errors.New("-retry-backoff must not be negative")
+	}
+	if opts.callRetries < 0 {
+		return opts, errors.New("-call-retries must not be negative")
+	}
+	if opts.callRetryTokens < 0 {
+		return opts, errors.New("-call-retry-tokens must not be negative")
+	}
+	return opts, nil
+}
+
+// readInput decodes the evaluation input from path, or from stdin when
+// path is "-".
+func readInput(path string, stdin io.Reader) (evaluationInput, error) {
+	var input evaluationInput
+
+	r := stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return input, fmt.Errorf("failed to open input: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&input); err != nil {
+		return input, fmt.Errorf("failed to decode input JSON: %w", err)
+	}
+	if input.Question == "" {
+		return input, errors.New("input must include a non-empty question")
+	}
+	return input, nil
+}
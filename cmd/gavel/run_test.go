@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+const testGraphPath = "testdata/exact_match_graph.yaml"
+
+// TestRunCommand tests end-to-end execution of a deterministic graph with
+// input read from a file and from stdin.
+func TestRunCommand(t *testing.T) {
+	input, err := os.ReadFile("testdata/input.json")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		stderr string
+	}{
+		{name: "input from file", args: []string{"-graph", testGraphPath, "-input", "testdata/input.json"}},
+		{name: "input from stdin", args: []string{"-graph", testGraphPath}, stdin: string(input)},
+		{name: "with item retries", args: []string{"-graph", testGraphPath, "-retries", "2", "-retry-backoff", "0s"}, stdin: string(input)},
+		{name: "with latency report", args: []string{"-graph", testGraphPath, "-latency"}, stdin: string(input), stderr: "provider latency: n=0"},
+		{name: "with call retries", args: []string{"-graph", testGraphPath, "-call-retries", "2", "-call-retry-tokens", "1000", "-trace", "debug"}, stdin: string(input), stderr: "provider retries: {Requests:0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := run(context.Background(), append([]string{"run"}, tt.args...),
+				strings.NewReader(tt.stdin), &stdout, &stderr)
+			require.NoError(t, err, stderr.String())
+
+			var verdict domain.Verdict
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &verdict))
+			require.NotNil(t, verdict.WinnerAnswer)
+			assert.Equal(t, "a2", verdict.WinnerAnswer.ID)
+			assert.InDelta(t, 1.0, verdict.AggregateScore, 1e-9)
+
+			require.NotNil(t, verdict.Provenance)
+			assert.Equal(t, "max_pool", verdict.Provenance.AggregationMethod)
+			require.Len(t, verdict.Provenance.Units, 2)
+			assert.Equal(t, "exact_match", verdict.Provenance.Units[0].Type)
+			assert.Contains(t, stderr.String(), tt.stderr)
+		})
+	}
+}
+
+// TestRunCommand_DryRun tests that dry runs validate the graph without
+// reading input or executing units.
+func TestRunCommand_DryRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), []string{"run", "-graph", testGraphPath, "-dry-run", "-trace", "debug"},
+		strings.NewReader("not json"), &stdout, &stderr)
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "is valid")
+	assert.Regexp(t, `fingerprint [0-9a-f]{64}`, stdout.String())
+	assert.Contains(t, stderr.String(), "[debug] node 1: main")
+}
+
+// TestRunCommand_Errors tests flag validation and input failures.
+func TestRunCommand_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		stdin   string
+		wantErr string
+	}{
+		{name: "missing subcommand", args: nil, wantErr: "missing subcommand"},
+		{name: "unknown subcommand", args: []string{"eval"}, wantErr: "unknown subcommand"},
+		{name: "missing graph", args: []string{"run"}, wantErr: "-graph is required"},
+		{name: "invalid trace level", args: []string{"run", "-graph", testGraphPath, "-trace", "verbose"}, wantErr: "invalid -trace level"},
+		{name: "negative retries", args: []string{"run", "-graph", testGraphPath, "-retries", "-1"}, wantErr: "-retries must not be negative"},
+		{name: "negative call retries", args: []string{"run", "-graph", testGraphPath, "-call-retries", "-1"}, wantErr: "-call-retries must not be negative"},
+		{name: "negative call retry tokens", args: []string{"run", "-graph", testGraphPath, "-call-retry-tokens", "-1"}, wantErr: "-call-retry-tokens must not be negative"},
+		{name: "missing graph file", args: []string{"run", "-graph", "testdata/missing.yaml"}, wantErr: "failed to load graph"},
+		{name: "malformed input", args: []string{"run", "-graph", testGraphPath}, stdin: "{", wantErr: "failed to decode input JSON"},
+		{name: "missing question", args: []string{"run", "-graph", testGraphPath}, stdin: `{"answers": []}`, wantErr: "non-empty question"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := run(context.Background(), tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package llm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// cacheKeyRequest is the canonical form of a request hashed by
+// ResponseCacheKey. It holds every option that can change a provider's
+// output and nothing else.
+type cacheKeyRequest struct {
+	Provider    string         `json:"provider"`
+	Model       string         `json:"model"`
+	System      string         `json:"system"`
+	Prompt      string         `json:"prompt"`
+	MaxTokens   int            `json:"max_tokens"`
+	Temperature *float64       `json:"temperature"`
+	TopP        *float64       `json:"top_p"`
+	Stop        []string       `json:"stop"`
+	Images      []domain.Image `json:"images,omitempty"`
+	Extra       map[string]any `json:"extra"`
+}
+
+// ResponseCacheKey returns the key under which a response from
+// providerType to prompt with opts may be cached. defaultModel is the model
+// the provider uses when opts does not name one. Requests with equal keys
+// are sent to the provider identically, so a cached response can never be
+// served for a request that would have been sampled differently.
+//
+// The key is the hex SHA-256 of the request as the provider sees it, after
+// this canonicalization:
+//   - opts are parsed with ParseRequestOptions, so the model and max_tokens
+//     defaults are filled in and invalid values the provider would ignore,
+//     such as a temperature of 5, are dropped;
+//   - the "deterministic" option is expanded through providerType's
+//     DeterminismProfile into the temperature, seed, and top_k it sends;
+//   - "timeout" is excluded because it does not affect the output;
+//   - stop sequences are sorted, since their order has no effect;
+//   - provider-specific options with nil values are dropped, and numbers
+//     are compared by value, so an int 42 and a float64 42 seed match.
+//
+// An unset temperature or top_p differs from an explicit one, since the
+// provider then applies its own default. Returns an error if a
+// provider-specific option cannot be encoded as JSON.
+func ResponseCacheKey(providerType, defaultModel, prompt string, opts map[string]any) (string, error) {
+	options := ParseRequestOptions(opts, defaultModel).withDeterminism(providerType)
+
+	stop := slices.Clone(options.Stop)
+	slices.Sort(stop)
+
+	extra := make(map[string]any, len(options.Extra))
+	for k, v := range options.Extra {
+		if v != nil {
+			extra[k] = v
+		}
+	}
+
+	encoded, err := json.Marshal(cacheKeyRequest{
+		Provider:    providerType,
+		Model:       options.Model,
+		System:      options.System,
+		Prompt:      prompt,
+		MaxTokens:   options.MaxTokens,
+		Temperature: options.Temperature,
+		TopP:        options.TopP,
+		Stop:        stop,
+		Images:      options.Images,
+		Extra:       extra,
+	})
+	if err != nil {
+		return "", fmt.Errorf("encode request for cache key: %w", err)
+	}
+
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,105 @@
+package llm
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// TestResponseCacheKey tests that logically identical requests share a key
+// and that every outcome-affecting option changes it.
+func TestResponseCacheKey(t *testing.T) {
+	key := func(t *testing.T, provider string, opts map[string]any) string {
+		t.Helper()
+		k, err := ResponseCacheKey(provider, "gpt-4", "Rate this answer", opts)
+		require.NoError(t, err)
+		return k
+	}
+	base := map[string]any{
+		"temperature":     0.0,
+		"max_tokens":      500,
+		"response_format": map[string]string{"type": "json_object"},
+		"seed":            7,
+	}
+	with := func(k string, v any) map[string]any {
+		opts := map[string]any{}
+		for key, value := range base {
+			opts[key] = value
+		}
+		opts[k] = v
+		return opts
+	}
+
+	t.Run("identical requests hit", func(t *testing.T) {
+		tests := []struct {
+			name string
+			a, b map[string]any
+		}{
+			{name: "same options", a: base, b: with("seed", 7)},
+			{name: "timeout ignored", a: base, b: with("timeout", 5*time.Second)},
+			{name: "default model named explicitly", a: base, b: with("model", "gpt-4")},
+			{name: "default max_tokens named explicitly", a: nil, b: map[string]any{"max_tokens": DefaultMaxTokens}},
+			{name: "numbers compared by value", a: base, b: with("seed", 7.0)},
+			{name: "map value types", a: base, b: with("response_format", map[string]any{"type": "json_object"})},
+			{name: "stop order", a: with("stop", []string{"a", "b"}), b: with("stop", []any{"b", "a"})},
+			{name: "nil option dropped", a: base, b: with("logprobs", nil)},
+			{name: "invalid temperature ignored like the provider", a: nil, b: map[string]any{"temperature": 5.0}},
+			{
+				name: "deterministic expands to its profile",
+				a:    map[string]any{"deterministic": true, "temperature": 0.9},
+				b:    map[string]any{"temperature": 0.0, "seed": DefaultDeterministicSeed},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, key(t, "openai", tt.a), key(t, "openai", tt.b))
+			})
+		}
+	})
+
+	t.Run("different requests miss", func(t *testing.T) {
+		tests := []struct {
+			name string
+			opts map[string]any
+		}{
+			{name: "temperature", opts: with("temperature", 0.9)},
+			{name: "unset temperature", opts: map[string]any{"max_tokens": 500, "seed": 7, "response_format": base["response_format"]}},
+			{name: "seed", opts: with("seed", 8)},
+			{name: "max_tokens", opts: with("max_tokens", 501)},
+			{name: "response_format", opts: with("response_format", map[string]string{"type": "text"})},
+			{name: "model", opts: with("model", "gpt-4o")},
+			{name: "system prompt", opts: with("system", "Be strict.")},
+			{name: "top_p", opts: with("top_p", 0.5)},
+			{name: "stop", opts: with("stop", []string{"}"})},
+			{name: "images", opts: with("images", []domain.Image{{URL: "https://example.com/chart.png"}})},
+		}
+		baseKey := key(t, "openai", base)
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.NotEqual(t, baseKey, key(t, "openai", tt.opts))
+			})
+		}
+	})
+
+	t.Run("prompt and provider", func(t *testing.T) {
+		other, err := ResponseCacheKey("openai", "gpt-4", "Rate this answer!", base)
+		require.NoError(t, err)
+		assert.NotEqual(t, key(t, "openai", base), other)
+		assert.NotEqual(t, key(t, "openai", base), key(t, "google", base))
+	})
+
+	t.Run("deterministic profile depends on provider", func(t *testing.T) {
+		opts := map[string]any{"deterministic": true}
+		assert.NotEqual(t, key(t, "openai", opts), key(t, "anthropic", opts))
+	})
+
+	t.Run("unencodable option", func(t *testing.T) {
+		_, err := ResponseCacheKey("openai", "gpt-4", "prompt", map[string]any{"bias": math.NaN()})
+		assert.Error(t, err)
+	})
+}
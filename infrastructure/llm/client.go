@@ -38,6 +38,8 @@ package llm
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"maps"
 	"time"
 
 	"github.com/ahrav/go-gavel/internal/ports"
@@ -107,6 +109,21 @@ type ClientConfig struct {
 	// Middleware allows custom middleware insertion.
 	// These are applied in the order specified.
 	Middleware []Middleware
+
+	// DefaultOptions are request options applied to every call made through
+	// the client, such as top_p or stop sequences that unit configs do not
+	// expose. They are merged under the per-call options map: any key set
+	// in a call's options always takes precedence over the same key here.
+	DefaultOptions map[string]any
+
+	// DebugLogger, when set, logs every provider request and response at
+	// debug level. Logging sits closest to the provider so each retry
+	// attempt is recorded.
+	DebugLogger *slog.Logger
+
+	// Redaction controls what DebugLogger captures. The API key is always
+	// scrubbed from logged strings.
+	Redaction RedactionConfig
 }
 
 // Middleware wraps a CoreLLM implementation to add cross-cutting functionality.
@@ -118,8 +135,9 @@ type Middleware func(CoreLLM) CoreLLM
 // It wraps a provider-specific CoreLLM implementation with middleware
 // to provide production-ready features like resilience and observability.
 type Client struct {
-	core      CoreLLM
-	estimator TokenEstimator
+	core           CoreLLM
+	estimator      TokenEstimator
+	defaultOptions map[string]any
 }
 
 // NewClient creates a new LLM client with the specified provider and configuration.
@@ -144,6 +162,12 @@ func NewClient(providerType string, config ClientConfig) (ports.LLMClient, error
 		return nil, fmt.Errorf("failed to create provider: %w", err)
 	}
 
+	if config.DebugLogger != nil {
+		redaction := config.Redaction
+		redaction.Secrets = append(append([]string{}, redaction.Secrets...), config.APIKey)
+		core = DebugLoggingMiddleware(config.DebugLogger, redaction)(core)
+	}
+
 	// Apply middleware in reverse order so the first middleware is the outermost.
 	for i := len(config.Middleware) - 1; i >= 0; i-- {
 		core = config.Middleware[i](core)
@@ -154,9 +178,16 @@ func NewClient(providerType string, config ClientConfig) (ports.LLMClient, error
 		estimator = &SimpleTokenEstimator{}
 	}
 
+	var defaults map[string]any
+	if len(config.DefaultOptions) > 0 {
+		defaults = make(map[string]any, len(config.DefaultOptions))
+		maps.Copy(defaults, config.DefaultOptions)
+	}
+
 	return &Client{
-		core:      core,
-		estimator: estimator,
+		core:           core,
+		estimator:      estimator,
+		defaultOptions: defaults,
 	}, nil
 }
 
@@ -171,12 +202,27 @@ func (c *Client) Complete(ctx context.Context, prompt string, options map[string
 // CompleteWithUsage sends a prompt to the LLM and returns detailed usage information.
 // This method provides access to token counts for cost calculation and usage tracking.
 // The options parameter allows provider-specific configuration like temperature or max tokens.
+// Options are merged over the client's DefaultOptions, so per-call values win.
 func (c *Client) CompleteWithUsage(
 	ctx context.Context,
 	prompt string,
 	options map[string]any,
 ) (string, int, int, error) {
-	return c.core.DoRequest(ctx, prompt, options)
+	return c.core.DoRequest(ctx, prompt, c.mergeOptions(options))
+}
+
+// mergeOptions layers per-call options over the client's default options.
+// The caller's map is never modified; a new map is returned whenever
+// defaults are configured so middleware and providers see the merged view.
+func (c *Client) mergeOptions(options map[string]any) map[string]any {
+	if len(c.defaultOptions) == 0 {
+		return options
+	}
+
+	merged := make(map[string]any, len(c.defaultOptions)+len(options))
+	maps.Copy(merged, c.defaultOptions)
+	maps.Copy(merged, options)
+	return merged
 }
 
 // EstimateTokens returns an approximate token count for the given text.
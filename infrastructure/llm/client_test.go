@@ -393,3 +393,61 @@ func TestClientWithTimeout(t *testing.T) {
 }
 
 var _ ports.LLMClient = (*Client)(nil)
+
+// TestClientDefaultOptions tests that ClientConfig.DefaultOptions are merged
+// under per-call options, with per-call values taking precedence, and that
+// neither the caller's map nor the configured defaults are mutated.
+func TestClientDefaultOptions(t *testing.T) {
+	mock := NewMockCoreLLM()
+	RegisterProviderFactory("mock-default-options", func(ClientConfig) (CoreLLM, error) {
+		return mock, nil
+	})
+
+	defaults := map[string]any{
+		"top_p":       0.9,
+		"temperature": 0.2,
+		"stop":        []string{"\n\n"},
+	}
+	client, err := NewClient("mock-default-options", ClientConfig{
+		APIKey:         "test-api-key",
+		Model:          "test-model",
+		DefaultOptions: defaults,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	callOpts := map[string]any{"temperature": 0.7, "max_tokens": 100}
+	if _, err := client.Complete(context.Background(), "test prompt", callOpts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := mock.LastOpts
+	if got["temperature"] != 0.7 {
+		t.Errorf("expected per-call temperature 0.7 to win, got %v", got["temperature"])
+	}
+	if got["top_p"] != 0.9 {
+		t.Errorf("expected default top_p 0.9, got %v", got["top_p"])
+	}
+	if got["max_tokens"] != 100 {
+		t.Errorf("expected per-call max_tokens 100, got %v", got["max_tokens"])
+	}
+	if _, ok := got["stop"]; !ok {
+		t.Errorf("expected default stop sequences to be applied")
+	}
+
+	if _, ok := callOpts["top_p"]; ok {
+		t.Errorf("per-call options map must not be mutated")
+	}
+	if defaults["temperature"] != 0.2 {
+		t.Errorf("default options must not be mutated")
+	}
+
+	// Nil per-call options still receive the defaults.
+	if _, err := client.Complete(context.Background(), "test prompt", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.LastOpts["temperature"] != 0.2 {
+		t.Errorf("expected default temperature 0.2 with nil options, got %v", mock.LastOpts["temperature"])
+	}
+}
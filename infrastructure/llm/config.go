@@ -4,6 +4,12 @@ package llm
 // for LLM provider options. This file contains functions for extracting
 // and validating parameters from generic option maps used across providers.
 
+import (
+	"time"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
 // ExtractOptionalInt extracts an integer value from options map with validation.
 // Returns defaultVal if key doesn't exist, value is not an int, or validator fails.
 func ExtractOptionalInt(opts map[string]any, key string, defaultVal int, validator func(int) bool) int {
@@ -75,3 +81,93 @@ func ExtractOptionalFloat64(opts map[string]any, key string, defaultVal float64,
 
 	return floatVal
 }
+
+// ExtractOptionalDuration extracts a time.Duration value from options map with validation.
+// String values are parsed with time.ParseDuration (e.g., "30s") so that durations
+// decoded from YAML or JSON configuration are accepted.
+// Returns defaultVal if key doesn't exist, value cannot be converted, or validator fails.
+func ExtractOptionalDuration(opts map[string]any, key string, defaultVal time.Duration, validator func(time.Duration) bool) time.Duration {
+	if opts == nil {
+		return defaultVal
+	}
+
+	val, ok := opts[key]
+	if !ok {
+		return defaultVal
+	}
+
+	var durVal time.Duration
+	switch v := val.(type) {
+	case time.Duration:
+		durVal = v
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return defaultVal
+		}
+		durVal = parsed
+	default:
+		return defaultVal
+	}
+
+	if validator != nil && !validator(durVal) {
+		return defaultVal
+	}
+
+	return durVal
+}
+
+// ExtractOptionalStrings extracts a list of non-empty strings from options map.
+// Both []string and []any values are accepted so that lists decoded from YAML
+// or JSON configuration work. Returns nil if key doesn't exist, the value is
+// not a list of strings, or the list has no non-empty entries.
+func ExtractOptionalStrings(opts map[string]any, key string) []string {
+	if opts == nil {
+		return nil
+	}
+
+	var items []any
+	switch v := opts[key].(type) {
+	case []string:
+		for _, s := range v {
+			items = append(items, s)
+		}
+	case []any:
+		items = v
+	default:
+		return nil
+	}
+
+	var result []string
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil
+		}
+		if s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// ExtractOptionalImages extracts answer images from options map.
+// Returns nil if key doesn't exist or the value is not a []domain.Image.
+func ExtractOptionalImages(opts map[string]any, key string) []domain.Image {
+	images, _ := opts[key].([]domain.Image)
+	return images
+}
+
+// ExtractOptionalBool extracts a bool value from options map.
+// Returns defaultVal if key doesn't exist or value is not a bool.
+func ExtractOptionalBool(opts map[string]any, key string, defaultVal bool) bool {
+	if opts == nil {
+		return defaultVal
+	}
+
+	boolVal, ok := opts[key].(bool)
+	if !ok {
+		return defaultVal
+	}
+	return boolVal
+}
@@ -0,0 +1,83 @@
+package llm
+
+import (
+	"maps"
+	"sync"
+)
+
+// DefaultDeterministicSeed is the sampling seed sent to providers that
+// support seeded sampling when a deterministic request does not set its own
+// "seed" option.
+const DefaultDeterministicSeed = 42
+
+// DeterminismProfile describes the request options a provider needs to
+// produce output that is as reproducible as it supports. Providers interpret
+// temperature 0 differently, so a unit only states its deterministic intent
+// with the "deterministic" request option and the profile translates it.
+type DeterminismProfile struct {
+	// Temperature replaces the request's temperature.
+	Temperature float64
+
+	// TopK, when positive, restricts sampling to the K most likely tokens
+	// and replaces any "top_k" request option.
+	TopK int
+
+	// Seed reports whether the provider accepts a sampling seed. When set,
+	// DefaultDeterministicSeed is sent unless the request has a "seed".
+	Seed bool
+}
+
+// determinismProfiles holds the profile for each provider type. Providers
+// without a profile fall back to temperature 0.
+var (
+	determinismMu       sync.RWMutex
+	determinismProfiles = map[string]DeterminismProfile{
+		// OpenAI sampling at temperature 0 still varies between calls;
+		// a fixed seed makes it best-effort reproducible.
+		"openai": {Temperature: 0, Seed: true},
+		// Anthropic has no seed, so greedy decoding is forced with top_k=1.
+		"anthropic": {Temperature: 0, TopK: 1},
+		"google":    {Temperature: 0, TopK: 1, Seed: true},
+	}
+)
+
+// RegisterDeterminismProfile sets the profile used for providerType, so
+// custom providers registered with RegisterProviderFactory can describe
+// how they achieve deterministic output.
+func RegisterDeterminismProfile(providerType string, profile DeterminismProfile) {
+	determinismMu.Lock()
+	defer determinismMu.Unlock()
+	determinismProfiles[providerType] = profile
+}
+
+// DeterminismProfileFor returns the profile registered for providerType, or
+// a temperature-0 profile when none is registered.
+func DeterminismProfileFor(providerType string) DeterminismProfile {
+	determinismMu.RLock()
+	defer determinismMu.RUnlock()
+	return determinismProfiles[providerType]
+}
+
+// withDeterminism returns options translated through providerType's
+// determinism profile when the request is deterministic, and options
+// unchanged otherwise. The receiver's Extra map is not modified.
+func (o RequestOptions) withDeterminism(providerType string) RequestOptions {
+	if !o.Deterministic {
+		return o
+	}
+
+	profile := DeterminismProfileFor(providerType)
+	temperature := profile.Temperature
+	o.Temperature = &temperature
+
+	extra := make(map[string]any, len(o.Extra)+2)
+	maps.Copy(extra, o.Extra)
+	if profile.TopK > 0 {
+		extra["top_k"] = profile.TopK
+	}
+	if _, ok := extra["seed"]; profile.Seed && !ok {
+		extra["seed"] = DefaultDeterministicSeed
+	}
+	o.Extra = extra
+	return o
+}
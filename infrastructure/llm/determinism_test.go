@@ -0,0 +1,99 @@
+package llm
+
+import (
+	"math"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRequestOptions_WithDeterminism tests that the "deterministic" option
+// is translated through each provider's profile.
+func TestRequestOptions_WithDeterminism(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  string
+		opts      map[string]any
+		wantExtra map[string]any
+	}{
+		{name: "openai seeds", provider: "openai", wantExtra: map[string]any{"seed": DefaultDeterministicSeed}},
+		{name: "anthropic forces greedy decoding", provider: "anthropic", opts: map[string]any{"top_k": 40}, wantExtra: map[string]any{"top_k": 1}},
+		{name: "google seeds and forces greedy decoding", provider: "google", wantExtra: map[string]any{"top_k": 1, "seed": DefaultDeterministicSeed}},
+		{name: "request seed wins", provider: "openai", opts: map[string]any{"seed": 7}, wantExtra: map[string]any{"seed": 7}},
+		{name: "unknown provider only zeroes temperature", provider: "custom", wantExtra: map[string]any{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := map[string]any{"temperature": 0.9, "deterministic": true}
+			for k, v := range tt.opts {
+				opts[k] = v
+			}
+
+			parsed := ParseRequestOptions(opts, "model")
+			options := parsed.withDeterminism(tt.provider)
+
+			require.NotNil(t, options.Temperature)
+			assert.Zero(t, *options.Temperature)
+			assert.Equal(t, tt.wantExtra, options.Extra)
+			assert.Len(t, parsed.Extra, len(tt.opts), "the parsed options are not modified")
+		})
+	}
+
+	t.Run("non-deterministic requests are unchanged", func(t *testing.T) {
+		options := ParseRequestOptions(map[string]any{"temperature": 0.9}, "model").withDeterminism("openai")
+		require.NotNil(t, options.Temperature)
+		assert.Equal(t, 0.9, *options.Temperature)
+		assert.Empty(t, options.Extra)
+	})
+}
+
+// TestRegisterDeterminismProfile tests that custom providers can register
+// a profile.
+func TestRegisterDeterminismProfile(t *testing.T) {
+	RegisterDeterminismProfile("test-determinism", DeterminismProfile{TopK: 3})
+	t.Cleanup(func() {
+		determinismMu.Lock()
+		delete(determinismProfiles, "test-determinism")
+		determinismMu.Unlock()
+	})
+
+	assert.Equal(t, DeterminismProfile{TopK: 3}, DeterminismProfileFor("test-determinism"))
+	assert.Equal(t, DeterminismProfile{}, DeterminismProfileFor("unregistered"))
+}
+
+// TestProviders_DeterministicOptions tests that each provider sends the
+// options its profile sets.
+func TestProviders_DeterministicOptions(t *testing.T) {
+	deterministic := map[string]any{"deterministic": true, "temperature": 0.7}
+
+	t.Run("openai", func(t *testing.T) {
+		var req openai.ChatCompletionRequest
+		(&openAIProvider{}).applyRequestParameters(&req, ParseRequestOptions(deterministic, "gpt-4").withDeterminism("openai"))
+
+		require.NotNil(t, req.Seed)
+		assert.Equal(t, DefaultDeterministicSeed, *req.Seed)
+		assert.Equal(t, float32(math.SmallestNonzeroFloat32), req.Temperature, "zero temperature must survive serialization")
+	})
+
+	t.Run("anthropic", func(t *testing.T) {
+		params := (&anthropicProvider{}).buildAnthropicParams("prompt", ParseRequestOptions(deterministic, "claude").withDeterminism("anthropic"))
+
+		assert.Equal(t, int64(1), params.TopK.Value)
+		assert.Zero(t, params.Temperature.Value)
+		assert.True(t, params.Temperature.Valid())
+	})
+
+	t.Run("google", func(t *testing.T) {
+		config := (&googleProvider{}).buildGenerationConfig(ParseRequestOptions(deterministic, "gemini").withDeterminism("google"))
+
+		require.NotNil(t, config.Seed)
+		assert.Equal(t, int32(DefaultDeterministicSeed), *config.Seed)
+		require.NotNil(t, config.TopK)
+		assert.Equal(t, float32(1), *config.TopK)
+		require.NotNil(t, config.Temperature)
+		assert.Zero(t, *config.Temperature)
+	})
+}
@@ -0,0 +1,106 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+var _ ports.EmbeddingClient = (*CachingEmbeddingClient)(nil)
+
+// CachingEmbeddingClient wraps another EmbeddingClient and caches vectors by
+// input text so repeated answers are never re-embedded. Cache misses within a
+// batch are deduplicated and sent to the underlying client in a single call.
+// It is safe for concurrent use.
+type CachingEmbeddingClient struct {
+	underlying ports.EmbeddingClient
+	mu         sync.RWMutex
+	cache      map[string][]float64
+	maxSize    int
+}
+
+// NewCachingEmbeddingClient creates a caching wrapper for any EmbeddingClient.
+// The maxSize parameter bounds the number of cached vectors; once full, new
+// vectors are returned but not cached (matching CachingTokenEstimator).
+func NewCachingEmbeddingClient(underlying ports.EmbeddingClient, maxSize int) *CachingEmbeddingClient {
+	if maxSize <= 0 {
+		maxSize = 1000 // Default cache size
+	}
+	return &CachingEmbeddingClient{
+		underlying: underlying,
+		cache:      make(map[string][]float64),
+		maxSize:    maxSize,
+	}
+}
+
+// Embed returns embeddings for texts, serving cached vectors where available
+// and embedding the remaining unique texts in one batched request.
+// Returned vectors are copies and may be modified by the caller.
+func (c *CachingEmbeddingClient) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	result := make([][]float64, len(texts))
+	var misses []string
+	seen := make(map[string]struct{})
+
+	c.mu.RLock()
+	for i, text := range texts {
+		if vec, ok := c.cache[text]; ok {
+			result[i] = slices.Clone(vec)
+			continue
+		}
+		if _, dup := seen[text]; !dup {
+			seen[text] = struct{}{}
+			misses = append(misses, text)
+		}
+	}
+	c.mu.RUnlock()
+
+	if len(misses) == 0 {
+		return result, nil
+	}
+
+	vectors, err := c.underlying.Embed(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(misses) {
+		return nil, fmt.Errorf("embedding client returned %d vectors for %d texts", len(vectors), len(misses))
+	}
+
+	fresh := make(map[string][]float64, len(misses))
+	c.mu.Lock()
+	for i, text := range misses {
+		fresh[text] = vectors[i]
+		if len(c.cache) < c.maxSize {
+			c.cache[text] = slices.Clone(vectors[i])
+		}
+	}
+	c.mu.Unlock()
+
+	for i, text := range texts {
+		if result[i] == nil {
+			result[i] = slices.Clone(fresh[text])
+		}
+	}
+	return result, nil
+}
+
+// GetModel returns the model of the underlying embedding client.
+func (c *CachingEmbeddingClient) GetModel() string { return c.underlying.GetModel() }
+
+// ClearCache removes all cached embeddings.
+// Call this when switching models, since vectors are not comparable across models.
+func (c *CachingEmbeddingClient) ClearCache() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.cache)
+}
+
+// CacheSize returns the current number of cached embeddings.
+func (c *CachingEmbeddingClient) CacheSize() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.cache)
+}
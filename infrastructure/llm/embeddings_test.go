@@ -0,0 +1,85 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// stubEmbeddingClient returns a vector derived from each text's length and
+// records the batches it receives.
+type stubEmbeddingClient struct {
+	batches [][]string
+	err     error
+}
+
+func (s *stubEmbeddingClient) Embed(_ context.Context, texts []string) ([][]float64, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.batches = append(s.batches, texts)
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float64{float64(len(text)), 1}
+	}
+	return vectors, nil
+}
+
+func (s *stubEmbeddingClient) GetModel() string { return "stub-embed" }
+
+// TestCachingEmbeddingClient tests that cache misses are deduplicated into a
+// single batch, cached vectors are reused, and results preserve input order.
+func TestCachingEmbeddingClient(t *testing.T) {
+	stub := &stubEmbeddingClient{}
+	client := NewCachingEmbeddingClient(stub, 10)
+
+	vectors, err := client.Embed(context.Background(), []string{"aa", "b", "aa"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stub.batches) != 1 || len(stub.batches[0]) != 2 {
+		t.Fatalf("expected one batch of 2 unique texts, got %v", stub.batches)
+	}
+	if vectors[0][0] != 2 || vectors[1][0] != 1 || vectors[2][0] != 2 {
+		t.Errorf("vectors out of order: %v", vectors)
+	}
+
+	// Mutating a returned vector must not corrupt the cache.
+	vectors[0][0] = 99
+
+	vectors, err = client.Embed(context.Background(), []string{"b", "ccc", "aa"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stub.batches) != 2 || len(stub.batches[1]) != 1 || stub.batches[1][0] != "ccc" {
+		t.Fatalf("expected only the new text to be embedded, got %v", stub.batches)
+	}
+	if vectors[2][0] != 2 {
+		t.Errorf("cached vector was mutated: %v", vectors[2])
+	}
+	if client.CacheSize() != 3 {
+		t.Errorf("expected cache size 3, got %d", client.CacheSize())
+	}
+
+	client.ClearCache()
+	if client.CacheSize() != 0 {
+		t.Errorf("expected empty cache after ClearCache, got %d", client.CacheSize())
+	}
+	if client.GetModel() != "stub-embed" {
+		t.Errorf("expected underlying model, got %s", client.GetModel())
+	}
+}
+
+// TestCachingEmbeddingClient_Error tests that underlying errors are returned
+// and nothing is cached.
+func TestCachingEmbeddingClient_Error(t *testing.T) {
+	stub := &stubEmbeddingClient{err: errors.New("boom")}
+	client := NewCachingEmbeddingClient(stub, 0)
+
+	if _, err := client.Embed(context.Background(), []string{"x"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if client.CacheSize() != 0 {
+		t.Errorf("expected nothing cached on error, got %d", client.CacheSize())
+	}
+}
@@ -0,0 +1,67 @@
+package llm
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+)
+
+// IdempotencyKeyHeader is the HTTP header carrying a request's idempotency
+// key to providers that honor it.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyKeyContextKey is the context key of a call's idempotency key.
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a context carrying key as the idempotency key
+// of the provider call made with it. Providers that support idempotency
+// send the key with the request, so a provider receiving the same key
+// twice performs, and bills, the call only once. The retry middleware sets
+// a key for calls that have none, so callers only need this to share a
+// key across calls they retry themselves.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyKey returns the idempotency key carried by ctx, if any.
+func IdempotencyKey(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key, ok && key != ""
+}
+
+// NewIdempotencyKey returns an idempotency key for one logical call of
+// prompt with opts. The key starts with a hash of the request, so keys can
+// be traced back to the request they were made for, and ends with a random
+// suffix, so that deliberate repeats of the same request, such as repeated
+// samples of a judge, are still performed separately by the provider.
+func NewIdempotencyKey(prompt string, opts map[string]any) string {
+	requestHash, err := ResponseCacheKey("", "", prompt, opts)
+	if err != nil {
+		sum := sha256.Sum256([]byte(prompt))
+		requestHash = hex.EncodeToString(sum[:])
+	}
+	var suffix [8]byte
+	_, _ = rand.Read(suffix[:])
+	return "gavel-" + requestHash[:32] + "-" + hex.EncodeToString(suffix[:])
+}
+
+// idempotencyDoer sends the idempotency key carried by each request's
+// context in the IdempotencyKeyHeader, for provider SDKs that take an HTTP
+// client but no per-request headers.
+type idempotencyDoer struct {
+	next interface {
+		Do(*http.Request) (*http.Response, error)
+	}
+}
+
+// Do sends req through the wrapped client, adding the idempotency header
+// when req's context carries a key.
+func (d idempotencyDoer) Do(req *http.Request) (*http.Response, error) {
+	if key, ok := IdempotencyKey(req.Context()); ok && req.Header.Get(IdempotencyKeyHeader) == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	return d.next.Do(req)
+}
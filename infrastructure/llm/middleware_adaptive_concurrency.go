@@ -0,0 +1,216 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Default settings for AdaptiveConcurrency.
+const (
+	DefaultAdaptiveMinConcurrency = 1
+	DefaultAdaptiveMaxConcurrency = 32
+	DefaultAdaptiveDecreaseFactor = 0.5
+	DefaultAdaptiveBackoff        = time.Second
+)
+
+// AdaptiveConcurrencyConfig configures an AdaptiveConcurrency controller.
+// Zero values select the defaults.
+type AdaptiveConcurrencyConfig struct {
+	// Min is the lowest concurrency limit the controller backs off to.
+	Min int
+
+	// Max is the highest concurrency limit the controller grows to.
+	Max int
+
+	// Initial is the starting concurrency limit. Defaults to Min.
+	Initial int
+
+	// DecreaseFactor multiplies the limit when a request is rate limited.
+	// It must be in (0, 1).
+	DecreaseFactor float64
+
+	// Backoff is how long new requests are held after a rate limit before
+	// the provider is tried again at the reduced limit.
+	Backoff time.Duration
+}
+
+// AdaptiveConcurrency limits concurrent provider requests with an
+// additive-increase, multiplicative-decrease (AIMD) controller. The limit
+// grows by one after a full window of successful requests, that is as many
+// consecutive successes as the current limit, and is multiplied by
+// DecreaseFactor when a request is rate limited, after which new requests
+// are held for Backoff. The limit always stays within [Min, Max].
+// It is safe for concurrent use and may be shared by several clients that
+// draw on the same provider quota.
+type AdaptiveConcurrency struct {
+	mu           sync.Mutex
+	config       AdaptiveConcurrencyConfig
+	limit        int
+	inFlight     int
+	successes    int
+	backoffUntil time.Time
+
+	// epoch counts decreases. A rate limit only decreases the limit when
+	// the request was admitted in the current epoch, so a burst of rate
+	// limits from requests already in flight counts as one signal.
+	epoch int
+
+	// changed is closed and replaced whenever a slot frees up or the limit
+	// changes, waking waiting requests.
+	changed chan struct{}
+}
+
+// NewAdaptiveConcurrency creates a controller with config, applying
+// defaults for zero values and clamping Initial into [Min, Max].
+func NewAdaptiveConcurrency(config AdaptiveConcurrencyConfig) *AdaptiveConcurrency {
+	if config.Min <= 0 {
+		config.Min = DefaultAdaptiveMinConcurrency
+	}
+	if config.Max <= 0 {
+		config.Max = DefaultAdaptiveMaxConcurrency
+	}
+	config.Max = max(config.Max, config.Min)
+	if config.DecreaseFactor <= 0 || config.DecreaseFactor >= 1 {
+		config.DecreaseFactor = DefaultAdaptiveDecreaseFactor
+	}
+	if config.Backoff <= 0 {
+		config.Backoff = DefaultAdaptiveBackoff
+	}
+	config.Initial = min(max(config.Initial, config.Min), config.Max)
+
+	return &AdaptiveConcurrency{
+		config:  config,
+		limit:   config.Initial,
+		changed: make(chan struct{}),
+	}
+}
+
+// Limit returns the current concurrency limit, for reporting as a metric.
+func (a *AdaptiveConcurrency) Limit() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.limit
+}
+
+// InFlight returns the number of requests currently admitted.
+func (a *AdaptiveConcurrency) InFlight() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.inFlight
+}
+
+// acquire blocks until a request may start: the limit has room and no
+// backoff is in effect. It returns the epoch the request was admitted in,
+// or the context's error if ctx is done first.
+func (a *AdaptiveConcurrency) acquire(ctx context.Context) (int, error) {
+	for {
+		a.mu.Lock()
+		wait := time.Until(a.backoffUntil)
+		if wait <= 0 && a.inFlight < a.limit {
+			a.inFlight++
+			epoch := a.epoch
+			a.mu.Unlock()
+			return epoch, nil
+		}
+		changed := a.changed
+		a.mu.Unlock()
+
+		var timer *time.Timer
+		var expired <-chan time.Time
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			expired = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return 0, ctx.Err()
+		case <-changed:
+		case <-expired:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// release frees the slot of a request admitted in epoch and adjusts the
+// limit from its outcome. Errors other than rate limits leave the limit
+// unchanged.
+func (a *AdaptiveConcurrency) release(epoch int, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.inFlight--
+	switch {
+	case isRateLimitError(err):
+		a.successes = 0
+		if epoch == a.epoch {
+			a.epoch++
+			a.limit = max(int(float64(a.limit)*a.config.DecreaseFactor), a.config.Min)
+			a.backoffUntil = time.Now().Add(a.config.Backoff)
+		}
+	case err == nil:
+		a.successes++
+		if a.successes >= a.limit && a.limit < a.config.Max {
+			a.limit++
+			a.successes = 0
+		}
+	}
+
+	close(a.changed)
+	a.changed = make(chan struct{})
+}
+
+// isRateLimitError reports whether err is a provider rate limit.
+func isRateLimitError(err error) bool {
+	var providerErr *ProviderError
+	if !errors.As(err, &providerErr) {
+		return false
+	}
+	return providerErr.Type == ErrorTypeRateLimit || providerErr.StatusCode == 429
+}
+
+// adaptiveConcurrencyLLM admits requests through an AdaptiveConcurrency
+// controller.
+type adaptiveConcurrencyLLM struct {
+	next       CoreLLM
+	controller *AdaptiveConcurrency
+}
+
+// AdaptiveConcurrencyMiddleware creates middleware that limits concurrent
+// requests with controller, replacing a fixed MaxConcurrency guess with a
+// limit that tracks the provider's observed rate limiting. List it after
+// RetryMiddleware so that every attempt is admitted separately and each
+// rate limit reaches the controller, rather than a retried request holding
+// its slot through the retry delays.
+func AdaptiveConcurrencyMiddleware(controller *AdaptiveConcurrency) Middleware {
+	return func(next CoreLLM) CoreLLM {
+		return &adaptiveConcurrencyLLM{
+			next:       next,
+			controller: controller,
+		}
+	}
+}
+
+// DoRequest waits for the controller to admit the request, honoring
+// context cancellation, then forwards it and reports the outcome.
+func (a *adaptiveConcurrencyLLM) DoRequest(ctx context.Context, prompt string, opts map[string]any) (string, int, int, error) {
+	epoch, err := a.controller.acquire(ctx)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	response, tokensIn, tokensOut, err := a.next.DoRequest(ctx, prompt, opts)
+	a.controller.release(epoch, err)
+	return response, tokensIn, tokensOut, err
+}
+
+// GetModel returns the model name from the wrapped implementation.
+func (a *adaptiveConcurrencyLLM) GetModel() string { return a.next.GetModel() }
+
+// SetModel updates the model name in the wrapped implementation.
+func (a *adaptiveConcurrencyLLM) SetModel(m string) { a.next.SetModel(m) }
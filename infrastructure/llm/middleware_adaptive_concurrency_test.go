@@ -0,0 +1,159 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rateLimitErr is a provider rate limit as classified by the providers.
+var rateLimitErr = NewProviderError("test", ErrorTypeRateLimit, 429, "slow down", nil)
+
+// TestAdaptiveConcurrency_AIMD tests that the limit grows by one per window
+// of successes and shrinks multiplicatively on rate limits, within bounds.
+func TestAdaptiveConcurrency_AIMD(t *testing.T) {
+	ac := NewAdaptiveConcurrency(AdaptiveConcurrencyConfig{Min: 1, Max: 4, Initial: 2, Backoff: time.Millisecond})
+	ctx := context.Background()
+
+	succeed := func(n int) {
+		for range n {
+			epoch, err := ac.acquire(ctx)
+			require.NoError(t, err)
+			ac.release(epoch, nil)
+		}
+	}
+
+	succeed(2)
+	assert.Equal(t, 3, ac.Limit(), "a full window of successes adds one")
+	succeed(2)
+	assert.Equal(t, 3, ac.Limit(), "a partial window does not")
+	succeed(1)
+	assert.Equal(t, 4, ac.Limit())
+	succeed(10)
+	assert.Equal(t, 4, ac.Limit(), "the limit never exceeds Max")
+
+	epoch, err := ac.acquire(ctx)
+	require.NoError(t, err)
+	ac.release(epoch, fmt.Errorf("wrapped: %w", rateLimitErr))
+	assert.Equal(t, 2, ac.Limit(), "a rate limit halves the limit")
+
+	epoch, err = ac.acquire(ctx)
+	require.NoError(t, err)
+	ac.release(epoch, errors.New("server error"))
+	assert.Equal(t, 2, ac.Limit(), "other errors leave the limit unchanged")
+
+	for range 3 {
+		epoch, err := ac.acquire(ctx)
+		require.NoError(t, err)
+		ac.release(epoch, rateLimitErr)
+	}
+	assert.Equal(t, 1, ac.Limit(), "the limit never drops below Min")
+	assert.Zero(t, ac.InFlight())
+}
+
+// TestAdaptiveConcurrency_BurstCountsOnce tests that rate limits from
+// requests admitted before a decrease do not decrease the limit again.
+func TestAdaptiveConcurrency_BurstCountsOnce(t *testing.T) {
+	ac := NewAdaptiveConcurrency(AdaptiveConcurrencyConfig{Min: 1, Max: 16, Initial: 16, Backoff: time.Millisecond})
+
+	epochs := make([]int, 8)
+	for i := range epochs {
+		epoch, err := ac.acquire(context.Background())
+		require.NoError(t, err)
+		epochs[i] = epoch
+	}
+	for _, epoch := range epochs {
+		ac.release(epoch, rateLimitErr)
+	}
+	assert.Equal(t, 8, ac.Limit())
+}
+
+// blockingCore is a CoreLLM whose requests block until released, recording
+// the highest number of concurrent requests it saw.
+type blockingCore struct {
+	mu        sync.Mutex
+	active    int
+	maxActive int
+	calls     int
+	started   chan struct{}
+	release   chan struct{}
+	model     string
+}
+
+// DoRequest signals started and blocks until release is closed.
+func (b *blockingCore) DoRequest(ctx context.Context, prompt string, opts map[string]any) (string, int, int, error) {
+	b.mu.Lock()
+	b.active++
+	b.calls++
+	b.maxActive = max(b.maxActive, b.active)
+	b.mu.Unlock()
+	b.started <- struct{}{}
+
+	<-b.release
+
+	b.mu.Lock()
+	b.active--
+	b.mu.Unlock()
+	return "ok", 1, 1, nil
+}
+
+// GetModel returns the configured model.
+func (b *blockingCore) GetModel() string { return b.model }
+
+// SetModel updates the configured model.
+func (b *blockingCore) SetModel(m string) { b.model = m }
+
+// TestAdaptiveConcurrencyMiddleware_EnforcesLimit tests that no more than
+// the current limit of requests reach the provider at once.
+func TestAdaptiveConcurrencyMiddleware_EnforcesLimit(t *testing.T) {
+	core := &blockingCore{release: make(chan struct{}), started: make(chan struct{}, 10)}
+	ac := NewAdaptiveConcurrency(AdaptiveConcurrencyConfig{Min: 1, Max: 2, Initial: 2})
+	wrapped := AdaptiveConcurrencyMiddleware(ac)(core)
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, _, err := wrapped.DoRequest(context.Background(), "prompt", nil)
+			assert.NoError(t, err)
+		}()
+	}
+
+	<-core.started
+	<-core.started
+	assert.Eventually(t, func() bool { return ac.InFlight() == 2 }, time.Second, time.Millisecond)
+	close(core.release)
+	wg.Wait()
+
+	assert.Equal(t, 2, core.maxActive)
+	assert.Equal(t, 5, core.calls)
+	assert.Zero(t, ac.InFlight())
+}
+
+// TestAdaptiveConcurrencyMiddleware_CancelDuringBackoff tests that a
+// request waiting out a rate limit backoff returns when its context ends.
+func TestAdaptiveConcurrencyMiddleware_CancelDuringBackoff(t *testing.T) {
+	mock := NewMockCoreLLM()
+	mock.Error = rateLimitErr
+	ac := NewAdaptiveConcurrency(AdaptiveConcurrencyConfig{Min: 1, Max: 4, Initial: 4, Backoff: time.Hour})
+	wrapped := AdaptiveConcurrencyMiddleware(ac)(mock)
+
+	_, _, _, err := wrapped.DoRequest(context.Background(), "prompt", nil)
+	require.ErrorIs(t, err, rateLimitErr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, _, err = wrapped.DoRequest(ctx, "prompt", nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, 1, mock.GetCallCount(), "the held request never reached the provider")
+	assert.Equal(t, "test-model", wrapped.GetModel())
+}
@@ -0,0 +1,47 @@
+package llm
+
+import (
+	"context"
+	"time"
+)
+
+// CallObserver receives the outcome of one provider call: the model it was
+// sent to, its token usage, how long it took, and any error.
+type CallObserver func(ctx context.Context, model string, tokensIn, tokensOut int, latency time.Duration, err error)
+
+// callObserverLLM reports the outcome of every provider call to an observer.
+type callObserverLLM struct {
+	next    CoreLLM
+	observe CallObserver
+}
+
+// CallObserverMiddleware creates middleware that reports every request,
+// including failed ones, to observe with the request's context. The model
+// is the request's "model" option when set, otherwise the client's model.
+// Under RetryMiddleware each attempt is reported separately.
+func CallObserverMiddleware(observe CallObserver) Middleware {
+	return func(next CoreLLM) CoreLLM {
+		return &callObserverLLM{
+			next:    next,
+			observe: observe,
+		}
+	}
+}
+
+// DoRequest executes the request and reports its outcome.
+func (c *callObserverLLM) DoRequest(ctx context.Context, prompt string, opts map[string]any) (string, int, int, error) {
+	start := time.Now()
+	response, tokensIn, tokensOut, err := c.next.DoRequest(ctx, prompt, opts)
+	model, _ := opts["model"].(string)
+	if model == "" {
+		model = c.next.GetModel()
+	}
+	c.observe(ctx, model, tokensIn, tokensOut, time.Since(start), err)
+	return response, tokensIn, tokensOut, err
+}
+
+// GetModel returns the model name from the wrapped implementation.
+func (c *callObserverLLM) GetModel() string { return c.next.GetModel() }
+
+// SetModel updates the model name in the wrapped implementation.
+func (c *callObserverLLM) SetModel(model string) { c.next.SetModel(model) }
@@ -0,0 +1,40 @@
+package llm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCallObserverMiddleware_ReportsEveryCall tests that successful and
+// failed requests are both reported with their model and usage.
+func TestCallObserverMiddleware_ReportsEveryCall(t *testing.T) {
+	type outcome struct {
+		model     string
+		tokensOut int
+		failed    bool
+	}
+	var outcomes []outcome
+	observe := func(_ context.Context, model string, _, tokensOut int, latency time.Duration, err error) {
+		assert.GreaterOrEqual(t, latency, time.Duration(0))
+		outcomes = append(outcomes, outcome{model: model, tokensOut: tokensOut, failed: err != nil})
+	}
+
+	mock := NewMockCoreLLM()
+	mock.FailUntilAttempt = 1
+	wrapped := CallObserverMiddleware(observe)(mock)
+
+	_, _, _, err := wrapped.DoRequest(context.Background(), "prompt", nil)
+	require.Error(t, err)
+	_, _, tokensOut, err := wrapped.DoRequest(context.Background(), "prompt", map[string]any{"model": "override-model"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []outcome{
+		{model: "test-model", failed: true},
+		{model: "override-model", tokensOut: tokensOut},
+	}, outcomes)
+	assert.Equal(t, "test-model", wrapped.GetModel())
+}
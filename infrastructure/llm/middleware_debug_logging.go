@@ -0,0 +1,192 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// redactedValue replaces sensitive values in debug logs.
+const redactedValue = "[REDACTED]"
+
+// defaultMaxLoggedContent bounds prompt and response content in debug logs
+// when RedactionConfig.MaxContentLength is zero.
+const defaultMaxLoggedContent = 2000
+
+// sensitiveOptionMarkers identify request option keys whose values are
+// always redacted, matched case-insensitively as substrings.
+var sensitiveOptionMarkers = []string{"api_key", "apikey", "authorization", "secret", "password", "access_token", "bearer", "credential"}
+
+// RedactionConfig controls what provider debug logging captures. The zero
+// value logs request options and response metadata only: prompt and
+// response content is omitted, credential-like options are redacted, and
+// the client's API key is scrubbed from every logged string.
+type RedactionConfig struct {
+	// LogPrompts includes prompt content in request logs. Prompts may
+	// contain PII from evaluated answers, so this is off by default.
+	LogPrompts bool
+
+	// LogResponses includes response content in response logs.
+	LogResponses bool
+
+	// MaxContentLength truncates logged prompt and response content to
+	// this many runes. Zero uses a default of 2000.
+	MaxContentLength int
+
+	// RedactOptions lists additional request option keys whose values are
+	// replaced, on top of the built-in credential-like keys.
+	RedactOptions []string
+
+	// Secrets lists literal values scrubbed from every logged string.
+	// NewClient adds the client's API key automatically.
+	Secrets []string
+}
+
+// debugLoggingLLM logs provider requests and responses for diagnosis.
+type debugLoggingLLM struct {
+	next      CoreLLM
+	logger    *slog.Logger
+	redaction RedactionConfig
+}
+
+// DebugLoggingMiddleware creates middleware that logs each provider request
+// and response at debug level. What is captured is governed by redaction;
+// see RedactionConfig for the defaults. A nil logger disables logging.
+func DebugLoggingMiddleware(logger *slog.Logger, redaction RedactionConfig) Middleware {
+	return func(next CoreLLM) CoreLLM {
+		if logger == nil {
+			return next
+		}
+		return &debugLoggingLLM{
+			next:      next,
+			logger:    logger,
+			redaction: redaction,
+		}
+	}
+}
+
+// DoRequest logs the request, delegates to the wrapped implementation, and
+// logs the outcome with latency and token usage.
+func (d *debugLoggingLLM) DoRequest(ctx context.Context, prompt string, opts map[string]any) (string, int, int, error) {
+	model := d.next.GetModel()
+
+	requestAttrs := []any{
+		slog.String("model", model),
+		slog.Int("prompt_chars", len(prompt)),
+		slog.Any("options", d.redactOptions(opts)),
+	}
+	if d.redaction.LogPrompts {
+		requestAttrs = append(requestAttrs, slog.String("prompt", d.content(prompt)))
+	}
+	d.logger.DebugContext(ctx, "llm request", requestAttrs...)
+
+	start := time.Now()
+	response, tokensIn, tokensOut, err := d.next.DoRequest(ctx, prompt, opts)
+
+	responseAttrs := []any{
+		slog.String("model", model),
+		slog.Duration("latency", time.Since(start)),
+		slog.Int("tokens_in", tokensIn),
+		slog.Int("tokens_out", tokensOut),
+		slog.Int("response_chars", len(response)),
+	}
+	if err != nil {
+		responseAttrs = append(responseAttrs, slog.String("error", d.scrub(err.Error())))
+	}
+	if d.redaction.LogResponses {
+		responseAttrs = append(responseAttrs, slog.String("response", d.content(response)))
+	}
+	d.logger.DebugContext(ctx, "llm response", responseAttrs...)
+
+	return response, tokensIn, tokensOut, err
+}
+
+// redactOptions returns a copy of opts that is safe to log. Values of
+// sensitive keys are replaced and secrets are scrubbed from strings,
+// including within nested maps.
+func (d *debugLoggingLLM) redactOptions(opts map[string]any) map[string]any {
+	if opts == nil {
+		return nil
+	}
+	redacted := make(map[string]any, len(opts))
+	for k, v := range opts {
+		if d.isSensitiveOption(k) {
+			redacted[k] = redactedValue
+			continue
+		}
+		redacted[k] = d.redactValue(v)
+	}
+	return redacted
+}
+
+// redactValue scrubs secrets from string values and recurses into maps.
+// Answer images are content like the prompt, so only their count is kept.
+func (d *debugLoggingLLM) redactValue(v any) any {
+	switch val := v.(type) {
+	case string:
+		return d.scrub(val)
+	case []domain.Image:
+		return fmt.Sprintf("%d image(s)", len(val))
+	case map[string]any:
+		return d.redactOptions(val)
+	case map[string]string:
+		out := make(map[string]any, len(val))
+		for k, s := range val {
+			out[k] = s
+		}
+		return d.redactOptions(out)
+	default:
+		return v
+	}
+}
+
+// isSensitiveOption reports whether an option key's value must be redacted.
+func (d *debugLoggingLLM) isSensitiveOption(key string) bool {
+	lower := strings.ToLower(key)
+	for _, marker := range sensitiveOptionMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	for _, k := range d.redaction.RedactOptions {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// scrub replaces every configured secret in s.
+func (d *debugLoggingLLM) scrub(s string) string {
+	for _, secret := range d.redaction.Secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, redactedValue)
+		}
+	}
+	return s
+}
+
+// content prepares prompt or response text for logging by scrubbing
+// secrets and truncating to the configured length.
+func (d *debugLoggingLLM) content(s string) string {
+	s = d.scrub(s)
+	limit := d.redaction.MaxContentLength
+	if limit <= 0 {
+		limit = defaultMaxLoggedContent
+	}
+	if utf8.RuneCountInString(s) > limit {
+		s = string([]rune(s)[:limit]) + "...(truncated)"
+	}
+	return s
+}
+
+// GetModel returns the model name from the wrapped implementation.
+func (d *debugLoggingLLM) GetModel() string { return d.next.GetModel() }
+
+// SetModel updates the model name in the wrapped implementation.
+func (d *debugLoggingLLM) SetModel(model string) { d.next.SetModel(model) }
@@ -0,0 +1,127 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDebugTestLogger returns a logger that writes debug-level text records to buf.
+func newDebugTestLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// TestDebugLoggingMiddleware_RedactsByDefault tests that the zero
+// RedactionConfig logs metadata and options but omits prompt and response
+// content, redacts credential-like options, and scrubs secrets.
+func TestDebugLoggingMiddleware_RedactsByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	mock := NewMockCoreLLM()
+	mock.Response = "secret response body"
+	wrapped := DebugLoggingMiddleware(newDebugTestLogger(&buf), RedactionConfig{
+		Secrets: []string{"sk-live-123"},
+	})(mock)
+
+	opts := map[string]any{
+		"temperature":     0.2,
+		"max_tokens":      100,
+		"api_key":         "sk-live-123",
+		"response_format": map[string]string{"type": "json_object"},
+		"user":            "id sk-live-123",
+	}
+	response, _, _, err := wrapped.DoRequest(context.Background(), "private prompt text", opts)
+	require.NoError(t, err)
+	assert.Equal(t, "secret response body", response)
+
+	logs := buf.String()
+	assert.Contains(t, logs, "llm request")
+	assert.Contains(t, logs, "llm response")
+	assert.Contains(t, logs, "model=test-model")
+	assert.Contains(t, logs, "temperature:0.2")
+	assert.Contains(t, logs, "max_tokens:100")
+	assert.Contains(t, logs, "tokens_out=20")
+	assert.Contains(t, logs, "json_object")
+	assert.NotContains(t, logs, "sk-live-123")
+	assert.NotContains(t, logs, "private prompt text")
+	assert.NotContains(t, logs, "secret response body")
+	assert.Equal(t, "sk-live-123", opts["api_key"], "caller options must not be mutated")
+	assert.Equal(t, "sk-live-123", mock.LastOpts["api_key"], "provider must receive unredacted options")
+}
+
+// TestDebugLoggingMiddleware_ContentWhenEnabled tests that prompt and
+// response content is logged only when enabled, truncated, and scrubbed.
+func TestDebugLoggingMiddleware_ContentWhenEnabled(t *testing.T) {
+	var buf bytes.Buffer
+	mock := NewMockCoreLLM()
+	mock.Response = "the model said sk-live-123 " + strings.Repeat("x", 50)
+	wrapped := DebugLoggingMiddleware(newDebugTestLogger(&buf), RedactionConfig{
+		LogPrompts:       true,
+		LogResponses:     true,
+		MaxContentLength: 30,
+		RedactOptions:    []string{"user"},
+		Secrets:          []string{"sk-live-123"},
+	})(mock)
+
+	_, _, _, err := wrapped.DoRequest(context.Background(), "visible prompt", map[string]any{"user": "alice"})
+	require.NoError(t, err)
+
+	logs := buf.String()
+	assert.Contains(t, logs, "visible prompt")
+	assert.Contains(t, logs, "the model said [REDACTED]")
+	assert.Contains(t, logs, "(truncated)")
+	assert.NotContains(t, logs, "alice")
+	assert.NotContains(t, logs, "sk-live-123")
+}
+
+// TestDebugLoggingMiddleware_LogsErrors tests that provider errors are
+// logged with secrets scrubbed and returned unchanged.
+func TestDebugLoggingMiddleware_LogsErrors(t *testing.T) {
+	var buf bytes.Buffer
+	mock := NewMockCoreLLM()
+	providerErr := errors.New("unauthorized key sk-live-123")
+	mock.Error = providerErr
+	mock.FailUntilAttempt = 1
+	wrapped := DebugLoggingMiddleware(newDebugTestLogger(&buf), RedactionConfig{Secrets: []string{"sk-live-123"}})(mock)
+
+	_, _, _, err := wrapped.DoRequest(context.Background(), "prompt", nil)
+	require.ErrorIs(t, err, providerErr)
+	assert.Contains(t, buf.String(), "unauthorized key [REDACTED]")
+	assert.NotContains(t, buf.String(), "sk-live-123")
+}
+
+// TestDebugLoggingMiddleware_NilLogger tests that a nil logger leaves the
+// wrapped implementation untouched.
+func TestDebugLoggingMiddleware_NilLogger(t *testing.T) {
+	mock := NewMockCoreLLM()
+	assert.Same(t, mock, DebugLoggingMiddleware(nil, RedactionConfig{})(mock))
+}
+
+// TestClientDebugLogger tests that ClientConfig.DebugLogger enables logging
+// and always scrubs the client's API key.
+func TestClientDebugLogger(t *testing.T) {
+	var buf bytes.Buffer
+	mock := NewMockCoreLLM()
+	mock.Response = "echo test-api-key-value"
+	RegisterProviderFactory("mock-debug-logger", func(ClientConfig) (CoreLLM, error) {
+		return mock, nil
+	})
+
+	client, err := NewClient("mock-debug-logger", ClientConfig{
+		APIKey:      "test-api-key-value",
+		Model:       "test-model",
+		DebugLogger: newDebugTestLogger(&buf),
+		Redaction:   RedactionConfig{LogResponses: true},
+	})
+	require.NoError(t, err)
+
+	_, err = client.Complete(context.Background(), "prompt", nil)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "echo [REDACTED]")
+	assert.NotContains(t, buf.String(), "test-api-key-value")
+}
@@ -0,0 +1,40 @@
+package llm
+
+import (
+	"context"
+	"time"
+
+	"github.com/ahrav/go-gavel/internal/latency"
+)
+
+// latencyLLM records the latency of every provider call.
+type latencyLLM struct {
+	next     CoreLLM
+	recorder *latency.Recorder
+}
+
+// LatencyMiddleware creates middleware that records each request's latency
+// in recorder, including failed requests, so callers can report p50/p95/p99
+// provider latency or assert latency objectives.
+func LatencyMiddleware(recorder *latency.Recorder) Middleware {
+	return func(next CoreLLM) CoreLLM {
+		return &latencyLLM{
+			next:     next,
+			recorder: recorder,
+		}
+	}
+}
+
+// DoRequest executes the request and records how long it took.
+func (l *latencyLLM) DoRequest(ctx context.Context, prompt string, opts map[string]any) (string, int, int, error) {
+	start := time.Now()
+	response, tokensIn, tokensOut, err := l.next.DoRequest(ctx, prompt, opts)
+	l.recorder.Record(time.Since(start))
+	return response, tokensIn, tokensOut, err
+}
+
+// GetModel returns the model name from the wrapped implementation.
+func (l *latencyLLM) GetModel() string { return l.next.GetModel() }
+
+// SetModel updates the model name in the wrapped implementation.
+func (l *latencyLLM) SetModel(model string) { l.next.SetModel(model) }
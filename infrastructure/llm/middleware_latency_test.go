@@ -0,0 +1,32 @@
+package llm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/latency"
+)
+
+// TestLatencyMiddleware_RecordsEveryCall tests that successful and failed
+// requests are both recorded.
+func TestLatencyMiddleware_RecordsEveryCall(t *testing.T) {
+	mock := NewMockCoreLLM()
+	mock.ResponseDelay = 5 * time.Millisecond
+	mock.FailUntilAttempt = 1
+	recorder := latency.NewRecorder(2)
+	wrapped := LatencyMiddleware(recorder)(mock)
+
+	_, _, _, err := wrapped.DoRequest(context.Background(), "prompt", nil)
+	require.Error(t, err)
+	response, _, _, err := wrapped.DoRequest(context.Background(), "prompt", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "test response", response)
+
+	assert.Equal(t, 2, recorder.Count())
+	assert.GreaterOrEqual(t, recorder.Summary().P50, 5*time.Millisecond)
+	assert.Equal(t, "test-model", wrapped.GetModel())
+}
@@ -2,11 +2,19 @@ package llm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
+	"sync"
 	"time"
+
+	"github.com/ahrav/go-gavel/internal/domain"
 )
 
+// ErrRetryBudgetExhausted indicates that a failed request was not retried
+// because its RetryBudget had no tokens left.
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
 // retryLLM implements automatic retry logic with exponential backoff.
 // This handles transient failures by retrying requests with increasing
 // delays while respecting circuit breaker and timeout constraints.
@@ -15,32 +23,81 @@ type retryLLM struct {
 	maxRetries int
 	baseDelay  time.Duration
 	maxDelay   time.Duration
+	budget     *RetryBudget
+}
+
+// RetryOption configures RetryMiddleware.
+type RetryOption func(*retryLLM)
+
+// WithRetryBudget caps the tokens retries may consume with budget and
+// records every attempt in its stats. One budget may be shared by several
+// clients to bound their retries together.
+func WithRetryBudget(budget *RetryBudget) RetryOption {
+	return func(r *retryLLM) {
+		r.budget = budget
+	}
 }
 
 // RetryMiddleware creates middleware that automatically retries failed requests
 // with exponential backoff. This helps handle transient failures and improves
 // overall reliability of LLM interactions.
-func RetryMiddleware(maxRetries int, baseDelay, maxDelay time.Duration) Middleware {
+//
+// Every attempt of a request carries the same idempotency key, taken from
+// the caller's context or else generated with NewIdempotencyKey, so a
+// provider that honors keys performs a request whose earlier attempt
+// succeeded slowly only once.
+//
+// The token counts returned are the sums over all attempts, including
+// failed attempts that reported tokens the provider billed, and every
+// attempt after the first is recorded in the caller's domain.CallMeter, so
+// callers charging them to a budget account for the true spend of retries.
+func RetryMiddleware(maxRetries int, baseDelay, maxDelay time.Duration, opts ...RetryOption) Middleware {
 	return func(next CoreLLM) CoreLLM {
-		return &retryLLM{
+		r := &retryLLM{
 			next:       next,
 			maxRetries: maxRetries,
 			baseDelay:  baseDelay,
 			maxDelay:   maxDelay,
 		}
+		for _, opt := range opts {
+			opt(r)
+		}
+		return r
 	}
 }
 
 // DoRequest executes the request with automatic retry logic.
 // It implements exponential backoff and respects circuit breaker states
-// and context cancellation to avoid unnecessary retries.
+// and context cancellation to avoid unnecessary retries. A retry is not
+// attempted once the retry budget is spent; the error then wraps both the
+// last failure and ErrRetryBudgetExhausted.
 func (r *retryLLM) DoRequest(ctx context.Context, prompt string, opts map[string]any) (string, int, int, error) {
-	var lastErr error
+	var (
+		lastErr           error
+		totalIn, totalOut int
+		attempts          int
+		denied            bool
+	)
+	r.budget.recordRequest()
+	defer func() { domain.RecordExtraCalls(ctx, attempts-1) }()
+
+	if _, ok := IdempotencyKey(ctx); !ok {
+		ctx = WithIdempotencyKey(ctx, NewIdempotencyKey(prompt, opts))
+	}
 
 	for attempt := 0; attempt <= r.maxRetries; attempt++ {
+		if attempt > 0 && !r.budget.allowRetry() {
+			denied = true
+			break
+		}
+
 		response, tokensIn, tokensOut, err := r.next.DoRequest(ctx, prompt, opts)
+		attempts++
+		totalIn += tokensIn
+		totalOut += tokensOut
+		r.budget.recordAttempt(attempt > 0, tokensIn+tokensOut)
 		if err == nil {
-			return response, tokensIn, tokensOut, nil
+			return response, totalIn, totalOut, nil
 		}
 
 		lastErr = err
@@ -57,13 +114,17 @@ func (r *retryLLM) DoRequest(ctx context.Context, prompt string, opts map[string
 
 		select {
 		case <-ctx.Done():
-			return "", 0, 0, ctx.Err()
+			return "", totalIn, totalOut, ctx.Err()
 		case <-time.After(delay):
 			// Continue to next attempt.
 		}
 	}
 
-	return "", 0, 0, fmt.Errorf("request failed after %d attempts: %w", r.maxRetries+1, lastErr)
+	if denied {
+		return "", totalIn, totalOut, fmt.Errorf("request failed after %d attempts: %w: %w",
+			attempts, ErrRetryBudgetExhausted, lastErr)
+	}
+	return "", totalIn, totalOut, fmt.Errorf("request failed after %d attempts: %w", attempts, lastErr)
 }
 
 func (r *retryLLM) calculateDelay(attempt int) time.Duration {
@@ -95,3 +156,92 @@ func (r *retryLLM) GetModel() string { return r.next.GetModel() }
 
 // SetModel updates the model name in the wrapped implementation.
 func (r *retryLLM) SetModel(m string) { r.next.SetModel(m) }
+
+// RetryStats counts the requests and attempts made through a RetryBudget.
+type RetryStats struct {
+	// Requests is the number of requests received from callers.
+	Requests int64
+	// Attempts is the number of provider calls made, including first
+	// attempts.
+	Attempts int64
+	// Retries is the number of attempts after the first.
+	Retries int64
+	// Tokens is the input and output tokens reported by all attempts.
+	Tokens int64
+	// RetryTokens is the part of Tokens reported by retries. It is the
+	// amount charged against the budget.
+	RetryTokens int64
+	// Denied is the number of retries not attempted because the budget
+	// was spent.
+	Denied int64
+}
+
+// RetryBudget caps the tokens that retries may consume and records the
+// attempts of the retry middleware using it. Retries are allowed while
+// RetryTokens is below the cap; since a retry's cost is known only after
+// it completes, the last allowed retry may overshoot the cap.
+//
+// A nil *RetryBudget allows every retry and records nothing. RetryBudget
+// is safe for concurrent use.
+type RetryBudget struct {
+	maxTokens int64
+
+	mu    sync.Mutex
+	stats RetryStats
+}
+
+// NewRetryBudget returns a RetryBudget allowing retries to consume up to
+// maxTokens tokens. Zero means unlimited, so only the stats are recorded.
+func NewRetryBudget(maxTokens int64) (*RetryBudget, error) {
+	if maxTokens < 0 {
+		return nil, fmt.Errorf("retry budget cannot be negative, got %d tokens", maxTokens)
+	}
+	return &RetryBudget{maxTokens: maxTokens}, nil
+}
+
+// Stats returns a snapshot of the budget's counters.
+func (b *RetryBudget) Stats() RetryStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stats
+}
+
+// recordRequest counts a request received from a caller.
+func (b *RetryBudget) recordRequest() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	b.stats.Requests++
+	b.mu.Unlock()
+}
+
+// allowRetry reports whether another retry fits in the budget, counting
+// it as denied when it does not.
+func (b *RetryBudget) allowRetry() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.maxTokens > 0 && b.stats.RetryTokens >= b.maxTokens {
+		b.stats.Denied++
+		return false
+	}
+	return true
+}
+
+// recordAttempt counts one provider call and the tokens it reported.
+func (b *RetryBudget) recordAttempt(retry bool, tokens int) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stats.Attempts++
+	b.stats.Tokens += int64(tokens)
+	if retry {
+		b.stats.Retries++
+		b.stats.RetryTokens += int64(tokens)
+	}
+}
@@ -3,11 +3,14 @@ package llm
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
 )
 
 // TestRetryMiddleware_SuccessOnFirstAttempt tests that the retry middleware does
@@ -211,3 +214,116 @@ func TestRetryMiddleware_CalculateDelayEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// billedFailureLLM fails its first failures calls while still reporting
+// the input tokens the provider billed, then succeeds.
+type billedFailureLLM struct {
+	*MockCoreLLM
+	failures int
+	err      error
+}
+
+// DoRequest fails with 7 billed input tokens until failures calls have
+// been made.
+func (b *billedFailureLLM) DoRequest(ctx context.Context, prompt string, opts map[string]any) (string, int, int, error) {
+	response, tokensIn, tokensOut, err := b.MockCoreLLM.DoRequest(ctx, prompt, opts)
+	if b.failures < 0 || b.GetCallCount() <= b.failures {
+		return "", 7, 0, b.err
+	}
+	return response, tokensIn, tokensOut, err
+}
+
+// TestRetryMiddleware_ReportsUsageOfAllAttempts tests that tokens billed
+// for failed attempts are added to the returned usage and that the budget
+// records every attempt.
+func TestRetryMiddleware_ReportsUsageOfAllAttempts(t *testing.T) {
+	budget, err := NewRetryBudget(0)
+	require.NoError(t, err)
+	core := &billedFailureLLM{MockCoreLLM: NewMockCoreLLM(), failures: 2, err: errors.New("overloaded")}
+	wrapped := RetryMiddleware(3, time.Millisecond, 10*time.Millisecond, WithRetryBudget(budget))(core)
+
+	ctx, meter := domain.WithCallMeter(context.Background())
+	response, tokensIn, tokensOut, err := wrapped.DoRequest(ctx, "test prompt", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "test response", response)
+	assert.Equal(t, 7+7+10, tokensIn)
+	assert.Equal(t, 20, tokensOut)
+	assert.Equal(t, 3, meter.Calls(), "retries are recorded in the caller's meter")
+	assert.Equal(t, RetryStats{Requests: 1, Attempts: 3, Retries: 2, Tokens: 44, RetryTokens: 37}, budget.Stats())
+
+	t.Run("failed request", func(t *testing.T) {
+		core := &billedFailureLLM{MockCoreLLM: NewMockCoreLLM(), failures: -1, err: errors.New("overloaded")}
+		wrapped := RetryMiddleware(2, time.Millisecond, 10*time.Millisecond)(core)
+
+		ctx, meter := domain.WithCallMeter(context.Background())
+		_, tokensIn, tokensOut, err := wrapped.DoRequest(ctx, "test prompt", nil)
+		require.Error(t, err)
+		assert.Equal(t, 21, tokensIn)
+		assert.Zero(t, tokensOut)
+		assert.Equal(t, 3, meter.Calls())
+	})
+}
+
+// TestRetryMiddleware_RetryBudget tests that retries stop once the shared
+// retry budget is spent while first attempts are always made.
+func TestRetryMiddleware_RetryBudget(t *testing.T) {
+	budget, err := NewRetryBudget(10)
+	require.NoError(t, err)
+	failErr := errors.New("overloaded")
+	newClient := func() (CoreLLM, *billedFailureLLM) {
+		core := &billedFailureLLM{MockCoreLLM: NewMockCoreLLM(), failures: -1, err: failErr}
+		return RetryMiddleware(5, time.Millisecond, 10*time.Millisecond, WithRetryBudget(budget))(core), core
+	}
+
+	first, firstCore := newClient()
+	_, tokensIn, _, err := first.DoRequest(context.Background(), "test prompt", nil)
+	require.ErrorIs(t, err, ErrRetryBudgetExhausted)
+	assert.ErrorIs(t, err, failErr)
+	assert.Contains(t, err.Error(), "after 3 attempts")
+	assert.Equal(t, 3, firstCore.GetCallCount())
+	assert.Equal(t, 21, tokensIn)
+
+	second, secondCore := newClient()
+	_, _, _, err = second.DoRequest(context.Background(), "test prompt", nil)
+	require.ErrorIs(t, err, ErrRetryBudgetExhausted)
+	assert.Equal(t, 1, secondCore.GetCallCount())
+
+	assert.Equal(t, RetryStats{Requests: 2, Attempts: 4, Retries: 2, Tokens: 28, RetryTokens: 14, Denied: 2}, budget.Stats())
+
+	_, err = NewRetryBudget(-1)
+	assert.Error(t, err)
+}
+
+// TestRetryMiddleware_IdempotencyKey tests that every attempt of a request
+// carries the same idempotency key, that separate requests get different
+// keys, and that a caller's key is kept.
+func TestRetryMiddleware_IdempotencyKey(t *testing.T) {
+	keys := func(t *testing.T, ctx context.Context) []string {
+		t.Helper()
+		mock := NewMockCoreLLM()
+		mock.FailUntilAttempt = 2
+		wrapped := RetryMiddleware(3, time.Millisecond, 10*time.Millisecond)(mock)
+		_, _, _, err := wrapped.DoRequest(ctx, "test prompt", map[string]any{"temperature": 0.7})
+		require.NoError(t, err)
+
+		var keys []string
+		for _, attemptCtx := range mock.Contexts {
+			key, ok := IdempotencyKey(attemptCtx)
+			require.True(t, ok)
+			keys = append(keys, key)
+		}
+		return keys
+	}
+
+	first := keys(t, context.Background())
+	require.Len(t, first, 3)
+	assert.Equal(t, first[0], first[1])
+	assert.Equal(t, first[0], first[2])
+	assert.True(t, strings.HasPrefix(first[0], "gavel-"))
+
+	second := keys(t, context.Background())
+	assert.NotEqual(t, first[0], second[0], "separate calls must not share a key")
+
+	supplied := keys(t, WithIdempotencyKey(context.Background(), "caller-key"))
+	assert.Equal(t, []string{"caller-key", "caller-key", "caller-key"}, supplied)
+}
@@ -7,6 +7,7 @@ package llm
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"strings"
@@ -22,6 +23,10 @@ const (
 	AnthropicDefaultModel = "claude-3-5-sonnet-20241022"
 )
 
+// anthropicImageTypes are the image media types accepted by the Messages
+// API.
+var anthropicImageTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
 func init() {
 	// Registers the Anthropic provider with the central provider factory.
 	// This allows the factory to create instances of the Anthropic provider
@@ -77,9 +82,15 @@ func newAnthropicProvider(config ClientConfig) (CoreLLM, error) {
 // response, while also tracking token usage for both the prompt and the
 // completion.
 func (p *anthropicProvider) DoRequest(ctx context.Context, prompt string, opts map[string]any) (string, int, int, error) {
-	options := ParseRequestOptions(opts, p.model)
+	options := ParseRequestOptions(opts, p.model).withDeterminism("anthropic")
+	if err := validateImages("anthropic", options.Images, anthropicImageTypes); err != nil {
+		return "", 0, 0, err
+	}
 	params := p.buildAnthropicParams(prompt, options)
 
+	ctx, cancel := requestContext(ctx, options)
+	defer cancel()
+
 	message, err := p.client.Messages.New(ctx, params)
 	if err != nil {
 		return "", 0, 0, p.handleError(err)
@@ -90,11 +101,19 @@ func (p *anthropicProvider) DoRequest(ctx context.Context, prompt string, opts m
 
 // buildAnthropicParams creates the API request parameters with proper validation.
 // It constructs the message list and sets model-specific options like
-// temperature and max tokens.
+// temperature and max tokens. Images follow the prompt as image blocks of
+// the user message.
 func (p *anthropicProvider) buildAnthropicParams(prompt string, options RequestOptions) anthropic.MessageNewParams {
-	messages := []anthropic.MessageParam{
-		anthropic.NewUserMessage(anthropic.NewTextBlock(prompt)),
+	blocks := make([]anthropic.ContentBlockParamUnion, 0, len(options.Images)+1)
+	blocks = append(blocks, anthropic.NewTextBlock(prompt))
+	for _, img := range options.Images {
+		if img.URL != "" {
+			blocks = append(blocks, anthropic.NewImageBlock(anthropic.URLImageSourceParam{URL: img.URL}))
+			continue
+		}
+		blocks = append(blocks, anthropic.NewImageBlockBase64(img.MediaType, base64.StdEncoding.EncodeToString(img.Data)))
 	}
+	messages := []anthropic.MessageParam{anthropic.NewUserMessage(blocks...)}
 
 	params := anthropic.MessageNewParams{
 		Model:     anthropic.Model(options.Model),
@@ -108,6 +127,14 @@ func (p *anthropicProvider) buildAnthropicParams(prompt string, options RequestO
 		params.Temperature = anthropic.Float(*options.Temperature)
 	}
 
+	if len(options.Stop) > 0 {
+		params.StopSequences = options.Stop
+	}
+
+	if topK, ok := SafeInt(options.Extra["top_k"]); ok && topK > 0 {
+		params.TopK = anthropic.Int(int64(topK))
+	}
+
 	if options.System != "" {
 		params.System = []anthropic.TextBlockParam{{Text: options.System}}
 	}
@@ -127,9 +154,11 @@ func (p *anthropicProvider) processResponse(message *anthropic.Message, original
 		}
 	}
 
+	// The provider bills an empty response, so its usage is still
+	// reported.
 	responseStr := responseText.String()
 	if responseStr == "" {
-		return "", 0, 0, fmt.Errorf("empty response from Anthropic API")
+		return "", int(message.Usage.InputTokens), int(message.Usage.OutputTokens), fmt.Errorf("anthropic: %w", ErrEmptyResponse)
 	}
 
 	tokensIn := p.getTokenCount(message.Usage.InputTokens, originalPrompt)
@@ -11,6 +11,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
 )
 
 // mockUsage provides a mock structure for token usage information in test
@@ -250,6 +252,33 @@ func TestAnthropicProvider_DoRequest_WithOptions(t *testing.T) {
 	assert.Equal(t, 25, tokensOut)
 }
 
+// TestAnthropicProvider_DoRequest_Timeout tests that the "timeout" option
+// bounds a single request independently of the caller's context deadline.
+func TestAnthropicProvider_DoRequest_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	provider, err := newAnthropicProvider(ClientConfig{
+		APIKey:  "test-api-key",
+		BaseURL: server.URL,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	_, _, _, err = provider.DoRequest(ctx, "Test prompt", map[string]any{"timeout": 100 * time.Millisecond})
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.NoError(t, ctx.Err(), "caller context should remain active")
+}
+
 // TestAnthropicProvider_DoRequest_MultipleContentBlocks tests a response from
 // the Anthropic provider that contains multiple content blocks.
 // It verifies that the provider correctly concatenates text from multiple
@@ -514,3 +543,44 @@ func TestAnthropicProvider(t *testing.T) {
 	t.Run("ContextCancellation", func(t *testing.T) { suite.TestContextCancellation() })
 	t.Run("ModelGetterSetter", func(t *testing.T) { suite.TestModelGetterSetter() })
 }
+
+// TestAnthropicProvider_Images tests that answer images are sent as image
+// blocks after the prompt and that invalid images fail before any request
+// is made.
+func TestAnthropicProvider_Images(t *testing.T) {
+	t.Run("images become image blocks", func(t *testing.T) {
+		options := ParseRequestOptions(map[string]any{
+			"images": []domain.Image{
+				{URL: "https://example.com/chart.png"},
+				{Data: []byte("png"), MediaType: "image/png"},
+			},
+		}, "claude")
+
+		params := (&anthropicProvider{}).buildAnthropicParams("Rate this chart", options)
+		require.Len(t, params.Messages, 1)
+		blocks := params.Messages[0].Content
+		require.Len(t, blocks, 3)
+		assert.Equal(t, "Rate this chart", blocks[0].OfText.Text)
+		require.NotNil(t, blocks[1].OfImage)
+		assert.Equal(t, "https://example.com/chart.png", blocks[1].OfImage.Source.OfURL.URL)
+		require.NotNil(t, blocks[2].OfImage)
+		assert.Equal(t, "cG5n", blocks[2].OfImage.Source.OfBase64.Data)
+		assert.EqualValues(t, "image/png", blocks[2].OfImage.Source.OfBase64.MediaType)
+	})
+
+	t.Run("invalid image fails before the request", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("unexpected request")
+		}))
+		defer server.Close()
+		provider, err := newAnthropicProvider(ClientConfig{APIKey: "test-key", BaseURL: server.URL})
+		require.NoError(t, err)
+
+		_, _, _, err = provider.DoRequest(context.Background(), "Rate",
+			map[string]any{"images": []domain.Image{{Data: []byte("bmp"), MediaType: "image/bmp"}}})
+		var providerErr *ProviderError
+		require.ErrorAs(t, err, &providerErr)
+		assert.Equal(t, ErrorTypeBadRequest, providerErr.Type)
+		assert.ErrorIs(t, err, domain.ErrInvalidImage)
+	})
+}
@@ -7,8 +7,14 @@
 package llm
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
+	"slices"
 	"sync"
+	"time"
+
+	"github.com/ahrav/go-gavel/internal/domain"
 )
 
 // BaseProvider provides common, thread-safe functionality for all LLM providers,
@@ -53,6 +59,23 @@ type RequestOptions struct {
 	// System provides instructions or context to the model,
 	// guiding its behavior and response style for the conversation.
 	System string
+	// Timeout bounds the duration of this single provider request.
+	// It is applied in addition to any deadline on the caller's context, so
+	// callers can keep a generous overall budget while failing slow calls fast.
+	// A zero value means only the caller's context applies.
+	Timeout time.Duration
+	// Stop lists sequences that end generation when the model emits them.
+	// Providers that support stop sequences pass them through; others
+	// ignore the field.
+	Stop []string
+	// Deterministic asks the provider for the most reproducible output it
+	// supports. Providers translate it through their DeterminismProfile,
+	// which overrides Temperature and may set "top_k" or "seed" in Extra.
+	Deterministic bool
+	// Images are attached to the user message after the prompt so that
+	// multimodal models can consider them. They are set through the
+	// "images" option as a []domain.Image.
+	Images []domain.Image
 	// Extra holds any provider-specific options that are not part of the standardized set.
 	// This allows for flexible configuration of unique provider features.
 	Extra map[string]any
@@ -67,7 +90,12 @@ func ParseRequestOptions(opts map[string]any, defaultModel string) RequestOption
 		MaxTokens: ExtractOptionalInt(opts, "max_tokens", DefaultMaxTokens, IsPositiveInt),
 		Model:     ExtractOptionalString(opts, "model", defaultModel, IsNonEmptyString),
 		System:    ExtractOptionalString(opts, "system", "", nil),
+		Timeout:   ExtractOptionalDuration(opts, "timeout", 0, IsPositiveDuration),
+		Stop:      ExtractOptionalStrings(opts, "stop"),
+		Images:    ExtractOptionalImages(opts, "images"),
 		Extra:     make(map[string]any),
+
+		Deterministic: ExtractOptionalBool(opts, "deterministic", false),
 	}
 
 	if temp := ExtractOptionalFloat64(opts, "temperature", -1, IsValidTemperature); temp != -1 {
@@ -81,7 +109,7 @@ func ParseRequestOptions(opts map[string]any, defaultModel string) RequestOption
 	// Collect any provider-specific options that were not handled above.
 	for k, v := range opts {
 		switch k {
-		case "max_tokens", "model", "system", "temperature", "top_p":
+		case "max_tokens", "model", "system", "temperature", "top_p", "timeout", "stop", "deterministic", "images":
 		// These are standard options and have already been processed.
 		default:
 			options.Extra[k] = v
@@ -91,6 +119,39 @@ func ParseRequestOptions(opts map[string]any, defaultModel string) RequestOption
 	return options
 }
 
+// requestContext derives the context for a single provider request.
+// When options carry a Timeout, the returned context is bounded by it;
+// the parent's own deadline still applies if it is sooner.
+// Callers must always invoke the returned cancel function.
+func requestContext(ctx context.Context, options RequestOptions) (context.Context, context.CancelFunc) {
+	if options.Timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, options.Timeout)
+}
+
+// validateImages returns a bad request *ProviderError for the first image
+// in images that fails domain.Image.Validate or whose media type is not in
+// supported. A nil supported accepts any image media type. Images are
+// checked before any request is made so malformed input is not retried.
+func validateImages(provider string, images []domain.Image, supported []string) error {
+	for i, img := range images {
+		err := img.Validate()
+		if err == nil && supported != nil && img.MediaType != "" && !slices.Contains(supported, img.MediaType) {
+			err = fmt.Errorf("%w: %s does not accept media type %q", domain.ErrInvalidImage, provider, img.MediaType)
+		}
+		if err != nil {
+			return NewProviderError(provider, ErrorTypeBadRequest, 0, fmt.Sprintf("image %d", i), err)
+		}
+	}
+	return nil
+}
+
+// imageDataURL returns img's inline data as a base64 data URL.
+func imageDataURL(img domain.Image) string {
+	return "data:" + img.MediaType + ";base64," + base64.StdEncoding.EncodeToString(img.Data)
+}
+
 // TokenCounter provides a utility for estimating token counts from text.
 // This is useful when an exact tokenizer is not available for a given model.
 type TokenCounter struct {
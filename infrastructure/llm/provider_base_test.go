@@ -0,0 +1,85 @@
+package llm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseRequestOptions_Timeout tests that the "timeout" option is parsed
+// from durations and duration strings, ignores invalid values, and is not
+// forwarded to providers as an extra option.
+func TestParseRequestOptions_Timeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected time.Duration
+	}{
+		{name: "duration", value: 2 * time.Second, expected: 2 * time.Second},
+		{name: "duration string", value: "500ms", expected: 500 * time.Millisecond},
+		{name: "invalid string", value: "soon", expected: 0},
+		{name: "negative duration", value: -time.Second, expected: 0},
+		{name: "unsupported type", value: 5, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := ParseRequestOptions(map[string]any{"timeout": tt.value}, "test-model")
+			assert.Equal(t, tt.expected, options.Timeout)
+			assert.NotContains(t, options.Extra, "timeout")
+		})
+	}
+}
+
+// TestParseRequestOptions_Stop tests that the "stop" option accepts string
+// lists from code and from decoded configuration, drops empty entries,
+// ignores malformed values, and is not forwarded as an extra option.
+func TestParseRequestOptions_Stop(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected []string
+	}{
+		{name: "string slice", value: []string{"}", "END"}, expected: []string{"}", "END"}},
+		{name: "decoded list", value: []any{"END", ""}, expected: []string{"END"}},
+		{name: "mixed types", value: []any{"END", 3}, expected: nil},
+		{name: "single string", value: "END", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := ParseRequestOptions(map[string]any{"stop": tt.value}, "test-model")
+			assert.Equal(t, tt.expected, options.Stop)
+			assert.NotContains(t, options.Extra, "stop")
+		})
+	}
+}
+
+// TestRequestContext tests that a per-request timeout bounds the derived
+// context even when the parent context has a much longer deadline.
+func TestRequestContext(t *testing.T) {
+	parent, cancelParent := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelParent()
+
+	t.Run("no timeout returns parent", func(t *testing.T) {
+		ctx, cancel := requestContext(parent, RequestOptions{})
+		defer cancel()
+		assert.Equal(t, parent, ctx)
+	})
+
+	t.Run("timeout tightens deadline", func(t *testing.T) {
+		ctx, cancel := requestContext(parent, RequestOptions{Timeout: 50 * time.Millisecond})
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(50*time.Millisecond), deadline, time.Second)
+
+		<-ctx.Done()
+		assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+		assert.NoError(t, parent.Err(), "parent context must not be cancelled")
+	})
+}
@@ -15,6 +15,8 @@ import (
 
 	"google.golang.org/api/googleapi"
 	"google.golang.org/genai"
+
+	"github.com/ahrav/go-gavel/internal/domain"
 )
 
 // Google provider constants define model names and other provider-specific
@@ -25,6 +27,9 @@ const (
 	GoogleDefaultModel = "gemini-2.0-flash-exp"
 )
 
+// googleImageTypes are the image media types accepted by Gemini models.
+var googleImageTypes = []string{"image/png", "image/jpeg", "image/webp", "image/heic", "image/heif"}
+
 func init() {
 	RegisterProviderFactory("google", newGoogleProvider)
 }
@@ -85,19 +90,31 @@ func newGoogleProvider(config ClientConfig) (CoreLLM, error) {
 // This method returns the generated content, token counts, and any errors
 // that occurred.
 func (p *googleProvider) DoRequest(ctx context.Context, prompt string, opts map[string]any) (string, int, int, error) {
-	options := ParseRequestOptions(opts, p.model)
+	options := ParseRequestOptions(opts, p.model).withDeterminism("google")
+	if err := validateGoogleImages(options.Images); err != nil {
+		return "", 0, 0, err
+	}
 
 	req := p.buildGenerateContentRequest(prompt, options)
 	config := p.buildGenerationConfig(options)
 
+	ctx, cancel := requestContext(ctx, options)
+	defer cancel()
+
 	resp, err := p.client.Models.GenerateContent(ctx, options.Model, req, config)
 	if err != nil {
 		return "", 0, 0, p.handleError(err)
 	}
 
+	// The provider bills an empty response, so its usage is still
+	// reported.
 	content := resp.Text()
 	if content == "" {
-		return "", 0, 0, ErrEmptyResponse
+		var tokensIn, tokensOut int
+		if usage := resp.UsageMetadata; usage != nil {
+			tokensIn, tokensOut = int(usage.PromptTokenCount), int(usage.CandidatesTokenCount)
+		}
+		return "", tokensIn, tokensOut, ErrEmptyResponse
 	}
 
 	tokensIn := p.getTokenCount(resp.UsageMetadata, true, prompt)
@@ -125,7 +142,7 @@ func (p *googleProvider) getTokenCount(usage *genai.GenerateContentResponseUsage
 // buildGenerateContentRequest creates the content for a Google Gemini API
 // request.
 // It prepends the system prompt to the user prompt, as Google's API does not
-// have a separate system role.
+// have a separate system role, and attaches any images after it.
 func (p *googleProvider) buildGenerateContentRequest(prompt string, options RequestOptions) []*genai.Content {
 	finalPrompt := prompt
 	if options.System != "" {
@@ -133,9 +150,38 @@ func (p *googleProvider) buildGenerateContentRequest(prompt string, options Requ
 		finalPrompt = fmt.Sprintf("System: %s\n\nUser: %s", options.System, prompt)
 	}
 
-	return []*genai.Content{
-		genai.NewContentFromText(finalPrompt, genai.RoleUser),
+	if len(options.Images) == 0 {
+		return []*genai.Content{
+			genai.NewContentFromText(finalPrompt, genai.RoleUser),
+		}
+	}
+
+	// Images follow the prompt as parts of the same user content.
+	parts := make([]*genai.Part, 0, len(options.Images)+1)
+	parts = append(parts, genai.NewPartFromText(finalPrompt))
+	for _, img := range options.Images {
+		if img.URL != "" {
+			parts = append(parts, genai.NewPartFromURI(img.URL, img.MediaType))
+			continue
+		}
+		parts = append(parts, genai.NewPartFromBytes(img.Data, img.MediaType))
+	}
+	return []*genai.Content{genai.NewContentFromParts(parts, genai.RoleUser)}
+}
+
+// validateGoogleImages validates images for Gemini, which also needs the
+// media type of images referenced by URL.
+func validateGoogleImages(images []domain.Image) error {
+	if err := validateImages("google", images, googleImageTypes); err != nil {
+		return err
+	}
+	for i, img := range images {
+		if img.MediaType == "" {
+			return NewProviderError("google", ErrorTypeBadRequest, 0, fmt.Sprintf("image %d", i),
+				fmt.Errorf("%w: google requires a media type for image URLs", domain.ErrInvalidImage))
+		}
 	}
+	return nil
 }
 
 // buildGenerationConfig creates the generation configuration for a Google
@@ -170,6 +216,14 @@ func (p *googleProvider) buildGenerationConfig(options RequestOptions) *genai.Ge
 		config.TopK = genai.Ptr(float32(topK))
 	}
 
+	if len(options.Stop) > 0 {
+		config.StopSequences = options.Stop
+	}
+
+	if seed, ok := SafeInt(options.Extra["seed"]); ok && seed >= math.MinInt32 && seed <= math.MaxInt32 {
+		config.Seed = genai.Ptr(int32(seed))
+	}
+
 	return config
 }
 
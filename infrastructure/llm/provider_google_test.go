@@ -8,6 +8,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
 )
 
 // TestNewGoogleProvider tests the behavior of the newGoogleProvider function.
@@ -135,6 +137,47 @@ func TestBuildGenerateContentRequest(t *testing.T) {
 		require.Len(t, content, 1)
 		assert.NotNil(t, content[0])
 	})
+
+	t.Run("images follow the prompt", func(t *testing.T) {
+		options := RequestOptions{
+			Model: "gemini-pro",
+			Images: []domain.Image{
+				{URL: "https://example.com/chart.png", MediaType: "image/png"},
+				{Data: []byte("png"), MediaType: "image/png"},
+			},
+		}
+
+		content := provider.buildGenerateContentRequest("Rate this chart", options)
+
+		require.Len(t, content, 1)
+		parts := content[0].Parts
+		require.Len(t, parts, 3)
+		assert.Equal(t, "Rate this chart", parts[0].Text)
+		assert.Equal(t, "https://example.com/chart.png", parts[1].FileData.FileURI)
+		assert.Equal(t, []byte("png"), parts[2].InlineData.Data)
+		assert.Equal(t, "image/png", parts[2].InlineData.MIMEType)
+	})
+}
+
+// TestValidateGoogleImages tests that Gemini rejects invalid images,
+// unsupported media types, and image URLs without a media type.
+func TestValidateGoogleImages(t *testing.T) {
+	assert.NoError(t, validateGoogleImages([]domain.Image{
+		{URL: "https://example.com/chart.png", MediaType: "image/png"},
+		{Data: []byte("jpg"), MediaType: "image/jpeg"},
+	}))
+
+	for _, img := range []domain.Image{
+		{URL: "https://example.com/chart.png"},
+		{Data: []byte("gif"), MediaType: "image/gif"},
+		{},
+	} {
+		err := validateGoogleImages([]domain.Image{img})
+		var providerErr *ProviderError
+		require.ErrorAs(t, err, &providerErr)
+		assert.Equal(t, ErrorTypeBadRequest, providerErr.Type)
+		assert.ErrorIs(t, err, domain.ErrInvalidImage)
+	}
 }
 
 // TestBuildGenerationConfig tests the construction of the generation
@@ -202,6 +245,16 @@ func TestBuildGenerationConfig(t *testing.T) {
 		assert.Equal(t, float32(20), *config.TopK)
 	})
 
+	t.Run("stop sequences", func(t *testing.T) {
+		options := RequestOptions{
+			Model: "gemini-pro",
+			Stop:  []string{"\n\n", "END"},
+		}
+		config := provider.buildGenerationConfig(options)
+
+		assert.Equal(t, []string{"\n\n", "END"}, config.StopSequences)
+	})
+
 	t.Run("all valid options", func(t *testing.T) {
 		temp := 0.8
 		topP := 0.95
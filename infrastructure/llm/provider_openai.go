@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 
 	openai "github.com/sashabaranov/go-openai"
@@ -14,6 +15,10 @@ const (
 	OpenAIDefaultModel = "gpt-3.5-turbo"
 )
 
+// openAIImageTypes are the image media types accepted by OpenAI vision
+// models.
+var openAIImageTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp"}
+
 func init() {
 	RegisterProviderFactory("openai", newOpenAIProvider)
 }
@@ -51,12 +56,13 @@ func newOpenAIProvider(config ClientConfig) (CoreLLM, error) {
 		clientConfig.BaseURL = validatedURL
 	}
 
+	httpClient := &http.Client{}
 	if config.Timeout > 0 {
-		validatedTimeout := ValidateTimeout(config.Timeout)
-		clientConfig.HTTPClient = &http.Client{
-			Timeout: validatedTimeout,
-		}
+		httpClient.Timeout = ValidateTimeout(config.Timeout)
 	}
+	// OpenAI honors idempotency keys, so retries of a call that may have
+	// succeeded are not performed or billed twice.
+	clientConfig.HTTPClient = idempotencyDoer{next: httpClient}
 
 	client := openai.NewClientWithConfig(clientConfig)
 
@@ -72,7 +78,14 @@ func newOpenAIProvider(config ClientConfig) (CoreLLM, error) {
 // It handles OpenAI-specific request formatting, authentication, and response parsing,
 // and returns the generated content along with token usage data.
 func (p *openAIProvider) DoRequest(ctx context.Context, prompt string, opts map[string]any) (string, int, int, error) {
-	options := ParseRequestOptions(opts, p.model)
+	options := ParseRequestOptions(opts, p.model).withDeterminism("openai")
+
+	if err := validateImages("openai", options.Images, openAIImageTypes); err != nil {
+		return "", 0, 0, err
+	}
+
+	ctx, cancel := requestContext(ctx, options)
+	defer cancel()
 
 	req := p.buildChatCompletionRequest(prompt, options)
 	resp, err := p.client.CreateChatCompletion(ctx, req)
@@ -80,8 +93,10 @@ func (p *openAIProvider) DoRequest(ctx context.Context, prompt string, opts map[
 		return "", 0, 0, p.handleError(err)
 	}
 
+	// The provider bills a response without choices, so its usage is
+	// still reported.
 	if len(resp.Choices) == 0 {
-		return "", 0, 0, ErrNoResponseChoice
+		return "", resp.Usage.PromptTokens, resp.Usage.CompletionTokens, ErrNoResponseChoice
 	}
 
 	content := resp.Choices[0].Message.Content
@@ -116,6 +131,7 @@ func (p *openAIProvider) buildChatCompletionRequest(prompt string, options Reque
 
 // buildMessages creates the message slice for an OpenAI chat completion request.
 // It constructs the messages from the user prompt and an optional system prompt.
+// With images, the user message carries the prompt and images as content parts.
 func (p *openAIProvider) buildMessages(prompt string, options RequestOptions) []openai.ChatCompletionMessage {
 	messages := make([]openai.ChatCompletionMessage, 0, 2)
 
@@ -126,12 +142,30 @@ func (p *openAIProvider) buildMessages(prompt string, options RequestOptions) []
 		})
 	}
 
-	messages = append(messages, openai.ChatCompletionMessage{
-		Role:    openai.ChatMessageRoleUser,
-		Content: prompt,
-	})
+	if len(options.Images) == 0 {
+		return append(messages, openai.ChatCompletionMessage{
+			Role:    openai.ChatMessageRoleUser,
+			Content: prompt,
+		})
+	}
 
-	return messages
+	// Images follow the prompt as image_url parts of the same user message.
+	parts := make([]openai.ChatMessagePart, 0, len(options.Images)+1)
+	parts = append(parts, openai.ChatMessagePart{Type: openai.ChatMessagePartTypeText, Text: prompt})
+	for _, img := range options.Images {
+		url := img.URL
+		if url == "" {
+			url = imageDataURL(img)
+		}
+		parts = append(parts, openai.ChatMessagePart{
+			Type:     openai.ChatMessagePartTypeImageURL,
+			ImageURL: &openai.ChatMessageImageURL{URL: url},
+		})
+	}
+	return append(messages, openai.ChatCompletionMessage{
+		Role:         openai.ChatMessageRoleUser,
+		MultiContent: parts,
+	})
 }
 
 // applyRequestParameters applies and validates optional parameters to the request.
@@ -141,6 +175,11 @@ func (p *openAIProvider) applyRequestParameters(req *openai.ChatCompletionReques
 		// OpenAI API supports a temperature range of 0.0 to 2.0.
 		temp := ClampFloat64(*options.Temperature, 0.0, 2.0)
 		req.Temperature = float32(temp)
+		if req.Temperature == 0 {
+			// The client omits a zero temperature from the request, which
+			// the API then treats as its default of 1.0.
+			req.Temperature = math.SmallestNonzeroFloat32
+		}
 	}
 
 	if options.MaxTokens > 0 {
@@ -152,7 +191,15 @@ func (p *openAIProvider) applyRequestParameters(req *openai.ChatCompletionReques
 		req.TopP = float32(topP)
 	}
 
+	if len(options.Stop) > 0 {
+		req.Stop = options.Stop
+	}
+
 	// Handle provider-specific options.
+	if seed, ok := SafeInt(options.Extra["seed"]); ok {
+		req.Seed = &seed
+	}
+
 	if frequencyPenalty, ok := options.Extra["frequency_penalty"]; ok {
 		if penalty, valid := SafeFloat32(frequencyPenalty); valid {
 			req.FrequencyPenalty = float32(ClampFloat64(float64(penalty), MinPenalty, MaxPenalty))
@@ -12,6 +12,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
 )
 
 // mockOpenAIResponse represents a mock response from the OpenAI API for testing.
@@ -754,3 +756,70 @@ func TestOpenAIProvider(t *testing.T) {
 	t.Run("ContextCancellation", func(t *testing.T) { suite.TestContextCancellation() })
 	t.Run("ModelGetterSetter", func(t *testing.T) { suite.TestModelGetterSetter() })
 }
+
+// TestOpenAIProvider_Images tests that answer images are sent as image_url
+// content parts after the prompt and that invalid images fail before any
+// request is made.
+func TestOpenAIProvider_Images(t *testing.T) {
+	provider := &openAIProvider{BaseProvider: BaseProvider{model: "gpt-4o"}}
+
+	t.Run("images become content parts", func(t *testing.T) {
+		options := ParseRequestOptions(map[string]any{
+			"images": []domain.Image{
+				{URL: "https://example.com/chart.png"},
+				{Data: []byte("png"), MediaType: "image/png"},
+			},
+		}, "gpt-4o")
+
+		messages := provider.buildMessages("Rate this chart", options)
+		require.Len(t, messages, 1)
+		assert.Empty(t, messages[0].Content)
+		parts := messages[0].MultiContent
+		require.Len(t, parts, 3)
+		assert.Equal(t, "Rate this chart", parts[0].Text)
+		assert.Equal(t, "https://example.com/chart.png", parts[1].ImageURL.URL)
+		assert.Equal(t, "data:image/png;base64,cG5n", parts[2].ImageURL.URL)
+	})
+
+	t.Run("invalid image fails before the request", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("unexpected request")
+		}))
+		defer server.Close()
+		provider, err := newOpenAIProvider(ClientConfig{APIKey: "test-key", BaseURL: server.URL})
+		require.NoError(t, err)
+
+		for _, img := range []domain.Image{{Data: []byte("png")}, {Data: []byte("tiff"), MediaType: "image/tiff"}} {
+			_, _, _, err = provider.DoRequest(context.Background(), "Rate", map[string]any{"images": []domain.Image{img}})
+			var providerErr *ProviderError
+			require.ErrorAs(t, err, &providerErr)
+			assert.Equal(t, ErrorTypeBadRequest, providerErr.Type)
+			assert.ErrorIs(t, err, domain.ErrInvalidImage)
+		}
+	})
+}
+
+// TestOpenAIProvider_IdempotencyKey verifies that the idempotency key in the
+// request context is sent as a header, and that no header is sent without
+// one.
+func TestOpenAIProvider_IdempotencyKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		json.NewEncoder(w).Encode(map[string]any{
+			"object":  "chat.completion",
+			"choices": []map[string]any{{"message": map[string]any{"role": "assistant", "content": "ok"}}},
+		})
+	}))
+	defer server.Close()
+
+	provider, err := newOpenAIProvider(ClientConfig{APIKey: "test-key", BaseURL: server.URL + "/v1", Timeout: time.Minute})
+	require.NoError(t, err)
+
+	_, _, _, err = provider.DoRequest(WithIdempotencyKey(context.Background(), "key-1"), "prompt", nil)
+	require.NoError(t, err)
+	_, _, _, err = provider.DoRequest(context.Background(), "prompt", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"key-1", ""}, keys)
+}
@@ -0,0 +1,275 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+// FixtureSchemaVersion is the version of the fixtures file format written
+// by RecordReplayClient.
+const FixtureSchemaVersion = 1
+
+// RecordMode selects whether a RecordReplayClient captures or serves
+// fixtures.
+type RecordMode string
+
+const (
+	// RecordModeRecord forwards every request to the wrapped client and
+	// captures the request/response pair for Save.
+	RecordModeRecord RecordMode = "record"
+
+	// RecordModeReplay serves responses from the fixtures file without
+	// contacting a provider. Requests with no fixture fail with
+	// ErrFixtureNotFound.
+	RecordModeReplay RecordMode = "replay"
+)
+
+var (
+	// ErrFixtureNotFound indicates that replay mode received a request that
+	// was not recorded, usually because a prompt changed and the fixtures
+	// need to be re-recorded.
+	ErrFixtureNotFound = errors.New("no recorded fixture for request")
+
+	// ErrUnsupportedFixtureVersion indicates that a fixtures file was
+	// written with a schema version this package cannot read.
+	ErrUnsupportedFixtureVersion = errors.New("unsupported fixture schema version")
+)
+
+// Fixture is a single recorded request/response pair.
+type Fixture struct {
+	// Key is the hash of the prompt and request options that identifies
+	// the request during replay.
+	Key string `json:"key"`
+
+	// Prompt is the recorded prompt, kept so fixture diffs are reviewable.
+	Prompt string `json:"prompt"`
+
+	// Options are the request options the prompt was sent with.
+	Options map[string]any `json:"options,omitempty"`
+
+	// Response is the provider's response text.
+	Response string `json:"response"`
+
+	// TokensIn and TokensOut are the token counts the provider reported.
+	TokensIn  int `json:"tokens_in"`
+	TokensOut int `json:"tokens_out"`
+}
+
+// fixtureFile is the persisted form of recorded fixtures.
+type fixtureFile struct {
+	SchemaVersion int       `json:"schema_version"`
+	Model         string    `json:"model"`
+	Fixtures      []Fixture `json:"fixtures"`
+}
+
+var _ ports.LLMClient = (*RecordReplayClient)(nil)
+
+// RecordReplayClient wraps a ports.LLMClient to record real provider
+// exchanges to a fixtures file and replay them deterministically, so
+// integration tests can exercise real responses without network access.
+//
+// Requests are keyed by a SHA-256 hash of the prompt and options, so any
+// prompt change causes a replay miss rather than a stale response. Because
+// it is itself a ports.LLMClient, it can wrap a Client built with any
+// Middleware chain and be wrapped by other client decorators.
+//
+// RecordReplayClient is safe for concurrent use.
+type RecordReplayClient struct {
+	next ports.LLMClient
+	mode RecordMode
+	path string
+
+	mu       sync.RWMutex
+	model    string
+	fixtures map[string]Fixture
+}
+
+// NewRecordReplayClient creates a client in the given mode backed by the
+// fixtures file at path. Record mode requires next and starts with no
+// fixtures; call Save to write them. Replay mode loads path immediately and
+// may be given a nil next, in which case GetModel reports the recorded
+// model and tokens are estimated with SimpleTokenEstimator.
+func NewRecordReplayClient(next ports.LLMClient, mode RecordMode, path string) (*RecordReplayClient, error) {
+	if path == "" {
+		return nil, fmt.Errorf("fixtures path is required")
+	}
+
+	client := &RecordReplayClient{
+		next:     next,
+		mode:     mode,
+		path:     path,
+		fixtures: make(map[string]Fixture),
+	}
+
+	switch mode {
+	case RecordModeRecord:
+		if next == nil {
+			return nil, fmt.Errorf("record mode requires a client to record from")
+		}
+		client.model = next.GetModel()
+	case RecordModeReplay:
+		if err := client.load(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown record mode %q: must be %q or %q", mode, RecordModeRecord, RecordModeReplay)
+	}
+
+	return client, nil
+}
+
+// Complete returns the recorded or freshly recorded response text.
+func (c *RecordReplayClient) Complete(ctx context.Context, prompt string, options map[string]any) (string, error) {
+	response, _, _, err := c.CompleteWithUsage(ctx, prompt, options)
+	return response, err
+}
+
+// CompleteWithUsage serves the request from fixtures in replay mode, or
+// forwards it to the wrapped client and records the result in record mode.
+// Failed requests are never recorded.
+func (c *RecordReplayClient) CompleteWithUsage(
+	ctx context.Context,
+	prompt string,
+	options map[string]any,
+) (string, int, int, error) {
+	if err := ctx.Err(); err != nil {
+		return "", 0, 0, err
+	}
+
+	key, err := FixtureKey(prompt, options)
+	if err != nil {
+		return "", 0, 0, err
+	}
+
+	if c.mode == RecordModeReplay {
+		c.mu.RLock()
+		fixture, ok := c.fixtures[key]
+		c.mu.RUnlock()
+		if !ok {
+			return "", 0, 0, fmt.Errorf("%w: key %s in %s", ErrFixtureNotFound, key, c.path)
+		}
+		return fixture.Response, fixture.TokensIn, fixture.TokensOut, nil
+	}
+
+	response, tokensIn, tokensOut, err := c.next.CompleteWithUsage(ctx, prompt, options)
+	if err != nil {
+		return response, tokensIn, tokensOut, err
+	}
+
+	c.mu.Lock()
+	c.fixtures[key] = Fixture{
+		Key:       key,
+		Prompt:    prompt,
+		Options:   maps.Clone(options),
+		Response:  response,
+		TokensIn:  tokensIn,
+		TokensOut: tokensOut,
+	}
+	c.mu.Unlock()
+
+	return response, tokensIn, tokensOut, nil
+}
+
+// EstimateTokens delegates to the wrapped client, falling back to
+// SimpleTokenEstimator when replaying without one.
+func (c *RecordReplayClient) EstimateTokens(text string) (int, error) {
+	if c.next != nil {
+		return c.next.EstimateTokens(text)
+	}
+	return (&SimpleTokenEstimator{}).EstimateTokens(text), nil
+}
+
+// GetModel returns the wrapped client's model, or the recorded model when
+// replaying without one.
+func (c *RecordReplayClient) GetModel() string {
+	if c.next != nil {
+		return c.next.GetModel()
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.model
+}
+
+// Len returns the number of fixtures currently held.
+func (c *RecordReplayClient) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.fixtures)
+}
+
+// Save writes the recorded fixtures to the fixtures path as indented JSON
+// ordered by key, so re-recording unchanged prompts produces no diff.
+// Save is a no-op in replay mode.
+func (c *RecordReplayClient) Save() error {
+	if c.mode != RecordModeRecord {
+		return nil
+	}
+
+	c.mu.RLock()
+	file := fixtureFile{SchemaVersion: FixtureSchemaVersion, Model: c.model, Fixtures: make([]Fixture, 0, len(c.fixtures))}
+	for _, key := range slices.Sorted(maps.Keys(c.fixtures)) {
+		file.Fixtures = append(file.Fixtures, c.fixtures[key])
+	}
+	c.mu.RUnlock()
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode fixtures: %w", err)
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write fixtures: %w", err)
+	}
+	return nil
+}
+
+// load reads the fixtures file into memory for replay.
+func (c *RecordReplayClient) load() error {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return fmt.Errorf("read fixtures: %w", err)
+	}
+
+	var file fixtureFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("decode fixtures: %w", err)
+	}
+	if file.SchemaVersion != FixtureSchemaVersion {
+		return fmt.Errorf("fixtures version %d: %w", file.SchemaVersion, ErrUnsupportedFixtureVersion)
+	}
+
+	c.model = file.Model
+	for _, fixture := range file.Fixtures {
+		c.fixtures[fixture.Key] = fixture
+	}
+	return nil
+}
+
+// FixtureKey returns the replay key for a request: the hex SHA-256 of the
+// prompt and its JSON-encoded options. Map keys are encoded in sorted
+// order, so the key does not depend on map iteration order, and nil and
+// empty options produce the same key.
+func FixtureKey(prompt string, options map[string]any) (string, error) {
+	if len(options) == 0 {
+		options = nil
+	}
+	encoded, err := json.Marshal(options)
+	if err != nil {
+		return "", fmt.Errorf("encode request options: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(prompt))
+	h.Write([]byte{0})
+	h.Write(encoded)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,119 @@
+package llm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRecordReplayClient_RoundTrip verifies that recorded exchanges are
+// replayed with identical responses and token counts without a provider,
+// and that changed prompts or options miss.
+func TestRecordReplayClient_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures.json")
+
+	core := NewMockCoreLLM()
+	core.Response = `{"score": 0.9}`
+	core.TokensIn, core.TokensOut = 42, 7
+	core.Model = "gpt-4"
+	provider := &Client{core: core, estimator: &SimpleTokenEstimator{}}
+
+	recorder, err := NewRecordReplayClient(provider, RecordModeRecord, path)
+	require.NoError(t, err)
+
+	options := map[string]any{"temperature": 0.0, "max_tokens": 256}
+	response, in, out, err := recorder.CompleteWithUsage(context.Background(), "Score this answer", options)
+	require.NoError(t, err)
+	assert.Equal(t, `{"score": 0.9}`, response)
+	assert.Equal(t, 1, recorder.Len())
+	require.NoError(t, recorder.Save())
+
+	replayer, err := NewRecordReplayClient(nil, RecordModeReplay, path)
+	require.NoError(t, err)
+	assert.Equal(t, "gpt-4", replayer.GetModel())
+
+	replayed, replayedIn, replayedOut, err := replayer.CompleteWithUsage(context.Background(), "Score this answer",
+		map[string]any{"max_tokens": 256, "temperature": 0.0})
+	require.NoError(t, err)
+	assert.Equal(t, response, replayed)
+	assert.Equal(t, in, replayedIn)
+	assert.Equal(t, out, replayedOut)
+	assert.Equal(t, 1, core.CallCount, "replay must not reach the provider")
+
+	_, err = replayer.Complete(context.Background(), "Score this answer again", options)
+	require.ErrorIs(t, err, ErrFixtureNotFound)
+
+	_, err = replayer.Complete(context.Background(), "Score this answer", map[string]any{"temperature": 0.5, "max_tokens": 256})
+	require.ErrorIs(t, err, ErrFixtureNotFound)
+}
+
+// TestRecordReplayClient_DoesNotRecordFailures verifies that provider errors
+// pass through and leave no fixture behind.
+func TestRecordReplayClient_DoesNotRecordFailures(t *testing.T) {
+	core := NewMockCoreLLM()
+	core.Error = &ProviderError{Type: ErrorTypeServerError, Provider: "mock", Message: "unavailable"}
+	provider := &Client{core: core, estimator: &SimpleTokenEstimator{}}
+
+	recorder, err := NewRecordReplayClient(provider, RecordModeRecord, filepath.Join(t.TempDir(), "fixtures.json"))
+	require.NoError(t, err)
+
+	_, err = recorder.Complete(context.Background(), "prompt", nil)
+	require.Error(t, err)
+	assert.Equal(t, 0, recorder.Len())
+}
+
+// TestNewRecordReplayClient_Errors tests constructor validation and fixture
+// file loading failures.
+func TestNewRecordReplayClient_Errors(t *testing.T) {
+	dir := t.TempDir()
+	future := filepath.Join(dir, "future.json")
+	require.NoError(t, os.WriteFile(future, []byte(`{"schema_version": 99, "fixtures": []}`), 0o644))
+
+	tests := []struct {
+		name      string
+		mode      RecordMode
+		path      string
+		wantErrIs error
+		errMsg    string
+	}{
+		{name: "missing path", mode: RecordModeReplay, errMsg: "fixtures path is required"},
+		{name: "record without client", mode: RecordModeRecord, path: filepath.Join(dir, "f.json"), errMsg: "requires a client"},
+		{name: "unknown mode", mode: "live", path: filepath.Join(dir, "f.json"), errMsg: "unknown record mode"},
+		{name: "missing fixtures file", mode: RecordModeReplay, path: filepath.Join(dir, "missing.json"), wantErrIs: os.ErrNotExist},
+		{name: "unsupported version", mode: RecordModeReplay, path: future, wantErrIs: ErrUnsupportedFixtureVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRecordReplayClient(nil, tt.mode, tt.path)
+			require.Error(t, err)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+			}
+			if tt.errMsg != "" {
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+// TestFixtureKey verifies that keys ignore map ordering and treat nil and
+// empty options alike.
+func TestFixtureKey(t *testing.T) {
+	a, err := FixtureKey("prompt", map[string]any{"a": 1, "b": "x"})
+	require.NoError(t, err)
+	b, err := FixtureKey("prompt", map[string]any{"b": "x", "a": 1})
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	empty, err := FixtureKey("prompt", map[string]any{})
+	require.NoError(t, err)
+	nilOpts, err := FixtureKey("prompt", nil)
+	require.NoError(t, err)
+	assert.Equal(t, empty, nilOpts)
+	assert.NotEqual(t, a, nilOpts)
+}
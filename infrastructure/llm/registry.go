@@ -5,11 +5,14 @@
 //
 // The Registry system supports:
 //   - Environment-based provider initialization
+//   - API keys resolved from pluggable secret stores
 //   - Default configuration inheritance across providers
 //   - Dynamic client registration and retrieval
 //   - Provider-specific configuration overrides
 //   - Centralized metrics and observability
 //   - Model-based client routing (provider/model format)
+//   - Health checks that verify credentials and reachability before a run
+//   - Retries with a token budget shared by all clients
 //
 // Usage Examples:
 //
@@ -37,8 +40,10 @@
 package llm
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
+	"log/slog"
 	"strings"
 	"sync"
 	"time"
@@ -62,6 +67,16 @@ type Registry struct {
 	defaultMiddleware []Middleware
 	// defaultTimeout sets the default request timeout for all providers
 	defaultTimeout time.Duration
+	// debugLogger, when set, logs provider requests and responses.
+	debugLogger *slog.Logger
+	// redaction controls what debugLogger captures.
+	redaction RedactionConfig
+	// secrets resolves provider API key references.
+	secrets SecretProvider
+	// retry, when set, is the retry middleware applied to every client.
+	retry Middleware
+	// retryBudget caps and records the retries of all clients.
+	retryBudget *RetryBudget
 	// mu provides thread-safe access to the registry.
 	mu sync.RWMutex
 }
@@ -74,6 +89,10 @@ type ProviderConfig struct {
 	Type string
 	// EnvVar specifies the environment variable name for the API key
 	EnvVar string
+	// APIKeyRef is a secret reference for the API key, such as
+	// "vault://secret/openai", resolved by the registry's SecretProvider.
+	// When empty, the key is read from EnvVar.
+	APIKeyRef string
 	// DefaultModel specifies the default model to use if not specified
 	DefaultModel string
 	// SupportedModels lists all models supported by this provider
@@ -83,6 +102,9 @@ type ProviderConfig struct {
 	BaseURL string
 	// Middleware specifies provider-specific middleware
 	Middleware []Middleware
+	// DefaultOptions specifies request options applied to every call made by
+	// clients of this provider. Per-call options take precedence.
+	DefaultOptions map[string]any
 }
 
 // RegistryConfig holds configuration for the provider registry.
@@ -97,6 +119,33 @@ type RegistryConfig struct {
 	DefaultTimeout time.Duration
 	// DefaultMiddleware specifies default middleware applied to all providers.
 	DefaultMiddleware []Middleware
+	// DebugLogger enables provider request/response logging for all clients.
+	DebugLogger *slog.Logger
+	// Redaction controls what DebugLogger captures.
+	Redaction RedactionConfig
+	// Secrets resolves provider API key references. Defaults to
+	// EnvSecretProvider, which reads keys from environment variables.
+	Secrets SecretProvider
+	// Retry configures the retries of all clients. The zero value
+	// disables retries.
+	Retry RetryPolicy
+}
+
+// RetryPolicy configures the retry middleware a Registry applies to every
+// client it creates, outside all other middleware. The retries of all
+// clients share one RetryBudget, whose stats Registry.RetryStats reports.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after a failed attempt. Zero
+	// disables retries.
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry, doubling for each
+	// retry after it.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff between retries.
+	MaxDelay time.Duration
+	// MaxRetryTokens caps the tokens the retries of all clients may
+	// consume together. Zero means unlimited.
+	MaxRetryTokens int64
 }
 
 // DefaultProviders provides standard provider configurations for common LLM services.
@@ -164,13 +213,54 @@ func NewRegistry(config RegistryConfig) (*Registry, error) {
 		return nil, fmt.Errorf("default provider %q not found in providers configuration", config.DefaultProvider)
 	}
 
-	return &Registry{
+	secrets := config.Secrets
+	if secrets == nil {
+		secrets = EnvSecretProvider{}
+	}
+
+	registry := &Registry{
 		providers:         config.Providers,
 		clients:           make(map[string]ports.LLMClient),
 		defaultProvider:   config.DefaultProvider,
 		defaultMiddleware: config.DefaultMiddleware,
 		defaultTimeout:    config.DefaultTimeout,
-	}, nil
+		debugLogger:       config.DebugLogger,
+		redaction:         config.Redaction,
+		secrets:           secrets,
+	}
+
+	if policy := config.Retry; policy != (RetryPolicy{}) {
+		if policy.MaxRetries < 0 || policy.BaseDelay < 0 || policy.MaxDelay < 0 {
+			return nil, fmt.Errorf("retry policy cannot be negative: %+v", policy)
+		}
+		budget, err := NewRetryBudget(policy.MaxRetryTokens)
+		if err != nil {
+			return nil, err
+		}
+		registry.retryBudget = budget
+		registry.retry = RetryMiddleware(policy.MaxRetries, policy.BaseDelay, policy.MaxDelay, WithRetryBudget(budget))
+	}
+	return registry, nil
+}
+
+// RetryStats returns a snapshot of the retries made by the registry's
+// clients. It is zero when the registry has no retry policy.
+func (r *Registry) RetryStats() RetryStats {
+	if r.retryBudget == nil {
+		return RetryStats{}
+	}
+	return r.retryBudget.Stats()
+}
+
+// middleware returns the middleware of a new client: the retry middleware,
+// if any, outermost, then the registry defaults, then extra.
+func (r *Registry) middleware(extra []Middleware) []Middleware {
+	var middleware []Middleware
+	if r.retry != nil {
+		middleware = append(middleware, r.retry)
+	}
+	middleware = append(middleware, r.defaultMiddleware...)
+	return append(middleware, extra...)
 }
 
 // GetDefaultClient returns a client for the default provider.
@@ -246,6 +336,15 @@ func (r *Registry) RegisterClient(name string, config ClientConfig) error {
 		return fmt.Errorf("unknown provider %q", provider)
 	}
 
+	// An API key given as a secret reference is resolved before use.
+	if isSecretReference(config.APIKey) {
+		apiKey, err := r.secrets.Resolve(context.Background(), config.APIKey)
+		if err != nil {
+			return fmt.Errorf("failed to resolve API key for client %q: %w", name, err)
+		}
+		config.APIKey = apiKey
+	}
+
 	// Create client with merged configuration
 	client, err := r.createClientWithConfig(providerConfig.Type, config)
 	if err != nil {
@@ -289,36 +388,63 @@ func (r *Registry) buildCacheKey(provider, model string) string {
 }
 
 // createClient creates a new client instance for the given provider and model.
-// It handles environment variable loading, configuration merging, model validation, and client initialization.
+// It handles API key resolution, configuration merging, model validation, and client initialization.
 func (r *Registry) createClient(provider, model string) (ports.LLMClient, error) {
+	if err := r.ValidateModel(provider, model); err != nil {
+		return nil, err
+	}
+	providerConfig := r.providers[provider]
+
+	apiKey, err := r.resolveAPIKey(context.Background(), provider, providerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	config := ClientConfig{
+		APIKey:         apiKey,
+		Model:          model,
+		BaseURL:        providerConfig.BaseURL,
+		Timeout:        r.defaultTimeout,
+		DefaultOptions: providerConfig.DefaultOptions,
+		DebugLogger:    r.debugLogger,
+		Redaction:      r.redaction,
+	}
+
+	config.Middleware = r.middleware(providerConfig.Middleware)
+
+	return NewClient(providerConfig.Type, config)
+}
+
+// ValidateModel checks that the named provider is configured and, when it
+// lists supported models, that model is one of them. No client is created,
+// so it is cheap enough to guard per-execution model overrides.
+func (r *Registry) ValidateModel(provider, model string) error {
 	providerConfig, exists := r.providers[provider]
 	if !exists {
-		return nil, fmt.Errorf("unknown provider %q", provider)
+		return fmt.Errorf("unknown provider %q", provider)
 	}
 
 	if len(providerConfig.SupportedModels) > 0 {
 		if !r.isModelSupported(model, providerConfig.SupportedModels) {
-			return nil, fmt.Errorf("model %q is not supported by provider %q. Supported models: %v",
+			return fmt.Errorf("model %q is not supported by provider %q. Supported models: %v",
 				model, provider, providerConfig.SupportedModels)
 		}
 	}
+	return nil
+}
 
-	apiKey := os.Getenv(providerConfig.EnvVar)
-	if apiKey == "" {
-		return nil, fmt.Errorf("%s environment variable not set for provider %q", providerConfig.EnvVar, provider)
+// resolveAPIKey resolves the API key for a provider from its APIKeyRef,
+// or from its EnvVar when no reference is configured.
+func (r *Registry) resolveAPIKey(ctx context.Context, provider string, providerConfig ProviderConfig) (string, error) {
+	ref := providerConfig.APIKeyRef
+	if ref == "" {
+		ref = providerConfig.EnvVar
 	}
-
-	config := ClientConfig{
-		APIKey:  apiKey,
-		Model:   model,
-		BaseURL: providerConfig.BaseURL,
-		Timeout: r.defaultTimeout,
+	apiKey, err := r.secrets.Resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve API key for provider %q: %w", provider, err)
 	}
-
-	config.Middleware = append([]Middleware{}, r.defaultMiddleware...)
-	config.Middleware = append(config.Middleware, providerConfig.Middleware...)
-
-	return NewClient(providerConfig.Type, config)
+	return apiKey, nil
 }
 
 // createClientWithConfig creates a client with explicit configuration.
@@ -327,16 +453,21 @@ func (r *Registry) createClientWithConfig(providerType string, config ClientConf
 	if config.Timeout == 0 {
 		config.Timeout = r.defaultTimeout
 	}
+	if config.DebugLogger == nil {
+		config.DebugLogger = r.debugLogger
+		config.Redaction = r.redaction
+	}
 
-	middleware := append([]Middleware{}, r.defaultMiddleware...)
-	config.Middleware = append(middleware, config.Middleware...)
+	config.Middleware = r.middleware(config.Middleware)
 
 	return NewClient(providerType, config)
 }
 
-// InitializeProviders automatically initializes providers based on environment variables.
-// This method discovers available providers by checking environment variables
-// and creates clients with default configuration for each available provider.
+// InitializeProviders automatically initializes providers whose API keys
+// resolve. This method resolves each provider's key through the registry's
+// SecretProvider and creates clients with default configuration for each
+// available provider. Providers whose key is not found are skipped unless
+// they are the default provider; any other resolution failure is returned.
 func (r *Registry) InitializeProviders() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -344,11 +475,10 @@ func (r *Registry) InitializeProviders() error {
 	foundDefault := false
 
 	for providerName, providerConfig := range r.providers {
-		apiKey := os.Getenv(providerConfig.EnvVar)
-		if apiKey == "" {
-			if r.defaultProvider == providerName {
-				return fmt.Errorf("%s environment variable not set for default provider %q",
-					providerConfig.EnvVar, providerName)
+		apiKey, err := r.resolveAPIKey(context.Background(), providerName, providerConfig)
+		if err != nil {
+			if r.defaultProvider == providerName || !errors.Is(err, ErrSecretNotFound) {
+				return err
 			}
 			continue
 		}
@@ -358,11 +488,14 @@ func (r *Registry) InitializeProviders() error {
 		}
 
 		config := ClientConfig{
-			APIKey:     apiKey,
-			Model:      providerConfig.DefaultModel,
-			BaseURL:    providerConfig.BaseURL,
-			Timeout:    r.defaultTimeout,
-			Middleware: append(append([]Middleware{}, r.defaultMiddleware...), providerConfig.Middleware...),
+			APIKey:         apiKey,
+			Model:          providerConfig.DefaultModel,
+			BaseURL:        providerConfig.BaseURL,
+			Timeout:        r.defaultTimeout,
+			Middleware:     r.middleware(providerConfig.Middleware),
+			DefaultOptions: providerConfig.DefaultOptions,
+			DebugLogger:    r.debugLogger,
+			Redaction:      r.redaction,
 		}
 
 		client, err := NewClient(providerConfig.Type, config)
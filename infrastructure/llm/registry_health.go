@@ -0,0 +1,118 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+// Health check probe parameters. The probe is the cheapest request every
+// provider accepts: a one-word prompt answered with a few tokens.
+const (
+	// healthCheckPrompt is the prompt sent by each probe.
+	healthCheckPrompt = "ping"
+	// healthCheckMaxTokens caps the output of each probe.
+	healthCheckMaxTokens = 8
+)
+
+// ClientHealth is the outcome of probing one registered client.
+type ClientHealth struct {
+	// Client is the registry key of the client, "provider/model" or the
+	// provider name alone.
+	Client string
+	// Model is the model the client sends requests to.
+	Model string
+	// Latency is how long the probe took.
+	Latency time.Duration
+	// Tokens is the number of input and output tokens the probe was billed.
+	Tokens int
+	// Err is why the probe failed; nil when the client is healthy.
+	Err error
+}
+
+// Healthy reports whether the probe succeeded.
+func (h ClientHealth) Healthy() bool { return h.Err == nil }
+
+// HealthCheck sends a minimal completion request to every registered
+// client concurrently and reports the outcome for each, sorted by client
+// key. Unlike InitializeProviders, which only resolves API keys, it proves
+// that the keys are accepted and the endpoints reachable. Each probe costs
+// a handful of tokens and passes through the client's middleware.
+//
+// A probe whose response is empty still counts as healthy: the provider
+// authenticated and answered the request. Cancelling ctx fails the probes
+// still in flight.
+func (r *Registry) HealthCheck(ctx context.Context) []ClientHealth {
+	r.mu.RLock()
+	keys := make([]string, 0, len(r.clients))
+	clients := make(map[string]ports.LLMClient, len(r.clients))
+	for key, client := range r.clients {
+		keys = append(keys, key)
+		clients[key] = client
+	}
+	r.mu.RUnlock()
+	slices.Sort(keys)
+
+	results := make([]ClientHealth, len(keys))
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = probeClient(ctx, key, clients[key])
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// Warmup creates the clients named by specs, or the default client when
+// none are given, so they exist before the first evaluation, then probes
+// every registered client with HealthCheck. The probes open the
+// connections later requests reuse. It returns the health of every client
+// and an error joining each failure.
+func (r *Registry) Warmup(ctx context.Context, specs ...string) ([]ClientHealth, error) {
+	if len(specs) == 0 {
+		if _, err := r.GetDefaultClient(); err != nil {
+			return nil, fmt.Errorf("warmup: %w", err)
+		}
+	}
+	for _, spec := range specs {
+		if _, err := r.GetClient(spec); err != nil {
+			return nil, fmt.Errorf("warmup: %w", err)
+		}
+	}
+
+	health := r.HealthCheck(ctx)
+	var errs []error
+	for _, h := range health {
+		if !h.Healthy() {
+			errs = append(errs, fmt.Errorf("client %s: %w", h.Client, h.Err))
+		}
+	}
+	return health, errors.Join(errs...)
+}
+
+// probeClient sends the health check request to client.
+func probeClient(ctx context.Context, key string, client ports.LLMClient) ClientHealth {
+	start := time.Now()
+	_, tokensIn, tokensOut, err := client.CompleteWithUsage(ctx, healthCheckPrompt, map[string]any{
+		"max_tokens":  healthCheckMaxTokens,
+		"temperature": 0.0,
+	})
+	if errors.Is(err, ErrEmptyResponse) || errors.Is(err, ErrNoResponseChoice) {
+		err = nil
+	}
+	return ClientHealth{
+		Client:  key,
+		Model:   client.GetModel(),
+		Latency: time.Since(start),
+		Tokens:  tokensIn + tokensOut,
+		Err:     err,
+	}
+}
@@ -0,0 +1,77 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// healthProvider is a CoreLLM whose behavior depends on its API key:
+// "bad-key" is rejected and "empty" gets an empty response.
+type healthProvider struct {
+	apiKey string
+	model  string
+}
+
+// DoRequest answers the probe according to the provider's API key.
+func (p *healthProvider) DoRequest(ctx context.Context, prompt string, opts map[string]any) (string, int, int, error) {
+	switch p.apiKey {
+	case "bad-key":
+		return "", 0, 0, &ProviderError{Provider: "health", StatusCode: 401, Type: ErrorTypeAuthentication, Message: "invalid API key"}
+	case "empty":
+		return "", 3, 0, ErrEmptyResponse
+	}
+	return "pong", 3, 1, nil
+}
+
+// GetModel returns the provider's model.
+func (p *healthProvider) GetModel() string { return p.model }
+
+// SetModel sets the provider's model.
+func (p *healthProvider) SetModel(m string) { p.model = m }
+
+// TestRegistry_HealthCheck tests per-client probe results and that Warmup
+// creates the requested clients and reports failures.
+func TestRegistry_HealthCheck(t *testing.T) {
+	RegisterProviderFactory("health", func(config ClientConfig) (CoreLLM, error) {
+		return &healthProvider{apiKey: config.APIKey, model: config.Model}, nil
+	})
+	t.Setenv("HEALTH_API_KEY", "good-key")
+
+	registry, err := NewRegistry(RegistryConfig{
+		DefaultProvider: "health",
+		Providers: map[string]ProviderConfig{
+			"health": {Type: "health", EnvVar: "HEALTH_API_KEY", DefaultModel: "m1"},
+		},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, registry.RegisterClient("health/bad", ClientConfig{APIKey: "bad-key", Model: "bad"}))
+	require.NoError(t, registry.RegisterClient("health/empty", ClientConfig{APIKey: "empty", Model: "empty"}))
+
+	health, err := registry.Warmup(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "health/bad")
+
+	require.Len(t, health, 3)
+	assert.Equal(t, []string{"health/bad", "health/empty", "health/m1"},
+		[]string{health[0].Client, health[1].Client, health[2].Client})
+
+	var providerErr *ProviderError
+	require.True(t, errors.As(health[0].Err, &providerErr))
+	assert.Equal(t, ErrorTypeAuthentication, providerErr.Type)
+	assert.False(t, health[0].Healthy())
+
+	assert.True(t, health[1].Healthy(), "an empty response still proves the credentials work")
+	assert.Equal(t, 3, health[1].Tokens)
+
+	assert.True(t, health[2].Healthy())
+	assert.Equal(t, "m1", health[2].Model)
+	assert.Equal(t, 4, health[2].Tokens)
+
+	_, err = registry.Warmup(context.Background(), "unknown/model")
+	assert.Error(t, err)
+}
@@ -2,12 +2,15 @@ package llm
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
 )
 
 // TestNewRegistry tests the creation of a new registry.
@@ -237,6 +240,58 @@ func (p *customProvider) SetModel(m string) {
 	p.model = m
 }
 
+// TestRegistry_RetryPolicy tests that clients created by a registry with a
+// retry policy retry failed requests, record the retries in the caller's
+// call meter, and share one retry budget.
+func TestRegistry_RetryPolicy(t *testing.T) {
+	RegisterProviderFactory("flaky", func(config ClientConfig) (CoreLLM, error) {
+		return &billedFailureLLM{MockCoreLLM: NewMockCoreLLM(), failures: 1, err: errors.New("overloaded")}, nil
+	})
+	t.Setenv("FLAKY_API_KEY", "flaky-key")
+
+	newRegistry := func(policy RetryPolicy) (*Registry, error) {
+		return NewRegistry(RegistryConfig{
+			DefaultProvider: "flaky",
+			Providers: map[string]ProviderConfig{
+				"flaky": {Type: "flaky", EnvVar: "FLAKY_API_KEY", DefaultModel: "flaky-model"},
+			},
+			Retry: policy,
+		})
+	}
+
+	registry, err := newRegistry(RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	require.NoError(t, err)
+	for _, spec := range []string{"flaky/a", "flaky/b"} {
+		client, err := registry.GetClient(spec)
+		require.NoError(t, err)
+
+		ctx, meter := domain.WithCallMeter(context.Background())
+		response, tokensIn, _, err := client.CompleteWithUsage(ctx, "test prompt", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "test response", response)
+		assert.Equal(t, 7+10, tokensIn, "the failed attempt's tokens are reported")
+		assert.Equal(t, 2, meter.Calls())
+	}
+	assert.Equal(t, RetryStats{Requests: 2, Attempts: 4, Retries: 2, Tokens: 74, RetryTokens: 60}, registry.RetryStats())
+
+	t.Run("no policy", func(t *testing.T) {
+		registry, err := newRegistry(RetryPolicy{})
+		require.NoError(t, err)
+		client, err := registry.GetDefaultClient()
+		require.NoError(t, err)
+		_, err = client.Complete(context.Background(), "test prompt", nil)
+		require.Error(t, err)
+		assert.Zero(t, registry.RetryStats())
+	})
+
+	t.Run("negative policy", func(t *testing.T) {
+		_, err := newRegistry(RetryPolicy{MaxRetries: -1})
+		assert.Error(t, err)
+		_, err = newRegistry(RetryPolicy{MaxRetries: 1, MaxRetryTokens: -1})
+		assert.Error(t, err)
+	})
+}
+
 // TestRegistry_EnvironmentVariables tests that the registry correctly uses
 // environment variables to configure providers.
 func TestRegistry_EnvironmentVariables(t *testing.T) {
@@ -352,6 +407,12 @@ func TestRegistry_ModelValidation(t *testing.T) {
 	client, err = registry.GetClient("custom/any-model")
 	assert.NoError(t, err, "Provider without supported models should allow any model")
 	assert.NotNil(t, client, "Expected non-nil client")
+
+	// ValidateModel applies the same rules without creating a client.
+	assert.NoError(t, registry.ValidateModel("openai", "gpt-3.5-turbo"))
+	assert.NoError(t, registry.ValidateModel("custom", "any-model"))
+	assert.ErrorContains(t, registry.ValidateModel("openai", "invalid-model"), "not supported by provider")
+	assert.ErrorContains(t, registry.ValidateModel("missing", "gpt-4"), "unknown provider")
 }
 
 // TestRegistry_GetDefaultClient tests the new GetDefaultClient method.
@@ -0,0 +1,75 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrSecretNotFound indicates that a secret reference resolved to nothing,
+// such as an unset environment variable.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// secretSchemeSeparator separates a secret reference's scheme from its path,
+// as in "vault://secret/openai".
+const secretSchemeSeparator = "://"
+
+// SecretProvider resolves secret references, such as API keys, to their
+// values. It lets keys live in a secret store like Vault or AWS Secrets
+// Manager instead of the process environment. Implementations must be safe
+// for concurrent use.
+type SecretProvider interface {
+	// Resolve returns the secret that ref points to. It returns an error
+	// wrapping ErrSecretNotFound when the secret does not exist.
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// SecretProviderFunc adapts a function to the SecretProvider interface.
+type SecretProviderFunc func(ctx context.Context, ref string) (string, error)
+
+// Resolve calls f(ctx, ref).
+func (f SecretProviderFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+// EnvSecretProvider resolves references to environment variables. A
+// reference is a variable name, optionally prefixed with "env://".
+// It is the registry's default SecretProvider.
+type EnvSecretProvider struct{}
+
+// Resolve returns the value of the environment variable named by ref.
+func (EnvSecretProvider) Resolve(_ context.Context, ref string) (string, error) {
+	name := strings.TrimPrefix(ref, "env"+secretSchemeSeparator)
+	value := os.Getenv(name)
+	if value == "" {
+		return "", fmt.Errorf("%s environment variable not set: %w", name, ErrSecretNotFound)
+	}
+	return value, nil
+}
+
+// SecretProviders routes secret references to providers by their URI
+// scheme, so "vault://secret/openai" is resolved by the provider registered
+// under "vault". References without a scheme, such as a bare environment
+// variable name, are resolved by the provider registered under "env".
+type SecretProviders map[string]SecretProvider
+
+// Resolve resolves ref with the provider registered for its scheme.
+func (s SecretProviders) Resolve(ctx context.Context, ref string) (string, error) {
+	scheme := "env"
+	if before, _, ok := strings.Cut(ref, secretSchemeSeparator); ok {
+		scheme = before
+	}
+	provider, ok := s[scheme]
+	if !ok {
+		return "", fmt.Errorf("no secret provider registered for scheme %q", scheme)
+	}
+	return provider.Resolve(ctx, ref)
+}
+
+// isSecretReference reports whether value is a "scheme://path" secret
+// reference rather than a literal secret.
+func isSecretReference(value string) bool {
+	return strings.Contains(value, secretSchemeSeparator)
+}
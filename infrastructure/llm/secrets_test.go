@@ -0,0 +1,116 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault is a SecretProvider backed by a map of "vault://" references.
+func fakeVault(secrets map[string]string) SecretProviderFunc {
+	return func(_ context.Context, ref string) (string, error) {
+		if value, ok := secrets[ref]; ok {
+			return value, nil
+		}
+		return "", errors.New("vault: permission denied")
+	}
+}
+
+// TestEnvSecretProvider tests resolution of bare and env:// references.
+func TestEnvSecretProvider(t *testing.T) {
+	t.Setenv("GAVEL_TEST_SECRET", "s3cret")
+	ctx := context.Background()
+
+	value, err := EnvSecretProvider{}.Resolve(ctx, "GAVEL_TEST_SECRET")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+
+	value, err = EnvSecretProvider{}.Resolve(ctx, "env://GAVEL_TEST_SECRET")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+
+	_, err = EnvSecretProvider{}.Resolve(ctx, "GAVEL_TEST_UNSET_SECRET")
+	require.ErrorIs(t, err, ErrSecretNotFound)
+	assert.Contains(t, err.Error(), "GAVEL_TEST_UNSET_SECRET environment variable not set")
+}
+
+// TestSecretProviders tests routing of references by scheme.
+func TestSecretProviders(t *testing.T) {
+	t.Setenv("GAVEL_TEST_SECRET", "from-env")
+	providers := SecretProviders{
+		"env":   EnvSecretProvider{},
+		"vault": fakeVault(map[string]string{"vault://secret/openai": "from-vault"}),
+	}
+	ctx := context.Background()
+
+	value, err := providers.Resolve(ctx, "vault://secret/openai")
+	require.NoError(t, err)
+	assert.Equal(t, "from-vault", value)
+
+	value, err = providers.Resolve(ctx, "GAVEL_TEST_SECRET")
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", value)
+
+	_, err = providers.Resolve(ctx, "aws-sm://prod/openai")
+	assert.ErrorContains(t, err, `no secret provider registered for scheme "aws-sm"`)
+}
+
+// TestRegistry_SecretProvider tests that provider API keys are resolved
+// through the configured SecretProvider at initialization and on demand.
+func TestRegistry_SecretProvider(t *testing.T) {
+	providers := map[string]ProviderConfig{
+		"openai": {
+			Type:         "openai",
+			EnvVar:       "OPENAI_API_KEY",
+			APIKeyRef:    "vault://secret/openai",
+			DefaultModel: "gpt-4",
+		},
+		"anthropic": {
+			Type:         "anthropic",
+			EnvVar:       "GAVEL_TEST_UNSET_ANTHROPIC_KEY",
+			DefaultModel: "claude-3-haiku",
+		},
+	}
+	secrets := SecretProviders{
+		"env":   EnvSecretProvider{},
+		"vault": fakeVault(map[string]string{"vault://secret/openai": "vault-key"}),
+	}
+
+	t.Run("initializes from secret store", func(t *testing.T) {
+		t.Setenv("OPENAI_API_KEY", "")
+		registry, err := NewRegistry(RegistryConfig{DefaultProvider: "openai", Providers: providers, Secrets: secrets})
+		require.NoError(t, err)
+
+		require.NoError(t, registry.InitializeProviders(), "providers with missing env keys are skipped")
+		assert.Equal(t, []string{"openai"}, registry.GetRegisteredProviders())
+
+		client, err := registry.GetClient("openai/gpt-4o")
+		require.NoError(t, err)
+		assert.Equal(t, "gpt-4o", client.GetModel())
+	})
+
+	t.Run("resolution failure", func(t *testing.T) {
+		failing := map[string]ProviderConfig{
+			"openai": {Type: "openai", APIKeyRef: "vault://secret/missing", DefaultModel: "gpt-4"},
+		}
+		registry, err := NewRegistry(RegistryConfig{DefaultProvider: "openai", Providers: failing, Secrets: secrets})
+		require.NoError(t, err)
+
+		err = registry.InitializeProviders()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `failed to resolve API key for provider "openai"`)
+		assert.Contains(t, err.Error(), "permission denied")
+	})
+
+	t.Run("registered client key reference", func(t *testing.T) {
+		registry, err := NewRegistry(RegistryConfig{DefaultProvider: "openai", Providers: providers, Secrets: secrets})
+		require.NoError(t, err)
+
+		require.NoError(t, registry.RegisterClient("openai/gpt-4", ClientConfig{APIKey: "vault://secret/openai", Model: "gpt-4"}))
+		err = registry.RegisterClient("openai/gpt-4o", ClientConfig{APIKey: "vault://secret/other", Model: "gpt-4o"})
+		assert.ErrorContains(t, err, "failed to resolve API key for client")
+	})
+}
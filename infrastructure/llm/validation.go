@@ -52,6 +52,11 @@ func IsPositiveInt(val int) bool {
 	return val > 0
 }
 
+// IsPositiveDuration checks if the duration value is positive.
+func IsPositiveDuration(val time.Duration) bool {
+	return val > 0
+}
+
 // IsNonEmptyString checks if the string is non-empty.
 func IsNonEmptyString(val string) bool {
 	return val != ""
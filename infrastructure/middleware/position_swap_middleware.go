@@ -67,7 +67,7 @@ func (psm *PositionSwapMiddleware) Execute(ctx context.Context, state domain.Sta
 		attribute.String("wrapped_unit.name", psm.next.Name()))
 	defer span.End()
 
-	answers, ok := domain.Get(state, domain.KeyAnswers)
+	answers, ok := state.GetAnswers()
 	if !ok {
 		err := fmt.Errorf("answers not found in state")
 		span.SetStatus(codes.Error, err.Error())
@@ -150,8 +150,8 @@ func (psm *PositionSwapMiddleware) combineScores(
 	firstResult, secondResult domain.State,
 	originalAnswers []domain.Answer,
 ) (domain.State, error) {
-	firstScores, ok1 := domain.Get(firstResult, domain.KeyJudgeScores)
-	secondScores, ok2 := domain.Get(secondResult, domain.KeyJudgeScores)
+	firstScores, ok1 := firstResult.GetJudgeScores()
+	secondScores, ok2 := secondResult.GetJudgeScores()
 	if !ok1 || !ok2 {
 		return firstResult, fmt.Errorf("judge scores not found in execution results")
 	}
@@ -181,7 +181,7 @@ func (psm *PositionSwapMiddleware) combineScores(
 	}
 
 	result := domain.With(firstResult, domain.KeyAnswers, originalAnswers)
-	return domain.With(result, domain.KeyJudgeScores, combinedScores), nil
+	return domain.WithJudgeScores(result, psm.next.Name(), combinedScores), nil
 }
 
 // Validate checks if the PositionSwapMiddleware is properly configured by
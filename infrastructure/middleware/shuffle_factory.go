@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+// NewShuffleFromConfig creates a ShuffleMiddleware from configuration. Like
+// NewPositionSwapFromConfig, it requires the already-created wrapped unit
+// under "wrapped_unit". Optional keys are "permutations", "salt", and
+// "seed".
+func NewShuffleFromConfig(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error) {
+	wrappedUnit, ok := config["wrapped_unit"].(ports.Unit)
+	if !ok {
+		return nil, fmt.Errorf("shuffle_wrapper requires 'wrapped_unit' as a Unit instance")
+	}
+
+	var cfg ShuffleConfig
+	if v, ok := config["permutations"]; ok {
+		n, ok := v.(int)
+		if !ok {
+			return nil, fmt.Errorf("permutations must be an integer")
+		}
+		cfg.Permutations = n
+	}
+	if v, ok := config["salt"]; ok {
+		salt, ok := v.(int)
+		if !ok {
+			return nil, fmt.Errorf("salt must be an integer")
+		}
+		cfg.Salt = int64(salt)
+	}
+	if v, ok := config["seed"]; ok {
+		seed, ok := v.(int)
+		if !ok {
+			return nil, fmt.Errorf("seed must be an integer")
+		}
+		fixed := int64(seed)
+		cfg.Seed = &fixed
+	}
+
+	return NewShuffleMiddleware(wrappedUnit, id, cfg)
+}
@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+var _ ports.Unit = (*ShuffleMiddleware)(nil)
+
+// maxShufflePermutations caps how many orderings a ShuffleMiddleware judges,
+// since each one is a full execution of the wrapped unit.
+const maxShufflePermutations = 16
+
+// ShuffleConfig configures a ShuffleMiddleware.
+type ShuffleConfig struct {
+	// Permutations is the number of random orderings the wrapped judge
+	// scores. Their scores are averaged. Zero means one.
+	Permutations int
+
+	// Salt is mixed into every derived seed, so separate studies over the
+	// same dataset draw different orderings while each stays reproducible.
+	Salt int64
+
+	// Seed, when set, seeds every item with the same value instead of one
+	// derived from the item. Items with the same number of answers are then
+	// shuffled identically.
+	Seed *int64
+}
+
+// ShuffleMiddleware mitigates positional bias by executing a judge Unit on
+// random orderings of the candidate answers and averaging each answer's
+// scores across them. Scores are mapped back to the original answer order.
+//
+// The orderings are reproducible per item: the random source is seeded from
+// KeyItemID, or the question when no item ID is set, so rerunning an item
+// shuffles it identically while neighbouring items are shuffled
+// independently of each other. The middleware is stateless and thread-safe.
+type ShuffleMiddleware struct {
+	next   ports.Unit
+	name   string
+	config ShuffleConfig
+}
+
+// NewShuffleMiddleware creates a ShuffleMiddleware that wraps the specified
+// judge unit. Returns an error if next is nil, name is empty, or
+// Permutations is out of range.
+func NewShuffleMiddleware(next ports.Unit, name string, config ShuffleConfig) (*ShuffleMiddleware, error) {
+	if next == nil {
+		return nil, errors.New("shuffle middleware: next unit is required")
+	}
+	if name == "" {
+		return nil, errors.New("shuffle middleware: name is required")
+	}
+	if config.Permutations < 0 || config.Permutations > maxShufflePermutations {
+		return nil, fmt.Errorf("shuffle middleware: permutations must be between 0 and %d, got %d",
+			maxShufflePermutations, config.Permutations)
+	}
+	if config.Permutations == 0 {
+		config.Permutations = 1
+	}
+	return &ShuffleMiddleware{next: next, name: name, config: config}, nil
+}
+
+// Name returns the unique identifier for this middleware instance.
+func (sm *ShuffleMiddleware) Name() string { return sm.name }
+
+// Execute runs the wrapped judge once per configured permutation of the
+// answers and records the averaged scores under the wrapped judge's name.
+// A single answer is passed through unchanged.
+func (sm *ShuffleMiddleware) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	ctx, span := otel.Tracer("shuffle-middleware").Start(ctx, "ShuffleMiddleware.Execute",
+		trace.WithAttributes(
+			attribute.String("middleware.name", sm.name),
+			attribute.String("middleware.type", "shuffle"),
+			attribute.String("wrapped_unit.name", sm.next.Name()),
+			attribute.Int("config.permutations", sm.config.Permutations),
+		))
+	defer span.End()
+
+	answers, err := domain.Require(state, domain.KeyAnswers, sm.name)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return state, err
+	}
+	if len(answers) == 0 {
+		err := fmt.Errorf("answers cannot be empty")
+		span.SetStatus(codes.Error, err.Error())
+		return state, err
+	}
+	if len(answers) == 1 {
+		return sm.next.Execute(ctx, state)
+	}
+
+	seed := sm.seed(state)
+	span.SetAttributes(attribute.Int64("shuffle.seed", seed))
+	rng := rand.New(rand.NewSource(seed)) // #nosec G404 -- reproducible orderings, not security
+
+	sums := make([]domain.JudgeSummary, len(answers))
+	result := state
+	for run := range sm.config.Permutations {
+		perm := rng.Perm(len(answers))
+		shuffled := make([]domain.Answer, len(answers))
+		for i, idx := range perm {
+			shuffled[i] = answers[idx]
+		}
+
+		result, err = sm.next.Execute(ctx, domain.With(result, domain.KeyAnswers, shuffled))
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return state, fmt.Errorf("shuffled execution %d failed: %w", run+1, err)
+		}
+
+		scores, ok := result.GetJudgeScores()
+		if !ok || len(scores) != len(answers) {
+			err := fmt.Errorf("shuffled execution %d: expected %d judge scores, got %d", run+1, len(answers), len(scores))
+			span.SetStatus(codes.Error, err.Error())
+			return state, err
+		}
+		for i, idx := range perm {
+			sums[idx].Score += scores[i].Score
+			sums[idx].Confidence += scores[i].Confidence
+			if sm.config.Permutations == 1 {
+				sums[idx].Reasoning = scores[i].Reasoning
+			}
+		}
+	}
+
+	n := float64(sm.config.Permutations)
+	combined := make([]domain.JudgeSummary, len(answers))
+	for i, sum := range sums {
+		combined[i] = domain.JudgeSummary{
+			Score:      sum.Score / n,
+			Confidence: sum.Confidence / n,
+			Reasoning:  sum.Reasoning,
+		}
+		if sm.config.Permutations > 1 {
+			combined[i].Reasoning = fmt.Sprintf("Shuffle: mean of %d orderings = %.3f", sm.config.Permutations, combined[i].Score)
+		}
+	}
+
+	result = domain.With(result, domain.KeyAnswers, answers)
+	span.SetStatus(codes.Ok, "Shuffle bias mitigation completed successfully")
+	return domain.WithJudgeScores(result, sm.next.Name(), combined), nil
+}
+
+// seed returns the seed of the item's random source: the configured Seed
+// if set, otherwise a hash of the item ID, or of the question when the
+// item has no ID, mixed with Salt.
+func (sm *ShuffleMiddleware) seed(state domain.State) int64 {
+	if sm.config.Seed != nil {
+		return *sm.config.Seed
+	}
+	return ItemSeed(state, sm.config.Salt)
+}
+
+// ItemSeed derives a deterministic seed for the item held in state; see
+// domain.ItemSeed.
+func ItemSeed(state domain.State, salt int64) int64 {
+	return domain.ItemSeed(state, salt)
+}
+
+// Validate checks if the ShuffleMiddleware is properly configured by
+// delegating validation to the wrapped unit.
+func (sm *ShuffleMiddleware) Validate() error {
+	if err := sm.next.Validate(); err != nil {
+		return fmt.Errorf("wrapped unit validation failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// orderRecordingJudge scores each answer by its content, which holds a
+// number, plus a bonus for being shown first, and records every ordering
+// it was shown.
+type orderRecordingJudge struct {
+	mu     sync.Mutex
+	orders []string
+}
+
+// Name returns the judge's name.
+func (j *orderRecordingJudge) Name() string { return "judge" }
+
+// Execute scores the answers and records their order.
+func (j *orderRecordingJudge) Execute(_ context.Context, state domain.State) (domain.State, error) {
+	answers, _ := state.GetAnswers()
+	ids := make([]string, len(answers))
+	scores := make([]domain.JudgeSummary, len(answers))
+	for i, answer := range answers {
+		ids[i] = answer.ID
+		var value float64
+		_, _ = fmt.Sscanf(answer.Content, "%g", &value)
+		if i == 0 {
+			value += 1
+		}
+		scores[i] = domain.JudgeSummary{Score: value, Confidence: 0.5, Reasoning: "scored " + answer.ID}
+	}
+	j.mu.Lock()
+	j.orders = append(j.orders, strings.Join(ids, ","))
+	j.mu.Unlock()
+	return domain.WithJudgeScores(state, j.Name(), scores), nil
+}
+
+// Validate reports no configuration errors.
+func (j *orderRecordingJudge) Validate() error { return nil }
+
+// shuffleState returns a state with six answers whose content is their
+// index, tagged with itemID.
+func shuffleState(t *testing.T, itemID string) domain.State {
+	t.Helper()
+	answers := make([]domain.Answer, 6)
+	for i := range answers {
+		answers[i] = domain.Answer{ID: fmt.Sprintf("a%d", i), Content: fmt.Sprint(i)}
+	}
+	state, err := domain.NewEvaluationState("Which answer is best?", answers, domain.WithItemID(itemID))
+	require.NoError(t, err)
+	return state
+}
+
+// TestShuffleMiddleware_Execute tests that scores map back to the original
+// order, that permutations are averaged, and that the ordering is
+// reproducible per item but differs between items.
+func TestShuffleMiddleware_Execute(t *testing.T) {
+	run := func(t *testing.T, config ShuffleConfig, itemID string) (domain.State, []string) {
+		t.Helper()
+		judge := &orderRecordingJudge{}
+		sm, err := NewShuffleMiddleware(judge, "shuffled", config)
+		require.NoError(t, err)
+		result, err := sm.Execute(context.Background(), shuffleState(t, itemID))
+		require.NoError(t, err)
+		return result, judge.orders
+	}
+
+	t.Run("scores follow the original order", func(t *testing.T) {
+		result, orders := run(t, ShuffleConfig{}, "item-1")
+		require.Len(t, orders, 1)
+		first := strings.Split(orders[0], ",")[0]
+
+		answers, _ := result.GetAnswers()
+		scores, _ := result.GetJudgeScores()
+		require.Len(t, scores, 6)
+		for i, answer := range answers {
+			assert.Equal(t, fmt.Sprintf("a%d", i), answer.ID)
+			want := float64(i)
+			if answer.ID == first {
+				want++
+			}
+			assert.InDelta(t, want, scores[i].Score, 1e-9)
+			assert.Equal(t, "scored "+answer.ID, scores[i].Reasoning)
+		}
+		byJudge, _ := domain.Get(result, domain.KeyJudgeScoresByJudge)
+		assert.Equal(t, scores, byJudge["judge"])
+	})
+
+	t.Run("permutations are averaged", func(t *testing.T) {
+		result, orders := run(t, ShuffleConfig{Permutations: 4}, "item-1")
+		require.Len(t, orders, 4)
+
+		firstCounts := map[string]int{}
+		for _, order := range orders {
+			firstCounts[strings.Split(order, ",")[0]]++
+		}
+		scores, _ := result.GetJudgeScores()
+		for i, score := range scores {
+			want := float64(i) + float64(firstCounts[fmt.Sprintf("a%d", i)])/4
+			assert.InDelta(t, want, score.Score, 1e-9)
+			assert.InDelta(t, 0.5, score.Confidence, 1e-9)
+		}
+	})
+
+	t.Run("reproducible per item and decorrelated across items", func(t *testing.T) {
+		_, first := run(t, ShuffleConfig{Permutations: 3}, "item-1")
+		_, again := run(t, ShuffleConfig{Permutations: 3}, "item-1")
+		_, other := run(t, ShuffleConfig{Permutations: 3}, "item-2")
+		_, salted := run(t, ShuffleConfig{Permutations: 3, Salt: 7}, "item-1")
+
+		assert.Equal(t, first, again)
+		assert.NotEqual(t, first, other)
+		assert.NotEqual(t, first, salted)
+	})
+
+	t.Run("seed override applies to every item", func(t *testing.T) {
+		seed := int64(99)
+		_, first := run(t, ShuffleConfig{Seed: &seed}, "item-1")
+		_, other := run(t, ShuffleConfig{Seed: &seed}, "item-2")
+		assert.Equal(t, first, other)
+	})
+}
+
+// TestItemSeed tests that the seed depends on the item ID, falls back to
+// the question, and changes with the salt.
+func TestItemSeed(t *testing.T) {
+	withID := shuffleState(t, "item-1")
+	assert.Equal(t, ItemSeed(withID, 0), ItemSeed(shuffleState(t, "item-1"), 0))
+	assert.NotEqual(t, ItemSeed(withID, 0), ItemSeed(shuffleState(t, "item-2"), 0))
+	assert.NotEqual(t, ItemSeed(withID, 0), ItemSeed(withID, 1))
+
+	q1, err := domain.NewEvaluationState("What is 2+2?", nil)
+	require.NoError(t, err)
+	q2, err := domain.NewEvaluationState("What is 3+3?", nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, ItemSeed(q1, 0), ItemSeed(q2, 0))
+}
+
+// TestNewShuffleFromConfig tests building the middleware from configuration.
+func TestNewShuffleFromConfig(t *testing.T) {
+	judge := &orderRecordingJudge{}
+
+	unit, err := NewShuffleFromConfig("shuffled", map[string]any{
+		"wrapped_unit": judge, "permutations": 2, "salt": 3, "seed": 5,
+	}, nil)
+	require.NoError(t, err)
+	sm, ok := unit.(*ShuffleMiddleware)
+	require.True(t, ok)
+	assert.Equal(t, 2, sm.config.Permutations)
+	assert.Equal(t, int64(3), sm.config.Salt)
+	require.NotNil(t, sm.config.Seed)
+	assert.Equal(t, int64(5), *sm.config.Seed)
+
+	tests := []struct {
+		name   string
+		config map[string]any
+	}{
+		{name: "missing wrapped unit", config: map[string]any{}},
+		{name: "too many permutations", config: map[string]any{"wrapped_unit": judge, "permutations": 100}},
+		{name: "non-integer seed", config: map[string]any{"wrapped_unit": judge, "seed": "abc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewShuffleFromConfig("shuffled", tt.config, nil)
+			assert.Error(t, err)
+		})
+	}
+}
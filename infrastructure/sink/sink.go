@@ -0,0 +1,67 @@
+// Package sink provides ports.ResultSink implementations that stream batch
+// verdicts out of the evaluation engine as items complete.
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+var (
+	_ ports.ResultSink = (*JSONLinesSink)(nil)
+	_ ports.ResultSink = Nop{}
+)
+
+// Record is one line written by JSONLinesSink.
+type Record struct {
+	// ItemID identifies the evaluated item.
+	ItemID string `json:"item_id"`
+	// Verdict is the item's verdict.
+	Verdict *domain.Verdict `json:"verdict"`
+}
+
+// JSONLinesSink writes each verdict as a JSON Record on its own line. It is
+// safe for concurrent use; lines from concurrent calls are never
+// interleaved.
+type JSONLinesSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONLinesSink returns a sink that writes JSON lines to w. Writes are
+// not buffered, so each record reaches w before Emit returns.
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{enc: json.NewEncoder(w)}
+}
+
+// NewStdoutSink returns a sink that writes JSON lines to standard output.
+func NewStdoutSink() *JSONLinesSink {
+	return NewJSONLinesSink(os.Stdout)
+}
+
+// Emit writes the item's verdict as one JSON line.
+func (s *JSONLinesSink) Emit(ctx context.Context, itemID string, verdict *domain.Verdict) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.enc.Encode(Record{ItemID: itemID, Verdict: verdict}); err != nil {
+		return fmt.Errorf("write verdict for item %s: %w", itemID, err)
+	}
+	return nil
+}
+
+// Nop is a sink that discards every verdict. It is useful when a batch
+// only needs per-item usage and errors, or in tests.
+type Nop struct{}
+
+// Emit discards the verdict.
+func (Nop) Emit(context.Context, string, *domain.Verdict) error { return nil }
@@ -0,0 +1,64 @@
+package sink
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// failingWriter fails every write with err.
+type failingWriter struct{ err error }
+
+func (w failingWriter) Write([]byte) (int, error) { return 0, w.err }
+
+// TestJSONLinesSink tests that concurrent emits produce one complete
+// record per line and that write and context errors are returned.
+func TestJSONLinesSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONLinesSink(&buf)
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			verdict := &domain.Verdict{ID: fmt.Sprintf("v%d", i), AggregateScore: 0.5}
+			assert.NoError(t, sink.Emit(context.Background(), fmt.Sprintf("item-%d", i), verdict))
+		}()
+	}
+	wg.Wait()
+
+	seen := map[string]string{}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var record Record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		require.NotNil(t, record.Verdict)
+		seen[record.ItemID] = record.Verdict.ID
+	}
+	require.Len(t, seen, 20)
+	assert.Equal(t, "v7", seen["item-7"])
+
+	errWrite := errors.New("disk full")
+	err := NewJSONLinesSink(failingWriter{errWrite}).Emit(context.Background(), "item-1", &domain.Verdict{})
+	assert.ErrorIs(t, err, errWrite)
+	assert.ErrorContains(t, err, "item item-1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	buf.Reset()
+	assert.ErrorIs(t, sink.Emit(ctx, "item-1", &domain.Verdict{}), context.Canceled)
+	assert.Zero(t, buf.Len())
+
+	assert.NoError(t, Nop{}.Emit(context.Background(), "item-1", &domain.Verdict{}))
+}
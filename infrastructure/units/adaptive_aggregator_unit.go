@@ -0,0 +1,395 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+var _ ports.Unit = (*AdaptiveAggregatorUnit)(nil)
+
+// Methods AdaptiveAggregatorUnit combines one answer's judge scores with.
+const (
+	// AdaptiveMean averages the scores. It is used when the judges agree.
+	AdaptiveMean = "mean"
+	// AdaptiveMedian takes the median score.
+	AdaptiveMedian = "median"
+	// AdaptiveTrimmedMean averages the scores left after dropping the
+	// highest and lowest TrimFraction of them.
+	AdaptiveTrimmedMean = "trimmed_mean"
+)
+
+// AdaptiveAggregatorUnit combines the scores several judges gave each answer
+// and picks the answer with the highest combined score. For every answer it
+// measures how much the judges disagree, as the population variance of
+// their scores, and chooses how to combine them: a plain mean when the
+// variance is at most VarianceThreshold, and the robust RobustMethod
+// (median or trimmed mean) above it. Outlier judges are thereby discounted
+// only on the answers where they disagree with the rest.
+//
+// Scores are read per judge from domain.KeyJudgeScoresByJudge. The method
+// chosen for each answer and its variance are recorded in the verdict's
+// Provenance.AnswerAggregations.
+//
+// Concurrency: The unit is stateless and thread-safe for concurrent
+// execution.
+type AdaptiveAggregatorUnit struct {
+	// name is the unique identifier for this unit instance.
+	name string
+	// config contains the validated configuration parameters.
+	config AdaptiveAggregatorConfig
+	// tracer is the OpenTelemetry tracer for observability.
+	tracer trace.Tracer
+}
+
+// AdaptiveAggregatorConfig configures when AdaptiveAggregatorUnit switches
+// from the mean to a robust method.
+type AdaptiveAggregatorConfig struct {
+	// VarianceThreshold is the largest variance of an answer's judge scores
+	// that is still combined with the mean, measured after normalizing the
+	// judges' declared score scale to 0-1 so that it means the same on a
+	// 1-10 judge as on a 0-1 one. The default of 0.01 corresponds to a
+	// standard deviation of a tenth of the scale.
+	VarianceThreshold float64 `yaml:"variance_threshold" json:"variance_threshold" validate:"min=0"`
+
+	// RobustMethod combines the scores of answers whose variance exceeds
+	// VarianceThreshold: "median" (the default) or "trimmed_mean".
+	RobustMethod string `yaml:"robust_method" json:"robust_method" validate:"required,oneof=median trimmed_mean"`
+
+	// TrimFraction is the share of scores the trimmed mean drops from each
+	// end, rounded up so that at least one score is dropped from each end
+	// while at least one score remains. Default: 0.2.
+	TrimFraction float64 `yaml:"trim_fraction" json:"trim_fraction" validate:"min=0,max=0.5"`
+
+	// Judges names the judge units whose scores are combined. Each must
+	// have scored every answer. When empty, every judge that reported
+	// scores is used.
+	Judges []string `yaml:"judges,omitempty" json:"judges,omitempty" validate:"omitempty,dive,required"`
+
+	// TieBreaker resolves answers with equal combined scores: "first",
+	// "lowest_id", or "error".
+	TieBreaker TieBreaker `yaml:"tie_breaker" json:"tie_breaker" validate:"required,oneof=first error lowest_id"`
+
+	// AbstainThreshold makes the unit abstain instead of picking a winner
+	// when the verdict's confidence falls below it. Zero disables
+	// abstention.
+	AbstainThreshold float64 `yaml:"abstain_threshold" json:"abstain_threshold" validate:"min=0.0,max=1.0"`
+
+	// MinAnswers is the fewest answers the unit accepts. Zero disables the
+	// check.
+	MinAnswers int `yaml:"min_answers,omitempty" json:"min_answers,omitempty" validate:"min=0,max=10000"`
+
+	// OutputScale presents the verdict's scores on a consumer-facing scale
+	// in Verdict.Display. Nil disables it.
+	OutputScale *OutputScaleConfig `yaml:"output_scale,omitempty" json:"output_scale,omitempty" validate:"omitempty"`
+}
+
+// DefaultAdaptiveAggregatorConfig returns an AdaptiveAggregatorConfig that
+// falls back to the median when an answer's judge scores have a standard
+// deviation above 0.1.
+func DefaultAdaptiveAggregatorConfig() AdaptiveAggregatorConfig {
+	return AdaptiveAggregatorConfig{
+		VarianceThreshold: 0.01,
+		RobustMethod:      AdaptiveMedian,
+		TrimFraction:      0.2,
+		TieBreaker:        TieFirst,
+	}
+}
+
+// NewAdaptiveAggregatorUnit creates an AdaptiveAggregatorUnit with
+// validated configuration. Returns ErrEmptyUnitName if name is empty, or a
+// configuration validation error if config fails validation.
+func NewAdaptiveAggregatorUnit(name string, config AdaptiveAggregatorConfig) (*AdaptiveAggregatorUnit, error) {
+	if name == "" {
+		return nil, ErrEmptyUnitName
+	}
+	if err := validate.Struct(config); err != nil {
+		return nil, domain.NewConfigValidationError(name, fieldValidationError(err))
+	}
+	if err := validateOutputScale(name, config.OutputScale); err != nil {
+		return nil, err
+	}
+	return &AdaptiveAggregatorUnit{
+		name:   name,
+		config: config,
+		tracer: otel.Tracer("adaptive-aggregator-unit"),
+	}, nil
+}
+
+// Name returns the unique identifier for this unit instance.
+func (aau *AdaptiveAggregatorUnit) Name() string { return aau.name }
+
+// Execute combines each answer's judge scores with the method its judges'
+// agreement calls for, selects the answer with the highest combined score,
+// and stores the verdict under domain.KeyVerdict. The verdict's aggregate
+// score is the winner's combined score.
+//
+// Returns an error if answers or judge scores are missing, a judge did not
+// score every answer, a score is not finite, or answers tie under the
+// "error" tie breaker.
+func (aau *AdaptiveAggregatorUnit) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	_, span := aau.tracer.Start(ctx, "AdaptiveAggregatorUnit.Execute",
+		trace.WithAttributes(
+			attribute.String("unit.type", "adaptive_aggregator"),
+			attribute.String("unit.id", aau.name),
+			attribute.Float64("config.variance_threshold", aau.config.VarianceThreshold),
+			attribute.String("config.robust_method", aau.config.RobustMethod),
+			attribute.String("config.tie_breaker", string(aau.config.TieBreaker)),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+
+	// A judge configured to tolerate empty items already recorded the
+	// outcome; there is nothing to aggregate.
+	if hasNoAnswersVerdict(state) && hasNoAnswers(state) {
+		span.SetAttributes(attribute.Bool("eval.no_answers", true))
+		return state, nil
+	}
+
+	answers, err := domain.Require(state, domain.KeyAnswers, aau.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	if len(answers) == 0 {
+		err := fmt.Errorf("no answers to aggregate")
+		span.RecordError(err)
+		return state, err
+	}
+
+	if err := checkMinAnswers(aau.name, len(answers), aau.config.MinAnswers); err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	byJudge, scale, err := aau.judgeScores(state, answers)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	combined := make([]domain.JudgeSummary, len(answers))
+	scores := make([]float64, len(answers))
+	aggregations := make([]domain.AnswerAggregation, len(answers))
+	var robust int
+	for i, answer := range answers {
+		if err := ctx.Err(); err != nil {
+			span.RecordError(err)
+			return state, err
+		}
+
+		judged := make([]float64, len(byJudge))
+		var confidence float64
+		for j, judgeScores := range byJudge {
+			judged[j] = judgeScores[i].Score
+			confidence += judgeScores[i].Confidence
+		}
+
+		score, method, variance := aau.combine(judged, scale)
+		if method != AdaptiveMean {
+			robust++
+		}
+		scores[i] = score
+		combined[i] = domain.JudgeSummary{
+			Score:      score,
+			Confidence: confidence / float64(len(byJudge)),
+			Reasoning:  fmt.Sprintf("Adaptive: %s of %d judge scores (variance %.4f)", method, len(judged), variance),
+		}
+		aggregations[i] = domain.AnswerAggregation{AnswerID: answer.ID, Method: method, Variance: variance}
+	}
+
+	winnerIdx, tieBreak, err := aau.selectWinner(scores, answers)
+	if err != nil {
+		err := fmt.Errorf("aggregation failed: %w", err)
+		span.RecordError(err)
+		return state, err
+	}
+	winner := answers[winnerIdx]
+
+	rank := func(score float64) float64 { return score }
+	verdict := domain.Verdict{
+		SchemaVersion:  domain.VerdictSchemaVersion,
+		ID:             fmt.Sprintf("%s_verdict", aau.name),
+		WinnerAnswer:   &winner,
+		AggregateScore: scores[winnerIdx],
+		// Surface content-identical candidates so callers know the choice
+		// between them was arbitrary rather than score-driven.
+		DuplicateAnswerIDs: duplicateAnswerIDs(winner, answers),
+		RankedAnswers:      rankAnswers(answers, scores, winner, aau.config.TieBreaker, rank),
+		TieBreak:           tieBreak,
+		// Participating units are stamped by the graph executor.
+		Provenance: &domain.Provenance{
+			AggregationMethod:  "adaptive_aggregator",
+			AnswerAggregations: aggregations,
+		},
+		CreatedAt: time.Now(),
+	}
+	verdict.Confidence = verdictConfidence(verdict.RankedAnswers, combined, rank, scale)
+	applyAbstention(&verdict, aau.config.AbstainThreshold)
+	applyOutputScale(&verdict, aau.config.OutputScale, scale)
+
+	span.SetAttributes(
+		attribute.Int64("eval.latency_ms", time.Since(start).Milliseconds()),
+		attribute.Int("eval.answers_count", len(answers)),
+		attribute.Int("eval.judges_count", len(byJudge)),
+		attribute.Int("eval.robust_count", robust),
+		attribute.Float64("eval.aggregate_score", verdict.AggregateScore),
+		attribute.String("eval.winner_id", winner.ID),
+		attribute.Float64("eval.confidence", verdict.Confidence),
+		attribute.String("eval.status", string(verdict.Status)),
+		attribute.Bool("no_llm_cost", true), // Deterministic units have no LLM cost
+	)
+
+	return domain.With(state, domain.KeyVerdict, &verdict), nil
+}
+
+// judgeScores returns the scores of each configured judge, or of every
+// judge in domain.KeyJudgeScoresByJudge in name order, and the score range
+// they share, checking that each scored every answer and that no score or
+// confidence is NaN or infinite. Without per-judge scores,
+// domain.KeyJudgeScores is treated as a single judge.
+func (aau *AdaptiveAggregatorUnit) judgeScores(state domain.State, answers []domain.Answer) ([][]domain.JudgeSummary, domain.ScoreRange, error) {
+	byJudge, _ := domain.Get(state, domain.KeyJudgeScoresByJudge)
+	if len(byJudge) == 0 {
+		scores, err := domain.Require(state, domain.KeyJudgeScores, aau.name)
+		if err != nil {
+			return nil, domain.ScoreRange{}, err
+		}
+		if len(scores) != len(answers) {
+			return nil, domain.ScoreRange{}, fmt.Errorf("mismatch between answers (%d) and judge scores (%d)", len(answers), len(scores))
+		}
+		if err := checkFiniteSummaries(scores); err != nil {
+			return nil, domain.ScoreRange{}, err
+		}
+		return [][]domain.JudgeSummary{scores}, latestJudgeScale(state), nil
+	}
+
+	if err := checkJudgeCoverage(state, aau.config.Judges, answers); err != nil {
+		return nil, domain.ScoreRange{}, err
+	}
+	judges := aau.config.Judges
+	if len(judges) == 0 {
+		judges = slices.Sorted(maps.Keys(byJudge))
+	}
+	scale, err := judgesScale(state, judges)
+	if err != nil {
+		return nil, domain.ScoreRange{}, fmt.Errorf("unit %s: %w", aau.name, err)
+	}
+	scores := make([][]domain.JudgeSummary, len(judges))
+	for i, judge := range judges {
+		if err := checkFiniteSummaries(byJudge[judge]); err != nil {
+			return nil, domain.ScoreRange{}, fmt.Errorf("judge %s: %w", judge, err)
+		}
+		scores[i] = byJudge[judge]
+	}
+	return scores, scale, nil
+}
+
+// combine returns the combined score of one answer's judge scores, the
+// method used, and the scores' population variance. The variance is
+// compared with VarianceThreshold after scaling it to a 0-1 version of
+// scale, the judges' score range.
+func (aau *AdaptiveAggregatorUnit) combine(scores []float64, scale domain.ScoreRange) (float64, string, float64) {
+	var sum float64
+	for _, score := range scores {
+		sum += score
+	}
+	mean := sum / float64(len(scores))
+
+	var variance float64
+	for _, score := range scores {
+		variance += (score - mean) * (score - mean)
+	}
+	variance /= float64(len(scores))
+
+	normalized := variance
+	if width := scale.Max - scale.Min; width > 0 {
+		normalized /= width * width
+	}
+	if normalized <= aau.config.VarianceThreshold {
+		return mean, AdaptiveMean, variance
+	}
+
+	sorted := slices.Clone(scores)
+	slices.Sort(sorted)
+	n := len(sorted)
+	if aau.config.RobustMethod == AdaptiveTrimmedMean {
+		trim := min(int(math.Ceil(float64(n)*aau.config.TrimFraction)), (n-1)/2)
+		kept := sorted[trim : n-trim]
+		var keptSum float64
+		for _, score := range kept {
+			keptSum += score
+		}
+		return keptSum / float64(len(kept)), AdaptiveTrimmedMean, variance
+	}
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	return median, AdaptiveMedian, variance
+}
+
+// selectWinner returns the index of the answer with the highest combined
+// score, resolving ties with the configured tie breaker.
+func (aau *AdaptiveAggregatorUnit) selectWinner(scores []float64, answers []domain.Answer) (int, *domain.TieBreak, error) {
+	best := slices.Max(scores)
+	tied := tiedIndices(scores, best)
+	if len(tied) == 1 {
+		return tied[0], nil, nil
+	}
+
+	var winnerIdx int
+	var reason string
+	switch aau.config.TieBreaker {
+	case TieError:
+		return 0, nil, fmt.Errorf("%w: %d answers with score %.3f", ErrTie, len(tied), best)
+	case TieLowestID:
+		winnerIdx = lowestIDIndex(answers, tied)
+		reason = fmt.Sprintf("lowest answer ID %q among tied candidates", answers[winnerIdx].ID)
+	default:
+		winnerIdx = tied[0]
+		reason = "first tied candidate in input order"
+	}
+	return winnerIdx, &domain.TieBreak{
+		Strategy:         string(aau.config.TieBreaker),
+		CandidateIndices: tied,
+		WinnerIndex:      winnerIdx,
+		Reason:           fmt.Sprintf("%d candidates with combined score %.3f; %s", len(tied), best, reason),
+	}, nil
+}
+
+// Validate checks if the unit is properly configured and ready for
+// execution.
+func (aau *AdaptiveAggregatorUnit) Validate() error {
+	if err := validate.Struct(aau.config); err != nil {
+		return domain.NewConfigValidationError(aau.name, fieldValidationError(err))
+	}
+	return validateOutputScale(aau.name, aau.config.OutputScale)
+}
+
+// NewAdaptiveAggregatorFromConfig creates an AdaptiveAggregatorUnit from a
+// configuration map. This is the boundary adapter for YAML/JSON
+// configuration. Adaptive aggregation doesn't require an LLM client.
+func NewAdaptiveAggregatorFromConfig(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error) {
+	// llm is ignored - adaptive aggregation is deterministic.
+
+	cfg := DefaultAdaptiveAggregatorConfig()
+	if err := overlayYAML(config, &cfg); err != nil {
+		return nil, err
+	}
+
+	return NewAdaptiveAggregatorUnit(id, cfg)
+}
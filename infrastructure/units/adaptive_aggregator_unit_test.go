@@ -0,0 +1,202 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/testutils"
+)
+
+// adaptiveState returns a state with two answers scored by three judges,
+// where judgeScores[j][i] is judge j's score for answer i.
+func adaptiveState(t *testing.T, judgeScores [3][2]float64) domain.State {
+	t.Helper()
+	answers := []domain.Answer{{ID: "a1", Content: "Paris"}, {ID: "a2", Content: "Lyon"}}
+	state := testutils.EvaluationState(t, "What is the capital of France?", answers)
+	for j, judge := range []string{"judge_a", "judge_b", "judge_c"} {
+		scores := make([]domain.JudgeSummary, len(answers))
+		for i := range answers {
+			scores[i] = domain.JudgeSummary{Score: judgeScores[j][i], Confidence: 0.6}
+		}
+		state = domain.WithJudgeScores(state, judge, scores)
+	}
+	return state
+}
+
+// TestAdaptiveAggregatorUnit_Execute tests that the method is chosen per
+// answer from the judges' variance and recorded in the verdict.
+func TestAdaptiveAggregatorUnit_Execute(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      map[string]any
+		scores      [3][2]float64
+		wantWinner  string
+		wantScore   float64
+		wantMethods []string
+	}{
+		{
+			name:        "agreement uses the mean",
+			config:      map[string]any{},
+			scores:      [3][2]float64{{0.8, 0.4}, {0.85, 0.45}, {0.9, 0.5}},
+			wantWinner:  "a1",
+			wantScore:   0.85,
+			wantMethods: []string{AdaptiveMean, AdaptiveMean},
+		},
+		{
+			name:   "outlier judge switches only its answer to the median",
+			config: map[string]any{},
+			// judge_c rates a2 far higher than the others; the mean would
+			// make a2 win (0.6 > 0.5), the median keeps it at 0.3.
+			scores:      [3][2]float64{{0.5, 0.3}, {0.5, 0.3}, {0.5, 1.0}},
+			wantWinner:  "a1",
+			wantScore:   0.5,
+			wantMethods: []string{AdaptiveMean, AdaptiveMedian},
+		},
+		{
+			name:        "trimmed mean",
+			config:      map[string]any{"robust_method": "trimmed_mean"},
+			scores:      [3][2]float64{{0.5, 0.3}, {0.5, 0.2}, {0.5, 1.0}},
+			wantWinner:  "a1",
+			wantScore:   0.5,
+			wantMethods: []string{AdaptiveMean, AdaptiveTrimmedMean},
+		},
+		{
+			name:        "higher threshold tolerates disagreement",
+			config:      map[string]any{"variance_threshold": 0.5},
+			scores:      [3][2]float64{{0.5, 0.3}, {0.5, 0.3}, {0.5, 1.0}},
+			wantWinner:  "a2",
+			wantScore:   (0.3 + 0.3 + 1.0) / 3,
+			wantMethods: []string{AdaptiveMean, AdaptiveMean},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := NewAdaptiveAggregatorFromConfig("adaptive", tt.config, nil)
+			require.NoError(t, err)
+
+			result, err := unit.Execute(context.Background(), adaptiveState(t, tt.scores))
+			require.NoError(t, err)
+
+			verdict, ok := result.GetVerdict()
+			require.True(t, ok)
+			require.NotNil(t, verdict.WinnerAnswer)
+			assert.Equal(t, tt.wantWinner, verdict.WinnerAnswer.ID)
+			assert.InDelta(t, tt.wantScore, verdict.AggregateScore, 1e-9)
+			assert.Equal(t, "adaptive_aggregator", verdict.Provenance.AggregationMethod)
+
+			require.Len(t, verdict.Provenance.AnswerAggregations, 2)
+			for i, aggregation := range verdict.Provenance.AnswerAggregations {
+				assert.Equal(t, tt.wantMethods[i], aggregation.Method, aggregation.AnswerID)
+			}
+			assert.Equal(t, "a1", verdict.Provenance.AnswerAggregations[0].AnswerID)
+		})
+	}
+}
+
+// TestAdaptiveAggregatorUnit_Judges tests judge selection and coverage.
+func TestAdaptiveAggregatorUnit_Judges(t *testing.T) {
+	state := adaptiveState(t, [3][2]float64{{0.5, 0.3}, {0.5, 0.3}, {0.5, 1.0}})
+
+	t.Run("configured judges only", func(t *testing.T) {
+		unit, err := NewAdaptiveAggregatorFromConfig("adaptive", map[string]any{"judges": []string{"judge_a", "judge_b"}}, nil)
+		require.NoError(t, err)
+		result, err := unit.Execute(context.Background(), state)
+		require.NoError(t, err)
+		verdict, _ := result.GetVerdict()
+		assert.InDelta(t, 0.3, verdict.RankedAnswers[1].Score, 1e-9)
+		assert.Equal(t, AdaptiveMean, verdict.Provenance.AnswerAggregations[1].Method)
+	})
+
+	t.Run("missing judge fails", func(t *testing.T) {
+		unit, err := NewAdaptiveAggregatorFromConfig("adaptive", map[string]any{"judges": []string{"judge_a", "judge_x"}}, nil)
+		require.NoError(t, err)
+		_, err = unit.Execute(context.Background(), state)
+		assert.ErrorIs(t, err, ErrMissingJudgeScores)
+	})
+
+	t.Run("single judge without per-judge scores", func(t *testing.T) {
+		answers := []domain.Answer{{ID: "a1", Content: "Paris"}, {ID: "a2", Content: "Lyon"}}
+		single := domain.With(testutils.EvaluationState(t, "Capital?", answers), domain.KeyJudgeScores,
+			[]domain.JudgeSummary{{Score: 0.4}, {Score: 0.7}})
+		unit, err := NewAdaptiveAggregatorUnit("adaptive", DefaultAdaptiveAggregatorConfig())
+		require.NoError(t, err)
+		result, err := unit.Execute(context.Background(), single)
+		require.NoError(t, err)
+		verdict, _ := result.GetVerdict()
+		assert.Equal(t, "a2", verdict.WinnerAnswer.ID)
+	})
+}
+
+// TestAdaptiveAggregatorUnit_ScoreScale tests that the variance threshold
+// applies to the judges' normalized scale and that judges on different
+// scales are rejected.
+func TestAdaptiveAggregatorUnit_ScoreScale(t *testing.T) {
+	tenPoint := domain.ScoreRange{Min: 1, Max: 10}
+	withScales := func(state domain.State, scales ...domain.ScoreRange) domain.State {
+		for i, judge := range []string{"judge_a", "judge_b", "judge_c"} {
+			state = domain.WithJudgeScoreScale(state, judge, scales[i])
+		}
+		return state
+	}
+
+	// A one-point spread is a variance of 0.22 on the raw scale but only
+	// 0.003 of the normalized 1-10 scale, so the judges agree.
+	state := withScales(adaptiveState(t, [3][2]float64{{7, 2}, {8, 2}, {8, 2}}), tenPoint, tenPoint, tenPoint)
+	unit, err := NewAdaptiveAggregatorUnit("adaptive", DefaultAdaptiveAggregatorConfig())
+	require.NoError(t, err)
+	result, err := unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+	verdict, _ := result.GetVerdict()
+	assert.Equal(t, AdaptiveMean, verdict.Provenance.AnswerAggregations[0].Method)
+	assert.InDelta(t, 23.0/3, verdict.AggregateScore, 1e-9)
+
+	mixed := withScales(adaptiveState(t, [3][2]float64{{7, 2}, {8, 2}, {0.8, 0.2}}), tenPoint, tenPoint, domain.UnitScoreRange)
+	_, err = unit.Execute(context.Background(), mixed)
+	assert.ErrorIs(t, err, ErrMixedScoreScales)
+}
+
+// TestAdaptiveAggregatorUnit_TieBreaker tests resolving equal combined
+// scores.
+func TestAdaptiveAggregatorUnit_TieBreaker(t *testing.T) {
+	state := adaptiveState(t, [3][2]float64{{0.5, 0.5}, {0.5, 0.5}, {0.5, 0.5}})
+
+	unit, err := NewAdaptiveAggregatorFromConfig("adaptive", map[string]any{"tie_breaker": "first"}, nil)
+	require.NoError(t, err)
+	result, err := unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+	verdict, _ := result.GetVerdict()
+	assert.Equal(t, "a1", verdict.WinnerAnswer.ID)
+	require.NotNil(t, verdict.TieBreak)
+	assert.Equal(t, []int{0, 1}, verdict.TieBreak.CandidateIndices)
+
+	unit, err = NewAdaptiveAggregatorFromConfig("adaptive", map[string]any{"tie_breaker": "error"}, nil)
+	require.NoError(t, err)
+	_, err = unit.Execute(context.Background(), state)
+	assert.ErrorIs(t, err, ErrTie)
+}
+
+// TestNewAdaptiveAggregatorFromConfig tests configuration errors.
+func TestNewAdaptiveAggregatorFromConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]any
+	}{
+		{name: "negative threshold", config: map[string]any{"variance_threshold": -0.1}},
+		{name: "unknown robust method", config: map[string]any{"robust_method": "mode"}},
+		{name: "trim fraction too large", config: map[string]any{"trim_fraction": 0.6}},
+		{name: "random tie breaker", config: map[string]any{"tie_breaker": "random"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAdaptiveAggregatorFromConfig("adaptive", tt.config, nil)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := NewAdaptiveAggregatorUnit("", DefaultAdaptiveAggregatorConfig())
+	assert.ErrorIs(t, err, ErrEmptyUnitName)
+}
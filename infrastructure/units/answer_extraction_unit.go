@@ -0,0 +1,387 @@
+package units
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+var _ ports.Unit = (*AnswerExtractionUnit)(nil)
+
+// ErrNoFinalAnswer is returned when an answer contains no final answer and
+// the unit is configured to fail rather than keep or blank it.
+var ErrNoFinalAnswer = errors.New("no final answer found")
+
+// Extraction strategies supported by AnswerExtractionUnit.
+const (
+	// ExtractionStrategyMarker takes the text after the last occurrence of
+	// any configured marker, such as "Final answer:".
+	ExtractionStrategyMarker = "marker"
+	// ExtractionStrategyLastLine takes the last non-blank line.
+	ExtractionStrategyLastLine = "last_line"
+	// ExtractionStrategyRegex takes the first capture group of the last
+	// match of a pattern, or the whole match if it has no groups.
+	ExtractionStrategyRegex = "regex"
+	// ExtractionStrategyCodeFence takes the code inside the last markdown
+	// code block, optionally only among blocks tagged with a language, so
+	// code graders receive code rather than markdown-wrapped text.
+	ExtractionStrategyCodeFence = "code_fence"
+)
+
+// Policies for answers in which AnswerExtractionUnit finds no final answer.
+const (
+	// NoMatchKeep leaves the answer's content unchanged.
+	NoMatchKeep = "keep"
+	// NoMatchEmpty replaces the content with the empty string so the
+	// answer scores as wrong.
+	NoMatchEmpty = "empty"
+	// NoMatchError fails execution with ErrNoFinalAnswer.
+	NoMatchError = "error"
+)
+
+// DefaultFinalAnswerMarker is the marker AnswerExtractionUnit looks for
+// when none are configured.
+const DefaultFinalAnswerMarker = "Final answer:"
+
+// AnswerExtractionUnit reduces verbose answers to their final answer so
+// deterministic scorers such as ExactMatchUnit and FuzzyMatchUnit grade
+// the answer rather than the reasoning that led to it. With the code_fence
+// strategy it unwraps code answers from their markdown fences, so code
+// graders and judges see the code itself rather than nested fences. Each
+// answer's content in KeyAnswers is replaced by the extracted text,
+// trimmed of surrounding whitespace, and the full response is kept in the
+// answer's metadata under domain.AnswerMetadataOriginalContent. An answer that
+// already carries original content keeps it, so running the unit twice
+// does not lose the full response.
+//
+// Place the unit before the scorers it feeds. It makes no LLM calls and
+// is stateless and thread-safe.
+type AnswerExtractionUnit struct {
+	// name is the unique identifier for this unit instance.
+	name string
+	// config contains the validated configuration parameters.
+	config AnswerExtractionConfig
+	// pattern matches the configured markers case-insensitively for the
+	// marker strategy, or is the compiled Pattern for the regex strategy.
+	pattern *regexp.Regexp
+	// tracer is the OpenTelemetry tracer for observability.
+	tracer trace.Tracer
+}
+
+// AnswerExtractionConfig defines how an AnswerExtractionUnit finds the
+// final answer in a response.
+type AnswerExtractionConfig struct {
+	// Strategy selects how the final answer is found: "marker" (the
+	// default), "last_line", "regex", or "code_fence".
+	Strategy string `yaml:"strategy" json:"strategy" validate:"required,oneof=marker last_line regex code_fence"`
+
+	// Markers are the phrases that introduce the final answer for the
+	// marker strategy, matched case-insensitively. The text after the last
+	// occurrence of any marker is the final answer.
+	Markers []string `yaml:"markers,omitempty" json:"markers,omitempty" validate:"max=20,dive,required,max=200"`
+
+	// Pattern is the regular expression for the regex strategy. The first
+	// capture group of its last match is the final answer; without groups
+	// the whole match is.
+	Pattern string `yaml:"pattern,omitempty" json:"pattern,omitempty" validate:"required_if=Strategy regex,max=1000"`
+
+	// Language restricts the code_fence strategy to blocks whose info
+	// string names this language, matched case-insensitively, such as
+	// "python" for blocks opened with ```python. Empty accepts any block.
+	Language string `yaml:"language,omitempty" json:"language,omitempty" validate:"omitempty,max=50"`
+
+	// OnNoMatch decides what happens to an answer in which no final answer
+	// is found: "keep" (the default) leaves it unchanged, "empty" blanks
+	// it, and "error" fails execution.
+	OnNoMatch string `yaml:"on_no_match,omitempty" json:"on_no_match,omitempty" validate:"omitempty,oneof=keep empty error"`
+}
+
+// DefaultAnswerExtractionConfig returns an AnswerExtractionConfig that
+// takes the text after the last "Final answer:" and keeps answers without
+// one unchanged.
+func DefaultAnswerExtractionConfig() AnswerExtractionConfig {
+	return AnswerExtractionConfig{
+		Strategy:  ExtractionStrategyMarker,
+		Markers:   []string{DefaultFinalAnswerMarker},
+		OnNoMatch: NoMatchKeep,
+	}
+}
+
+// onNoMatch returns the no-match policy, defaulting to NoMatchKeep.
+func (c AnswerExtractionConfig) onNoMatch() string {
+	if c.OnNoMatch == "" {
+		return NoMatchKeep
+	}
+	return c.OnNoMatch
+}
+
+// NewAnswerExtractionUnit creates a new AnswerExtractionUnit with the
+// specified configuration. Returns an error if configuration validation
+// fails, the marker strategy has no markers, or Pattern does not compile.
+func NewAnswerExtractionUnit(name string, config AnswerExtractionConfig) (*AnswerExtractionUnit, error) {
+	if name == "" {
+		return nil, ErrEmptyUnitName
+	}
+
+	if err := validate.Struct(config); err != nil {
+		return nil, domain.NewConfigValidationError(name, fieldValidationError(err))
+	}
+
+	unit := &AnswerExtractionUnit{
+		name:   name,
+		config: config,
+		tracer: otel.Tracer("answer-extraction-unit"),
+	}
+
+	switch config.Strategy {
+	case ExtractionStrategyMarker:
+		if len(config.Markers) == 0 {
+			return nil, domain.NewConfigValidationError(name, errors.New("marker strategy requires at least one marker"))
+		}
+		quoted := make([]string, len(config.Markers))
+		for i, marker := range config.Markers {
+			quoted[i] = regexp.QuoteMeta(marker)
+		}
+		unit.pattern = regexp.MustCompile(`(?i)(?:` + strings.Join(quoted, "|") + `)`)
+	case ExtractionStrategyRegex:
+		pattern, err := regexp.Compile(config.Pattern)
+		if err != nil {
+			return nil, domain.NewConfigValidationError(name, fmt.Errorf("invalid pattern: %w", err))
+		}
+		unit.pattern = pattern
+	}
+
+	return unit, nil
+}
+
+// Name returns the unique identifier for this unit instance.
+func (aeu *AnswerExtractionUnit) Name() string { return aeu.name }
+
+// Execute replaces the content of every answer in KeyAnswers with its
+// extracted final answer. Judge scores already in State are left as they
+// are, so the unit belongs before scoring.
+//
+// Returns an error if answers are missing, an answer is too long, or the
+// no-match policy is "error" and an answer has no final answer.
+func (aeu *AnswerExtractionUnit) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	_, span := aeu.tracer.Start(ctx, "AnswerExtractionUnit.Execute",
+		trace.WithAttributes(
+			attribute.String("unit.type", "answer_extraction"),
+			attribute.String("unit.id", aeu.name),
+			attribute.String("config.strategy", aeu.config.Strategy),
+			attribute.String("config.on_no_match", aeu.config.onNoMatch()),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+
+	answers, err := domain.Require(state, domain.KeyAnswers, aeu.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	if len(answers) > MaxAnswers {
+		err := fmt.Errorf("too many answers: %d exceeds limit of %d", len(answers), MaxAnswers)
+		span.RecordError(err)
+		return state, err
+	}
+
+	extracted := make([]domain.Answer, len(answers))
+	var unmatched int
+	for i, answer := range answers {
+		if len(answer.Content) > MaxStringLength {
+			err := fmt.Errorf("answer %d too long: %d bytes exceeds limit of %d", i, len(answer.Content), MaxStringLength)
+			span.RecordError(err)
+			return state, err
+		}
+
+		final, found := aeu.extract(answer.Content)
+		if !found {
+			unmatched++
+			switch aeu.config.onNoMatch() {
+			case NoMatchError:
+				err := fmt.Errorf("unit %s: answer %s: %w", aeu.name, answer.ID, ErrNoFinalAnswer)
+				span.RecordError(err)
+				return state, err
+			case NoMatchKeep:
+				extracted[i] = answer
+				continue
+			}
+		}
+		extracted[i] = withExtractedContent(answer, final)
+	}
+
+	span.SetAttributes(
+		attribute.Int64("eval.latency_ms", time.Since(start).Milliseconds()),
+		attribute.Int("eval.answers_count", len(answers)),
+		attribute.Int("eval.unmatched_count", unmatched),
+		attribute.Bool("no_llm_cost", true),
+	)
+
+	return domain.With(state, domain.KeyAnswers, extracted), nil
+}
+
+// extract returns the final answer in content and whether one was found.
+func (aeu *AnswerExtractionUnit) extract(content string) (string, bool) {
+	switch aeu.config.Strategy {
+	case ExtractionStrategyMarker:
+		return extractAfterMarker(content, aeu.pattern)
+	case ExtractionStrategyLastLine:
+		return extractLastLine(content)
+	case ExtractionStrategyCodeFence:
+		return extractCodeFence(content, aeu.config.Language)
+	default:
+		return extractPatternMatch(content, aeu.pattern)
+	}
+}
+
+// extractAfterMarker returns the trimmed text after the last match of
+// markers. A marker followed only by whitespace does not count as a final
+// answer.
+func extractAfterMarker(content string, markers *regexp.Regexp) (string, bool) {
+	matches := markers.FindAllStringIndex(content, -1)
+	if len(matches) == 0 {
+		return "", false
+	}
+	final := strings.TrimSpace(content[matches[len(matches)-1][1]:])
+	return final, final != ""
+}
+
+// extractLastLine returns the last line of content that is not blank.
+func extractLastLine(content string) (string, bool) {
+	lines := strings.Split(content, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return line, true
+		}
+	}
+	return "", false
+}
+
+// extractPatternMatch returns the first capture group of the last match of
+// pattern in content, or the whole match if pattern has no groups.
+func extractPatternMatch(content string, pattern *regexp.Regexp) (string, bool) {
+	matches := pattern.FindAllStringSubmatch(content, -1)
+	if len(matches) == 0 {
+		return "", false
+	}
+	last := matches[len(matches)-1]
+	final := last[0]
+	if len(last) > 1 {
+		final = last[1]
+	}
+	final = strings.TrimSpace(final)
+	return final, final != ""
+}
+
+// extractCodeFence returns the code inside the last fenced code block of
+// content whose language is language, or of any block when language is
+// empty. Fences are lines opening with three or more backticks or tildes,
+// closed by a line of at least as many of the same character; a block left
+// open, as in a truncated response, runs to the end of content. Blank lines
+// around the code and trailing whitespace are removed, but the indentation
+// of its first line is kept because it can be significant in code.
+func extractCodeFence(content, language string) (string, bool) {
+	var (
+		code, block []string
+		inBlock     bool
+		matches     bool
+		found       bool
+		fence       string
+	)
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !inBlock {
+			if marker := fenceMarker(trimmed); marker != "" {
+				info := strings.Fields(strings.TrimPrefix(trimmed, marker))
+				inBlock, fence, block = true, marker, nil
+				matches = language == "" || (len(info) > 0 && strings.EqualFold(info[0], language))
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			if matches {
+				code, found = block, true
+			}
+			inBlock = false
+			continue
+		}
+		block = append(block, line)
+	}
+	if inBlock && matches {
+		code, found = block, true
+	}
+	if !found {
+		return "", false
+	}
+	for len(code) > 0 && strings.TrimSpace(code[0]) == "" {
+		code = code[1:]
+	}
+	final := strings.TrimRightFunc(strings.Join(code, "\n"), unicode.IsSpace)
+	return final, final != ""
+}
+
+// fenceMarker returns the run of backticks or tildes opening a code fence
+// at the start of line, or "" if line does not open a fence. Backtick
+// fences may not contain backticks in their info string.
+func fenceMarker(line string) string {
+	if line == "" || (line[0] != '`' && line[0] != '~') {
+		return ""
+	}
+	n := len(line) - len(strings.TrimLeft(line, line[:1]))
+	if n < 3 || (line[0] == '`' && strings.Contains(line[n:], "`")) {
+		return ""
+	}
+	return line[:n]
+}
+
+// withExtractedContent returns answer with its content replaced by final
+// and its previous content recorded in a copy of its metadata.
+func withExtractedContent(answer domain.Answer, final string) domain.Answer {
+	metadata := make(map[string]string, len(answer.Metadata)+1)
+	for k, v := range answer.Metadata {
+		metadata[k] = v
+	}
+	if _, ok := metadata[domain.AnswerMetadataOriginalContent]; !ok {
+		metadata[domain.AnswerMetadataOriginalContent] = answer.Content
+	}
+	answer.Metadata = metadata
+	answer.Content = final
+	return answer
+}
+
+// Validate checks if the unit is properly configured and ready for
+// execution.
+func (aeu *AnswerExtractionUnit) Validate() error {
+	if err := validate.Struct(aeu.config); err != nil {
+		return domain.NewConfigValidationError(aeu.name, fieldValidationError(err))
+	}
+	return nil
+}
+
+// NewAnswerExtractionFromConfig creates an AnswerExtractionUnit from a
+// configuration map. This is the boundary adapter for YAML/JSON
+// configuration. A configured markers list replaces the default marker.
+func NewAnswerExtractionFromConfig(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error) {
+	// llm is ignored - extraction is purely textual.
+
+	cfg := DefaultAnswerExtractionConfig()
+	if err := overlayYAML(config, &cfg); err != nil {
+		return nil, err
+	}
+
+	return NewAnswerExtractionUnit(id, cfg)
+}
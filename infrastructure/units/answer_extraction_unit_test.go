@@ -0,0 +1,177 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/testutils"
+)
+
+// TestAnswerExtractionUnit_Execute tests each strategy and no-match policy
+// and that the full response is kept in metadata.
+func TestAnswerExtractionUnit_Execute(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]any
+		content string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "default marker",
+			config:  map[string]any{},
+			content: "France's capital is on the Seine.\nFinal answer: Paris\n",
+			want:    "Paris",
+		},
+		{
+			name:    "marker is case-insensitive and the last one wins",
+			config:  map[string]any{},
+			content: "Final answer: Lyon? No.\nFINAL ANSWER:  Paris",
+			want:    "Paris",
+		},
+		{
+			name:    "configured markers",
+			config:  map[string]any{"markers": []string{"The answer is", "Answer:"}},
+			content: "Thinking... The answer is 42",
+			want:    "42",
+		},
+		{
+			name:    "no marker keeps the answer",
+			config:  map[string]any{},
+			content: "Paris",
+			want:    "Paris",
+		},
+		{
+			name:    "marker without text is no match",
+			config:  map[string]any{"on_no_match": "empty"},
+			content: "I am not sure. Final answer:",
+			want:    "",
+		},
+		{
+			name:    "last line",
+			config:  map[string]any{"strategy": "last_line"},
+			content: "Step 1: recall geography.\nStep 2: answer.\n  Paris  \n\n",
+			want:    "Paris",
+		},
+		{
+			name:    "regex capture group",
+			config:  map[string]any{"strategy": "regex", "pattern": `\\boxed\{([^}]*)\}`},
+			content: `So x = \boxed{3}, then y = \boxed{7}.`,
+			want:    "7",
+		},
+		{
+			name:    "regex without groups",
+			config:  map[string]any{"strategy": "regex", "pattern": `[0-9]+`},
+			content: "Between 10 and 12 there is 11",
+			want:    "11",
+		},
+		{
+			name:    "code fence",
+			config:  map[string]any{"strategy": "code_fence"},
+			content: "Here is the solution:\n\n```python\n\n    def f(x):\n        return x\n```\nIt returns x.",
+			want:    "    def f(x):\n        return x",
+		},
+		{
+			name:    "code fence picks the last block of the language",
+			config:  map[string]any{"strategy": "code_fence", "language": "Go"},
+			content: "```go\nfunc a() {}\n```\n```go\nfunc b() {}\n```\n```text\noutput\n```",
+			want:    "func b() {}",
+		},
+		{
+			name:    "longer fence keeps inner fences",
+			config:  map[string]any{"strategy": "code_fence"},
+			content: "````markdown\n```go\nx := 1\n```\n````",
+			want:    "```go\nx := 1\n```",
+		},
+		{
+			name:    "unclosed fence runs to the end",
+			config:  map[string]any{"strategy": "code_fence"},
+			content: "~~~\nprint(1)\n",
+			want:    "print(1)",
+		},
+		{
+			name:    "code fence without block",
+			config:  map[string]any{"strategy": "code_fence", "on_no_match": "error"},
+			content: "print(1) with ``inline`` code",
+			wantErr: ErrNoFinalAnswer,
+		},
+		{
+			name:    "no match fails when configured",
+			config:  map[string]any{"on_no_match": "error"},
+			content: "Paris",
+			wantErr: ErrNoFinalAnswer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := NewAnswerExtractionFromConfig("extract", tt.config, nil)
+			require.NoError(t, err)
+
+			answers := []domain.Answer{{ID: "a1", Content: tt.content, Metadata: map[string]string{"source": "test"}}}
+			state := testutils.EvaluationState(t, "What is the capital of France?", answers)
+
+			result, err := unit.Execute(context.Background(), state)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			got, _ := domain.Get(result, domain.KeyAnswers)
+			require.Len(t, got, 1)
+			assert.Equal(t, tt.want, got[0].Content)
+			assert.Equal(t, "test", got[0].Metadata["source"])
+			if got[0].Content != tt.content {
+				assert.Equal(t, tt.content, got[0].Metadata[domain.AnswerMetadataOriginalContent])
+			}
+			assert.Equal(t, map[string]string{"source": "test"}, answers[0].Metadata, "input metadata must not change")
+		})
+	}
+}
+
+// TestAnswerExtractionUnit_KeepsFirstOriginal tests that extracting twice
+// keeps the full response rather than the first extraction.
+func TestAnswerExtractionUnit_KeepsFirstOriginal(t *testing.T) {
+	unit, err := NewAnswerExtractionUnit("extract", DefaultAnswerExtractionConfig())
+	require.NoError(t, err)
+
+	const content = "Reasoning.\nFinal answer: Final answer: Paris"
+	state := testutils.EvaluationState(t, "Capital?", []domain.Answer{{ID: "a1", Content: content}})
+
+	once, err := unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+	twice, err := unit.Execute(context.Background(), once)
+	require.NoError(t, err)
+
+	got, _ := domain.Get(twice, domain.KeyAnswers)
+	assert.Equal(t, "Paris", got[0].Content)
+	assert.Equal(t, content, got[0].Metadata[domain.AnswerMetadataOriginalContent])
+}
+
+// TestNewAnswerExtractionFromConfig tests configuration errors.
+func TestNewAnswerExtractionFromConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]any
+	}{
+		{name: "unknown strategy", config: map[string]any{"strategy": "first_line"}},
+		{name: "regex without pattern", config: map[string]any{"strategy": "regex"}},
+		{name: "invalid pattern", config: map[string]any{"strategy": "regex", "pattern": "("}},
+		{name: "no markers", config: map[string]any{"markers": []string{}}},
+		{name: "empty marker", config: map[string]any{"markers": []string{""}}},
+		{name: "unknown no-match policy", config: map[string]any{"on_no_match": "skip"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAnswerExtractionFromConfig("extract", tt.config, nil)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := NewAnswerExtractionUnit("", DefaultAnswerExtractionConfig())
+	assert.ErrorIs(t, err, ErrEmptyUnitName)
+}
@@ -0,0 +1,155 @@
+package units
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+// ErrDimensionMismatch is returned when comparing embedding vectors of
+// different lengths.
+var ErrDimensionMismatch = errors.New("embedding dimensions do not match")
+
+// EmbedAnswers embeds the content of every answer in a single batched call
+// to the embedding client. Wrap the client with llm.NewCachingEmbeddingClient
+// to avoid re-embedding answers seen in earlier calls.
+func EmbedAnswers(ctx context.Context, client ports.EmbeddingClient, answers []domain.Answer) ([][]float64, error) {
+	if client == nil {
+		return nil, fmt.Errorf("embedding client cannot be nil")
+	}
+	if len(answers) == 0 {
+		return nil, nil
+	}
+
+	texts := make([]string, len(answers))
+	for i, answer := range answers {
+		texts[i] = answer.Content
+	}
+
+	vectors, err := client.Embed(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed answers: %w", err)
+	}
+	if len(vectors) != len(answers) {
+		return nil, fmt.Errorf("embedding client returned %d vectors for %d answers", len(vectors), len(answers))
+	}
+	return vectors, nil
+}
+
+// AnswerSimilarityMatrix embeds the answers in one batch and returns the
+// N×N cosine similarity matrix, where entry [i][j] is the similarity between
+// answers i and j. The matrix is symmetric with a diagonal of 1.0 for
+// non-zero vectors.
+func AnswerSimilarityMatrix(ctx context.Context, client ports.EmbeddingClient, answers []domain.Answer) ([][]float64, error) {
+	vectors, err := EmbedAnswers(ctx, client, answers)
+	if err != nil {
+		return nil, err
+	}
+	return CosineSimilarityMatrix(vectors)
+}
+
+// CosineSimilarityMatrix computes pairwise cosine similarities between the
+// given vectors. Each pair is computed once and mirrored across the diagonal.
+func CosineSimilarityMatrix(vectors [][]float64) ([][]float64, error) {
+	n := len(vectors)
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			sim, err := CosineSimilarity(vectors[i], vectors[j])
+			if err != nil {
+				return nil, fmt.Errorf("vectors %d and %d: %w", i, j, err)
+			}
+			matrix[i][j] = sim
+			matrix[j][i] = sim
+		}
+	}
+	return matrix, nil
+}
+
+// CosineSimilarity returns the cosine of the angle between a and b, in the
+// range [-1, 1]. Zero vectors have no direction, so their similarity to any
+// vector is defined as 0.
+func CosineSimilarity(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("%w: %d vs %d", ErrDimensionMismatch, len(a), len(b))
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0, nil
+	}
+
+	sim := dot / (math.Sqrt(normA) * math.Sqrt(normB))
+	// Clamp to guard against floating-point drift outside [-1, 1].
+	return math.Max(-1, math.Min(1, sim)), nil
+}
+
+// NearDuplicateClusters groups answers whose pairwise similarity is at or
+// above threshold, treating similarity as transitive (single-linkage).
+// Only clusters with two or more answers are returned. Answer IDs within a
+// cluster keep their input order, and clusters are ordered by their first
+// member, so the output is deterministic for a given input.
+func NearDuplicateClusters(matrix [][]float64, answers []domain.Answer, threshold float64) ([][]string, error) {
+	n := len(answers)
+	if len(matrix) != n {
+		return nil, fmt.Errorf("%w: matrix=%d, answers=%d", ErrScoreMismatch, len(matrix), n)
+	}
+
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i := 0; i < n; i++ {
+		if len(matrix[i]) != n {
+			return nil, fmt.Errorf("%w: matrix row %d has %d columns, want %d", ErrScoreMismatch, i, len(matrix[i]), n)
+		}
+		for j := i + 1; j < n; j++ {
+			if matrix[i][j] >= threshold {
+				ri, rj := find(i), find(j)
+				// Attach to the smaller root so each cluster is keyed by its first member.
+				parent[max(ri, rj)] = min(ri, rj)
+			}
+		}
+	}
+
+	groups := make(map[int][]string)
+	var roots []int
+	for i, answer := range answers {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], answer.ID)
+	}
+
+	slices.Sort(roots)
+	var clusters [][]string
+	for _, root := range roots {
+		if len(groups[root]) > 1 {
+			clusters = append(clusters, groups[root])
+		}
+	}
+	return clusters, nil
+}
@@ -0,0 +1,129 @@
+package units
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/infrastructure/llm"
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/testutils"
+)
+
+// TestCosineSimilarity tests cosine similarity for parallel, orthogonal,
+// opposite, and zero vectors, as well as dimension mismatches.
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     []float64
+		expected float64
+		wantErr  bool
+	}{
+		{name: "identical", a: []float64{1, 2, 3}, b: []float64{1, 2, 3}, expected: 1.0},
+		{name: "scaled", a: []float64{1, 2, 3}, b: []float64{2, 4, 6}, expected: 1.0},
+		{name: "orthogonal", a: []float64{1, 0}, b: []float64{0, 1}, expected: 0.0},
+		{name: "opposite", a: []float64{1, 0}, b: []float64{-1, 0}, expected: -1.0},
+		{name: "zero vector", a: []float64{0, 0}, b: []float64{1, 1}, expected: 0.0},
+		{name: "dimension mismatch", a: []float64{1}, b: []float64{1, 2}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sim, err := CosineSimilarity(tt.a, tt.b)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrDimensionMismatch)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, sim, 1e-9)
+		})
+	}
+}
+
+// TestAnswerSimilarityMatrix tests that answers are embedded in a single
+// batched call and that the resulting matrix is symmetric with a unit diagonal.
+func TestAnswerSimilarityMatrix(t *testing.T) {
+	client := testutils.NewMockEmbeddingClient("embed-test", 128)
+	answers := []domain.Answer{
+		{ID: "a1", Content: "The capital of France is Paris"},
+		{ID: "a2", Content: "the capital of france is paris"},
+		{ID: "a3", Content: "Bananas are yellow"},
+	}
+
+	matrix, err := AnswerSimilarityMatrix(context.Background(), client, answers)
+	require.NoError(t, err)
+	require.Len(t, matrix, 3)
+	assert.Equal(t, 1, client.Calls(), "answers should be embedded in one batch")
+
+	for i := range matrix {
+		require.Len(t, matrix[i], 3)
+		assert.InDelta(t, 1.0, matrix[i][i], 1e-9)
+		for j := range matrix {
+			assert.Equal(t, matrix[i][j], matrix[j][i])
+		}
+	}
+	assert.InDelta(t, 1.0, matrix[0][1], 1e-9)
+	assert.Less(t, matrix[0][2], 0.5)
+}
+
+// TestAnswerSimilarityMatrix_UsesCache tests that repeated answers are served
+// from the caching embedding client instead of being re-embedded.
+func TestAnswerSimilarityMatrix_UsesCache(t *testing.T) {
+	base := testutils.NewMockEmbeddingClient("embed-test", 64)
+	client := llm.NewCachingEmbeddingClient(base, 100)
+	answers := []domain.Answer{
+		{ID: "a1", Content: "same"},
+		{ID: "a2", Content: "same"},
+		{ID: "a3", Content: "different"},
+	}
+
+	_, err := AnswerSimilarityMatrix(context.Background(), client, answers)
+	require.NoError(t, err)
+	assert.Equal(t, 2, base.TextsEmbedded(), "duplicate content should be embedded once")
+
+	_, err = AnswerSimilarityMatrix(context.Background(), client, answers)
+	require.NoError(t, err)
+	assert.Equal(t, 1, base.Calls(), "second call should be served entirely from cache")
+}
+
+// TestAnswerSimilarityMatrix_Errors tests error propagation from the
+// embedding client and nil client handling.
+func TestAnswerSimilarityMatrix_Errors(t *testing.T) {
+	answers := []domain.Answer{{ID: "a1", Content: "x"}}
+
+	_, err := AnswerSimilarityMatrix(context.Background(), nil, answers)
+	require.Error(t, err)
+
+	client := testutils.NewMockEmbeddingClient("embed-test", 8)
+	client.SetError(errors.New("provider down"))
+	_, err = AnswerSimilarityMatrix(context.Background(), client, answers)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "provider down")
+}
+
+// TestNearDuplicateClusters tests single-linkage clustering of answers above
+// a similarity threshold, including transitive grouping and deterministic order.
+func TestNearDuplicateClusters(t *testing.T) {
+	answers := []domain.Answer{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}, {ID: "e"}}
+	matrix := [][]float64{
+		{1.0, 0.1, 0.95, 0.1, 0.1},
+		{0.1, 1.0, 0.1, 0.1, 0.92},
+		{0.95, 0.1, 1.0, 0.91, 0.1},
+		{0.1, 0.1, 0.91, 1.0, 0.1},
+		{0.1, 0.92, 0.1, 0.1, 1.0},
+	}
+
+	clusters, err := NearDuplicateClusters(matrix, answers, 0.9)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "c", "d"}, {"b", "e"}}, clusters)
+
+	clusters, err = NearDuplicateClusters(matrix, answers, 0.99)
+	require.NoError(t, err)
+	assert.Empty(t, clusters)
+
+	_, err = NearDuplicateClusters(matrix[:2], answers, 0.9)
+	require.ErrorIs(t, err, ErrScoreMismatch)
+}
@@ -1,14 +1,12 @@
 package units
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"text/template"
+	"sync"
 	"time"
 
-	"github.com/go-playground/validator/v10"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -22,7 +20,7 @@ import (
 var _ ports.Unit = (*AnswererUnit)(nil)
 
 // Shared validator instance to reduce allocations.
-var answererValidator = validator.New()
+var answererValidator = newConfigValidator()
 
 // Configuration constants for the AnswererUnit.
 const (
@@ -38,15 +36,18 @@ const (
 	DefaultTimeoutSeconds = 30
 )
 
-// Sentinel errors for clear, testable error conditions.
+// Sentinel errors for clear, testable error conditions. ErrQuestionMissing,
+// ErrConfigValidation, and ErrLLMCallFailed alias the domain sentinels
+// matched by the typed errors the unit returns, so errors.Is keeps working
+// for callers that predate them.
 var (
-	ErrQuestionMissing   = errors.New("question not found in state")
+	ErrQuestionMissing   = domain.ErrKeyNotFound
 	ErrQuestionEmpty     = errors.New("question cannot be empty")
 	ErrUnitNameEmpty     = errors.New("unit name cannot be empty")
 	ErrLLMClientNil      = errors.New("LLM client cannot be nil")
-	ErrConfigValidation  = errors.New("configuration validation failed")
+	ErrConfigValidation  = domain.ErrInvalidConfiguration
 	ErrTemplateExecution = errors.New("failed to execute prompt template")
-	ErrLLMCallFailed     = errors.New("LLM call failed")
+	ErrLLMCallFailed     = domain.ErrLLMCallFailed
 )
 
 // LLMOptions contains typed configuration parameters for LLM client calls.
@@ -82,7 +83,7 @@ type AnswererUnit struct {
 	name           string
 	config         AnswererConfig
 	llmClient      ports.LLMClient
-	promptTemplate *template.Template
+	promptRenderer PromptRenderer
 	tracer         trace.Tracer
 }
 
@@ -118,6 +119,10 @@ type AnswererConfig struct {
 	// MaxConcurrency limits concurrent LLM calls to prevent service overload.
 	// Range: 1-20 concurrent requests. Consider LLM service rate limits and quotas.
 	MaxConcurrency int `yaml:"max_concurrency" json:"max_concurrency" validate:"required,min=1,max=20"`
+
+	// TemplateEngine selects the registered PromptRenderer used for Prompt.
+	// Defaults to the Go text/template engine when empty.
+	TemplateEngine string `yaml:"template_engine,omitempty" json:"template_engine,omitempty"`
 }
 
 // NewAnswererUnit creates a new AnswererUnit with validated configuration
@@ -133,7 +138,8 @@ type AnswererConfig struct {
 // rather than runtime errors.
 //
 // Returns ErrUnitNameEmpty if name is empty, ErrLLMClientNil if client is nil,
-// ErrConfigValidation if validation fails, or template parsing errors.
+// a *domain.ConfigValidationError if validation fails, or template parsing
+// errors.
 func NewAnswererUnit(
 	name string,
 	llmClient ports.LLMClient,
@@ -147,10 +153,10 @@ func NewAnswererUnit(
 	}
 
 	if err := answererValidator.Struct(config); err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrConfigValidation, err)
+		return nil, domain.NewConfigValidationError(name, fieldValidationError(err))
 	}
 
-	tmpl, err := template.New("prompt").Funcs(GetTemplateFuncMap()).Parse(config.Prompt)
+	renderer, err := newPromptRenderer(config.TemplateEngine, "prompt", config.Prompt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse prompt template: %w", err)
 	}
@@ -159,7 +165,7 @@ func NewAnswererUnit(
 		name:           name,
 		config:         config,
 		llmClient:      llmClient,
-		promptTemplate: tmpl,
+		promptRenderer: renderer,
 		tracer:         otel.Tracer("answerer-unit"),
 	}, nil
 }
@@ -188,10 +194,10 @@ func (au *AnswererUnit) Name() string { return au.name }
 // to prevent injection attacks while enabling flexible prompt customization.
 //
 // Errors:
-//   - ErrQuestionMissing: Question not found in state
+//   - *domain.MissingStateError: Question not found in state
 //   - ErrQuestionEmpty: Empty question string provided
 //   - ErrTemplateExecution: Template parsing or execution failure
-//   - ErrLLMCallFailed: LLM service error with answer index context
+//   - *domain.LLMCallError: LLM service error with answer index context
 //   - Context cancellation or timeout errors
 //
 // The function is safe for concurrent execution and does not modify input state.
@@ -211,9 +217,8 @@ func (au *AnswererUnit) Execute(ctx context.Context, state domain.State) (domain
 
 	start := time.Now()
 
-	question, ok := domain.Get(state, domain.KeyQuestion)
-	if !ok {
-		err := ErrQuestionMissing
+	question, err := domain.Require(state, domain.KeyQuestion, au.name)
+	if err != nil {
 		span.RecordError(err)
 		return state, err
 	}
@@ -228,34 +233,52 @@ func (au *AnswererUnit) Execute(ctx context.Context, state domain.State) (domain
 	ctx, cancel := context.WithTimeout(ctx, au.config.Timeout)
 	defer cancel()
 
-	// Execute Go template with safe parameter injection.
-	// Template is pre-parsed during unit creation for performance.
-	var promptBuf bytes.Buffer
-	if err := au.promptTemplate.Execute(&promptBuf, struct{ Question string }{Question: question}); err != nil {
+	// Render the prompt with safe parameter injection.
+	// Template is pre-compiled during unit creation for performance.
+	prompt, err := au.promptRenderer.Render(struct{ Question string }{Question: question})
+	if err != nil {
 		err := fmt.Errorf("%w: %v", ErrTemplateExecution, err)
 		span.RecordError(err)
 		return state, err
 	}
-	prompt := promptBuf.String()
 
 	options := map[string]any{
 		"temperature": au.config.Temperature,
 		"max_tokens":  au.config.MaxTokens,
 	}
+	model := au.llmClient.GetModel()
+	if override, ok := domain.ModelOverride(state, au.name); ok {
+		options["model"] = override
+		model = override
+	}
 
 	answers := make([]domain.Answer, au.config.NumAnswers)
+	var (
+		mu                    sync.Mutex // Protects the usage totals from concurrent writes
+		tokensUsed, callsMade int
+	)
 	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(au.config.MaxConcurrency)
 
 	for i := 0; i < au.config.NumAnswers; i++ {
 		g.Go(func() error {
-			response, err := au.llmClient.Complete(ctx, prompt, options)
+			response, tokensIn, tokensOut, calls, err := completeMetered(ctx, au.llmClient, prompt, options)
 			if err != nil {
-				return fmt.Errorf("%w for answer %d: %v", ErrLLMCallFailed, i+1, err)
+				return domain.NewLLMCallError(au.name, fmt.Sprintf("for answer %d", i+1), err)
 			}
+			mu.Lock()
+			tokensUsed += tokensIn + tokensOut
+			callsMade += calls
+			mu.Unlock()
 			answers[i] = domain.Answer{
 				ID:      fmt.Sprintf("%s_answer_%d", au.name, i+1),
 				Content: response,
+				// Record provenance so the winning generator can be
+				// identified from the verdict.
+				Metadata: map[string]string{
+					domain.AnswerMetadataGenerator: au.name,
+					domain.AnswerMetadataModel:     model,
+				},
 			}
 			return nil
 		})
@@ -274,7 +297,8 @@ func (au *AnswererUnit) Execute(ctx context.Context, state domain.State) (domain
 		attribute.Int("eval.question_length", len(question)),
 	)
 
-	return domain.With(state, domain.KeyAnswers, answers), nil
+	state = domain.With(state, domain.KeyAnswers, answers)
+	return chargeBudget(state, tokensUsed, callsMade), nil
 }
 
 // Validate verifies the unit is properly configured and ready for execution.
@@ -293,7 +317,7 @@ func (au *AnswererUnit) Validate() error {
 		return fmt.Errorf("LLM client is not configured")
 	}
 	if err := answererValidator.Struct(au.config); err != nil {
-		return fmt.Errorf("configuration validation failed: %w", err)
+		return domain.NewConfigValidationError(au.name, fieldValidationError(err))
 	}
 	if model := au.llmClient.GetModel(); model == "" {
 		return fmt.Errorf("LLM client model is not configured")
@@ -334,10 +358,10 @@ func (au *AnswererUnit) UnmarshalParameters(params yaml.Node) (*AnswererUnit, er
 	}
 
 	if err := answererValidator.Struct(config); err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrConfigValidation, err)
+		return nil, domain.NewConfigValidationError(au.name, fieldValidationError(err))
 	}
 
-	tmpl, err := template.New("prompt").Funcs(GetTemplateFuncMap()).Parse(config.Prompt)
+	renderer, err := newPromptRenderer(config.TemplateEngine, "prompt", config.Prompt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse prompt template: %w", err)
 	}
@@ -346,7 +370,7 @@ func (au *AnswererUnit) UnmarshalParameters(params yaml.Node) (*AnswererUnit, er
 		name:           au.name,
 		config:         config,
 		llmClient:      au.llmClient,
-		promptTemplate: tmpl,
+		promptRenderer: renderer,
 		tracer:         otel.Tracer("answerer-unit"),
 	}, nil
 }
@@ -384,7 +408,7 @@ func NewAnswererFromConfig(id string, config map[string]any, llm ports.LLMClient
 	}
 
 	if err := answererValidator.Struct(cfg); err != nil {
-		return nil, fmt.Errorf("validate config: %w", err)
+		return nil, fmt.Errorf("validate config: %w", fieldValidationError(err))
 	}
 
 	return NewAnswererUnit(id, llm, cfg)
@@ -49,6 +49,10 @@ func TestAnswererUnit_Execute(t *testing.T) {
 					assert.NotEmpty(t, answer.ID, "Answer %d should have non-empty ID", i+1)
 					assert.NotEmpty(t, answer.Content, "Answer %d should have non-empty content", i+1)
 					assert.Contains(t, answer.ID, "test_answerer_answer_", "Answer ID should follow expected pattern")
+					assert.Equal(t, map[string]string{
+						domain.AnswerMetadataGenerator: "test_answerer",
+						domain.AnswerMetadataModel:     "test-model",
+					}, answer.Metadata, "Answer %d should record its generator", i+1)
 				}
 			},
 		},
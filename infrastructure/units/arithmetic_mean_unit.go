@@ -4,7 +4,6 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
-	"math"
 	"math/big"
 	"time"
 
@@ -60,9 +59,10 @@ type ArithmeticMeanUnit struct {
 type ArithmeticMeanConfig struct {
 	// TieBreaker defines the strategy for resolving equal highest scores.
 	// "first": Select first candidate (deterministic, reproducible)
+	// "lowest_id": Select the candidate with the smallest answer ID (order-independent)
 	// "random": Cryptographically secure random selection (unbiased)
 	// "error": Fail with explicit error (strict evaluation requirements)
-	TieBreaker TieBreaker `yaml:"tie_breaker" json:"tie_breaker" validate:"required,oneof=first random error"`
+	TieBreaker TieBreaker `yaml:"tie_breaker" json:"tie_breaker" validate:"required,oneof=first random error lowest_id"`
 
 	// MinScore sets the minimum acceptable aggregate score threshold (0.0-1.0).
 	// Aggregations below this value trigger ErrBelowMinScore for quality enforcement.
@@ -73,6 +73,29 @@ type ArithmeticMeanConfig struct {
 	// true: Mismatch between answers and scores triggers validation error
 	// false: Process available answer-score pairs, ignore unscored candidates
 	RequireAllScores bool `yaml:"require_all_scores" json:"require_all_scores"`
+
+	// ExpectedJudges names the judge units that must have scored every answer
+	// when RequireAllScores is true. When empty, every judge that reported
+	// scores is checked.
+	ExpectedJudges []string `yaml:"expected_judges,omitempty" json:"expected_judges,omitempty" validate:"omitempty,dive,required"`
+
+	// AbstainThreshold makes the unit abstain instead of picking a winner
+	// when the verdict's confidence falls below it. Abstained verdicts have
+	// no WinnerAnswer and require human review. Zero disables abstention.
+	AbstainThreshold float64 `yaml:"abstain_threshold" json:"abstain_threshold" validate:"min=0.0,max=1.0"`
+
+	// MinAnswers is the fewest answers the unit accepts. Execution fails
+	// with ErrTooFewAnswers when fewer are present, rather than producing a
+	// meaningless verdict. Zero disables the check.
+	MinAnswers int `yaml:"min_answers,omitempty" json:"min_answers,omitempty" validate:"min=0,max=10000"`
+	// OutputScale presents the verdict's scores on a consumer-facing scale
+	// in Verdict.Display, leaving AggregateScore and RankedAnswers on the
+	// internal scale. Nil disables it.
+	OutputScale *OutputScaleConfig `yaml:"output_scale,omitempty" json:"output_scale,omitempty" validate:"omitempty"`
+
+	// ReferenceSignal blends a deterministic unit's scores into the judge
+	// scores as a weighted voter. Nil aggregates judge scores only.
+	ReferenceSignal *ReferenceSignalConfig `yaml:"reference_signal,omitempty" json:"reference_signal,omitempty" validate:"omitempty"`
 }
 
 // NewArithmeticMeanUnit creates a new ArithmeticMeanUnit with validated
@@ -91,7 +114,10 @@ func NewArithmeticMeanUnit(name string, config ArithmeticMeanConfig) (*Arithmeti
 	}
 
 	if err := validate.Struct(config); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
+		return nil, domain.NewConfigValidationError(name, fieldValidationError(err))
+	}
+	if err := validateOutputScale(name, config.OutputScale); err != nil {
+		return nil, err
 	}
 
 	return &ArithmeticMeanUnit{
@@ -142,9 +168,15 @@ func (mpu *ArithmeticMeanUnit) Execute(ctx context.Context, state domain.State)
 
 	start := time.Now()
 
-	answers, ok := domain.Get(state, domain.KeyAnswers)
-	if !ok {
-		err := fmt.Errorf("answers not found in state")
+	// A judge configured to tolerate empty items already recorded the
+	// outcome; there is nothing to aggregate.
+	if hasNoAnswersVerdict(state) && hasNoAnswers(state) {
+		span.SetAttributes(attribute.Bool("eval.no_answers", true))
+		return state, nil
+	}
+
+	answers, err := domain.Require(state, domain.KeyAnswers, mpu.name)
+	if err != nil {
 		span.RecordError(err)
 		return state, err
 	}
@@ -155,13 +187,37 @@ func (mpu *ArithmeticMeanUnit) Execute(ctx context.Context, state domain.State)
 		return state, err
 	}
 
-	judgeSummaries, ok := domain.Get(state, domain.KeyJudgeScores)
-	if !ok {
-		err := fmt.Errorf("judge scores not found in state")
+	if err := checkMinAnswers(mpu.name, len(answers), mpu.config.MinAnswers); err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	judgeSummaries, err := domain.Require(state, domain.KeyJudgeScores, mpu.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	judgeSummaries, err = applyReferenceSignal(state, judgeSummaries, mpu.config.ReferenceSignal)
+	if err != nil {
+		err = fmt.Errorf("unit %s: %w", mpu.name, err)
 		span.RecordError(err)
 		return state, err
 	}
 
+	if err := checkFiniteSummaries(judgeSummaries); err != nil {
+		err = fmt.Errorf("unit %s: %w", mpu.name, err)
+		span.RecordError(err)
+		return state, err
+	}
+
+	if mpu.config.RequireAllScores {
+		if err := checkJudgeCoverage(state, mpu.config.ExpectedJudges, answers); err != nil {
+			span.RecordError(err)
+			return state, err
+		}
+	}
+
 	numAnswers := len(answers)
 	numScores := len(judgeSummaries)
 
@@ -185,6 +241,10 @@ func (mpu *ArithmeticMeanUnit) Execute(ctx context.Context, state domain.State)
 	scores := make([]float64, numAnswers)
 	validAnswers := make([]domain.Answer, numAnswers)
 	for i := 0; i < numAnswers; i++ {
+		if err := ctx.Err(); err != nil {
+			span.RecordError(err)
+			return state, err
+		}
 		scores[i] = judgeSummaries[i].Score
 		validAnswers[i] = answers[i]
 	}
@@ -196,12 +256,28 @@ func (mpu *ArithmeticMeanUnit) Execute(ctx context.Context, state domain.State)
 		return state, err
 	}
 
+	rank := func(score float64) float64 { return score }
 	verdict := domain.Verdict{
+		SchemaVersion:  domain.VerdictSchemaVersion,
 		ID:             fmt.Sprintf("%s_verdict", mpu.name),
 		WinnerAnswer:   &winner,
 		AggregateScore: aggregateScore,
+		// Surface content-identical candidates so callers know the choice
+		// between them was arbitrary rather than score-driven.
+		DuplicateAnswerIDs: duplicateAnswerIDs(winner, validAnswers),
+		RankedAnswers:      rankAnswers(validAnswers, scores, winner, mpu.config.TieBreaker, rank),
+		// Participating units are stamped by the graph executor.
+		Provenance: &domain.Provenance{
+			AggregationMethod: "arithmetic_mean",
+			ReferenceSignal:   mpu.config.ReferenceSignal.provenance(),
+		},
+		CreatedAt: time.Now(),
 		// TODO: Add trace and budget information when available.
 	}
+	scale := latestJudgeScale(state)
+	verdict.Confidence = verdictConfidence(verdict.RankedAnswers, judgeSummaries[:numAnswers], rank, scale)
+	applyAbstention(&verdict, mpu.config.AbstainThreshold)
+	applyOutputScale(&verdict, mpu.config.OutputScale, scale)
 
 	latency := time.Since(start)
 	span.SetAttributes(
@@ -210,6 +286,9 @@ func (mpu *ArithmeticMeanUnit) Execute(ctx context.Context, state domain.State)
 		attribute.Int("eval.judge_scores_count", len(judgeSummaries)),
 		attribute.Float64("eval.aggregate_score", aggregateScore),
 		attribute.String("eval.winner_id", winner.ID),
+		attribute.Int("eval.duplicate_answers_count", len(verdict.DuplicateAnswerIDs)),
+		attribute.Float64("eval.confidence", verdict.Confidence),
+		attribute.String("eval.status", string(verdict.Status)),
 		attribute.Bool("no_llm_cost", true), // Deterministic units have no LLM cost
 	)
 
@@ -258,8 +337,8 @@ func (mpu *ArithmeticMeanUnit) Aggregate(
 
 	for i, score := range scores {
 		// Validate mathematical correctness of IEEE 754 floating-point values.
-		if math.IsNaN(score) || math.IsInf(score, 0) {
-			return domain.Answer{}, 0, fmt.Errorf("invalid score at index %d: %f", i, score)
+		if err := checkFinite("score", score); err != nil {
+			return domain.Answer{}, 0, fmt.Errorf("invalid score at index %d: %w", i, err)
 		}
 
 		sum += score
@@ -292,6 +371,9 @@ func (mpu *ArithmeticMeanUnit) Aggregate(
 		case TieError:
 			// Strict: fail on ambiguous results for critical evaluations
 			return domain.Answer{}, 0, fmt.Errorf("%w: %d answers with score %.3f", ErrTie, len(tieIndices), maxScore)
+		case TieLowestID:
+			// Stable: select by answer ID so input order cannot change the winner
+			winnerIdx = lowestIDIndex(candidates, tieIndices)
 		case TieRandom:
 			// Unbiased: cryptographically secure random selection
 			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(tieIndices))))
@@ -313,7 +395,10 @@ func (mpu *ArithmeticMeanUnit) Aggregate(
 // the specific validation failure. Safe for concurrent use.
 func (mpu *ArithmeticMeanUnit) Validate() error {
 	if err := validate.Struct(mpu.config); err != nil {
-		return fmt.Errorf("configuration validation failed: %w", err)
+		return domain.NewConfigValidationError(mpu.name, fieldValidationError(err))
+	}
+	if err := validateOutputScale(mpu.name, mpu.config.OutputScale); err != nil {
+		return err
 	}
 
 	return nil
@@ -340,6 +425,9 @@ func (mpu *ArithmeticMeanUnit) UnmarshalParameters(params yaml.Node) error {
 
 	// Validate the decoded configuration.
 	if err := validate.Struct(config); err != nil {
+		return fmt.Errorf("parameter validation failed: %w", fieldValidationError(err))
+	}
+	if err := config.OutputScale.check(); err != nil {
 		return fmt.Errorf("parameter validation failed: %w", err)
 	}
 
@@ -26,6 +26,22 @@ func TestArithmeticMeanUnit_Aggregate(t *testing.T) {
 		expectedScore    float64
 		expectedError    string
 	}{
+		{
+			name: "lowest_id tie breaker selects smallest tied ID regardless of order",
+			config: ArithmeticMeanConfig{
+				TieBreaker:       TieLowestID,
+				MinScore:         0.0,
+				RequireAllScores: true,
+			},
+			scores: []float64{0.8, 0.8, 0.5},
+			candidates: []domain.Answer{
+				{ID: "z", Content: "First answer"},
+				{ID: "b", Content: "Second answer"},
+				{ID: "a", Content: "Third answer"},
+			},
+			expectedWinnerID: "b",
+			expectedScore:    0.7,
+		},
 		{
 			name: "calculates arithmetic mean with highest score winner",
 			config: ArithmeticMeanConfig{
@@ -169,6 +185,76 @@ func TestArithmeticMeanUnit_Execute(t *testing.T) {
 		expectedError  string
 		validateResult func(t *testing.T, state domain.State)
 	}{
+		{
+			name: "ranks all answers best-first with winner on top",
+			config: ArithmeticMeanConfig{
+				TieBreaker:       TieFirst,
+				MinScore:         0.0,
+				RequireAllScores: true,
+			},
+			setupState: func() domain.State {
+				state := domain.NewState()
+				answers := []domain.Answer{
+					{ID: "answer1", Content: "First answer"},
+					{ID: "answer2", Content: "Second answer"},
+					{ID: "answer3", Content: "Third answer"},
+					{ID: "answer4", Content: "Fourth answer"},
+				}
+				judgeSummaries := []domain.JudgeSummary{
+					{Score: 0.5, Reasoning: "r1", Confidence: 0.9},
+					{Score: 0.9, Reasoning: "r2", Confidence: 0.9},
+					{Score: 0.7, Reasoning: "r3", Confidence: 0.9},
+					{Score: 0.9, Reasoning: "r4", Confidence: 0.9},
+				}
+				state = domain.With(state, domain.KeyAnswers, answers)
+				state = domain.With(state, domain.KeyJudgeScores, judgeSummaries)
+				return state
+			},
+			validateResult: func(t *testing.T, state domain.State) {
+				verdict, ok := domain.Get(state, domain.KeyVerdict)
+				require.True(t, ok, "Verdict should be present in state")
+				require.Len(t, verdict.RankedAnswers, 4)
+
+				var ids []string
+				for _, ranked := range verdict.RankedAnswers {
+					ids = append(ids, ranked.Answer.ID)
+				}
+				assert.Equal(t, []string{"answer2", "answer4", "answer3", "answer1"}, ids)
+				assert.Equal(t, *verdict.WinnerAnswer, verdict.RankedAnswers[0].Answer)
+			},
+		},
+		{
+			name: "reports content-identical candidates tied with the winner",
+			config: ArithmeticMeanConfig{
+				TieBreaker:       TieLowestID,
+				MinScore:         0.0,
+				RequireAllScores: true,
+			},
+			setupState: func() domain.State {
+				state := domain.NewState()
+				answers := []domain.Answer{
+					{ID: "answer3", Content: "Paris"},
+					{ID: "answer1", Content: " Paris "},
+					{ID: "answer2", Content: "London"},
+				}
+				judgeSummaries := []domain.JudgeSummary{
+					{Score: 0.9, Reasoning: "Correct", Confidence: 0.9},
+					{Score: 0.9, Reasoning: "Correct", Confidence: 0.9},
+					{Score: 0.9, Reasoning: "Incorrect", Confidence: 0.9},
+				}
+				state = domain.With(state, domain.KeyAnswers, answers)
+				state = domain.With(state, domain.KeyJudgeScores, judgeSummaries)
+				return state
+			},
+			validateResult: func(t *testing.T, state domain.State) {
+				verdict, ok := domain.Get(state, domain.KeyVerdict)
+				require.True(t, ok, "Verdict should be present in state")
+				require.NotNil(t, verdict, "Verdict should not be nil")
+
+				assert.Equal(t, "answer1", verdict.WinnerAnswer.ID)
+				assert.Equal(t, []string{"answer1", "answer3"}, verdict.DuplicateAnswerIDs)
+			},
+		},
 		{
 			name: "successful execution with valid data",
 			config: ArithmeticMeanConfig{
@@ -234,7 +320,7 @@ func TestArithmeticMeanUnit_Execute(t *testing.T) {
 				// Missing judge scores
 				return state
 			},
-			expectedError: "judge scores not found in state",
+			expectedError: "judge_scores not found in state",
 		},
 		{
 			name: "handles length mismatch when RequireAllScores is false",
@@ -0,0 +1,231 @@
+package units
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"os"
+	"slices"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// CalibrationSchemaVersion is the version of the persisted calibration
+// format written by WriteCalibration.
+const CalibrationSchemaVersion = 1
+
+// ErrInsufficientCalibrationData is returned when there are no calibration
+// items to fit corrections from.
+var ErrInsufficientCalibrationData = errors.New("insufficient calibration data")
+
+// LinearCalibration is a per-judge correction applied as
+// Scale*score + Offset. The zero value is not an identity; use
+// IdentityCalibration for a correction that leaves scores unchanged.
+type LinearCalibration struct {
+	// Scale multiplies the raw score.
+	Scale float64 `yaml:"scale" json:"scale" validate:"gt=0"`
+
+	// Offset is added after scaling.
+	Offset float64 `yaml:"offset" json:"offset"`
+
+	// Samples is the number of calibration items the correction was fitted
+	// from. It is informational and not used when applying the correction.
+	Samples int `yaml:"samples,omitempty" json:"samples,omitempty" validate:"min=0"`
+
+	// Confidence, when set, also rescales the confidence the judge reports
+	// so that it is comparable with other judges' confidence. A judge whose
+	// scores need no correction uses a scale of 1 with only this set.
+	Confidence *ConfidenceCalibration `yaml:"confidence,omitempty" json:"confidence,omitempty" validate:"omitempty"`
+}
+
+// IdentityCalibration leaves scores unchanged.
+var IdentityCalibration = LinearCalibration{Scale: 1}
+
+// Apply returns the corrected score.
+func (lc LinearCalibration) Apply(score float64) float64 {
+	return lc.Scale*score + lc.Offset
+}
+
+// ConfidenceCalibration maps the range of confidence a judge actually
+// reports, [Min, Max], linearly onto [0, 1]. Judge models use incompatible
+// internal scales, one never exceeding 0.8 and another clustering above
+// 0.95, so their raw confidences cannot be compared or used as weights
+// until each is rescaled by its own range.
+type ConfidenceCalibration struct {
+	// Min is the confidence the judge reports when least certain. It and
+	// anything below it map to 0.
+	Min float64 `yaml:"min" json:"min" validate:"min=0,max=1"`
+
+	// Max is the confidence the judge reports when most certain. It and
+	// anything above it map to 1.
+	Max float64 `yaml:"max" json:"max" validate:"min=0,max=1,gtfield=Min"`
+}
+
+// Apply returns the rescaled confidence, clamped to [0, 1].
+func (cc ConfidenceCalibration) Apply(confidence float64) float64 {
+	return min(max((confidence-cc.Min)/(cc.Max-cc.Min), 0), 1)
+}
+
+// CalibrationItem pairs a judge's score for a calibration answer with the
+// ground-truth score that answer should have received.
+type CalibrationItem struct {
+	// JudgeID identifies the judge that produced Score.
+	JudgeID string `json:"judge_id"`
+
+	// Score is the judge's raw score.
+	Score float64 `json:"score"`
+
+	// Truth is the known correct score on the same scale.
+	Truth float64 `json:"truth"`
+
+	// Confidence is the confidence the judge reported with Score, if
+	// recorded. Items with confidence let FitCalibration fit the judge's
+	// ConfidenceCalibration as well.
+	Confidence *float64 `json:"confidence,omitempty"`
+}
+
+// FitCalibration fits a LinearCalibration for every judge in items by
+// ordinary least squares, regressing ground truth on the judge's scores.
+// A judge whose scores are all equal cannot be rescaled, so only an offset
+// is fitted for it. Fits that would invert or flatten the judge's ordering
+// (a non-positive slope) also fall back to an offset-only correction, so
+// calibration never reorders a judge's answers.
+//
+// The confidence range of a judge is fitted as the lowest and highest
+// confidence among its items that record one. A judge with fewer than two
+// distinct confidences gets no confidence calibration.
+func FitCalibration(items []CalibrationItem) (map[string]LinearCalibration, error) {
+	byJudge := make(map[string][]CalibrationItem)
+	for _, item := range items {
+		if item.JudgeID == "" {
+			return nil, fmt.Errorf("calibration item has no judge ID")
+		}
+		if math.IsNaN(item.Score) || math.IsNaN(item.Truth) || math.IsInf(item.Score, 0) || math.IsInf(item.Truth, 0) {
+			return nil, fmt.Errorf("judge %q: calibration scores must be finite", item.JudgeID)
+		}
+		if c := item.Confidence; c != nil && !(*c >= 0 && *c <= 1) {
+			return nil, fmt.Errorf("judge %q: calibration confidence must be between 0 and 1", item.JudgeID)
+		}
+		byJudge[item.JudgeID] = append(byJudge[item.JudgeID], item)
+	}
+	if len(byJudge) == 0 {
+		return nil, ErrInsufficientCalibrationData
+	}
+
+	fits := make(map[string]LinearCalibration, len(byJudge))
+	for judgeID, judgeItems := range byJudge {
+		fits[judgeID] = fitLinear(judgeItems)
+	}
+	return fits, nil
+}
+
+// fitLinear fits truth ≈ scale*score + offset for a non-empty item set.
+func fitLinear(items []CalibrationItem) LinearCalibration {
+	n := float64(len(items))
+	var meanScore, meanTruth float64
+	for _, item := range items {
+		meanScore += item.Score
+		meanTruth += item.Truth
+	}
+	meanScore /= n
+	meanTruth /= n
+
+	var covariance, variance float64
+	for _, item := range items {
+		ds := item.Score - meanScore
+		covariance += ds * (item.Truth - meanTruth)
+		variance += ds * ds
+	}
+
+	scale := 1.0
+	if variance > 0 && covariance > 0 {
+		scale = covariance / variance
+	}
+	return LinearCalibration{
+		Scale:      scale,
+		Offset:     meanTruth - scale*meanScore,
+		Samples:    len(items),
+		Confidence: fitConfidence(items),
+	}
+}
+
+// fitConfidence returns the range of the confidences recorded in items, or
+// nil if they do not span a range.
+func fitConfidence(items []CalibrationItem) *ConfidenceCalibration {
+	fit := ConfidenceCalibration{Min: math.Inf(1), Max: math.Inf(-1)}
+	for _, item := range items {
+		if item.Confidence != nil {
+			fit.Min = min(fit.Min, *item.Confidence)
+			fit.Max = max(fit.Max, *item.Confidence)
+		}
+	}
+	if !(fit.Max > fit.Min) {
+		return nil
+	}
+	return &fit
+}
+
+// calibrationFile is the persisted form of fitted calibration parameters.
+type calibrationFile struct {
+	SchemaVersion int                          `json:"schema_version"`
+	Judges        map[string]LinearCalibration `json:"judges"`
+}
+
+// WriteCalibration persists fitted parameters as indented JSON so they can
+// be reviewed, committed, and reloaded with ReadCalibration.
+func WriteCalibration(w io.Writer, params map[string]LinearCalibration) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(calibrationFile{SchemaVersion: CalibrationSchemaVersion, Judges: params}); err != nil {
+		return fmt.Errorf("encode calibration: %w", err)
+	}
+	return nil
+}
+
+// ReadCalibration loads parameters written by WriteCalibration and
+// validates every correction.
+func ReadCalibration(r io.Reader) (map[string]LinearCalibration, error) {
+	var file calibrationFile
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("decode calibration: %w", err)
+	}
+	if file.SchemaVersion != CalibrationSchemaVersion {
+		return nil, fmt.Errorf("calibration schema version %d: %w", file.SchemaVersion, domain.ErrUnsupportedSchemaVersion)
+	}
+
+	// Validate in a stable order so the reported judge is deterministic.
+	for _, judgeID := range slices.Sorted(maps.Keys(file.Judges)) {
+		if err := validate.Struct(file.Judges[judgeID]); err != nil {
+			return nil, fmt.Errorf("judge %q: %w", judgeID, fieldValidationError(err))
+		}
+	}
+	return file.Judges, nil
+}
+
+// LoadCalibrationFile reads calibration parameters from path.
+func LoadCalibrationFile(path string) (map[string]LinearCalibration, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open calibration file: %w", err)
+	}
+	defer f.Close()
+	return ReadCalibration(f)
+}
+
+// SaveCalibrationFile writes calibration parameters to path.
+func SaveCalibrationFile(path string, params map[string]LinearCalibration) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create calibration file: %w", err)
+	}
+	if err := WriteCalibration(f, params); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
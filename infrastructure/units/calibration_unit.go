@@ -0,0 +1,212 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+var _ ports.Unit = (*CalibrationUnit)(nil)
+
+// CalibrationUnit corrects systematic judge bias by applying a per-judge
+// linear correction, fitted offline with FitCalibration, to each judge's
+// scores. Place it between judges and an aggregator so that a conservative
+// judge that consistently under-scores, or a generous one that
+// over-scores, is mapped back onto the shared grading curve before votes
+// are combined.
+//
+// Corrected scores are clamped to the judge's declared scale. Judges
+// without parameters keep their raw scores unless RequireAllJudges is set.
+// Judges whose parameters include a ConfidenceCalibration also have their
+// confidence rescaled, so that confidence-weighted aggregation and verdict
+// confidence compare judges on one scale; the raw confidence is kept in
+// each summary's RawConfidence and so appears in the verdict trace.
+//
+// The unit is deterministic, stateless, and thread-safe.
+type CalibrationUnit struct {
+	// name is the unique identifier for this unit instance.
+	name string
+	// config contains the validated configuration parameters.
+	config CalibrationConfig
+	// params holds the corrections by judge ID, merged from the
+	// parameters file and inline Parameters.
+	params map[string]LinearCalibration
+	// tracer is the OpenTelemetry tracer for observability.
+	tracer trace.Tracer
+}
+
+// CalibrationConfig defines the configuration parameters for the
+// CalibrationUnit.
+type CalibrationConfig struct {
+	// Parameters maps judge IDs to their fitted correction.
+	Parameters map[string]LinearCalibration `yaml:"parameters" json:"parameters" validate:"omitempty,dive"`
+
+	// ParametersFile is the path of a file written by SaveCalibrationFile.
+	// Inline Parameters take precedence over file entries for the same judge.
+	ParametersFile string `yaml:"parameters_file" json:"parameters_file"`
+
+	// RequireAllJudges fails execution when a judge with scores has no
+	// calibration parameters instead of passing its scores through.
+	RequireAllJudges bool `yaml:"require_all_judges" json:"require_all_judges"`
+}
+
+// NewCalibrationUnit creates a new CalibrationUnit with the specified
+// configuration, loading ParametersFile if set. Returns an error if
+// validation fails, the file cannot be read, or no parameters are
+// configured.
+func NewCalibrationUnit(name string, config CalibrationConfig) (*CalibrationUnit, error) {
+	if name == "" {
+		return nil, ErrEmptyUnitName
+	}
+
+	if err := validate.Struct(config); err != nil {
+		return nil, domain.NewConfigValidationError(name, fieldValidationError(err))
+	}
+
+	params := make(map[string]LinearCalibration)
+	if config.ParametersFile != "" {
+		loaded, err := LoadCalibrationFile(config.ParametersFile)
+		if err != nil {
+			return nil, fmt.Errorf("unit %s: %w", name, err)
+		}
+		maps.Copy(params, loaded)
+	}
+	maps.Copy(params, config.Parameters)
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("unit %s: %w: no calibration parameters configured", name, ErrInsufficientCalibrationData)
+	}
+
+	return &CalibrationUnit{
+		name:   name,
+		config: config,
+		params: params,
+		tracer: otel.Tracer("calibration-unit"),
+	}, nil
+}
+
+// Name returns the unique identifier for this unit instance.
+func (cu *CalibrationUnit) Name() string { return cu.name }
+
+// Execute applies each judge's correction to its scores in
+// domain.KeyJudgeScoresByJudge and to the latest judge's scores in
+// domain.KeyJudgeScores. Confidence is rescaled for judges with a
+// confidence calibration and otherwise left unchanged, as is reasoning.
+//
+// Returns ErrNoScores if no judge has recorded scores, or an error if
+// RequireAllJudges is set and a judge has no parameters.
+func (cu *CalibrationUnit) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	_, span := cu.tracer.Start(ctx, "CalibrationUnit.Execute",
+		trace.WithAttributes(
+			attribute.String("unit.type", "calibration"),
+			attribute.String("unit.id", cu.name),
+			attribute.Int("config.calibrated_judges", len(cu.params)),
+			attribute.Bool("config.require_all_judges", cu.config.RequireAllJudges),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+
+	byJudge, ok := domain.Get(state, domain.KeyJudgeScoresByJudge)
+	if !ok || len(byJudge) == 0 {
+		err := fmt.Errorf("unit %s: %w", cu.name, ErrNoScores)
+		span.RecordError(err)
+		return state, err
+	}
+
+	// Visit judges in a stable order so errors and attributes are deterministic.
+	judges := slices.Sorted(maps.Keys(byJudge))
+	calibrated, confidenceCalibrated := 0, 0
+	for _, judgeID := range judges {
+		if err := ctx.Err(); err != nil {
+			span.RecordError(err)
+			return state, err
+		}
+
+		correction, ok := cu.params[judgeID]
+		if !ok {
+			if cu.config.RequireAllJudges {
+				err := fmt.Errorf("unit %s: judge %q has no calibration parameters", cu.name, judgeID)
+				span.RecordError(err)
+				return state, err
+			}
+			continue
+		}
+
+		scale, _ := domain.JudgeScoreScale(state, judgeID)
+		summaries := byJudge[judgeID]
+		for i := range summaries {
+			summaries[i].Score = min(max(correction.Apply(summaries[i].Score), scale.Min), scale.Max)
+			if correction.Confidence != nil {
+				if summaries[i].RawConfidence == nil {
+					raw := summaries[i].Confidence
+					summaries[i].RawConfidence = &raw
+				}
+				summaries[i].Confidence = correction.Confidence.Apply(summaries[i].Confidence)
+			}
+		}
+		byJudge[judgeID] = summaries
+		calibrated++
+		if correction.Confidence != nil {
+			confidenceCalibrated++
+		}
+	}
+	newState := domain.With(state, domain.KeyJudgeScoresByJudge, byJudge)
+
+	if latest, ok := domain.Get(state, domain.KeyLatestJudge); ok {
+		if summaries, ok := byJudge[latest]; ok {
+			newState = domain.With(newState, domain.KeyJudgeScores, summaries)
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int64("eval.latency_ms", time.Since(start).Milliseconds()),
+		attribute.Int("eval.judges_count", len(judges)),
+		attribute.Int("eval.calibrated_judges", calibrated),
+		attribute.Int("eval.confidence_calibrated_judges", confidenceCalibrated),
+		attribute.Bool("no_llm_cost", true),
+	)
+
+	return newState, nil
+}
+
+// Validate checks if the unit is properly configured and ready for execution.
+func (cu *CalibrationUnit) Validate() error {
+	if err := validate.Struct(cu.config); err != nil {
+		return domain.NewConfigValidationError(cu.name, fieldValidationError(err))
+	}
+	if len(cu.params) == 0 {
+		return fmt.Errorf("unit %s: %w: no calibration parameters configured", cu.name, ErrInsufficientCalibrationData)
+	}
+	return nil
+}
+
+// NewCalibrationFromConfig creates a CalibrationUnit from a configuration
+// map. This is the boundary adapter for YAML/JSON configuration.
+// Calibration doesn't require an LLM client.
+func NewCalibrationFromConfig(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error) {
+	// llm is ignored - calibration is deterministic.
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	var cfg CalibrationConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+
+	return NewCalibrationUnit(id, cfg)
+}
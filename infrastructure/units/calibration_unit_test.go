@@ -0,0 +1,248 @@
+package units
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// TestFitCalibration tests least-squares fitting, including the offset-only
+// fallbacks for constant and inversely correlated judges.
+func TestFitCalibration(t *testing.T) {
+	items := []CalibrationItem{
+		// A conservative judge: truth = 2*score - 0.2.
+		{JudgeID: "conservative", Score: 0.2, Truth: 0.2},
+		{JudgeID: "conservative", Score: 0.4, Truth: 0.6},
+		{JudgeID: "conservative", Score: 0.5, Truth: 0.8},
+		// A judge that always gives the same score.
+		{JudgeID: "flat", Score: 0.7, Truth: 0.2},
+		{JudgeID: "flat", Score: 0.7, Truth: 0.6},
+		// A judge whose scores run against the truth.
+		{JudgeID: "inverted", Score: 0.9, Truth: 0.1},
+		{JudgeID: "inverted", Score: 0.1, Truth: 0.9},
+	}
+
+	fits, err := FitCalibration(items)
+	require.NoError(t, err)
+	require.Len(t, fits, 3)
+
+	assert.InDelta(t, 2.0, fits["conservative"].Scale, 1e-9)
+	assert.InDelta(t, -0.2, fits["conservative"].Offset, 1e-9)
+	assert.Equal(t, 3, fits["conservative"].Samples)
+
+	assert.Equal(t, 1.0, fits["flat"].Scale)
+	assert.InDelta(t, -0.3, fits["flat"].Offset, 1e-9)
+
+	assert.Equal(t, 1.0, fits["inverted"].Scale, "calibration must never reverse a judge's ordering")
+	assert.InDelta(t, 0.0, fits["inverted"].Offset, 1e-9)
+
+	_, err = FitCalibration(nil)
+	require.ErrorIs(t, err, ErrInsufficientCalibrationData)
+	_, err = FitCalibration([]CalibrationItem{{Score: 1, Truth: 1}})
+	require.Error(t, err)
+	_, err = FitCalibration([]CalibrationItem{{JudgeID: "j", Score: math.NaN(), Truth: 1}})
+	require.Error(t, err)
+}
+
+// TestCalibration_Persistence tests that fitted parameters round-trip
+// through a file and that invalid files are rejected.
+func TestCalibration_Persistence(t *testing.T) {
+	params := map[string]LinearCalibration{
+		"judge_a": {Scale: 1.5, Offset: -0.1, Samples: 20, Confidence: &ConfidenceCalibration{Min: 0.5, Max: 0.9}},
+		"judge_b": IdentityCalibration,
+	}
+	path := filepath.Join(t.TempDir(), "calibration.json")
+	require.NoError(t, SaveCalibrationFile(path, params))
+
+	loaded, err := LoadCalibrationFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, params, loaded)
+
+	tests := []struct {
+		name  string
+		input string
+		errIs error
+	}{
+		{name: "unsupported version", input: `{"schema_version": 2, "judges": {}}`, errIs: domain.ErrUnsupportedSchemaVersion},
+		{name: "non-positive scale", input: `{"schema_version": 1, "judges": {"j": {"scale": 0, "offset": 0}}}`},
+		{name: "unknown field", input: `{"schema_version": 1, "judges": {}, "extra": true}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadCalibration(bytes.NewBufferString(tt.input))
+			require.Error(t, err)
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+		})
+	}
+}
+
+// TestCalibrationUnit_Execute tests that fitted corrections reduce the
+// error of systematically biased judges, clamp to the judge's scale, and
+// leave uncalibrated judges unchanged.
+func TestCalibrationUnit_Execute(t *testing.T) {
+	truth := []float64{0.9, 0.5, 0.2}
+	// The conservative judge under-scores by 40%; the generous judge adds 0.2.
+	conservative := []float64{0.54, 0.30, 0.12}
+	generous := []float64{1.0, 0.7, 0.4}
+
+	var items []CalibrationItem
+	for i := range truth {
+		items = append(items,
+			CalibrationItem{JudgeID: "conservative", Score: conservative[i], Truth: truth[i]},
+			CalibrationItem{JudgeID: "generous", Score: generous[i], Truth: truth[i]},
+		)
+	}
+	params, err := FitCalibration(items)
+	require.NoError(t, err)
+
+	summaries := func(scores []float64) []domain.JudgeSummary {
+		out := make([]domain.JudgeSummary, len(scores))
+		for i, s := range scores {
+			out[i] = domain.JudgeSummary{Score: s, Confidence: 0.8, Reasoning: "r"}
+		}
+		return out
+	}
+	state := domain.WithJudgeScores(domain.NewState(), "conservative", summaries(conservative))
+	state = domain.WithJudgeScores(state, "generous", summaries(generous))
+	state = domain.WithJudgeScores(state, "uncalibrated", summaries([]float64{0.3, 0.3, 0.3}))
+
+	unit, err := NewCalibrationUnit("calibrate", CalibrationConfig{Parameters: params})
+	require.NoError(t, err)
+
+	newState, err := unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+
+	byJudge, ok := domain.Get(newState, domain.KeyJudgeScoresByJudge)
+	require.True(t, ok)
+
+	meanAbsError := func(scores []float64) float64 {
+		total := 0.0
+		for i, s := range scores {
+			total += math.Abs(s - truth[i])
+		}
+		return total / float64(len(scores))
+	}
+	for judge, raw := range map[string][]float64{"conservative": conservative, "generous": generous} {
+		corrected := scoresOf(byJudge[judge])
+		assert.Less(t, meanAbsError(corrected), meanAbsError(raw)/2, "%s error should drop substantially", judge)
+		for _, s := range corrected {
+			assert.GreaterOrEqual(t, s, 0.0)
+			assert.LessOrEqual(t, s, 1.0)
+		}
+		assert.Equal(t, "r", byJudge[judge][0].Reasoning)
+	}
+	assert.Equal(t, []float64{0.3, 0.3, 0.3}, scoresOf(byJudge["uncalibrated"]))
+
+	latest, ok := domain.Get(newState, domain.KeyJudgeScores)
+	require.True(t, ok)
+	assert.Equal(t, scoresOf(byJudge["uncalibrated"]), scoresOf(latest))
+
+	t.Run("require all judges", func(t *testing.T) {
+		strict, err := NewCalibrationUnit("calibrate", CalibrationConfig{Parameters: params, RequireAllJudges: true})
+		require.NoError(t, err)
+		_, err = strict.Execute(context.Background(), state)
+		require.ErrorContains(t, err, `judge "uncalibrated" has no calibration parameters`)
+	})
+
+	t.Run("no scores", func(t *testing.T) {
+		_, err := unit.Execute(context.Background(), domain.NewState())
+		require.ErrorIs(t, err, ErrNoScores)
+	})
+}
+
+// TestCalibrationUnit_Confidence tests that judges with confidence
+// calibration report confidence on a shared scale and keep the raw value.
+func TestCalibrationUnit_Confidence(t *testing.T) {
+	confidence := func(c float64) *float64 { return &c }
+	// The cautious judge never exceeds 0.8; the sure judge never drops
+	// below 0.95. Both express the same spread of certainty.
+	params, err := FitCalibration([]CalibrationItem{
+		{JudgeID: "cautious", Score: 0.2, Truth: 0.2, Confidence: confidence(0.4)},
+		{JudgeID: "cautious", Score: 0.8, Truth: 0.8, Confidence: confidence(0.8)},
+		{JudgeID: "sure", Score: 0.2, Truth: 0.2, Confidence: confidence(0.95)},
+		{JudgeID: "sure", Score: 0.8, Truth: 0.8, Confidence: confidence(0.99)},
+		{JudgeID: "plain", Score: 0.2, Truth: 0.2},
+		{JudgeID: "plain", Score: 0.8, Truth: 0.8},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, params["cautious"].Confidence)
+	assert.Equal(t, ConfidenceCalibration{Min: 0.4, Max: 0.8}, *params["cautious"].Confidence)
+	assert.Nil(t, params["plain"].Confidence)
+
+	state := domain.WithJudgeScores(domain.NewState(), "cautious", []domain.JudgeSummary{{Score: 0.5, Confidence: 0.6}, {Score: 0.5, Confidence: 0.9}})
+	state = domain.WithJudgeScores(state, "sure", []domain.JudgeSummary{{Score: 0.5, Confidence: 0.97}, {Score: 0.5, Confidence: 0.9}})
+	state = domain.WithJudgeScores(state, "plain", []domain.JudgeSummary{{Score: 0.5, Confidence: 0.7}, {Score: 0.5, Confidence: 0.7}})
+
+	unit, err := NewCalibrationUnit("calibrate", CalibrationConfig{Parameters: params})
+	require.NoError(t, err)
+	once, err := unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+	twice, err := unit.Execute(context.Background(), once)
+	require.NoError(t, err)
+
+	byJudge, _ := domain.Get(once, domain.KeyJudgeScoresByJudge)
+	assert.InDelta(t, 0.5, byJudge["cautious"][0].Confidence, 1e-9)
+	assert.InDelta(t, 0.5, byJudge["sure"][0].Confidence, 1e-9)
+	assert.Equal(t, 1.0, byJudge["cautious"][1].Confidence, "confidence above the range is clamped")
+	assert.Equal(t, 0.0, byJudge["sure"][1].Confidence, "confidence below the range is clamped")
+	require.NotNil(t, byJudge["sure"][0].RawConfidence)
+	assert.Equal(t, 0.97, *byJudge["sure"][0].RawConfidence)
+	assert.Equal(t, 0.7, byJudge["plain"][0].Confidence)
+	assert.Nil(t, byJudge["plain"][0].RawConfidence)
+
+	byJudge, _ = domain.Get(twice, domain.KeyJudgeScoresByJudge)
+	assert.Equal(t, 0.97, *byJudge["sure"][0].RawConfidence, "recalibrating keeps the first raw confidence")
+
+	_, err = FitCalibration([]CalibrationItem{{JudgeID: "j", Score: 1, Truth: 1, Confidence: confidence(1.5)}})
+	require.Error(t, err)
+	_, err = NewCalibrationFromConfig("calibrate", map[string]any{
+		"parameters": map[string]any{"j": map[string]any{"scale": 1, "confidence": map[string]any{"min": 0.9, "max": 0.5}}},
+	}, nil)
+	require.Error(t, err)
+}
+
+// TestNewCalibrationFromConfig tests creation from a configuration map,
+// merging a parameters file with inline overrides.
+func TestNewCalibrationFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calibration.json")
+	require.NoError(t, SaveCalibrationFile(path, map[string]LinearCalibration{
+		"judge_a": {Scale: 2, Offset: 0},
+		"judge_b": {Scale: 3, Offset: 0},
+	}))
+
+	unit, err := NewCalibrationFromConfig("calibrate", map[string]any{
+		"parameters_file": path,
+		"parameters": map[string]any{
+			"judge_b": map[string]any{"scale": 0.5, "offset": 0.1},
+		},
+	}, nil)
+	require.NoError(t, err)
+	calibration, ok := unit.(*CalibrationUnit)
+	require.True(t, ok)
+	assert.Equal(t, 2.0, calibration.params["judge_a"].Scale)
+	assert.Equal(t, LinearCalibration{Scale: 0.5, Offset: 0.1}, calibration.params["judge_b"])
+	assert.NoError(t, unit.Validate())
+
+	_, err = NewCalibrationFromConfig("calibrate", map[string]any{}, nil)
+	require.ErrorIs(t, err, ErrInsufficientCalibrationData)
+
+	_, err = NewCalibrationFromConfig("calibrate", map[string]any{"parameters_file": filepath.Join(t.TempDir(), "missing.json")}, nil)
+	require.Error(t, err)
+
+	_, err = NewCalibrationFromConfig("calibrate", map[string]any{
+		"parameters": map[string]any{"judge_a": map[string]any{"scale": -1}},
+	}, nil)
+	require.Error(t, err)
+
+	_, err = NewCalibrationUnit("", CalibrationConfig{Parameters: map[string]LinearCalibration{"j": IdentityCalibration}})
+	require.ErrorIs(t, err, ErrEmptyUnitName)
+}
@@ -0,0 +1,470 @@
+package units
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+var _ ports.Unit = (*DecompositionUnit)(nil)
+
+// Decomposition unit defaults and limits.
+const (
+	// DefaultMaxSubQuestions is the default cap on sub-questions produced by
+	// a split.
+	DefaultMaxSubQuestions = 5
+	// DefaultDecompositionMaxTokens is the default token limit for the
+	// splitting LLM call.
+	DefaultDecompositionMaxTokens = 500
+)
+
+// Split strategies reported in traces, chosen from the configuration.
+const (
+	splitStrategyFixed   = "fixed"
+	splitStrategyPattern = "pattern"
+	splitStrategyLLM     = "llm"
+)
+
+// ErrSubQuestionCount indicates that a question split into more parts
+// than max_sub_questions allows, or into a different number of parts than
+// there are configured weights.
+var ErrSubQuestionCount = errors.New("sub-question count does not match configuration")
+
+// defaultDecompositionPrompt asks the LLM to split a compound question.
+const defaultDecompositionPrompt = `Break the following question into the separate parts that should be graded independently.
+Each sub-question must be self-contained and answerable on its own. If the question has only one part, return it unchanged as the only sub-question.
+{{if .Count}}Return exactly {{.Count}} sub-questions.{{else}}Return at most {{.MaxSubQuestions}} sub-questions.{{end}}
+
+Question: {{.Question}}
+
+Respond with JSON in exactly this format:
+{"sub_questions": ["<first sub-question>", "<second sub-question>"]}`
+
+// DecompositionUnit grades compound questions part by part. It splits the
+// question into sub-questions, runs a judge unit once per sub-question with
+// the sub-question in place of domain.KeyQuestion, and recombines each
+// answer's per-aspect scores into a single weighted JudgeSummary.
+//
+// Sub-questions come from, in order of precedence, a fixed list in the
+// configuration, a regular expression that splits the question text, or an
+// LLM call. The combined scores are recorded under the unit's own name and
+// use the judge's score scale; the judge's per-sub-question results are not
+// kept in State.
+//
+// The unit is stateless and thread-safe if its judge is.
+type DecompositionUnit struct {
+	name           string
+	config         DecompositionConfig
+	judge          ports.Unit
+	llmClient      ports.LLMClient
+	splitPattern   *regexp.Regexp
+	promptRenderer PromptRenderer
+	tracer         trace.Tracer
+}
+
+// DecompositionConfig defines how a DecompositionUnit splits questions and
+// recombines per-aspect scores.
+type DecompositionConfig struct {
+	// SubQuestions fixes the sub-questions instead of splitting the
+	// question. Use it when every item in a dataset shares the same parts.
+	SubQuestions []string `yaml:"sub_questions,omitempty" json:"sub_questions,omitempty" validate:"omitempty,max=20,dive,required"`
+
+	// SplitPattern is a regular expression whose matches separate the
+	// sub-questions in the question text, e.g. `(?m)^\s*\d+[.)]\s+` for
+	// numbered parts. Empty parts are dropped. It cannot be combined with
+	// SubQuestions.
+	SplitPattern string `yaml:"split_pattern,omitempty" json:"split_pattern,omitempty" validate:"excluded_with=SubQuestions"`
+
+	// Weights are the relative weights of the sub-questions, in order. When
+	// set, a split must produce exactly len(Weights) sub-questions. When
+	// empty, sub-questions are weighted equally.
+	Weights []float64 `yaml:"weights,omitempty" json:"weights,omitempty" validate:"omitempty,max=20,dive,gt=0"`
+
+	// MaxSubQuestions caps the number of sub-questions a pattern or LLM
+	// split may produce. LLM splits beyond it are trimmed; pattern splits
+	// beyond it fail.
+	MaxSubQuestions int `yaml:"max_sub_questions" json:"max_sub_questions" validate:"min=1,max=20"`
+
+	// PromptTemplate is the prompt used to split questions with the LLM.
+	// It receives .Question, .MaxSubQuestions, and .Count, which is
+	// len(Weights) or zero.
+	PromptTemplate string `yaml:"prompt_template,omitempty" json:"prompt_template,omitempty"`
+
+	// TemplateEngine selects the registered PromptRenderer used for
+	// PromptTemplate. Empty selects the built-in "go" engine.
+	TemplateEngine string `yaml:"template_engine,omitempty" json:"template_engine,omitempty"`
+
+	// Temperature controls randomness in the splitting LLM call.
+	Temperature float64 `yaml:"temperature" json:"temperature" validate:"min=0.0,max=1.0"`
+
+	// MaxTokens limits the splitting LLM response length.
+	MaxTokens int `yaml:"max_tokens" json:"max_tokens" validate:"min=50,max=2000"`
+}
+
+// strategy returns how sub-questions are produced.
+func (c DecompositionConfig) strategy() string {
+	switch {
+	case len(c.SubQuestions) > 0:
+		return splitStrategyFixed
+	case c.SplitPattern != "":
+		return splitStrategyPattern
+	default:
+		return splitStrategyLLM
+	}
+}
+
+// defaultDecompositionConfig returns the defaults overlaid by configuration.
+func defaultDecompositionConfig() DecompositionConfig {
+	return DecompositionConfig{
+		MaxSubQuestions: DefaultMaxSubQuestions,
+		MaxTokens:       DefaultDecompositionMaxTokens,
+	}
+}
+
+// validateDecompositionConfig checks struct rules and the constraints
+// between fields.
+func validateDecompositionConfig(config DecompositionConfig) error {
+	if err := validate.Struct(config); err != nil {
+		return fieldValidationError(err)
+	}
+	if len(config.SubQuestions) > 0 && len(config.Weights) > 0 && len(config.Weights) != len(config.SubQuestions) {
+		return fmt.Errorf("weights has %d entries but sub_questions has %d", len(config.Weights), len(config.SubQuestions))
+	}
+	if len(config.Weights) > config.MaxSubQuestions && len(config.SubQuestions) == 0 {
+		return fmt.Errorf("weights has %d entries, more than max_sub_questions %d", len(config.Weights), config.MaxSubQuestions)
+	}
+	if config.SplitPattern != "" {
+		if _, err := regexp.Compile(config.SplitPattern); err != nil {
+			return fmt.Errorf("invalid split_pattern: %w", err)
+		}
+	}
+	return nil
+}
+
+// NewDecompositionUnit creates a DecompositionUnit that scores each
+// sub-question with judge. llmClient is only required when neither
+// SubQuestions nor SplitPattern is configured.
+func NewDecompositionUnit(
+	name string,
+	judge ports.Unit,
+	llmClient ports.LLMClient,
+	config DecompositionConfig,
+) (*DecompositionUnit, error) {
+	if name == "" {
+		return nil, ErrEmptyUnitName
+	}
+	if judge == nil {
+		return nil, fmt.Errorf("unit %s: judge unit cannot be nil", name)
+	}
+	if err := validateDecompositionConfig(config); err != nil {
+		return nil, domain.NewConfigValidationError(name, err)
+	}
+
+	du := &DecompositionUnit{
+		name:      name,
+		config:    config,
+		judge:     judge,
+		llmClient: llmClient,
+		tracer:    otel.Tracer("decomposition-unit"),
+	}
+
+	switch config.strategy() {
+	case splitStrategyPattern:
+		du.splitPattern = regexp.MustCompile(config.SplitPattern)
+	case splitStrategyLLM:
+		if llmClient == nil {
+			return nil, fmt.Errorf("unit %s: LLM client cannot be nil without sub_questions or split_pattern", name)
+		}
+		template := config.PromptTemplate
+		if template == "" {
+			template = defaultDecompositionPrompt
+		}
+		renderer, err := newPromptRenderer(config.TemplateEngine, "decompositionPrompt", template)
+		if err != nil {
+			return nil, fmt.Errorf("unit %s: failed to parse prompt template: %w", name, err)
+		}
+		du.promptRenderer = renderer
+	}
+
+	return du, nil
+}
+
+// Name returns the unique identifier for this unit instance.
+func (du *DecompositionUnit) Name() string { return du.name }
+
+// Execute splits the question, judges every answer against each
+// sub-question, and stores the weighted combination as the unit's judge
+// scores. Token usage of the split and of every judge run is charged to
+// the budget.
+func (du *DecompositionUnit) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	ctx, span := du.tracer.Start(ctx, "DecompositionUnit.Execute",
+		trace.WithAttributes(
+			attribute.String("unit.type", "decomposition"),
+			attribute.String("unit.id", du.name),
+			attribute.String("judge.name", du.judge.Name()),
+			attribute.String("config.split_strategy", du.config.strategy()),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+
+	question, err := domain.Require(state, domain.KeyQuestion, du.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+	answers, err := domain.Require(state, domain.KeyAnswers, du.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	subQuestions, current, err := du.split(ctx, state, question)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	aspects := make([][]domain.JudgeSummary, len(subQuestions))
+	for k, subQuestion := range subQuestions {
+		// Thread the judge's result into the next run so budget usage
+		// accumulates across sub-questions.
+		result, err := du.judge.Execute(ctx, domain.With(current, domain.KeyQuestion, subQuestion))
+		if err != nil {
+			err := fmt.Errorf("unit %s: sub-question %d: %w", du.name, k+1, err)
+			span.RecordError(err)
+			return state, err
+		}
+		scores, ok := result.GetJudgeScores()
+		if !ok || len(scores) != len(answers) {
+			err := fmt.Errorf("unit %s: sub-question %d: judge %s returned %d scores for %d answers",
+				du.name, k+1, du.judge.Name(), len(scores), len(answers))
+			span.RecordError(err)
+			return state, err
+		}
+		aspects[k] = scores
+		current = result
+	}
+
+	newState := restoreJudgeMaps(domain.With(current, domain.KeyQuestion, question), state)
+	newState = domain.WithJudgeScores(newState, du.name, combineAspects(aspects, du.weights(len(subQuestions))))
+	if scale, ok := domain.JudgeScoreScale(current, du.judge.Name()); ok {
+		newState = domain.WithJudgeScoreScale(newState, du.name, scale)
+	}
+
+	span.SetAttributes(
+		attribute.Int("eval.sub_questions", len(subQuestions)),
+		attribute.Int("eval.answers_scored", len(answers)),
+		attribute.Int64("eval.latency_ms", time.Since(start).Milliseconds()),
+	)
+	return newState, nil
+}
+
+// restoreJudgeMaps returns current with the per-judge score and scale maps
+// of original, so the results of a wrapped judge do not leak into
+// aggregation.
+func restoreJudgeMaps(current, original domain.State) domain.State {
+	byJudge, _ := domain.Get(original, domain.KeyJudgeScoresByJudge)
+	restored := domain.With(current, domain.KeyJudgeScoresByJudge, maps.Clone(byJudge))
+	scales, _ := domain.Get(original, domain.KeyJudgeScoreScales)
+	return domain.With(restored, domain.KeyJudgeScoreScales, maps.Clone(scales))
+}
+
+// split returns the sub-questions for question and the state with any
+// splitting LLM call charged to the budget.
+func (du *DecompositionUnit) split(ctx context.Context, state domain.State, question string) ([]string, domain.State, error) {
+	switch du.config.strategy() {
+	case splitStrategyFixed:
+		return du.config.SubQuestions, state, nil
+
+	case splitStrategyPattern:
+		var parts []string
+		for _, part := range du.splitPattern.Split(question, -1) {
+			if part = strings.TrimSpace(part); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		if len(parts) == 0 {
+			parts = []string{question}
+		}
+		if len(parts) > du.config.MaxSubQuestions {
+			return nil, state, fmt.Errorf("unit %s: %w: split_pattern produced %d sub-questions, max_sub_questions is %d",
+				du.name, ErrSubQuestionCount, len(parts), du.config.MaxSubQuestions)
+		}
+		return parts, state, du.checkWeightCount(len(parts))
+
+	default:
+		return du.splitWithLLM(ctx, state, question)
+	}
+}
+
+// decompositionTemplateData is the data passed to the splitting prompt.
+type decompositionTemplateData struct {
+	Question        string
+	MaxSubQuestions int
+	Count           int
+}
+
+// decompositionResponse is the JSON structure expected from the LLM.
+type decompositionResponse struct {
+	SubQuestions []string `json:"sub_questions"`
+}
+
+// splitWithLLM asks the LLM to split the question. A response with no
+// usable sub-questions falls back to grading the question as a whole.
+func (du *DecompositionUnit) splitWithLLM(ctx context.Context, state domain.State, question string) ([]string, domain.State, error) {
+	prompt, err := du.promptRenderer.Render(decompositionTemplateData{
+		Question:        question,
+		MaxSubQuestions: du.config.MaxSubQuestions,
+		Count:           len(du.config.Weights),
+	})
+	if err != nil {
+		return nil, state, fmt.Errorf("unit %s: failed to execute prompt template: %w", du.name, err)
+	}
+
+	options := map[string]any{
+		"temperature": du.config.Temperature,
+		"max_tokens":  du.config.MaxTokens,
+	}
+	if model, ok := domain.ModelOverride(state, du.name); ok {
+		options["model"] = model
+	}
+
+	response, tokensIn, tokensOut, calls, err := completeMetered(ctx, du.llmClient, prompt, options)
+	if err != nil {
+		return nil, state, domain.NewLLMCallError(du.name, "splitting question", err)
+	}
+	state = chargeBudget(state, tokensIn+tokensOut, calls)
+
+	jsonStr := extractJSON(response)
+	if jsonStr == "" {
+		return nil, state, domain.NewResponseParseError(du.name, "",
+			fmt.Errorf("no valid JSON found (response length: %d chars)", len(response)))
+	}
+	var parsed decompositionResponse
+	if err := json.Unmarshal([]byte(jsonStr), &parsed); err != nil {
+		return nil, state, domain.NewResponseParseError(du.name, "", err)
+	}
+
+	var parts []string
+	for _, part := range parsed.SubQuestions {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		parts = []string{question}
+	}
+	if len(parts) > du.config.MaxSubQuestions {
+		parts = parts[:du.config.MaxSubQuestions]
+	}
+	return parts, state, du.checkWeightCount(len(parts))
+}
+
+// checkWeightCount reports an error when weights are configured and the
+// split produced a different number of sub-questions.
+func (du *DecompositionUnit) checkWeightCount(count int) error {
+	if len(du.config.Weights) == 0 || len(du.config.Weights) == count {
+		return nil
+	}
+	return fmt.Errorf("unit %s: %w: split produced %d sub-questions for %d weights",
+		du.name, ErrSubQuestionCount, count, len(du.config.Weights))
+}
+
+// weights returns the weight of each of count sub-questions.
+func (du *DecompositionUnit) weights(count int) []float64 {
+	if len(du.config.Weights) == count {
+		return du.config.Weights
+	}
+	weights := make([]float64, count)
+	for i := range weights {
+		weights[i] = 1
+	}
+	return weights
+}
+
+// combineAspects merges per-sub-question scores into one summary per
+// answer: score and confidence are weighted means, and the reasoning lists
+// each aspect's score and reasoning.
+func combineAspects(aspects [][]domain.JudgeSummary, weights []float64) []domain.JudgeSummary {
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+
+	combined := make([]domain.JudgeSummary, len(aspects[0]))
+	for i := range combined {
+		var score, confidence float64
+		var reasoning strings.Builder
+		for k, scores := range aspects {
+			w := weights[k] / total
+			score += w * scores[i].Score
+			confidence += w * scores[i].Confidence
+			if k > 0 {
+				reasoning.WriteString("\n")
+			}
+			fmt.Fprintf(&reasoning, "Sub-question %d (weight %.2f): score %.2f. %s",
+				k+1, w, scores[i].Score, scores[i].Reasoning)
+		}
+		combined[i] = domain.JudgeSummary{
+			Score:      score,
+			Confidence: confidence,
+			Reasoning:  reasoning.String(),
+		}
+	}
+	return combined
+}
+
+// Validate checks the configuration and the wrapped judge.
+func (du *DecompositionUnit) Validate() error {
+	if err := validateDecompositionConfig(du.config); err != nil {
+		return domain.NewConfigValidationError(du.name, err)
+	}
+	if err := du.judge.Validate(); err != nil {
+		return fmt.Errorf("unit %s: judge validation failed: %w", du.name, err)
+	}
+	return nil
+}
+
+// NewDecompositionFromConfig creates a DecompositionUnit from a
+// configuration map. The "judge" entry holds the parameters of the
+// score_judge unit that scores each sub-question; it is created with the
+// ID "<id>_judge" and the same LLM client.
+func NewDecompositionFromConfig(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error) {
+	judgeParams, ok := config["judge"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("decomposition requires 'judge' parameters for a score_judge unit")
+	}
+	judge, err := NewScoreJudgeFromConfig(id+"_judge", judgeParams, llm)
+	if err != nil {
+		return nil, fmt.Errorf("create judge: %w", err)
+	}
+
+	params := maps.Clone(config)
+	delete(params, "judge")
+	data, err := yaml.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	cfg := defaultDecompositionConfig()
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+
+	return NewDecompositionUnit(id, judge, llm, cfg)
+}
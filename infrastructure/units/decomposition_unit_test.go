@@ -0,0 +1,218 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+	"github.com/ahrav/go-gavel/internal/testutils"
+)
+
+// aspectJudge is a judge stub that scores every answer with the scores
+// configured for the question it sees, declares a 0-10 scale, and charges
+// one call per run.
+type aspectJudge struct {
+	scores    map[string][]float64
+	questions []string
+}
+
+func (j *aspectJudge) Name() string    { return "aspect_judge" }
+func (j *aspectJudge) Validate() error { return nil }
+
+// Execute records the question and stores its configured scores.
+func (j *aspectJudge) Execute(_ context.Context, state domain.State) (domain.State, error) {
+	question, _ := domain.Get(state, domain.KeyQuestion)
+	j.questions = append(j.questions, question)
+
+	summaries := make([]domain.JudgeSummary, len(j.scores[question]))
+	for i, score := range j.scores[question] {
+		summaries[i] = domain.JudgeSummary{Score: score, Confidence: 0.8, Reasoning: "graded " + question}
+	}
+	state = domain.WithJudgeScores(state, j.Name(), summaries)
+	state = domain.WithJudgeScoreScale(state, j.Name(), domain.ScoreRange{Min: 0, Max: 10})
+	return state.UpdateBudgetUsage(10, 1), nil
+}
+
+// decompositionState returns a state with a compound question and two
+// answers.
+func decompositionState(question string) domain.State {
+	state := domain.With(domain.NewState(), domain.KeyQuestion, question)
+	return domain.With(state, domain.KeyAnswers, []domain.Answer{{ID: "a1", Content: "A"}, {ID: "a2", Content: "B"}})
+}
+
+// TestDecompositionUnit_Execute tests splitting strategies and the weighted
+// recombination of per-aspect scores.
+func TestDecompositionUnit_Execute(t *testing.T) {
+	judgeScores := map[string][]float64{
+		"What is X?": {10, 2},
+		"Why is X?":  {4, 8},
+	}
+
+	tests := []struct {
+		name       string
+		question   string
+		config     func(*DecompositionConfig)
+		llm        ports.LLMClient
+		wantScores []float64
+		wantCalls  int64
+	}{
+		{
+			name:     "fixed sub-questions with weights",
+			question: "Explain X.",
+			config: func(c *DecompositionConfig) {
+				c.SubQuestions = []string{"What is X?", "Why is X?"}
+				c.Weights = []float64{3, 1}
+			},
+			wantScores: []float64{8.5, 3.5},
+			wantCalls:  2,
+		},
+		{
+			name:     "pattern split with equal weights",
+			question: "1. What is X?\n2. Why is X?",
+			config: func(c *DecompositionConfig) {
+				c.SplitPattern = `(?m)^\s*\d+\.\s*`
+			},
+			wantScores: []float64{7, 5},
+			wantCalls:  2,
+		},
+		{
+			name:     "LLM split is charged to the budget",
+			question: "What and why is X?",
+			llm: func() ports.LLMClient {
+				client := testutils.NewMockLLMClient("test-model")
+				client.SetResponse(`{"sub_questions": ["What is X?", " Why is X? ", ""]}`)
+				return client
+			}(),
+			wantScores: []float64{7, 5},
+			wantCalls:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := defaultDecompositionConfig()
+			if tt.config != nil {
+				tt.config(&config)
+			}
+			judge := &aspectJudge{scores: judgeScores}
+			unit, err := NewDecompositionUnit("decomposer", judge, tt.llm, config)
+			require.NoError(t, err)
+
+			result, err := unit.Execute(context.Background(), decompositionState(tt.question))
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{"What is X?", "Why is X?"}, judge.questions)
+			scores, ok := domain.Get(result, domain.KeyJudgeScores)
+			require.True(t, ok)
+			require.Len(t, scores, 2)
+			for i, want := range tt.wantScores {
+				assert.InDelta(t, want, scores[i].Score, 1e-9)
+				assert.InDelta(t, 0.8, scores[i].Confidence, 1e-9)
+			}
+			assert.Contains(t, scores[0].Reasoning, "Sub-question 2")
+
+			question, _ := domain.Get(result, domain.KeyQuestion)
+			assert.Equal(t, tt.question, question, "the original question is restored")
+			byJudge, _ := domain.Get(result, domain.KeyJudgeScoresByJudge)
+			assert.Contains(t, byJudge, "decomposer")
+			assert.NotContains(t, byJudge, judge.Name(), "per-aspect scores do not leak into aggregation")
+			scale, ok := domain.JudgeScoreScale(result, "decomposer")
+			require.True(t, ok)
+			assert.Equal(t, domain.ScoreRange{Min: 0, Max: 10}, scale)
+			assert.Equal(t, tt.wantCalls, result.GetBudgetUsage().Calls)
+		})
+	}
+}
+
+// TestDecompositionUnit_Errors tests configuration and runtime failures.
+func TestDecompositionUnit_Errors(t *testing.T) {
+	judge := &aspectJudge{scores: map[string][]float64{}}
+
+	t.Run("invalid configs", func(t *testing.T) {
+		configs := map[string]func(*DecompositionConfig){
+			"pattern with fixed sub-questions": func(c *DecompositionConfig) {
+				c.SubQuestions = []string{"a"}
+				c.SplitPattern = ","
+			},
+			"weight count mismatch": func(c *DecompositionConfig) {
+				c.SubQuestions = []string{"a", "b"}
+				c.Weights = []float64{1}
+			},
+			"non-positive weight": func(c *DecompositionConfig) {
+				c.SubQuestions = []string{"a"}
+				c.Weights = []float64{0}
+			},
+			"bad pattern": func(c *DecompositionConfig) { c.SplitPattern = "(" },
+		}
+		for name, mutate := range configs {
+			config := defaultDecompositionConfig()
+			mutate(&config)
+			_, err := NewDecompositionUnit("decomposer", judge, nil, config)
+			assert.ErrorIs(t, err, domain.ErrInvalidConfiguration, name)
+		}
+
+		_, err := NewDecompositionUnit("decomposer", judge, nil, defaultDecompositionConfig())
+		assert.ErrorContains(t, err, "LLM client cannot be nil")
+	})
+
+	t.Run("split count must match weights", func(t *testing.T) {
+		config := defaultDecompositionConfig()
+		config.SplitPattern = ";"
+		config.Weights = []float64{1, 2}
+		unit, err := NewDecompositionUnit("decomposer", judge, nil, config)
+		require.NoError(t, err)
+
+		_, err = unit.Execute(context.Background(), decompositionState("a; b; c"))
+		assert.ErrorIs(t, err, ErrSubQuestionCount)
+	})
+
+	t.Run("judge must score every answer", func(t *testing.T) {
+		config := defaultDecompositionConfig()
+		config.SubQuestions = []string{"a"}
+		unit, err := NewDecompositionUnit("decomposer", &aspectJudge{scores: map[string][]float64{"a": {1}}}, nil, config)
+		require.NoError(t, err)
+
+		_, err = unit.Execute(context.Background(), decompositionState("q"))
+		assert.ErrorContains(t, err, "returned 1 scores for 2 answers")
+	})
+
+	t.Run("unparseable LLM split", func(t *testing.T) {
+		client := testutils.NewMockLLMClient("test-model")
+		client.SetResponse("no JSON")
+		unit, err := NewDecompositionUnit("decomposer", judge, client, defaultDecompositionConfig())
+		require.NoError(t, err)
+
+		_, err = unit.Execute(context.Background(), decompositionState("q"))
+		assert.ErrorIs(t, err, domain.ErrResponseParse)
+	})
+}
+
+// TestNewDecompositionFromConfig tests creating the unit and its nested
+// score_judge from a configuration map.
+func TestNewDecompositionFromConfig(t *testing.T) {
+	llm := testutils.NewMockLLMClient("test-model")
+	judgeParams := map[string]any{
+		"judge_prompt": "Rate the answer to {{.Question}}: {{.Answer}}",
+		"score_scale":  "0.0-1.0",
+	}
+
+	unit, err := NewDecompositionFromConfig("decomposer", map[string]any{
+		"judge":         judgeParams,
+		"sub_questions": []any{"What is X?", "Why is X?"},
+		"weights":       []any{2, 1},
+	}, llm)
+	require.NoError(t, err)
+	require.NoError(t, unit.Validate())
+
+	decomposer, ok := unit.(*DecompositionUnit)
+	require.True(t, ok)
+	assert.Equal(t, "decomposer_judge", decomposer.judge.Name())
+	assert.Equal(t, []float64{2, 1}, decomposer.config.Weights)
+
+	_, err = NewDecompositionFromConfig("decomposer", map[string]any{}, llm)
+	assert.ErrorContains(t, err, "requires 'judge'")
+}
@@ -0,0 +1,348 @@
+package units
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+var _ ports.Unit = (*DiverseSelectionUnit)(nil)
+
+// ErrNoEmbeddingClient is returned when embedding similarity is configured
+// without an embedding client.
+var ErrNoEmbeddingClient = errors.New("embedding similarity requires an embedding client")
+
+// Similarity signals supported by DiverseSelectionUnit.
+const (
+	// DiversitySimilarityLexical compares answers by the Jaccard similarity
+	// of their lowercased word sets. It needs no external service.
+	DiversitySimilarityLexical = "lexical"
+	// DiversitySimilarityEmbedding compares answers by the cosine similarity
+	// of their embeddings and requires an embedding client.
+	DiversitySimilarityEmbedding = "embedding"
+)
+
+// DiverseSelectionUnit selects K answers that score well but differ from
+// each other, using maximal marginal relevance (MMR). Answers are chosen
+// greedily; each step picks the answer maximizing
+//
+//	relevance - λ × max similarity to the answers already selected
+//
+// where relevance is the answer's judge score rescaled to [0, 1] across the
+// candidates. With λ = 0 the unit reduces to top-k by score; larger values
+// trade score for variety, which suits picking varied outputs or diverse
+// few-shot exemplars.
+//
+// Selected answers replace KeyAnswers in their original order, the
+// remainder are recorded under KeyDroppedAnswers, and aligned judge scores
+// are filtered to match, as TopKSelectionUnit does.
+//
+// The unit is deterministic and thread-safe.
+type DiverseSelectionUnit struct {
+	// name is the unique identifier for this unit instance.
+	name string
+	// config contains the validated configuration parameters.
+	config DiverseSelectionConfig
+	// embedder computes embeddings for the embedding similarity signal.
+	embedder ports.EmbeddingClient
+	// tracer is the OpenTelemetry tracer for observability.
+	tracer trace.Tracer
+}
+
+// DiverseSelectionConfig defines the configuration parameters for the
+// DiverseSelectionUnit.
+type DiverseSelectionConfig struct {
+	// K is the number of answers passed downstream. When there are K or
+	// fewer answers, all of them are kept.
+	K int `yaml:"k" json:"k" validate:"required,min=1,max=10000"`
+
+	// Lambda weights the similarity penalty against relevance. Zero
+	// selects purely by score.
+	Lambda float64 `yaml:"lambda" json:"lambda" validate:"min=0,max=10"`
+
+	// Similarity selects the similarity signal: "lexical" (the default) or
+	// "embedding".
+	Similarity string `yaml:"similarity" json:"similarity" validate:"required,oneof=lexical embedding"`
+
+	// MinAnswers is the fewest answers the unit accepts. Execution fails
+	// with ErrTooFewAnswers when fewer are present. Zero disables the check.
+	MinAnswers int `yaml:"min_answers,omitempty" json:"min_answers,omitempty" validate:"min=0,max=10000"`
+}
+
+// DefaultDiverseSelectionConfig returns a DiverseSelectionConfig that keeps
+// three answers, weighting relevance and lexical similarity equally.
+func DefaultDiverseSelectionConfig() DiverseSelectionConfig {
+	return DiverseSelectionConfig{
+		K:          3,
+		Lambda:     1.0,
+		Similarity: DiversitySimilarityLexical,
+	}
+}
+
+// NewDiverseSelectionUnit creates a new DiverseSelectionUnit with the
+// specified configuration. embedder is required for the embedding
+// similarity signal and ignored otherwise. Returns an error if
+// configuration validation fails.
+func NewDiverseSelectionUnit(
+	name string,
+	config DiverseSelectionConfig,
+	embedder ports.EmbeddingClient,
+) (*DiverseSelectionUnit, error) {
+	if name == "" {
+		return nil, ErrEmptyUnitName
+	}
+
+	if err := validate.Struct(config); err != nil {
+		return nil, domain.NewConfigValidationError(name, fieldValidationError(err))
+	}
+
+	if config.Similarity == DiversitySimilarityEmbedding && embedder == nil {
+		return nil, domain.NewConfigValidationError(name, ErrNoEmbeddingClient)
+	}
+
+	return &DiverseSelectionUnit{
+		name:     name,
+		config:   config,
+		embedder: embedder,
+		tracer:   otel.Tracer("diverse-selection-unit"),
+	}, nil
+}
+
+// Name returns the unique identifier for this unit instance.
+func (dsu *DiverseSelectionUnit) Name() string { return dsu.name }
+
+// Execute selects K answers from KeyAnswers by maximal marginal relevance
+// over the scores in KeyJudgeScores. Ties are broken by original position,
+// so the same input always yields the same selection.
+//
+// Returns an error if answers or judge scores are missing, the scores do
+// not align with the answers, or embedding fails.
+func (dsu *DiverseSelectionUnit) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	ctx, span := dsu.tracer.Start(ctx, "DiverseSelectionUnit.Execute",
+		trace.WithAttributes(
+			attribute.String("unit.type", "diverse_selection"),
+			attribute.String("unit.id", dsu.name),
+			attribute.Int("config.k", dsu.config.K),
+			attribute.Float64("config.lambda", dsu.config.Lambda),
+			attribute.String("config.similarity", dsu.config.Similarity),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+
+	answers, err := domain.Require(state, domain.KeyAnswers, dsu.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	if len(answers) == 0 {
+		err := fmt.Errorf("no answers provided for diverse selection")
+		span.RecordError(err)
+		return state, err
+	}
+
+	if err := checkMinAnswers(dsu.name, len(answers), dsu.config.MinAnswers); err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	if len(answers) > MaxAnswers {
+		err := fmt.Errorf("too many answers: %d exceeds limit of %d", len(answers), MaxAnswers)
+		span.RecordError(err)
+		return state, err
+	}
+
+	judgeScores, err := domain.Require(state, domain.KeyJudgeScores, dsu.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+	if len(judgeScores) != len(answers) {
+		err := fmt.Errorf("%w: %d scores for %d answers", ErrScoreMismatch, len(judgeScores), len(answers))
+		span.RecordError(err)
+		return state, err
+	}
+	if err := checkFiniteSummaries(judgeScores); err != nil {
+		err = fmt.Errorf("unit %s: %w", dsu.name, err)
+		span.RecordError(err)
+		return state, err
+	}
+
+	similarity, err := dsu.similarityMatrix(ctx, answers)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	order := selectMMR(relevance(judgeScores), similarity, dsu.config.Lambda, dsu.config.K)
+
+	keep := make([]bool, len(answers))
+	for _, idx := range order {
+		keep[idx] = true
+	}
+
+	selected := make([]domain.Answer, 0, len(order))
+	var dropped []domain.Answer
+	for i, answer := range answers {
+		if keep[i] {
+			selected = append(selected, answer)
+		} else {
+			dropped = append(dropped, answer)
+		}
+	}
+
+	newState := domain.With(state, domain.KeyAnswers, selected)
+	newState = domain.With(newState, domain.KeyDroppedAnswers, dropped)
+	newState = filterAlignedJudgeScores(newState, keep)
+
+	span.SetAttributes(
+		attribute.Int64("eval.latency_ms", time.Since(start).Milliseconds()),
+		attribute.Int("eval.answers_count", len(answers)),
+		attribute.Int("eval.selected_count", len(selected)),
+		attribute.Int("eval.dropped_count", len(dropped)),
+		attribute.Bool("no_llm_cost", dsu.config.Similarity != DiversitySimilarityEmbedding),
+	)
+
+	return newState, nil
+}
+
+// similarityMatrix returns pairwise answer similarities using the
+// configured signal.
+func (dsu *DiverseSelectionUnit) similarityMatrix(ctx context.Context, answers []domain.Answer) ([][]float64, error) {
+	if dsu.config.Similarity == DiversitySimilarityEmbedding {
+		return AnswerSimilarityMatrix(ctx, dsu.embedder, answers)
+	}
+
+	words := make([]map[string]struct{}, len(answers))
+	for i, answer := range answers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if len(answer.Content) > MaxStringLength {
+			return nil, fmt.Errorf("answer %d too long: %d bytes exceeds limit of %d", i, len(answer.Content), MaxStringLength)
+		}
+		words[i] = wordSet(answer.Content)
+	}
+
+	matrix := make([][]float64, len(answers))
+	for i := range matrix {
+		matrix[i] = make([]float64, len(answers))
+	}
+	for i := range answers {
+		for j := i; j < len(answers); j++ {
+			sim := jaccard(words[i], words[j])
+			matrix[i][j] = sim
+			matrix[j][i] = sim
+		}
+	}
+	return matrix, nil
+}
+
+// relevance rescales judge scores to [0, 1] across the candidates so the
+// similarity penalty has the same weight regardless of the score scale.
+// When every score is equal all answers are equally relevant.
+func relevance(scores []domain.JudgeSummary) []float64 {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, s := range scores {
+		lo = math.Min(lo, s.Score)
+		hi = math.Max(hi, s.Score)
+	}
+
+	rel := make([]float64, len(scores))
+	for i, s := range scores {
+		if hi > lo {
+			rel[i] = (s.Score - lo) / (hi - lo)
+		} else {
+			rel[i] = 1
+		}
+	}
+	return rel
+}
+
+// selectMMR greedily picks up to k indices by maximal marginal relevance
+// and returns them in selection order. The first pick is the most relevant
+// answer; ties go to the earlier index. A NaN marginal relevance ranks
+// below every other value, so a pick is always made.
+func selectMMR(rel []float64, similarity [][]float64, lambda float64, k int) []int {
+	n := len(rel)
+	k = min(k, n)
+	selected := make([]int, 0, k)
+	chosen := make([]bool, n)
+	// maxSim[i] is the highest similarity between answer i and any
+	// selected answer, updated incrementally after each pick.
+	maxSim := make([]float64, n)
+	for i := range maxSim {
+		maxSim[i] = math.Inf(-1)
+	}
+
+	for len(selected) < k {
+		best, bestValue := -1, math.Inf(-1)
+		for i := range n {
+			if chosen[i] {
+				continue
+			}
+			value := rel[i]
+			if len(selected) > 0 {
+				value -= lambda * maxSim[i]
+			}
+			if math.IsNaN(value) {
+				value = math.Inf(-1)
+			}
+			if best < 0 || value > bestValue {
+				best, bestValue = i, value
+			}
+		}
+
+		chosen[best] = true
+		selected = append(selected, best)
+		for i := range n {
+			maxSim[i] = math.Max(maxSim[i], similarity[i][best])
+		}
+	}
+	return selected
+}
+
+// Validate checks if the unit is properly configured and ready for execution.
+func (dsu *DiverseSelectionUnit) Validate() error {
+	if err := validate.Struct(dsu.config); err != nil {
+		return domain.NewConfigValidationError(dsu.name, fieldValidationError(err))
+	}
+	if dsu.config.Similarity == DiversitySimilarityEmbedding && dsu.embedder == nil {
+		return domain.NewConfigValidationError(dsu.name, ErrNoEmbeddingClient)
+	}
+	return nil
+}
+
+// NewDiverseSelectionFromConfig creates a DiverseSelectionUnit from a
+// configuration map. This is the boundary adapter for YAML/JSON
+// configuration. Units built this way have no embedding client, so only
+// lexical similarity is available; construct the unit with
+// NewDiverseSelectionUnit to use embeddings.
+func NewDiverseSelectionFromConfig(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error) {
+	// llm is ignored - selection uses judge scores already in state.
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	// Start with defaults, then overlay user config.
+	cfg := DefaultDiverseSelectionConfig()
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+
+	return NewDiverseSelectionUnit(id, cfg, nil)
+}
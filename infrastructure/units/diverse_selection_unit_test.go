@@ -0,0 +1,200 @@
+package units
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/testutils"
+)
+
+// TestNewDiverseSelectionUnit tests unit creation and configuration
+// validation.
+func TestNewDiverseSelectionUnit(t *testing.T) {
+	embedder := testutils.NewMockEmbeddingClient("embed", 0)
+
+	tests := []struct {
+		name    string
+		unit    string
+		config  DiverseSelectionConfig
+		wantErr error
+	}{
+		{name: "default config", unit: "diverse", config: DefaultDiverseSelectionConfig()},
+		{name: "empty name", unit: "", config: DefaultDiverseSelectionConfig(), wantErr: ErrEmptyUnitName},
+		{name: "zero k", unit: "diverse", config: DiverseSelectionConfig{K: 0, Similarity: DiversitySimilarityLexical}, wantErr: domain.ErrInvalidConfiguration},
+		{name: "negative lambda", unit: "diverse", config: DiverseSelectionConfig{K: 2, Lambda: -1, Similarity: DiversitySimilarityLexical}, wantErr: domain.ErrInvalidConfiguration},
+		{name: "unknown similarity", unit: "diverse", config: DiverseSelectionConfig{K: 2, Similarity: "semantic"}, wantErr: domain.ErrInvalidConfiguration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := NewDiverseSelectionUnit(tt.unit, tt.config, embedder)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.unit, unit.Name())
+			assert.NoError(t, unit.Validate())
+		})
+	}
+
+	t.Run("embedding similarity requires client", func(t *testing.T) {
+		config := DiverseSelectionConfig{K: 2, Similarity: DiversitySimilarityEmbedding}
+		_, err := NewDiverseSelectionUnit("diverse", config, nil)
+		require.ErrorIs(t, err, ErrNoEmbeddingClient)
+	})
+}
+
+// TestDiverseSelectionUnit_Execute tests MMR selection with lexical and
+// embedding similarity, including the λ = 0 top-k case and filtering of
+// aligned judge scores.
+func TestDiverseSelectionUnit_Execute(t *testing.T) {
+	answers := []domain.Answer{
+		{ID: "a1", Content: "Paris is the capital of France"},
+		{ID: "a2", Content: "The capital of France is Paris"},
+		{ID: "a3", Content: "France governs from its largest city on the Seine"},
+		{ID: "a4", Content: "I do not know"},
+	}
+	scores := []domain.JudgeSummary{
+		{Score: 0.95, Confidence: 0.9},
+		{Score: 0.9, Confidence: 0.9},
+		{Score: 0.7, Confidence: 0.8},
+		{Score: 0.1, Confidence: 0.5},
+	}
+	base := domain.With(domain.NewState(), domain.KeyAnswers, answers)
+	base = domain.With(base, domain.KeyJudgeScores, scores)
+
+	tests := []struct {
+		name        string
+		config      DiverseSelectionConfig
+		wantKept    []string
+		wantDropped []string
+	}{
+		{
+			name:        "zero lambda selects by score",
+			config:      DiverseSelectionConfig{K: 2, Lambda: 0, Similarity: DiversitySimilarityLexical},
+			wantKept:    []string{"a1", "a2"},
+			wantDropped: []string{"a3", "a4"},
+		},
+		{
+			name:        "lexical penalty skips paraphrase",
+			config:      DiverseSelectionConfig{K: 2, Lambda: 1, Similarity: DiversitySimilarityLexical},
+			wantKept:    []string{"a1", "a3"},
+			wantDropped: []string{"a2", "a4"},
+		},
+		{
+			name:        "embedding penalty skips paraphrase",
+			config:      DiverseSelectionConfig{K: 2, Lambda: 1, Similarity: DiversitySimilarityEmbedding},
+			wantKept:    []string{"a1", "a3"},
+			wantDropped: []string{"a2", "a4"},
+		},
+		{
+			name:        "k larger than answers keeps all",
+			config:      DiverseSelectionConfig{K: 10, Lambda: 1, Similarity: DiversitySimilarityLexical},
+			wantKept:    []string{"a1", "a2", "a3", "a4"},
+			wantDropped: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := NewDiverseSelectionUnit("diverse", tt.config, testutils.NewMockEmbeddingClient("embed", 0))
+			require.NoError(t, err)
+
+			result, err := unit.Execute(context.Background(), base)
+			require.NoError(t, err)
+
+			kept, ok := domain.Get(result, domain.KeyAnswers)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantKept, answerIDs(kept))
+
+			dropped, _ := domain.Get(result, domain.KeyDroppedAnswers)
+			assert.Equal(t, tt.wantDropped, answerIDs(dropped))
+
+			filtered, _ := domain.Get(result, domain.KeyJudgeScores)
+			assert.Len(t, filtered, len(tt.wantKept), "judge scores stay aligned with answers")
+		})
+	}
+}
+
+// TestDiverseSelectionUnit_Errors tests failures for missing or misaligned
+// inputs and embedding errors.
+func TestDiverseSelectionUnit_Errors(t *testing.T) {
+	answers := []domain.Answer{{ID: "a1", Content: "one"}, {ID: "a2", Content: "two"}}
+	withAnswers := domain.With(domain.NewState(), domain.KeyAnswers, answers)
+
+	unit, err := NewDiverseSelectionUnit("diverse", DefaultDiverseSelectionConfig(), nil)
+	require.NoError(t, err)
+
+	t.Run("missing answers", func(t *testing.T) {
+		_, err := unit.Execute(context.Background(), domain.NewState())
+		require.ErrorIs(t, err, domain.ErrKeyNotFound)
+	})
+
+	t.Run("missing judge scores", func(t *testing.T) {
+		_, err := unit.Execute(context.Background(), withAnswers)
+		require.ErrorIs(t, err, domain.ErrKeyNotFound)
+	})
+
+	t.Run("misaligned judge scores", func(t *testing.T) {
+		state := domain.With(withAnswers, domain.KeyJudgeScores, []domain.JudgeSummary{{Score: 1}})
+		_, err := unit.Execute(context.Background(), state)
+		require.ErrorIs(t, err, ErrScoreMismatch)
+	})
+
+	t.Run("embedding failure", func(t *testing.T) {
+		embedder := testutils.NewMockEmbeddingClient("embed", 0)
+		embedder.SetError(errors.New("embedding service unavailable"))
+		config := DiverseSelectionConfig{K: 1, Lambda: 1, Similarity: DiversitySimilarityEmbedding}
+		unit, err := NewDiverseSelectionUnit("diverse", config, embedder)
+		require.NoError(t, err)
+
+		state := domain.With(withAnswers, domain.KeyJudgeScores, []domain.JudgeSummary{{Score: 1}, {Score: 0}})
+		_, err = unit.Execute(context.Background(), state)
+		assert.ErrorContains(t, err, "embedding service unavailable")
+	})
+}
+
+// TestSelectMMR_NonFinite tests that non-finite relevance or similarity
+// values still yield k distinct picks.
+func TestSelectMMR_NonFinite(t *testing.T) {
+	similarity := [][]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	tests := []struct {
+		name       string
+		rel        []float64
+		similarity [][]float64
+		want       []int
+	}{
+		{name: "all NaN relevance", rel: []float64{math.NaN(), math.NaN(), math.NaN()}, similarity: similarity, want: []int{0, 1, 2}},
+		{name: "NaN ranks last", rel: []float64{math.NaN(), 0.5, 1}, similarity: similarity, want: []int{2, 1, 0}},
+		{name: "negative infinite relevance", rel: []float64{math.Inf(-1), math.Inf(-1), 0}, similarity: similarity, want: []int{2, 0, 1}},
+		{
+			name:       "NaN similarity",
+			rel:        []float64{1, 0.5, 0},
+			similarity: [][]float64{{1, math.NaN(), math.NaN()}, {math.NaN(), 1, 0}, {math.NaN(), 0, 1}},
+			want:       []int{0, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, selectMMR(tt.rel, tt.similarity, 1, len(tt.rel)))
+		})
+	}
+}
+
+// TestNewDiverseSelectionFromConfig tests construction from a configuration
+// map, including that embedding similarity is rejected without a client.
+func TestNewDiverseSelectionFromConfig(t *testing.T) {
+	unit, err := NewDiverseSelectionFromConfig("diverse", map[string]any{"k": 2, "lambda": 0.5}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "diverse", unit.Name())
+
+	_, err = NewDiverseSelectionFromConfig("diverse", map[string]any{"similarity": "embedding"}, nil)
+	require.ErrorIs(t, err, ErrNoEmbeddingClient)
+}
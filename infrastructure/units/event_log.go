@@ -0,0 +1,175 @@
+package units
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// Attribute keys shared by every unit event, so log records can be queried
+// consistently across units and joined with spans.
+const (
+	eventAttrUnitID     = "unit.id"
+	eventAttrUnitType   = "unit.type"
+	eventAttrModel      = "llm.model"
+	eventAttrTokensIn   = "llm.tokens_in"
+	eventAttrTokensOut  = "llm.tokens_out"
+	eventAttrLatency    = "latency"
+	eventAttrConfidence = "confidence"
+	eventAttrError      = "error"
+	eventAttrTraceID    = "trace_id"
+	eventAttrSpanID     = "span_id"
+)
+
+// eventLogger receives structured unit events. It is nil until
+// SetEventLogger is called, which disables event logging.
+var eventLogger atomic.Pointer[slog.Logger]
+
+// SetEventLogger installs the logger that receives structured unit events:
+// unit start and end from StartUnitEvents, LLM call outcomes from
+// LogLLMCall, and finalized verdicts from LogVerdictFinalized. Events
+// complement spans with a queryable record, so the logger is typically
+// backed by an OpenTelemetry log bridge handler; any slog.Handler works.
+// Records are logged with the unit's context, and carry trace_id and
+// span_id attributes when the context holds a span. A nil logger disables
+// event logging, which is the default.
+func SetEventLogger(logger *slog.Logger) {
+	eventLogger.Store(logger)
+}
+
+// unitEvents emits structured events for a single unit execution. The
+// trace level in state gates which events are emitted: none without a
+// trace level, info events at any level other than "debug", and every
+// event at "debug". The zero value emits nothing.
+type unitEvents struct {
+	ctx      context.Context
+	logger   *slog.Logger
+	minLevel slog.Level
+	attrs    []slog.Attr
+}
+
+// unitEventsContextKey is the context key of the running unit's events.
+type unitEventsContextKey struct{}
+
+// StartUnitEvents records the start of one execution of the unit
+// identified by unitType and unitID. It returns a context carrying the
+// unit's events, so LLM calls made with it are logged against the unit,
+// and a function that records the end of the execution and its outcome.
+func StartUnitEvents(ctx context.Context, state domain.State, unitType, unitID string) (context.Context, func(err error)) {
+	events := newUnitEvents(ctx, state, unitType, unitID)
+	if events.logger == nil {
+		return ctx, func(error) {}
+	}
+	events.started()
+	start := time.Now()
+	ctx = context.WithValue(ctx, unitEventsContextKey{}, events)
+	return ctx, func(err error) { events.finished(time.Since(start), err) }
+}
+
+// LogLLMCall records the outcome of an LLM call against the unit whose
+// events ctx carries, if any. Its signature matches the observer taken by
+// llm.CallObserverMiddleware.
+func LogLLMCall(ctx context.Context, model string, tokensIn, tokensOut int, latency time.Duration, err error) {
+	if events, ok := ctx.Value(unitEventsContextKey{}).(unitEvents); ok {
+		events.llmCall(model, tokensIn, tokensOut, latency, err)
+	}
+}
+
+// LogVerdictFinalized records the verdict in state once a graph has
+// finalized it. The event is attributed to the graph named by
+// domain.KeyGraphID.
+func LogVerdictFinalized(ctx context.Context, state domain.State) {
+	verdict, ok := state.GetVerdict()
+	if !ok || verdict == nil {
+		return
+	}
+	graphID, _ := domain.Get(state, domain.KeyGraphID)
+	newUnitEvents(ctx, state, "graph", graphID).verdictFinalized(verdict)
+}
+
+// newUnitEvents returns the event emitter for one execution of the unit
+// identified by unitType and unitID.
+func newUnitEvents(ctx context.Context, state domain.State, unitType, unitID string) unitEvents {
+	logger := eventLogger.Load()
+	traceLevel, _ := state.GetTraceLevel()
+	if logger == nil || traceLevel == "" {
+		return unitEvents{}
+	}
+
+	minLevel := slog.LevelInfo
+	if strings.EqualFold(traceLevel, "debug") {
+		minLevel = slog.LevelDebug
+	}
+
+	attrs := []slog.Attr{
+		slog.String(eventAttrUnitType, unitType),
+		slog.String(eventAttrUnitID, unitID),
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(attrs,
+			slog.String(eventAttrTraceID, sc.TraceID().String()),
+			slog.String(eventAttrSpanID, sc.SpanID().String()),
+		)
+	}
+	return unitEvents{ctx: ctx, logger: logger, minLevel: minLevel, attrs: attrs}
+}
+
+// log emits msg with the unit attributes followed by attrs when level is
+// enabled by the trace level and the logger.
+func (e unitEvents) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	if e.logger == nil || level < e.minLevel || !e.logger.Enabled(e.ctx, level) {
+		return
+	}
+	e.logger.LogAttrs(e.ctx, level, msg, append(e.attrs[:len(e.attrs):len(e.attrs)], attrs...)...)
+}
+
+// started records the start of the execution at debug level.
+func (e unitEvents) started() {
+	e.log(slog.LevelDebug, "unit started")
+}
+
+// finished records the end of the execution and its outcome.
+func (e unitEvents) finished(latency time.Duration, err error) {
+	if err != nil {
+		e.log(slog.LevelError, "unit failed",
+			slog.Duration(eventAttrLatency, latency),
+			slog.String(eventAttrError, err.Error()),
+		)
+		return
+	}
+	e.log(slog.LevelInfo, "unit completed", slog.Duration(eventAttrLatency, latency))
+}
+
+// llmCall records the outcome of an LLM call made by the unit.
+func (e unitEvents) llmCall(model string, tokensIn, tokensOut int, latency time.Duration, err error) {
+	attrs := []slog.Attr{
+		slog.String(eventAttrModel, model),
+		slog.Int(eventAttrTokensIn, tokensIn),
+		slog.Int(eventAttrTokensOut, tokensOut),
+		slog.Duration(eventAttrLatency, latency),
+	}
+	if err != nil {
+		e.log(slog.LevelWarn, "llm call failed", append(attrs, slog.String(eventAttrError, err.Error()))...)
+		return
+	}
+	e.log(slog.LevelInfo, "llm call completed", attrs...)
+}
+
+// verdictFinalized records a finalized verdict and its confidence.
+func (e unitEvents) verdictFinalized(verdict *domain.Verdict) {
+	attrs := []slog.Attr{
+		slog.Float64(eventAttrConfidence, verdict.Confidence),
+		slog.String("verdict.status", string(verdict.Status)),
+		slog.Bool("verdict.requires_human_review", verdict.RequiresHumanReview),
+	}
+	if verdict.WinnerAnswer != nil {
+		attrs = append(attrs, slog.String("verdict.winner_id", verdict.WinnerAnswer.ID))
+	}
+	e.log(slog.LevelInfo, "verdict finalized", attrs...)
+}
@@ -0,0 +1,127 @@
+package units
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// captureEvents installs a JSON event logger for the duration of the test
+// and returns a function that decodes the records logged so far.
+func captureEvents(t *testing.T) func() []map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	SetEventLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { SetEventLogger(nil) })
+
+	return func() []map[string]any {
+		var records []map[string]any
+		dec := json.NewDecoder(bytes.NewReader(buf.Bytes()))
+		for dec.More() {
+			var record map[string]any
+			require.NoError(t, dec.Decode(&record))
+			records = append(records, record)
+		}
+		return records
+	}
+}
+
+// eventMessages returns the messages of records in order.
+func eventMessages(records []map[string]any) []string {
+	msgs := make([]string, len(records))
+	for i, record := range records {
+		msgs[i], _ = record["msg"].(string)
+	}
+	return msgs
+}
+
+// TestUnitEvents tests that unit, LLM call, and verdict events carry the
+// shared attributes and are gated by the trace level in state.
+func TestUnitEvents(t *testing.T) {
+	verdict := &domain.Verdict{ID: "v1", WinnerAnswer: &domain.Answer{ID: "a1"}, Confidence: 0.9}
+	eventState := func(traceLevel string) domain.State {
+		state := buildState(
+			domain.KeyVerdict, verdict,
+			domain.KeyGraphID, "demo-graph",
+		)
+		if traceLevel != "" {
+			state = domain.With(state, domain.KeyTraceLevel, traceLevel)
+		}
+		return state
+	}
+
+	tests := []struct {
+		name       string
+		traceLevel string
+		callErr    error
+		want       []string
+	}{
+		{name: "no trace level emits nothing", want: []string{}},
+		{
+			name:       "info omits debug events",
+			traceLevel: "info",
+			want:       []string{"llm call completed", "unit completed", "verdict finalized"},
+		},
+		{
+			name:       "debug emits every event",
+			traceLevel: "debug",
+			want:       []string{"unit started", "llm call completed", "unit completed", "verdict finalized"},
+		},
+		{
+			name:       "failed LLM call",
+			traceLevel: "info",
+			callErr:    errors.New("upstream unavailable"),
+			want:       []string{"llm call failed", "unit failed", "verdict finalized"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := captureEvents(t)
+			state := eventState(tt.traceLevel)
+
+			ctx, finished := StartUnitEvents(context.Background(), state, "verification", "verifier")
+			LogLLMCall(ctx, "test-model", 10, 5, time.Millisecond, tt.callErr)
+			finished(tt.callErr)
+			LogVerdictFinalized(context.Background(), state)
+
+			got := records()
+			assert.Equal(t, tt.want, eventMessages(got))
+			for _, record := range got {
+				switch record["msg"] {
+				case "verdict finalized":
+					assert.Equal(t, "demo-graph", record[eventAttrUnitID])
+					assert.Equal(t, "graph", record[eventAttrUnitType])
+					assert.InDelta(t, 0.9, record[eventAttrConfidence], 1e-9)
+					assert.Equal(t, "a1", record["verdict.winner_id"])
+					continue
+				case "llm call completed", "llm call failed":
+					assert.Equal(t, "test-model", record[eventAttrModel])
+					assert.EqualValues(t, 10, record[eventAttrTokensIn])
+					assert.Contains(t, record, eventAttrLatency)
+				case "unit failed":
+					assert.Contains(t, record[eventAttrError], "upstream unavailable")
+				}
+				assert.Equal(t, "verifier", record[eventAttrUnitID])
+				assert.Equal(t, "verification", record[eventAttrUnitType])
+			}
+		})
+	}
+}
+
+// TestLogLLMCall_OutsideUnit tests that LLM calls made without a unit's
+// context are not logged.
+func TestLogLLMCall_OutsideUnit(t *testing.T) {
+	records := captureEvents(t)
+	LogLLMCall(context.Background(), "test-model", 10, 5, time.Millisecond, nil)
+	assert.Empty(t, records())
+}
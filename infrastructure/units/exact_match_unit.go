@@ -9,11 +9,11 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
-	"golang.org/x/text/cases"
 	"gopkg.in/yaml.v3"
 
 	"github.com/ahrav/go-gavel/internal/domain"
 	"github.com/ahrav/go-gavel/internal/ports"
+	"github.com/ahrav/go-gavel/internal/textnorm"
 )
 
 var _ ports.Unit = (*ExactMatchUnit)(nil)
@@ -56,7 +56,7 @@ type ExactMatchUnit struct {
 // access. Changes require creating a new unit instance.
 type ExactMatchConfig struct {
 	// CaseSensitive controls case sensitivity during string comparison.
-	// When false, uses Unicode-aware case folding for proper internationalization.
+	// When false, uses the textnorm Unicode case folding policy.
 	// Default: false (case-insensitive matching).
 	CaseSensitive bool `yaml:"case_sensitive" json:"case_sensitive"`
 
@@ -64,6 +64,20 @@ type ExactMatchConfig struct {
 	// When true, applies strings.TrimSpace before comparison.
 	// Default: true (whitespace is trimmed).
 	TrimWhitespace bool `yaml:"trim_whitespace" json:"trim_whitespace"`
+
+	// RemoveStopwords drops stopwords before comparison, leaving the
+	// remaining words separated by single spaces.
+	// Default: false (stopwords are kept).
+	RemoveStopwords bool `yaml:"remove_stopwords" json:"remove_stopwords"`
+
+	// Stopwords replaces the default English stopword list used when
+	// RemoveStopwords is true.
+	Stopwords []string `yaml:"stopwords" json:"stopwords" validate:"omitempty,dive,required"`
+
+	// Conclusive, when set, ends the evaluation after this unit once an
+	// answer matches, skipping later stages such as LLM judges.
+	// Default: nil (later stages always run).
+	Conclusive *ConclusiveConfig `yaml:"conclusive,omitempty" json:"conclusive,omitempty" validate:"omitempty"`
 }
 
 // NewExactMatchUnit creates a new ExactMatchUnit with validated configuration.
@@ -80,7 +94,7 @@ func NewExactMatchUnit(name string, config ExactMatchConfig) (*ExactMatchUnit, e
 	}
 
 	if err := validate.Struct(config); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
+		return nil, domain.NewConfigValidationError(name, fieldValidationError(err))
 	}
 
 	return &ExactMatchUnit{
@@ -105,7 +119,8 @@ func (emu *ExactMatchUnit) Name() string { return emu.name }
 //
 // Returns a new state containing domain.KeyJudgeScores with match results.
 // Each JudgeSummary contains a score of 1.0 (exact match) or 0.0 (no match),
-// deterministic reasoning text, and confidence of 1.0.
+// deterministic reasoning text, and confidence of 1.0. With Conclusive
+// configured, a match also sets domain.KeyConclusive.
 //
 // Errors:
 //   - Missing or empty answers in state
@@ -122,6 +137,7 @@ func (emu *ExactMatchUnit) Execute(ctx context.Context, state domain.State) (dom
 			attribute.String("unit.id", emu.name),
 			attribute.Bool("config.case_sensitive", emu.config.CaseSensitive),
 			attribute.Bool("config.trim_whitespace", emu.config.TrimWhitespace),
+			attribute.Bool("config.remove_stopwords", emu.config.RemoveStopwords),
 		),
 	)
 	defer span.End()
@@ -130,9 +146,8 @@ func (emu *ExactMatchUnit) Execute(ctx context.Context, state domain.State) (dom
 
 	// Extract candidate answers from state.
 	// This is a required input for deterministic evaluation.
-	answers, ok := domain.Get(state, domain.KeyAnswers)
-	if !ok {
-		err := fmt.Errorf("answers not found in state")
+	answers, err := domain.Require(state, domain.KeyAnswers, emu.name)
+	if err != nil {
 		span.RecordError(err)
 		return state, err
 	}
@@ -153,7 +168,7 @@ func (emu *ExactMatchUnit) Execute(ctx context.Context, state domain.State) (dom
 
 	// Extract reference answer from state.
 	// Ground truth answer is mandatory for exact matching evaluation.
-	referenceAnswer, ok := domain.Get(state, domain.KeyReferenceAnswer)
+	referenceAnswer, ok := state.GetReferenceAnswer()
 	if !ok {
 		err := fmt.Errorf("reference_answer required for deterministic evaluation")
 		span.RecordError(err)
@@ -170,19 +185,29 @@ func (emu *ExactMatchUnit) Execute(ctx context.Context, state domain.State) (dom
 
 	// Prepare the reference answer according to configuration.
 	// Apply case folding and whitespace normalization once for efficiency.
-	preparedReference := emu.prepareString(referenceAnswer)
+	normalizer := textnorm.New(textnorm.Policy{
+		CaseSensitive:   emu.config.CaseSensitive,
+		RemoveStopwords: emu.config.RemoveStopwords,
+		Stopwords:       emu.config.Stopwords,
+	})
+	preparedReference := emu.prepareString(normalizer, referenceAnswer)
 
 	judgeSummaries := make([]domain.JudgeSummary, len(answers))
 	totalScore := 0.0
 
 	for i, answer := range answers {
+		if err := ctx.Err(); err != nil {
+			span.RecordError(err)
+			return state, err
+		}
+
 		if len(answer.Content) > MaxStringLength {
 			err := fmt.Errorf("answer %d too long: %d bytes exceeds limit of %d", i, len(answer.Content), MaxStringLength)
 			span.RecordError(err)
 			return state, err
 		}
 
-		preparedAnswer := emu.prepareString(answer.Content)
+		preparedAnswer := emu.prepareString(normalizer, answer.Content)
 		score := 0.0
 		reasoning := "No exact match"
 
@@ -212,27 +237,19 @@ func (emu *ExactMatchUnit) Execute(ctx context.Context, state domain.State) (dom
 		attribute.Bool("no_llm_cost", true), // Deterministic units have no LLM cost
 	)
 
-	return domain.With(state, domain.KeyJudgeScores, judgeSummaries), nil
+	newState := domain.WithJudgeScores(state, emu.name, judgeSummaries)
+	return withConclusion(newState, emu.name, emu.config.Conclusive, answers, judgeSummaries), nil
 }
 
 // prepareString normalizes a string according to the unit's configuration.
-// Applies transformations in order: whitespace trimming, then case folding.
-// Uses Unicode-aware case folding for proper internationalization support.
-func (emu *ExactMatchUnit) prepareString(s string) string {
-	result := s
-
+// Applies transformations in order: whitespace trimming, then the case
+// folding and stopword removal of normalizer, which Execute builds once
+// from the configuration current at the start of the call.
+func (emu *ExactMatchUnit) prepareString(normalizer *textnorm.Normalizer, s string) string {
 	if emu.config.TrimWhitespace {
-		result = strings.TrimSpace(result)
+		s = strings.TrimSpace(s)
 	}
-
-	if !emu.config.CaseSensitive {
-		// Use Unicode-aware case folding for proper internationalization.
-		// This handles complex Unicode characters correctly, unlike strings.ToLower.
-		caser := cases.Fold()
-		result = caser.String(result)
-	}
-
-	return result
+	return normalizer.Normalize(s)
 }
 
 // Validate verifies the unit is properly configured and ready for execution.
@@ -244,7 +261,7 @@ func (emu *ExactMatchUnit) prepareString(s string) string {
 // using the validator package.
 func (emu *ExactMatchUnit) Validate() error {
 	if err := validate.Struct(emu.config); err != nil {
-		return fmt.Errorf("configuration validation failed: %w", err)
+		return domain.NewConfigValidationError(emu.name, fieldValidationError(err))
 	}
 
 	return nil
@@ -268,7 +285,7 @@ func (emu *ExactMatchUnit) UnmarshalParameters(params yaml.Node) error {
 	}
 
 	if err := validate.Struct(config); err != nil {
-		return fmt.Errorf("parameter validation failed: %w", err)
+		return fmt.Errorf("parameter validation failed: %w", fieldValidationError(err))
 	}
 
 	emu.config = config
@@ -0,0 +1,293 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+	"github.com/ahrav/go-gavel/internal/textnorm"
+)
+
+var _ ports.Unit = (*FormatValidationUnit)(nil)
+
+// Answer types supported by FormatValidationUnit.
+const (
+	FormatTypeInteger = "integer"
+	FormatTypeFloat   = "float"
+	FormatTypeDate    = "date"
+	FormatTypeEnum    = "enum"
+	FormatTypeURL     = "url"
+	FormatTypeEmail   = "email"
+)
+
+// FormatValidationUnit checks that each candidate answer parses as a declared
+// type such as an integer, a date in a given layout, or one of a fixed set of
+// values. Each answer receives a binary score: 1.0 when it parses or 0.0 when
+// it does not, with the parse error as the reasoning.
+//
+// The unit is deterministic and costs no LLM calls, which makes it a cheap
+// early gate ahead of LLM judges when answers must follow a strict format.
+//
+// Concurrency: FormatValidationUnit is stateless and safe for concurrent
+// execution.
+type FormatValidationUnit struct {
+	// name is the unique identifier for this unit instance.
+	name string
+	// config contains the validated configuration parameters.
+	config FormatValidationConfig
+	// tracer is the OpenTelemetry tracer for observability.
+	tracer trace.Tracer
+}
+
+// FormatValidationConfig declares the type answers must parse as.
+type FormatValidationConfig struct {
+	// Type is the expected answer type: integer, float, date, enum, url or
+	// email.
+	Type string `yaml:"type" json:"type" validate:"required,oneof=integer float date enum url email"`
+
+	// DateFormat is the Go time layout dates must match.
+	// Default: "2006-01-02".
+	DateFormat string `yaml:"date_format" json:"date_format"`
+
+	// Values lists the accepted answers when Type is enum.
+	Values []string `yaml:"values" json:"values" validate:"required_if=Type enum,dive,required"`
+
+	// CaseSensitive controls whether enum values are compared case
+	// sensitively. Default: false.
+	CaseSensitive bool `yaml:"case_sensitive" json:"case_sensitive"`
+
+	// TrimWhitespace controls whether leading and trailing whitespace is
+	// removed before parsing. Default: true.
+	TrimWhitespace bool `yaml:"trim_whitespace" json:"trim_whitespace"`
+}
+
+// NewFormatValidationUnit creates a FormatValidationUnit with validated
+// configuration. Returns ErrEmptyUnitName if name is empty, or a
+// configuration validation error if config fails validation.
+func NewFormatValidationUnit(name string, config FormatValidationConfig) (*FormatValidationUnit, error) {
+	if name == "" {
+		return nil, ErrEmptyUnitName
+	}
+
+	if err := validate.Struct(config); err != nil {
+		return nil, domain.NewConfigValidationError(name, fieldValidationError(err))
+	}
+
+	return &FormatValidationUnit{
+		name:   name,
+		config: config,
+		tracer: otel.Tracer("format-validation-unit"),
+	}, nil
+}
+
+// Name returns the unique identifier for this unit instance.
+func (fvu *FormatValidationUnit) Name() string { return fvu.name }
+
+// Execute scores each answer under domain.KeyAnswers by whether it parses
+// as the configured type and returns a new state with the results under
+// domain.KeyJudgeScores on a 0-1 scale. No reference answer is needed.
+func (fvu *FormatValidationUnit) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	_, span := fvu.tracer.Start(ctx, "FormatValidationUnit.Execute",
+		trace.WithAttributes(
+			attribute.String("unit.type", "format_validation"),
+			attribute.String("unit.id", fvu.name),
+			attribute.String("config.type", fvu.config.Type),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+
+	answers, err := domain.Require(state, domain.KeyAnswers, fvu.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	if len(answers) == 0 {
+		err := fmt.Errorf("no answers provided for format validation")
+		span.RecordError(err)
+		return state, err
+	}
+
+	if len(answers) > MaxAnswers {
+		err := fmt.Errorf("too many answers: %d exceeds limit of %d", len(answers), MaxAnswers)
+		span.RecordError(err)
+		return state, err
+	}
+
+	judgeSummaries := make([]domain.JudgeSummary, len(answers))
+	valid := 0
+
+	for i, answer := range answers {
+		if err := ctx.Err(); err != nil {
+			span.RecordError(err)
+			return state, err
+		}
+
+		if len(answer.Content) > MaxStringLength {
+			err := fmt.Errorf("answer %d too long: %d bytes exceeds limit of %d", i, len(answer.Content), MaxStringLength)
+			span.RecordError(err)
+			return state, err
+		}
+
+		score := 0.0
+		reasoning := fmt.Sprintf("Valid %s", fvu.config.Type)
+		if err := fvu.check(answer.Content); err != nil {
+			reasoning = fmt.Sprintf("Not a valid %s: %v", fvu.config.Type, err)
+		} else {
+			score = 1.0
+			valid++
+		}
+
+		judgeSummaries[i] = domain.JudgeSummary{
+			Score:      score,
+			Reasoning:  reasoning,
+			Confidence: 1.0, // Parsing is deterministic.
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Float64("eval.score", float64(valid)/float64(len(answers))),
+		attribute.Int64("eval.latency_ms", time.Since(start).Milliseconds()),
+		attribute.Int("eval.answers_count", len(answers)),
+		attribute.Int("eval.valid_count", valid),
+		attribute.Bool("no_llm_cost", true),
+	)
+
+	newState := domain.WithJudgeScores(state, fvu.name, judgeSummaries)
+	return domain.WithJudgeScoreScale(newState, fvu.name, domain.UnitScoreRange), nil
+}
+
+// check returns nil if content parses as the configured type, or the
+// reason it does not.
+func (fvu *FormatValidationUnit) check(content string) error {
+	if fvu.config.TrimWhitespace {
+		content = strings.TrimSpace(content)
+	}
+	if content == "" {
+		return fmt.Errorf("answer is empty")
+	}
+
+	switch fvu.config.Type {
+	case FormatTypeInteger:
+		_, err := strconv.ParseInt(content, 10, 64)
+		return err
+	case FormatTypeFloat:
+		_, err := strconv.ParseFloat(content, 64)
+		return err
+	case FormatTypeDate:
+		_, err := time.Parse(fvu.dateFormat(), content)
+		return err
+	case FormatTypeEnum:
+		return fvu.checkEnum(content)
+	case FormatTypeURL:
+		u, err := url.ParseRequestURI(content)
+		if err != nil {
+			return err
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("URL %q must have a scheme and host", content)
+		}
+		return nil
+	case FormatTypeEmail:
+		addr, err := mail.ParseAddress(content)
+		if err != nil {
+			return err
+		}
+		if addr.Address != content {
+			return fmt.Errorf("%q is not a bare email address", content)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported type %q", fvu.config.Type)
+	}
+}
+
+// checkEnum reports whether content is one of the configured values.
+func (fvu *FormatValidationUnit) checkEnum(content string) error {
+	if fvu.config.CaseSensitive {
+		if slices.Contains(fvu.config.Values, content) {
+			return nil
+		}
+	} else {
+		folded := textnorm.Fold(content)
+		if slices.ContainsFunc(fvu.config.Values, func(v string) bool { return textnorm.Fold(v) == folded }) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is not one of %s", content, strings.Join(fvu.config.Values, ", "))
+}
+
+// dateFormat returns the configured date layout or the default.
+func (fvu *FormatValidationUnit) dateFormat() string {
+	if fvu.config.DateFormat == "" {
+		return time.DateOnly
+	}
+	return fvu.config.DateFormat
+}
+
+// Validate verifies the unit is properly configured and ready for execution.
+func (fvu *FormatValidationUnit) Validate() error {
+	if err := validate.Struct(fvu.config); err != nil {
+		return domain.NewConfigValidationError(fvu.name, fieldValidationError(err))
+	}
+
+	return nil
+}
+
+// UnmarshalParameters deserializes YAML configuration into the unit's
+// config, leaving it unchanged on error.
+func (fvu *FormatValidationUnit) UnmarshalParameters(params yaml.Node) error {
+	config := DefaultFormatValidationConfig()
+
+	if err := params.Decode(&config); err != nil {
+		return fmt.Errorf("failed to decode parameters: %w", err)
+	}
+
+	if err := validate.Struct(config); err != nil {
+		return fmt.Errorf("parameter validation failed: %w", fieldValidationError(err))
+	}
+
+	fvu.config = config
+	return nil
+}
+
+// DefaultFormatValidationConfig returns a FormatValidationConfig with
+// ISO 8601 dates, case-insensitive enums and whitespace trimming. Type has
+// no default and must be set.
+func DefaultFormatValidationConfig() FormatValidationConfig {
+	return FormatValidationConfig{
+		DateFormat:     time.DateOnly,
+		CaseSensitive:  false,
+		TrimWhitespace: true,
+	}
+}
+
+// NewFormatValidationFromConfig creates a FormatValidationUnit from a
+// configuration map. Format validation doesn't require an LLM client.
+func NewFormatValidationFromConfig(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error) {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	cfg := DefaultFormatValidationConfig()
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+
+	return NewFormatValidationUnit(id, cfg)
+}
@@ -0,0 +1,196 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+func TestNewFormatValidationUnit(t *testing.T) {
+	tests := []struct {
+		name      string
+		unitName  string
+		config    FormatValidationConfig
+		wantError string
+	}{
+		{
+			name:     "valid integer",
+			unitName: "format",
+			config:   FormatValidationConfig{Type: FormatTypeInteger},
+		},
+		{
+			name:      "empty unit name",
+			config:    FormatValidationConfig{Type: FormatTypeInteger},
+			wantError: "unit name cannot be empty",
+		},
+		{
+			name:      "missing type",
+			unitName:  "format",
+			config:    DefaultFormatValidationConfig(),
+			wantError: "type failed rule",
+		},
+		{
+			name:      "unknown type",
+			unitName:  "format",
+			config:    FormatValidationConfig{Type: "uuid"},
+			wantError: "type failed rule",
+		},
+		{
+			name:      "enum without values",
+			unitName:  "format",
+			config:    FormatValidationConfig{Type: FormatTypeEnum},
+			wantError: "values failed rule",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := NewFormatValidationUnit(tt.unitName, tt.config)
+			if tt.wantError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantError)
+				assert.Nil(t, unit)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.unitName, unit.Name())
+			assert.NoError(t, unit.Validate())
+		})
+	}
+}
+
+func TestFormatValidationUnit_Execute(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  FormatValidationConfig
+		answers []string
+		want    []float64
+	}{
+		{
+			name:    "integer",
+			config:  FormatValidationConfig{Type: FormatTypeInteger, TrimWhitespace: true},
+			answers: []string{"42", " -7 ", "3.5", "forty"},
+			want:    []float64{1, 1, 0, 0},
+		},
+		{
+			name:    "float",
+			config:  FormatValidationConfig{Type: FormatTypeFloat, TrimWhitespace: true},
+			answers: []string{"3.14", "1e3", "42", "pi"},
+			want:    []float64{1, 1, 1, 0},
+		},
+		{
+			name:    "default date format",
+			config:  FormatValidationConfig{Type: FormatTypeDate, TrimWhitespace: true},
+			answers: []string{"2024-02-29", "2023-02-29", "02/01/2024"},
+			want:    []float64{1, 0, 0},
+		},
+		{
+			name:    "custom date format",
+			config:  FormatValidationConfig{Type: FormatTypeDate, DateFormat: "01/02/2006"},
+			answers: []string{"02/01/2024", "2024-02-01"},
+			want:    []float64{1, 0},
+		},
+		{
+			name:    "case-insensitive enum",
+			config:  FormatValidationConfig{Type: FormatTypeEnum, Values: []string{"Yes", "No"}, TrimWhitespace: true},
+			answers: []string{"yes", " NO ", "maybe"},
+			want:    []float64{1, 1, 0},
+		},
+		{
+			name:    "case-sensitive enum",
+			config:  FormatValidationConfig{Type: FormatTypeEnum, Values: []string{"Yes", "No"}, CaseSensitive: true},
+			answers: []string{"Yes", "yes"},
+			want:    []float64{1, 0},
+		},
+		{
+			name:    "url",
+			config:  FormatValidationConfig{Type: FormatTypeURL},
+			answers: []string{"https://example.com/path", "example.com", "/relative"},
+			want:    []float64{1, 0, 0},
+		},
+		{
+			name:    "email",
+			config:  FormatValidationConfig{Type: FormatTypeEmail},
+			answers: []string{"user@example.com", "Jo <jo@example.com>", "not-an-email"},
+			want:    []float64{1, 0, 0},
+		},
+		{
+			name:    "whitespace kept when not trimming",
+			config:  FormatValidationConfig{Type: FormatTypeInteger},
+			answers: []string{" 42", ""},
+			want:    []float64{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := NewFormatValidationUnit("format", tt.config)
+			require.NoError(t, err)
+
+			answers := make([]domain.Answer, len(tt.answers))
+			for i, content := range tt.answers {
+				answers[i] = domain.Answer{ID: "a" + string(rune('0'+i)), Content: content}
+			}
+			state := domain.With(domain.NewState(), domain.KeyAnswers, answers)
+
+			result, err := unit.Execute(context.Background(), state)
+			require.NoError(t, err)
+
+			scores, ok := domain.Get(result, domain.KeyJudgeScores)
+			require.True(t, ok)
+			require.Len(t, scores, len(tt.want))
+			for i, want := range tt.want {
+				assert.Equal(t, want, scores[i].Score, "answer %q", tt.answers[i])
+				assert.Equal(t, 1.0, scores[i].Confidence)
+				if want == 0 {
+					assert.Contains(t, scores[i].Reasoning, "Not a valid "+tt.config.Type)
+				}
+			}
+
+			scale, ok := domain.JudgeScoreScale(result, "format")
+			require.True(t, ok)
+			assert.Equal(t, domain.UnitScoreRange, scale)
+		})
+	}
+}
+
+func TestFormatValidationUnit_ReasoningIncludesParseError(t *testing.T) {
+	unit, err := NewFormatValidationUnit("format", FormatValidationConfig{Type: FormatTypeInteger})
+	require.NoError(t, err)
+
+	state := domain.With(domain.NewState(), domain.KeyAnswers, []domain.Answer{{ID: "a", Content: "abc"}})
+	result, err := unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+
+	scores, _ := domain.Get(result, domain.KeyJudgeScores)
+	assert.Contains(t, scores[0].Reasoning, "invalid syntax")
+}
+
+func TestFormatValidationUnit_MissingAnswers(t *testing.T) {
+	unit, err := NewFormatValidationUnit("format", FormatValidationConfig{Type: FormatTypeInteger})
+	require.NoError(t, err)
+
+	_, err = unit.Execute(context.Background(), domain.NewState())
+	require.Error(t, err)
+	var missing *domain.MissingStateError
+	assert.ErrorAs(t, err, &missing)
+}
+
+func TestNewFormatValidationFromConfig(t *testing.T) {
+	unit, err := NewFormatValidationFromConfig("format", map[string]any{
+		"type":   "enum",
+		"values": []any{"red", "green"},
+	}, nil)
+	require.NoError(t, err)
+
+	fvu := unit.(*FormatValidationUnit)
+	assert.Equal(t, []string{"red", "green"}, fvu.config.Values)
+	assert.True(t, fvu.config.TrimWhitespace, "defaults fill unset keys")
+
+	_, err = NewFormatValidationFromConfig("format", map[string]any{}, nil)
+	assert.Error(t, err, "type is required")
+}
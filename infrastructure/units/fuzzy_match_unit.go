@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 	"unicode/utf8"
 
@@ -11,19 +13,42 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
-	"golang.org/x/text/cases"
 	"gopkg.in/yaml.v3"
 
 	"github.com/ahrav/go-gavel/internal/domain"
 	"github.com/ahrav/go-gavel/internal/ports"
+	"github.com/ahrav/go-gavel/internal/textnorm"
 )
 
-var (
-	_ ports.Unit = (*FuzzyMatchUnit)(nil)
+var _ ports.Unit = (*FuzzyMatchUnit)(nil)
 
-	// foldCaser is a package-level Unicode case folder for performance.
-	// This avoids creating a new caser for each string preparation.
-	foldCaser = cases.Fold()
+// Supported matching modes for the FuzzyMatchUnit.
+const (
+	// MatchModeFull compares the whole candidate against the whole reference.
+	MatchModeFull = "full"
+
+	// MatchModeBestSubstring compares the reference against the most similar
+	// substring of the candidate, so answers that embed the reference inside
+	// a longer response still score highly.
+	MatchModeBestSubstring = "best_substring"
+
+	// ConfidenceFixed reports a confidence of 1.0 for every answer, reflecting
+	// that matching is deterministic. This is the default.
+	ConfidenceFixed = "fixed"
+
+	// ConfidenceMargin derives confidence from how far the similarity lies
+	// from the threshold, normalized to the available range on that side.
+	// Borderline matches and near-misses both report low confidence, while
+	// exact matches and clear mismatches report 1.0.
+	ConfidenceMargin = "margin"
+
+	// ConfidenceSimilarity reports the raw similarity as the confidence.
+	ConfidenceSimilarity = "similarity"
+
+	// MaxSubstringMatchCells caps the dynamic programming table size
+	// (reference runes x candidate runes) for best_substring matching,
+	// bounding CPU time well below what MaxStringLength alone would allow.
+	MaxSubstringMatchCells = 50_000_000
 )
 
 // FuzzyMatchUnit implements a deterministic Unit that performs fuzzy string matching
@@ -41,6 +66,10 @@ type FuzzyMatchUnit struct {
 	name string
 	// config contains the validated configuration parameters.
 	config FuzzyMatchConfig
+	// tokenizer splits strings into the tokens compared by edit distance.
+	tokenizer Tokenizer
+	// normalizer applies the configured case folding and stopword removal.
+	normalizer *textnorm.Normalizer
 	// tracer is the OpenTelemetry tracer for observability.
 	tracer trace.Tracer
 }
@@ -57,8 +86,126 @@ type FuzzyMatchConfig struct {
 	Threshold float64 `yaml:"threshold" json:"threshold" validate:"min=0.0,max=1.0"`
 
 	// CaseSensitive determines whether string comparison is case-sensitive.
-	// When false, both strings are converted to lowercase before comparison.
+	// When false, both strings are case folded before comparison following
+	// the textnorm folding policy.
 	CaseSensitive bool `yaml:"case_sensitive" json:"case_sensitive"`
+
+	// RemoveStopwords drops stopwords from answers and the reference before
+	// comparison, leaving the remaining words separated by single spaces.
+	RemoveStopwords bool `yaml:"remove_stopwords" json:"remove_stopwords"`
+
+	// Stopwords replaces the default English stopword list used when
+	// RemoveStopwords is set.
+	Stopwords []string `yaml:"stopwords" json:"stopwords" validate:"omitempty,dive,required"`
+
+	// MatchMode selects how the reference is compared to each answer.
+	// "full" (the default) compares whole strings, while "best_substring"
+	// scores the best-matching window of the answer against the reference.
+	MatchMode string `yaml:"match_mode" json:"match_mode" validate:"omitempty,oneof=full best_substring"`
+
+	// ConfidenceMode selects how each answer's confidence is computed so that
+	// confidence-weighted aggregators can discount borderline matches.
+	// "fixed" (the default) always reports 1.0; "margin" and "similarity"
+	// derive confidence from the raw similarity.
+	ConfidenceMode string `yaml:"confidence_mode" json:"confidence_mode" validate:"omitempty,oneof=fixed margin similarity"`
+
+	// Tokenizer selects the units the edit distance counts: "character"
+	// (the default) compares runes, while "whitespace" and "subword" count
+	// token insertions, deletions, and substitutions instead.
+	Tokenizer string `yaml:"tokenizer" json:"tokenizer" validate:"omitempty,oneof=character whitespace subword"`
+
+	// UnorderedDelimiter, when set, treats answers and the reference as
+	// unordered lists: both are split on this delimiter, each part is
+	// trimmed of surrounding whitespace, empty parts are dropped, and the
+	// parts are sorted and rejoined before similarity is computed, so
+	// "a, b, c" and "c, b, a" match exactly. Case folding is applied first,
+	// so with CaseSensitive false the sort order ignores case; with
+	// CaseSensitive true parts are sorted by byte value. Empty (the
+	// default) compares answers as written.
+	UnorderedDelimiter string `yaml:"unordered_delimiter" json:"unordered_delimiter" validate:"omitempty,max=16"`
+
+	// EmptyAnswerPolicy controls how empty or whitespace-only answers are
+	// handled: "skip" (the default) assigns EmptyAnswerScore without
+	// comparing, "send" compares them normally, and "reject" fails with
+	// ErrEmptyAnswer.
+	EmptyAnswerPolicy EmptyAnswerPolicy `yaml:"empty_answer_policy" json:"empty_answer_policy" validate:"omitempty,oneof=skip send reject"`
+
+	// EmptyAnswerScore is the score (0.0-1.0) assigned to skipped empty answers.
+	EmptyAnswerScore float64 `yaml:"empty_answer_score" json:"empty_answer_score" validate:"min=0.0,max=1.0"`
+
+	// OnNoAnswers selects what happens when the item has no answers:
+	// "error" (the default) fails, and "abstain" records a verdict with
+	// status domain.VerdictNoAnswers so batch processing continues.
+	OnNoAnswers NoAnswersPolicy `yaml:"on_no_answers,omitempty" json:"on_no_answers,omitempty" validate:"omitempty,oneof=error abstain"`
+
+	// EditWeights sets asymmetric costs for edit operations in "full" match
+	// mode, e.g. a low insertion cost to be lenient about answers that add
+	// extra detail. Nil (the default) counts every edit as 1.
+	EditWeights *EditWeights `yaml:"edit_weights" json:"edit_weights" validate:"omitempty"`
+
+	// LengthFloor discounts inexact matches between short strings, which
+	// score deceptively high because there are few symbols to differ: "ab"
+	// against "ac" is 50% similar. When the compared length, in the
+	// tokenizer's units, is below LengthFloor, similarity is scaled by
+	// length/LengthFloor before the threshold is applied. The compared
+	// length is the longer of the answer and the reference in "full" mode
+	// and the reference in "best_substring" mode. Answers identical to the
+	// reference after normalization keep full credit. Zero (the default)
+	// disables the discount.
+	LengthFloor int `yaml:"length_floor" json:"length_floor" validate:"min=0,max=10000"`
+
+	// Conclusive, when set, ends the evaluation after this unit once an
+	// answer's score reaches its MinScore, skipping later stages such as
+	// LLM judges. Nil (the default) always runs later stages.
+	Conclusive *ConclusiveConfig `yaml:"conclusive,omitempty" json:"conclusive,omitempty" validate:"omitempty"`
+}
+
+// EditWeights sets the cost of each edit operation that turns the reference
+// into a candidate answer. Weighted distances are normalized by the largest
+// distance possible for the two lengths, so similarity stays in 0-1. Zero
+// weights default to 1.
+type EditWeights struct {
+	// Insertion is the cost of content in the answer that is absent from
+	// the reference.
+	Insertion float64 `yaml:"insertion" json:"insertion" validate:"omitempty,gt=0,max=10"`
+
+	// Deletion is the cost of reference content missing from the answer.
+	Deletion float64 `yaml:"deletion" json:"deletion" validate:"omitempty,gt=0,max=10"`
+
+	// Substitution is the cost of replacing reference content.
+	Substitution float64 `yaml:"substitution" json:"substitution" validate:"omitempty,gt=0,max=10"`
+}
+
+// withDefaults returns the weights with zero values replaced by 1.
+func (w EditWeights) withDefaults() EditWeights {
+	for _, weight := range []*float64{&w.Insertion, &w.Deletion, &w.Substitution} {
+		if *weight == 0 {
+			*weight = 1
+		}
+	}
+	return w
+}
+
+// validateFuzzyMatchConfig checks the configuration's struct tags and the
+// constraints between fields.
+func validateFuzzyMatchConfig(config FuzzyMatchConfig) error {
+	if err := validate.Struct(config); err != nil {
+		return fieldValidationError(err)
+	}
+	if config.EditWeights != nil && config.MatchMode == MatchModeBestSubstring {
+		return fmt.Errorf("edit_weights is only supported with match_mode %q", MatchModeFull)
+	}
+	return nil
+}
+
+// normalizer returns the text normalizer for the configured folding and
+// stopword policy.
+func (c FuzzyMatchConfig) normalizer() *textnorm.Normalizer {
+	return textnorm.New(textnorm.Policy{
+		CaseSensitive:   c.CaseSensitive,
+		RemoveStopwords: c.RemoveStopwords,
+		Stopwords:       c.Stopwords,
+	})
 }
 
 // NewFuzzyMatchUnit creates a new FuzzyMatchUnit with the specified configuration.
@@ -69,14 +216,21 @@ func NewFuzzyMatchUnit(name string, config FuzzyMatchConfig) (*FuzzyMatchUnit, e
 		return nil, ErrEmptyUnitName
 	}
 
-	if err := validate.Struct(config); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	if err := validateFuzzyMatchConfig(config); err != nil {
+		return nil, domain.NewConfigValidationError(name, err)
+	}
+
+	tokenizer, err := NewTokenizer(config.Tokenizer)
+	if err != nil {
+		return nil, domain.NewConfigValidationError(name, err)
 	}
 
 	return &FuzzyMatchUnit{
-		name:   name,
-		config: config,
-		tracer: otel.Tracer("fuzzy-match-unit"),
+		name:       name,
+		config:     config,
+		tokenizer:  tokenizer,
+		normalizer: config.normalizer(),
+		tracer:     otel.Tracer("fuzzy-match-unit"),
 	}, nil
 }
 
@@ -95,16 +249,27 @@ func (fmu *FuzzyMatchUnit) Execute(ctx context.Context, state domain.State) (dom
 			attribute.String("config.algorithm", fmu.config.Algorithm),
 			attribute.Float64("config.threshold", fmu.config.Threshold),
 			attribute.Bool("config.case_sensitive", fmu.config.CaseSensitive),
+			attribute.Bool("config.remove_stopwords", fmu.config.RemoveStopwords),
+			attribute.String("config.match_mode", fmu.matchMode()),
+			attribute.String("config.confidence_mode", fmu.confidenceMode()),
+			attribute.String("config.tokenizer", fmu.tokenizerName()),
+			attribute.Bool("config.unordered", fmu.config.UnorderedDelimiter != ""),
+			attribute.Bool("config.weighted_edits", fmu.config.EditWeights != nil),
+			attribute.Int("config.length_floor", fmu.config.LengthFloor),
 		),
 	)
 	defer span.End()
 
 	start := time.Now()
 
+	if fmu.config.OnNoAnswers == NoAnswersAbstain && hasNoAnswers(state) {
+		span.SetAttributes(attribute.Bool("eval.no_answers", true))
+		return withNoAnswersVerdict(state, fmu.name), nil
+	}
+
 	// Extract candidate answers from state.
-	answers, ok := domain.Get(state, domain.KeyAnswers)
-	if !ok {
-		err := fmt.Errorf("answers not found in state")
+	answers, err := domain.Require(state, domain.KeyAnswers, fmu.name)
+	if err != nil {
 		span.RecordError(err)
 		return state, err
 	}
@@ -123,7 +288,7 @@ func (fmu *FuzzyMatchUnit) Execute(ctx context.Context, state domain.State) (dom
 	}
 
 	// Extract reference answer from state.
-	referenceAnswer, ok := domain.Get(state, domain.KeyReferenceAnswer)
+	referenceAnswer, ok := state.GetReferenceAnswer()
 	if !ok {
 		err := fmt.Errorf("reference_answer required for deterministic evaluation")
 		span.RecordError(err)
@@ -136,21 +301,60 @@ func (fmu *FuzzyMatchUnit) Execute(ctx context.Context, state domain.State) (dom
 		return state, err
 	}
 
-	preparedReference := fmu.prepareString(referenceAnswer)
+	// The reference is normalized and tokenized once and shared by every
+	// candidate comparison below.
+	preparedReference := newPreparedText(fmu.prepareString(referenceAnswer), fmu.tokenizer)
 
 	// Compute fuzzy match scores for each answer.
 	judgeSummaries := make([]domain.JudgeSummary, len(answers))
 	totalScore := 0.0
 
 	for i, answer := range answers {
+		if err := ctx.Err(); err != nil {
+			span.RecordError(err)
+			return state, err
+		}
+
 		if len(answer.Content) > MaxStringLength {
 			err := fmt.Errorf("answer %d too long: %d bytes exceeds limit of %d", i, len(answer.Content), MaxStringLength)
 			span.RecordError(err)
 			return state, err
 		}
 
+		if isBlankAnswer(answer.Content) {
+			switch fmu.config.EmptyAnswerPolicy {
+			case EmptyAnswerSend:
+			case EmptyAnswerReject:
+				err := fmt.Errorf("answer %d (%s): %w", i, answer.ID, ErrEmptyAnswer)
+				span.RecordError(err)
+				return state, err
+			default:
+				judgeSummaries[i] = emptyAnswerSummary(fmu.config.EmptyAnswerScore)
+				totalScore += fmu.config.EmptyAnswerScore
+				continue
+			}
+		}
+
 		preparedAnswer := fmu.prepareString(answer.Content)
-		rawSimilarity := fmu.calculateSimilarity(preparedAnswer, preparedReference)
+
+		var rawSimilarity float64
+		if fmu.matchMode() == MatchModeBestSubstring {
+			var err error
+			rawSimilarity, err = fmu.substringSimilarityTo(preparedAnswer, preparedReference)
+			if err != nil {
+				err := fmt.Errorf("answer %d: %w", i, err)
+				span.RecordError(err)
+				return state, err
+			}
+		} else {
+			rawSimilarity = fmu.similarityTo(preparedAnswer, preparedReference)
+		}
+
+		var lengthNote string
+		if length, discounted := fmu.shortLength(preparedAnswer, preparedReference, rawSimilarity); discounted {
+			rawSimilarity *= float64(length) / float64(fmu.config.LengthFloor)
+			lengthNote = fmt.Sprintf(", discounted for length %d below floor %d", length, fmu.config.LengthFloor)
+		}
 
 		// Apply threshold to determine final score.
 		// Raw similarity below threshold is treated as no match (0.0) to filter weak matches.
@@ -165,11 +369,12 @@ func (fmu *FuzzyMatchUnit) Execute(ctx context.Context, state domain.State) (dom
 				rawSimilarity*100,
 				fmu.config.Threshold*100)
 		}
+		reasoning += lengthNote
 
 		judgeSummaries[i] = domain.JudgeSummary{
 			Score:      score,
 			Reasoning:  reasoning,
-			Confidence: 1.0, // Deterministic matching has perfect confidence
+			Confidence: fmu.confidence(rawSimilarity),
 		}
 
 		totalScore += score
@@ -186,30 +391,118 @@ func (fmu *FuzzyMatchUnit) Execute(ctx context.Context, state domain.State) (dom
 		attribute.Bool("no_llm_cost", true), // Deterministic units have no LLM cost
 	)
 
-	return domain.With(state, domain.KeyJudgeScores, judgeSummaries), nil
+	newState := domain.WithJudgeScores(state, fmu.name, judgeSummaries)
+	return withConclusion(newState, fmu.name, fmu.config.Conclusive, answers, judgeSummaries), nil
+}
+
+// matchMode returns the configured match mode, treating an empty value as
+// the default full-string mode.
+func (fmu *FuzzyMatchUnit) matchMode() string {
+	if fmu.config.MatchMode == "" {
+		return MatchModeFull
+	}
+	return fmu.config.MatchMode
+}
+
+// tokenizerName returns the configured tokenizer, treating an empty value as
+// the default character tokenizer.
+func (fmu *FuzzyMatchUnit) tokenizerName() string {
+	if fmu.config.Tokenizer == "" {
+		return TokenizerCharacter
+	}
+	return fmu.config.Tokenizer
+}
+
+// runeLevel reports whether distances are computed over runes, which takes
+// the optimized string paths instead of tokenizing first.
+func (fmu *FuzzyMatchUnit) runeLevel() bool {
+	_, ok := fmu.tokenizer.(CharacterTokenizer)
+	return fmu.tokenizer == nil || ok
+}
+
+// shortLength returns the compared length of a prepared answer and
+// reference, and whether it is below LengthFloor so that similarity should
+// be discounted. Exact matches and disabled floors are never discounted.
+func (fmu *FuzzyMatchUnit) shortLength(answer string, reference *preparedText, similarity float64) (int, bool) {
+	if fmu.config.LengthFloor == 0 || similarity == 1.0 {
+		return 0, false
+	}
+
+	var answerLen, referenceLen int
+	if fmu.runeLevel() {
+		answerLen, referenceLen = utf8.RuneCountInString(answer), reference.RuneCount()
+	} else {
+		answerLen, referenceLen = len(fmu.tokenizer.Tokenize(answer)), len(reference.Tokens())
+	}
+
+	length := max(answerLen, referenceLen)
+	if fmu.matchMode() == MatchModeBestSubstring {
+		length = referenceLen
+	}
+	return length, length < fmu.config.LengthFloor
 }
 
 // prepareString normalizes a string according to the unit's configuration.
-// It applies case conversion as specified.
+// It applies case folding and stopword removal as specified, then
+// canonicalizes part order when UnorderedDelimiter is set.
 func (fmu *FuzzyMatchUnit) prepareString(s string) string {
-	result := s
+	result := fmu.normalizer.Normalize(s)
 
-	if !fmu.config.CaseSensitive {
-		result = foldCaser.String(result)
+	if fmu.config.UnorderedDelimiter != "" {
+		result = sortParts(result, fmu.config.UnorderedDelimiter)
 	}
 
 	return result
 }
 
+// sortParts splits s on delimiter, trims and drops empty parts, and rejoins
+// the sorted parts with delimiter followed by a space.
+func sortParts(s, delimiter string) string {
+	parts := strings.Split(s, delimiter)
+	kept := parts[:0]
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			kept = append(kept, part)
+		}
+	}
+	slices.Sort(kept)
+	return strings.Join(kept, delimiter+" ")
+}
+
 // calculateSimilarity computes the similarity score between two strings
 // using the Levenshtein distance algorithm. Returns a value between 0.0 and 1.0
 // where 1.0 indicates identical strings and 0.0 indicates maximum dissimilarity.
-// TODO: there might be room for performance improvements here.
+// With a non-character tokenizer the distance counts token edits and is
+// normalized by the longer token sequence.
 func (fmu *FuzzyMatchUnit) calculateSimilarity(s1, s2 string) float64 {
+	return fmu.similarityTo(s1, newPreparedText(s2, fmu.tokenizer))
+}
+
+// similarityTo implements calculateSimilarity against a prepared reference
+// so its tokens are computed once across candidates.
+func (fmu *FuzzyMatchUnit) similarityTo(s1 string, reference *preparedText) float64 {
+	s2 := reference.Text()
 	if s1 == s2 {
 		return 1.0
 	}
 
+	if fmu.config.EditWeights != nil {
+		weights := fmu.config.EditWeights.withDefaults()
+		if fmu.runeLevel() {
+			return weightedSimilarity([]rune(s1), reference.Runes(), weights)
+		}
+		return weightedSimilarity(fmu.tokenizer.Tokenize(s1), reference.Tokens(), weights)
+	}
+
+	if !fmu.runeLevel() {
+		t1, t2 := fmu.tokenizer.Tokenize(s1), reference.Tokens()
+		maxLen := max(len(t1), len(t2))
+		if maxLen == 0 {
+			return 1.0
+		}
+		return max(1.0-float64(editDistance(t1, t2))/float64(maxLen), 0)
+	}
+
 	// Calculate Levenshtein distance (operates on runes for Unicode correctness).
 	// The levenshtein library correctly handles multi-byte UTF-8 characters.
 	distance := levenshtein.ComputeDistance(s1, s2)
@@ -217,10 +510,7 @@ func (fmu *FuzzyMatchUnit) calculateSimilarity(s1, s2 string) float64 {
 	// Calculate maximum possible distance using rune count for correct Unicode handling.
 	// The Levenshtein distance operates on runes, so we must use rune count for consistency.
 	// For example: "café" has 4 runes but 5 bytes due to the é character.
-	maxLen := utf8.RuneCountInString(s1)
-	if n := utf8.RuneCountInString(s2); n > maxLen {
-		maxLen = n
-	}
+	maxLen := max(utf8.RuneCountInString(s1), reference.RuneCount())
 
 	// Handle edge case where both strings are empty.
 	// Two empty strings are considered identical (similarity = 1.0).
@@ -242,12 +532,176 @@ func (fmu *FuzzyMatchUnit) calculateSimilarity(s1, s2 string) float64 {
 	return similarity
 }
 
+// calculateSubstringSimilarity computes how closely the reference matches the
+// best local window of the candidate. It uses the semi-global variant of
+// Levenshtein distance (Sellers' algorithm), where skipping candidate runes
+// before and after the match is free, so the result is the minimum edit
+// distance between the reference and any substring of the candidate.
+//
+// The distance is normalized by the reference length, yielding a value between
+// 0.0 and 1.0 where 1.0 means the reference appears verbatim in the candidate.
+// With a non-character tokenizer the windows are token-aligned. Runs in
+// O(n*m) time and O(m) memory; inputs whose table would exceed
+// MaxSubstringMatchCells are rejected to keep evaluation bounded.
+func (fmu *FuzzyMatchUnit) calculateSubstringSimilarity(candidate, reference string) (float64, error) {
+	return fmu.substringSimilarityTo(candidate, newPreparedText(reference, fmu.tokenizer))
+}
+
+// substringSimilarityTo implements calculateSubstringSimilarity against a
+// prepared reference so its runes or tokens are computed once across
+// candidates.
+func (fmu *FuzzyMatchUnit) substringSimilarityTo(candidate string, reference *preparedText) (float64, error) {
+	if fmu.runeLevel() {
+		if reference.Text() == "" || strings.Contains(candidate, reference.Text()) {
+			return 1.0, nil
+		}
+		return substringSimilarity([]rune(candidate), reference.Runes(), "runes")
+	}
+	return substringSimilarity(fmu.tokenizer.Tokenize(candidate), reference.Tokens(), "tokens")
+}
+
+// substringSimilarity implements calculateSubstringSimilarity over any
+// sequence of comparable symbols. unit names the symbols in errors.
+func substringSimilarity[T comparable](cand, ref []T, unit string) (float64, error) {
+	if len(ref) == 0 {
+		return 1.0, nil
+	}
+	if len(ref)*len(cand) > MaxSubstringMatchCells {
+		return 0, fmt.Errorf("best_substring match too large: %d x %d %s exceeds limit of %d cells",
+			len(cand), len(ref), unit, MaxSubstringMatchCells)
+	}
+
+	// prev[i] holds the minimum cost of matching ref[:i] ending at the
+	// previous candidate position. The empty candidate prefix costs i deletions.
+	prev := make([]int, len(ref)+1)
+	cur := make([]int, len(ref)+1)
+	for i := range prev {
+		prev[i] = i
+	}
+	best := prev[len(ref)]
+
+	for _, c := range cand {
+		cur[0] = 0 // A match may start at any candidate position for free.
+		for i := 1; i <= len(ref); i++ {
+			cost := 1
+			if ref[i-1] == c {
+				cost = 0
+			}
+			cur[i] = min(prev[i-1]+cost, prev[i]+1, cur[i-1]+1)
+		}
+		best = min(best, cur[len(ref)])
+		prev, cur = cur, prev
+	}
+
+	similarity := 1.0 - float64(best)/float64(len(ref))
+	if similarity < 0 {
+		similarity = 0
+	}
+	return similarity, nil
+}
+
+// weightedSimilarity returns 1 minus the weighted edit distance that turns
+// ref into cand, normalized by the largest optimal distance possible for
+// their lengths: either deleting all of ref and inserting all of cand, or
+// substituting the overlap and inserting or deleting the remainder. With
+// unit weights this reduces to the unweighted normalization by the longer
+// length.
+func weightedSimilarity[T comparable](cand, ref []T, w EditWeights) float64 {
+	n, m := len(cand), len(ref)
+
+	// prev[j] holds the cost of turning the previous ref prefix into cand[:j].
+	prev := make([]float64, n+1)
+	cur := make([]float64, n+1)
+	for j := range prev {
+		prev[j] = float64(j) * w.Insertion
+	}
+	for i := 1; i <= m; i++ {
+		cur[0] = float64(i) * w.Deletion
+		for j := 1; j <= n; j++ {
+			substitution := w.Substitution
+			if ref[i-1] == cand[j-1] {
+				substitution = 0
+			}
+			cur[j] = min(prev[j]+w.Deletion, cur[j-1]+w.Insertion, prev[j-1]+substitution)
+		}
+		prev, cur = cur, prev
+	}
+	distance := prev[n]
+
+	overlap := float64(min(n, m)) * w.Substitution
+	if n > m {
+		overlap += float64(n-m) * w.Insertion
+	} else {
+		overlap += float64(m-n) * w.Deletion
+	}
+	maxDistance := min(float64(m)*w.Deletion+float64(n)*w.Insertion, overlap)
+	if maxDistance == 0 {
+		return 1.0
+	}
+	return max(1.0-distance/maxDistance, 0)
+}
+
+// editDistance returns the Levenshtein distance between two token
+// sequences using a two-row dynamic programming table.
+func editDistance(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j-1]+cost, prev[j]+1, cur[j-1]+1)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// confidenceMode returns the configured confidence mode, treating an empty
+// value as the default fixed mode.
+func (fmu *FuzzyMatchUnit) confidenceMode() string {
+	if fmu.config.ConfidenceMode == "" {
+		return ConfidenceFixed
+	}
+	return fmu.config.ConfidenceMode
+}
+
+// confidence computes the confidence for an answer with the given raw
+// similarity according to the configured confidence mode.
+func (fmu *FuzzyMatchUnit) confidence(rawSimilarity float64) float64 {
+	switch fmu.confidenceMode() {
+	case ConfidenceSimilarity:
+		return rawSimilarity
+	case ConfidenceMargin:
+		threshold := fmu.config.Threshold
+		if rawSimilarity >= threshold {
+			if threshold >= 1.0 {
+				return 1.0
+			}
+			return (rawSimilarity - threshold) / (1.0 - threshold)
+		}
+		// Below the threshold, confidence reflects how clearly the answer
+		// fails to match; threshold is necessarily positive here.
+		return (threshold - rawSimilarity) / threshold
+	default:
+		// Deterministic matching has perfect confidence.
+		return 1.0
+	}
+}
+
 // Validate checks if the unit is properly configured and ready for execution.
 // It validates the configuration parameters to ensure proper matching behavior.
 // Returns nil if validation passes, or an error describing what is invalid.
 func (fmu *FuzzyMatchUnit) Validate() error {
-	if err := validate.Struct(fmu.config); err != nil {
-		return fmt.Errorf("configuration validation failed: %w", err)
+	if err := validateFuzzyMatchConfig(fmu.config); err != nil {
+		return domain.NewConfigValidationError(fmu.name, err)
 	}
 
 	return nil
@@ -280,15 +734,22 @@ func (fmu *FuzzyMatchUnit) UnmarshalParameters(params yaml.Node) (*FuzzyMatchUni
 	}
 
 	// Validate the decoded configuration.
-	if err := validate.Struct(config); err != nil {
+	if err := validateFuzzyMatchConfig(config); err != nil {
+		return nil, fmt.Errorf("parameter validation failed: %w", err)
+	}
+
+	tokenizer, err := NewTokenizer(config.Tokenizer)
+	if err != nil {
 		return nil, fmt.Errorf("parameter validation failed: %w", err)
 	}
 
 	// Return a new unit instance with the updated configuration.
 	return &FuzzyMatchUnit{
-		name:   fmu.name,
-		config: config,
-		tracer: fmu.tracer,
+		name:       fmu.name,
+		config:     config,
+		tokenizer:  tokenizer,
+		normalizer: config.normalizer(),
+		tracer:     fmu.tracer,
 	}, nil
 }
 
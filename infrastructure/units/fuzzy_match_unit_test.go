@@ -13,6 +13,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/latency"
 )
 
 func TestNewFuzzyMatchUnit(t *testing.T) {
@@ -590,32 +591,76 @@ func BenchmarkFuzzyMatchUnit_Execute(b *testing.B) {
 	})
 	state = domain.With(state, domain.KeyReferenceAnswer, "This is a test answer with some text")
 
-	// Measure latencies for p95 calculation.
-	latencies := make([]time.Duration, 0, b.N)
+	recorder := latency.NewRecorder(b.N)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		start := time.Now()
-		_, err := unit.Execute(ctx, state)
-		latency := time.Since(start)
+		err := recorder.Time(func() error {
+			_, err := unit.Execute(ctx, state)
+			return err
+		})
 		require.NoError(b, err)
-		latencies = append(latencies, latency)
 	}
 
-	// Calculate p95 latency.
-	if len(latencies) > 0 {
-		p95Index := int(float64(len(latencies)) * 0.95)
-		if p95Index >= len(latencies) {
-			p95Index = len(latencies) - 1
-		}
-		p95Latency := latencies[p95Index]
-		b.Logf("p95 latency: %v (target: ≤300µs)", p95Latency)
+	b.Logf("latency: %v (target: p95 ≤300µs)", recorder.Summary())
+	if err := recorder.CheckSLO(95, 300*time.Microsecond); err != nil {
+		b.Error(err)
+	}
+}
+
+// BenchmarkFuzzyMatchUnit_SharedReference compares scoring a 50-answer item
+// against a reference prepared once with re-preparing it per candidate.
+func BenchmarkFuzzyMatchUnit_SharedReference(b *testing.B) {
+	for _, tokenizer := range []string{TokenizerCharacter, TokenizerSubword} {
+		unit, err := NewFuzzyMatchUnit("benchmark", FuzzyMatchConfig{
+			Algorithm: "levenshtein",
+			Threshold: 0.5,
+			MatchMode: MatchModeBestSubstring,
+			Tokenizer: tokenizer,
+		})
+		require.NoError(b, err)
 
-		// Assert that p95 latency meets the requirement.
-		if p95Latency > 300*time.Microsecond {
-			b.Errorf("p95 latency %v exceeds 300µs requirement", p95Latency)
+		reference := unit.prepareString(strings.Repeat("The mitochondria is the powerhouse of the cell. ", 4))
+		answers := make([]string, 50)
+		for i := range answers {
+			answers[i] = unit.prepareString(fmt.Sprintf("Answer %d: the mitochondria, as the powerhouse of the cell, produces ATP.", i))
 		}
+
+		b.Run(tokenizer+"/per_candidate", func(b *testing.B) {
+			for b.Loop() {
+				for _, answer := range answers {
+					_, _ = unit.substringSimilarityTo(answer, newPreparedText(reference, unit.tokenizer))
+				}
+			}
+		})
+		b.Run(tokenizer+"/shared", func(b *testing.B) {
+			for b.Loop() {
+				prepared := newPreparedText(reference, unit.tokenizer)
+				for _, answer := range answers {
+					_, _ = unit.substringSimilarityTo(answer, prepared)
+				}
+			}
+		})
+	}
+}
+
+// TestPreparedText_Concurrent verifies that derived forms are computed once
+// and are identical across concurrent readers.
+func TestPreparedText_Concurrent(t *testing.T) {
+	prepared := newPreparedText("héllo wörld", WhitespaceTokenizer{})
+
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, []string{"héllo", "wörld"}, prepared.Tokens())
+			assert.Equal(t, 11, prepared.RuneCount())
+			assert.Equal(t, []rune("héllo wörld"), prepared.Runes())
+		}()
 	}
+	wg.Wait()
+	assert.Equal(t, "héllo wörld", prepared.Text())
 }
 
 // TestFuzzyMatchUnit_UnicodeHandling tests that Unicode strings are handled correctly.
@@ -790,3 +835,609 @@ case_sensitive: false`,
 		t.Errorf("Concurrent operation failed: %v", err)
 	}
 }
+
+// TestFuzzyMatchUnit_CalculateSubstringSimilarity tests best_substring scoring.
+// It verifies that references embedded in longer answers score highly, that
+// near-miss substrings are normalized by the reference length, and that
+// oversized inputs are rejected rather than computed.
+func TestFuzzyMatchUnit_CalculateSubstringSimilarity(t *testing.T) {
+	unit, err := NewFuzzyMatchUnit("test", DefaultFuzzyMatchConfig())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		candidate string
+		reference string
+		expected  float64
+	}{
+		{
+			name:      "reference embedded verbatim",
+			candidate: "The capital is Paris, as of 2024",
+			reference: "Paris",
+			expected:  1.0,
+		},
+		{
+			name:      "embedded transposition aligns to best window",
+			candidate: "The capital is Parsi, as of 2024",
+			reference: "Paris",
+			expected:  0.8, // "Pars" is one deletion away from "Paris"
+		},
+		{
+			name:      "one substitution in embedded reference",
+			candidate: "I think it is Barcelona.",
+			reference: "Barcelone",
+			expected:  1.0 - 1.0/9.0,
+		},
+		{
+			name:      "candidate shorter than reference",
+			candidate: "Par",
+			reference: "Paris",
+			expected:  0.6,
+		},
+		{
+			name:      "empty reference matches anything",
+			candidate: "anything",
+			reference: "",
+			expected:  1.0,
+		},
+		{
+			name:      "no overlap",
+			candidate: "xyz",
+			reference: "abc",
+			expected:  0.0,
+		},
+		{
+			name:      "unicode runes",
+			candidate: "Je bois un cafe au lait",
+			reference: "café",
+			expected:  0.75,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			similarity, err := unit.calculateSubstringSimilarity(tt.candidate, tt.reference)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, similarity, 0.0001)
+		})
+	}
+
+	t.Run("oversized input is rejected", func(t *testing.T) {
+		candidate := strings.Repeat("a", 100_000)
+		reference := strings.Repeat("b", 1_000)
+		_, err := unit.calculateSubstringSimilarity(candidate, reference)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "best_substring match too large")
+	})
+}
+
+// TestFuzzyMatchUnit_ExecuteBestSubstring tests that best_substring mode
+// scores embedded correct answers above the threshold where full matching
+// would reject them.
+func TestFuzzyMatchUnit_ExecuteBestSubstring(t *testing.T) {
+	answers := []domain.Answer{
+		{ID: "a1", Content: "The capital is Paris, as of 2024"},
+		{ID: "a2", Content: "The capital is London"},
+	}
+	state := domain.With(domain.NewState(), domain.KeyAnswers, answers)
+	state = domain.With(state, domain.KeyReferenceAnswer, "paris")
+
+	full, err := NewFuzzyMatchUnit("full", DefaultFuzzyMatchConfig())
+	require.NoError(t, err)
+	fullState, err := full.Execute(context.Background(), state)
+	require.NoError(t, err)
+	fullScores, ok := domain.Get(fullState, domain.KeyJudgeScores)
+	require.True(t, ok)
+	assert.Equal(t, 0.0, fullScores[0].Score)
+
+	config := DefaultFuzzyMatchConfig()
+	config.MatchMode = MatchModeBestSubstring
+	substring, err := NewFuzzyMatchUnit("substring", config)
+	require.NoError(t, err)
+	newState, err := substring.Execute(context.Background(), state)
+	require.NoError(t, err)
+	scores, ok := domain.Get(newState, domain.KeyJudgeScores)
+	require.True(t, ok)
+	require.Len(t, scores, 2)
+	assert.Equal(t, 1.0, scores[0].Score)
+	assert.Equal(t, 0.0, scores[1].Score)
+}
+
+// TestFuzzyMatchUnit_ConfidenceMode tests that confidence is fixed at 1.0 by
+// default and otherwise derived from the similarity or its margin from the
+// threshold, so borderline matches report low confidence.
+func TestFuzzyMatchUnit_ConfidenceMode(t *testing.T) {
+	answers := []domain.Answer{
+		{ID: "exact", Content: "paris"},
+		{ID: "borderline", Content: "pariz"},
+		{ID: "near_miss", Content: "parxx"},
+		{ID: "mismatch", Content: "xxxxx"},
+	}
+	state := domain.With(domain.NewState(), domain.KeyAnswers, answers)
+	state = domain.With(state, domain.KeyReferenceAnswer, "paris")
+
+	tests := []struct {
+		name     string
+		mode     string
+		expected []float64
+	}{
+		{name: "default is fixed", mode: "", expected: []float64{1.0, 1.0, 1.0, 1.0}},
+		{name: "fixed", mode: ConfidenceFixed, expected: []float64{1.0, 1.0, 1.0, 1.0}},
+		{name: "similarity", mode: ConfidenceSimilarity, expected: []float64{1.0, 0.8, 0.6, 0.0}},
+		{name: "margin", mode: ConfidenceMargin, expected: []float64{1.0, 0.0, 0.25, 1.0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultFuzzyMatchConfig()
+			config.ConfidenceMode = tt.mode
+			unit, err := NewFuzzyMatchUnit("test", config)
+			require.NoError(t, err)
+
+			newState, err := unit.Execute(context.Background(), state)
+			require.NoError(t, err)
+			scores, ok := domain.Get(newState, domain.KeyJudgeScores)
+			require.True(t, ok)
+			require.Len(t, scores, len(tt.expected))
+			for i, want := range tt.expected {
+				assert.InDelta(t, want, scores[i].Confidence, 1e-9, "answer %s", answers[i].ID)
+			}
+		})
+	}
+
+	t.Run("margin with threshold of one", func(t *testing.T) {
+		config := DefaultFuzzyMatchConfig()
+		config.Threshold = 1.0
+		config.ConfidenceMode = ConfidenceMargin
+		unit, err := NewFuzzyMatchUnit("test", config)
+		require.NoError(t, err)
+		assert.Equal(t, 1.0, unit.confidence(1.0))
+		assert.InDelta(t, 0.2, unit.confidence(0.8), 1e-9)
+	})
+
+	t.Run("rejects unknown mode", func(t *testing.T) {
+		config := DefaultFuzzyMatchConfig()
+		config.ConfidenceMode = "random"
+		_, err := NewFuzzyMatchUnit("test", config)
+		require.Error(t, err)
+	})
+}
+
+// TestFuzzyMatchUnit_EmptyAnswers verifies the skip, send, and reject
+// policies for empty and whitespace-only answers.
+func TestFuzzyMatchUnit_EmptyAnswers(t *testing.T) {
+	answers := []domain.Answer{
+		{ID: "a1", Content: "paris"},
+		{ID: "a2", Content: "   "},
+	}
+	state := domain.With(domain.NewState(), domain.KeyAnswers, answers)
+	state = domain.With(state, domain.KeyReferenceAnswer, "paris")
+
+	tests := []struct {
+		name      string
+		policy    EmptyAnswerPolicy
+		score     float64
+		expected  float64
+		reasoning string
+		wantErr   bool
+	}{
+		{name: "skip by default", expected: 0.0, reasoning: "empty"},
+		{name: "skip with configured score", policy: EmptyAnswerSkip, score: 0.1, expected: 0.1, reasoning: "empty"},
+		{name: "send compares normally", policy: EmptyAnswerSend, expected: 0.0, reasoning: "No match"},
+		{name: "reject", policy: EmptyAnswerReject, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultFuzzyMatchConfig()
+			config.EmptyAnswerPolicy = tt.policy
+			config.EmptyAnswerScore = tt.score
+			unit, err := NewFuzzyMatchUnit("test", config)
+			require.NoError(t, err)
+
+			newState, err := unit.Execute(context.Background(), state)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrEmptyAnswer)
+				return
+			}
+			require.NoError(t, err)
+			scores, ok := domain.Get(newState, domain.KeyJudgeScores)
+			require.True(t, ok)
+			require.Len(t, scores, 2)
+			assert.Equal(t, 1.0, scores[0].Score)
+			assert.InDelta(t, tt.expected, scores[1].Score, 1e-9)
+			assert.Contains(t, scores[1].Reasoning, tt.reasoning)
+		})
+	}
+}
+
+// TestFuzzyMatchUnit_NoAnswers tests that an empty answer list fails by
+// default and yields a no-answers verdict with the abstain policy.
+func TestFuzzyMatchUnit_NoAnswers(t *testing.T) {
+	state := domain.With(domain.NewState(), domain.KeyAnswers, []domain.Answer{})
+	state = domain.With(state, domain.KeyReferenceAnswer, "paris")
+
+	config := DefaultFuzzyMatchConfig()
+	unit, err := NewFuzzyMatchUnit("fuzzy", config)
+	require.NoError(t, err)
+	_, err = unit.Execute(context.Background(), state)
+	require.Error(t, err)
+
+	config.OnNoAnswers = NoAnswersAbstain
+	unit, err = NewFuzzyMatchUnit("fuzzy", config)
+	require.NoError(t, err)
+	newState, err := unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+
+	verdict, ok := newState.GetVerdict()
+	require.True(t, ok)
+	assert.Equal(t, domain.VerdictNoAnswers, verdict.Status)
+	assert.Nil(t, verdict.WinnerAnswer)
+}
+
+// TestFuzzyMatchUnit_Tokenizer tests that the configured tokenizer changes
+// what the edit distance counts, in both match modes.
+func TestFuzzyMatchUnit_Tokenizer(t *testing.T) {
+	tests := []struct {
+		name      string
+		tokenizer string
+		matchMode string
+		candidate string
+		reference string
+		expected  float64
+	}{
+		{
+			name:      "default counts rune edits",
+			candidate: "the cat sat",
+			reference: "the bat sat",
+			expected:  1.0 - 1.0/11.0,
+		},
+		{
+			name:      "whitespace counts word edits",
+			tokenizer: TokenizerWhitespace,
+			candidate: "the cat sat",
+			reference: "the bat sat",
+			expected:  1.0 - 1.0/3.0,
+		},
+		{
+			name:      "whitespace treats trailing punctuation as a different word",
+			tokenizer: TokenizerWhitespace,
+			candidate: "Paris.",
+			reference: "Paris",
+			expected:  0.0,
+		},
+		{
+			name:      "subword separates trailing punctuation",
+			tokenizer: TokenizerSubword,
+			candidate: "Paris.",
+			reference: "Paris",
+			expected:  1.0 - 1.0/3.0,
+		},
+		{
+			name:      "whitespace best substring requires whole words",
+			tokenizer: TokenizerWhitespace,
+			matchMode: MatchModeBestSubstring,
+			candidate: "I think it is parisian",
+			reference: "paris",
+			expected:  0.0,
+		},
+		{
+			name:      "whitespace best substring finds embedded words",
+			tokenizer: TokenizerWhitespace,
+			matchMode: MatchModeBestSubstring,
+			candidate: "I think it is paris france",
+			reference: "paris france",
+			expected:  1.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultFuzzyMatchConfig()
+			config.Tokenizer = tt.tokenizer
+			config.MatchMode = tt.matchMode
+			config.Threshold = 0
+			unit, err := NewFuzzyMatchUnit("test", config)
+			require.NoError(t, err)
+
+			state := domain.With(domain.NewState(), domain.KeyAnswers, []domain.Answer{{ID: "a1", Content: tt.candidate}})
+			state = domain.With(state, domain.KeyReferenceAnswer, tt.reference)
+
+			newState, err := unit.Execute(context.Background(), state)
+			require.NoError(t, err)
+
+			scores, ok := domain.Get(newState, domain.KeyJudgeScores)
+			require.True(t, ok)
+			require.Len(t, scores, 1)
+			assert.InDelta(t, tt.expected, scores[0].Score, 1e-9)
+		})
+	}
+
+	_, err := NewFuzzyMatchUnit("test", FuzzyMatchConfig{Algorithm: "levenshtein", Tokenizer: "bpe"})
+	require.Error(t, err)
+}
+
+// TestFuzzyMatchUnit_UnorderedDelimiter verifies that list-valued answers
+// are compared independent of part order, after case folding, and that the
+// option is off by default.
+func TestFuzzyMatchUnit_UnorderedDelimiter(t *testing.T) {
+	tests := []struct {
+		name          string
+		delimiter     string
+		caseSensitive bool
+		candidate     string
+		reference     string
+		expected      float64
+	}{
+		{
+			name:      "reordered parts match",
+			delimiter: ",",
+			candidate: "c, b, a",
+			reference: "a, b, c",
+			expected:  1.0,
+		},
+		{
+			name:      "whitespace and empty parts ignored",
+			delimiter: ",",
+			candidate: " b,a ,, c",
+			reference: "a, b, c",
+			expected:  1.0,
+		},
+		{
+			name:      "case folded before sorting",
+			delimiter: ";",
+			candidate: "Banana; apple",
+			reference: "apple; banana",
+			expected:  1.0,
+		},
+		{
+			name:          "case sensitive sorts uppercase first",
+			delimiter:     ";",
+			caseSensitive: true,
+			candidate:     "Banana; apple",
+			reference:     "apple; banana",
+			// "Banana; apple" vs "apple; banana" differ in 10 of 13 runes.
+			expected: 3.0 / 13.0,
+		},
+		{
+			name:      "off by default",
+			candidate: "b, a",
+			reference: "a, b",
+			expected:  0.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultFuzzyMatchConfig()
+			config.UnorderedDelimiter = tt.delimiter
+			config.CaseSensitive = tt.caseSensitive
+			config.Threshold = 0
+			unit, err := NewFuzzyMatchUnit("test", config)
+			require.NoError(t, err)
+
+			state := domain.With(domain.NewState(), domain.KeyAnswers, []domain.Answer{{ID: "a1", Content: tt.candidate}})
+			state = domain.With(state, domain.KeyReferenceAnswer, tt.reference)
+
+			newState, err := unit.Execute(context.Background(), state)
+			require.NoError(t, err)
+
+			scores, ok := domain.Get(newState, domain.KeyJudgeScores)
+			require.True(t, ok)
+			require.Len(t, scores, 1)
+			assert.InDelta(t, tt.expected, scores[0].Score, 1e-9)
+		})
+	}
+}
+
+// TestFuzzyMatchUnit_Stopwords verifies that stopwords are removed from
+// both the candidate and the reference before comparison.
+func TestFuzzyMatchUnit_Stopwords(t *testing.T) {
+	tests := []struct {
+		name      string
+		remove    bool
+		stopwords []string
+		candidate string
+		reference string
+		expected  float64
+	}{
+		{
+			name:      "default stopwords removed",
+			remove:    true,
+			candidate: "The capital of France is Paris",
+			reference: "capital France Paris",
+			expected:  1.0,
+		},
+		{
+			name:      "custom stopwords",
+			remove:    true,
+			stopwords: []string{"answer:"},
+			candidate: "Answer: Paris",
+			reference: "paris",
+			expected:  1.0,
+		},
+		{
+			name:      "off by default",
+			candidate: "the Paris",
+			reference: "Paris",
+			// "the paris" vs "paris" differs by 4 of 9 runes.
+			expected: 5.0 / 9.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultFuzzyMatchConfig()
+			config.RemoveStopwords = tt.remove
+			config.Stopwords = tt.stopwords
+			config.Threshold = 0
+			unit, err := NewFuzzyMatchUnit("test", config)
+			require.NoError(t, err)
+
+			state := domain.With(domain.NewState(), domain.KeyAnswers, []domain.Answer{{ID: "a1", Content: tt.candidate}})
+			state = domain.With(state, domain.KeyReferenceAnswer, tt.reference)
+
+			newState, err := unit.Execute(context.Background(), state)
+			require.NoError(t, err)
+
+			scores, ok := domain.Get(newState, domain.KeyJudgeScores)
+			require.True(t, ok)
+			require.Len(t, scores, 1)
+			assert.InDelta(t, tt.expected, scores[0].Score, 1e-9)
+		})
+	}
+
+	t.Run("rejects empty stopword", func(t *testing.T) {
+		config := DefaultFuzzyMatchConfig()
+		config.RemoveStopwords = true
+		config.Stopwords = []string{""}
+		_, err := NewFuzzyMatchUnit("test", config)
+		assert.Error(t, err)
+	})
+}
+
+// TestFuzzyMatchUnit_EditWeights verifies asymmetric edit costs, that unit
+// weights reproduce the unweighted similarity, and that weights are
+// rejected for best_substring matching.
+func TestFuzzyMatchUnit_EditWeights(t *testing.T) {
+	lenient := &EditWeights{Insertion: 0.25}
+
+	tests := []struct {
+		name      string
+		weights   *EditWeights
+		tokenizer string
+		answer    string
+		reference string
+		expected  float64
+	}{
+		{name: "unweighted extra detail", tokenizer: TokenizerWhitespace, answer: "a b c d e f", reference: "a b c d", expected: 1 - 2.0/6},
+		{name: "cheap insertions", weights: lenient, tokenizer: TokenizerWhitespace, answer: "a b c d e f", reference: "a b c d", expected: 1 - 0.5/4.5},
+		{name: "deletions stay strict", weights: lenient, tokenizer: TokenizerWhitespace, answer: "a b", reference: "a b c d", expected: 0.5},
+		{name: "unit weights match default", weights: &EditWeights{Insertion: 1, Deletion: 1, Substitution: 1}, answer: "kitten", reference: "sitting", expected: 1 - 3.0/7},
+		{name: "character level", weights: &EditWeights{Deletion: 2}, answer: "cat", reference: "cart", expected: 1 - 2.0/5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := NewFuzzyMatchUnit("fuzzy", FuzzyMatchConfig{
+				Algorithm:     "levenshtein",
+				CaseSensitive: true,
+				Tokenizer:     tt.tokenizer,
+				EditWeights:   tt.weights,
+			})
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, unit.calculateSimilarity(tt.answer, tt.reference), 1e-9)
+		})
+	}
+
+	t.Run("best_substring rejected", func(t *testing.T) {
+		_, err := NewFuzzyMatchUnit("fuzzy", FuzzyMatchConfig{
+			Algorithm:   "levenshtein",
+			MatchMode:   MatchModeBestSubstring,
+			EditWeights: lenient,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "edit_weights is only supported")
+	})
+
+	t.Run("out of range weight rejected", func(t *testing.T) {
+		_, err := NewFuzzyMatchUnit("fuzzy", FuzzyMatchConfig{
+			Algorithm:   "levenshtein",
+			EditWeights: &EditWeights{Deletion: 20},
+		})
+		require.Error(t, err)
+	})
+}
+
+// TestFuzzyMatchUnit_Conclusive tests that the best answer concludes once
+// its similarity reaches the configured minimum.
+func TestFuzzyMatchUnit_Conclusive(t *testing.T) {
+	answers := []domain.Answer{{ID: "a1", Content: "Lyon"}, {ID: "a2", Content: "Pariss"}}
+	state := domain.With(domain.With(domain.NewState(), domain.KeyAnswers, answers), domain.KeyReferenceAnswer, "Paris")
+
+	unit, err := NewFuzzyMatchFromConfig("fuzzy", map[string]any{"conclusive": map[string]any{"min_score": 0.8}}, nil)
+	require.NoError(t, err)
+	result, err := unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+	conclusion, ok := domain.Get(result, domain.KeyConclusive)
+	require.True(t, ok)
+	assert.Equal(t, "a2", conclusion.AnswerID)
+	assert.InDelta(t, 5.0/6, conclusion.Score, 1e-9)
+
+	unit, err = NewFuzzyMatchFromConfig("fuzzy", map[string]any{"conclusive": map[string]any{}}, nil)
+	require.NoError(t, err)
+	result, err = unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+	_, ok = domain.Get(result, domain.KeyConclusive)
+	assert.False(t, ok, "the default minimum requires a perfect match")
+
+	_, err = NewFuzzyMatchFromConfig("fuzzy", map[string]any{"conclusive": map[string]any{"min_score": 1.5}}, nil)
+	assert.Error(t, err)
+}
+
+// TestFuzzyMatchUnit_LengthFloor tests that inexact matches between short
+// strings are discounted below the length floor, measured in the
+// tokenizer's units, while exact and long enough matches are not.
+func TestFuzzyMatchUnit_LengthFloor(t *testing.T) {
+	tests := []struct {
+		name      string
+		floor     int
+		mode      string
+		tokenizer string
+		answer    string
+		reference string
+		expected  float64
+	}{
+		{name: "disabled", answer: "ab", reference: "ac", expected: 0.5},
+		{name: "two characters differ by luck", floor: 4, answer: "ab", reference: "ac", expected: 0.25},
+		{name: "single character mismatch", floor: 4, answer: "a", reference: "b", expected: 0},
+		{name: "exact match keeps full credit", floor: 4, answer: "ab", reference: "ab", expected: 1},
+		{name: "exact after case folding", floor: 4, answer: "AB", reference: "ab", expected: 1},
+		{name: "at the floor", floor: 4, answer: "abcd", reference: "abce", expected: 0.75},
+		{name: "long reference is not discounted", floor: 4, answer: "ab", reference: "abcdef", expected: 1 - 4.0/6},
+		{name: "long answer is not discounted", floor: 4, answer: "abcdef", reference: "ab", expected: 1 - 4.0/6},
+		{name: "counts runes not bytes", floor: 4, answer: "éa", reference: "éb", expected: 0.25},
+		{name: "counts tokens", floor: 4, tokenizer: TokenizerWhitespace, answer: "red car", reference: "red cat", expected: 0.25},
+		{name: "best substring uses reference length", floor: 4, mode: MatchModeBestSubstring, answer: "xx ab yy", reference: "ac", expected: 0.25},
+		{name: "best substring verbatim", floor: 4, mode: MatchModeBestSubstring, answer: "xx ab yy", reference: "ab", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := NewFuzzyMatchUnit("fuzzy", FuzzyMatchConfig{
+				Algorithm:   "levenshtein",
+				MatchMode:   tt.mode,
+				Tokenizer:   tt.tokenizer,
+				LengthFloor: tt.floor,
+			})
+			require.NoError(t, err)
+
+			state := domain.With(domain.With(domain.NewState(),
+				domain.KeyAnswers, []domain.Answer{{ID: "a1", Content: tt.answer}}),
+				domain.KeyReferenceAnswer, tt.reference)
+			result, err := unit.Execute(context.Background(), state)
+			require.NoError(t, err)
+			scores, _ := result.GetJudgeScores()
+			require.Len(t, scores, 1)
+			assert.InDelta(t, tt.expected, scores[0].Score, 1e-9)
+		})
+	}
+
+	t.Run("discount applies before the threshold", func(t *testing.T) {
+		unit, err := NewFuzzyMatchFromConfig("fuzzy", map[string]any{"threshold": 0.4, "length_floor": 4}, nil)
+		require.NoError(t, err)
+		state := domain.With(domain.With(domain.NewState(),
+			domain.KeyAnswers, []domain.Answer{{ID: "a1", Content: "ab"}}),
+			domain.KeyReferenceAnswer, "ac")
+		result, err := unit.Execute(context.Background(), state)
+		require.NoError(t, err)
+		scores, _ := result.GetJudgeScores()
+		assert.Zero(t, scores[0].Score)
+		assert.Equal(t, "No match (similarity 25.00% below threshold 40.00%), discounted for length 2 below floor 4", scores[0].Reasoning)
+	})
+
+	t.Run("negative floor rejected", func(t *testing.T) {
+		_, err := NewFuzzyMatchFromConfig("fuzzy", map[string]any{"length_floor": -1}, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,302 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+var _ ports.Unit = (*HybridJudgeUnit)(nil)
+
+// Hybrid judge defaults.
+const (
+	// DefaultHybridAmbiguousMin is the default lowest fuzzy similarity
+	// that is treated as ambiguous.
+	DefaultHybridAmbiguousMin = 0.3
+	// DefaultHybridAmbiguousMax is the default highest fuzzy similarity
+	// that is treated as ambiguous.
+	DefaultHybridAmbiguousMax = 0.85
+)
+
+// HybridJudgeUnit combines a deterministic fuzzy match with an LLM judge in
+// a single unit. Every answer is first compared to the reference with a
+// FuzzyMatchUnit. Answers whose similarity falls outside the ambiguous
+// band are clear matches or clear misses and keep the fuzzy score without
+// an LLM call; only answers inside the band are sent to the judge, and
+// their final score is a weighted blend of the similarity and the judge's
+// score mapped onto 0.0-1.0.
+//
+// Scores are recorded under the unit's own name on the 0.0-1.0 scale. The
+// judge's results on the ambiguous answers are not kept in State, and its
+// token usage is charged to the budget as usual.
+//
+// The unit is stateless and thread-safe if its judge is.
+type HybridJudgeUnit struct {
+	name    string
+	config  HybridJudgeConfig
+	matcher *FuzzyMatchUnit
+	judge   ports.Unit
+	tracer  trace.Tracer
+}
+
+// HybridJudgeConfig defines the ambiguous band and the blend weights of a
+// HybridJudgeUnit.
+type HybridJudgeConfig struct {
+	// AmbiguousMin and AmbiguousMax bound, inclusively, the fuzzy
+	// similarities for which the LLM judge is consulted.
+	AmbiguousMin float64 `yaml:"ambiguous_min" json:"ambiguous_min" validate:"min=0.0,max=1.0"`
+	AmbiguousMax float64 `yaml:"ambiguous_max" json:"ambiguous_max" validate:"min=0.0,max=1.0"`
+
+	// FuzzyWeight and LLMWeight are the relative weights of the fuzzy
+	// similarity and the normalized judge score in the blend. They are
+	// divided by their sum, which must be positive.
+	FuzzyWeight float64 `yaml:"fuzzy_weight" json:"fuzzy_weight" validate:"min=0,max=100"`
+	LLMWeight   float64 `yaml:"llm_weight" json:"llm_weight" validate:"min=0,max=100"`
+}
+
+// DefaultHybridJudgeConfig returns a HybridJudgeConfig that consults the
+// judge for similarities between 0.3 and 0.85 and weights both signals
+// equally.
+func DefaultHybridJudgeConfig() HybridJudgeConfig {
+	return HybridJudgeConfig{
+		AmbiguousMin: DefaultHybridAmbiguousMin,
+		AmbiguousMax: DefaultHybridAmbiguousMax,
+		FuzzyWeight:  1,
+		LLMWeight:    1,
+	}
+}
+
+// defaultHybridFuzzyConfig returns the fuzzy match defaults of a hybrid
+// judge. The threshold is zero so that raw similarities reach the band
+// instead of being zeroed.
+func defaultHybridFuzzyConfig() FuzzyMatchConfig {
+	config := DefaultFuzzyMatchConfig()
+	config.Threshold = 0
+	return config
+}
+
+// validateHybridJudgeConfig checks struct rules and the constraints
+// between fields.
+func validateHybridJudgeConfig(config HybridJudgeConfig) error {
+	if err := validate.Struct(config); err != nil {
+		return fieldValidationError(err)
+	}
+	if config.AmbiguousMin > config.AmbiguousMax {
+		return fmt.Errorf("ambiguous_min %.2f exceeds ambiguous_max %.2f", config.AmbiguousMin, config.AmbiguousMax)
+	}
+	if config.FuzzyWeight+config.LLMWeight == 0 {
+		return fmt.Errorf("fuzzy_weight and llm_weight cannot both be zero")
+	}
+	return nil
+}
+
+// NewHybridJudgeUnit creates a HybridJudgeUnit that scores answers with
+// matcher and consults judge for those in the ambiguous band.
+func NewHybridJudgeUnit(
+	name string,
+	matcher *FuzzyMatchUnit,
+	judge ports.Unit,
+	config HybridJudgeConfig,
+) (*HybridJudgeUnit, error) {
+	if name == "" {
+		return nil, ErrEmptyUnitName
+	}
+	if matcher == nil {
+		return nil, fmt.Errorf("unit %s: fuzzy match unit cannot be nil", name)
+	}
+	if judge == nil {
+		return nil, fmt.Errorf("unit %s: judge unit cannot be nil", name)
+	}
+	if err := validateHybridJudgeConfig(config); err != nil {
+		return nil, domain.NewConfigValidationError(name, err)
+	}
+
+	return &HybridJudgeUnit{
+		name:    name,
+		config:  config,
+		matcher: matcher,
+		judge:   judge,
+		tracer:  otel.Tracer("hybrid-judge-unit"),
+	}, nil
+}
+
+// Name returns the unique identifier for this unit instance.
+func (hju *HybridJudgeUnit) Name() string { return hju.name }
+
+// Execute fuzzy-matches every answer against the reference, judges the
+// answers whose similarity is ambiguous, and stores the blended scores as
+// the unit's judge scores.
+func (hju *HybridJudgeUnit) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	ctx, span := hju.tracer.Start(ctx, "HybridJudgeUnit.Execute",
+		trace.WithAttributes(
+			attribute.String("unit.type", "hybrid_judge"),
+			attribute.String("unit.id", hju.name),
+			attribute.String("judge.name", hju.judge.Name()),
+			attribute.Float64("config.ambiguous_min", hju.config.AmbiguousMin),
+			attribute.Float64("config.ambiguous_max", hju.config.AmbiguousMax),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+
+	answers, err := domain.Require(state, domain.KeyAnswers, hju.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	matched, err := hju.matcher.Execute(ctx, state)
+	if err != nil {
+		err := fmt.Errorf("unit %s: fuzzy match: %w", hju.name, err)
+		span.RecordError(err)
+		return state, err
+	}
+	similarities, _ := matched.GetJudgeScores()
+
+	summaries := make([]domain.JudgeSummary, len(similarities))
+	var ambiguous []int
+	for i, s := range similarities {
+		if hju.inBand(s.Score) {
+			ambiguous = append(ambiguous, i)
+			continue
+		}
+		summaries[i] = s
+		summaries[i].Reasoning = s.Reasoning + "; outside the ambiguous band, LLM judge not consulted"
+	}
+
+	current := state
+	if len(ambiguous) > 0 {
+		subset := make([]domain.Answer, len(ambiguous))
+		for k, i := range ambiguous {
+			subset[k] = answers[i]
+		}
+
+		result, err := hju.judge.Execute(ctx, domain.With(state, domain.KeyAnswers, subset))
+		if err != nil {
+			err := fmt.Errorf("unit %s: judge: %w", hju.name, err)
+			span.RecordError(err)
+			return state, err
+		}
+		scores, ok := result.GetJudgeScores()
+		if !ok || len(scores) != len(subset) {
+			err := fmt.Errorf("unit %s: judge %s returned %d scores for %d answers",
+				hju.name, hju.judge.Name(), len(scores), len(subset))
+			span.RecordError(err)
+			return state, err
+		}
+
+		scale, _ := domain.JudgeScoreScale(result, hju.judge.Name())
+		for k, i := range ambiguous {
+			summaries[i] = hju.blend(similarities[i], scores[k], scale)
+		}
+		current = restoreJudgeMaps(domain.With(result, domain.KeyAnswers, answers), state)
+	}
+
+	newState := domain.WithJudgeScores(current, hju.name, summaries)
+	newState = domain.WithJudgeScoreScale(newState, hju.name, domain.UnitScoreRange)
+
+	span.SetAttributes(
+		attribute.Int("eval.answers_count", len(answers)),
+		attribute.Int("eval.answers_judged", len(ambiguous)),
+		attribute.Int("eval.llm_calls_skipped", len(answers)-len(ambiguous)),
+		attribute.Int64("eval.latency_ms", time.Since(start).Milliseconds()),
+		attribute.Bool("no_llm_cost", len(ambiguous) == 0),
+	)
+	return newState, nil
+}
+
+// inBand reports whether similarity lies in the ambiguous band.
+func (hju *HybridJudgeUnit) inBand(similarity float64) bool {
+	return similarity >= hju.config.AmbiguousMin && similarity <= hju.config.AmbiguousMax
+}
+
+// blend combines an answer's fuzzy summary with the judge's summary, whose
+// score is on scale. Score and confidence are weighted means.
+func (hju *HybridJudgeUnit) blend(fuzzy, judged domain.JudgeSummary, scale domain.ScoreRange) domain.JudgeSummary {
+	total := hju.config.FuzzyWeight + hju.config.LLMWeight
+	wf, wl := hju.config.FuzzyWeight/total, hju.config.LLMWeight/total
+	normalized := scale.Normalize(judged.Score)
+	return domain.JudgeSummary{
+		Score:      wf*fuzzy.Score + wl*normalized,
+		Confidence: wf*fuzzy.Confidence + wl*judged.Confidence,
+		Reasoning: fmt.Sprintf("Fuzzy similarity %.2f (weight %.2f) blended with LLM score %.2f (weight %.2f). %s",
+			fuzzy.Score, wf, normalized, wl, judged.Reasoning),
+	}
+}
+
+// Validate checks the configuration, the fuzzy matcher, and the judge.
+func (hju *HybridJudgeUnit) Validate() error {
+	if err := validateHybridJudgeConfig(hju.config); err != nil {
+		return domain.NewConfigValidationError(hju.name, err)
+	}
+	if err := hju.matcher.Validate(); err != nil {
+		return fmt.Errorf("unit %s: fuzzy match validation failed: %w", hju.name, err)
+	}
+	if err := hju.judge.Validate(); err != nil {
+		return fmt.Errorf("unit %s: judge validation failed: %w", hju.name, err)
+	}
+	return nil
+}
+
+// NewHybridJudgeFromConfig creates a HybridJudgeUnit from a configuration
+// map. The "judge" entry holds the parameters of the score_judge unit
+// consulted for ambiguous answers, created with the ID "<id>_judge". The
+// optional "fuzzy" entry holds fuzzy_match parameters for the matcher,
+// created with the ID "<id>_fuzzy"; its threshold defaults to zero.
+func NewHybridJudgeFromConfig(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error) {
+	judgeParams, ok := config["judge"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("hybrid_judge requires 'judge' parameters for a score_judge unit")
+	}
+	judge, err := NewScoreJudgeFromConfig(id+"_judge", judgeParams, llm)
+	if err != nil {
+		return nil, fmt.Errorf("create judge: %w", err)
+	}
+
+	fuzzyConfig := defaultHybridFuzzyConfig()
+	if fuzzyParams, ok := config["fuzzy"]; ok {
+		if _, ok := fuzzyParams.(map[string]any); !ok {
+			return nil, fmt.Errorf("hybrid_judge 'fuzzy' must be a mapping of fuzzy_match parameters")
+		}
+		if err := overlayYAML(fuzzyParams, &fuzzyConfig); err != nil {
+			return nil, err
+		}
+	}
+	matcher, err := NewFuzzyMatchUnit(id+"_fuzzy", fuzzyConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create fuzzy matcher: %w", err)
+	}
+
+	params := maps.Clone(config)
+	delete(params, "judge")
+	delete(params, "fuzzy")
+	cfg := DefaultHybridJudgeConfig()
+	if err := overlayYAML(params, &cfg); err != nil {
+		return nil, err
+	}
+
+	return NewHybridJudgeUnit(id, matcher, judge, cfg)
+}
+
+// overlayYAML overlays params onto the defaults in cfg through a YAML round
+// trip.
+func overlayYAML(params any, cfg any) error {
+	data, err := yaml.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("parse config: %w", err)
+	}
+	return nil
+}
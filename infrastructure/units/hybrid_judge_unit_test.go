@@ -0,0 +1,144 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/testutils"
+)
+
+// TestHybridJudgeUnit_Execute tests that only answers in the ambiguous band
+// reach the LLM judge and that their scores blend both signals.
+func TestHybridJudgeUnit_Execute(t *testing.T) {
+	answers := []domain.Answer{
+		{ID: "a1", Content: "Paris"},
+		{ID: "a2", Content: "Paris, France"},
+		{ID: "a3", Content: "Tokyo"},
+	}
+	state := testutils.EvaluationState(t, "What is the capital of France?", answers,
+		domain.WithReferenceAnswer("Paris"))
+
+	matcher, err := NewFuzzyMatchUnit("fuzzy", defaultHybridFuzzyConfig())
+	require.NoError(t, err)
+	matched, err := matcher.Execute(context.Background(), state)
+	require.NoError(t, err)
+	similarities, _ := domain.Get(matched, domain.KeyJudgeScores)
+	require.InDelta(t, 1.0, similarities[0].Score, 1e-9)
+	require.True(t, similarities[1].Score > DefaultHybridAmbiguousMin && similarities[1].Score < DefaultHybridAmbiguousMax)
+	require.Less(t, similarities[2].Score, DefaultHybridAmbiguousMin)
+
+	// The exact match a1 has no scripted response, so judging it fails.
+	newClient := func() *scriptedClient {
+		return &scriptedClient{
+			MockLLMClient: testutils.NewMockLLMClient("test-model"),
+			responses: map[string][]string{
+				answers[1].Content: {`{"score": 7, "confidence": 0.6, "reasoning": "Correct city", "version": 1}`},
+				answers[2].Content: {`{"score": 1, "confidence": 0.9, "reasoning": "Wrong city", "version": 1}`},
+			},
+			calls: make(map[string]int),
+		}
+	}
+
+	t.Run("judges only ambiguous answers", func(t *testing.T) {
+		client := newClient()
+		unit, err := NewHybridJudgeFromConfig("hybrid", map[string]any{
+			"judge": map[string]any{"judge_prompt": "Question: {{.Question}}\nAnswer: {{.Answer}}", "score_scale": "1-10"},
+		}, client)
+		require.NoError(t, err)
+
+		result, err := unit.Execute(context.Background(), state)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]int{answers[1].Content: 1}, client.calls)
+
+		scores, _ := domain.Get(result, domain.KeyJudgeScores)
+		require.Len(t, scores, 3)
+		assert.InDelta(t, 1.0, scores[0].Score, 1e-9)
+		assert.InDelta(t, 0.5*similarities[1].Score+0.5*6.0/9.0, scores[1].Score, 1e-9)
+		assert.InDelta(t, 0.5*similarities[1].Confidence+0.5*0.6, scores[1].Confidence, 1e-9)
+		assert.Contains(t, scores[1].Reasoning, "Correct city")
+		assert.InDelta(t, similarities[2].Score, scores[2].Score, 1e-9)
+		assert.Contains(t, scores[2].Reasoning, "LLM judge not consulted")
+
+		got, _ := domain.Get(result, domain.KeyAnswers)
+		assert.Equal(t, answers, got)
+		byJudge, _ := domain.Get(result, domain.KeyJudgeScoresByJudge)
+		assert.Equal(t, []string{"hybrid"}, judgeIDs(byJudge))
+		scale, ok := domain.JudgeScoreScale(result, "hybrid")
+		assert.True(t, ok)
+		assert.Equal(t, domain.UnitScoreRange, scale)
+	})
+
+	t.Run("weights and band from config", func(t *testing.T) {
+		client := newClient()
+		unit, err := NewHybridJudgeFromConfig("hybrid", map[string]any{
+			"judge":         map[string]any{"judge_prompt": "Question: {{.Question}}\nAnswer: {{.Answer}}", "score_scale": "1-10"},
+			"ambiguous_min": 0.0,
+			"ambiguous_max": 0.5,
+			"fuzzy_weight":  0,
+			"llm_weight":    2,
+		}, client)
+		require.NoError(t, err)
+
+		result, err := unit.Execute(context.Background(), state)
+		require.NoError(t, err)
+
+		scores, _ := domain.Get(result, domain.KeyJudgeScores)
+		assert.InDelta(t, 1.0, scores[0].Score, 1e-9)
+		assert.InDelta(t, 6.0/9.0, scores[1].Score, 1e-9)
+		assert.InDelta(t, 0.0, scores[2].Score, 1e-9)
+		assert.Equal(t, 1, client.calls[answers[2].Content])
+	})
+
+	t.Run("no ambiguous answers skips the judge", func(t *testing.T) {
+		client := newClient()
+		unit, err := NewHybridJudgeFromConfig("hybrid", map[string]any{
+			"judge":         map[string]any{"judge_prompt": "Question: {{.Question}}\nAnswer: {{.Answer}}", "score_scale": "1-10"},
+			"ambiguous_min": 0.95,
+			"ambiguous_max": 0.99,
+		}, client)
+		require.NoError(t, err)
+
+		result, err := unit.Execute(context.Background(), state)
+		require.NoError(t, err)
+		assert.Empty(t, client.calls)
+		scores, _ := domain.Get(result, domain.KeyJudgeScores)
+		assert.Len(t, scores, 3)
+	})
+}
+
+// judgeIDs returns the judge IDs recorded in byJudge.
+func judgeIDs(byJudge map[string][]domain.JudgeSummary) []string {
+	ids := make([]string, 0, len(byJudge))
+	for id := range byJudge {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// TestNewHybridJudgeFromConfig tests configuration errors.
+func TestNewHybridJudgeFromConfig(t *testing.T) {
+	judge := map[string]any{"judge_prompt": "Rate {{.Answer}}", "score_scale": "1-10"}
+	tests := []struct {
+		name   string
+		config map[string]any
+	}{
+		{name: "missing judge", config: map[string]any{}},
+		{name: "fuzzy not a mapping", config: map[string]any{"judge": judge, "fuzzy": "levenshtein"}},
+		{name: "invalid fuzzy", config: map[string]any{"judge": judge, "fuzzy": map[string]any{"algorithm": "jaro"}}},
+		{name: "inverted band", config: map[string]any{"judge": judge, "ambiguous_min": 0.8, "ambiguous_max": 0.2}},
+		{name: "band out of range", config: map[string]any{"judge": judge, "ambiguous_max": 1.5}},
+		{name: "zero weights", config: map[string]any{"judge": judge, "fuzzy_weight": 0, "llm_weight": 0}},
+		{name: "negative weight", config: map[string]any{"judge": judge, "llm_weight": -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHybridJudgeFromConfig("hybrid", tt.config, testutils.NewMockLLMClient("test-model"))
+			assert.Error(t, err)
+		})
+	}
+}
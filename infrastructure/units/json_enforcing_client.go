@@ -0,0 +1,126 @@
+package units
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+var _ ports.LLMClient = (*JSONEnforcingLLMClient)(nil)
+
+// jsonCorrectionInstruction is appended to the original prompt when a
+// response contained no valid JSON.
+const jsonCorrectionInstruction = "\n\nYour previous response did not contain valid JSON. " +
+	"Return only a single valid JSON object with no prose, explanation, or code fences."
+
+// JSONEnforcementStats counts how often a JSONEnforcingLLMClient had to
+// correct responses.
+type JSONEnforcementStats struct {
+	// Requests is the number of completions requested by callers.
+	Requests int
+	// Corrections is the number of corrective re-prompts issued.
+	Corrections int
+	// Failures is the number of requests that still lacked valid JSON
+	// after the corrective re-prompt.
+	Failures int
+}
+
+// JSONEnforcingLLMClient wraps a ports.LLMClient so that every successful
+// completion is a single valid JSON object. Responses are reduced to their
+// JSON object, discarding any prose a provider wrapped around it even when
+// response_format was requested. When a response contains no valid JSON,
+// the client re-prompts once with an instruction to return only JSON and
+// fails with domain.ErrResponseParse if that also fails.
+//
+// Graphs enable it per unit with enforce_json. The tokens of both attempts
+// are reported by CompleteWithUsage and the corrective call is recorded in
+// the caller's domain.CallMeter, so budget accounting includes it.
+//
+// JSONEnforcingLLMClient is safe for concurrent use.
+type JSONEnforcingLLMClient struct {
+	next      ports.LLMClient
+	selection JSONSelection
+
+	mu    sync.Mutex
+	stats JSONEnforcementStats
+}
+
+// NewJSONEnforcingLLMClient wraps next. selection chooses which object to
+// keep when a response contains several; empty selects the first.
+func NewJSONEnforcingLLMClient(next ports.LLMClient, selection JSONSelection) *JSONEnforcingLLMClient {
+	return &JSONEnforcingLLMClient{next: next, selection: selection}
+}
+
+// Complete returns the JSON object from the wrapped client's response.
+func (c *JSONEnforcingLLMClient) Complete(ctx context.Context, prompt string, options map[string]any) (string, error) {
+	response, _, _, err := c.CompleteWithUsage(ctx, prompt, options)
+	return response, err
+}
+
+// CompleteWithUsage returns the JSON object from the wrapped client's
+// response, re-prompting once if it had none. Token counts include both
+// attempts, and are reported even when the request fails after the first.
+func (c *JSONEnforcingLLMClient) CompleteWithUsage(
+	ctx context.Context,
+	prompt string,
+	options map[string]any,
+) (string, int, int, error) {
+	c.record(func(s *JSONEnforcementStats) { s.Requests++ })
+
+	response, tokensIn, tokensOut, err := c.next.CompleteWithUsage(ctx, prompt, options)
+	if err != nil {
+		return "", tokensIn, tokensOut, err
+	}
+	if jsonStr, ok := c.extract(response); ok {
+		return jsonStr, tokensIn, tokensOut, nil
+	}
+
+	c.record(func(s *JSONEnforcementStats) { s.Corrections++ })
+	domain.RecordExtraCalls(ctx, 1)
+	retry, retryIn, retryOut, err := c.next.CompleteWithUsage(ctx, prompt+jsonCorrectionInstruction, options)
+	tokensIn += retryIn
+	tokensOut += retryOut
+	if err != nil {
+		return "", tokensIn, tokensOut, fmt.Errorf("corrective JSON re-prompt: %w", err)
+	}
+	if jsonStr, ok := c.extract(retry); ok {
+		return jsonStr, tokensIn, tokensOut, nil
+	}
+
+	c.record(func(s *JSONEnforcementStats) { s.Failures++ })
+	return "", tokensIn, tokensOut, fmt.Errorf("%w: no valid JSON after corrective re-prompt (response length: %d chars)",
+		domain.ErrResponseParse, len(retry))
+}
+
+// extract returns the selected JSON object in response and whether it is
+// valid JSON.
+func (c *JSONEnforcingLLMClient) extract(response string) (string, bool) {
+	jsonStr, _ := selectJSON(response, c.selection)
+	return jsonStr, jsonStr != "" && json.Valid([]byte(jsonStr))
+}
+
+// record applies update to the stats under the lock.
+func (c *JSONEnforcingLLMClient) record(update func(*JSONEnforcementStats)) {
+	c.mu.Lock()
+	update(&c.stats)
+	c.mu.Unlock()
+}
+
+// Stats returns a snapshot of the correction counters.
+func (c *JSONEnforcingLLMClient) Stats() JSONEnforcementStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// EstimateTokens delegates to the wrapped client.
+func (c *JSONEnforcingLLMClient) EstimateTokens(text string) (int, error) {
+	return c.next.EstimateTokens(text)
+}
+
+// GetModel returns the wrapped client's model.
+func (c *JSONEnforcingLLMClient) GetModel() string { return c.next.GetModel() }
@@ -0,0 +1,140 @@
+package units
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/testutils"
+)
+
+// queuedClient returns queued responses in order with 10 input and 5
+// output tokens per call, recording the prompts it receives.
+type queuedClient struct {
+	*testutils.MockLLMClient
+	mu        sync.Mutex
+	responses []string
+	err       error
+	prompts   []string
+}
+
+// CompleteWithUsage returns the next queued response.
+func (c *queuedClient) CompleteWithUsage(ctx context.Context, prompt string, options map[string]any) (string, int, int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prompts = append(c.prompts, prompt)
+	if c.err != nil {
+		return "", 10, 0, c.err
+	}
+	response := c.responses[0]
+	c.responses = c.responses[1:]
+	return response, 10, 5, nil
+}
+
+// TestJSONEnforcingLLMClient verifies JSON extraction, the single
+// corrective re-prompt, and token accounting across attempts.
+func TestJSONEnforcingLLMClient(t *testing.T) {
+	t.Run("extracts JSON wrapped in prose", func(t *testing.T) {
+		next := &queuedClient{
+			MockLLMClient: testutils.NewMockLLMClient("test-model"),
+			responses:     []string{"Sure! Here is my verdict:\n```json\n{\"score\": 0.8}\n```\nHope that helps."},
+		}
+		client := NewJSONEnforcingLLMClient(next, "")
+
+		response, tokensIn, tokensOut, err := client.CompleteWithUsage(context.Background(), "prompt", nil)
+		require.NoError(t, err)
+		assert.Equal(t, `{"score": 0.8}`, response)
+		assert.Equal(t, 10, tokensIn)
+		assert.Equal(t, 5, tokensOut)
+		assert.Equal(t, JSONEnforcementStats{Requests: 1}, client.Stats())
+		assert.Equal(t, "test-model", client.GetModel())
+	})
+
+	t.Run("re-prompts once on invalid JSON", func(t *testing.T) {
+		next := &queuedClient{
+			MockLLMClient: testutils.NewMockLLMClient("test-model"),
+			responses:     []string{"The answer deserves a high score.", `{"score": 0.9}`},
+		}
+		client := NewJSONEnforcingLLMClient(next, "")
+
+		ctx, meter := domain.WithCallMeter(context.Background())
+		response, tokensIn, tokensOut, err := client.CompleteWithUsage(ctx, "prompt", nil)
+		require.NoError(t, err)
+		assert.Equal(t, `{"score": 0.9}`, response)
+		assert.Equal(t, 20, tokensIn, "both attempts are charged")
+		assert.Equal(t, 10, tokensOut)
+		assert.Equal(t, 2, meter.Calls(), "the corrective call is recorded")
+
+		require.Len(t, next.prompts, 2)
+		assert.True(t, strings.HasPrefix(next.prompts[1], "prompt"))
+		assert.Contains(t, next.prompts[1], "Return only a single valid JSON object")
+		assert.Equal(t, JSONEnforcementStats{Requests: 1, Corrections: 1}, client.Stats())
+	})
+
+	t.Run("fails after corrective re-prompt", func(t *testing.T) {
+		next := &queuedClient{
+			MockLLMClient: testutils.NewMockLLMClient("test-model"),
+			responses:     []string{"no json here", `{"score": 0.9,}`},
+		}
+		client := NewJSONEnforcingLLMClient(next, "")
+
+		_, err := client.Complete(context.Background(), "prompt", nil)
+		require.ErrorIs(t, err, domain.ErrResponseParse)
+		assert.Len(t, next.prompts, 2, "only one corrective re-prompt is issued")
+		assert.Equal(t, JSONEnforcementStats{Requests: 1, Corrections: 1, Failures: 1}, client.Stats())
+	})
+
+	t.Run("provider errors are not retried", func(t *testing.T) {
+		boom := errors.New("provider unavailable")
+		next := &queuedClient{MockLLMClient: testutils.NewMockLLMClient("test-model"), err: boom}
+		client := NewJSONEnforcingLLMClient(next, "")
+
+		_, tokensIn, _, err := client.CompleteWithUsage(context.Background(), "prompt", nil)
+		require.ErrorIs(t, err, boom)
+		assert.Equal(t, 10, tokensIn)
+		assert.Len(t, next.prompts, 1)
+	})
+
+	t.Run("selection picks the final object", func(t *testing.T) {
+		next := &queuedClient{
+			MockLLMClient: testutils.NewMockLLMClient("test-model"),
+			responses:     []string{`Draft: {"score": 0.2} Final: {"score": 0.7}`},
+		}
+		client := NewJSONEnforcingLLMClient(next, JSONSelectLast)
+
+		response, err := client.Complete(context.Background(), "prompt", nil)
+		require.NoError(t, err)
+		assert.Equal(t, `{"score": 0.7}`, response)
+	})
+}
+
+// TestJSONEnforcingLLMClient_WithScoreJudge verifies that a judge given the
+// wrapper scores a response it could not parse on the first attempt and
+// charges both calls to the budget.
+func TestJSONEnforcingLLMClient_WithScoreJudge(t *testing.T) {
+	next := &queuedClient{
+		MockLLMClient: testutils.NewMockLLMClient("test-model"),
+		responses: []string{
+			"I would rate this answer highly.",
+			`{"score": 0.9, "confidence": 0.8, "reasoning": "Correct and concise", "version": 1}`,
+		},
+	}
+	config := defaultScoreJudgeConfig()
+	config.ScoreScale = "0.0-1.0"
+	unit, err := NewScoreJudgeUnit("judge", NewJSONEnforcingLLMClient(next, ""), config)
+	require.NoError(t, err)
+
+	state := testutils.EvaluationState(t, "What is the capital of France?", []domain.Answer{{ID: "a1", Content: "Paris"}})
+	result, err := unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+
+	scores, _ := domain.Get(result, domain.KeyJudgeScores)
+	assert.Equal(t, 0.9, scores[0].Score)
+	assert.Equal(t, domain.Usage{Tokens: 30, Calls: 2}, result.GetBudgetUsage())
+}
@@ -0,0 +1,305 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+	"github.com/ahrav/go-gavel/internal/textnorm"
+)
+
+var _ ports.Unit = (*LCSStepUnit)(nil)
+
+// MaxLCSSteps caps the steps in an answer or the reference, bounding the
+// steps x steps table LCSStepUnit builds for each answer.
+const MaxLCSSteps = 1000
+
+// stepMarkerPattern matches the list markers that commonly introduce a
+// step, such as "1.", "2)", "-", "*", or "Step 3:", so that numbering
+// differences do not keep otherwise identical steps from matching. Number
+// and bullet markers must be followed by whitespace, so "3.5 cups" and
+// "-5 degrees" are left alone.
+var stepMarkerPattern = regexp.MustCompile(`(?i)^(?:step\s*\d+\s*[:.)-]?\s*|\d+[.)](?:\s+|$)|[-*•+](?:\s+|$))`)
+
+// LCSStepUnit grades procedural answers, such as recipes or instructions,
+// where the order of the steps matters. Answer and reference are split
+// into steps, and each answer scores the length of the longest common
+// subsequence of its steps with the reference steps, divided by the number
+// of reference steps. A step counts only if it matches a reference step
+// exactly after normalization, and only in the reference order, so
+// reordered or missing steps lower the score while extra steps do not.
+// Unlike FuzzyMatchUnit it compares whole steps, not characters.
+//
+// The reasoning of each score lists the reference steps that were matched.
+// The unit makes no LLM calls and is stateless and thread-safe.
+type LCSStepUnit struct {
+	// name is the unique identifier for this unit instance.
+	name string
+	// config contains the validated configuration parameters.
+	config LCSStepConfig
+	// normalizer applies the configured case folding.
+	normalizer *textnorm.Normalizer
+	// tracer is the OpenTelemetry tracer for observability.
+	tracer trace.Tracer
+}
+
+// LCSStepConfig defines how an LCSStepUnit splits and compares steps.
+type LCSStepConfig struct {
+	// Delimiter separates steps in answers and the reference. It defaults
+	// to a newline; use, e.g., ";" for steps written on one line.
+	Delimiter string `yaml:"delimiter" json:"delimiter" validate:"required,max=16"`
+
+	// CaseSensitive determines whether steps are compared case-sensitively.
+	CaseSensitive bool `yaml:"case_sensitive" json:"case_sensitive"`
+
+	// StripMarkers removes a leading list marker such as "1.", "-", or
+	// "Step 2:" from each step before comparison. Enabled by default.
+	StripMarkers bool `yaml:"strip_markers" json:"strip_markers"`
+
+	// Conclusive, when set, ends the evaluation after this unit once an
+	// answer's score reaches its MinScore, skipping later stages such as
+	// LLM judges. Nil (the default) always runs later stages.
+	Conclusive *ConclusiveConfig `yaml:"conclusive,omitempty" json:"conclusive,omitempty" validate:"omitempty"`
+}
+
+// DefaultLCSStepConfig returns an LCSStepConfig that splits steps on
+// newlines, ignores case, and strips list markers.
+func DefaultLCSStepConfig() LCSStepConfig {
+	return LCSStepConfig{
+		Delimiter:    "\n",
+		StripMarkers: true,
+	}
+}
+
+// procedureStep is one step of an answer or the reference: its text as
+// written, without any list marker, and the normalized key it is compared
+// by.
+type procedureStep struct {
+	text string
+	key  string
+}
+
+// NewLCSStepUnit creates a new LCSStepUnit with the specified
+// configuration. Returns an error if configuration validation fails.
+func NewLCSStepUnit(name string, config LCSStepConfig) (*LCSStepUnit, error) {
+	if name == "" {
+		return nil, ErrEmptyUnitName
+	}
+
+	if err := validate.Struct(config); err != nil {
+		return nil, domain.NewConfigValidationError(name, fieldValidationError(err))
+	}
+
+	return &LCSStepUnit{
+		name:       name,
+		config:     config,
+		normalizer: textnorm.New(textnorm.Policy{CaseSensitive: config.CaseSensitive}),
+		tracer:     otel.Tracer("lcs-step-unit"),
+	}, nil
+}
+
+// Name returns the unique identifier for this unit instance.
+func (lsu *LCSStepUnit) Name() string { return lsu.name }
+
+// Execute scores every answer in KeyAnswers by its ordered step overlap
+// with the reference answer and records the scores in State.
+//
+// Returns an error if answers or the reference are missing or too long, or
+// if the reference has no steps.
+func (lsu *LCSStepUnit) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	_, span := lsu.tracer.Start(ctx, "LCSStepUnit.Execute",
+		trace.WithAttributes(
+			attribute.String("unit.type", "lcs_step"),
+			attribute.String("unit.id", lsu.name),
+			attribute.Bool("config.case_sensitive", lsu.config.CaseSensitive),
+			attribute.Bool("config.strip_markers", lsu.config.StripMarkers),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+
+	answers, err := domain.Require(state, domain.KeyAnswers, lsu.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+	if len(answers) == 0 {
+		err := fmt.Errorf("no answers provided for step evaluation")
+		span.RecordError(err)
+		return state, err
+	}
+	if len(answers) > MaxAnswers {
+		err := fmt.Errorf("too many answers: %d exceeds limit of %d", len(answers), MaxAnswers)
+		span.RecordError(err)
+		return state, err
+	}
+
+	referenceAnswer, ok := state.GetReferenceAnswer()
+	if !ok {
+		err := fmt.Errorf("reference_answer required for deterministic evaluation")
+		span.RecordError(err)
+		return state, err
+	}
+	if len(referenceAnswer) > MaxStringLength {
+		err := fmt.Errorf("reference answer too long: %d bytes exceeds limit of %d", len(referenceAnswer), MaxStringLength)
+		span.RecordError(err)
+		return state, err
+	}
+
+	reference := lsu.splitSteps(referenceAnswer)
+	if len(reference) == 0 {
+		err := fmt.Errorf("reference answer has no steps")
+		span.RecordError(err)
+		return state, err
+	}
+	if len(reference) > MaxLCSSteps {
+		err := fmt.Errorf("reference answer has %d steps, exceeding limit of %d", len(reference), MaxLCSSteps)
+		span.RecordError(err)
+		return state, err
+	}
+
+	judgeSummaries := make([]domain.JudgeSummary, len(answers))
+	totalScore := 0.0
+	for i, answer := range answers {
+		if err := ctx.Err(); err != nil {
+			span.RecordError(err)
+			return state, err
+		}
+
+		if len(answer.Content) > MaxStringLength {
+			err := fmt.Errorf("answer %d too long: %d bytes exceeds limit of %d", i, len(answer.Content), MaxStringLength)
+			span.RecordError(err)
+			return state, err
+		}
+
+		steps := lsu.splitSteps(answer.Content)
+		if len(steps) > MaxLCSSteps {
+			err := fmt.Errorf("answer %d has %d steps, exceeding limit of %d", i, len(steps), MaxLCSSteps)
+			span.RecordError(err)
+			return state, err
+		}
+
+		matched := longestCommonSteps(steps, reference)
+		score := float64(len(matched)) / float64(len(reference))
+		judgeSummaries[i] = domain.JudgeSummary{
+			Score:      score,
+			Reasoning:  describeMatchedSteps(matched, reference),
+			Confidence: 1.0,
+		}
+		totalScore += score
+	}
+
+	span.SetAttributes(
+		attribute.Float64("eval.score", totalScore/float64(len(answers))),
+		attribute.Int64("eval.latency_ms", time.Since(start).Milliseconds()),
+		attribute.Int("eval.answers_count", len(answers)),
+		attribute.Int("eval.reference_steps", len(reference)),
+		attribute.Bool("no_llm_cost", true),
+	)
+
+	newState := domain.WithJudgeScores(state, lsu.name, judgeSummaries)
+	return withConclusion(newState, lsu.name, lsu.config.Conclusive, answers, judgeSummaries), nil
+}
+
+// splitSteps splits content on the configured delimiter into its non-empty
+// steps. Each step is trimmed, stripped of its list marker if configured,
+// and keyed by its normalized text with runs of whitespace collapsed.
+func (lsu *LCSStepUnit) splitSteps(content string) []procedureStep {
+	var steps []procedureStep
+	for _, part := range strings.Split(content, lsu.config.Delimiter) {
+		text := strings.TrimSpace(part)
+		if lsu.config.StripMarkers {
+			text = strings.TrimSpace(stepMarkerPattern.ReplaceAllString(text, ""))
+		}
+		if text == "" {
+			continue
+		}
+		key := strings.Join(strings.Fields(lsu.normalizer.Normalize(text)), " ")
+		steps = append(steps, procedureStep{text: text, key: key})
+	}
+	return steps
+}
+
+// longestCommonSteps returns the indices into reference of a longest
+// common subsequence of steps and reference, in increasing order. Of
+// several longest subsequences it returns the one matching the earliest
+// reference steps.
+func longestCommonSteps(steps, reference []procedureStep) []int {
+	n, m := len(steps), len(reference)
+	// lengths[i][j] is the LCS length of steps[i:] and reference[j:], so
+	// the matched steps can be read off front to back.
+	lengths := make([][]int, n+1)
+	for i := range lengths {
+		lengths[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if steps[i].key == reference[j].key {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	matched := make([]int, 0, lengths[0][0])
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case steps[i].key == reference[j].key:
+			matched = append(matched, j)
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return matched
+}
+
+// describeMatchedSteps explains a score by listing the reference steps,
+// numbered from 1, that the answer matched in order.
+func describeMatchedSteps(matched []int, reference []procedureStep) string {
+	summary := fmt.Sprintf("Matched %d of %d reference steps in order", len(matched), len(reference))
+	if len(matched) == 0 {
+		return summary
+	}
+	listed := make([]string, len(matched))
+	for k, j := range matched {
+		listed[k] = fmt.Sprintf("[%d] %s", j+1, reference[j].text)
+	}
+	return summary + ": " + strings.Join(listed, "; ")
+}
+
+// Validate checks if the unit is properly configured and ready for
+// execution.
+func (lsu *LCSStepUnit) Validate() error {
+	if err := validate.Struct(lsu.config); err != nil {
+		return domain.NewConfigValidationError(lsu.name, fieldValidationError(err))
+	}
+	return nil
+}
+
+// NewLCSStepFromConfig creates an LCSStepUnit from a configuration map.
+// This is the boundary adapter for YAML/JSON configuration. Step matching
+// doesn't require an LLM client.
+func NewLCSStepFromConfig(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error) {
+	// llm is ignored - step matching is deterministic.
+
+	cfg := DefaultLCSStepConfig()
+	if err := overlayYAML(config, &cfg); err != nil {
+		return nil, err
+	}
+
+	return NewLCSStepUnit(id, cfg)
+}
@@ -0,0 +1,135 @@
+package units
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/testutils"
+)
+
+// recipeReference is a four-step reference procedure.
+const recipeReference = "1. Preheat the oven\n2. Mix flour and sugar\n3. Add eggs\n4. Bake for 20 minutes"
+
+// TestLCSStepUnit_Execute tests scoring by the longest common subsequence
+// of steps and the matched steps recorded in reasoning.
+func TestLCSStepUnit_Execute(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        map[string]any
+		content       string
+		wantScore     float64
+		wantReasoning string
+	}{
+		{
+			name:          "same steps with different markers and case",
+			config:        map[string]any{},
+			content:       "- preheat the oven\n- Mix  flour and sugar\nStep 3: add eggs\n* bake for 20 minutes",
+			wantScore:     1,
+			wantReasoning: "Matched 4 of 4 reference steps in order: [1] Preheat the oven; [2] Mix flour and sugar; [3] Add eggs; [4] Bake for 20 minutes",
+		},
+		{
+			name:          "extra steps are not penalized",
+			config:        map[string]any{},
+			content:       "Preheat the oven\nGrease the pan\nMix flour and sugar\n\nAdd eggs\nBake for 20 minutes",
+			wantScore:     1,
+			wantReasoning: "Matched 4 of 4 reference steps in order",
+		},
+		{
+			name:          "swapped steps count once",
+			config:        map[string]any{},
+			content:       "Preheat the oven\nAdd eggs\nMix flour and sugar\nBake for 20 minutes",
+			wantScore:     0.75,
+			wantReasoning: "Matched 3 of 4 reference steps in order: [1] Preheat the oven; [2] Mix flour and sugar; [4] Bake for 20 minutes",
+		},
+		{
+			name:          "missing step",
+			config:        map[string]any{},
+			content:       "Preheat the oven\nBake for 20 minutes",
+			wantScore:     0.5,
+			wantReasoning: "[1] Preheat the oven; [4] Bake for 20 minutes",
+		},
+		{
+			name:          "no matching steps",
+			config:        map[string]any{},
+			content:       "Buy a cake",
+			wantScore:     0,
+			wantReasoning: "Matched 0 of 4 reference steps in order",
+		},
+		{
+			name:      "case sensitive",
+			config:    map[string]any{"case_sensitive": true},
+			content:   "preheat the oven\nMix flour and sugar\nAdd eggs\nBake for 20 minutes",
+			wantScore: 0.75,
+		},
+		{
+			name:      "markers kept when not stripped",
+			config:    map[string]any{"strip_markers": false},
+			content:   "Preheat the oven\nMix flour and sugar\nAdd eggs\nBake for 20 minutes",
+			wantScore: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := NewLCSStepFromConfig("steps", tt.config, nil)
+			require.NoError(t, err)
+
+			state := testutils.EvaluationState(t, "How do I bake a cake?", []domain.Answer{{ID: "a1", Content: tt.content}})
+			state = domain.With(state, domain.KeyReferenceAnswer, recipeReference)
+
+			result, err := unit.Execute(context.Background(), state)
+			require.NoError(t, err)
+
+			scores, ok := result.GetJudgeScores()
+			require.True(t, ok)
+			require.Len(t, scores, 1)
+			assert.InDelta(t, tt.wantScore, scores[0].Score, 1e-9)
+			assert.Equal(t, 1.0, scores[0].Confidence)
+			assert.Contains(t, scores[0].Reasoning, tt.wantReasoning)
+		})
+	}
+}
+
+// TestLCSStepUnit_Delimiter tests steps written on one line.
+func TestLCSStepUnit_Delimiter(t *testing.T) {
+	unit, err := NewLCSStepFromConfig("steps", map[string]any{"delimiter": ";"}, nil)
+	require.NoError(t, err)
+
+	state := testutils.EvaluationState(t, "Steps?", []domain.Answer{{ID: "a1", Content: "unplug it; open the case"}})
+	state = domain.With(state, domain.KeyReferenceAnswer, "Unplug it; Open the case; Replace the fan")
+
+	result, err := unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+	scores, _ := result.GetJudgeScores()
+	assert.InDelta(t, 2.0/3, scores[0].Score, 1e-9)
+	assert.Contains(t, scores[0].Reasoning, "[1] Unplug it; [2] Open the case")
+}
+
+// TestLCSStepUnit_Errors tests missing inputs, limits, and configuration
+// errors.
+func TestLCSStepUnit_Errors(t *testing.T) {
+	unit, err := NewLCSStepUnit("steps", DefaultLCSStepConfig())
+	require.NoError(t, err)
+
+	answers := []domain.Answer{{ID: "a1", Content: "Preheat the oven"}}
+	state := testutils.EvaluationState(t, "How?", answers)
+
+	_, err = unit.Execute(context.Background(), state)
+	assert.ErrorContains(t, err, "reference_answer required")
+
+	_, err = unit.Execute(context.Background(), domain.With(state, domain.KeyReferenceAnswer, "\n - \n"))
+	assert.ErrorContains(t, err, "no steps")
+
+	long := strings.Repeat("step\n", MaxLCSSteps+1)
+	_, err = unit.Execute(context.Background(), domain.With(state, domain.KeyReferenceAnswer, long))
+	assert.ErrorContains(t, err, "exceeding limit")
+
+	_, err = NewLCSStepFromConfig("steps", map[string]any{"delimiter": ""}, nil)
+	assert.Error(t, err)
+	_, err = NewLCSStepUnit("", DefaultLCSStepConfig())
+	assert.ErrorIs(t, err, ErrEmptyUnitName)
+}
@@ -33,8 +33,9 @@ type MaxPoolUnit struct {
 // All fields are validated during unit creation and parameter unmarshaling.
 type MaxPoolConfig struct {
 	// TieBreaker defines how to handle equal scores.
-	// Options: "first" (select first), "random" (random selection), "error" (fail on ties).
-	TieBreaker TieBreaker `yaml:"tie_breaker" json:"tie_breaker" validate:"required,oneof=first random error"`
+	// Options: "first" (select first), "random" (random selection), "error" (fail on ties),
+	// "lowest_id" (select the tied answer with the smallest ID, independent of order).
+	TieBreaker TieBreaker `yaml:"tie_breaker" json:"tie_breaker" validate:"required,oneof=first random error lowest_id"`
 
 	// MinScore sets the minimum acceptable aggregate score.
 	// Answers below this threshold may be rejected.
@@ -43,6 +44,29 @@ type MaxPoolConfig struct {
 	// RequireAllScores determines if all answers must have scores.
 	// When true, missing scores cause an error. When false, only scored answers are considered.
 	RequireAllScores bool `yaml:"require_all_scores" json:"require_all_scores"`
+
+	// ExpectedJudges names the judge units that must have scored every answer
+	// when RequireAllScores is true. When empty, every judge that reported
+	// scores is checked.
+	ExpectedJudges []string `yaml:"expected_judges,omitempty" json:"expected_judges,omitempty" validate:"omitempty,dive,required"`
+
+	// AbstainThreshold makes the unit abstain instead of picking a winner
+	// when the verdict's confidence falls below it. Abstained verdicts have
+	// no WinnerAnswer and require human review. Zero disables abstention.
+	AbstainThreshold float64 `yaml:"abstain_threshold" json:"abstain_threshold" validate:"min=0.0,max=1.0"`
+
+	// MinAnswers is the fewest answers the unit accepts. Execution fails
+	// with ErrTooFewAnswers when fewer are present, rather than producing a
+	// meaningless verdict. Zero disables the check.
+	MinAnswers int `yaml:"min_answers,omitempty" json:"min_answers,omitempty" validate:"min=0,max=10000"`
+	// OutputScale presents the verdict's scores on a consumer-facing scale
+	// in Verdict.Display, leaving AggregateScore and RankedAnswers on the
+	// internal scale. Nil disables it.
+	OutputScale *OutputScaleConfig `yaml:"output_scale,omitempty" json:"output_scale,omitempty" validate:"omitempty"`
+
+	// ReferenceSignal blends a deterministic unit's scores into the judge
+	// scores as a weighted voter. Nil aggregates judge scores only.
+	ReferenceSignal *ReferenceSignalConfig `yaml:"reference_signal,omitempty" json:"reference_signal,omitempty" validate:"omitempty"`
 }
 
 // NewMaxPoolUnit creates a new MaxPoolUnit with the specified configuration.
@@ -52,7 +76,10 @@ func NewMaxPoolUnit(name string, config MaxPoolConfig) (*MaxPoolUnit, error) {
 		return nil, ErrEmptyUnitName
 	}
 	if err := validate.Struct(config); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
+		return nil, domain.NewConfigValidationError(name, fieldValidationError(err))
+	}
+	if err := validateOutputScale(name, config.OutputScale); err != nil {
+		return nil, err
 	}
 	return &MaxPoolUnit{
 		name:   name,
@@ -83,9 +110,15 @@ func (mpu *MaxPoolUnit) Execute(ctx context.Context, state domain.State) (domain
 
 	start := time.Now()
 
-	answers, ok := domain.Get(state, domain.KeyAnswers)
-	if !ok {
-		err := fmt.Errorf("answers not found in state")
+	// A judge configured to tolerate empty items already recorded the
+	// outcome; there is nothing to aggregate.
+	if hasNoAnswersVerdict(state) && hasNoAnswers(state) {
+		span.SetAttributes(attribute.Bool("eval.no_answers", true))
+		return state, nil
+	}
+
+	answers, err := domain.Require(state, domain.KeyAnswers, mpu.name)
+	if err != nil {
 		span.RecordError(err)
 		return state, err
 	}
@@ -96,13 +129,37 @@ func (mpu *MaxPoolUnit) Execute(ctx context.Context, state domain.State) (domain
 		return state, err
 	}
 
-	judgeSummaries, ok := domain.Get(state, domain.KeyJudgeScores)
-	if !ok {
-		err := fmt.Errorf("judge scores not found in state")
+	if err := checkMinAnswers(mpu.name, len(answers), mpu.config.MinAnswers); err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	judgeSummaries, err := domain.Require(state, domain.KeyJudgeScores, mpu.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	judgeSummaries, err = applyReferenceSignal(state, judgeSummaries, mpu.config.ReferenceSignal)
+	if err != nil {
+		err = fmt.Errorf("unit %s: %w", mpu.name, err)
+		span.RecordError(err)
+		return state, err
+	}
+
+	if err := checkFiniteSummaries(judgeSummaries); err != nil {
+		err = fmt.Errorf("unit %s: %w", mpu.name, err)
 		span.RecordError(err)
 		return state, err
 	}
 
+	if mpu.config.RequireAllScores {
+		if err := checkJudgeCoverage(state, mpu.config.ExpectedJudges, answers); err != nil {
+			span.RecordError(err)
+			return state, err
+		}
+	}
+
 	numAnswers := len(answers)
 	numScores := len(judgeSummaries)
 
@@ -120,6 +177,10 @@ func (mpu *MaxPoolUnit) Execute(ctx context.Context, state domain.State) (domain
 
 	scores := make([]float64, numAnswers)
 	for i := 0; i < numAnswers; i++ {
+		if err := ctx.Err(); err != nil {
+			span.RecordError(err)
+			return state, err
+		}
 		scores[i] = judgeSummaries[i].Score
 	}
 
@@ -130,11 +191,27 @@ func (mpu *MaxPoolUnit) Execute(ctx context.Context, state domain.State) (domain
 		return state, err
 	}
 
+	rank := func(score float64) float64 { return score }
 	verdict := domain.Verdict{
+		SchemaVersion:  domain.VerdictSchemaVersion,
 		ID:             fmt.Sprintf("%s_verdict", mpu.name),
 		WinnerAnswer:   &winner,
 		AggregateScore: aggregateScore,
+		// Surface content-identical candidates so callers know the choice
+		// between them was arbitrary rather than score-driven.
+		DuplicateAnswerIDs: duplicateAnswerIDs(winner, answers[:numAnswers]),
+		RankedAnswers:      rankAnswers(answers[:numAnswers], scores, winner, mpu.config.TieBreaker, rank),
+		// Participating units are stamped by the graph executor.
+		Provenance: &domain.Provenance{
+			AggregationMethod: "max_pool",
+			ReferenceSignal:   mpu.config.ReferenceSignal.provenance(),
+		},
+		CreatedAt: time.Now(),
 	}
+	scale := latestJudgeScale(state)
+	verdict.Confidence = verdictConfidence(verdict.RankedAnswers, judgeSummaries[:numAnswers], rank, scale)
+	applyAbstention(&verdict, mpu.config.AbstainThreshold)
+	applyOutputScale(&verdict, mpu.config.OutputScale, scale)
 
 	latency := time.Since(start)
 	span.SetAttributes(
@@ -143,6 +220,9 @@ func (mpu *MaxPoolUnit) Execute(ctx context.Context, state domain.State) (domain
 		attribute.Int("eval.judge_scores_count", len(judgeSummaries)),
 		attribute.Float64("eval.aggregate_score", aggregateScore),
 		attribute.String("eval.winner_id", winner.ID),
+		attribute.Int("eval.duplicate_answers_count", len(verdict.DuplicateAnswerIDs)),
+		attribute.Float64("eval.confidence", verdict.Confidence),
+		attribute.String("eval.status", string(verdict.Status)),
 		attribute.Bool("no_llm_cost", true), // Deterministic units have no LLM cost
 	)
 
@@ -174,8 +254,8 @@ func (mpu *MaxPoolUnit) Aggregate(
 	for i, score := range scores {
 		// Validate score is not NaN or infinite to prevent corrupted aggregation.
 		// NaN and infinite values can break comparison logic and produce invalid results.
-		if math.IsNaN(score) || math.IsInf(score, 0) {
-			return domain.Answer{}, 0, fmt.Errorf("invalid score at index %d: %f", i, score)
+		if err := checkFinite("score", score); err != nil {
+			return domain.Answer{}, 0, fmt.Errorf("invalid score at index %d: %w", i, err)
 		}
 
 		if score > maxScore {
@@ -203,15 +283,13 @@ func (mpu *MaxPoolUnit) Aggregate(
 		case TieError:
 			// Fail explicitly when ties occur, forcing caller to handle ambiguity.
 			return domain.Answer{}, 0, fmt.Errorf("%w: %d answers with score %.3f", ErrTie, tieCount, maxScore)
+		case TieLowestID:
+			// Select by answer ID so reordering the input cannot change the winner.
+			winnerIdx = lowestIDIndex(candidates, tiedIndices(scores, maxScore))
 		case TieRandom:
 			// Randomly select among tied candidates for fairness.
 			// This prevents systematic bias toward first/last positions.
-			tiedCandidates := make([]int, 0, tieCount)
-			for i, score := range scores {
-				if score == maxScore {
-					tiedCandidates = append(tiedCandidates, i)
-				}
-			}
+			tiedCandidates := tiedIndices(scores, maxScore)
 			// Use crypto/rand for cryptographically secure, unbiased selection.
 			// This ensures no predictable patterns in tie-breaking decisions.
 			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(tiedCandidates))))
@@ -230,7 +308,10 @@ func (mpu *MaxPoolUnit) Aggregate(
 // Validate checks if the unit is properly configured.
 func (mpu *MaxPoolUnit) Validate() error {
 	if err := validate.Struct(mpu.config); err != nil {
-		return fmt.Errorf("configuration validation failed: %w", err)
+		return domain.NewConfigValidationError(mpu.name, fieldValidationError(err))
+	}
+	if err := validateOutputScale(mpu.name, mpu.config.OutputScale); err != nil {
+		return err
 	}
 	return nil
 }
@@ -242,6 +323,9 @@ func (mpu *MaxPoolUnit) UnmarshalParameters(params yaml.Node) error {
 		return fmt.Errorf("failed to decode parameters: %w", err)
 	}
 	if err := validate.Struct(config); err != nil {
+		return fmt.Errorf("parameter validation failed: %w", fieldValidationError(err))
+	}
+	if err := config.OutputScale.check(); err != nil {
 		return fmt.Errorf("parameter validation failed: %w", err)
 	}
 	mpu.config = config
@@ -25,6 +25,22 @@ func TestMeanPoolUnit_Aggregate(t *testing.T) {
 		expectedScore    float64 // This should be the mean of all scores
 		expectedError    string
 	}{
+		{
+			name: "lowest_id tie breaker selects smallest tied ID regardless of order",
+			config: MaxPoolConfig{
+				TieBreaker:       TieLowestID,
+				MinScore:         0.0,
+				RequireAllScores: true,
+			},
+			scores: []float64{0.8, 0.8, 0.7},
+			candidates: []domain.Answer{
+				{ID: "z", Content: "First answer"},
+				{ID: "b", Content: "Second answer"},
+				{ID: "a", Content: "Third answer"},
+			},
+			expectedWinnerID: "b",
+			expectedScore:    0.8,
+		},
 		{
 			name: "selects highest score winner with max aggregate score",
 			config: MaxPoolConfig{
@@ -186,6 +202,78 @@ func TestMeanPoolUnit_Execute(t *testing.T) {
 		expectedError  string
 		validateResult func(t *testing.T, state domain.State)
 	}{
+		{
+			name: "ranks all answers best-first with winner on top",
+			config: MaxPoolConfig{
+				TieBreaker:       TieLowestID,
+				MinScore:         0.0,
+				RequireAllScores: true,
+			},
+			setupState: func() domain.State {
+				state := domain.NewState()
+				answers := []domain.Answer{
+					{ID: "answer1", Content: "First answer"},
+					{ID: "answer2", Content: "Second answer"},
+					{ID: "answer3", Content: "Third answer"},
+					{ID: "answer4", Content: "Fourth answer"},
+				}
+				judgeSummaries := []domain.JudgeSummary{
+					{Score: 0.5, Reasoning: "r1", Confidence: 0.9},
+					{Score: 0.9, Reasoning: "r2", Confidence: 0.9},
+					{Score: 0.7, Reasoning: "r3", Confidence: 0.9},
+					{Score: 0.9, Reasoning: "r4", Confidence: 0.9},
+				}
+				state = domain.With(state, domain.KeyAnswers, answers)
+				state = domain.With(state, domain.KeyJudgeScores, judgeSummaries)
+				return state
+			},
+			validateResult: func(t *testing.T, state domain.State) {
+				verdict, ok := domain.Get(state, domain.KeyVerdict)
+				require.True(t, ok, "Verdict should be present in state")
+				require.Len(t, verdict.RankedAnswers, 4)
+				assert.InDelta(t, 0.9, verdict.RankedAnswers[0].Score, 0.0001)
+				assert.InDelta(t, 0.5, verdict.RankedAnswers[3].Score, 0.0001)
+
+				var ids []string
+				for _, ranked := range verdict.RankedAnswers {
+					ids = append(ids, ranked.Answer.ID)
+				}
+				assert.Equal(t, []string{"answer2", "answer4", "answer3", "answer1"}, ids)
+				assert.Equal(t, *verdict.WinnerAnswer, verdict.RankedAnswers[0].Answer)
+			},
+		},
+		{
+			name: "reports content-identical candidates tied with the winner",
+			config: MaxPoolConfig{
+				TieBreaker:       TieLowestID,
+				MinScore:         0.0,
+				RequireAllScores: true,
+			},
+			setupState: func() domain.State {
+				state := domain.NewState()
+				answers := []domain.Answer{
+					{ID: "answer3", Content: "Paris"},
+					{ID: "answer1", Content: " Paris "},
+					{ID: "answer2", Content: "London"},
+				}
+				judgeSummaries := []domain.JudgeSummary{
+					{Score: 0.9, Reasoning: "Correct", Confidence: 0.9},
+					{Score: 0.9, Reasoning: "Correct", Confidence: 0.9},
+					{Score: 0.9, Reasoning: "Incorrect", Confidence: 0.9},
+				}
+				state = domain.With(state, domain.KeyAnswers, answers)
+				state = domain.With(state, domain.KeyJudgeScores, judgeSummaries)
+				return state
+			},
+			validateResult: func(t *testing.T, state domain.State) {
+				verdict, ok := domain.Get(state, domain.KeyVerdict)
+				require.True(t, ok, "Verdict should be present in state")
+				require.NotNil(t, verdict, "Verdict should not be nil")
+
+				assert.Equal(t, "answer1", verdict.WinnerAnswer.ID)
+				assert.Equal(t, []string{"answer1", "answer3"}, verdict.DuplicateAnswerIDs)
+			},
+		},
 		{
 			name: "successful execution with valid data",
 			config: MaxPoolConfig{
@@ -251,7 +339,7 @@ func TestMeanPoolUnit_Execute(t *testing.T) {
 				// Missing judge scores
 				return state
 			},
-			expectedError: "judge scores not found in state",
+			expectedError: "judge_scores not found in state",
 		},
 		{
 			name: "handles length mismatch when RequireAllScores is false",
@@ -428,3 +516,46 @@ func TestNewMaxPoolFromConfig(t *testing.T) {
 		assert.Equal(t, "test_id", unit.Name())
 	})
 }
+
+// TestRankAnswers_WinnerFirst verifies that the ranking always starts with the
+// aggregator's chosen winner, even when a random tie-break picked a candidate
+// that stable ordering would have placed later.
+func TestRankAnswers_WinnerFirst(t *testing.T) {
+	candidates := []domain.Answer{
+		{ID: "a", Content: "A"},
+		{ID: "b", Content: "B"},
+		{ID: "c", Content: "C"},
+	}
+	scores := []float64{0.9, 0.2, 0.9}
+	identity := func(score float64) float64 { return score }
+
+	ranked := rankAnswers(candidates, scores, candidates[2], TieRandom, identity)
+	require.Len(t, ranked, 3)
+	assert.Equal(t, "c", ranked[0].Answer.ID)
+	assert.Equal(t, "a", ranked[1].Answer.ID)
+	assert.Equal(t, "b", ranked[2].Answer.ID)
+	assert.InDelta(t, 0.2, ranked[2].Score, 0.0001)
+}
+
+// TestMaxPoolUnit_PreservesAnswerMetadata verifies that answer metadata is
+// carried through aggregation onto the verdict's winner and ranking.
+func TestMaxPoolUnit_PreservesAnswerMetadata(t *testing.T) {
+	answers := []domain.Answer{
+		{ID: "a1", Content: "Paris", Metadata: map[string]string{domain.AnswerMetadataModel: "gen-a"}},
+		{ID: "a2", Content: "Lyon", Metadata: map[string]string{domain.AnswerMetadataModel: "gen-b"}},
+	}
+	state := domain.With(domain.NewState(), domain.KeyAnswers, answers)
+	state = domain.With(state, domain.KeyJudgeScores, []domain.JudgeSummary{{Score: 0.4}, {Score: 0.9}})
+
+	unit, err := NewMaxPoolUnit("pool", DefaultMaxPoolConfig())
+	require.NoError(t, err)
+
+	newState, err := unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+	verdict, ok := domain.Get(newState, domain.KeyVerdict)
+	require.True(t, ok)
+	require.NotNil(t, verdict.WinnerAnswer)
+	assert.Equal(t, "gen-b", verdict.WinnerAnswer.Metadata[domain.AnswerMetadataModel])
+	require.Len(t, verdict.RankedAnswers, 2)
+	assert.Equal(t, "gen-a", verdict.RankedAnswers[1].Answer.Metadata[domain.AnswerMetadataModel])
+}
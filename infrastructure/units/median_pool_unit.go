@@ -19,6 +19,35 @@ import (
 
 var _ ports.Unit = (*MedianPoolUnit)(nil)
 
+// Supported even-count median methods for the MedianPoolUnit.
+const (
+	// MedianInterpolate averages the two middle scores. This is the default.
+	MedianInterpolate = "interpolate"
+
+	// MedianLower takes the lower of the two middle scores.
+	MedianLower = "lower"
+
+	// MedianUpper takes the upper of the two middle scores.
+	MedianUpper = "upper"
+
+	// medianMiddle is the selection recorded for an odd number of scores,
+	// where the median is always the single middle score.
+	medianMiddle = "middle"
+)
+
+// medianResult is the outcome of median aggregation.
+type medianResult struct {
+	// winnerIdx is the index of the winning candidate.
+	winnerIdx int
+	// median is the aggregate median score.
+	median float64
+	// selection records how the median was derived (see
+	// domain.Provenance.MedianSelection).
+	selection string
+	// tieBreak describes the tie resolution, or is nil if there was no tie.
+	tieBreak *domain.TieBreak
+}
+
 // MedianPoolUnit implements an Aggregator that uses the median score to
 // determine the aggregate score. The candidate whose score is closest to the
 // median is selected as the winner.
@@ -67,9 +96,26 @@ type MedianPoolConfig struct {
 	//   - "first": Select the first candidate (deterministic)
 	//   - "random": Randomly select among tied candidates (fair but non-deterministic)
 	//   - "error": Return an error requiring explicit handling
+	//   - "lowest_id": Select the tied candidate with the smallest answer ID
+	//   - "confidence": Select the tied candidate with the highest judge confidence
+	//   - "seeded_random": Randomly select using TieSeed (fair and reproducible)
 	//
 	// Default: "first" for deterministic behavior in evaluation pipelines.
-	TieBreaker TieBreaker `yaml:"tie_breaker" json:"tie_breaker" validate:"required,oneof=first random error"`
+	// The verdict's TieBreak records which candidate won a tie and why.
+	TieBreaker TieBreaker `yaml:"tie_breaker" json:"tie_breaker" validate:"required,oneof=first random error lowest_id confidence seeded_random"`
+
+	// TieSeed seeds the pseudo-random source used by the "seeded_random"
+	// tie breaker. Execute mixes it with the item as domain.ItemSeed does,
+	// so rerunning an item breaks its ties the same way while ties across a
+	// dataset do not all resolve to the same position. It is ignored by
+	// other strategies.
+	TieSeed int64 `yaml:"tie_seed" json:"tie_seed"`
+
+	// MedianMethod selects the median of an even number of scores:
+	// "interpolate" (the default) averages the two middle values, while
+	// "lower" and "upper" take the lower or upper middle value. Taking an
+	// actual score guarantees the median matches at least one candidate.
+	MedianMethod string `yaml:"median_method" json:"median_method" validate:"omitempty,oneof=interpolate lower upper"`
 
 	// MinScore sets the minimum acceptable aggregate (median) score.
 	// If the calculated median falls below this threshold, aggregation fails
@@ -86,6 +132,29 @@ type MedianPoolConfig struct {
 	// Set to true for strict evaluation scenarios requiring complete scoring.
 	// Set to false when partial scoring is acceptable (e.g., optional judges).
 	RequireAllScores bool `yaml:"require_all_scores" json:"require_all_scores"`
+
+	// ExpectedJudges names the judge units that must have scored every answer
+	// when RequireAllScores is true. When empty, every judge that reported
+	// scores is checked.
+	ExpectedJudges []string `yaml:"expected_judges,omitempty" json:"expected_judges,omitempty" validate:"omitempty,dive,required"`
+
+	// AbstainThreshold makes the unit abstain instead of picking a winner
+	// when the verdict's confidence falls below it. Abstained verdicts have
+	// no WinnerAnswer and require human review. Zero disables abstention.
+	AbstainThreshold float64 `yaml:"abstain_threshold" json:"abstain_threshold" validate:"min=0.0,max=1.0"`
+
+	// MinAnswers is the fewest answers the unit accepts. Execution fails
+	// with ErrTooFewAnswers when fewer are present, rather than producing a
+	// meaningless verdict. Zero disables the check.
+	MinAnswers int `yaml:"min_answers,omitempty" json:"min_answers,omitempty" validate:"min=0,max=10000"`
+	// OutputScale presents the verdict's scores on a consumer-facing scale
+	// in Verdict.Display, leaving AggregateScore and RankedAnswers on the
+	// internal scale. Nil disables it.
+	OutputScale *OutputScaleConfig `yaml:"output_scale,omitempty" json:"output_scale,omitempty" validate:"omitempty"`
+
+	// ReferenceSignal blends a deterministic unit's scores into the judge
+	// scores as a weighted voter. Nil aggregates judge scores only.
+	ReferenceSignal *ReferenceSignalConfig `yaml:"reference_signal,omitempty" json:"reference_signal,omitempty" validate:"omitempty"`
 }
 
 // NewMedianPoolUnit creates a new MedianPoolUnit with the specified configuration.
@@ -105,7 +174,10 @@ func NewMedianPoolUnit(name string, config MedianPoolConfig) (*MedianPoolUnit, e
 		return nil, ErrEmptyUnitName
 	}
 	if err := validate.Struct(config); err != nil {
-		return nil, fmt.Errorf("configuration validation failed: %w", err)
+		return nil, domain.NewConfigValidationError(name, fieldValidationError(err))
+	}
+	if err := validateOutputScale(name, config.OutputScale); err != nil {
+		return nil, err
 	}
 	return &MedianPoolUnit{
 		name:   name,
@@ -153,6 +225,7 @@ func (mpu *MedianPoolUnit) Execute(ctx context.Context, state domain.State) (dom
 			attribute.String("unit.type", "median_pool"),
 			attribute.String("unit.id", mpu.name),
 			attribute.String("config.tie_breaker", string(mpu.config.TieBreaker)),
+			attribute.String("config.median_method", mpu.medianMethod()),
 			attribute.Float64("config.min_score", mpu.config.MinScore),
 			attribute.Bool("config.require_all_scores", mpu.config.RequireAllScores),
 		),
@@ -161,9 +234,15 @@ func (mpu *MedianPoolUnit) Execute(ctx context.Context, state domain.State) (dom
 
 	start := time.Now()
 
-	answers, ok := domain.Get(state, domain.KeyAnswers)
-	if !ok {
-		err := fmt.Errorf("answers not found in state")
+	// A judge configured to tolerate empty items already recorded the
+	// outcome; there is nothing to aggregate.
+	if hasNoAnswersVerdict(state) && hasNoAnswers(state) {
+		span.SetAttributes(attribute.Bool("eval.no_answers", true))
+		return state, nil
+	}
+
+	answers, err := domain.Require(state, domain.KeyAnswers, mpu.name)
+	if err != nil {
 		span.RecordError(err)
 		return state, err
 	}
@@ -174,13 +253,37 @@ func (mpu *MedianPoolUnit) Execute(ctx context.Context, state domain.State) (dom
 		return state, err
 	}
 
-	judgeSummaries, ok := domain.Get(state, domain.KeyJudgeScores)
-	if !ok {
-		err := fmt.Errorf("judge scores not found in state")
+	if err := checkMinAnswers(mpu.name, len(answers), mpu.config.MinAnswers); err != nil {
 		span.RecordError(err)
 		return state, err
 	}
 
+	judgeSummaries, err := domain.Require(state, domain.KeyJudgeScores, mpu.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	judgeSummaries, err = applyReferenceSignal(state, judgeSummaries, mpu.config.ReferenceSignal)
+	if err != nil {
+		err = fmt.Errorf("unit %s: %w", mpu.name, err)
+		span.RecordError(err)
+		return state, err
+	}
+
+	if err := checkFiniteSummaries(judgeSummaries); err != nil {
+		err = fmt.Errorf("unit %s: %w", mpu.name, err)
+		span.RecordError(err)
+		return state, err
+	}
+
+	if mpu.config.RequireAllScores {
+		if err := checkJudgeCoverage(state, mpu.config.ExpectedJudges, answers); err != nil {
+			span.RecordError(err)
+			return state, err
+		}
+	}
+
 	numAnswers := len(answers)
 	numScores := len(judgeSummaries)
 
@@ -196,22 +299,48 @@ func (mpu *MedianPoolUnit) Execute(ctx context.Context, state domain.State) (dom
 	}
 
 	scores := make([]float64, numAnswers)
+	confidences := make([]float64, numAnswers)
 	for i := 0; i < numAnswers; i++ {
+		if err := ctx.Err(); err != nil {
+			span.RecordError(err)
+			return state, err
+		}
 		scores[i] = judgeSummaries[i].Score
+		confidences[i] = judgeSummaries[i].Confidence
 	}
 
-	winner, aggregateScore, err := mpu.Aggregate(scores, answers[:numAnswers])
+	result, err := mpu.aggregate(scores, confidences, answers[:numAnswers], domain.ItemSeed(state, mpu.config.TieSeed))
 	if err != nil {
 		err := fmt.Errorf("aggregation failed: %w", err)
 		span.RecordError(err)
 		return state, err
 	}
+	winner, aggregateScore := answers[result.winnerIdx], result.median
 
+	// Candidates closest to the median rank highest, mirroring winner selection.
+	rank := func(score float64) float64 { return -math.Abs(score - aggregateScore) }
 	verdict := domain.Verdict{
+		SchemaVersion:  domain.VerdictSchemaVersion,
 		ID:             fmt.Sprintf("%s_verdict", mpu.name),
 		WinnerAnswer:   &winner,
 		AggregateScore: aggregateScore,
+		// Surface content-identical candidates so callers know the choice
+		// between them was arbitrary rather than score-driven.
+		DuplicateAnswerIDs: duplicateAnswerIDs(winner, answers[:numAnswers]),
+		RankedAnswers:      rankAnswers(answers[:numAnswers], scores, winner, mpu.config.TieBreaker, rank),
+		TieBreak:           result.tieBreak,
+		// Participating units are stamped by the graph executor.
+		Provenance: &domain.Provenance{
+			AggregationMethod: "median_pool",
+			MedianSelection:   result.selection,
+			ReferenceSignal:   mpu.config.ReferenceSignal.provenance(),
+		},
+		CreatedAt: time.Now(),
 	}
+	scale := latestJudgeScale(state)
+	verdict.Confidence = verdictConfidence(verdict.RankedAnswers, judgeSummaries[:numAnswers], rank, scale)
+	applyAbstention(&verdict, mpu.config.AbstainThreshold)
+	applyOutputScale(&verdict, mpu.config.OutputScale, scale)
 
 	latency := time.Since(start)
 	span.SetAttributes(
@@ -220,16 +349,22 @@ func (mpu *MedianPoolUnit) Execute(ctx context.Context, state domain.State) (dom
 		attribute.Int("eval.judge_scores_count", len(judgeSummaries)),
 		attribute.Float64("eval.aggregate_score", aggregateScore),
 		attribute.String("eval.winner_id", winner.ID),
+		attribute.String("eval.median_selection", result.selection),
+		attribute.Bool("eval.tie_broken", result.tieBreak != nil),
+		attribute.Int("eval.duplicate_answers_count", len(verdict.DuplicateAnswerIDs)),
+		attribute.Float64("eval.confidence", verdict.Confidence),
+		attribute.String("eval.status", string(verdict.Status)),
 		attribute.Bool("no_llm_cost", true), // Deterministic units have no LLM cost
 	)
 
 	return domain.With(state, domain.KeyVerdict, &verdict), nil
 }
 
-// calculateMedian computes the statistical median from a slice of scores.
-// The method implements the standard mathematical definition:
-//   - Odd count: returns the middle value after sorting
-//   - Even count: returns the arithmetic mean of the two middle values
+// calculateMedian computes the statistical median from a slice of scores
+// and reports how it was derived:
+//   - Odd count: returns the middle value after sorting ("middle")
+//   - Even count: applies the configured MedianMethod to the two middle
+//     values, averaging them by default ("interpolate")
 //
 // Side Effects: The input slice is sorted in-place for performance.
 // Callers should pass a copy if original order must be preserved.
@@ -241,20 +376,37 @@ func (mpu *MedianPoolUnit) Execute(ctx context.Context, state domain.State) (dom
 //
 // Time Complexity: O(n log n) due to sorting
 // Space Complexity: O(1) as sorting is in-place
-func (mpu *MedianPoolUnit) calculateMedian(scores []float64) float64 {
+func (mpu *MedianPoolUnit) calculateMedian(scores []float64) (float64, string) {
 	if len(scores) == 0 {
-		return 0
+		return 0, medianMiddle
 	}
 	sort.Float64s(scores)
 	n := len(scores)
 	if n%2 == 1 {
 		// Odd count: middle element is at index n/2 after sorting
-		return scores[n/2]
+		return scores[n/2], medianMiddle
 	}
-	// Even count: median is arithmetic mean of two middle elements
-	// This ensures the median represents the central tendency even
-	// when no single score represents the exact middle.
-	return (scores[n/2-1] + scores[n/2]) / 2
+	lower, upper := scores[n/2-1], scores[n/2]
+	switch method := mpu.medianMethod(); method {
+	case MedianLower:
+		return lower, method
+	case MedianUpper:
+		return upper, method
+	default:
+		// Even count: median is arithmetic mean of two middle elements
+		// This ensures the median represents the central tendency even
+		// when no single score represents the exact middle.
+		return (lower + upper) / 2, method
+	}
+}
+
+// medianMethod returns the configured even-count median method, treating
+// an empty value as MedianInterpolate.
+func (mpu *MedianPoolUnit) medianMethod() string {
+	if mpu.config.MedianMethod == "" {
+		return MedianInterpolate
+	}
+	return mpu.config.MedianMethod
 }
 
 // Aggregate implements the domain.Aggregator interface by selecting the
@@ -273,7 +425,8 @@ func (mpu *MedianPoolUnit) calculateMedian(scores []float64) float64 {
 //  1. Validates input arrays have equal length and contain valid scores
 //  2. Calculates median using standard statistical definition
 //  3. Finds candidate(s) with minimum absolute distance from median
-//  4. Applies configured tie-breaking strategy for equidistant candidates
+//  4. Applies configured tie-breaking strategy for equidistant candidates;
+//     without an item, "seeded_random" uses TieSeed as is
 //  5. Validates median meets minimum score threshold
 //
 // Error Conditions:
@@ -288,28 +441,45 @@ func (mpu *MedianPoolUnit) Aggregate(
 	scores []float64,
 	candidates []domain.Answer,
 ) (domain.Answer, float64, error) {
+	result, err := mpu.aggregate(scores, nil, candidates, mpu.config.TieSeed)
+	if err != nil {
+		return domain.Answer{}, 0, err
+	}
+	return candidates[result.winnerIdx], result.median, nil
+}
+
+// aggregate performs median aggregation and records how the median and any
+// tie were resolved. confidences holds the judge confidence for each score
+// and may be nil, in which case the "confidence" tie breaker falls back to
+// input order. seed drives the "seeded_random" tie breaker.
+func (mpu *MedianPoolUnit) aggregate(
+	scores []float64,
+	confidences []float64,
+	candidates []domain.Answer,
+	seed int64,
+) (medianResult, error) {
 	if len(scores) == 0 {
-		return domain.Answer{}, 0, ErrNoScores
+		return medianResult{}, ErrNoScores
 	}
 	if len(scores) != len(candidates) {
-		return domain.Answer{}, 0, fmt.Errorf("%w: scores=%d, candidates=%d",
+		return medianResult{}, fmt.Errorf("%w: scores=%d, candidates=%d",
 			ErrScoreMismatch, len(scores), len(candidates))
 	}
 
 	// Validate all scores are finite numbers before processing.
 	// NaN and Inf values would corrupt median calculation and distance comparisons.
 	for i, score := range scores {
-		if math.IsNaN(score) || math.IsInf(score, 0) {
-			return domain.Answer{}, 0, fmt.Errorf("invalid score at index %d: %f", i, score)
+		if err := checkFinite("score", score); err != nil {
+			return medianResult{}, fmt.Errorf("invalid score at index %d: %w", i, err)
 		}
 	}
 
 	scoresCopy := make([]float64, len(scores))
 	copy(scoresCopy, scores)
-	medianScore := mpu.calculateMedian(scoresCopy)
+	medianScore, selection := mpu.calculateMedian(scoresCopy)
 
 	if medianScore < mpu.config.MinScore {
-		return domain.Answer{}, 0, fmt.Errorf("%w: median=%.3f, minimum=%.3f",
+		return medianResult{}, fmt.Errorf("%w: median=%.3f, minimum=%.3f",
 			ErrBelowMinScore, medianScore, mpu.config.MinScore)
 	}
 
@@ -328,26 +498,74 @@ func (mpu *MedianPoolUnit) Aggregate(
 		}
 	}
 
+	result := medianResult{winnerIdx: winnerIdx, median: medianScore, selection: selection}
+
 	// Handle ties: multiple candidates with identical distance from median
 	if len(tieIndices) > 1 {
+		var reason string
 		switch mpu.config.TieBreaker {
 		case TieFirst:
 			// Deterministic selection: choose first tied candidate
 			// Provides reproducible results for testing and evaluation consistency
 			winnerIdx = tieIndices[0]
+			reason = "first tied candidate in input order"
 		case TieError:
 			// Explicit handling required: force caller to address ambiguity
 			// Useful when tie-breaking has business logic implications
-			return domain.Answer{}, 0, fmt.Errorf("%w: %d answers with distance %.3f from median %.3f (tied candidates: %v)",
+			return medianResult{}, fmt.Errorf("%w: %d answers with distance %.3f from median %.3f (tied candidates: %v)",
 				ErrTie, len(tieIndices), bestDistance, medianScore, tieIndices)
+		case TieLowestID:
+			// Order-independent selection keyed on answer ID
+			winnerIdx = lowestIDIndex(candidates, tieIndices)
+			reason = fmt.Sprintf("lowest answer ID %q among tied candidates", candidates[winnerIdx].ID)
+		case TieConfidence:
+			winnerIdx, reason = highestConfidenceIndex(confidences, tieIndices)
+		case TieSeededRandom:
+			// A fresh source per call keeps the choice reproducible for a
+			// given item regardless of concurrent executions.
+			rng := rand.New(rand.NewSource(seed)) // #nosec G404
+			winnerIdx = tieIndices[rng.Intn(len(tieIndices))]
+			reason = fmt.Sprintf("seeded random selection (item seed %d from tie_seed %d)", seed, mpu.config.TieSeed)
 		case TieRandom:
 			// Fair random selection among tied candidates
 			// Use math/rand for better performance - cryptographic security not needed for tie-breaking
 			winnerIdx = tieIndices[rand.Intn(len(tieIndices))] // #nosec G404
+			reason = "random selection"
+		}
+		result.winnerIdx = winnerIdx
+		result.tieBreak = &domain.TieBreak{
+			Strategy:         string(mpu.config.TieBreaker),
+			CandidateIndices: tieIndices,
+			WinnerIndex:      winnerIdx,
+			Reason:           fmt.Sprintf("%d candidates at distance %.3f from median %.3f; %s", len(tieIndices), bestDistance, medianScore, reason),
 		}
 	}
 
-	return candidates[winnerIdx], medianScore, nil
+	return result, nil
+}
+
+// highestConfidenceIndex returns the index from tied with the highest
+// confidence and a reason describing the choice. Equal confidences, or a
+// nil confidences slice, fall back to the earliest tied index.
+func highestConfidenceIndex(confidences []float64, tied []int) (int, string) {
+	if confidences == nil {
+		return tied[0], "no confidences available; first tied candidate in input order"
+	}
+	best := tied[0]
+	unique := true
+	for _, idx := range tied[1:] {
+		switch {
+		case confidences[idx] > confidences[best]:
+			best = idx
+			unique = true
+		case confidences[idx] == confidences[best]:
+			unique = false
+		}
+	}
+	if !unique {
+		return best, fmt.Sprintf("confidence %.2f shared by several tied candidates; first in input order", confidences[best])
+	}
+	return best, fmt.Sprintf("highest judge confidence %.2f among tied candidates", confidences[best])
 }
 
 // Validate checks if the unit is properly configured and ready for execution.
@@ -363,7 +581,10 @@ func (mpu *MedianPoolUnit) Aggregate(
 // the specific configuration issue that must be resolved.
 func (mpu *MedianPoolUnit) Validate() error {
 	if err := validate.Struct(mpu.config); err != nil {
-		return fmt.Errorf("configuration validation failed: %w", err)
+		return domain.NewConfigValidationError(mpu.name, fieldValidationError(err))
+	}
+	if err := validateOutputScale(mpu.name, mpu.config.OutputScale); err != nil {
+		return err
 	}
 	return nil
 }
@@ -376,7 +597,9 @@ func (mpu *MedianPoolUnit) Validate() error {
 //   - params: YAML node containing configuration fields
 //
 // Supported YAML fields:
-//   - tie_breaker: "first"|"random"|"error"
+//   - tie_breaker: "first"|"random"|"error"|"lowest_id"|"confidence"|"seeded_random"
+//   - tie_seed: int64
+//   - median_method: "interpolate"|"lower"|"upper"
 //   - min_score: float64 (0.0-1.0)
 //   - require_all_scores: boolean
 //
@@ -399,6 +622,9 @@ func (mpu *MedianPoolUnit) UnmarshalParameters(params yaml.Node) error {
 		return fmt.Errorf("failed to decode parameters: %w", err)
 	}
 	if err := validate.Struct(config); err != nil {
+		return fmt.Errorf("parameter validation failed: %w", fieldValidationError(err))
+	}
+	if err := config.OutputScale.check(); err != nil {
 		return fmt.Errorf("parameter validation failed: %w", err)
 	}
 	mpu.config = config
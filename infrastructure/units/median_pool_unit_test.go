@@ -2,6 +2,7 @@ package units
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"testing"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
 )
 
 func TestMedianPoolUnit_calculateMedian(t *testing.T) {
@@ -70,7 +72,7 @@ func TestMedianPoolUnit_calculateMedian(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := unit.calculateMedian(tt.scores)
+			result, _ := unit.calculateMedian(tt.scores)
 			assert.InDelta(t, tt.expected, result, 0.0001, "Expected median %f, got %f", tt.expected, result)
 		})
 	}
@@ -86,6 +88,21 @@ func TestMedianPoolUnit_Aggregate(t *testing.T) {
 		expectedScore    float64 // This should be the median of all scores
 		expectedError    string
 	}{
+		{
+			name: "lowest_id tie breaker selects smallest tied ID regardless of order",
+			config: MedianPoolConfig{
+				TieBreaker:       TieLowestID,
+				MinScore:         0.0,
+				RequireAllScores: true,
+			},
+			scores: []float64{0.4, 0.8}, // median = 0.6, both candidates equidistant
+			candidates: []domain.Answer{
+				{ID: "z", Content: "Low answer"},
+				{ID: "b", Content: "High answer"},
+			},
+			expectedWinnerID: "b",
+			expectedScore:    0.6,
+		},
 		{
 			name: "selects candidate closest to median with odd number of scores",
 			config: MedianPoolConfig{
@@ -277,6 +294,77 @@ func TestMedianPoolUnit_Execute(t *testing.T) {
 		expectedError  string
 		validateResult func(t *testing.T, state domain.State)
 	}{
+		{
+			name: "ranks all answers best-first with winner on top",
+			config: MedianPoolConfig{
+				TieBreaker:       TieFirst,
+				MinScore:         0.0,
+				RequireAllScores: true,
+			},
+			setupState: func() domain.State {
+				state := domain.NewState()
+				answers := []domain.Answer{
+					{ID: "answer1", Content: "First answer"},
+					{ID: "answer2", Content: "Second answer"},
+					{ID: "answer3", Content: "Third answer"},
+					{ID: "answer4", Content: "Fourth answer"},
+				}
+				judgeSummaries := []domain.JudgeSummary{
+					{Score: 0.1, Reasoning: "r1", Confidence: 0.9},
+					{Score: 0.4, Reasoning: "r2", Confidence: 0.9},
+					{Score: 0.6, Reasoning: "r3", Confidence: 0.9},
+					{Score: 1.0, Reasoning: "r4", Confidence: 0.9},
+				}
+				state = domain.With(state, domain.KeyAnswers, answers)
+				state = domain.With(state, domain.KeyJudgeScores, judgeSummaries)
+				return state
+			},
+			validateResult: func(t *testing.T, state domain.State) {
+				verdict, ok := domain.Get(state, domain.KeyVerdict)
+				require.True(t, ok, "Verdict should be present in state")
+				require.Len(t, verdict.RankedAnswers, 4)
+				assert.InDelta(t, 0.5, verdict.AggregateScore, 0.0001)
+
+				var ids []string
+				for _, ranked := range verdict.RankedAnswers {
+					ids = append(ids, ranked.Answer.ID)
+				}
+				assert.Equal(t, []string{"answer2", "answer3", "answer1", "answer4"}, ids)
+				assert.Equal(t, *verdict.WinnerAnswer, verdict.RankedAnswers[0].Answer)
+			},
+		},
+		{
+			name: "reports content-identical candidates tied with the winner",
+			config: MedianPoolConfig{
+				TieBreaker:       TieLowestID,
+				MinScore:         0.0,
+				RequireAllScores: true,
+			},
+			setupState: func() domain.State {
+				state := domain.NewState()
+				answers := []domain.Answer{
+					{ID: "answer3", Content: "Paris"},
+					{ID: "answer1", Content: " Paris "},
+					{ID: "answer2", Content: "London"},
+				}
+				judgeSummaries := []domain.JudgeSummary{
+					{Score: 0.9, Reasoning: "Correct", Confidence: 0.9},
+					{Score: 0.9, Reasoning: "Correct", Confidence: 0.9},
+					{Score: 0.9, Reasoning: "Incorrect", Confidence: 0.9},
+				}
+				state = domain.With(state, domain.KeyAnswers, answers)
+				state = domain.With(state, domain.KeyJudgeScores, judgeSummaries)
+				return state
+			},
+			validateResult: func(t *testing.T, state domain.State) {
+				verdict, ok := domain.Get(state, domain.KeyVerdict)
+				require.True(t, ok, "Verdict should be present in state")
+				require.NotNil(t, verdict, "Verdict should not be nil")
+
+				assert.Equal(t, "answer1", verdict.WinnerAnswer.ID)
+				assert.Equal(t, []string{"answer1", "answer3"}, verdict.DuplicateAnswerIDs)
+			},
+		},
 		{
 			name: "successful execution with valid data",
 			config: MedianPoolConfig{
@@ -345,7 +433,7 @@ func TestMedianPoolUnit_Execute(t *testing.T) {
 				// Missing judge scores
 				return state
 			},
-			expectedError: "judge scores not found in state",
+			expectedError: "judge_scores not found in state",
 		},
 		{
 			name: "handles length mismatch when RequireAllScores is false",
@@ -427,6 +515,176 @@ func TestMedianPoolUnit_Execute(t *testing.T) {
 	}
 }
 
+// TestMedianPoolUnit_MedianMethod verifies the even-count median methods
+// and that the chosen method is recorded on the verdict provenance.
+func TestMedianPoolUnit_MedianMethod(t *testing.T) {
+	tests := []struct {
+		name              string
+		method            string
+		scores            []float64
+		expectedMedian    float64
+		expectedSelection string
+		expectedWinner    string
+	}{
+		{name: "default interpolates", scores: []float64{0.25, 0.5, 1.0, 1.0}, expectedMedian: 0.75, expectedSelection: MedianInterpolate, expectedWinner: "a2"},
+		{name: "lower middle", method: MedianLower, scores: []float64{0.25, 0.5, 1.0, 1.0}, expectedMedian: 0.5, expectedSelection: MedianLower, expectedWinner: "a2"},
+		{name: "upper middle", method: MedianUpper, scores: []float64{0.25, 0.5, 1.0, 1.0}, expectedMedian: 1.0, expectedSelection: MedianUpper, expectedWinner: "a3"},
+		{name: "odd count uses middle", method: MedianLower, scores: []float64{0.25, 0.5, 1.0}, expectedMedian: 0.5, expectedSelection: "middle", expectedWinner: "a2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultMedianPoolConfig()
+			config.MedianMethod = tt.method
+			unit, err := NewMedianPoolUnit("median", config)
+			require.NoError(t, err)
+
+			answers := make([]domain.Answer, len(tt.scores))
+			summaries := make([]domain.JudgeSummary, len(tt.scores))
+			for i, score := range tt.scores {
+				answers[i] = domain.Answer{ID: fmt.Sprintf("a%d", i+1), Content: fmt.Sprintf("answer %d", i+1)}
+				summaries[i] = domain.JudgeSummary{Score: score, Confidence: 0.9}
+			}
+			state := domain.With(domain.NewState(), domain.KeyAnswers, answers)
+			state = domain.WithJudgeScores(state, "judge", summaries)
+
+			newState, err := unit.Execute(context.Background(), state)
+			require.NoError(t, err)
+
+			verdict, ok := domain.Get(newState, domain.KeyVerdict)
+			require.True(t, ok)
+			assert.InDelta(t, tt.expectedMedian, verdict.AggregateScore, 1e-9)
+			assert.Equal(t, tt.expectedWinner, verdict.WinnerAnswer.ID)
+			require.NotNil(t, verdict.Provenance)
+			assert.Equal(t, tt.expectedSelection, verdict.Provenance.MedianSelection)
+		})
+	}
+
+	_, err := NewMedianPoolUnit("median", MedianPoolConfig{TieBreaker: TieFirst, MedianMethod: "mean"})
+	require.Error(t, err)
+}
+
+// TestMedianPoolUnit_TieBreakAudit verifies the confidence and seeded random
+// tie breakers and that the verdict records which tied candidate won.
+func TestMedianPoolUnit_TieBreakAudit(t *testing.T) {
+	// Scores 0.4 and 0.6 are equidistant from the interpolated median 0.5.
+	answers := []domain.Answer{{ID: "a1", Content: "low"}, {ID: "a2", Content: "high"}}
+
+	tests := []struct {
+		name           string
+		config         MedianPoolConfig
+		confidences    []float64
+		expectedWinner int
+		reasonContains string
+	}{
+		{
+			name:           "first favors input order",
+			config:         MedianPoolConfig{TieBreaker: TieFirst},
+			confidences:    []float64{0.5, 0.9},
+			expectedWinner: 0,
+			reasonContains: "first tied candidate",
+		},
+		{
+			name:           "confidence picks most confident judge",
+			config:         MedianPoolConfig{TieBreaker: TieConfidence},
+			confidences:    []float64{0.5, 0.9},
+			expectedWinner: 1,
+			reasonContains: "highest judge confidence 0.90",
+		},
+		{
+			name:           "equal confidence falls back to input order",
+			config:         MedianPoolConfig{TieBreaker: TieConfidence},
+			confidences:    []float64{0.7, 0.7},
+			expectedWinner: 0,
+			reasonContains: "shared by several tied candidates",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := NewMedianPoolUnit("median", tt.config)
+			require.NoError(t, err)
+
+			state := domain.With(domain.NewState(), domain.KeyAnswers, answers)
+			state = domain.WithJudgeScores(state, "judge", []domain.JudgeSummary{
+				{Score: 0.4, Confidence: tt.confidences[0]},
+				{Score: 0.6, Confidence: tt.confidences[1]},
+			})
+
+			newState, err := unit.Execute(context.Background(), state)
+			require.NoError(t, err)
+
+			verdict, ok := domain.Get(newState, domain.KeyVerdict)
+			require.True(t, ok)
+			assert.Equal(t, answers[tt.expectedWinner].ID, verdict.WinnerAnswer.ID)
+			require.NotNil(t, verdict.TieBreak)
+			assert.Equal(t, string(tt.config.TieBreaker), verdict.TieBreak.Strategy)
+			assert.Equal(t, []int{0, 1}, verdict.TieBreak.CandidateIndices)
+			assert.Equal(t, tt.expectedWinner, verdict.TieBreak.WinnerIndex)
+			assert.Contains(t, verdict.TieBreak.Reason, tt.reasonContains)
+		})
+	}
+
+	t.Run("seeded random is reproducible per item and varies across items", func(t *testing.T) {
+		unit, err := NewMedianPoolUnit("median", MedianPoolConfig{TieBreaker: TieSeededRandom, TieSeed: 42})
+		require.NoError(t, err)
+
+		tied := []domain.Answer{{ID: "a1"}, {ID: "a2"}, {ID: "a3"}, {ID: "a4"}}
+		winner := func(itemID string) (string, string) {
+			state := domain.With(domain.NewState(), domain.KeyAnswers, tied)
+			state = domain.With(state, domain.KeyItemID, itemID)
+			state = domain.WithJudgeScores(state, "judge", []domain.JudgeSummary{
+				{Score: 0.4, Confidence: 0.9}, {Score: 0.6, Confidence: 0.9},
+				{Score: 0.4, Confidence: 0.9}, {Score: 0.6, Confidence: 0.9},
+			})
+			newState, err := unit.Execute(context.Background(), state)
+			require.NoError(t, err)
+			verdict, _ := domain.Get(newState, domain.KeyVerdict)
+			require.NotNil(t, verdict.TieBreak)
+			return verdict.WinnerAnswer.ID, verdict.TieBreak.Reason
+		}
+
+		winners := make(map[string]bool)
+		for i := range 20 {
+			itemID := fmt.Sprintf("item-%d", i)
+			first, reason := winner(itemID)
+			again, _ := winner(itemID)
+			assert.Equal(t, first, again, "rerunning an item breaks its tie the same way")
+			assert.Contains(t, reason, "from tie_seed 42")
+			winners[first] = true
+		}
+		assert.Greater(t, len(winners), 1, "ties across items should not all resolve to one position")
+	})
+
+	t.Run("seeded random aggregate is reproducible", func(t *testing.T) {
+		unit, err := NewMedianPoolUnit("median", MedianPoolConfig{TieBreaker: TieSeededRandom, TieSeed: 42})
+		require.NoError(t, err)
+
+		scores := []float64{0.4, 0.6, 0.4, 0.6}
+		candidates := []domain.Answer{{ID: "a1"}, {ID: "a2"}, {ID: "a3"}, {ID: "a4"}}
+		first, _, err := unit.Aggregate(scores, candidates)
+		require.NoError(t, err)
+		for range 10 {
+			winner, _, err := unit.Aggregate(scores, candidates)
+			require.NoError(t, err)
+			assert.Equal(t, first.ID, winner.ID)
+		}
+	})
+
+	t.Run("no tie leaves tie break unset", func(t *testing.T) {
+		unit, err := NewMedianPoolUnit("median", DefaultMedianPoolConfig())
+		require.NoError(t, err)
+
+		state := domain.With(domain.NewState(), domain.KeyAnswers, answers[:1])
+		state = domain.WithJudgeScores(state, "judge", []domain.JudgeSummary{{Score: 0.4, Confidence: 0.9}})
+
+		newState, err := unit.Execute(context.Background(), state)
+		require.NoError(t, err)
+		verdict, _ := domain.Get(newState, domain.KeyVerdict)
+		assert.Nil(t, verdict.TieBreak)
+	})
+}
+
 func TestMedianPoolUnit_Validate(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -560,3 +818,238 @@ func TestNewMedianPoolFromConfig(t *testing.T) {
 		assert.NotNil(t, unit)
 	})
 }
+
+// TestPoolUnits_RequireAllJudges verifies that aggregators configured with
+// RequireAllScores reject verdicts when an expected judge did not score every
+// answer, and that the error names each missing judge/answer pair.
+func TestPoolUnits_RequireAllJudges(t *testing.T) {
+	answers := []domain.Answer{
+		{ID: "answer1", Content: "First answer"},
+		{ID: "answer2", Content: "Second answer"},
+	}
+	complete := []domain.JudgeSummary{{Score: 0.8}, {Score: 0.6}}
+
+	newUnits := func(expected []string) map[string]ports.Unit {
+		mean, err := NewArithmeticMeanUnit("mean", ArithmeticMeanConfig{
+			TieBreaker: TieFirst, RequireAllScores: true, ExpectedJudges: expected,
+		})
+		require.NoError(t, err)
+		maxPool, err := NewMaxPoolUnit("max", MaxPoolConfig{
+			TieBreaker: TieFirst, RequireAllScores: true, ExpectedJudges: expected,
+		})
+		require.NoError(t, err)
+		median, err := NewMedianPoolUnit("median", MedianPoolConfig{
+			TieBreaker: TieFirst, RequireAllScores: true, ExpectedJudges: expected,
+		})
+		require.NoError(t, err)
+		return map[string]ports.Unit{"arithmetic_mean": mean, "max_pool": maxPool, "median_pool": median}
+	}
+
+	tests := []struct {
+		name          string
+		expected      []string
+		setupState    func() domain.State
+		expectedError string
+	}{
+		{
+			name:     "expected judge never ran",
+			expected: []string{"judge_a", "judge_b"},
+			setupState: func() domain.State {
+				state := domain.With(domain.NewState(), domain.KeyAnswers, answers)
+				return domain.WithJudgeScores(state, "judge_a", complete)
+			},
+			expectedError: "judge_b/answer1, judge_b/answer2",
+		},
+		{
+			name: "reporting judge scored only some answers",
+			setupState: func() domain.State {
+				state := domain.With(domain.NewState(), domain.KeyAnswers, answers)
+				state = domain.WithJudgeScores(state, "judge_a", complete[:1])
+				return domain.WithJudgeScores(state, "judge_b", complete)
+			},
+			expectedError: "judge_a/answer2",
+		},
+		{
+			name:     "all expected judges scored every answer",
+			expected: []string{"judge_a", "judge_b"},
+			setupState: func() domain.State {
+				state := domain.With(domain.NewState(), domain.KeyAnswers, answers)
+				state = domain.WithJudgeScores(state, "judge_a", complete)
+				return domain.WithJudgeScores(state, "judge_b", complete)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for unitType, unit := range newUnits(tt.expected) {
+			t.Run(tt.name+"/"+unitType, func(t *testing.T) {
+				_, err := unit.Execute(context.Background(), tt.setupState())
+				if tt.expectedError == "" {
+					require.NoError(t, err)
+					return
+				}
+				require.ErrorIs(t, err, ErrMissingJudgeScores)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			})
+		}
+	}
+}
+
+// TestPoolUnits_AbstainThreshold tests that every pooling unit abstains
+// instead of picking a winner when the winner's margin over the runner-up is
+// below the configured threshold.
+func TestPoolUnits_AbstainThreshold(t *testing.T) {
+	answers := []domain.Answer{
+		{ID: "answer1", Content: "First answer"},
+		{ID: "answer2", Content: "Second answer"},
+	}
+
+	newUnits := func(threshold float64) map[string]ports.Unit {
+		mean, err := NewArithmeticMeanUnit("mean", ArithmeticMeanConfig{
+			TieBreaker: TieFirst, AbstainThreshold: threshold,
+		})
+		require.NoError(t, err)
+		maxPool, err := NewMaxPoolUnit("max", MaxPoolConfig{
+			TieBreaker: TieFirst, AbstainThreshold: threshold,
+		})
+		require.NoError(t, err)
+		median, err := NewMedianPoolUnit("median", MedianPoolConfig{
+			TieBreaker: TieFirst, AbstainThreshold: threshold,
+		})
+		require.NoError(t, err)
+		return map[string]ports.Unit{"arithmetic_mean": mean, "max_pool": maxPool, "median_pool": median}
+	}
+
+	tests := []struct {
+		name          string
+		threshold     float64
+		answers       []domain.Answer
+		scores        []domain.JudgeSummary
+		scale         *domain.ScoreRange
+		wantAbstained bool
+	}{
+		{
+			name:          "close margin abstains",
+			threshold:     0.2,
+			answers:       answers,
+			scores:        []domain.JudgeSummary{{Score: 0.81, Confidence: 0.9}, {Score: 0.79, Confidence: 0.9}},
+			wantAbstained: true,
+		},
+		{
+			name:      "clear margin decides",
+			threshold: 0.2,
+			answers:   append(answers, domain.Answer{ID: "answer3", Content: "Third answer"}),
+			scores:    []domain.JudgeSummary{{Score: 0.9, Confidence: 0.9}, {Score: 0.5, Confidence: 0.9}, {Score: 0.1, Confidence: 0.9}},
+		},
+		{
+			name:    "zero threshold never abstains",
+			answers: answers,
+			scores:  []domain.JudgeSummary{{Score: 0.5, Confidence: 0.9}, {Score: 0.5, Confidence: 0.9}},
+		},
+		{
+			name:          "margin is normalized by the judge scale",
+			threshold:     0.2,
+			answers:       answers,
+			scores:        []domain.JudgeSummary{{Score: 8, Confidence: 0.9}, {Score: 7, Confidence: 0.9}},
+			scale:         &domain.ScoreRange{Min: 1, Max: 10},
+			wantAbstained: true,
+		},
+		{
+			name:      "wide margin on a wide scale decides",
+			threshold: 0.2,
+			answers:   append(answers, domain.Answer{ID: "answer3", Content: "Third answer"}),
+			scores:    []domain.JudgeSummary{{Score: 9, Confidence: 0.9}, {Score: 5, Confidence: 0.9}, {Score: 1, Confidence: 0.9}},
+			scale:     &domain.ScoreRange{Min: 1, Max: 10},
+		},
+		{
+			name:          "lone candidate uses judge confidence",
+			threshold:     0.5,
+			answers:       answers[:1],
+			scores:        []domain.JudgeSummary{{Score: 0.9, Confidence: 0.3}},
+			wantAbstained: true,
+		},
+	}
+
+	for _, tt := range tests {
+		for unitType, unit := range newUnits(tt.threshold) {
+			t.Run(tt.name+"/"+unitType, func(t *testing.T) {
+				state := domain.With(domain.NewState(), domain.KeyAnswers, tt.answers)
+				state = domain.WithJudgeScores(state, "judge", tt.scores)
+				if tt.scale != nil {
+					state = domain.WithJudgeScoreScale(state, "judge", *tt.scale)
+				}
+
+				newState, err := unit.Execute(context.Background(), state)
+				require.NoError(t, err)
+
+				verdict, ok := domain.Get(newState, domain.KeyVerdict)
+				require.True(t, ok)
+				assert.Len(t, verdict.RankedAnswers, len(tt.answers), "ranking should be kept for reviewers")
+				if tt.wantAbstained {
+					assert.Equal(t, domain.VerdictAbstained, verdict.Status)
+					assert.Nil(t, verdict.WinnerAnswer)
+					assert.True(t, verdict.RequiresHumanReview)
+					assert.Less(t, verdict.Confidence, tt.threshold)
+					return
+				}
+				assert.Equal(t, domain.VerdictDecided, verdict.Status)
+				assert.NotNil(t, verdict.WinnerAnswer)
+				assert.False(t, verdict.RequiresHumanReview)
+			})
+		}
+	}
+}
+
+// TestUnitConstructors_ValidationError tests that configuration validation
+// failures carry field-level details under their YAML names.
+func TestUnitConstructors_ValidationError(t *testing.T) {
+	tests := []struct {
+		name      string
+		construct func() error
+		entity    string
+		field     string
+		rule      string
+		value     any
+	}{
+		{
+			name: "max pool tie breaker",
+			construct: func() error {
+				_, err := NewMaxPoolUnit("max", MaxPoolConfig{TieBreaker: "last"})
+				return err
+			},
+			entity: "MaxPoolConfig", field: "tie_breaker", rule: "oneof", value: TieBreaker("last"),
+		},
+		{
+			name: "top k selection k",
+			construct: func() error {
+				_, err := NewTopKSelectionUnit("select", TopKSelectionConfig{K: 0, Source: SelectionSourceLength})
+				return err
+			},
+			entity: "TopKSelectionConfig", field: "k", rule: "required", value: 0,
+		},
+		{
+			name: "median pool expected judges",
+			construct: func() error {
+				_, err := NewMedianPoolUnit("median", MedianPoolConfig{TieBreaker: TieFirst, ExpectedJudges: []string{"judge_a", ""}})
+				return err
+			},
+			entity: "MedianPoolConfig", field: "expected_judges[1]", rule: "required", value: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.construct()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "configuration validation failed")
+
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.entity, validationErr.Entity)
+			fe, ok := validationErr.Field(tt.field)
+			require.True(t, ok, "expected a failure for %s, got %v", tt.field, validationErr.Fields)
+			assert.Equal(t, tt.rule, fe.Rule)
+			assert.Equal(t, tt.value, fe.Value)
+		})
+	}
+}
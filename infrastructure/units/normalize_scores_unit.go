@@ -0,0 +1,165 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+var _ ports.Unit = (*NormalizeScoresUnit)(nil)
+
+// NormalizeScoresUnit rescales every judge's scores to the common 0.0-1.0
+// range using the scale each judge declared in domain.KeyJudgeScoreScales.
+// Place it between judges and an aggregator when judges use different
+// ScoreScale values; aggregating raw scores from, say, a 1-10 judge and a
+// 0-1 judge otherwise produces meaningless verdicts.
+//
+// Scores outside a judge's declared scale are clamped. Judges that did not
+// declare a scale, such as the deterministic match units, are assumed to
+// score on 0.0-1.0 unless RequireDeclaredScale is set.
+//
+// The unit is deterministic, stateless, and thread-safe.
+type NormalizeScoresUnit struct {
+	// name is the unique identifier for this unit instance.
+	name string
+	// config contains the validated configuration parameters.
+	config NormalizeScoresConfig
+	// tracer is the OpenTelemetry tracer for observability.
+	tracer trace.Tracer
+}
+
+// NormalizeScoresConfig defines the configuration parameters for the
+// NormalizeScoresUnit.
+type NormalizeScoresConfig struct {
+	// RequireDeclaredScale fails execution when a judge's scores have no
+	// recorded scale instead of assuming 0.0-1.0.
+	RequireDeclaredScale bool `yaml:"require_declared_scale" json:"require_declared_scale"`
+}
+
+// NewNormalizeScoresUnit creates a new NormalizeScoresUnit with the
+// specified configuration.
+func NewNormalizeScoresUnit(name string, config NormalizeScoresConfig) (*NormalizeScoresUnit, error) {
+	if name == "" {
+		return nil, ErrEmptyUnitName
+	}
+
+	if err := validate.Struct(config); err != nil {
+		return nil, domain.NewConfigValidationError(name, fieldValidationError(err))
+	}
+
+	return &NormalizeScoresUnit{
+		name:   name,
+		config: config,
+		tracer: otel.Tracer("normalize-scores-unit"),
+	}, nil
+}
+
+// Name returns the unique identifier for this unit instance.
+func (nsu *NormalizeScoresUnit) Name() string { return nsu.name }
+
+// Execute normalizes the scores in domain.KeyJudgeScoresByJudge and the
+// latest judge's scores in domain.KeyJudgeScores, then records every judge's
+// scale as 0.0-1.0 so normalization is idempotent.
+//
+// Returns ErrNoScores if no judge has recorded scores, or an error if
+// RequireDeclaredScale is set and a judge's scale is unknown.
+func (nsu *NormalizeScoresUnit) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	_, span := nsu.tracer.Start(ctx, "NormalizeScoresUnit.Execute",
+		trace.WithAttributes(
+			attribute.String("unit.type", "normalize_scores"),
+			attribute.String("unit.id", nsu.name),
+			attribute.Bool("config.require_declared_scale", nsu.config.RequireDeclaredScale),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+
+	byJudge, ok := domain.Get(state, domain.KeyJudgeScoresByJudge)
+	if !ok || len(byJudge) == 0 {
+		err := fmt.Errorf("unit %s: %w", nsu.name, ErrNoScores)
+		span.RecordError(err)
+		return state, err
+	}
+
+	// Visit judges in a stable order so errors and attributes are deterministic.
+	judges := slices.Sorted(maps.Keys(byJudge))
+	newState := state
+	clamped := 0
+	for _, judgeID := range judges {
+		if err := ctx.Err(); err != nil {
+			span.RecordError(err)
+			return state, err
+		}
+
+		scale, declared := domain.JudgeScoreScale(state, judgeID)
+		if !declared && nsu.config.RequireDeclaredScale {
+			err := fmt.Errorf("unit %s: judge %q did not declare a score scale", nsu.name, judgeID)
+			span.RecordError(err)
+			return state, err
+		}
+
+		summaries := byJudge[judgeID]
+		for i := range summaries {
+			if summaries[i].Score < scale.Min || summaries[i].Score > scale.Max {
+				clamped++
+			}
+			summaries[i].Score = scale.Normalize(summaries[i].Score)
+		}
+		byJudge[judgeID] = summaries
+		newState = domain.WithJudgeScoreScale(newState, judgeID, domain.UnitScoreRange)
+	}
+	newState = domain.With(newState, domain.KeyJudgeScoresByJudge, byJudge)
+
+	if latest, ok := domain.Get(state, domain.KeyLatestJudge); ok {
+		if summaries, ok := byJudge[latest]; ok {
+			newState = domain.With(newState, domain.KeyJudgeScores, summaries)
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int64("eval.latency_ms", time.Since(start).Milliseconds()),
+		attribute.Int("eval.judges_count", len(judges)),
+		attribute.Int("eval.clamped_scores", clamped),
+		attribute.Bool("no_llm_cost", true),
+	)
+
+	return newState, nil
+}
+
+// Validate checks if the unit is properly configured and ready for execution.
+func (nsu *NormalizeScoresUnit) Validate() error {
+	if err := validate.Struct(nsu.config); err != nil {
+		return domain.NewConfigValidationError(nsu.name, fieldValidationError(err))
+	}
+	return nil
+}
+
+// NewNormalizeScoresFromConfig creates a NormalizeScoresUnit from a
+// configuration map. This is the boundary adapter for YAML/JSON configuration.
+// Normalization doesn't require an LLM client.
+func NewNormalizeScoresFromConfig(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error) {
+	// llm is ignored - normalization is deterministic.
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	var cfg NormalizeScoresConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+
+	return NewNormalizeScoresUnit(id, cfg)
+}
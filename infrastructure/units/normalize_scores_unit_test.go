@@ -0,0 +1,102 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/testutils"
+)
+
+// TestNormalizeScoresUnit_Execute tests that each judge's scores are
+// rescaled by its own declared scale and that the latest judge's scores in
+// KeyJudgeScores are replaced with their normalized values.
+func TestNormalizeScoresUnit_Execute(t *testing.T) {
+	state := domain.WithJudgeScores(domain.NewState(), "wide", []domain.JudgeSummary{{Score: 1}, {Score: 10}, {Score: 5.5}})
+	state = domain.WithJudgeScoreScale(state, "wide", domain.ScoreRange{Min: 1, Max: 10})
+	state = domain.WithJudgeScores(state, "exact", []domain.JudgeSummary{{Score: 1}, {Score: 0}, {Score: 0}})
+
+	unit, err := NewNormalizeScoresUnit("normalize", NormalizeScoresConfig{})
+	require.NoError(t, err)
+
+	newState, err := unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+
+	byJudge, ok := domain.Get(newState, domain.KeyJudgeScoresByJudge)
+	require.True(t, ok)
+	assert.InDeltaSlice(t, []float64{0, 1, 0.5}, scoresOf(byJudge["wide"]), 1e-9)
+	assert.InDeltaSlice(t, []float64{1, 0, 0}, scoresOf(byJudge["exact"]), 1e-9)
+
+	latest, ok := domain.Get(newState, domain.KeyJudgeScores)
+	require.True(t, ok)
+	assert.InDeltaSlice(t, []float64{1, 0, 0}, scoresOf(latest), 1e-9)
+
+	scale, declared := domain.JudgeScoreScale(newState, "wide")
+	assert.True(t, declared)
+	assert.Equal(t, domain.UnitScoreRange, scale)
+
+	again, err := unit.Execute(context.Background(), newState)
+	require.NoError(t, err)
+	byJudgeAgain, _ := domain.Get(again, domain.KeyJudgeScoresByJudge)
+	assert.Equal(t, byJudge, byJudgeAgain, "normalization should be idempotent")
+}
+
+// TestNormalizeScoresUnit_MixedScaleJudges tests normalization of real
+// score judges configured with different scales.
+func TestNormalizeScoresUnit_MixedScaleJudges(t *testing.T) {
+	state := testutils.EvaluationState(t, "What is the capital of France?", []domain.Answer{{ID: "a1", Content: "Paris"}})
+
+	tenClient := testutils.NewMockLLMClient("test-model")
+	tenClient.SetResponse(`{"score": 8.5, "confidence": 0.9, "reasoning": "Correct and concise answer", "version": 1}`)
+	tenJudge, err := NewScoreJudgeUnit("ten_point", tenClient, defaultScoreJudgeConfig())
+	require.NoError(t, err)
+
+	unitPoint := defaultScoreJudgeConfig()
+	unitPoint.ScoreScale = "0.0-1.0"
+	unitJudge, err := NewScoreJudgeUnit("unit_point", testutils.NewMockLLMClient("test-model"), unitPoint)
+	require.NoError(t, err)
+
+	state, err = tenJudge.Execute(context.Background(), state)
+	require.NoError(t, err)
+	state, err = unitJudge.Execute(context.Background(), state)
+	require.NoError(t, err)
+
+	unit, err := NewNormalizeScoresUnit("normalize", NormalizeScoresConfig{RequireDeclaredScale: true})
+	require.NoError(t, err)
+	state, err = unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+
+	byJudge, _ := domain.Get(state, domain.KeyJudgeScoresByJudge)
+	assert.InDelta(t, (8.5-1)/9, byJudge["ten_point"][0].Score, 1e-9)
+	assert.InDelta(t, 0.85, byJudge["unit_point"][0].Score, 1e-9)
+}
+
+// TestNormalizeScoresUnit_Errors tests missing scores and undeclared scales
+// under RequireDeclaredScale.
+func TestNormalizeScoresUnit_Errors(t *testing.T) {
+	unit, err := NewNormalizeScoresUnit("normalize", NormalizeScoresConfig{RequireDeclaredScale: true})
+	require.NoError(t, err)
+
+	_, err = unit.Execute(context.Background(), domain.NewState())
+	require.ErrorIs(t, err, ErrNoScores)
+
+	state := domain.WithJudgeScores(domain.NewState(), "exact", []domain.JudgeSummary{{Score: 1}})
+	_, err = unit.Execute(context.Background(), state)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `judge "exact" did not declare a score scale`)
+
+	_, err = NewNormalizeScoresUnit("", NormalizeScoresConfig{})
+	require.ErrorIs(t, err, ErrEmptyUnitName)
+}
+
+// scoresOf returns the scores of summaries in order.
+func scoresOf(summaries []domain.JudgeSummary) []float64 {
+	scores := make([]float64, len(summaries))
+	for i, s := range summaries {
+		scores[i] = s.Score
+	}
+	return scores
+}
@@ -0,0 +1,177 @@
+package units
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// Output scale presets supported by OutputScaleConfig.
+const (
+	// OutputScalePercentage presents scores from 0 to 100.
+	OutputScalePercentage = "percentage"
+	// OutputScaleStars presents scores from 1 to 5 stars.
+	OutputScaleStars = "stars"
+	// OutputScaleLetter presents scores from 0 to 100 with A–F letter
+	// grades at 90, 80, 70, and 60.
+	OutputScaleLetter = "letter"
+)
+
+// outputScaleCustom names scales defined by Min, Max, and Labels.
+const outputScaleCustom = "custom"
+
+// ErrInvalidOutputScale is returned when an output scale has neither a
+// preset nor a usable custom range or labels.
+var ErrInvalidOutputScale = errors.New("invalid output scale")
+
+// ScoreLabel assigns Label to scores whose position within the output
+// scale is at least Min, where 0 is the bottom of the scale and 1 the top.
+type ScoreLabel struct {
+	// Label is the text shown for matching scores, such as "A".
+	Label string `yaml:"label" json:"label" validate:"required"`
+
+	// Min is the lowest position on the scale, from 0.0 to 1.0, that
+	// receives Label.
+	Min float64 `yaml:"min" json:"min" validate:"min=0,max=1"`
+}
+
+// OutputScaleConfig configures how an aggregator presents its scores in
+// Verdict.Display. Internal computation, AggregateScore, and RankedAnswers
+// are unaffected; only the display copy is rescaled.
+//
+// Either Preset or a custom scale is used. A custom scale maps onto
+// [Min, Max], attaches Labels, or both.
+type OutputScaleConfig struct {
+	// Preset selects a built-in scale: "percentage", "stars", or "letter".
+	// When set, Min, Max, and Labels are ignored.
+	Preset string `yaml:"preset,omitempty" json:"preset,omitempty" validate:"omitempty,oneof=percentage stars letter"`
+
+	// Min and Max bound a custom output scale.
+	Min float64 `yaml:"min,omitempty" json:"min,omitempty"`
+	Max float64 `yaml:"max,omitempty" json:"max,omitempty"`
+
+	// Labels attach text to ranges of a custom scale. The label with the
+	// highest Min not above a score's position is used.
+	Labels []ScoreLabel `yaml:"labels,omitempty" json:"labels,omitempty" validate:"omitempty,dive"`
+
+	// InputMin and InputMax bound the aggregator's internal scores. When
+	// both are zero they default to the score scale the judges declared,
+	// such as 1-10, or 0.0-1.0 when the judges declared none.
+	InputMin float64 `yaml:"input_min,omitempty" json:"input_min,omitempty"`
+	InputMax float64 `yaml:"input_max,omitempty" json:"input_max,omitempty"`
+}
+
+// letterGrades are the labels of the letter preset.
+var letterGrades = []ScoreLabel{
+	{Label: "A", Min: 0.9},
+	{Label: "B", Min: 0.8},
+	{Label: "C", Min: 0.7},
+	{Label: "D", Min: 0.6},
+	{Label: "F", Min: 0},
+}
+
+// resolve returns the scale's name, output range, and labels sorted by
+// descending Min, applying the preset if one is set.
+func (c *OutputScaleConfig) resolve() (string, domain.ScoreRange, []ScoreLabel) {
+	switch c.Preset {
+	case OutputScalePercentage:
+		return c.Preset, domain.ScoreRange{Min: 0, Max: 100}, nil
+	case OutputScaleStars:
+		return c.Preset, domain.ScoreRange{Min: 1, Max: 5}, nil
+	case OutputScaleLetter:
+		return c.Preset, domain.ScoreRange{Min: 0, Max: 100}, letterGrades
+	}
+
+	labels := slices.Clone(c.Labels)
+	slices.SortStableFunc(labels, func(a, b ScoreLabel) int {
+		return cmp.Compare(b.Min, a.Min)
+	})
+	return outputScaleCustom, domain.ScoreRange{Min: c.Min, Max: c.Max}, labels
+}
+
+// input returns the range of the aggregator's internal scores, defaulting
+// to judgeScale.
+func (c *OutputScaleConfig) input(judgeScale domain.ScoreRange) domain.ScoreRange {
+	if c.InputMin == 0 && c.InputMax == 0 {
+		return judgeScale
+	}
+	return domain.ScoreRange{Min: c.InputMin, Max: c.InputMax}
+}
+
+// check reports configurations the struct tags cannot express: a custom
+// scale needs a range or labels, and ranges must not be empty.
+func (c *OutputScaleConfig) check() error {
+	if c == nil {
+		return nil
+	}
+	if (c.InputMin != 0 || c.InputMax != 0) && c.InputMax <= c.InputMin {
+		return fmt.Errorf("%w: input_max must exceed input_min", ErrInvalidOutputScale)
+	}
+	if c.Preset != "" {
+		return nil
+	}
+	hasRange := c.Min != 0 || c.Max != 0
+	if !hasRange && len(c.Labels) == 0 {
+		return fmt.Errorf("%w: requires a preset, a min/max range, or labels", ErrInvalidOutputScale)
+	}
+	if hasRange && c.Max <= c.Min {
+		return fmt.Errorf("%w: max must exceed min", ErrInvalidOutputScale)
+	}
+	return nil
+}
+
+// display rescales the verdict's aggregate and ranked scores, which are on
+// judgeScale unless InputMin and InputMax say otherwise, onto the output
+// scale. A custom scale with labels but no range keeps scores on the input
+// scale and only adds labels.
+func (c *OutputScaleConfig) display(verdict *domain.Verdict, judgeScale domain.ScoreRange) *domain.ScoreDisplay {
+	name, out, labels := c.resolve()
+	in := c.input(judgeScale)
+	if out.Min == 0 && out.Max == 0 {
+		out = in
+	}
+
+	convert := func(score float64) (float64, string) {
+		position := in.Normalize(score)
+		label := ""
+		for _, l := range labels {
+			if position >= l.Min {
+				label = l.Label
+				break
+			}
+		}
+		return out.Min + position*(out.Max-out.Min), label
+	}
+
+	display := &domain.ScoreDisplay{Scale: name}
+	display.AggregateScore, display.AggregateLabel = convert(verdict.AggregateScore)
+	if len(verdict.RankedAnswers) > 0 {
+		display.RankedScores = make([]domain.DisplayScore, len(verdict.RankedAnswers))
+		for i, ranked := range verdict.RankedAnswers {
+			score, label := convert(ranked.Score)
+			display.RankedScores[i] = domain.DisplayScore{AnswerID: ranked.Answer.ID, Score: score, Label: label}
+		}
+	}
+	return display
+}
+
+// applyOutputScale sets verdict.Display from config for scores on the
+// judges' declared judgeScale, leaving the verdict unchanged when no output
+// scale is configured.
+func applyOutputScale(verdict *domain.Verdict, config *OutputScaleConfig, judgeScale domain.ScoreRange) {
+	if config == nil {
+		return
+	}
+	verdict.Display = config.display(verdict, judgeScale)
+}
+
+// validateOutputScale validates an optional output scale for the named unit.
+func validateOutputScale(unit string, config *OutputScaleConfig) error {
+	if err := config.check(); err != nil {
+		return domain.NewConfigValidationError(unit, err)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// TestOutputScaleConfig_Display tests conversion of verdict scores onto each
+// preset and custom scales.
+func TestOutputScaleConfig_Display(t *testing.T) {
+	verdict := &domain.Verdict{
+		AggregateScore: 0.85,
+		RankedAnswers: []domain.RankedAnswer{
+			{Answer: domain.Answer{ID: "a1"}, Score: 0.85},
+			{Answer: domain.Answer{ID: "a2"}, Score: 0.5},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		config     OutputScaleConfig
+		wantScale  string
+		wantScore  float64
+		wantLabel  string
+		wantRanked []domain.DisplayScore
+	}{
+		{
+			name:      "percentage",
+			config:    OutputScaleConfig{Preset: OutputScalePercentage},
+			wantScale: "percentage",
+			wantScore: 85,
+			wantRanked: []domain.DisplayScore{
+				{AnswerID: "a1", Score: 85},
+				{AnswerID: "a2", Score: 50},
+			},
+		},
+		{
+			name:      "stars",
+			config:    OutputScaleConfig{Preset: OutputScaleStars},
+			wantScale: "stars",
+			wantScore: 4.4,
+			wantRanked: []domain.DisplayScore{
+				{AnswerID: "a1", Score: 4.4},
+				{AnswerID: "a2", Score: 3},
+			},
+		},
+		{
+			name:      "letter",
+			config:    OutputScaleConfig{Preset: OutputScaleLetter},
+			wantScale: "letter",
+			wantScore: 85,
+			wantLabel: "B",
+			wantRanked: []domain.DisplayScore{
+				{AnswerID: "a1", Score: 85, Label: "B"},
+				{AnswerID: "a2", Score: 50, Label: "F"},
+			},
+		},
+		{
+			name: "custom labels without range keep input scale",
+			config: OutputScaleConfig{Labels: []ScoreLabel{
+				{Label: "fail", Min: 0},
+				{Label: "pass", Min: 0.6},
+			}},
+			wantScale: "custom",
+			wantScore: 0.85,
+			wantLabel: "pass",
+			wantRanked: []domain.DisplayScore{
+				{AnswerID: "a1", Score: 0.85, Label: "pass"},
+				{AnswerID: "a2", Score: 0.5, Label: "fail"},
+			},
+		},
+		{
+			name:      "custom range from 1-10 input",
+			config:    OutputScaleConfig{Min: 0, Max: 1000, InputMin: 0, InputMax: 10},
+			wantScale: "custom",
+			wantScore: 85,
+			wantRanked: []domain.DisplayScore{
+				{AnswerID: "a1", Score: 85},
+				{AnswerID: "a2", Score: 50},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.config.check())
+			display := tt.config.display(verdict, domain.UnitScoreRange)
+			assert.Equal(t, tt.wantScale, display.Scale)
+			assert.InDelta(t, tt.wantScore, display.AggregateScore, 1e-9)
+			assert.Equal(t, tt.wantLabel, display.AggregateLabel)
+			require.Len(t, display.RankedScores, len(tt.wantRanked))
+			for i, want := range tt.wantRanked {
+				got := display.RankedScores[i]
+				assert.Equal(t, want.AnswerID, got.AnswerID)
+				assert.InDelta(t, want.Score, got.Score, 1e-9)
+				assert.Equal(t, want.Label, got.Label)
+			}
+		})
+	}
+
+	t.Run("input defaults to the judge scale", func(t *testing.T) {
+		config := OutputScaleConfig{Preset: OutputScalePercentage}
+		display := config.display(&domain.Verdict{AggregateScore: 8.5}, domain.ScoreRange{Min: 1, Max: 10})
+		assert.InDelta(t, 250.0/3, display.AggregateScore, 1e-9)
+
+		config.InputMin, config.InputMax = 0, 10
+		display = config.display(&domain.Verdict{AggregateScore: 8.5}, domain.ScoreRange{Min: 1, Max: 10})
+		assert.InDelta(t, 85, display.AggregateScore, 1e-9, "an explicit input range wins")
+	})
+
+	t.Run("invalid configurations", func(t *testing.T) {
+		for _, config := range []OutputScaleConfig{
+			{},
+			{Min: 5, Max: 1},
+			{Preset: OutputScalePercentage, InputMin: 10, InputMax: 1},
+		} {
+			assert.ErrorIs(t, config.check(), ErrInvalidOutputScale)
+		}
+	})
+}
+
+// TestAggregators_OutputScale verifies that aggregators populate
+// Verdict.Display without changing the verdict's internal scores.
+func TestAggregators_OutputScale(t *testing.T) {
+	state := domain.With(domain.NewState(), domain.KeyAnswers, []domain.Answer{
+		{ID: "a1", Content: "First"},
+		{ID: "a2", Content: "Second"},
+	})
+	state = domain.WithJudgeScores(state, "judge", []domain.JudgeSummary{
+		{Score: 0.92, Confidence: 0.9, Reasoning: "Strong answer"},
+		{Score: 0.4, Confidence: 0.9, Reasoning: "Weak answer"},
+	})
+	config := map[string]any{"output_scale": map[string]any{"preset": "letter"}}
+
+	aggregators := map[string]func() (*domain.Verdict, error){
+		"max_pool": func() (*domain.Verdict, error) {
+			unit, err := NewMaxPoolFromConfig("agg", config, nil)
+			if err != nil {
+				return nil, err
+			}
+			return executeVerdict(unit.Execute(context.Background(), state))
+		},
+		"arithmetic_mean": func() (*domain.Verdict, error) {
+			unit, err := NewArithmeticMeanFromConfig("agg", config, nil)
+			if err != nil {
+				return nil, err
+			}
+			return executeVerdict(unit.Execute(context.Background(), state))
+		},
+		"median_pool": func() (*domain.Verdict, error) {
+			unit, err := NewMedianPoolFromConfig("agg", config, nil)
+			if err != nil {
+				return nil, err
+			}
+			return executeVerdict(unit.Execute(context.Background(), state))
+		},
+	}
+
+	for name, run := range aggregators {
+		t.Run(name, func(t *testing.T) {
+			verdict, err := run()
+			require.NoError(t, err)
+			require.NotNil(t, verdict.Display)
+			assert.Equal(t, "letter", verdict.Display.Scale)
+			assert.InDelta(t, verdict.AggregateScore*100, verdict.Display.AggregateScore, 1e-9)
+			assert.LessOrEqual(t, verdict.AggregateScore, 1.0, "internal score is not rescaled")
+			require.Len(t, verdict.Display.RankedScores, len(verdict.RankedAnswers))
+			for i, ranked := range verdict.RankedAnswers {
+				assert.Equal(t, ranked.Answer.ID, verdict.Display.RankedScores[i].AnswerID)
+			}
+		})
+	}
+
+	t.Run("judge scale", func(t *testing.T) {
+		tenPoint := domain.ScoreRange{Min: 1, Max: 10}
+		state := domain.WithJudgeScores(state, "judge", []domain.JudgeSummary{
+			{Score: 9.1, Confidence: 0.9, Reasoning: "Strong answer"},
+			{Score: 4, Confidence: 0.9, Reasoning: "Weak answer"},
+		})
+		state = domain.WithJudgeScoreScale(state, "judge", tenPoint)
+		unit, err := NewMaxPoolFromConfig("agg", map[string]any{"output_scale": map[string]any{"preset": "percentage"}}, nil)
+		require.NoError(t, err)
+		verdict, err := executeVerdict(unit.Execute(context.Background(), state))
+		require.NoError(t, err)
+		require.NotNil(t, verdict.Display)
+		assert.InDelta(t, tenPoint.Normalize(verdict.AggregateScore)*100, verdict.Display.AggregateScore, 1e-9)
+		assert.LessOrEqual(t, verdict.Display.AggregateScore, 100.0)
+	})
+
+	t.Run("rejects invalid scale", func(t *testing.T) {
+		_, err := NewMaxPoolFromConfig("agg", map[string]any{"output_scale": map[string]any{"min": 10, "max": 1}}, nil)
+		require.ErrorIs(t, err, ErrInvalidOutputScale)
+	})
+
+	t.Run("absent by default", func(t *testing.T) {
+		unit, err := NewMaxPoolFromConfig("agg", map[string]any{}, nil)
+		require.NoError(t, err)
+		verdict, err := executeVerdict(unit.Execute(context.Background(), state))
+		require.NoError(t, err)
+		assert.Nil(t, verdict.Display)
+	})
+}
+
+// executeVerdict returns the verdict from a unit's output state.
+func executeVerdict(state domain.State, err error) (*domain.Verdict, error) {
+	if err != nil {
+		return nil, err
+	}
+	verdict, _ := domain.Get(state, domain.KeyVerdict)
+	return verdict, nil
+}
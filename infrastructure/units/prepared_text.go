@@ -0,0 +1,43 @@
+package units
+
+import "sync"
+
+// preparedText memoizes the derived forms of a normalized string, such as
+// a reference answer, so that units comparing it against many candidates
+// compute its runes and tokens once per execution instead of once per
+// candidate. Each form is computed lazily on first use and is safe to read
+// from concurrent goroutines. The derived forms must not be mutated.
+type preparedText struct {
+	text      string
+	tokenizer Tokenizer
+
+	runesOnce sync.Once
+	runes     []rune
+
+	tokensOnce sync.Once
+	tokens     []string
+}
+
+// newPreparedText wraps an already normalized string. tokenizer is used by
+// Tokens and may be nil when tokens are never requested.
+func newPreparedText(text string, tokenizer Tokenizer) *preparedText {
+	return &preparedText{text: text, tokenizer: tokenizer}
+}
+
+// Text returns the normalized string.
+func (p *preparedText) Text() string { return p.text }
+
+// Runes returns the string's runes.
+func (p *preparedText) Runes() []rune {
+	p.runesOnce.Do(func() { p.runes = []rune(p.text) })
+	return p.runes
+}
+
+// RuneCount returns the number of runes in the string.
+func (p *preparedText) RuneCount() int { return len(p.Runes()) }
+
+// Tokens returns the string split by the configured tokenizer.
+func (p *preparedText) Tokens() []string {
+	p.tokensOnce.Do(func() { p.tokens = p.tokenizer.Tokenize(p.text) })
+	return p.tokens
+}
@@ -0,0 +1,88 @@
+package units
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"text/template"
+)
+
+// TemplateEngineGo identifies the default Go text/template prompt engine.
+const TemplateEngineGo = "go"
+
+// PromptRenderer renders an LLM prompt from template data.
+// Implementations wrap a specific template engine so that units can share
+// prompt assets with non-Go tooling (e.g., Jinja-style templates).
+//
+// Renderers always receive data that has already been sanitized by the
+// calling unit, so injection protection does not depend on the engine.
+// Implementations must be safe for concurrent use.
+type PromptRenderer interface {
+	// Render produces the prompt text for the given template data.
+	Render(data any) (string, error)
+}
+
+// PromptRendererFactory compiles template source into a PromptRenderer.
+// The name identifies the template for error messages and debugging.
+// Compilation errors should be returned here rather than at render time.
+type PromptRendererFactory func(name, source string) (PromptRenderer, error)
+
+var (
+	promptRenderersMu sync.RWMutex
+	promptRenderers   = map[string]PromptRendererFactory{
+		TemplateEngineGo: func(name, source string) (PromptRenderer, error) {
+			return NewGoTemplateRenderer(name, source)
+		},
+	}
+)
+
+// RegisterPromptRenderer makes a template engine available to LLM units under
+// the given engine name, which units select via their template_engine setting.
+// Registering an engine name twice replaces the previous factory.
+func RegisterPromptRenderer(engine string, factory PromptRendererFactory) {
+	promptRenderersMu.Lock()
+	defer promptRenderersMu.Unlock()
+	promptRenderers[engine] = factory
+}
+
+// newPromptRenderer compiles source with the factory registered for engine.
+// An empty engine selects the default Go template engine.
+func newPromptRenderer(engine, name, source string) (PromptRenderer, error) {
+	if engine == "" {
+		engine = TemplateEngineGo
+	}
+
+	promptRenderersMu.RLock()
+	factory, ok := promptRenderers[engine]
+	promptRenderersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown template engine: %s", engine)
+	}
+
+	return factory(name, source)
+}
+
+// GoTemplateRenderer renders prompts using Go's text/template package with
+// the standard evaluation template functions from GetTemplateFuncMap.
+type GoTemplateRenderer struct {
+	tmpl *template.Template
+}
+
+// NewGoTemplateRenderer parses source as a Go template and returns a renderer
+// for it. It returns an error if the template fails to parse.
+func NewGoTemplateRenderer(name, source string) (*GoTemplateRenderer, error) {
+	tmpl, err := template.New(name).Funcs(GetTemplateFuncMap()).Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	return &GoTemplateRenderer{tmpl: tmpl}, nil
+}
+
+// Render executes the compiled Go template with the provided data.
+func (r *GoTemplateRenderer) Render(data any) (string, error) {
+	var buf bytes.Buffer
+	if err := r.tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,117 @@
+package units
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/testutils"
+)
+
+// replaceRenderer is a minimal non-Go template engine used in tests.
+// It substitutes the {{ question }} placeholder with the question text.
+type replaceRenderer struct {
+	source string
+}
+
+// Render substitutes the question placeholder with the provided value.
+func (r *replaceRenderer) Render(data any) (string, error) {
+	out := r.source
+	if q, ok := data.(struct{ Question string }); ok {
+		out = strings.ReplaceAll(out, "{{ question }}", q.Question)
+	}
+	return out, nil
+}
+
+// TestGoTemplateRenderer tests rendering with the default Go template engine,
+// including use of the standard template functions and parse failures.
+func TestGoTemplateRenderer(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		data    any
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "renders fields",
+			source: "Q: {{.Question}}",
+			data:   struct{ Question string }{Question: "What is 2+2?"},
+			want:   "Q: What is 2+2?",
+		},
+		{
+			name:   "supports template functions",
+			source: "{{upper .Question}}",
+			data:   struct{ Question string }{Question: "hi"},
+			want:   "HI",
+		},
+		{
+			name:    "invalid template fails to parse",
+			source:  "{{.Question",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer, err := NewGoTemplateRenderer("test", tt.source)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := renderer.Render(tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestNewPromptRenderer tests engine selection, including the default engine,
+// unknown engines, and engines registered at runtime.
+func TestNewPromptRenderer(t *testing.T) {
+	t.Run("empty engine uses go templates", func(t *testing.T) {
+		renderer, err := newPromptRenderer("", "test", "{{.Question}}")
+		require.NoError(t, err)
+		assert.IsType(t, &GoTemplateRenderer{}, renderer)
+	})
+
+	t.Run("unknown engine returns error", func(t *testing.T) {
+		_, err := newPromptRenderer("mustache", "test", "{{question}}")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown template engine")
+	})
+
+	t.Run("registered engine is used by units", func(t *testing.T) {
+		RegisterPromptRenderer("replace", func(name, source string) (PromptRenderer, error) {
+			return &replaceRenderer{source: source}, nil
+		})
+
+		mockLLM := testutils.NewMockLLMClient("test-model")
+		config := AnswererConfig{
+			NumAnswers:     1,
+			Prompt:         "Please answer: {{ question }}",
+			Temperature:    0.0,
+			MaxTokens:      100,
+			Timeout:        time.Second,
+			MaxConcurrency: 1,
+			TemplateEngine: "replace",
+		}
+		unit, err := NewAnswererUnit("answerer", mockLLM, config)
+		require.NoError(t, err)
+
+		rendered, err := unit.promptRenderer.Render(struct{ Question string }{Question: "What is Go?"})
+		require.NoError(t, err)
+		assert.Equal(t, "Please answer: What is Go?", rendered)
+
+		state := domain.With(domain.NewState(), domain.KeyQuestion, "What is Go?")
+		_, err = unit.Execute(context.Background(), state)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,82 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// ErrReferenceSignalIsJudge is returned when a reference signal names the
+// judge whose scores are being aggregated.
+var ErrReferenceSignalIsJudge = errors.New("reference signal unit is the aggregated judge")
+
+// ReferenceSignalConfig adds a deterministic unit's scores, such as those of
+// a fuzzy_match against a reference answer, to aggregation as a weighted
+// voter. Each aggregated score becomes
+//
+//	(1 - Weight) × judge score + Weight × reference score
+//
+// with the reference score mapped onto the judge's declared scale. Small
+// weights (e.g., 0.01) leave the judges' ordering intact and only break
+// ties between equally scored answers.
+type ReferenceSignalConfig struct {
+	// Unit is the ID of the deterministic unit whose scores join the vote.
+	// Its scores are read from domain.KeyJudgeScoresByJudge.
+	Unit string `yaml:"unit" json:"unit" validate:"required"`
+
+	// Weight is the reference signal's share of each aggregated score.
+	Weight float64 `yaml:"weight" json:"weight" validate:"gt=0,max=1"`
+}
+
+// blend returns a copy of summaries, the latest judge's scores, with each
+// score blended with the reference unit's score for the same answer.
+func (c *ReferenceSignalConfig) blend(state domain.State, summaries []domain.JudgeSummary) ([]domain.JudgeSummary, error) {
+	latest, _ := domain.Get(state, domain.KeyLatestJudge)
+	if latest == c.Unit {
+		return nil, fmt.Errorf("%w: %q", ErrReferenceSignalIsJudge, c.Unit)
+	}
+
+	byJudge, _ := domain.Get(state, domain.KeyJudgeScoresByJudge)
+	reference, ok := byJudge[c.Unit]
+	if !ok {
+		return nil, fmt.Errorf("reference signal unit %q has no scores in state", c.Unit)
+	}
+	if len(reference) != len(summaries) {
+		return nil, fmt.Errorf("%w: reference signal %q has %d scores for %d judge scores",
+			ErrScoreMismatch, c.Unit, len(reference), len(summaries))
+	}
+
+	judgeScale, _ := domain.JudgeScoreScale(state, latest)
+	referenceScale, _ := domain.JudgeScoreScale(state, c.Unit)
+
+	blended := make([]domain.JudgeSummary, len(summaries))
+	for i, summary := range summaries {
+		ref := judgeScale.Min + referenceScale.Normalize(reference[i].Score)*(judgeScale.Max-judgeScale.Min)
+		summary.Score = (1-c.Weight)*summary.Score + c.Weight*ref
+		blended[i] = summary
+	}
+	return blended, nil
+}
+
+// provenance returns the verdict provenance entry for the reference
+// signal, or nil when none is configured.
+func (c *ReferenceSignalConfig) provenance() *domain.ReferenceSignal {
+	if c == nil {
+		return nil
+	}
+	return &domain.ReferenceSignal{Unit: c.Unit, Weight: c.Weight}
+}
+
+// applyReferenceSignal blends the reference signal into summaries when one
+// is configured and returns summaries unchanged otherwise.
+func applyReferenceSignal(
+	state domain.State,
+	summaries []domain.JudgeSummary,
+	config *ReferenceSignalConfig,
+) ([]domain.JudgeSummary, error) {
+	if config == nil {
+		return summaries, nil
+	}
+	return config.blend(state, summaries)
+}
@@ -0,0 +1,90 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+// TestAggregators_ReferenceSignal verifies that a deterministic unit's
+// scores join aggregation as a weighted voter and are recorded in the
+// verdict provenance.
+func TestAggregators_ReferenceSignal(t *testing.T) {
+	state := domain.With(domain.NewState(), domain.KeyAnswers, []domain.Answer{
+		{ID: "a1", Content: "First"},
+		{ID: "a2", Content: "Second"},
+	})
+	state = domain.WithJudgeScores(state, "fuzzy", []domain.JudgeSummary{
+		{Score: 0.2, Confidence: 1, Reasoning: "Low overlap"},
+		{Score: 1.0, Confidence: 1, Reasoning: "Exact match"},
+	})
+	state = domain.WithJudgeScores(state, "judge", []domain.JudgeSummary{
+		{Score: 8, Confidence: 0.9, Reasoning: "Good"},
+		{Score: 8, Confidence: 0.9, Reasoning: "Good"},
+	})
+	state = domain.WithJudgeScoreScale(state, "judge", domain.ScoreRange{Min: 0, Max: 10})
+	config := map[string]any{"reference_signal": map[string]any{"unit": "fuzzy", "weight": 0.1}}
+
+	constructors := map[string]func(string, map[string]any, ports.LLMClient) (ports.Unit, error){
+		"max_pool":        NewMaxPoolFromConfig,
+		"arithmetic_mean": NewArithmeticMeanFromConfig,
+		"median_pool":     NewMedianPoolFromConfig,
+	}
+
+	for name, newUnit := range constructors {
+		t.Run(name, func(t *testing.T) {
+			unit, err := newUnit("agg", config, nil)
+			require.NoError(t, err)
+			verdict, err := executeVerdict(unit.Execute(context.Background(), state))
+			require.NoError(t, err)
+
+			want := map[string]float64{"a1": 0.9*8 + 0.1*2, "a2": 0.9*8 + 0.1*10}
+			require.Len(t, verdict.RankedAnswers, len(want))
+			for _, ranked := range verdict.RankedAnswers {
+				assert.InDelta(t, want[ranked.Answer.ID], ranked.Score, 1e-9)
+			}
+			if name != "median_pool" {
+				require.NotNil(t, verdict.WinnerAnswer)
+				assert.Equal(t, "a2", verdict.WinnerAnswer.ID, "reference signal breaks the judge tie")
+			}
+
+			require.NotNil(t, verdict.Provenance)
+			assert.Equal(t, &domain.ReferenceSignal{Unit: "fuzzy", Weight: 0.1}, verdict.Provenance.ReferenceSignal)
+		})
+	}
+
+	t.Run("absent by default", func(t *testing.T) {
+		unit, err := NewMaxPoolFromConfig("agg", map[string]any{}, nil)
+		require.NoError(t, err)
+		verdict, err := executeVerdict(unit.Execute(context.Background(), state))
+		require.NoError(t, err)
+		assert.Nil(t, verdict.Provenance.ReferenceSignal)
+	})
+
+	t.Run("missing reference scores", func(t *testing.T) {
+		unit, err := NewMaxPoolFromConfig("agg",
+			map[string]any{"reference_signal": map[string]any{"unit": "exact", "weight": 0.5}}, nil)
+		require.NoError(t, err)
+		_, err = unit.Execute(context.Background(), state)
+		assert.ErrorContains(t, err, `"exact" has no scores`)
+	})
+
+	t.Run("rejects the aggregated judge", func(t *testing.T) {
+		unit, err := NewMaxPoolFromConfig("agg",
+			map[string]any{"reference_signal": map[string]any{"unit": "judge", "weight": 0.5}}, nil)
+		require.NoError(t, err)
+		_, err = unit.Execute(context.Background(), state)
+		assert.ErrorIs(t, err, ErrReferenceSignalIsJudge)
+	})
+
+	t.Run("rejects invalid weight", func(t *testing.T) {
+		_, err := NewMaxPoolFromConfig("agg",
+			map[string]any{"reference_signal": map[string]any{"unit": "fuzzy", "weight": 1.5}}, nil)
+		assert.Error(t, err)
+	})
+}
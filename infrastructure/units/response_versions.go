@@ -0,0 +1,106 @@
+package units
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ErrUnsupportedResponseVersion is returned when an LLM response declares a
+// schema version with no registered decoder.
+var ErrUnsupportedResponseVersion = errors.New("unsupported response schema version")
+
+// DefaultResponseVersion is assumed when an LLM response omits "version".
+const DefaultResponseVersion = 1
+
+// responseDecoder decodes a JSON object of one declared schema version.
+type responseDecoder[T any] func(jsonStr string) (T, error)
+
+// responseVersions maps declared schema versions to their decoders, so a
+// new response shape is supported by registering a decoder that converts
+// it to the unit's response type rather than by changing existing ones.
+type responseVersions[T any] map[int]responseDecoder[T]
+
+// decode reads the "version" field of jsonStr, defaulting to
+// DefaultResponseVersion, and decodes it with that version's decoder.
+func (r responseVersions[T]) decode(jsonStr string) (T, error) {
+	var zero T
+	var header struct {
+		Version *int `json:"version"`
+	}
+	if err := json.Unmarshal([]byte(jsonStr), &header); err != nil {
+		return zero, fmt.Errorf("failed to parse JSON response (JSON length: %d chars): %w", len(jsonStr), err)
+	}
+
+	version := DefaultResponseVersion
+	if header.Version != nil && *header.Version != 0 {
+		version = *header.Version
+	}
+
+	decoder, ok := r[version]
+	if !ok {
+		return zero, fmt.Errorf("%w: %d (supported: %v)",
+			ErrUnsupportedResponseVersion, version, slices.Sorted(maps.Keys(r)))
+	}
+	resp, err := decoder(jsonStr)
+	if err != nil {
+		return zero, fmt.Errorf("version %d: %w", version, err)
+	}
+	return resp, nil
+}
+
+// decodeJSON is the decoder for versions whose JSON matches T directly.
+// A number too large for its float field, such as 1e400, would be infinite
+// and is reported as ErrNonFiniteScore.
+func decodeJSON[T any](jsonStr string) (T, error) {
+	var resp T
+	if err := json.Unmarshal([]byte(jsonStr), &resp); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Type != nil && typeErr.Type.Kind() == reflect.Float64 &&
+			strings.HasPrefix(typeErr.Value, "number ") {
+			return resp, fmt.Errorf("%w: %s is %s", ErrNonFiniteScore, typeErr.Field, strings.TrimPrefix(typeErr.Value, "number "))
+		}
+		return resp, fmt.Errorf("failed to parse JSON response (JSON length: %d chars): %w", len(jsonStr), err)
+	}
+	return resp, nil
+}
+
+// llmJudgeResponseV2 is version 2 of the judge response. It reports
+// sub-scores alongside the overall score in every mode; in quality mode
+// they are the dimension scores.
+type llmJudgeResponseV2 struct {
+	Score      float64            `json:"score"`
+	Confidence float64            `json:"confidence"`
+	Reasoning  string             `json:"reasoning"`
+	SubScores  map[string]float64 `json:"sub_scores,omitempty"`
+	Version    int                `json:"version"`
+}
+
+// judgeResponseVersions holds the decoders for each judge response version.
+var judgeResponseVersions = responseVersions[LLMJudgeResponse]{
+	1: decodeJSON[LLMJudgeResponse],
+	2: func(jsonStr string) (LLMJudgeResponse, error) {
+		v2, err := decodeJSON[llmJudgeResponseV2](jsonStr)
+		if err != nil {
+			return LLMJudgeResponse{}, err
+		}
+		return LLMJudgeResponse{
+			Score:      v2.Score,
+			Confidence: v2.Confidence,
+			Reasoning:  v2.Reasoning,
+			Dimensions: v2.SubScores,
+			SubScores:  v2.SubScores,
+			Version:    v2.Version,
+		}, nil
+	},
+}
+
+// verificationResponseVersions holds the decoders for each verification
+// response version.
+var verificationResponseVersions = responseVersions[LLMVerificationResponse]{
+	1: decodeJSON[LLMVerificationResponse],
+}
@@ -0,0 +1,124 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/testutils"
+)
+
+// TestScoreJudgeUnit_ResponseVersions tests that judge responses are decoded
+// by the parser for their declared version.
+func TestScoreJudgeUnit_ResponseVersions(t *testing.T) {
+	config := defaultScoreJudgeConfig()
+	config.ScoreScale = "0.0-1.0"
+	unit, err := NewScoreJudgeUnit("judge", testutils.NewMockLLMClient("test-model"), config)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		response       string
+		wantScore      float64
+		wantDimensions map[string]float64
+		wantErr        error
+		wantErrText    string
+	}{
+		{
+			name:      "missing version parses as version 1",
+			response:  `{"score": 0.7, "confidence": 0.9, "reasoning": "Mostly correct answer"}`,
+			wantScore: 0.7,
+		},
+		{
+			name:      "version 1 ignores sub-scores",
+			response:  `{"score": 0.7, "confidence": 0.9, "reasoning": "Mostly correct answer", "sub_scores": {"accuracy": 0.6}, "version": 1}`,
+			wantScore: 0.7,
+		},
+		{
+			name:           "version 2 records sub-scores",
+			response:       `{"score": 0.8, "confidence": 0.9, "reasoning": "Accurate but terse", "sub_scores": {"accuracy": 0.9, "clarity": 0.6}, "version": 2}`,
+			wantScore:      0.8,
+			wantDimensions: map[string]float64{"accuracy": 0.9, "clarity": 0.6},
+		},
+		{
+			name:        "version 2 sub-score out of range",
+			response:    `{"score": 0.8, "confidence": 0.9, "reasoning": "Accurate but terse", "sub_scores": {"accuracy": 4}, "version": 2}`,
+			wantErrText: `sub-score "accuracy" out of range`,
+		},
+		{
+			name:        "unsupported version",
+			response:    `{"score": 0.8, "confidence": 0.9, "reasoning": "Accurate but terse", "version": 3}`,
+			wantErr:     ErrUnsupportedResponseVersion,
+			wantErrText: "3 (supported: [1 2])",
+		},
+		{
+			name:        "non-numeric version",
+			response:    `{"score": 0.8, "confidence": 0.9, "reasoning": "Accurate but terse", "version": "2"}`,
+			wantErrText: "failed to parse JSON response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary, err := unit.parseLLMResponse(tt.response, "judge_1")
+			if tt.wantErr != nil || tt.wantErrText != "" {
+				if tt.wantErr != nil {
+					require.ErrorIs(t, err, tt.wantErr)
+				}
+				require.ErrorContains(t, err, tt.wantErrText)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantScore, summary.Score)
+			assert.Equal(t, tt.wantDimensions, summary.Dimensions)
+		})
+	}
+
+	t.Run("quality mode reads version 2 sub-scores as dimensions", func(t *testing.T) {
+		config := defaultScoreJudgeConfig()
+		config.ScoreScale = "0.0-1.0"
+		config.Mode = ScoreJudgeModeQuality
+		config.Dimensions = []QualityDimension{{Name: "accuracy"}, {Name: "clarity"}}
+		unit, err := NewScoreJudgeUnit("judge", testutils.NewMockLLMClient("test-model"), config)
+		require.NoError(t, err)
+
+		summary, err := unit.parseLLMResponse(
+			`{"score": 0, "confidence": 0.9, "reasoning": "Accurate but terse", "sub_scores": {"accuracy": 1.0, "clarity": 0.5}, "version": 2}`,
+			"judge_1")
+		require.NoError(t, err)
+		assert.InDelta(t, 0.75, summary.Score, 1e-9)
+	})
+
+	t.Run("unsupported version fails execution as a parse error", func(t *testing.T) {
+		client := testutils.NewMockLLMClient("test-model")
+		client.SetResponse(`{"score": 0.8, "confidence": 0.9, "reasoning": "Accurate but terse", "version": 9}`)
+		unit, err := NewScoreJudgeUnit("judge", client, config)
+		require.NoError(t, err)
+
+		state := domain.With(domain.NewState(), domain.KeyQuestion, "What is 2+2?")
+		state = domain.With(state, domain.KeyAnswers, []domain.Answer{{ID: "a1", Content: "4"}})
+		_, err = unit.Execute(context.Background(), state)
+		require.ErrorIs(t, err, domain.ErrResponseParse)
+		assert.ErrorIs(t, err, ErrUnsupportedResponseVersion)
+	})
+}
+
+// TestVerificationUnit_ResponseVersions tests that verification responses
+// default to version 1 and reject versions without a parser.
+func TestVerificationUnit_ResponseVersions(t *testing.T) {
+	unit := &VerificationUnit{validator: testutils.NewTestValidator()}
+
+	resp, err := unit.parseLLMResponse(`{"confidence": 0.85, "reasoning": "Consistent with the evidence"}`)
+	require.NoError(t, err)
+	assert.Equal(t, 0.85, resp.Confidence)
+
+	resp, err = unit.parseLLMResponse(`{"confidence": 0.85, "reasoning": "Consistent with the evidence", "version": 1}`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Version)
+
+	_, err = unit.parseLLMResponse(`{"confidence": 0.85, "reasoning": "Consistent with the evidence", "version": 2}`)
+	require.ErrorIs(t, err, ErrUnsupportedResponseVersion)
+}
@@ -0,0 +1,249 @@
+package units
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+var _ ports.Unit = (*SamplingUnit)(nil)
+
+// ErrNoSampleSize is returned when a SamplingUnit is configured with
+// neither a sample size nor a sample fraction.
+var ErrNoSampleSize = errors.New("sampling requires size or fraction")
+
+// SamplingUnit trims a large candidate pool to a random subset so that
+// downstream units, typically LLM judges, score only a representative
+// sample. This deliberately trades exhaustiveness for cost: the verdict is
+// based on the best sampled answer, which need not be the best in the pool.
+//
+// The sample is drawn uniformly without replacement and is reproducible:
+// the random source is seeded with the configured Seed, or else from the
+// item as domain.ItemSeed derives it, so rerunning an item draws the same
+// sample. Sampled answers replace KeyAnswers in their original order, the
+// remainder are recorded under KeyDroppedAnswers, and aligned judge scores
+// are filtered to match, as TopKSelectionUnit does. The draw is recorded
+// under KeySample, which the graph executor copies onto the verdict. Pools
+// no larger than the sample size pass through untouched and record no
+// sample.
+//
+// The unit makes no LLM calls and is stateless and thread-safe.
+type SamplingUnit struct {
+	// name is the unique identifier for this unit instance.
+	name string
+	// config contains the validated configuration parameters.
+	config SamplingConfig
+	// tracer is the OpenTelemetry tracer for observability.
+	tracer trace.Tracer
+}
+
+// SamplingConfig defines how many answers a SamplingUnit keeps and how the
+// sample is seeded. At least one of Size and Fraction must be set.
+type SamplingConfig struct {
+	// Size is the number of answers to keep. When Fraction is also set it
+	// caps the fractional sample size.
+	Size int `yaml:"size" json:"size" validate:"min=0,max=10000"`
+
+	// Fraction is the share of the pool to keep, rounded up so a non-empty
+	// pool always yields at least one answer. Zero disables it.
+	Fraction float64 `yaml:"fraction" json:"fraction" validate:"min=0,max=1"`
+
+	// Seed, when set, seeds every item's sample with the same value instead
+	// of one derived from the item.
+	Seed *int64 `yaml:"seed,omitempty" json:"seed,omitempty"`
+
+	// Salt is mixed into seeds derived from the item, so separate studies
+	// over the same dataset draw different samples while each stays
+	// reproducible. It is ignored when Seed is set.
+	Salt int64 `yaml:"salt,omitempty" json:"salt,omitempty"`
+
+	// MinAnswers is the fewest answers the unit accepts. Execution fails
+	// with ErrTooFewAnswers when fewer are present. Zero disables the check.
+	MinAnswers int `yaml:"min_answers,omitempty" json:"min_answers,omitempty" validate:"min=0,max=10000"`
+}
+
+// DefaultSamplingConfig returns a SamplingConfig that keeps 50 answers,
+// seeded per item.
+func DefaultSamplingConfig() SamplingConfig {
+	return SamplingConfig{Size: 50}
+}
+
+// NewSamplingUnit creates a new SamplingUnit with the specified
+// configuration. Returns an error if configuration validation fails.
+func NewSamplingUnit(name string, config SamplingConfig) (*SamplingUnit, error) {
+	if name == "" {
+		return nil, ErrEmptyUnitName
+	}
+
+	if err := validateSamplingConfig(config); err != nil {
+		return nil, domain.NewConfigValidationError(name, err)
+	}
+
+	return &SamplingUnit{
+		name:   name,
+		config: config,
+		tracer: otel.Tracer("sampling-unit"),
+	}, nil
+}
+
+// validateSamplingConfig checks the field constraints of config and that it
+// sets a sample size or fraction.
+func validateSamplingConfig(config SamplingConfig) error {
+	if err := validate.Struct(config); err != nil {
+		return fieldValidationError(err)
+	}
+	if config.Size == 0 && config.Fraction == 0 {
+		return ErrNoSampleSize
+	}
+	return nil
+}
+
+// Name returns the unique identifier for this unit instance.
+func (su *SamplingUnit) Name() string { return su.name }
+
+// Execute keeps a seeded random sample of the answers in KeyAnswers and
+// records the draw in KeySample.
+//
+// Returns an error if answers are missing or there are fewer than
+// MinAnswers.
+func (su *SamplingUnit) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	_, span := su.tracer.Start(ctx, "SamplingUnit.Execute",
+		trace.WithAttributes(
+			attribute.String("unit.type", "sampling"),
+			attribute.String("unit.id", su.name),
+			attribute.Int("config.size", su.config.Size),
+			attribute.Float64("config.fraction", su.config.Fraction),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+
+	answers, err := domain.Require(state, domain.KeyAnswers, su.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	if len(answers) == 0 {
+		err := fmt.Errorf("no answers provided for sampling")
+		span.RecordError(err)
+		return state, err
+	}
+
+	if err := checkMinAnswers(su.name, len(answers), su.config.MinAnswers); err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	size := su.sampleSize(len(answers))
+	span.SetAttributes(
+		attribute.Int("eval.answers_count", len(answers)),
+		attribute.Int("eval.selected_count", size),
+		attribute.Bool("no_llm_cost", true),
+	)
+	if size >= len(answers) {
+		span.SetAttributes(attribute.Int64("eval.latency_ms", time.Since(start).Milliseconds()))
+		return state, nil
+	}
+
+	seed := su.seed(state)
+	rng := rand.New(rand.NewSource(seed)) // #nosec G404 -- reproducible samples, not security
+	picked := rng.Perm(len(answers))[:size]
+	slices.Sort(picked)
+
+	keep := make([]bool, len(answers))
+	for _, idx := range picked {
+		keep[idx] = true
+	}
+
+	sampled := make([]domain.Answer, 0, size)
+	var dropped []domain.Answer
+	sample := &domain.Sample{
+		Unit:       su.name,
+		Seed:       seed,
+		PoolSize:   len(answers),
+		SampleSize: size,
+	}
+	for i, answer := range answers {
+		if keep[i] {
+			sampled = append(sampled, answer)
+		} else {
+			dropped = append(dropped, answer)
+			sample.UnsampledIDs = append(sample.UnsampledIDs, answer.ID)
+		}
+	}
+
+	newState := domain.With(state, domain.KeyAnswers, sampled)
+	newState = domain.With(newState, domain.KeyDroppedAnswers, dropped)
+	newState = domain.With(newState, domain.KeySample, sample)
+	newState = filterAlignedJudgeScores(newState, keep)
+
+	span.SetAttributes(
+		attribute.Int64("eval.latency_ms", time.Since(start).Milliseconds()),
+		attribute.Int("eval.dropped_count", len(dropped)),
+		attribute.Int64("sampling.seed", seed),
+	)
+
+	return newState, nil
+}
+
+// sampleSize returns how many of n answers to keep: the configured
+// fraction of n rounded up, capped at Size when both are set.
+func (su *SamplingUnit) sampleSize(n int) int {
+	if su.config.Fraction == 0 {
+		return su.config.Size
+	}
+	size := int(math.Ceil(su.config.Fraction * float64(n)))
+	if su.config.Size > 0 {
+		size = min(size, su.config.Size)
+	}
+	return size
+}
+
+// seed returns the seed of the item's sample: the configured Seed if set,
+// otherwise one derived from the item and Salt.
+func (su *SamplingUnit) seed(state domain.State) int64 {
+	if su.config.Seed != nil {
+		return *su.config.Seed
+	}
+	return domain.ItemSeed(state, su.config.Salt)
+}
+
+// Validate checks if the unit is properly configured and ready for
+// execution.
+func (su *SamplingUnit) Validate() error {
+	if err := validateSamplingConfig(su.config); err != nil {
+		return domain.NewConfigValidationError(su.name, err)
+	}
+	return nil
+}
+
+// NewSamplingFromConfig creates a SamplingUnit from a configuration map.
+// This is the boundary adapter for YAML/JSON configuration. Setting only
+// "fraction" replaces the default size, so the fraction is not capped
+// unless "size" is given as well.
+func NewSamplingFromConfig(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error) {
+	// llm is ignored - sampling is deterministic given the seed.
+
+	cfg := DefaultSamplingConfig()
+	if _, ok := config["fraction"]; ok {
+		cfg.Size = 0
+	}
+	if err := overlayYAML(config, &cfg); err != nil {
+		return nil, err
+	}
+
+	return NewSamplingUnit(id, cfg)
+}
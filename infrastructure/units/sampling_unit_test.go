@@ -0,0 +1,138 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/testutils"
+)
+
+// samplingState returns a state for item with n answers a0..a(n-1) and a
+// judge score per answer equal to its index.
+func samplingState(t *testing.T, item string, n int) domain.State {
+	t.Helper()
+	answers := make([]domain.Answer, n)
+	scores := make([]domain.JudgeSummary, n)
+	for i := range n {
+		answers[i] = domain.Answer{ID: fmt.Sprintf("a%d", i), Content: fmt.Sprintf("answer %d", i)}
+		scores[i] = domain.JudgeSummary{Score: float64(i)}
+	}
+	state := testutils.EvaluationState(t, "Which answer?", answers)
+	state = domain.With(state, domain.KeyItemID, item)
+	return domain.WithJudgeScores(state, "prefilter", scores)
+}
+
+// TestSamplingUnit_Execute tests sample sizes, the recorded sample, and
+// that unsampled answers and their aligned scores are dropped.
+func TestSamplingUnit_Execute(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]any
+		pool     int
+		wantSize int
+	}{
+		{name: "fixed size", config: map[string]any{"size": 10}, pool: 100, wantSize: 10},
+		{name: "fraction rounds up", config: map[string]any{"fraction": 0.25}, pool: 10, wantSize: 3},
+		{name: "size caps fraction", config: map[string]any{"fraction": 0.5, "size": 20}, pool: 100, wantSize: 20},
+		{name: "default size", config: map[string]any{}, pool: 200, wantSize: 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := NewSamplingFromConfig("sample", tt.config, nil)
+			require.NoError(t, err)
+
+			result, err := unit.Execute(context.Background(), samplingState(t, "item-1", tt.pool))
+			require.NoError(t, err)
+
+			answers, _ := result.GetAnswers()
+			require.Len(t, answers, tt.wantSize)
+			dropped, _ := result.GetDroppedAnswers()
+			assert.Len(t, dropped, tt.pool-tt.wantSize)
+
+			scores, _ := result.GetJudgeScores()
+			require.Len(t, scores, tt.wantSize)
+			for i, answer := range answers {
+				assert.Equal(t, fmt.Sprintf("a%d", int(scores[i].Score)), answer.ID, "scores stay aligned")
+				if i > 0 {
+					assert.Less(t, scores[i-1].Score, scores[i].Score, "original order is kept")
+				}
+			}
+
+			sample, ok := domain.Get(result, domain.KeySample)
+			require.True(t, ok)
+			assert.Equal(t, "sample", sample.Unit)
+			assert.Equal(t, tt.pool, sample.PoolSize)
+			assert.Equal(t, tt.wantSize, sample.SampleSize)
+			assert.Len(t, sample.UnsampledIDs, tt.pool-tt.wantSize)
+			assert.InDelta(t, float64(tt.wantSize)/float64(tt.pool), sample.Fraction(), 1e-9)
+		})
+	}
+}
+
+// TestSamplingUnit_Reproducible tests that the sample depends only on the
+// item, salt, and seed.
+func TestSamplingUnit_Reproducible(t *testing.T) {
+	sampleIDs := func(config map[string]any, item string) []string {
+		t.Helper()
+		unit, err := NewSamplingFromConfig("sample", config, nil)
+		require.NoError(t, err)
+		result, err := unit.Execute(context.Background(), samplingState(t, item, 50))
+		require.NoError(t, err)
+		sample, _ := domain.Get(result, domain.KeySample)
+		return sample.UnsampledIDs
+	}
+
+	config := map[string]any{"size": 5}
+	assert.Equal(t, sampleIDs(config, "item-1"), sampleIDs(config, "item-1"))
+	assert.NotEqual(t, sampleIDs(config, "item-1"), sampleIDs(config, "item-2"))
+	assert.NotEqual(t, sampleIDs(config, "item-1"), sampleIDs(map[string]any{"size": 5, "salt": 7}, "item-1"))
+
+	seeded := map[string]any{"size": 5, "seed": 42}
+	assert.Equal(t, sampleIDs(seeded, "item-1"), sampleIDs(seeded, "item-2"))
+}
+
+// TestSamplingUnit_SmallPool tests that a pool no larger than the sample
+// passes through without recording a sample.
+func TestSamplingUnit_SmallPool(t *testing.T) {
+	unit, err := NewSamplingUnit("sample", SamplingConfig{Size: 5})
+	require.NoError(t, err)
+
+	state := samplingState(t, "item-1", 5)
+	result, err := unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+
+	answers, _ := result.GetAnswers()
+	assert.Len(t, answers, 5)
+	_, ok := domain.Get(result, domain.KeySample)
+	assert.False(t, ok)
+}
+
+// TestSamplingUnit_Errors tests missing answers, the minimum answer count,
+// and configuration errors.
+func TestSamplingUnit_Errors(t *testing.T) {
+	unit, err := NewSamplingUnit("sample", SamplingConfig{Size: 2, MinAnswers: 3})
+	require.NoError(t, err)
+
+	_, err = unit.Execute(context.Background(), domain.NewState())
+	assert.Error(t, err)
+	_, err = unit.Execute(context.Background(), samplingState(t, "item-1", 2))
+	assert.ErrorIs(t, err, ErrTooFewAnswers)
+
+	_, err = NewSamplingUnit("sample", SamplingConfig{})
+	assert.ErrorIs(t, err, ErrNoSampleSize)
+	for _, config := range []map[string]any{
+		{"fraction": 1.5},
+		{"size": -1},
+		{"fraction": 0.0},
+	} {
+		_, err := NewSamplingFromConfig("sample", config, nil)
+		assert.Error(t, err, config)
+	}
+	_, err = NewSamplingUnit("", DefaultSamplingConfig())
+	assert.ErrorIs(t, err, ErrEmptyUnitName)
+}
@@ -0,0 +1,158 @@
+package units
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// SanitizationStrategy selects how untrusted content (questions, answers,
+// judge reasoning) is isolated before being interpolated into an LLM prompt.
+// Strategies trade off injection resistance against fidelity of the content
+// the model sees, which matters when answers are themselves code or markdown.
+type SanitizationStrategy string
+
+// Supported sanitization strategies.
+const (
+	// SanitizeNone inserts content verbatim.
+	//
+	// Security: provides no protection. Content can impersonate prompt
+	// instructions or the required response format. Use only when every
+	// input is trusted, e.g., curated benchmark datasets.
+	SanitizeNone SanitizationStrategy = "none"
+
+	// SanitizeCodeFence wraps content in a markdown code block and rewrites
+	// embedded ``` fences to ''' so content cannot close the block. This is
+	// the default.
+	//
+	// Security: strong isolation for prose, but it alters code and markdown
+	// answers that contain fences, which can mislead the model when judging
+	// programming answers.
+	SanitizeCodeFence SanitizationStrategy = "code_fence"
+
+	// SanitizeDelimiter wraps content between explicit BEGIN/END markers and
+	// escapes only occurrences of those markers inside the content.
+	//
+	// Security: content is preserved byte-for-byte unless it contains the
+	// markers themselves, so code and markdown survive intact. Isolation
+	// relies on the prompt telling the model to treat marked regions as data.
+	SanitizeDelimiter SanitizationStrategy = "delimiter"
+
+	// SanitizeXMLTag wraps content in <content> tags and escapes only opening
+	// or closing content tags found inside it.
+	//
+	// Security: comparable to SanitizeDelimiter and well suited to models
+	// trained to respect XML-delimited context. Other markup, including
+	// HTML in answers, is left untouched.
+	SanitizeXMLTag SanitizationStrategy = "xml_tag"
+)
+
+// Markers used by SanitizeDelimiter to bound untrusted content.
+const (
+	contentBeginMarker = "<<<BEGIN_CONTENT>>>"
+	contentEndMarker   = "<<<END_CONTENT>>>"
+)
+
+// Tags that delimit the question and the answer in judge prompts rendered
+// through {{.QuestionBlock}} and {{.AnswerBlock}}. They do not change with
+// the configured labels, so parsers of judge prompts can rely on them. The
+// raw {{.Question}} and {{.Answer}} fields are not wrapped in them.
+const (
+	PromptQuestionTag = domain.PromptQuestionTag
+	PromptAnswerTag   = domain.PromptAnswerTag
+)
+
+var (
+	// delimiterEscaper shortens markers embedded in content by one bracket
+	// on each side. escapeDelimiters applies it until no marker remains.
+	delimiterEscaper = strings.NewReplacer(
+		contentBeginMarker, "<<BEGIN_CONTENT>>",
+		contentEndMarker, "<<END_CONTENT>>",
+	)
+
+	// contentTagPattern matches opening and closing content tags in any case,
+	// including variants with attributes or whitespace.
+	contentTagPattern = regexp.MustCompile(`(?i)<(/?\s*content)\b`)
+
+	// promptBlockTagPattern matches opening and closing question and answer
+	// tags in any case, so content cannot end its block early or open
+	// another.
+	promptBlockTagPattern = regexp.MustCompile(`(?i)<(/?\s*(?:` + PromptQuestionTag + `|` + PromptAnswerTag + `))\b`)
+)
+
+// promptBlock renders content under label, wrapped in tag. Question and
+// answer tags inside content are escaped so the block's closing tag is the
+// only one.
+func promptBlock(label, tag, content string) string {
+	return label + ":\n<" + tag + ">\n" +
+		promptBlockTagPattern.ReplaceAllString(content, "&lt;$1") +
+		"\n</" + tag + ">"
+}
+
+// sanitizeContent isolates untrusted content according to strategy.
+// Strategies are validated with the unit configuration, so any unrecognized
+// value falls back to the safest default, SanitizeCodeFence.
+func sanitizeContent(strategy SanitizationStrategy, content string) string {
+	switch strategy {
+	case SanitizeNone:
+		return content
+	case SanitizeDelimiter:
+		return contentBeginMarker + "\n" + escapeDelimiters(content) + "\n" + contentEndMarker + "\n"
+	case SanitizeXMLTag:
+		return "<content>\n" + contentTagPattern.ReplaceAllString(content, "&lt;$1") + "\n</content>\n"
+	default:
+		content = strings.ReplaceAll(content, "```", "'''")
+		return "```\n" + content + "\n```\n"
+	}
+}
+
+// escapeDelimiters neutralizes markers embedded in content so they cannot
+// terminate the delimited region early. Escaping a nested marker such as
+// <<<<END_CONTENT>>>> yields a real marker, so replacement repeats until
+// none remains; each pass shortens the content, so it terminates.
+func escapeDelimiters(content string) string {
+	for strings.Contains(content, contentBeginMarker) || strings.Contains(content, contentEndMarker) {
+		content = delimiterEscaper.Replace(content)
+	}
+	return content
+}
+
+// maxMetadataValueRunes bounds the length of answer metadata values exposed
+// to prompt templates.
+const maxMetadataValueRunes = 200
+
+// selectAnswerMetadata returns the requested fields from an answer's
+// metadata, sanitized for interpolation into a prompt. Fields absent from
+// the metadata are omitted. It returns nil when no fields are requested.
+func selectAnswerMetadata(metadata map[string]string, fields []string) map[string]string {
+	if len(fields) == 0 {
+		return nil
+	}
+	selected := make(map[string]string, len(fields))
+	for _, field := range fields {
+		if value, ok := metadata[field]; ok {
+			selected[field] = sanitizeMetadataValue(value)
+		}
+	}
+	return selected
+}
+
+// sanitizeMetadataValue flattens a metadata value onto a single line of
+// bounded length and neutralizes code fences. Metadata is short descriptive
+// text, so unlike answer content it can be normalized aggressively without
+// losing meaning.
+func sanitizeMetadataValue(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, value)
+	value = strings.ReplaceAll(value, "```", "'''")
+	if runes := []rune(value); len(runes) > maxMetadataValueRunes {
+		value = string(runes[:maxMetadataValueRunes])
+	}
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,133 @@
+package units
+
+import (
+	"math"
+	"strings"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/textnorm"
+)
+
+// Defaults for ConsistencyCheckConfig.
+const (
+	// DefaultConsistencySimilarity is the word overlap at or above which two
+	// answers are treated as near-duplicates.
+	DefaultConsistencySimilarity = 0.9
+
+	// DefaultConsistencyDivergence is the score difference, as a fraction
+	// of the score scale's range, above which near-duplicates are flagged.
+	DefaultConsistencyDivergence = 0.3
+)
+
+// ConsistencyCheckConfig configures a pass after scoring that compares
+// answers with each other. Scoring answers independently hides relative
+// inconsistencies, such as two near-identical answers receiving very
+// different scores; the pass flags such pairs in the trace and can score
+// them again.
+type ConsistencyCheckConfig struct {
+	// Similarity is the word overlap (Jaccard similarity of the answers'
+	// lowercased word sets) at or above which two answers are compared.
+	// Defaults to DefaultConsistencySimilarity when zero.
+	Similarity float64 `yaml:"similarity,omitempty" json:"similarity,omitempty" validate:"min=0,max=1"`
+
+	// Divergence is the score difference, as a fraction of the score
+	// scale's range, above which two similar answers are inconsistent.
+	// Defaults to DefaultConsistencyDivergence when zero.
+	Divergence float64 `yaml:"divergence,omitempty" json:"divergence,omitempty" validate:"min=0,max=1"`
+
+	// Rescore scores every answer in an inconsistent pair once more and
+	// combines the new judgment with the earlier ones as self-consistency
+	// does. Rescoring calls are charged to the budget.
+	Rescore bool `yaml:"rescore,omitempty" json:"rescore,omitempty"`
+}
+
+// similarity returns the similarity threshold, applying the default.
+func (c *ConsistencyCheckConfig) similarity() float64 {
+	if c.Similarity == 0 {
+		return DefaultConsistencySimilarity
+	}
+	return c.Similarity
+}
+
+// divergence returns the divergence threshold, applying the default.
+func (c *ConsistencyCheckConfig) divergence() float64 {
+	if c.Divergence == 0 {
+		return DefaultConsistencyDivergence
+	}
+	return c.Divergence
+}
+
+// scoreInconsistency is a pair of similar answers, by index, whose scores
+// diverge.
+type scoreInconsistency struct {
+	first, second int
+	similarity    float64
+	scoreDelta    float64
+}
+
+// findInconsistencies compares every pair of scored answers and returns
+// those at least as similar as the configured threshold whose scores
+// differ by more than the configured fraction of scaleRange. Skipped
+// answers were not judged and are ignored.
+func (c *ConsistencyCheckConfig) findInconsistencies(
+	answers []domain.Answer,
+	summaries []domain.JudgeSummary,
+	skipped []bool,
+	scaleRange float64,
+) []scoreInconsistency {
+	words := make([]map[string]struct{}, len(answers))
+	for i, answer := range answers {
+		if !skipped[i] {
+			words[i] = wordSet(answer.Content)
+		}
+	}
+
+	maxDelta := c.divergence() * scaleRange
+	var found []scoreInconsistency
+	for i := range answers {
+		if skipped[i] {
+			continue
+		}
+		for j := i + 1; j < len(answers); j++ {
+			if skipped[j] {
+				continue
+			}
+			delta := math.Abs(summaries[i].Score - summaries[j].Score)
+			if delta <= maxDelta {
+				continue
+			}
+			if sim := jaccard(words[i], words[j]); sim >= c.similarity() {
+				found = append(found, scoreInconsistency{first: i, second: j, similarity: sim, scoreDelta: delta})
+			}
+		}
+	}
+	return found
+}
+
+// wordSet returns the set of case-folded whitespace-separated words in s.
+func wordSet(s string) map[string]struct{} {
+	fields := strings.Fields(textnorm.Fold(s))
+	set := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		set[field] = struct{}{}
+	}
+	return set
+}
+
+// jaccard returns the size of the intersection of a and b over the size of
+// their union. Two empty sets are identical.
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	shared := 0
+	for word := range a {
+		if _, ok := b[word]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,147 @@
+package units
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// Supported scoring modes for the ScoreJudgeUnit.
+const (
+	// ScoreJudgeModeScore asks the judge for a single holistic score. It is
+	// the default.
+	ScoreJudgeModeScore = "score"
+
+	// ScoreJudgeModeQuality asks the judge to score each answer along
+	// quality dimensions without a reference answer, and aggregates the
+	// dimension scores into the answer's score. It is intended for
+	// open-ended generation and production monitoring where no ground
+	// truth exists.
+	ScoreJudgeModeQuality = "quality"
+)
+
+// QualityDimension is one axis of reference-free quality assessment.
+type QualityDimension struct {
+	// Name identifies the dimension in the prompt, the judge's response,
+	// and JudgeSummary.Dimensions.
+	Name string `yaml:"name" json:"name" validate:"required,max=50"`
+
+	// Description tells the judge what the dimension measures.
+	Description string `yaml:"description,omitempty" json:"description,omitempty" validate:"max=500"`
+
+	// Weight is the dimension's share of the aggregated score, relative to
+	// the other dimensions. Zero counts as 1.
+	Weight float64 `yaml:"weight,omitempty" json:"weight,omitempty" validate:"min=0"`
+}
+
+// weight returns the dimension's weight, applying the default.
+func (d QualityDimension) weight() float64 {
+	if d.Weight == 0 {
+		return 1
+	}
+	return d.Weight
+}
+
+// DefaultQualityDimensions returns the dimensions used by quality mode
+// when none are configured.
+func DefaultQualityDimensions() []QualityDimension {
+	return []QualityDimension{
+		{Name: "helpfulness", Description: "How well the answer addresses what the question asks for."},
+		{Name: "coherence", Description: "How clear, well organized, and internally consistent the answer is."},
+		{Name: "factuality", Description: "Whether the claims in the answer are accurate and free of fabrication."},
+	}
+}
+
+// validateQualityConfig checks the settings specific to quality mode.
+func validateQualityConfig(config ScoreJudgeConfig) error {
+	if config.Mode != ScoreJudgeModeQuality {
+		if len(config.Dimensions) > 0 {
+			return fmt.Errorf("dimensions require mode %q", ScoreJudgeModeQuality)
+		}
+		return nil
+	}
+
+	seen := make(map[string]bool, len(config.Dimensions))
+	for _, dim := range config.Dimensions {
+		if seen[dim.Name] {
+			return fmt.Errorf("duplicate quality dimension %q", dim.Name)
+		}
+		seen[dim.Name] = true
+	}
+	return nil
+}
+
+// qualityDimensions returns the configured dimensions, or the defaults
+// when none are configured.
+func (sju *ScoreJudgeUnit) qualityDimensions() []QualityDimension {
+	if len(sju.config.Dimensions) > 0 {
+		return sju.config.Dimensions
+	}
+	return DefaultQualityDimensions()
+}
+
+// describeQualityDimensions renders the dimension instructions and the
+// JSON response format for quality mode.
+func describeQualityDimensions(dimensions []QualityDimension, scale string) string {
+	var b strings.Builder
+	b.WriteString("\n\nNo reference answer is available. Judge the answer on its own merits, scoring each of these quality dimensions on the scale ")
+	b.WriteString(scale)
+	b.WriteString(":")
+	for _, dim := range dimensions {
+		b.WriteString("\n- ")
+		b.WriteString(dim.Name)
+		if dim.Description != "" {
+			b.WriteString(": ")
+			b.WriteString(dim.Description)
+		}
+	}
+
+	fields := make([]string, len(dimensions))
+	for i, dim := range dimensions {
+		fields[i] = fmt.Sprintf("%q: <number>", dim.Name)
+	}
+	b.WriteString("\n\nIMPORTANT: You must respond with valid JSON in exactly this format:\n")
+	fmt.Fprintf(&b, `{"dimensions": {%s}, "confidence": <0.0-1.0>, "reasoning": "<detailed explanation>", "version": 1}`,
+		strings.Join(fields, ", "))
+	return b.String()
+}
+
+// aggregateDimensions checks that the response scores every configured
+// dimension within the scale and returns the weighted mean along with the
+// per-dimension breakdown. Dimensions the unit did not ask for are ignored.
+func (sju *ScoreJudgeUnit) aggregateDimensions(scores map[string]float64) (float64, map[string]float64, error) {
+	dimensions := sju.qualityDimensions()
+	breakdown := make(map[string]float64, len(dimensions))
+	var total, weights float64
+	for _, dim := range dimensions {
+		score, ok := scores[dim.Name]
+		if !ok {
+			return 0, nil, fmt.Errorf("missing score for quality dimension %q", dim.Name)
+		}
+		if err := sju.validateScoreInRange(score); err != nil {
+			return 0, nil, fmt.Errorf("quality dimension %q: %w", dim.Name, err)
+		}
+		breakdown[dim.Name] = score
+		total += dim.weight() * score
+		weights += dim.weight()
+	}
+	return total / weights, breakdown, nil
+}
+
+// qualityTrace renders the per-dimension breakdown of each scored answer,
+// in dimension order, for the execution span.
+func qualityTrace(dimensions []QualityDimension, summaries []domain.JudgeSummary) []string {
+	lines := make([]string, 0, len(summaries))
+	for i, summary := range summaries {
+		if summary.Dimensions == nil {
+			continue
+		}
+		parts := make([]string, len(dimensions))
+		for j, dim := range dimensions {
+			parts[j] = fmt.Sprintf("%s=%.2f", dim.Name, summary.Dimensions[dim.Name])
+		}
+		lines = append(lines, fmt.Sprintf("answer %d: %s", i+1, strings.Join(parts, " ")))
+	}
+	return lines
+}
@@ -0,0 +1,220 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/textnorm"
+)
+
+// Actions taken when a judge's reasoning fails the quality gate.
+const (
+	// ReasoningActionReprompt asks the judge once more with the failure
+	// explained, and lowers the confidence of the second response if it
+	// also fails. This is the default.
+	ReasoningActionReprompt = "reprompt"
+
+	// ReasoningActionLowerConfidence keeps the response and lowers its
+	// confidence without another call.
+	ReasoningActionLowerConfidence = "lower_confidence"
+)
+
+// Defaults for ReasoningQualityConfig.
+const (
+	// DefaultReasoningMinWords is the fewest words substantive reasoning
+	// may contain.
+	DefaultReasoningMinWords = 10
+
+	// DefaultReasoningConfidenceFactor scales the confidence of responses
+	// whose reasoning fails the gate.
+	DefaultReasoningConfidenceFactor = 0.5
+)
+
+// ReasoningQualityConfig configures a gate on the reasoning each judge
+// response carries. The response schema only requires a few characters of
+// reasoning, which filler such as "Good answer." satisfies; the gate holds
+// judges to substantive justifications for the humans who read them later.
+type ReasoningQualityConfig struct {
+	// MinWords is the fewest whitespace-separated words the reasoning must
+	// contain. Defaults to DefaultReasoningMinWords when zero.
+	MinWords int `yaml:"min_words,omitempty" json:"min_words,omitempty" validate:"min=0,max=500"`
+
+	// MustCiteAnswer requires the reasoning to share at least one word
+	// with the answer, ignoring case, punctuation, and stopwords, so that
+	// it refers to what the answer actually says.
+	MustCiteAnswer bool `yaml:"must_cite_answer,omitempty" json:"must_cite_answer,omitempty"`
+
+	// TemplatePhrases lists boilerplate the reasoning must not consist of.
+	// Reasoning fails when, ignoring case, spacing, and surrounding
+	// punctuation, it equals one of the phrases. Defaults to
+	// DefaultTemplatePhrases when empty.
+	TemplatePhrases []string `yaml:"template_phrases,omitempty" json:"template_phrases,omitempty" validate:"omitempty,max=100,dive,required"`
+
+	// OnFailure selects what happens to a failing response: "reprompt"
+	// (the default) or "lower_confidence". Re-prompt calls are charged to
+	// the budget.
+	OnFailure string `yaml:"on_failure,omitempty" json:"on_failure,omitempty" validate:"omitempty,oneof=reprompt lower_confidence"`
+
+	// ConfidenceFactor multiplies the confidence of a response whose
+	// reasoning still fails after OnFailure is applied. The lowered
+	// confidence is subject to MinConfidence. Defaults to
+	// DefaultReasoningConfidenceFactor when zero.
+	ConfidenceFactor float64 `yaml:"confidence_factor,omitempty" json:"confidence_factor,omitempty" validate:"min=0,max=1"`
+}
+
+// DefaultTemplatePhrases returns the boilerplate reasoning rejected when no
+// template phrases are configured.
+func DefaultTemplatePhrases() []string {
+	return []string{
+		"good answer", "great answer", "good", "correct", "incorrect",
+		"the answer is correct", "the answer is incorrect", "looks good",
+		"no issues", "well done", "see above", "as above", "n/a",
+	}
+}
+
+// minWords returns the word minimum, applying the default.
+func (c *ReasoningQualityConfig) minWords() int {
+	if c.MinWords == 0 {
+		return DefaultReasoningMinWords
+	}
+	return c.MinWords
+}
+
+// onFailure returns the failure action, applying the default.
+func (c *ReasoningQualityConfig) onFailure() string {
+	if c.OnFailure == "" {
+		return ReasoningActionReprompt
+	}
+	return c.OnFailure
+}
+
+// confidenceFactor returns the confidence multiplier, applying the default.
+func (c *ReasoningQualityConfig) confidenceFactor() float64 {
+	if c.ConfidenceFactor == 0 {
+		return DefaultReasoningConfidenceFactor
+	}
+	return c.ConfidenceFactor
+}
+
+// templatePhrases returns the configured phrases, or the defaults when
+// none are configured.
+func (c *ReasoningQualityConfig) templatePhrases() []string {
+	if len(c.TemplatePhrases) > 0 {
+		return c.TemplatePhrases
+	}
+	return DefaultTemplatePhrases()
+}
+
+// check returns why reasoning about answer fails the gate, or an empty
+// string when it passes.
+func (c *ReasoningQualityConfig) check(reasoning, answer string) string {
+	if words := len(strings.Fields(reasoning)); words < c.minWords() {
+		return fmt.Sprintf("reasoning has %d words, fewer than %d", words, c.minWords())
+	}
+
+	phrase := templateKey(reasoning)
+	if slices.ContainsFunc(c.templatePhrases(), func(p string) bool { return templateKey(p) == phrase }) {
+		return fmt.Sprintf("reasoning is the template phrase %q", phrase)
+	}
+
+	if c.MustCiteAnswer && !sharesContentWord(reasoning, answer) {
+		return "reasoning does not refer to the answer"
+	}
+	return ""
+}
+
+// templateKey folds s, collapses its whitespace, and trims surrounding
+// punctuation so template phrases match regardless of formatting.
+func templateKey(s string) string {
+	s = strings.Join(strings.Fields(textnorm.Fold(s)), " ")
+	return strings.TrimFunc(s, func(r rune) bool { return unicode.IsPunct(r) || unicode.IsSpace(r) })
+}
+
+// citationStopwords excludes function words from the answer citation check.
+var citationStopwords = textnorm.New(textnorm.Policy{RemoveStopwords: true})
+
+// sharesContentWord reports whether reasoning contains any non-stopword
+// word of answer. An answer without such words cannot be cited and passes.
+func sharesContentWord(reasoning, answer string) bool {
+	answerWords := contentWords(answer)
+	if len(answerWords) == 0 {
+		return true
+	}
+	for word := range contentWords(reasoning) {
+		if _, ok := answerWords[word]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// contentWords returns the set of folded letter and digit runs in s that
+// are not stopwords.
+func contentWords(s string) map[string]struct{} {
+	fields := strings.FieldsFunc(textnorm.Fold(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	words := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		if !citationStopwords.IsStopword(field) {
+			words[field] = struct{}{}
+		}
+	}
+	return words
+}
+
+// reasoningGateOutcome records how one judge response fared at the gate.
+type reasoningGateOutcome struct {
+	// failure is why the first response failed; empty when it passed.
+	failure string
+	// reprompted reports whether the judge was asked again.
+	reprompted bool
+	// lowered reports whether the final response's confidence was lowered.
+	lowered bool
+	// tokens and calls are the usage of the re-prompt.
+	tokens, calls int
+}
+
+// gateReasoning applies the reasoning quality gate to summary, the judge's
+// response for answer i, re-prompting or lowering confidence as configured.
+func (sju *ScoreJudgeUnit) gateReasoning(
+	ctx context.Context,
+	summary domain.JudgeSummary,
+	prompt string,
+	options map[string]any,
+	judgeID string,
+	i int,
+	answer string,
+) (domain.JudgeSummary, reasoningGateOutcome, error) {
+	gate := sju.config.RequireReasoningQuality
+	var outcome reasoningGateOutcome
+	outcome.failure = gate.check(summary.Reasoning, answer)
+	if outcome.failure == "" {
+		return summary, outcome, nil
+	}
+
+	failure := outcome.failure
+	if gate.onFailure() == ReasoningActionReprompt {
+		reprompt := fmt.Sprintf("%s\n\nA previous response was rejected because its %s. "+
+			"Explain your score in at least %d words, referring to specific content in the answer.",
+			prompt, failure, gate.minWords())
+		retried, tokens, calls, err := sju.scoreAnswer(ctx, reprompt, options, judgeID+"_reprompt", i, len(answer))
+		if err != nil {
+			return domain.JudgeSummary{}, outcome, err
+		}
+		outcome.reprompted = true
+		outcome.tokens, outcome.calls = tokens, calls
+		summary = retried
+		failure = gate.check(summary.Reasoning, answer)
+	}
+
+	if failure != "" {
+		summary.Confidence *= gate.confidenceFactor()
+		outcome.lowered = true
+	}
+	return summary, outcome, nil
+}
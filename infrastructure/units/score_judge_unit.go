@@ -1,14 +1,15 @@
 package units
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
-	"text/template"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -35,6 +36,15 @@ const (
 	DefaultJudgeMaxConcurrency = 5   // Default number of concurrent LLM calls for scoring
 	DefaultJudgeMaxTokens      = 256 // Default maximum tokens for judge reasoning
 	DefaultJudgeTemperature    = 0.0 // Default temperature for consistent scoring
+
+	// DefaultSelfConsistencyTemperature is the sampling temperature used
+	// for self-consistency when none is configured.
+	DefaultSelfConsistencyTemperature = 0.7
+
+	// Labels heading the question and answer blocks of judge prompts when
+	// none are configured.
+	DefaultQuestionLabel = "Question"
+	DefaultAnswerLabel   = "Answer"
 )
 
 // ScoreJudgeUnit scores candidate answers using LLM evaluation.
@@ -50,8 +60,8 @@ type ScoreJudgeUnit struct {
 	llmClient ports.LLMClient
 	// validator ensures configuration parameter validation.
 	validator *validator.Validate
-	// promptTemplate is the compiled template for safe prompt generation.
-	promptTemplate *template.Template
+	// promptRenderer is the compiled prompt template for safe prompt generation.
+	promptRenderer PromptRenderer
 	// tracer is the OpenTelemetry tracer for observability.
 	tracer trace.Tracer
 }
@@ -60,14 +70,25 @@ type ScoreJudgeUnit struct {
 // All fields undergo validation during unit creation.
 type ScoreJudgeConfig struct {
 	// JudgePrompt is the Go template used to score answers.
-	// Should use {{.Question}} and {{.Answer}} placeholders for safe substitution.
-	// Example: "Rate this answer to '{{.Question}}': {{.Answer}}"
+	// {{.QuestionBlock}} and {{.AnswerBlock}} render the labeled question
+	// and answer wrapped in PromptQuestionTag and PromptAnswerTag tags, which
+	// mark where each begins and ends regardless of the content. Only these
+	// blocks are delimited: the raw {{.Question}} and {{.Answer}} fields are
+	// substituted verbatim, for templates that frame the content themselves.
+	// Example: "Rate the answer.\n\n{{.QuestionBlock}}\n\n{{.AnswerBlock}}"
 	JudgePrompt string `yaml:"judge_prompt" json:"judge_prompt" validate:"required,min=20"`
 
 	// ScoreScale defines the scoring range (e.g., "1-10" or "0.0-1.0").
 	// Used to normalize scores and validate LLM responses.
 	ScoreScale string `yaml:"score_scale" json:"score_scale" validate:"required"`
 
+	// Anchors describe what scores on ScoreScale mean, e.g. what a 1, a 5,
+	// and a 10 look like. They are listed in the prompt after any criteria,
+	// in score order, so judges interpret the scale the same way across runs
+	// and models. Each key must lie within ScoreScale; descriptions are
+	// flattened onto a single line.
+	Anchors map[float64]string `yaml:"anchors,omitempty" json:"anchors,omitempty" validate:"omitempty,max=20,dive,required,max=200"`
+
 	// Temperature controls randomness in LLM scoring (0.0-1.0).
 	// Lower values produce more consistent scoring.
 	Temperature float64 `yaml:"temperature" json:"temperature" validate:"min=0.0,max=1.0"`
@@ -84,6 +105,153 @@ type ScoreJudgeConfig struct {
 	// Prevents overwhelming the LLM service with too many simultaneous requests.
 	// Defaults to 5 if not specified.
 	MaxConcurrency int `yaml:"max_concurrency" json:"max_concurrency" validate:"min=1,max=20"`
+
+	// RequestTimeout bounds each individual scoring call to the LLM.
+	// It is independent of the execution context's deadline, allowing a tight
+	// per-call limit within a more generous overall budget. Zero disables it.
+	RequestTimeout time.Duration `yaml:"request_timeout,omitempty" json:"request_timeout,omitempty" validate:"omitempty,min=100ms,max=300s"`
+
+	// TemplateEngine selects the registered PromptRenderer used for JudgePrompt.
+	// Defaults to the Go text/template engine when empty.
+	TemplateEngine string `yaml:"template_engine,omitempty" json:"template_engine,omitempty"`
+
+	// MetadataFields lists answer metadata keys exposed to JudgePrompt as
+	// {{.Metadata.<key>}}. Values are sanitized to a single bounded line.
+	// No metadata is exposed when empty, keeping judges blind to it.
+	MetadataFields []string `yaml:"metadata_fields,omitempty" json:"metadata_fields,omitempty" validate:"omitempty,max=20,dive,required"`
+
+	// QuestionLabel heads {{.QuestionBlock}} in JudgePrompt. Defaults to
+	// DefaultQuestionLabel when empty.
+	QuestionLabel string `yaml:"question_label,omitempty" json:"question_label,omitempty" validate:"omitempty,max=64"`
+
+	// AnswerLabel heads {{.AnswerBlock}} in JudgePrompt. Defaults to
+	// DefaultAnswerLabel when empty.
+	AnswerLabel string `yaml:"answer_label,omitempty" json:"answer_label,omitempty" validate:"omitempty,max=64"`
+
+	// IncludeImages attaches each answer's images to its scoring calls as
+	// the "images" option so a multimodal model can judge them with the
+	// text. Images are validated before any LLM call. The client must
+	// support images, as the OpenAI, Anthropic, and Google providers do;
+	// when false, images are ignored.
+	IncludeImages bool `yaml:"include_images,omitempty" json:"include_images,omitempty"`
+
+	// EmptyAnswerPolicy controls how empty or whitespace-only answers are
+	// handled: "skip" (the default) scores them without an LLM call, "send"
+	// evaluates them normally, and "reject" fails with ErrEmptyAnswer.
+	EmptyAnswerPolicy EmptyAnswerPolicy `yaml:"empty_answer_policy,omitempty" json:"empty_answer_policy,omitempty" validate:"omitempty,oneof=skip send reject"`
+
+	// EmptyAnswerScore is the score assigned to skipped empty answers.
+	// Must lie within ScoreScale; defaults to the scale minimum when unset.
+	EmptyAnswerScore *float64 `yaml:"empty_answer_score,omitempty" json:"empty_answer_score,omitempty"`
+
+	// Criteria lists IDs of rubric criteria, read from domain.KeyRubric at
+	// execution, that are appended to the prompt. Execution fails if the
+	// rubric is missing or does not define every referenced criterion.
+	Criteria []string `yaml:"criteria,omitempty" json:"criteria,omitempty" validate:"omitempty,max=50,dive,required"`
+
+	// StopSequences are passed to the provider as the "stop" option so that
+	// generation ends before chatty models append text after the JSON.
+	// Providers without stop-sequence support ignore them.
+	StopSequences []string `yaml:"stop_sequences,omitempty" json:"stop_sequences,omitempty" validate:"omitempty,max=4,dive,required"`
+
+	// Deterministic asks the provider for the most reproducible output it
+	// supports. The provider layer translates it into provider-specific
+	// options, such as temperature 0 with a fixed seed or top_k=1, and
+	// overrides Temperature. It cannot be combined with SelfConsistency,
+	// which depends on varied samples.
+	Deterministic bool `yaml:"deterministic,omitempty" json:"deterministic,omitempty" validate:"excluded_with=SelfConsistency"`
+
+	// PostProcessors names registered ScorePostProcessors applied, in
+	// order, to every parsed summary after any global post-processors. See
+	// RegisterScorePostProcessor.
+	PostProcessors []string `yaml:"post_processors,omitempty" json:"post_processors,omitempty" validate:"omitempty,max=16,dive,required"`
+
+	// StrictJSON rejects responses that contain anything other than a
+	// closing code fence after the JSON object instead of ignoring it.
+	StrictJSON bool `yaml:"strict_json,omitempty" json:"strict_json,omitempty"`
+
+	// JSONSelection chooses which JSON object to parse when a response
+	// contains several, such as a reasoning model that emits a draft
+	// before its final answer: "first" (the default), "last", or
+	// "largest". See JSONSelection.
+	JSONSelection JSONSelection `yaml:"json_selection,omitempty" json:"json_selection,omitempty" validate:"omitempty,oneof=first last largest"`
+
+	// PromptBudget is the fraction of the model's context window that a
+	// rendered prompt plus MaxTokens may occupy. Prompts over budget are
+	// handled per OversizePolicy before any LLM call is made. Zero disables
+	// the pre-flight check.
+	PromptBudget float64 `yaml:"prompt_budget,omitempty" json:"prompt_budget,omitempty" validate:"omitempty,gt=0,max=1"`
+
+	// ContextWindow overrides the model's context window, in tokens, used
+	// by the PromptBudget check. When zero the window is looked up by model.
+	ContextWindow int `yaml:"context_window,omitempty" json:"context_window,omitempty" validate:"omitempty,min=1000"`
+
+	// OversizePolicy selects how over-budget prompts are handled:
+	// "truncate" (the default, as for VerificationUnit) shortens the answer
+	// until the prompt fits, "error" fails with ErrPromptTooLarge, and
+	// "skip_answer" assigns the answer its empty answer score with zero
+	// confidence without calling the LLM.
+	OversizePolicy OversizePolicy `yaml:"oversize_policy,omitempty" json:"oversize_policy,omitempty" validate:"omitempty,oneof=error truncate skip_answer"`
+
+	// Mode selects how answers are scored: "score" (the default) asks for a
+	// single holistic score, and "quality" asks for a score per quality
+	// dimension without a reference answer and aggregates them into the
+	// answer's score. See ScoreJudgeModeQuality.
+	Mode string `yaml:"mode,omitempty" json:"mode,omitempty" validate:"omitempty,oneof=score quality"`
+
+	// Dimensions lists the quality dimensions scored in quality mode.
+	// Defaults to DefaultQualityDimensions when empty; setting it in any
+	// other mode is an error.
+	Dimensions []QualityDimension `yaml:"dimensions,omitempty" json:"dimensions,omitempty" validate:"omitempty,max=10,dive"`
+
+	// MinAnswers is the fewest answers the unit accepts. Execution fails
+	// with ErrTooFewAnswers before any LLM call when fewer are present.
+	// Zero disables the check.
+	MinAnswers int `yaml:"min_answers,omitempty" json:"min_answers,omitempty" validate:"min=0,max=10000"`
+
+	// OnNoAnswers selects what happens when the item has no answers:
+	// "error" (the default) fails, and "abstain" records a verdict with
+	// status domain.VerdictNoAnswers so batch processing continues. It
+	// takes precedence over MinAnswers for items with no answers.
+	OnNoAnswers NoAnswersPolicy `yaml:"on_no_answers,omitempty" json:"on_no_answers,omitempty" validate:"omitempty,oneof=error abstain"`
+
+	// SelfConsistency, when set, scores each answer from several sampled
+	// completions instead of one. MinConfidence applies to the combined
+	// confidence rather than to individual samples.
+	SelfConsistency *SelfConsistencyConfig `yaml:"self_consistency,omitempty" json:"self_consistency,omitempty"`
+
+	// ConsistencyCheck, when set, flags pairs of near-identical answers
+	// whose scores diverge after scoring and optionally scores them again.
+	// See ConsistencyCheckConfig.
+	ConsistencyCheck *ConsistencyCheckConfig `yaml:"consistency_check,omitempty" json:"consistency_check,omitempty"`
+
+	// RequireReasoningQuality, when set, checks the reasoning of every
+	// scoring response, including each self-consistency sample, and
+	// re-prompts or lowers confidence when it is not substantive. Rescores
+	// from ConsistencyCheck are not gated. See ReasoningQualityConfig.
+	RequireReasoningQuality *ReasoningQualityConfig `yaml:"require_reasoning_quality,omitempty" json:"require_reasoning_quality,omitempty"`
+}
+
+// SelfConsistencyConfig configures sampling the judge several times per
+// answer. Each answer's score is the median of its sampled scores, its
+// confidence the mean of their confidences, and JudgeSummary.Spread records
+// how far the samples disagreed.
+type SelfConsistencyConfig struct {
+	// Samples is the number of completions requested per answer.
+	Samples int `yaml:"samples" json:"samples" validate:"required,min=2,max=15"`
+
+	// Temperature replaces ScoreJudgeConfig.Temperature for sampled calls,
+	// since identical low-temperature samples add cost without information.
+	// Defaults to DefaultSelfConsistencyTemperature when zero.
+	Temperature float64 `yaml:"temperature,omitempty" json:"temperature,omitempty" validate:"min=0.0,max=1.0"`
+}
+
+// temperature returns the sampling temperature, applying the default.
+func (c *SelfConsistencyConfig) temperature() float64 {
+	if c.Temperature == 0 {
+		return DefaultSelfConsistencyTemperature
+	}
+	return c.Temperature
 }
 
 // ScoreScale represents a validated scoring range.
@@ -195,7 +363,16 @@ type LLMJudgeResponse struct {
 	// Reasoning provides the detailed explanation for the score.
 	Reasoning string `json:"reasoning" validate:"required,min=10"`
 
-	// Version allows for future schema evolution.
+	// Dimensions holds per-dimension scores in quality mode, where they
+	// replace Score.
+	Dimensions map[string]float64 `json:"dimensions,omitempty"`
+
+	// SubScores holds the sub-scores reported by version 2 responses. In
+	// score mode they are recorded on the summary's Dimensions.
+	SubScores map[string]float64 `json:"-"`
+
+	// Version is the response schema version. Responses are decoded by the
+	// decoder registered for it; a missing version means version 1.
 	Version int `json:"version,omitempty"`
 }
 
@@ -203,7 +380,7 @@ type LLMJudgeResponse struct {
 // Ensures consistent behavior when configuration values are missing.
 func defaultScoreJudgeConfig() ScoreJudgeConfig {
 	return ScoreJudgeConfig{
-		JudgePrompt:    "Please score the following answer to the question on a scale from 1 to 10:\n\nQuestion: {{.Question}}\nAnswer: {{.Answer}}\n\nConsider accuracy, completeness, and clarity in your scoring.",
+		JudgePrompt:    "Please score the following answer to the question on a scale from 1 to 10:\n\n{{.QuestionBlock}}\n\n{{.AnswerBlock}}\n\nConsider accuracy, completeness, and clarity in your scoring.",
 		ScoreScale:     "1-10",
 		Temperature:    DefaultJudgeTemperature,
 		MaxTokens:      DefaultJudgeMaxTokens,
@@ -213,19 +390,34 @@ func defaultScoreJudgeConfig() ScoreJudgeConfig {
 }
 
 // validateConfig validates ScoreJudgeConfig using struct validation.
-// Centralizes validation logic to avoid duplication.
+// Centralizes validation logic to avoid duplication. Callers wrap the
+// result in a *domain.ConfigValidationError.
 func validateConfig(v *validator.Validate, config ScoreJudgeConfig) error {
 	if err := v.Struct(config); err != nil {
-		return fmt.Errorf("configuration validation failed: %w", err)
+		return fieldValidationError(err)
 	}
 
 	// Validate score scale format using the value object
-	_, err := ParseScoreScale(config.ScoreScale)
+	scale, err := ParseScoreScale(config.ScoreScale)
 	if err != nil {
 		return fmt.Errorf("invalid score scale: %w", err)
 	}
 
-	return nil
+	if config.EmptyAnswerScore != nil && !scale.Contains(*config.EmptyAnswerScore) {
+		return fmt.Errorf("empty answer score %.2f outside score scale %s", *config.EmptyAnswerScore, scale)
+	}
+
+	for point := range config.Anchors {
+		if !scale.Contains(point) {
+			return fmt.Errorf("anchor %g outside score scale %s", point, scale)
+		}
+	}
+
+	if _, err := resolveScorePostProcessors(config.PostProcessors, config.Deterministic); err != nil {
+		return err
+	}
+
+	return validateQualityConfig(config)
 }
 
 // NewScoreJudgeUnit creates a ScoreJudgeUnit with validated configuration.
@@ -239,13 +431,13 @@ func NewScoreJudgeUnit(name string, llmClient ports.LLMClient, config ScoreJudge
 		return nil, fmt.Errorf("LLM client cannot be nil")
 	}
 
-	v := validator.New()
+	v := newConfigValidator()
 	if err := validateConfig(v, config); err != nil {
-		return nil, err
+		return nil, domain.NewConfigValidationError(name, err)
 	}
 
 	// Compile the prompt template with custom functions to prevent injection attacks.
-	tmpl, err := template.New("judgePrompt").Funcs(GetTemplateFuncMap()).Parse(config.JudgePrompt)
+	renderer, err := newPromptRenderer(config.TemplateEngine, "judgePrompt", config.JudgePrompt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse judge prompt template: %w", err)
 	}
@@ -255,7 +447,7 @@ func NewScoreJudgeUnit(name string, llmClient ports.LLMClient, config ScoreJudge
 		config:         config,
 		llmClient:      llmClient,
 		validator:      v,
-		promptTemplate: tmpl,
+		promptRenderer: renderer,
 		tracer:         otel.Tracer("score-judge-unit"),
 	}, nil
 }
@@ -267,7 +459,10 @@ func (sju *ScoreJudgeUnit) Name() string { return sju.name }
 //
 // Reads question from KeyQuestion and answers from KeyAnswers,
 // scores each answer concurrently with configured limits,
-// and stores JudgeSummary results in KeyJudgeScores.
+// and stores JudgeSummary results in KeyJudgeScores. With SelfConsistency
+// configured, each answer is sampled several times and the samples are
+// combined. Every call, including samples and the re-prompts made by
+// RequireReasoningQuality, is charged to the budget.
 //
 // Returns error if question/answers missing, LLM calls fail,
 // confidence below threshold, or context cancellation occurs.
@@ -277,6 +472,7 @@ func (sju *ScoreJudgeUnit) Execute(ctx context.Context, state domain.State) (dom
 			attribute.String("unit.type", "score_judge"),
 			attribute.String("unit.id", sju.name),
 			attribute.String("config.score_scale", sju.config.ScoreScale),
+			attribute.String("config.mode", sju.mode()),
 			attribute.Float64("config.temperature", sju.config.Temperature),
 			attribute.Int("config.max_tokens", sju.config.MaxTokens),
 			attribute.Float64("config.min_confidence", sju.config.MinConfidence),
@@ -287,16 +483,19 @@ func (sju *ScoreJudgeUnit) Execute(ctx context.Context, state domain.State) (dom
 
 	start := time.Now()
 
-	question, ok := domain.Get(state, domain.KeyQuestion)
-	if !ok {
-		err := fmt.Errorf("unit %s: question not found in state", sju.name)
+	question, err := domain.Require(state, domain.KeyQuestion, sju.name)
+	if err != nil {
 		span.RecordError(err)
 		return state, err
 	}
 
-	answers, ok := domain.Get(state, domain.KeyAnswers)
-	if !ok {
-		err := fmt.Errorf("unit %s: answers not found in state", sju.name)
+	if sju.config.OnNoAnswers == NoAnswersAbstain && hasNoAnswers(state) {
+		span.SetAttributes(attribute.Bool("eval.no_answers", true))
+		return withNoAnswersVerdict(state, sju.name), nil
+	}
+
+	answers, err := domain.Require(state, domain.KeyAnswers, sju.name)
+	if err != nil {
 		span.RecordError(err)
 		return state, err
 	}
@@ -307,9 +506,124 @@ func (sju *ScoreJudgeUnit) Execute(ctx context.Context, state domain.State) (dom
 		return state, err
 	}
 
-	// Score each answer concurrently for better performance.
+	if err := checkMinAnswers(sju.name, len(answers), sju.config.MinAnswers); err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	criteria, err := resolveCriteria(state, sju.config.Criteria)
+	if err != nil {
+		err := fmt.Errorf("unit %s: %w", sju.name, err)
+		span.RecordError(err)
+		return state, err
+	}
+	criteriaSection := describeCriteria(criteria)
+
+	// Resolve empty answers up front so a rejection happens before any
+	// LLM call is made and skipped answers never reach the provider.
 	judgeSummaries := make([]domain.JudgeSummary, len(answers))
-	var mu sync.Mutex // Protect judgeSummaries slice from concurrent writes
+	skipped := make([]bool, len(answers))
+	skippedCount := 0
+	for i, answer := range answers {
+		if !isBlankAnswer(answer.Content) {
+			continue
+		}
+		switch sju.config.EmptyAnswerPolicy {
+		case EmptyAnswerSend:
+		case EmptyAnswerReject:
+			err := fmt.Errorf("unit %s: answer %d (%s): %w", sju.name, i+1, answer.ID, ErrEmptyAnswer)
+			span.RecordError(err)
+			return state, err
+		default:
+			judgeSummaries[i] = emptyAnswerSummary(sju.emptyAnswerScore())
+			skipped[i] = true
+			skippedCount++
+		}
+	}
+
+	if sju.config.IncludeImages {
+		for i, answer := range answers {
+			for _, img := range answer.Images {
+				if err := img.Validate(); err != nil {
+					err := fmt.Errorf("unit %s: answer %d (%s): %w", sju.name, i+1, answer.ID, err)
+					span.RecordError(err)
+					return state, err
+				}
+			}
+		}
+	}
+
+	model, overridden := domain.ModelOverride(state, sju.name)
+
+	// Render every prompt before any LLM call so a template failure or an
+	// oversized prompt never leaves calls in flight.
+	promptLimit := sju.promptTokenLimit(state)
+	prompts := make([]string, len(answers))
+	truncatedCount, oversizeSkipped := 0, 0
+	for i, answer := range answers {
+		if skipped[i] {
+			continue
+		}
+		metadata := selectAnswerMetadata(answer.Metadata, sju.config.MetadataFields)
+		prompt, oversized, err := sju.buildPrompt(question, answer.Content, metadata, criteriaSection, promptLimit)
+		if err != nil {
+			err := fmt.Errorf("unit %s: answer %d: %w", sju.name, i+1, err)
+			span.RecordError(err)
+			return state, err
+		}
+		if oversized {
+			span.AddEvent("prompt.oversize", trace.WithAttributes(
+				attribute.Int("answer.index", i),
+				attribute.String("answer.id", answer.ID),
+				attribute.String("oversize.action", string(sju.oversizePolicy())),
+			))
+			if sju.config.OversizePolicy == OversizeSkipAnswer {
+				judgeSummaries[i] = oversizeSkipSummary(sju.emptyAnswerScore())
+				skipped[i] = true
+				oversizeSkipped++
+				continue
+			}
+			truncatedCount++
+		}
+		prompts[i] = prompt
+	}
+
+	// Prepare LLM options with JSON response format if supported.
+	options := map[string]any{
+		"temperature": sju.config.Temperature,
+		"max_tokens":  sju.config.MaxTokens,
+	}
+	if sju.config.RequestTimeout > 0 {
+		options["timeout"] = sju.config.RequestTimeout
+	}
+	if overridden {
+		options["model"] = model
+	}
+	if len(sju.config.StopSequences) > 0 {
+		options["stop"] = sju.config.StopSequences
+	}
+	if sju.config.Deterministic {
+		options["deterministic"] = true
+	}
+
+	// Request JSON output format if the provider supports it.
+	// Structured output reduces parsing errors and improves reliability.
+	if supportsJSONMode(sju.llmClient) {
+		options["response_format"] = map[string]string{"type": "json_object"}
+	}
+
+	samples := 1
+	if sc := sju.config.SelfConsistency; sc != nil {
+		samples = sc.Samples
+		options["temperature"] = sc.temperature()
+	}
+
+	// Score each answer concurrently for better performance. With
+	// self-consistency every sample is an independent call.
+	var mu sync.Mutex // Protect sampled and usage totals from concurrent writes
+	sampled := make([][]domain.JudgeSummary, len(answers))
+	gateOutcomes := make([][]reasoningGateOutcome, len(answers))
+	var tokensUsed, callsMade int
 
 	g, gctx := errgroup.WithContext(ctx)
 
@@ -321,84 +635,528 @@ func (sju *ScoreJudgeUnit) Execute(ctx context.Context, state domain.State) (dom
 	}
 	g.SetLimit(maxConcurrency)
 
+	imagesIncluded := 0
 	for i, answer := range answers {
+		if skipped[i] {
+			continue
+		}
 		answerContent := answer.Content
+		prompt := prompts[i]
+		options := sju.answerOptions(options, answer)
+		if sju.config.IncludeImages {
+			imagesIncluded += len(answer.Images)
+		}
+		sampled[i] = make([]domain.JudgeSummary, samples)
+		gateOutcomes[i] = make([]reasoningGateOutcome, samples)
 
-		g.Go(func() error {
-			// Create scoring prompt with question and answer using template for safe generation.
-			var promptBuf bytes.Buffer
-			templateData := struct {
-				Question string
-				Answer   string
-			}{
-				Question: question,
-				Answer:   answerContent,
+		for n := range samples {
+			judgeID := fmt.Sprintf("%s_judge_%d", sju.name, i+1)
+			if samples > 1 {
+				judgeID = fmt.Sprintf("%s_sample_%d", judgeID, n+1)
 			}
-			if err := sju.promptTemplate.Execute(&promptBuf, templateData); err != nil {
-				return fmt.Errorf("unit %s: failed to execute prompt template for answer %d: %w",
-					sju.name, i+1, err)
+
+			g.Go(func() error {
+				summary, tokens, calls, err := sju.scoreAnswer(gctx, prompt, options, judgeID, i, len(answerContent))
+				if err != nil {
+					return err
+				}
+
+				var gate reasoningGateOutcome
+				if sju.config.RequireReasoningQuality != nil {
+					summary, gate, err = sju.gateReasoning(gctx, summary, prompt, options, judgeID, i, answerContent)
+					if err != nil {
+						return err
+					}
+				}
+
+				// Store the result in the correct position (thread-safe).
+				// Mutex ensures concurrent goroutines don't corrupt the slice.
+				mu.Lock()
+				sampled[i][n] = summary
+				gateOutcomes[i][n] = gate
+				tokensUsed += tokens + gate.tokens
+				callsMade += calls + gate.calls
+				mu.Unlock()
+
+				return nil
+			})
+		}
+	}
+
+	if err := g.Wait(); err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	for i := range answers {
+		if !skipped[i] {
+			judgeSummaries[i] = combineSamples(sampled[i])
+		}
+	}
+
+	var gateFailures, reprompts, lowered int
+	for i, outcomes := range gateOutcomes {
+		for n, outcome := range outcomes {
+			if outcome.failure == "" {
+				continue
 			}
-			basePrompt := promptBuf.String()
-			prompt := basePrompt + "\n\nIMPORTANT: You must respond with valid JSON in exactly this format:\n" +
-				`{"score": <number>, "confidence": <0.0-1.0>, "reasoning": "<detailed explanation>", "version": 1}`
-
-			// Prepare LLM options with JSON response format if supported.
-			options := map[string]any{
-				"temperature": sju.config.Temperature,
-				"max_tokens":  sju.config.MaxTokens,
+			gateFailures++
+			if outcome.reprompted {
+				reprompts++
 			}
-
-			// Request JSON output format if the provider supports it.
-			// Structured output reduces parsing errors and improves reliability.
-			if supportsJSONMode(sju.llmClient) {
-				options["response_format"] = map[string]string{"type": "json_object"}
+			if outcome.lowered {
+				lowered++
 			}
+			span.AddEvent("reasoning_quality.failed", trace.WithAttributes(
+				attribute.String("answer.id", answers[i].ID),
+				attribute.Int("sample", n+1),
+				attribute.String("reason", outcome.failure),
+				attribute.Bool("reprompted", outcome.reprompted),
+				attribute.Bool("confidence_lowered", outcome.lowered),
+			))
+		}
+	}
 
-			// Call LLM to score the answer.
-			response, err := sju.llmClient.Complete(gctx, prompt, options)
-			if err != nil {
-				return fmt.Errorf("unit %s: LLM call failed for answer %d (content length: %d chars): %w",
-					sju.name, i+1, len(answerContent), err)
-			}
+	var inconsistencies []scoreInconsistency
+	rescored := 0
+	if cc := sju.config.ConsistencyCheck; cc != nil {
+		scale, _ := ParseScoreScale(sju.config.ScoreScale)
+		inconsistencies = cc.findInconsistencies(answers, judgeSummaries, skipped, scale.Max-scale.Min)
+		for _, inc := range inconsistencies {
+			span.AddEvent("consistency.inconsistent_pair", trace.WithAttributes(
+				attribute.String("answer.first", answers[inc.first].ID),
+				attribute.String("answer.second", answers[inc.second].ID),
+				attribute.Float64("similarity", inc.similarity),
+				attribute.Float64("score_delta", inc.scoreDelta),
+			))
+		}
 
-			// Parse the LLM response to extract score, reasoning, and confidence.
-			summary, err := sju.parseLLMResponse(response, fmt.Sprintf("%s_judge_%d", sju.name, i+1))
+		if cc.Rescore && len(inconsistencies) > 0 {
+			tokens, calls, err := sju.rescore(ctx, answers, prompts, options, sampled, inconsistencies)
 			if err != nil {
-				return fmt.Errorf("unit %s: failed to parse LLM response for answer %d (response length: %d chars): %w",
-					sju.name, i+1, len(response), err)
+				span.RecordError(err)
+				return state, err
 			}
-
-			// Validate minimum confidence requirement.
-			if summary.Confidence < sju.config.MinConfidence {
-				return fmt.Errorf("unit %s: answer %d confidence %.3f below minimum %.3f (score: %.3f, reasoning length: %d)",
-					sju.name, i+1, summary.Confidence, sju.config.MinConfidence, summary.Score, len(summary.Reasoning))
+			for i := range answers {
+				if len(sampled[i]) > samples {
+					judgeSummaries[i] = combineSamples(sampled[i])
+					rescored++
+				}
 			}
-
-			// Store the result in the correct position (thread-safe).
-			// Mutex ensures concurrent goroutines don't corrupt the slice.
-			mu.Lock()
-			judgeSummaries[i] = summary
-			mu.Unlock()
-
-			return nil
-		})
+			tokensUsed += tokens
+			callsMade += calls
+		}
 	}
 
-	if err := g.Wait(); err != nil {
+	postProcessed, err := sju.postProcess(ctx, question, answers, judgeSummaries, skipped)
+	if err != nil {
 		span.RecordError(err)
 		return state, err
 	}
 
+	for i := range answers {
+		if skipped[i] {
+			continue
+		}
+		summary := judgeSummaries[i]
+
+		// Validate minimum confidence requirement.
+		if summary.Confidence < sju.config.MinConfidence {
+			err := fmt.Errorf("unit %s: answer %d confidence %.3f below minimum %.3f (score: %.3f, reasoning length: %d)",
+				sju.name, i+1, summary.Confidence, sju.config.MinConfidence, summary.Score, len(summary.Reasoning))
+			span.RecordError(err)
+			return state, err
+		}
+	}
+
 	latency := time.Since(start)
 	span.SetAttributes(
 		attribute.Int64("eval.latency_ms", latency.Milliseconds()),
 		attribute.Int("eval.answers_count", len(answers)),
 		attribute.Int("eval.question_length", len(question)),
 		attribute.Int("eval.judge_scores_count", len(judgeSummaries)),
+		attribute.Int("eval.empty_answers_skipped", skippedCount),
+		attribute.Int("eval.samples_per_answer", samples),
+		attribute.Int("eval.answers_truncated", truncatedCount),
+		attribute.Int("eval.answers_skipped_oversize", oversizeSkipped),
+		attribute.String("eval.oversize_action", oversizeAction(sju.oversizePolicy(), truncatedCount+oversizeSkipped)),
+		attribute.Int("eval.images_included", imagesIncluded),
+		attribute.StringSlice("eval.post_processors", postProcessed),
 		attribute.Bool("no_llm_cost", false), // LLM-based units have cost
 	)
+	if sju.config.ConsistencyCheck != nil {
+		span.SetAttributes(
+			attribute.Int("eval.inconsistent_pairs", len(inconsistencies)),
+			attribute.Int("eval.answers_rescored", rescored),
+		)
+	}
+	if sju.config.RequireReasoningQuality != nil {
+		span.SetAttributes(
+			attribute.Int("eval.reasoning_gate_failures", gateFailures),
+			attribute.Int("eval.reasoning_reprompts", reprompts),
+			attribute.Int("eval.reasoning_confidence_lowered", lowered),
+		)
+	}
+	if sju.mode() == ScoreJudgeModeQuality {
+		span.SetAttributes(attribute.StringSlice("eval.quality_dimensions", qualityTrace(sju.qualityDimensions(), judgeSummaries)))
+	}
+
+	newState := domain.WithJudgeScores(state, sju.name, judgeSummaries)
+	if callsMade > 0 {
+		newState = chargeBudget(newState, tokensUsed, callsMade)
+	}
+	if scale, err := ParseScoreScale(sju.config.ScoreScale); err == nil {
+		newState = domain.WithJudgeScoreScale(newState, sju.name, domain.ScoreRange{Min: scale.Min, Max: scale.Max})
+	}
+	return newState, nil
+}
+
+// postProcess applies the unit's score post-processors to the summaries of
+// judged answers in place and returns the names of those applied. Skipped
+// answers keep their placeholder summaries.
+func (sju *ScoreJudgeUnit) postProcess(
+	ctx context.Context,
+	question string,
+	answers []domain.Answer,
+	summaries []domain.JudgeSummary,
+	skipped []bool,
+) ([]string, error) {
+	processors, err := resolveScorePostProcessors(sju.config.PostProcessors, sju.config.Deterministic)
+	if err != nil {
+		return nil, fmt.Errorf("unit %s: %w", sju.name, err)
+	}
+	if len(processors) == 0 {
+		return nil, nil
+	}
 
-	return domain.With(state, domain.KeyJudgeScores, judgeSummaries), nil
+	names := make([]string, len(processors))
+	for k, p := range processors {
+		names[k] = p.name
+	}
+	for i, answer := range answers {
+		if skipped[i] {
+			continue
+		}
+		target := ScoreTarget{Unit: sju.name, Question: question, Answer: answer}
+		for _, p := range processors {
+			summary, err := p.processor.PostProcess(ctx, target, summaries[i])
+			if err != nil {
+				return nil, fmt.Errorf("unit %s: answer %d: post-processor %s: %w", sju.name, i+1, p.name, err)
+			}
+			// The range checks below pass NaN, so finiteness is checked first.
+			if err := checkFinite("score", summary.Score); err != nil {
+				return nil, fmt.Errorf("unit %s: answer %d: post-processor %s: %w", sju.name, i+1, p.name, err)
+			}
+			if err := checkFinite("confidence", summary.Confidence); err != nil {
+				return nil, fmt.Errorf("unit %s: answer %d: post-processor %s: %w", sju.name, i+1, p.name, err)
+			}
+			if err := sju.validateScoreInRange(summary.Score); err != nil {
+				return nil, fmt.Errorf("unit %s: answer %d: post-processor %s: %w", sju.name, i+1, p.name, err)
+			}
+			if summary.Confidence < 0 || summary.Confidence > 1 {
+				return nil, fmt.Errorf("unit %s: answer %d: post-processor %s: confidence %.3f outside [0, 1]",
+					sju.name, i+1, p.name, summary.Confidence)
+			}
+			summaries[i] = summary
+		}
+	}
+	return names, nil
+}
+
+// scoreAnswer makes one judge call for answer i and parses its response.
+// It returns the tokens and provider calls the judgment used so that every
+// call is charged to the budget.
+func (sju *ScoreJudgeUnit) scoreAnswer(
+	ctx context.Context,
+	prompt string,
+	options map[string]any,
+	judgeID string,
+	i, contentLength int,
+) (domain.JudgeSummary, int, int, error) {
+	response, tokensIn, tokensOut, calls, err := completeMetered(ctx, sju.llmClient, prompt, options)
+	if err != nil {
+		return domain.JudgeSummary{}, 0, 0, domain.NewLLMCallError(sju.name,
+			fmt.Sprintf("for answer %d (content length: %d chars)", i+1, contentLength), err)
+	}
+
+	// Parse the LLM response to extract score, reasoning, and confidence.
+	summary, err := sju.parseLLMResponse(response, judgeID)
+	if err != nil {
+		return domain.JudgeSummary{}, 0, 0, domain.NewResponseParseError(sju.name,
+			fmt.Sprintf("for answer %d (response length: %d chars)", i+1, len(response)), err)
+	}
+	return summary, tokensIn + tokensOut, calls, nil
+}
+
+// rescore scores every answer in an inconsistent pair once more, appending
+// the new judgment to its samples. Each answer is rescored once however
+// many pairs it appears in. It returns the tokens and calls used.
+func (sju *ScoreJudgeUnit) rescore(
+	ctx context.Context,
+	answers []domain.Answer,
+	prompts []string,
+	options map[string]any,
+	sampled [][]domain.JudgeSummary,
+	inconsistencies []scoreInconsistency,
+) (int, int, error) {
+	flagged := make(map[int]bool)
+	for _, inc := range inconsistencies {
+		flagged[inc.first] = true
+		flagged[inc.second] = true
+	}
+
+	var (
+		mu            sync.Mutex
+		tokens, calls int
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	maxConcurrency := sju.config.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultJudgeMaxConcurrency
+	}
+	g.SetLimit(maxConcurrency)
+	for _, i := range slices.Sorted(maps.Keys(flagged)) {
+		judgeID := fmt.Sprintf("%s_judge_%d_rescore", sju.name, i+1)
+		g.Go(func() error {
+			summary, used, made, err := sju.scoreAnswer(gctx, prompts[i], sju.answerOptions(options, answers[i]), judgeID, i, len(answers[i].Content))
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			sampled[i] = append(sampled[i], summary)
+			tokens += used
+			calls += made
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return 0, 0, err
+	}
+	return tokens, calls, nil
+}
+
+// answerOptions returns the LLM options for scoring answer: options
+// itself, or a copy carrying the answer's images when IncludeImages is set.
+func (sju *ScoreJudgeUnit) answerOptions(options map[string]any, answer domain.Answer) map[string]any {
+	if !sju.config.IncludeImages || len(answer.Images) == 0 {
+		return options
+	}
+	withImages := maps.Clone(options)
+	withImages["images"] = answer.Images
+	return withImages
+}
+
+// mode returns the scoring mode, applying the default.
+func (sju *ScoreJudgeUnit) mode() string {
+	if sju.config.Mode == "" {
+		return ScoreJudgeModeScore
+	}
+	return sju.config.Mode
+}
+
+// renderPrompt renders the judge prompt for one answer and appends the
+// rubric criteria, score anchors, and JSON response format instructions. In
+// quality mode the instructions ask for a score per quality dimension.
+func (sju *ScoreJudgeUnit) renderPrompt(question, answer string, metadata map[string]string, criteriaSection string) (string, error) {
+	// Create scoring prompt with question and answer using template for safe generation.
+	templateData := struct {
+		Question      string
+		Answer        string
+		QuestionBlock string
+		AnswerBlock   string
+		Metadata      map[string]string
+	}{
+		Question:      question,
+		Answer:        answer,
+		QuestionBlock: promptBlock(sju.questionLabel(), PromptQuestionTag, question),
+		AnswerBlock:   promptBlock(sju.answerLabel(), PromptAnswerTag, answer),
+		Metadata:      metadata,
+	}
+	basePrompt, err := sju.promptRenderer.Render(templateData)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute prompt template: %w", err)
+	}
+	basePrompt += criteriaSection + describeAnchors(sju.config.Anchors)
+	if sju.mode() == ScoreJudgeModeQuality {
+		return basePrompt + describeQualityDimensions(sju.qualityDimensions(), sju.config.ScoreScale), nil
+	}
+	return basePrompt + "\n\nIMPORTANT: You must respond with valid JSON in exactly this format:\n" +
+		`{"score": <number>, "confidence": <0.0-1.0>, "reasoning": "<detailed explanation>", "version": 1}`, nil
+}
+
+// describeAnchors formats score anchors as a prompt section, lowest score
+// first. It returns "" for no anchors.
+func describeAnchors(anchors map[float64]string) string {
+	if len(anchors) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n\nUse these anchors for what scores mean:")
+	for _, point := range slices.Sorted(maps.Keys(anchors)) {
+		fmt.Fprintf(&b, "\n- %g: %s", point, sanitizeMetadataValue(anchors[point]))
+	}
+	return b.String()
+}
+
+// questionLabel returns the label heading the question block, applying the
+// default.
+func (sju *ScoreJudgeUnit) questionLabel() string {
+	if sju.config.QuestionLabel == "" {
+		return DefaultQuestionLabel
+	}
+	return sju.config.QuestionLabel
+}
+
+// answerLabel returns the label heading the answer block, applying the
+// default.
+func (sju *ScoreJudgeUnit) answerLabel() string {
+	if sju.config.AnswerLabel == "" {
+		return DefaultAnswerLabel
+	}
+	return sju.config.AnswerLabel
+}
+
+// promptTokenLimit returns the maximum prompt size in tokens allowed by
+// PromptBudget, or zero when the pre-flight check is disabled. Space for
+// MaxTokens of output is reserved within the budget.
+func (sju *ScoreJudgeUnit) promptTokenLimit(state domain.State) int {
+	if sju.config.PromptBudget == 0 {
+		return 0
+	}
+	window := sju.config.ContextWindow
+	if window == 0 {
+		window = unitContextWindow(state, sju.name, sju.llmClient)
+	}
+	return max(int(float64(window)*sju.config.PromptBudget)-sju.config.MaxTokens, 1)
+}
+
+// oversizePolicy returns the configured oversize policy, applying the
+// default.
+func (sju *ScoreJudgeUnit) oversizePolicy() OversizePolicy {
+	return sju.config.OversizePolicy.orDefault()
+}
+
+// buildPrompt renders the prompt for one answer and, when limit is
+// positive, checks its estimated size against it. Oversized prompts fail
+// with ErrPromptTooLarge under OversizeError; otherwise oversized reports
+// that the policy applies. Under OversizeTruncate the prompt is re-rendered
+// with a shortened answer, and under OversizeSkipAnswer it is empty.
+func (sju *ScoreJudgeUnit) buildPrompt(
+	question, answer string,
+	metadata map[string]string,
+	criteriaSection string,
+	limit int,
+) (prompt string, oversized bool, err error) {
+	prompt, err = sju.renderPrompt(question, answer, metadata, criteriaSection)
+	if err != nil || limit == 0 {
+		return prompt, false, err
+	}
+
+	tokens, err := sju.llmClient.EstimateTokens(prompt)
+	if err != nil {
+		return "", false, fmt.Errorf("estimate prompt tokens: %w", err)
+	}
+	if tokens <= limit {
+		return prompt, false, nil
+	}
+	switch sju.oversizePolicy() {
+	case OversizeSkipAnswer:
+		return "", true, nil
+	case OversizeError:
+		return "", false, fmt.Errorf("%w: ~%d tokens, limit %d", ErrPromptTooLarge, tokens, limit)
+	}
+
+	// Scale the answer down by the share of the prompt it must give up,
+	// retrying with progressively shorter answers since token estimates
+	// are not linear in length.
+	answerRunes := []rune(answer)
+	keep := len(answerRunes)
+	for range 5 {
+		excess := float64(tokens-limit) / float64(tokens)
+		keep = int(float64(keep) * (1 - excess) * 0.95)
+		if keep <= 0 {
+			break
+		}
+		prompt, err = sju.renderPrompt(question, truncateContent(answer, keep), metadata, criteriaSection)
+		if err != nil {
+			return "", false, err
+		}
+		if tokens, err = sju.llmClient.EstimateTokens(prompt); err != nil {
+			return "", false, fmt.Errorf("estimate prompt tokens: %w", err)
+		}
+		if tokens <= limit {
+			return prompt, true, nil
+		}
+	}
+	return "", false, fmt.Errorf("%w: prompt does not fit limit %d even after truncating the answer",
+		ErrPromptTooLarge, limit)
+}
+
+// combineSamples merges sampled judgments of one answer into a single
+// summary: the median score, the mean confidence, and the reasoning and
+// dimension breakdown of the sample whose score is closest to the median.
+// A single sample is returned unchanged.
+func combineSamples(samples []domain.JudgeSummary) domain.JudgeSummary {
+	if len(samples) == 1 {
+		return samples[0]
+	}
+
+	scores := make([]float64, len(samples))
+	confidence := 0.0
+	for i, sample := range samples {
+		scores[i] = sample.Score
+		confidence += sample.Confidence
+	}
+	slices.Sort(scores)
+
+	mid := len(scores) / 2
+	median := scores[mid]
+	if len(scores)%2 == 0 {
+		median = (scores[mid-1] + scores[mid]) / 2
+	}
+
+	closest := 0
+	for i, sample := range samples {
+		if math.Abs(sample.Score-median) < math.Abs(samples[closest].Score-median) {
+			closest = i
+		}
+	}
+
+	return domain.JudgeSummary{
+		Reasoning:  samples[closest].Reasoning,
+		Confidence: confidence / float64(len(samples)),
+		Score:      median,
+		Samples:    len(samples),
+		Spread:     scores[len(scores)-1] - scores[0],
+		Dimensions: samples[closest].Dimensions,
+	}
+}
+
+// chargeBudget adds token and call usage to the state's budget counters
+// and, when present, its budget report.
+func chargeBudget(state domain.State, tokens, calls int) domain.State {
+	state = state.UpdateBudgetUsage(int64(tokens), int64(calls))
+	if report, ok := state.GetBudget(); ok && report != nil {
+		updated := *report
+		updated.TokensUsed += tokens
+		updated.CallsMade += calls
+		state = domain.With(state, domain.KeyBudget, &updated)
+	}
+	return state
+}
+
+// emptyAnswerScore returns the score assigned to skipped empty answers,
+// falling back to the minimum of the configured scale.
+func (sju *ScoreJudgeUnit) emptyAnswerScore() float64 {
+	if sju.config.EmptyAnswerScore != nil {
+		return *sju.config.EmptyAnswerScore
+	}
+	scale, err := ParseScoreScale(sju.config.ScoreScale)
+	if err != nil {
+		return 0
+	}
+	return scale.Min
 }
 
 // Validate checks unit readiness for execution.
@@ -411,7 +1169,7 @@ func (sju *ScoreJudgeUnit) Validate() error {
 
 	// Use centralized validation logic
 	if err := validateConfig(sju.validator, sju.config); err != nil {
-		return fmt.Errorf("unit %s: %w", sju.name, err)
+		return domain.NewConfigValidationError(sju.name, err)
 	}
 
 	// Verify LLM client is functional by checking model.
@@ -438,22 +1196,40 @@ func supportsJSONMode(client ports.LLMClient) bool {
 //
 // Handles various response formats including markdown code blocks and plain JSON.
 // Validates JSON structure, field constraints, and score range compliance.
+// In quality mode the score is aggregated from the dimension scores.
 // Returns JudgeSummary with validated score, confidence, and reasoning.
-// Returns error if JSON extraction fails, validation fails, or score out of range.
+// Returns error if JSON extraction fails, validation fails, or score out of range,
+// and an error wrapping ErrNonFiniteScore if a score or confidence is NaN or infinite.
 func (sju *ScoreJudgeUnit) parseLLMResponse(
 	response string,
 	judgeID string,
 ) (domain.JudgeSummary, error) {
-	jsonStr := extractJSON(response)
+	jsonStr, err := extractResponseJSON(response, sju.config.StrictJSON, sju.config.JSONSelection)
+	if err != nil {
+		return domain.JudgeSummary{}, fmt.Errorf("judge %s: %w", judgeID, err)
+	}
 	if jsonStr == "" {
 		return domain.JudgeSummary{}, fmt.Errorf("judge %s: no valid JSON found in LLM response (response length: %d chars)",
 			judgeID, len(response))
 	}
 
-	var llmResponse LLMJudgeResponse
-	if err := json.Unmarshal([]byte(jsonStr), &llmResponse); err != nil {
-		return domain.JudgeSummary{}, fmt.Errorf("judge %s: failed to parse JSON response (JSON length: %d chars): %w",
-			judgeID, len(jsonStr), err)
+	llmResponse, err := judgeResponseVersions.decode(jsonStr)
+	if err != nil {
+		return domain.JudgeSummary{}, fmt.Errorf("judge %s: %w", judgeID, err)
+	}
+
+	// Reject NaN and infinite values before range validation, which
+	// NaN passes because every comparison with it is false.
+	if err := checkFinite("score", llmResponse.Score); err != nil {
+		return domain.JudgeSummary{}, fmt.Errorf("judge %s: %w", judgeID, err)
+	}
+	if err := checkFinite("confidence", llmResponse.Confidence); err != nil {
+		return domain.JudgeSummary{}, fmt.Errorf("judge %s: %w", judgeID, err)
+	}
+	for _, name := range slices.Sorted(maps.Keys(llmResponse.Dimensions)) {
+		if err := checkFinite(fmt.Sprintf("dimension %q", name), llmResponse.Dimensions[name]); err != nil {
+			return domain.JudgeSummary{}, fmt.Errorf("judge %s: %w", judgeID, err)
+		}
 	}
 
 	if err := sju.validator.Struct(llmResponse); err != nil {
@@ -461,18 +1237,125 @@ func (sju *ScoreJudgeUnit) parseLLMResponse(
 			judgeID, llmResponse.Score, llmResponse.Confidence, err)
 	}
 
+	if sju.mode() == ScoreJudgeModeQuality {
+		score, breakdown, err := sju.aggregateDimensions(llmResponse.Dimensions)
+		if err != nil {
+			return domain.JudgeSummary{}, fmt.Errorf("judge %s: %w", judgeID, err)
+		}
+		return domain.JudgeSummary{
+			Reasoning:  llmResponse.Reasoning,
+			Confidence: llmResponse.Confidence,
+			Score:      score,
+			Dimensions: breakdown,
+		}, nil
+	}
+
 	if err := sju.validateScoreInRange(llmResponse.Score); err != nil {
 		return domain.JudgeSummary{}, fmt.Errorf("judge %s: score out of range (scale: %s): %w",
 			judgeID, sju.config.ScoreScale, err)
 	}
+	for name, score := range llmResponse.SubScores {
+		if err := sju.validateScoreInRange(score); err != nil {
+			return domain.JudgeSummary{}, fmt.Errorf("judge %s: sub-score %q out of range (scale: %s): %w",
+				judgeID, name, sju.config.ScoreScale, err)
+		}
+	}
 
 	return domain.JudgeSummary{
 		Reasoning:  llmResponse.Reasoning,
 		Confidence: llmResponse.Confidence,
 		Score:      llmResponse.Score,
+		Dimensions: llmResponse.SubScores,
 	}, nil
 }
 
+// extractResponseJSON extracts the JSON object chosen by selection from an
+// LLM response. In strict mode, any text after the object other than a
+// closing code fence fails with ErrTrailingContent rather than being
+// discarded.
+func extractResponseJSON(response string, strict bool, selection JSONSelection) (string, error) {
+	jsonStr, end := selectJSON(response, selection)
+	if !strict || jsonStr == "" {
+		return jsonStr, nil
+	}
+
+	trailing := strings.TrimSpace(response[end:])
+	trailing = strings.TrimSpace(strings.TrimPrefix(trailing, "```"))
+	if trailing != "" {
+		return "", fmt.Errorf("%w (%d chars)", ErrTrailingContent, len(trailing))
+	}
+	return jsonStr, nil
+}
+
+// selectJSON returns the JSON object chosen by selection and the offset in
+// response just past it. JSONSelectFirst, the default, uses extractJSON.
+// The other selections choose among the complete top-level objects that are
+// valid JSON, falling back to extractJSON when there are none.
+func selectJSON(response string, selection JSONSelection) (string, int) {
+	if selection == JSONSelectLast || selection == JSONSelectLargest {
+		var chosen [2]int
+		found := false
+		for _, span := range findJSONObjects(response) {
+			if !json.Valid([]byte(response[span[0]:span[1]])) {
+				continue
+			}
+			// Ties go to the later object, which is the final answer of a
+			// model that narrates before answering.
+			if !found || selection == JSONSelectLast || span[1]-span[0] >= chosen[1]-chosen[0] {
+				chosen = span
+				found = true
+			}
+		}
+		if found {
+			return response[chosen[0]:chosen[1]], chosen[1]
+		}
+	}
+
+	jsonStr := extractJSON(response)
+	return jsonStr, strings.Index(response, jsonStr) + len(jsonStr)
+}
+
+// findJSONObjects returns the start and end offsets of every balanced
+// top-level brace-delimited object in response, in order. Braces inside
+// JSON strings are ignored. Objects are not checked for validity.
+func findJSONObjects(response string) [][2]int {
+	var (
+		spans      [][2]int
+		depth      int
+		start      int
+		inString   bool
+		escapeNext bool
+	)
+	for i := 0; i < len(response); i++ {
+		char := response[i]
+		if depth == 0 {
+			if char == '{' {
+				start = i
+				depth = 1
+			}
+			continue
+		}
+
+		switch {
+		case escapeNext:
+			escapeNext = false
+		case char == '\\':
+			escapeNext = inString
+		case char == '"':
+			inString = !inString
+		case inString:
+		case char == '{':
+			depth++
+		case char == '}':
+			depth--
+			if depth == 0 {
+				spans = append(spans, [2]int{start, i + 1})
+			}
+		}
+	}
+	return spans
+}
+
 // extractJSON extracts JSON objects from LLM responses with surrounding text.
 //
 // Handles multiple formats:
@@ -602,11 +1485,11 @@ func (sju *ScoreJudgeUnit) UnmarshalParameters(params yaml.Node) (*ScoreJudgeUni
 
 	// Validate the decoded configuration using centralized logic.
 	if err := validateConfig(sju.validator, config); err != nil {
-		return nil, err
+		return nil, domain.NewConfigValidationError(sju.name, err)
 	}
 
 	// Compile the prompt template with custom functions to prevent injection attacks.
-	tmpl, err := template.New("judgePrompt").Funcs(GetTemplateFuncMap()).Parse(config.JudgePrompt)
+	renderer, err := newPromptRenderer(config.TemplateEngine, "judgePrompt", config.JudgePrompt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse judge prompt template: %w", err)
 	}
@@ -617,7 +1500,7 @@ func (sju *ScoreJudgeUnit) UnmarshalParameters(params yaml.Node) (*ScoreJudgeUni
 		config:         config,
 		llmClient:      sju.llmClient,
 		validator:      sju.validator,
-		promptTemplate: tmpl,
+		promptRenderer: renderer,
 		tracer:         otel.Tracer("score-judge-unit"),
 	}, nil
 }
@@ -0,0 +1,120 @@
+package units
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// ScoreTarget identifies the answer a judge summary scores, for
+// post-processors whose adjustments depend on it.
+type ScoreTarget struct {
+	// Unit is the ID of the judge unit that produced the summary.
+	Unit string
+	// Question is the question being evaluated.
+	Question string
+	// Answer is the scored answer.
+	Answer domain.Answer
+}
+
+// ScorePostProcessor adjusts a judge's summary of one answer after the unit
+// has parsed it, so teams can apply business rules or blocklists uniformly
+// without forking units. It may change the score, confidence, and
+// reasoning; the unit rejects summaries whose score leaves its scale or
+// whose confidence leaves [0, 1]. Implementations must be safe for
+// concurrent use.
+type ScorePostProcessor interface {
+	// PostProcess returns the adjusted summary. An error fails the unit.
+	PostProcess(ctx context.Context, target ScoreTarget, summary domain.JudgeSummary) (domain.JudgeSummary, error)
+}
+
+// ScorePostProcessorFunc adapts a function to the ScorePostProcessor
+// interface.
+type ScorePostProcessorFunc func(ctx context.Context, target ScoreTarget, summary domain.JudgeSummary) (domain.JudgeSummary, error)
+
+// PostProcess calls f.
+func (f ScorePostProcessorFunc) PostProcess(ctx context.Context, target ScoreTarget, summary domain.JudgeSummary) (domain.JudgeSummary, error) {
+	return f(ctx, target, summary)
+}
+
+// ScorePostProcessorOptions describe how a registered post-processor is
+// applied.
+type ScorePostProcessorOptions struct {
+	// Global applies the post-processor to every judge unit. Otherwise
+	// units apply it only when they list it in their post_processors
+	// setting.
+	Global bool
+
+	// Deterministic declares that the post-processor returns the same
+	// summary for the same input. Units configured as deterministic accept
+	// only deterministic post-processors.
+	Deterministic bool
+}
+
+// registeredScorePostProcessor is a post-processor with its registration.
+type registeredScorePostProcessor struct {
+	name      string
+	processor ScorePostProcessor
+	options   ScorePostProcessorOptions
+}
+
+var (
+	scorePostProcessorsMu sync.RWMutex
+	scorePostProcessors   = map[string]registeredScorePostProcessor{}
+)
+
+// RegisterScorePostProcessor makes processor available to judge units
+// under name. Registering a name twice replaces the previous processor.
+func RegisterScorePostProcessor(name string, processor ScorePostProcessor, options ScorePostProcessorOptions) {
+	scorePostProcessorsMu.Lock()
+	defer scorePostProcessorsMu.Unlock()
+	scorePostProcessors[name] = registeredScorePostProcessor{name: name, processor: processor, options: options}
+}
+
+// UnregisterScorePostProcessor removes the post-processor registered under
+// name, if any. Units that list it fail on their next execution.
+func UnregisterScorePostProcessor(name string) {
+	scorePostProcessorsMu.Lock()
+	defer scorePostProcessorsMu.Unlock()
+	delete(scorePostProcessors, name)
+}
+
+// resolveScorePostProcessors returns the post-processors a unit applies, in
+// order: the global ones sorted by name, then those named in names in the
+// order given. It returns an error if a name is not registered or if
+// deterministic is set and a post-processor is not deterministic.
+func resolveScorePostProcessors(names []string, deterministic bool) ([]registeredScorePostProcessor, error) {
+	scorePostProcessorsMu.RLock()
+	defer scorePostProcessorsMu.RUnlock()
+
+	var resolved []registeredScorePostProcessor
+	for _, p := range scorePostProcessors {
+		if p.options.Global && !slices.Contains(names, p.name) {
+			resolved = append(resolved, p)
+		}
+	}
+	slices.SortFunc(resolved, func(a, b registeredScorePostProcessor) int {
+		return cmp.Compare(a.name, b.name)
+	})
+
+	for _, name := range names {
+		p, ok := scorePostProcessors[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown score post-processor: %s", name)
+		}
+		resolved = append(resolved, p)
+	}
+
+	if deterministic {
+		for _, p := range resolved {
+			if !p.options.Deterministic {
+				return nil, fmt.Errorf("score post-processor %s is not deterministic", p.name)
+			}
+		}
+	}
+	return resolved, nil
+}
@@ -0,0 +1,143 @@
+package units
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/testutils"
+)
+
+// postProcessorJudge returns a 0.0-1.0 score judge listing names as its
+// post-processors, whose client scores every answer 0.8.
+func postProcessorJudge(t *testing.T, deterministic bool, names ...string) (*ScoreJudgeUnit, error) {
+	t.Helper()
+	client := testutils.NewMockLLMClient("test-model")
+	client.SetResponse(`{"score": 0.8, "confidence": 0.9, "reasoning": "Names the correct capital."}`)
+	config := defaultScoreJudgeConfig()
+	config.ScoreScale = "0.0-1.0"
+	config.Deterministic = deterministic
+	config.PostProcessors = names
+	return NewScoreJudgeUnit("judge", client, config)
+}
+
+// TestScoreJudgeUnit_PostProcessors tests that global post-processors run
+// for every judge before the unit's own, in order, on each parsed summary.
+func TestScoreJudgeUnit_PostProcessors(t *testing.T) {
+	blocklist := ScorePostProcessorFunc(func(ctx context.Context, target ScoreTarget, summary domain.JudgeSummary) (domain.JudgeSummary, error) {
+		if strings.Contains(target.Answer.Content, "Lyon") {
+			summary.Score = 0
+			summary.Reasoning += " [blocklisted]"
+		}
+		return summary, nil
+	})
+	halveConfidence := ScorePostProcessorFunc(func(ctx context.Context, target ScoreTarget, summary domain.JudgeSummary) (domain.JudgeSummary, error) {
+		summary.Confidence /= 2
+		summary.Reasoning += " [" + target.Unit + "]"
+		return summary, nil
+	})
+	RegisterScorePostProcessor("test_blocklist", blocklist, ScorePostProcessorOptions{Deterministic: true})
+	RegisterScorePostProcessor("test_halve", halveConfidence, ScorePostProcessorOptions{Global: true, Deterministic: true})
+	t.Cleanup(func() {
+		UnregisterScorePostProcessor("test_blocklist")
+		UnregisterScorePostProcessor("test_halve")
+	})
+
+	state := testutils.EvaluationState(t, "What is the capital of France?", []domain.Answer{
+		{ID: "a1", Content: "Paris"},
+		{ID: "a2", Content: "Lyon"},
+	})
+
+	unit, err := postProcessorJudge(t, true, "test_blocklist")
+	require.NoError(t, err)
+	result, err := unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+
+	scores, _ := result.GetJudgeScores()
+	require.Len(t, scores, 2)
+	assert.InDelta(t, 0.8, scores[0].Score, 1e-9)
+	assert.InDelta(t, 0.45, scores[0].Confidence, 1e-9)
+	assert.Equal(t, "Names the correct capital. [judge]", scores[0].Reasoning)
+	assert.Zero(t, scores[1].Score)
+	assert.Equal(t, "Names the correct capital. [judge] [blocklisted]", scores[1].Reasoning)
+
+	// A unit that lists no post-processors still applies the global one.
+	unit, err = postProcessorJudge(t, false)
+	require.NoError(t, err)
+	result, err = unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+	scores, _ = result.GetJudgeScores()
+	assert.InDelta(t, 0.8, scores[1].Score, 1e-9)
+	assert.InDelta(t, 0.45, scores[1].Confidence, 1e-9)
+}
+
+// TestScoreJudgeUnit_PostProcessorErrors tests unknown and
+// non-deterministic post-processors, hook errors, and out-of-range or
+// non-finite output.
+func TestScoreJudgeUnit_PostProcessorErrors(t *testing.T) {
+	errBlocked := errors.New("blocked")
+	RegisterScorePostProcessor("test_random", ScorePostProcessorFunc(func(ctx context.Context, target ScoreTarget, summary domain.JudgeSummary) (domain.JudgeSummary, error) {
+		return summary, nil
+	}), ScorePostProcessorOptions{})
+	RegisterScorePostProcessor("test_fail", ScorePostProcessorFunc(func(ctx context.Context, target ScoreTarget, summary domain.JudgeSummary) (domain.JudgeSummary, error) {
+		return summary, errBlocked
+	}), ScorePostProcessorOptions{Deterministic: true})
+	RegisterScorePostProcessor("test_overflow", ScorePostProcessorFunc(func(ctx context.Context, target ScoreTarget, summary domain.JudgeSummary) (domain.JudgeSummary, error) {
+		summary.Score = 2
+		return summary, nil
+	}), ScorePostProcessorOptions{Deterministic: true})
+	RegisterScorePostProcessor("test_nan_score", ScorePostProcessorFunc(func(ctx context.Context, target ScoreTarget, summary domain.JudgeSummary) (domain.JudgeSummary, error) {
+		summary.Score = math.NaN()
+		return summary, nil
+	}), ScorePostProcessorOptions{Deterministic: true})
+	RegisterScorePostProcessor("test_nan_confidence", ScorePostProcessorFunc(func(ctx context.Context, target ScoreTarget, summary domain.JudgeSummary) (domain.JudgeSummary, error) {
+		summary.Confidence = math.NaN()
+		return summary, nil
+	}), ScorePostProcessorOptions{Deterministic: true})
+	t.Cleanup(func() {
+		for _, name := range []string{"test_random", "test_fail", "test_overflow", "test_nan_score", "test_nan_confidence"} {
+			UnregisterScorePostProcessor(name)
+		}
+	})
+
+	_, err := postProcessorJudge(t, false, "test_missing")
+	assert.ErrorContains(t, err, "unknown score post-processor: test_missing")
+
+	_, err = postProcessorJudge(t, true, "test_random")
+	assert.ErrorContains(t, err, "test_random is not deterministic")
+	_, err = postProcessorJudge(t, false, "test_random")
+	assert.NoError(t, err)
+
+	state := testutils.EvaluationState(t, "What is the capital of France?", []domain.Answer{{ID: "a1", Content: "Paris"}})
+
+	unit, err := postProcessorJudge(t, false, "test_fail")
+	require.NoError(t, err)
+	_, err = unit.Execute(context.Background(), state)
+	assert.ErrorIs(t, err, errBlocked)
+	assert.ErrorContains(t, err, "post-processor test_fail")
+
+	unit, err = postProcessorJudge(t, false, "test_overflow")
+	require.NoError(t, err)
+	_, err = unit.Execute(context.Background(), state)
+	assert.ErrorContains(t, err, "score 2.00 not in range")
+
+	for _, name := range []string{"test_nan_score", "test_nan_confidence"} {
+		unit, err = postProcessorJudge(t, false, name)
+		require.NoError(t, err)
+		_, err = unit.Execute(context.Background(), state)
+		assert.ErrorIs(t, err, ErrNonFiniteScore, name)
+	}
+
+	// Unregistering a listed post-processor fails the unit at execution.
+	unit, err = postProcessorJudge(t, false, "test_overflow")
+	require.NoError(t, err)
+	UnregisterScorePostProcessor("test_overflow")
+	_, err = unit.Execute(context.Background(), state)
+	assert.ErrorContains(t, err, "unknown score post-processor: test_overflow")
+}
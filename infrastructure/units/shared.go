@@ -4,12 +4,13 @@
 //
 // Units are stateless, thread-safe components that transform evaluation state
 // by computing judge scores, aggregating results, or performing verification.
-// The package supports three primary unit categories:
+// The package supports these unit categories:
 //
 //   - Scoring Units: Generate scores for individual answers (ScoreJudgeUnit)
-//   - Aggregation Units: Combine multiple scores into final decisions (MedianPoolUnit, ArithmeticMeanUnit, MaxPoolUnit)
+//   - Aggregation Units: Combine multiple scores into final decisions (MedianPoolUnit, ArithmeticMeanUnit, MaxPoolUnit, AdaptiveAggregatorUnit)
+//   - Ranking Units: Rank answers from pairwise comparisons (WinMatrixUnit)
 //   - Verification Units: Validate evaluation quality and flag human review needs (VerificationUnit)
-//   - Matching Units: Compare answers against reference criteria (ExactMatchUnit, FuzzyMatchUnit)
+//   - Matching Units: Compare answers against reference criteria (ExactMatchUnit, FuzzyMatchUnit, LCSStepUnit)
 //
 // Architecture Integration:
 //
@@ -25,9 +26,22 @@
 package units
 
 import (
+	"cmp"
+	"context"
 	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/go-playground/validator/v10"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
 )
 
 // TieBreaker represents the strategy for handling equal scores when multiple
@@ -47,6 +61,20 @@ const (
 	// TieError returns an error when multiple candidates have tied scores.
 	// Useful when tie-breaking strategy must be explicitly handled by caller.
 	TieError TieBreaker = "error"
+
+	// TieLowestID selects the tied candidate with the lexicographically
+	// smallest answer ID. Unlike TieFirst, the result does not depend on the
+	// order in which answers were supplied.
+	TieLowestID TieBreaker = "lowest_id"
+
+	// TieConfidence selects the tied candidate whose judge reported the
+	// highest confidence, falling back to input order if confidences tie.
+	TieConfidence TieBreaker = "confidence"
+
+	// TieSeededRandom selects among tied candidates using a pseudo-random
+	// source seeded from the unit's TieSeed, so repeated runs over the same
+	// input pick the same winner without favoring input order.
+	TieSeededRandom TieBreaker = "seeded_random"
 )
 
 // Common errors returned by aggregator units.
@@ -66,8 +94,567 @@ var (
 
 	// ErrScoreMismatch is returned when the number of scores doesn't match the number of candidates.
 	ErrScoreMismatch = errors.New("scores and candidates length mismatch")
+
+	// ErrMissingJudgeScores is returned when RequireAllScores is set and an
+	// expected judge did not score one or more answers.
+	ErrMissingJudgeScores = errors.New("missing judge scores")
+
+	// ErrEmptyAnswer is returned by judges configured with EmptyAnswerReject
+	// when a candidate answer is empty or contains only whitespace.
+	ErrEmptyAnswer = errors.New("answer is empty")
+
+	// ErrTrailingContent is returned by judges configured with StrictJSON
+	// when an LLM response continues after its JSON object.
+	ErrTrailingContent = errors.New("unexpected content after JSON response")
+
+	// ErrPromptTooLarge is returned by judges with a prompt budget when a
+	// rendered prompt would not fit in the model's context window.
+	ErrPromptTooLarge = errors.New("prompt exceeds context budget")
+
+	// ErrTooFewAnswers is returned by units configured with MinAnswers when
+	// fewer answers are present than the unit's logic requires.
+	ErrTooFewAnswers = errors.New("too few answers")
+
+	// ErrMixedScoreScales is returned when scores from judges that
+	// declared different score ranges would be compared directly. Insert a
+	// normalize_scores unit before the consumer to put them on one scale.
+	ErrMixedScoreScales = errors.New("judges declared different score scales")
+
+	// ErrNonFiniteScore is returned when a score or confidence is NaN or
+	// infinite, whether an LLM reported it or it reached an aggregator. A
+	// single such value would silently poison every mean or comparison it
+	// enters.
+	ErrNonFiniteScore = errors.New("score is not a finite number")
+)
+
+// checkFinite returns an error wrapping ErrNonFiniteScore that names field
+// when v is NaN or infinite.
+func checkFinite(field string, v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Errorf("%w: %s is %v", ErrNonFiniteScore, field, v)
+	}
+	return nil
+}
+
+// checkFiniteSummaries returns an error naming the first of summaries whose
+// score or confidence is NaN or infinite. Aggregators and score-driven
+// selectors call it on their inputs before any arithmetic.
+func checkFiniteSummaries(summaries []domain.JudgeSummary) error {
+	for i, summary := range summaries {
+		if err := checkFinite("score", summary.Score); err != nil {
+			return fmt.Errorf("judge score %d: %w", i+1, err)
+		}
+		if err := checkFinite("confidence", summary.Confidence); err != nil {
+			return fmt.Errorf("judge score %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// ConclusiveConfig lets a deterministic unit settle an item on its own. When
+// an answer scores at least MinScore, the unit records a domain.Conclusion
+// naming it under domain.KeyConclusive, and the graph skips the units after
+// it, such as LLM judges that would only confirm an exact match.
+type ConclusiveConfig struct {
+	// MinScore is the lowest score that settles the item. Zero means 1.0,
+	// a perfect match.
+	MinScore float64 `yaml:"min_score" json:"min_score" validate:"min=0.0,max=1.0"`
+}
+
+// withConclusion returns state with a conclusion from unit when config is
+// set and the best of summaries reaches its MinScore. The first of equally
+// scored answers is named.
+func withConclusion(
+	state domain.State,
+	unit string,
+	config *ConclusiveConfig,
+	answers []domain.Answer,
+	summaries []domain.JudgeSummary,
+) domain.State {
+	if config == nil || len(summaries) == 0 {
+		return state
+	}
+	minScore := config.MinScore
+	if minScore == 0 {
+		minScore = 1
+	}
+	best := 0
+	for i, summary := range summaries {
+		if summary.Score > summaries[best].Score {
+			best = i
+		}
+	}
+	if summaries[best].Score < minScore {
+		return state
+	}
+	return domain.With(state, domain.KeyConclusive, &domain.Conclusion{
+		Unit:     unit,
+		AnswerID: answers[best].ID,
+		Score:    summaries[best].Score,
+		Reason:   fmt.Sprintf("score %.2f reached the conclusive minimum %.2f", summaries[best].Score, minScore),
+	})
+}
+
+// checkMinAnswers returns an error naming unit and its requirement when
+// count is below minimum. A minimum of zero disables the check.
+func checkMinAnswers(unit string, count, minimum int) error {
+	if count < minimum {
+		return fmt.Errorf("unit %s: requires at least %d answers, got %d: %w", unit, minimum, count, ErrTooFewAnswers)
+	}
+	return nil
+}
+
+// OversizePolicy selects how LLM units handle answers too large for the
+// prompt they are sent in. ScoreJudgeUnit and VerificationUnit accept the
+// same policies so a pipeline treats oversized content consistently at
+// every stage.
+type OversizePolicy string
+
+// Supported oversize prompt policies.
+const (
+	// OversizeError fails with ErrPromptTooLarge before calling the LLM.
+	OversizeError OversizePolicy = "error"
+
+	// OversizeTruncate shortens oversized answers until the prompt fits.
+	OversizeTruncate OversizePolicy = "truncate"
+
+	// OversizeSkipAnswer leaves oversized answers unevaluated: judges
+	// assign them their empty answer score without a call, and
+	// verification omits them from its prompt.
+	OversizeSkipAnswer OversizePolicy = "skip_answer"
+)
+
+// orDefault returns p, or OversizeTruncate, the default for every unit,
+// when p is unset.
+func (p OversizePolicy) orDefault() OversizePolicy {
+	if p == "" {
+		return OversizeTruncate
+	}
+	return p
+}
+
+// NoAnswersPolicy selects how judges handle an item with no candidate
+// answers. ScoreJudgeUnit and FuzzyMatchUnit accept the same policies.
+type NoAnswersPolicy string
+
+// Supported no-answers policies.
+const (
+	// NoAnswersError fails execution. It is the default.
+	NoAnswersError NoAnswersPolicy = "error"
+
+	// NoAnswersAbstain records empty judge scores and a verdict with
+	// status domain.VerdictNoAnswers and no winner, so the rest of the
+	// graph, and a batch the item belongs to, carry on. Aggregators pass
+	// such a verdict through rather than failing on the empty answers.
+	NoAnswersAbstain NoAnswersPolicy = "abstain"
+)
+
+// withNoAnswersVerdict records that the item had no answers to judge:
+// empty scores under judgeID and a verdict without a winner whose status
+// is domain.VerdictNoAnswers.
+func withNoAnswersVerdict(state domain.State, judgeID string) domain.State {
+	state = domain.WithJudgeScores(state, judgeID, []domain.JudgeSummary{})
+	return domain.With(state, domain.KeyVerdict, &domain.Verdict{
+		SchemaVersion: domain.VerdictSchemaVersion,
+		ID:            judgeID + "_verdict",
+		Status:        domain.VerdictNoAnswers,
+		CreatedAt:     time.Now(),
+	})
+}
+
+// hasNoAnswersVerdict reports whether an upstream judge recorded that the
+// item had no answers, which aggregators pass through unchanged.
+func hasNoAnswersVerdict(state domain.State) bool {
+	verdict, ok := state.GetVerdict()
+	return ok && verdict != nil && verdict.Status == domain.VerdictNoAnswers
+}
+
+// hasNoAnswers reports whether state holds no candidate answers, either
+// because KeyAnswers is empty or because it was never set, as for items
+// built by domain.NewEvaluationState without answers.
+func hasNoAnswers(state domain.State) bool {
+	answers, _ := state.GetAnswers()
+	return len(answers) == 0
+}
+
+// JSONSelection selects which JSON object a judge parses when an LLM
+// response contains more than one.
+type JSONSelection string
+
+// Supported JSON selections.
+const (
+	// JSONSelectFirst parses the first JSON object, preferring one inside
+	// a markdown code block. It is the default.
+	JSONSelectFirst JSONSelection = "first"
+
+	// JSONSelectLast parses the last complete top-level JSON object, for
+	// models that narrate or draft before producing their final answer.
+	JSONSelectLast JSONSelection = "last"
+
+	// JSONSelectLargest parses the longest complete top-level JSON object,
+	// preferring the later one on ties.
+	JSONSelectLargest JSONSelection = "largest"
+)
+
+// truncationMarker is appended to answers shortened to fit a prompt budget.
+const truncationMarker = "... [truncated]"
+
+// truncateContent returns the first keep runes of content followed by
+// truncationMarker. Content of at most keep runes is returned unchanged.
+func truncateContent(content string, keep int) string {
+	if utf8.RuneCountInString(content) <= keep {
+		return content
+	}
+	return string([]rune(content)[:max(keep, 0)]) + truncationMarker
+}
+
+// completeMetered makes one LLM call for a unit and returns the response,
+// its token usage, and the provider calls it took: the call itself plus
+// any retries or corrective re-prompts the client recorded in a
+// domain.CallMeter. Units charge all of them to the budget.
+func completeMetered(
+	ctx context.Context,
+	client ports.LLMClient,
+	prompt string,
+	options map[string]any,
+) (response string, tokensIn, tokensOut, calls int, err error) {
+	ctx, meter := domain.WithCallMeter(ctx)
+	response, tokensIn, tokensOut, err = client.CompleteWithUsage(ctx, prompt, options)
+	return response, tokensIn, tokensOut, meter.Calls(), err
+}
+
+// unitContextWindow returns the context window of the model unit calls:
+// the override for unit recorded in state, or client's model.
+func unitContextWindow(state domain.State, unit string, client ports.LLMClient) int {
+	model, ok := domain.ModelOverride(state, unit)
+	if !ok {
+		model = client.GetModel()
+	}
+	return modelContextWindow(model)
+}
+
+// modelContextWindow returns the context window, in tokens, of well-known
+// model families, falling back to a conservative 8K for unknown models.
+func modelContextWindow(model string) int {
+	model = strings.ToLower(model)
+	switch {
+	case strings.Contains(model, "gemini"), strings.Contains(model, "gpt-4.1"):
+		return 1_000_000
+	case strings.Contains(model, "claude"):
+		return 200_000
+	case strings.Contains(model, "gpt-4o"), strings.Contains(model, "gpt-4-turbo"),
+		strings.HasPrefix(model, "o1"), strings.HasPrefix(model, "o3"):
+		return 128_000
+	case strings.Contains(model, "gpt-3.5"):
+		return 16_385
+	default:
+		return 8_192
+	}
+}
+
+// EmptyAnswerPolicy selects how judges handle answers that are empty or
+// contain only whitespace.
+type EmptyAnswerPolicy string
+
+// Supported empty answer policies.
+const (
+	// EmptyAnswerSkip assigns the configured empty answer score with full
+	// confidence without evaluating the answer. This is the default.
+	EmptyAnswerSkip EmptyAnswerPolicy = "skip"
+
+	// EmptyAnswerSend evaluates empty answers like any other answer.
+	EmptyAnswerSend EmptyAnswerPolicy = "send"
+
+	// EmptyAnswerReject fails execution with ErrEmptyAnswer.
+	EmptyAnswerReject EmptyAnswerPolicy = "reject"
 )
 
+// isBlankAnswer reports whether content is empty or whitespace-only.
+func isBlankAnswer(content string) bool {
+	return strings.TrimSpace(content) == ""
+}
+
+// emptyAnswerSummary returns the summary recorded for a blank answer that
+// was scored under EmptyAnswerSkip.
+func emptyAnswerSummary(score float64) domain.JudgeSummary {
+	return domain.JudgeSummary{
+		Reasoning:  fmt.Sprintf("Answer is empty or whitespace-only; assigned score %.2f without evaluation", score),
+		Confidence: 1.0,
+		Score:      score,
+	}
+}
+
+// oversizeAction returns the action recorded in traces after policy was
+// applied to oversized answers: the policy, or "none" when no answer was
+// oversized.
+func oversizeAction(policy OversizePolicy, oversized int) string {
+	if oversized == 0 {
+		return "none"
+	}
+	return string(policy)
+}
+
+// oversizeSkipSummary returns the summary recorded for an answer left
+// unevaluated under OversizeSkipAnswer.
+func oversizeSkipSummary(score float64) domain.JudgeSummary {
+	return domain.JudgeSummary{
+		Reasoning:  fmt.Sprintf("Answer is too large for the prompt budget; assigned score %.2f without evaluation", score),
+		Confidence: 0,
+		Score:      score,
+	}
+}
+
 // Package-level validator instance for configuration validation.
 // Uses go-playground/validator v10 for struct tag-based validation.
-var validate = validator.New()
+var validate = newConfigValidator()
+
+// newConfigValidator returns a validator that reports fields by their YAML
+// name, falling back to the JSON name and then the Go field name, so
+// validation errors refer to the keys users actually write.
+func newConfigValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"yaml", "json"} {
+			name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+	return v
+}
+
+// fieldValidationError converts the validator.ValidationErrors returned by
+// struct validation into a *domain.ValidationError with one FieldError per
+// failed rule, so callers can use errors.As to tell which field failed.
+// Any other error is returned unchanged.
+func fieldValidationError(err error) error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) == 0 {
+		return err
+	}
+
+	// Namespaces are rooted at the config type, e.g. "MaxPoolConfig.k".
+	entity, _, _ := strings.Cut(validationErrs[0].StructNamespace(), ".")
+	result := domain.NewValidationError(entity)
+	for _, fe := range validationErrs {
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+		result.AddFieldError(domain.FieldError{
+			Field: field,
+			Rule:  fe.Tag(),
+			Param: fe.Param(),
+			Value: fe.Value(),
+		})
+	}
+	return result
+}
+
+// tiedIndices returns the indices of all scores equal to target, in order.
+func tiedIndices(scores []float64, target float64) []int {
+	var indices []int
+	for i, score := range scores {
+		if score == target {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// lowestIDIndex returns the index from tied whose candidate has the
+// lexicographically smallest ID. Candidates sharing an ID fall back to the
+// earliest index so the result is fully deterministic.
+func lowestIDIndex(candidates []domain.Answer, tied []int) int {
+	best := tied[0]
+	for _, idx := range tied[1:] {
+		if candidates[idx].ID < candidates[best].ID {
+			best = idx
+		}
+	}
+	return best
+}
+
+// duplicateAnswerIDs returns the sorted IDs of every candidate whose content
+// matches the winner's after trimming surrounding whitespace. It returns nil
+// when the winner's content is unique among the candidates.
+func duplicateAnswerIDs(winner domain.Answer, candidates []domain.Answer) []string {
+	content := strings.TrimSpace(winner.Content)
+	var ids []string
+	for _, c := range candidates {
+		if strings.TrimSpace(c.Content) == content {
+			ids = append(ids, c.ID)
+		}
+	}
+	if len(ids) < 2 {
+		return nil
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// rankAnswers orders candidates best-first by the value rank assigns to each
+// score, where a higher rank is better. Equal ranks keep input order, or are
+// ordered by ID when tieBreaker is TieLowestID. The winner chosen by the
+// aggregator is always placed first so the ranking agrees with the verdict,
+// even when a random tie-break selected a later candidate.
+func rankAnswers(
+	candidates []domain.Answer,
+	scores []float64,
+	winner domain.Answer,
+	tieBreaker TieBreaker,
+	rank func(score float64) float64,
+) []domain.RankedAnswer {
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		if c := cmp.Compare(rank(scores[b]), rank(scores[a])); c != 0 {
+			return c
+		}
+		if tieBreaker == TieLowestID {
+			return strings.Compare(candidates[a].ID, candidates[b].ID)
+		}
+		return 0
+	})
+
+	isWinner := func(i int) bool {
+		return candidates[i].ID == winner.ID && candidates[i].Content == winner.Content
+	}
+	if pos := slices.IndexFunc(order, isWinner); pos > 0 {
+		winnerIdx := order[pos]
+		copy(order[1:pos+1], order[:pos])
+		order[0] = winnerIdx
+	}
+
+	ranked := make([]domain.RankedAnswer, len(order))
+	for i, idx := range order {
+		ranked[i] = domain.RankedAnswer{Answer: candidates[idx], Score: scores[idx]}
+	}
+	return ranked
+}
+
+// checkJudgeCoverage verifies that every expected judge recorded a score for
+// every answer in domain.KeyJudgeScoresByJudge. When expected is empty, all
+// judges that reported scores are checked. The error names each missing
+// judge/answer pair so a silently-failed judge is easy to identify.
+func checkJudgeCoverage(state domain.State, expected []string, answers []domain.Answer) error {
+	byJudge, _ := domain.Get(state, domain.KeyJudgeScoresByJudge)
+
+	judges := expected
+	if len(judges) == 0 {
+		judges = slices.Sorted(maps.Keys(byJudge))
+	}
+
+	var missing []string
+	for _, judge := range judges {
+		for i := len(byJudge[judge]); i < len(answers); i++ {
+			missing = append(missing, judge+"/"+answers[i].ID)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w for judge/answer pairs: %s", ErrMissingJudgeScores, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// verdictConfidence measures how decisively the winner was chosen as the
+// gap between the rank of the winner and the runner-up, as a fraction of
+// the width of scale, the judges' score range, clamped to [0, 1]. A margin
+// of 1 is thus decisive on a 0-1 judge but not on a 1-10 one. rank is the
+// same function used to order the candidates. A lone candidate has no
+// competitor, so the judge's confidence in it is used.
+func verdictConfidence(
+	ranked []domain.RankedAnswer,
+	summaries []domain.JudgeSummary,
+	rank func(score float64) float64,
+	scale domain.ScoreRange,
+) float64 {
+	switch {
+	case len(ranked) == 0:
+		return 0
+	case len(ranked) == 1:
+		if len(summaries) == 0 {
+			return 0
+		}
+		return min(max(summaries[0].Confidence, 0), 1)
+	default:
+		margin := rank(ranked[0].Score) - rank(ranked[1].Score)
+		if width := scale.Max - scale.Min; width > 0 {
+			margin /= width
+		}
+		return min(max(margin, 0), 1)
+	}
+}
+
+// latestJudgeScale returns the score range declared by the judge whose
+// scores are in domain.KeyJudgeScores, or domain.UnitScoreRange when it
+// declared none.
+func latestJudgeScale(state domain.State) domain.ScoreRange {
+	latest, _ := domain.Get(state, domain.KeyLatestJudge)
+	scale, _ := domain.JudgeScoreScale(state, latest)
+	return scale
+}
+
+// judgesScale returns the score range shared by the named judges, which
+// must be non-empty. Judges that declared none use domain.UnitScoreRange.
+// It returns an error wrapping ErrMixedScoreScales when the judges declared
+// different ranges, since their raw scores cannot be compared.
+func judgesScale(state domain.State, judges []string) (domain.ScoreRange, error) {
+	scale, _ := domain.JudgeScoreScale(state, judges[0])
+	for _, judge := range judges[1:] {
+		if other, _ := domain.JudgeScoreScale(state, judge); other != scale {
+			return domain.ScoreRange{}, fmt.Errorf("%w: %s uses %g-%g but %s uses %g-%g",
+				ErrMixedScoreScales, judges[0], scale.Min, scale.Max, judge, other.Min, other.Max)
+		}
+	}
+	return scale, nil
+}
+
+// applyAbstention sets the verdict's status, abstaining when its confidence
+// is below threshold. An abstained verdict keeps its ranking for reviewers
+// but has no winner and is flagged for human review. A zero threshold
+// never abstains.
+func applyAbstention(verdict *domain.Verdict, threshold float64) {
+	verdict.Status = domain.VerdictDecided
+	if threshold > 0 && verdict.Confidence < threshold {
+		verdict.Status = domain.VerdictAbstained
+		verdict.WinnerAnswer = nil
+		verdict.DuplicateAnswerIDs = nil
+		verdict.RequiresHumanReview = true
+	}
+}
+
+// resolveCriteria returns the rubric criteria referenced by ids, read from
+// domain.KeyRubric. It returns nil when ids is empty and wraps
+// domain.ErrUnknownCriterion when the rubric is missing or lacks an ID.
+func resolveCriteria(state domain.State, ids []string) ([]domain.Criterion, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	rubric, ok := state.GetRubric()
+	if !ok || rubric == nil {
+		return nil, fmt.Errorf("%w: no rubric in state for %s", domain.ErrUnknownCriterion, strings.Join(ids, ", "))
+	}
+	return rubric.Select(ids)
+}
+
+// describeCriteria formats criteria as a prompt section instructing the
+// model to evaluate against them. It returns "" for no criteria.
+func describeCriteria(criteria []domain.Criterion) string {
+	if len(criteria) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n\nEvaluate against these criteria:")
+	for _, c := range criteria {
+		fmt.Fprintf(&b, "\n- %s: %s", c.ID, c.Description)
+		if c.Weight > 0 {
+			fmt.Fprintf(&b, " (weight %.2f)", c.Weight)
+		}
+	}
+	return b.String()
+}
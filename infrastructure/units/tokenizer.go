@@ -0,0 +1,123 @@
+package units
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Supported tokenizers for the deterministic text units.
+const (
+	// TokenizerCharacter splits text into individual Unicode code points.
+	// This is the default and matches the historical rune-level behavior.
+	TokenizerCharacter = "character"
+
+	// TokenizerWhitespace splits text on runs of Unicode whitespace, leaving
+	// punctuation attached to the surrounding word.
+	TokenizerWhitespace = "whitespace"
+
+	// TokenizerSubword splits text into words and standalone punctuation,
+	// then breaks long words into fixed-length pieces.
+	TokenizerSubword = "subword"
+
+	// DefaultSubwordPieceLength is the maximum number of runes in a subword
+	// piece when SubwordTokenizer.PieceLength is zero.
+	DefaultSubwordPieceLength = 4
+
+	// subwordContinuationPrefix marks pieces that continue a word, so "ab"
+	// at the start of a word and "ab" in its middle are distinct tokens.
+	subwordContinuationPrefix = "##"
+)
+
+// Tokenizer splits text into the tokens compared by deterministic text
+// metrics. Implementations must be deterministic and safe for concurrent use
+// so every unit sharing a tokenizer name sees identical tokens.
+type Tokenizer interface {
+	// Tokenize returns the tokens of s in order. Empty input yields no tokens.
+	Tokenize(s string) []string
+}
+
+// NewTokenizer returns the tokenizer registered under name. An empty name
+// selects TokenizerCharacter for backward compatibility.
+func NewTokenizer(name string) (Tokenizer, error) {
+	switch name {
+	case "", TokenizerCharacter:
+		return CharacterTokenizer{}, nil
+	case TokenizerWhitespace:
+		return WhitespaceTokenizer{}, nil
+	case TokenizerSubword:
+		return SubwordTokenizer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown tokenizer %q: must be %q, %q, or %q",
+			name, TokenizerCharacter, TokenizerWhitespace, TokenizerSubword)
+	}
+}
+
+// CharacterTokenizer emits one token per Unicode code point, including
+// whitespace and punctuation.
+type CharacterTokenizer struct{}
+
+// Tokenize returns each rune of s as a separate token.
+func (CharacterTokenizer) Tokenize(s string) []string {
+	tokens := make([]string, 0, len(s))
+	for _, r := range s {
+		tokens = append(tokens, string(r))
+	}
+	return tokens
+}
+
+// WhitespaceTokenizer emits the whitespace-separated fields of the text.
+type WhitespaceTokenizer struct{}
+
+// Tokenize returns the fields of s as split by strings.Fields.
+func (WhitespaceTokenizer) Tokenize(s string) []string {
+	return strings.Fields(s)
+}
+
+// SubwordTokenizer is a lightweight, vocabulary-free subword tokenizer.
+// Letters and digits form words, every other non-space rune is its own
+// token, and words longer than PieceLength are split into consecutive
+// pieces with continuation pieces prefixed by "##". Separating punctuation
+// means "Paris." and "Paris" share the token "Pari", while piecing lets
+// inflected forms such as "evaluate" and "evaluated" overlap partially.
+type SubwordTokenizer struct {
+	// PieceLength is the maximum number of runes per piece. Zero uses
+	// DefaultSubwordPieceLength.
+	PieceLength int
+}
+
+// Tokenize returns the subword pieces and punctuation tokens of s.
+func (st SubwordTokenizer) Tokenize(s string) []string {
+	pieceLength := st.PieceLength
+	if pieceLength <= 0 {
+		pieceLength = DefaultSubwordPieceLength
+	}
+
+	var tokens []string
+	var word []rune
+	flush := func() {
+		for i := 0; i < len(word); i += pieceLength {
+			piece := string(word[i:min(i+pieceLength, len(word))])
+			if i > 0 {
+				piece = subwordContinuationPrefix + piece
+			}
+			tokens = append(tokens, piece)
+		}
+		word = word[:0]
+	}
+
+	for _, r := range s {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			word = append(word, r)
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			tokens = append(tokens, string(r))
+		}
+	}
+	flush()
+
+	return tokens
+}
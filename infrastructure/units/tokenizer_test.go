@@ -0,0 +1,60 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewTokenizer tests tokenizer selection by name, including the
+// backward-compatible default.
+func TestNewTokenizer(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Tokenizer
+		wantErr bool
+	}{
+		{name: "", want: CharacterTokenizer{}},
+		{name: TokenizerCharacter, want: CharacterTokenizer{}},
+		{name: TokenizerWhitespace, want: WhitespaceTokenizer{}},
+		{name: TokenizerSubword, want: SubwordTokenizer{}},
+		{name: "bpe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenizer, err := NewTokenizer(tt.name)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tokenizer)
+		})
+	}
+}
+
+// TestTokenizers_Tokenize tests the tokens produced by each implementation.
+func TestTokenizers_Tokenize(t *testing.T) {
+	tests := []struct {
+		name      string
+		tokenizer Tokenizer
+		input     string
+		want      []string
+	}{
+		{name: "character splits runes", tokenizer: CharacterTokenizer{}, input: "café!", want: []string{"c", "a", "f", "é", "!"}},
+		{name: "character empty", tokenizer: CharacterTokenizer{}, input: "", want: []string{}},
+		{name: "whitespace keeps punctuation", tokenizer: WhitespaceTokenizer{}, input: " Paris,  France. ", want: []string{"Paris,", "France."}},
+		{name: "subword separates punctuation", tokenizer: SubwordTokenizer{}, input: "It's Rome.", want: []string{"It", "'", "s", "Rome", "."}},
+		{name: "subword pieces long words", tokenizer: SubwordTokenizer{}, input: "evaluated", want: []string{"eval", "##uate", "##d"}},
+		{name: "subword custom piece length", tokenizer: SubwordTokenizer{PieceLength: 2}, input: "abcde 42", want: []string{"ab", "##cd", "##e", "42"}},
+		{name: "subword empty", tokenizer: SubwordTokenizer{}, input: "   ", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.tokenizer.Tokenize(tt.input))
+		})
+	}
+}
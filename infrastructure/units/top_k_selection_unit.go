@@ -0,0 +1,315 @@
+package units
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+var _ ports.Unit = (*TopKSelectionUnit)(nil)
+
+// Selection sources supported by TopKSelectionUnit.
+const (
+	// SelectionSourceFuzzyMatch ranks answers by Levenshtein similarity to
+	// the reference answer.
+	SelectionSourceFuzzyMatch = "fuzzy_match"
+	// SelectionSourceLength ranks answers by content length in runes.
+	SelectionSourceLength = "length"
+	// SelectionSourceJudgeScores ranks answers by the scores already in
+	// KeyJudgeScores, typically produced by a cheap upstream unit such as
+	// exact_match or a score_judge backed by a lightweight model.
+	SelectionSourceJudgeScores = "judge_scores"
+)
+
+// TopKSelectionUnit narrows the candidate answers to the K best according to
+// a cheap ranking signal so that expensive downstream judges only evaluate
+// promising candidates. Selected answers replace KeyAnswers in their original
+// order and the remainder are recorded under KeyDroppedAnswers.
+//
+// Judge scores already in state that are aligned with the original answers
+// are filtered to the selected answers, keeping them aligned for aggregators.
+//
+// The unit is deterministic, stateless, and thread-safe.
+type TopKSelectionUnit struct {
+	// name is the unique identifier for this unit instance.
+	name string
+	// config contains the validated configuration parameters.
+	config TopKSelectionConfig
+	// matcher computes similarity for the fuzzy_match source.
+	matcher *FuzzyMatchUnit
+	// tracer is the OpenTelemetry tracer for observability.
+	tracer trace.Tracer
+}
+
+// TopKSelectionConfig defines the configuration parameters for the
+// TopKSelectionUnit.
+type TopKSelectionConfig struct {
+	// K is the number of answers passed downstream. When there are K or
+	// fewer answers, all of them are kept.
+	K int `yaml:"k" json:"k" validate:"required,min=1,max=10000"`
+
+	// Source selects the ranking signal: "fuzzy_match" (the default),
+	// "length", or "judge_scores".
+	Source string `yaml:"source" json:"source" validate:"required,oneof=fuzzy_match length judge_scores"`
+
+	// CaseSensitive controls case folding for the fuzzy_match source.
+	CaseSensitive bool `yaml:"case_sensitive" json:"case_sensitive"`
+
+	// Tokenizer selects the tokenization used by the fuzzy_match source;
+	// see FuzzyMatchConfig.Tokenizer. Defaults to "character".
+	Tokenizer string `yaml:"tokenizer" json:"tokenizer" validate:"omitempty,oneof=character whitespace subword"`
+
+	// PreferShorter ranks shorter answers first for the length source.
+	// By default longer answers rank first.
+	PreferShorter bool `yaml:"prefer_shorter" json:"prefer_shorter"`
+
+	// MinAnswers is the fewest answers the unit accepts. Execution fails
+	// with ErrTooFewAnswers when fewer are present. Zero disables the check.
+	MinAnswers int `yaml:"min_answers,omitempty" json:"min_answers,omitempty" validate:"min=0,max=10000"`
+}
+
+// DefaultTopKSelectionConfig returns a TopKSelectionConfig that keeps the
+// five answers most similar to the reference answer.
+func DefaultTopKSelectionConfig() TopKSelectionConfig {
+	return TopKSelectionConfig{
+		K:      5,
+		Source: SelectionSourceFuzzyMatch,
+	}
+}
+
+// NewTopKSelectionUnit creates a new TopKSelectionUnit with the specified
+// configuration. Returns an error if configuration validation fails.
+func NewTopKSelectionUnit(name string, config TopKSelectionConfig) (*TopKSelectionUnit, error) {
+	if name == "" {
+		return nil, ErrEmptyUnitName
+	}
+
+	if err := validate.Struct(config); err != nil {
+		return nil, domain.NewConfigValidationError(name, fieldValidationError(err))
+	}
+
+	matcherConfig := DefaultFuzzyMatchConfig()
+	matcherConfig.CaseSensitive = config.CaseSensitive
+	matcherConfig.Tokenizer = config.Tokenizer
+	matcher, err := NewFuzzyMatchUnit(name, matcherConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TopKSelectionUnit{
+		name:    name,
+		config:  config,
+		matcher: matcher,
+		tracer:  otel.Tracer("top-k-selection-unit"),
+	}, nil
+}
+
+// Name returns the unique identifier for this unit instance.
+func (tku *TopKSelectionUnit) Name() string { return tku.name }
+
+// Execute ranks the answers in KeyAnswers by the configured source and keeps
+// the top K. Ties are broken by original position, so the same input always
+// yields the same selection.
+//
+// Returns an error if answers are missing, the fuzzy_match source has no
+// reference answer, or the judge_scores source has scores that do not align
+// with the answers.
+func (tku *TopKSelectionUnit) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	_, span := tku.tracer.Start(ctx, "TopKSelectionUnit.Execute",
+		trace.WithAttributes(
+			attribute.String("unit.type", "top_k_selection"),
+			attribute.String("unit.id", tku.name),
+			attribute.Int("config.k", tku.config.K),
+			attribute.String("config.source", tku.config.Source),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+
+	answers, err := domain.Require(state, domain.KeyAnswers, tku.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	if len(answers) == 0 {
+		err := fmt.Errorf("no answers provided for top-k selection")
+		span.RecordError(err)
+		return state, err
+	}
+
+	if err := checkMinAnswers(tku.name, len(answers), tku.config.MinAnswers); err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	if len(answers) > MaxAnswers {
+		err := fmt.Errorf("too many answers: %d exceeds limit of %d", len(answers), MaxAnswers)
+		span.RecordError(err)
+		return state, err
+	}
+
+	scores, err := tku.rankScores(ctx, state, answers)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+
+	// Rank indices by score, breaking ties by original position.
+	order := make([]int, len(answers))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(scores[b], scores[a])
+	})
+
+	keep := make([]bool, len(answers))
+	for _, idx := range order[:min(tku.config.K, len(answers))] {
+		keep[idx] = true
+	}
+
+	selected := make([]domain.Answer, 0, min(tku.config.K, len(answers)))
+	var dropped []domain.Answer
+	for i, answer := range answers {
+		if keep[i] {
+			selected = append(selected, answer)
+		} else {
+			dropped = append(dropped, answer)
+		}
+	}
+
+	newState := domain.With(state, domain.KeyAnswers, selected)
+	newState = domain.With(newState, domain.KeyDroppedAnswers, dropped)
+	newState = filterAlignedJudgeScores(newState, keep)
+
+	span.SetAttributes(
+		attribute.Int64("eval.latency_ms", time.Since(start).Milliseconds()),
+		attribute.Int("eval.answers_count", len(answers)),
+		attribute.Int("eval.selected_count", len(selected)),
+		attribute.Int("eval.dropped_count", len(dropped)),
+		attribute.Bool("no_llm_cost", true),
+	)
+
+	return newState, nil
+}
+
+// rankScores returns the ranking signal for each answer, where higher
+// values rank first. Fuzzy matching stops early if ctx is cancelled.
+func (tku *TopKSelectionUnit) rankScores(ctx context.Context, state domain.State, answers []domain.Answer) ([]float64, error) {
+	scores := make([]float64, len(answers))
+
+	switch tku.config.Source {
+	case SelectionSourceLength:
+		for i, answer := range answers {
+			scores[i] = float64(utf8.RuneCountInString(answer.Content))
+			if tku.config.PreferShorter {
+				scores[i] = -scores[i]
+			}
+		}
+
+	case SelectionSourceJudgeScores:
+		judgeScores, ok := state.GetJudgeScores()
+		if !ok {
+			return nil, fmt.Errorf("judge_scores source requires judge scores in state")
+		}
+		if len(judgeScores) != len(answers) {
+			return nil, fmt.Errorf("%w: %d scores for %d answers", ErrScoreMismatch, len(judgeScores), len(answers))
+		}
+		for i, summary := range judgeScores {
+			scores[i] = summary.Score
+		}
+
+	default:
+		reference, ok := state.GetReferenceAnswer()
+		if !ok {
+			return nil, fmt.Errorf("reference_answer required for fuzzy_match selection")
+		}
+		if len(reference) > MaxStringLength {
+			return nil, fmt.Errorf("reference answer too long: %d bytes exceeds limit of %d", len(reference), MaxStringLength)
+		}
+		preparedReference := tku.matcher.prepareString(reference)
+		for i, answer := range answers {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if len(answer.Content) > MaxStringLength {
+				return nil, fmt.Errorf("answer %d too long: %d bytes exceeds limit of %d", i, len(answer.Content), MaxStringLength)
+			}
+			scores[i] = tku.matcher.calculateSimilarity(tku.matcher.prepareString(answer.Content), preparedReference)
+		}
+	}
+
+	return scores, nil
+}
+
+// filterAlignedJudgeScores drops the entries for unselected answers from
+// judge scores recorded against the original answer list. Score lists of a
+// different length are left untouched because they cannot be aligned.
+func filterAlignedJudgeScores(state domain.State, keep []bool) domain.State {
+	filter := func(scores []domain.JudgeSummary) ([]domain.JudgeSummary, bool) {
+		if len(scores) != len(keep) {
+			return scores, false
+		}
+		filtered := make([]domain.JudgeSummary, 0, len(scores))
+		for i, s := range scores {
+			if keep[i] {
+				filtered = append(filtered, s)
+			}
+		}
+		return filtered, true
+	}
+
+	if scores, ok := state.GetJudgeScores(); ok {
+		if filtered, changed := filter(scores); changed {
+			state = domain.With(state, domain.KeyJudgeScores, filtered)
+		}
+	}
+	if byJudge, ok := domain.Get(state, domain.KeyJudgeScoresByJudge); ok && byJudge != nil {
+		for judgeID, scores := range byJudge {
+			byJudge[judgeID], _ = filter(scores)
+		}
+		state = domain.With(state, domain.KeyJudgeScoresByJudge, byJudge)
+	}
+	return state
+}
+
+// Validate checks if the unit is properly configured and ready for execution.
+func (tku *TopKSelectionUnit) Validate() error {
+	if err := validate.Struct(tku.config); err != nil {
+		return domain.NewConfigValidationError(tku.name, fieldValidationError(err))
+	}
+	return nil
+}
+
+// NewTopKSelectionFromConfig creates a TopKSelectionUnit from a
+// configuration map. This is the boundary adapter for YAML/JSON configuration.
+// Top-k selection doesn't require an LLM client.
+func NewTopKSelectionFromConfig(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error) {
+	// llm is ignored - selection uses cheap deterministic signals.
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	// Start with defaults, then overlay user config.
+	cfg := DefaultTopKSelectionConfig()
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+
+	return NewTopKSelectionUnit(id, cfg)
+}
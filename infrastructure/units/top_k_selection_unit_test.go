@@ -0,0 +1,191 @@
+package units
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// answerIDs returns the IDs of answers in order.
+func answerIDs(answers []domain.Answer) []string {
+	ids := make([]string, len(answers))
+	for i, a := range answers {
+		ids[i] = a.ID
+	}
+	return ids
+}
+
+// TestNewTopKSelectionUnit tests unit creation and configuration validation.
+func TestNewTopKSelectionUnit(t *testing.T) {
+	tests := []struct {
+		name    string
+		unit    string
+		config  TopKSelectionConfig
+		wantErr bool
+	}{
+		{name: "default config", unit: "select", config: DefaultTopKSelectionConfig()},
+		{name: "empty name", unit: "", config: DefaultTopKSelectionConfig(), wantErr: true},
+		{name: "zero k", unit: "select", config: TopKSelectionConfig{K: 0, Source: SelectionSourceLength}, wantErr: true},
+		{name: "unknown source", unit: "select", config: TopKSelectionConfig{K: 2, Source: "embedding"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := NewTopKSelectionUnit(tt.unit, tt.config)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.unit, unit.Name())
+			assert.NoError(t, unit.Validate())
+		})
+	}
+}
+
+// TestTopKSelectionUnit_Execute tests selection by each source, including
+// preservation of original order, deterministic tie-breaking, and recording
+// of dropped answers.
+func TestTopKSelectionUnit_Execute(t *testing.T) {
+	answers := []domain.Answer{
+		{ID: "a1", Content: "The capital is Berlin"},
+		{ID: "a2", Content: "paris"},
+		{ID: "a3", Content: "Paris!"},
+		{ID: "a4", Content: "Rome"},
+	}
+	base := domain.With(domain.NewState(), domain.KeyAnswers, answers)
+	base = domain.With(base, domain.KeyReferenceAnswer, "Paris")
+
+	tests := []struct {
+		name        string
+		config      TopKSelectionConfig
+		scores      []float64
+		wantKept    []string
+		wantDropped []string
+	}{
+		{
+			name:        "fuzzy match keeps closest in original order",
+			config:      TopKSelectionConfig{K: 2, Source: SelectionSourceFuzzyMatch},
+			wantKept:    []string{"a2", "a3"},
+			wantDropped: []string{"a1", "a4"},
+		},
+		{
+			name:        "length prefers longer answers",
+			config:      TopKSelectionConfig{K: 1, Source: SelectionSourceLength},
+			wantKept:    []string{"a1"},
+			wantDropped: []string{"a2", "a3", "a4"},
+		},
+		{
+			name:        "length prefers shorter answers",
+			config:      TopKSelectionConfig{K: 1, Source: SelectionSourceLength, PreferShorter: true},
+			wantKept:    []string{"a4"},
+			wantDropped: []string{"a1", "a2", "a3"},
+		},
+		{
+			name:        "judge scores with ties broken by position",
+			config:      TopKSelectionConfig{K: 2, Source: SelectionSourceJudgeScores},
+			scores:      []float64{0.2, 0.9, 0.2, 0.2},
+			wantKept:    []string{"a1", "a2"},
+			wantDropped: []string{"a3", "a4"},
+		},
+		{
+			name:     "k larger than answers keeps all",
+			config:   TopKSelectionConfig{K: 10, Source: SelectionSourceFuzzyMatch},
+			wantKept: []string{"a1", "a2", "a3", "a4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := base
+			if tt.scores != nil {
+				summaries := make([]domain.JudgeSummary, len(tt.scores))
+				for i, s := range tt.scores {
+					summaries[i] = domain.JudgeSummary{Score: s, Confidence: 1.0, Reasoning: "cheap"}
+				}
+				state = domain.WithJudgeScores(state, "cheap", summaries)
+			}
+
+			unit, err := NewTopKSelectionUnit("select", tt.config)
+			require.NoError(t, err)
+
+			newState, err := unit.Execute(context.Background(), state)
+			require.NoError(t, err)
+
+			kept, ok := domain.Get(newState, domain.KeyAnswers)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantKept, answerIDs(kept))
+
+			dropped, ok := domain.Get(newState, domain.KeyDroppedAnswers)
+			require.True(t, ok)
+			if len(tt.wantDropped) == 0 {
+				assert.Empty(t, dropped)
+			} else {
+				assert.Equal(t, tt.wantDropped, answerIDs(dropped))
+			}
+
+			if tt.scores != nil {
+				scores, ok := domain.Get(newState, domain.KeyJudgeScores)
+				require.True(t, ok)
+				require.Len(t, scores, len(kept), "judge scores should stay aligned with selected answers")
+				byJudge, ok := domain.Get(newState, domain.KeyJudgeScoresByJudge)
+				require.True(t, ok)
+				assert.Len(t, byJudge["cheap"], len(kept))
+			}
+		})
+	}
+}
+
+// TestTopKSelectionUnit_Errors tests missing inputs for each source.
+func TestTopKSelectionUnit_Errors(t *testing.T) {
+	answers := []domain.Answer{{ID: "a1", Content: "x"}, {ID: "a2", Content: "y"}}
+	withAnswers := domain.With(domain.NewState(), domain.KeyAnswers, answers)
+
+	tests := []struct {
+		name   string
+		source string
+		state  domain.State
+		errIs  error
+	}{
+		{name: "missing answers", source: SelectionSourceLength, state: domain.NewState()},
+		{name: "missing reference", source: SelectionSourceFuzzyMatch, state: withAnswers},
+		{name: "missing judge scores", source: SelectionSourceJudgeScores, state: withAnswers},
+		{
+			name:   "misaligned judge scores",
+			source: SelectionSourceJudgeScores,
+			state:  domain.With(withAnswers, domain.KeyJudgeScores, []domain.JudgeSummary{{Score: 1}}),
+			errIs:  ErrScoreMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := NewTopKSelectionUnit("select", TopKSelectionConfig{K: 1, Source: tt.source})
+			require.NoError(t, err)
+
+			_, err = unit.Execute(context.Background(), tt.state)
+			require.Error(t, err)
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+		})
+	}
+}
+
+// TestNewTopKSelectionFromConfig tests creation from a configuration map
+// with defaults applied for omitted keys.
+func TestNewTopKSelectionFromConfig(t *testing.T) {
+	unit, err := NewTopKSelectionFromConfig("select", map[string]any{"k": 3}, nil)
+	require.NoError(t, err)
+	selection, ok := unit.(*TopKSelectionUnit)
+	require.True(t, ok)
+	assert.Equal(t, 3, selection.config.K)
+	assert.Equal(t, SelectionSourceFuzzyMatch, selection.config.Source)
+
+	_, err = NewTopKSelectionFromConfig("select", map[string]any{"k": 3, "source": "random"}, nil)
+	require.Error(t, err)
+}
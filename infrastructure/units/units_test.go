@@ -0,0 +1,282 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+	"github.com/ahrav/go-gavel/internal/testutils"
+)
+
+// TestUnits_MinAnswers verifies that judge and aggregator units configured
+// with min_answers reject too few answers with an error naming the unit and
+// the requirement, and accept enough answers.
+func TestUnits_MinAnswers(t *testing.T) {
+	factories := map[string]func(id string, config map[string]any) (ports.Unit, error){
+		"max_pool": func(id string, config map[string]any) (ports.Unit, error) {
+			return NewMaxPoolFromConfig(id, config, nil)
+		},
+		"median_pool": func(id string, config map[string]any) (ports.Unit, error) {
+			return NewMedianPoolFromConfig(id, config, nil)
+		},
+		"arithmetic_mean": func(id string, config map[string]any) (ports.Unit, error) {
+			return NewArithmeticMeanFromConfig(id, config, nil)
+		},
+		"top_k_selection": func(id string, config map[string]any) (ports.Unit, error) {
+			config["k"] = 1
+			config["source"] = "length"
+			return NewTopKSelectionFromConfig(id, config, nil)
+		},
+		"score_judge": func(id string, config map[string]any) (ports.Unit, error) {
+			config["judge_prompt"] = "Rate this answer to '{{.Question}}': {{.Answer}}"
+			config["score_scale"] = "0.0-1.0"
+			return NewScoreJudgeFromConfig(id, config, testutils.NewMockLLMClient("test-model"))
+		},
+	}
+
+	state := testutils.EvaluationState(t, "Which is better?", []domain.Answer{{ID: "a1", Content: "Only answer"}})
+	state = domain.WithJudgeScores(state, "judge", []domain.JudgeSummary{{Score: 0.8, Confidence: 0.9, Reasoning: "Good answer"}})
+
+	for unitType, factory := range factories {
+		t.Run(unitType, func(t *testing.T) {
+			unit, err := factory("unit1", map[string]any{"min_answers": 2})
+			require.NoError(t, err)
+
+			_, err = unit.Execute(context.Background(), state)
+			require.ErrorIs(t, err, ErrTooFewAnswers)
+			assert.Contains(t, err.Error(), "unit unit1: requires at least 2 answers, got 1")
+
+			unit, err = factory("unit1", map[string]any{"min_answers": 1})
+			require.NoError(t, err)
+			_, err = unit.Execute(context.Background(), state)
+			assert.NotErrorIs(t, err, ErrTooFewAnswers)
+
+			_, err = factory("unit1", map[string]any{"min_answers": -1})
+			assert.Error(t, err, "negative minimums are rejected")
+		})
+	}
+}
+
+// TestUnits_NonFinite verifies that aggregators and score-driven selectors
+// reject NaN and infinite judge scores and confidences with
+// ErrNonFiniteScore instead of letting them propagate into the verdict.
+func TestUnits_NonFinite(t *testing.T) {
+	factories := map[string]func(id string) (ports.Unit, error){
+		"max_pool": func(id string) (ports.Unit, error) {
+			return NewMaxPoolFromConfig(id, map[string]any{}, nil)
+		},
+		"median_pool": func(id string) (ports.Unit, error) {
+			return NewMedianPoolFromConfig(id, map[string]any{}, nil)
+		},
+		"arithmetic_mean": func(id string) (ports.Unit, error) {
+			return NewArithmeticMeanFromConfig(id, map[string]any{}, nil)
+		},
+		"adaptive_aggregator": func(id string) (ports.Unit, error) {
+			return NewAdaptiveAggregatorFromConfig(id, map[string]any{}, nil)
+		},
+		"diverse_selection": func(id string) (ports.Unit, error) {
+			return NewDiverseSelectionFromConfig(id, map[string]any{"k": 1}, nil)
+		},
+	}
+	inputs := map[string]domain.JudgeSummary{
+		"NaN score":           {Score: math.NaN(), Confidence: 0.9},
+		"infinite score":      {Score: math.Inf(-1), Confidence: 0.9},
+		"NaN confidence":      {Score: 0.5, Confidence: math.NaN()},
+		"infinite confidence": {Score: 0.5, Confidence: math.Inf(1)},
+	}
+
+	answers := []domain.Answer{{ID: "a1", Content: "Paris"}, {ID: "a2", Content: "Lyon"}}
+	for unitType, factory := range factories {
+		for name, bad := range inputs {
+			t.Run(unitType+"/"+name, func(t *testing.T) {
+				unit, err := factory("agg")
+				require.NoError(t, err)
+
+				state := testutils.EvaluationState(t, "Capital of France?", answers)
+				state = domain.WithJudgeScores(state, "judge", []domain.JudgeSummary{{Score: 0.9, Confidence: 0.9}, bad})
+				result, err := unit.Execute(context.Background(), state)
+				require.ErrorIs(t, err, ErrNonFiniteScore)
+				_, ok := result.GetVerdict()
+				assert.False(t, ok)
+			})
+		}
+	}
+}
+
+// cancelAfterContext reports cancellation from the (n+1)th call to Err,
+// simulating a caller that cancels while a unit is partway through its
+// answers.
+type cancelAfterContext struct {
+	context.Context
+	n     int
+	calls int
+}
+
+// Err counts the check and returns context.Canceled once n checks passed.
+func (c *cancelAfterContext) Err() error {
+	c.calls++
+	if c.calls > c.n {
+		return context.Canceled
+	}
+	return nil
+}
+
+// TestUnits_ContextCancellation verifies that deterministic units and
+// aggregators check for cancellation in their per-answer loops and return
+// as soon as it is observed instead of finishing the remaining answers.
+func TestUnits_ContextCancellation(t *testing.T) {
+	factories := map[string]func(id string) (ports.Unit, error){
+		"exact_match": func(id string) (ports.Unit, error) {
+			return NewExactMatchFromConfig(id, map[string]any{}, nil)
+		},
+		"fuzzy_match": func(id string) (ports.Unit, error) {
+			return NewFuzzyMatchFromConfig(id, map[string]any{}, nil)
+		},
+		"format_validation": func(id string) (ports.Unit, error) {
+			return NewFormatValidationFromConfig(id, map[string]any{"type": "integer"}, nil)
+		},
+		"top_k_selection": func(id string) (ports.Unit, error) {
+			return NewTopKSelectionFromConfig(id, map[string]any{"k": 1}, nil)
+		},
+		"diverse_selection": func(id string) (ports.Unit, error) {
+			return NewDiverseSelectionFromConfig(id, map[string]any{"k": 1}, nil)
+		},
+		"max_pool": func(id string) (ports.Unit, error) {
+			return NewMaxPoolFromConfig(id, map[string]any{}, nil)
+		},
+		"median_pool": func(id string) (ports.Unit, error) {
+			return NewMedianPoolFromConfig(id, map[string]any{}, nil)
+		},
+		"arithmetic_mean": func(id string) (ports.Unit, error) {
+			return NewArithmeticMeanFromConfig(id, map[string]any{}, nil)
+		},
+		"normalize_scores": func(id string) (ports.Unit, error) {
+			return NewNormalizeScoresFromConfig(id, map[string]any{}, nil)
+		},
+		"calibration": func(id string) (ports.Unit, error) {
+			params := map[string]any{}
+			for _, judge := range []string{"j1", "j2", "j3"} {
+				params[judge] = map[string]any{"scale": 1.0, "offset": 0.0}
+			}
+			return NewCalibrationFromConfig(id, map[string]any{"parameters": params}, nil)
+		},
+	}
+
+	answers := make([]domain.Answer, 5)
+	summaries := make([]domain.JudgeSummary, len(answers))
+	for i := range answers {
+		answers[i] = domain.Answer{ID: fmt.Sprintf("a%d", i+1), Content: fmt.Sprintf("answer number %d", i+1)}
+		summaries[i] = domain.JudgeSummary{Score: float64(i) / 10, Confidence: 0.9, Reasoning: "Scored answer"}
+	}
+	state := domain.With(domain.NewState(), domain.KeyAnswers, answers)
+	state = domain.With(state, domain.KeyReferenceAnswer, "answer number 3")
+	for _, judge := range []string{"j1", "j2", "j3"} {
+		state = domain.WithJudgeScores(state, judge, summaries)
+	}
+
+	for unitType, factory := range factories {
+		t.Run(unitType, func(t *testing.T) {
+			unit, err := factory("unit1")
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err = unit.Execute(ctx, state)
+			require.ErrorIs(t, err, context.Canceled, "already-cancelled context stops work")
+
+			ctx2 := &cancelAfterContext{Context: context.Background(), n: 1}
+			_, err = unit.Execute(ctx2, state)
+			require.ErrorIs(t, err, context.Canceled, "cancellation mid-loop stops work")
+			assert.Equal(t, 2, ctx2.calls, "unit returns at the first check after cancellation")
+
+			_, err = unit.Execute(context.Background(), state)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// retryingClient reports every call as having been retried once, as the
+// retry middleware does for a call whose first attempt failed.
+type retryingClient struct {
+	*testutils.MockLLMClient
+}
+
+// CompleteWithUsage records one extra call in ctx's call meter and
+// reports 10 input and 5 output tokens.
+func (c *retryingClient) CompleteWithUsage(ctx context.Context, prompt string, options map[string]any) (string, int, int, error) {
+	domain.RecordExtraCalls(ctx, 1)
+	response, _, _, err := c.MockLLMClient.CompleteWithUsage(ctx, prompt, options)
+	return response, 10, 5, err
+}
+
+// TestUnits_ChargeRetries verifies that LLM units charge the retries their
+// client records to both the budget counters and the budget report.
+func TestUnits_ChargeRetries(t *testing.T) {
+	answers := []domain.Answer{{ID: "a1", Content: "Paris"}, {ID: "a2", Content: "Lyon"}}
+	state := testutils.EvaluationState(t, "What is the capital of France?", answers)
+	state = domain.WithJudgeScores(state, "judge", []domain.JudgeSummary{
+		{Score: 0.9, Confidence: 0.9, Reasoning: "Correct"},
+		{Score: 0.2, Confidence: 0.9, Reasoning: "Wrong city"},
+	})
+	state = domain.With(state, domain.KeyVerdict, &domain.Verdict{ID: "v1", WinnerAnswer: &answers[0]})
+	state = domain.WithBudget(domain.BudgetReport{})(state)
+
+	tests := []struct {
+		name      string
+		unit      func(client ports.LLMClient) (ports.Unit, error)
+		response  string
+		wantCalls int
+	}{
+		{
+			name: "answerer",
+			unit: func(client ports.LLMClient) (ports.Unit, error) {
+				return NewAnswererUnit("answerer", client, defaultAnswererConfig())
+			},
+			wantCalls: 2 * DefaultNumAnswers,
+		},
+		{
+			name: "score_judge",
+			unit: func(client ports.LLMClient) (ports.Unit, error) {
+				return NewScoreJudgeUnit("judge", client, defaultScoreJudgeConfig())
+			},
+			response:  `{"score": 8, "confidence": 0.9, "reasoning": "Names the right city", "version": 1}`,
+			wantCalls: 2 * len(answers),
+		},
+		{
+			name: "verification",
+			unit: func(client ports.LLMClient) (ports.Unit, error) {
+				return NewVerificationUnit("verify", client, defaultVerificationConfig())
+			},
+			response:  `{"confidence": 0.9, "reasoning": "Sound judging", "version": 1}`,
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := testutils.NewMockLLMClient("test-model")
+			if tt.response != "" {
+				mock.SetResponse(tt.response)
+			}
+			unit, err := tt.unit(&retryingClient{MockLLMClient: mock})
+			require.NoError(t, err)
+
+			result, err := unit.Execute(context.Background(), state)
+			require.NoError(t, err)
+
+			usage := result.GetBudgetUsage()
+			assert.Equal(t, int64(tt.wantCalls), usage.Calls)
+			assert.Equal(t, int64(15*tt.wantCalls/2), usage.Tokens)
+			report, ok := result.GetBudget()
+			require.True(t, ok)
+			assert.Equal(t, tt.wantCalls, report.CallsMade)
+			assert.Equal(t, 15*tt.wantCalls/2, report.TokensUsed)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package units
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Value types supported for custom verifier response fields.
+const (
+	ResponseFieldString  = "string"
+	ResponseFieldNumber  = "number"
+	ResponseFieldInteger = "integer"
+	ResponseFieldBoolean = "boolean"
+	ResponseFieldArray   = "array"
+	ResponseFieldObject  = "object"
+)
+
+// coreVerificationFields are the LLMVerificationResponse fields that custom
+// response fields may not redefine.
+var coreVerificationFields = []string{"confidence", "reasoning", "issues", "issue_details", "recommendation", "version"}
+
+// ResponseField declares a custom field the verifier must or may include in
+// its JSON response alongside the core confidence and reasoning fields,
+// e.g., a severity level or a suggested score adjustment.
+type ResponseField struct {
+	// Name is the JSON key of the field.
+	Name string `yaml:"name" json:"name" validate:"required,max=64"`
+
+	// Type is the expected JSON type: string, number, integer, boolean,
+	// array, or object.
+	Type string `yaml:"type" json:"type" validate:"required,oneof=string number integer boolean array object"`
+
+	// Required rejects responses that omit the field.
+	Required bool `yaml:"required" json:"required"`
+
+	// Enum restricts string fields to the listed values.
+	Enum []string `yaml:"enum,omitempty" json:"enum,omitempty" validate:"omitempty,dive,required"`
+
+	// Description is shown to the verifier to explain the field's meaning.
+	Description string `yaml:"description,omitempty" json:"description,omitempty" validate:"max=500"`
+}
+
+// validateResponseFields checks constraints that struct tags cannot express:
+// names must be unique, must not shadow core fields, and enums only apply to
+// string fields.
+func validateResponseFields(fields []ResponseField) error {
+	seen := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		if slices.Contains(coreVerificationFields, field.Name) {
+			return fmt.Errorf("response field %q conflicts with a core verification field", field.Name)
+		}
+		if _, ok := seen[field.Name]; ok {
+			return fmt.Errorf("duplicate response field %q", field.Name)
+		}
+		seen[field.Name] = struct{}{}
+		if len(field.Enum) > 0 && field.Type != ResponseFieldString {
+			return fmt.Errorf("response field %q: enum is only supported for string fields", field.Name)
+		}
+	}
+	return nil
+}
+
+// describeResponseFields renders prompt instructions listing the custom
+// fields the verifier should include. It returns an empty string when no
+// custom fields are configured.
+func describeResponseFields(fields []ResponseField) string {
+	if len(fields) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("\nInclude these additional fields in the same JSON object:\n")
+	for _, field := range fields {
+		presence := "optional"
+		if field.Required {
+			presence = "required"
+		}
+		fmt.Fprintf(&b, "- %q (%s, %s)", field.Name, field.Type, presence)
+		if len(field.Enum) > 0 {
+			fmt.Fprintf(&b, ", one of: %s", strings.Join(field.Enum, ", "))
+		}
+		if field.Description != "" {
+			b.WriteString(": " + field.Description)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// parseResponseFields extracts and validates the declared custom fields from
+// the verifier's JSON object. Undeclared fields are ignored. It returns nil
+// when no custom fields are configured.
+func parseResponseFields(jsonStr string, fields []ResponseField) (map[string]any, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(jsonStr), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON response (len: %d): %w", len(jsonStr), err)
+	}
+
+	parsed := make(map[string]any, len(fields))
+	for _, field := range fields {
+		value, ok := raw[field.Name]
+		if !ok || string(value) == "null" {
+			if field.Required {
+				return nil, fmt.Errorf("response field %q is required", field.Name)
+			}
+			continue
+		}
+
+		decoded, err := decodeResponseField(field, value)
+		if err != nil {
+			return nil, fmt.Errorf("response field %q: %w", field.Name, err)
+		}
+		parsed[field.Name] = decoded
+	}
+	return parsed, nil
+}
+
+// decodeResponseField decodes a single field value and checks it against
+// the declared type and enum.
+func decodeResponseField(field ResponseField, value json.RawMessage) (any, error) {
+	switch field.Type {
+	case ResponseFieldString:
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return nil, fmt.Errorf("expected string")
+		}
+		if len(field.Enum) > 0 && !slices.Contains(field.Enum, s) {
+			return nil, fmt.Errorf("value %q is not one of %v", s, field.Enum)
+		}
+		return s, nil
+	case ResponseFieldNumber:
+		var f float64
+		if err := json.Unmarshal(value, &f); err != nil {
+			return nil, fmt.Errorf("expected number")
+		}
+		return f, nil
+	case ResponseFieldInteger:
+		var n int64
+		if err := json.Unmarshal(value, &n); err != nil {
+			return nil, fmt.Errorf("expected integer")
+		}
+		return n, nil
+	case ResponseFieldBoolean:
+		var b bool
+		if err := json.Unmarshal(value, &b); err != nil {
+			return nil, fmt.Errorf("expected boolean")
+		}
+		return b, nil
+	case ResponseFieldArray:
+		var a []any
+		if err := json.Unmarshal(value, &a); err != nil {
+			return nil, fmt.Errorf("expected array")
+		}
+		return a, nil
+	case ResponseFieldObject:
+		var o map[string]any
+		if err := json.Unmarshal(value, &o); err != nil {
+			return nil, fmt.Errorf("expected object")
+		}
+		return o, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %q", field.Type)
+	}
+}
@@ -0,0 +1,98 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateResponseFields tests rejection of response fields that shadow
+// core fields, repeat a name, or apply enums to non-string types.
+func TestValidateResponseFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []ResponseField
+		wantErr string
+	}{
+		{name: "valid", fields: []ResponseField{
+			{Name: "severity", Type: ResponseFieldString, Enum: []string{"low", "high"}},
+			{Name: "score_adjustment", Type: ResponseFieldNumber},
+		}},
+		{name: "core field", fields: []ResponseField{{Name: "confidence", Type: ResponseFieldNumber}}, wantErr: "conflicts with a core"},
+		{name: "duplicate", fields: []ResponseField{
+			{Name: "severity", Type: ResponseFieldString},
+			{Name: "severity", Type: ResponseFieldString},
+		}, wantErr: "duplicate response field"},
+		{name: "enum on number", fields: []ResponseField{{Name: "level", Type: ResponseFieldInteger, Enum: []string{"1"}}}, wantErr: "only supported for string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateResponseFields(tt.fields)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// TestParseResponseFields tests type checking, enum enforcement, and
+// required-field handling for custom verifier response fields.
+func TestParseResponseFields(t *testing.T) {
+	fields := []ResponseField{
+		{Name: "severity", Type: ResponseFieldString, Required: true, Enum: []string{"low", "medium", "high"}},
+		{Name: "score_adjustment", Type: ResponseFieldNumber},
+		{Name: "issue_count", Type: ResponseFieldInteger},
+		{Name: "blocking", Type: ResponseFieldBoolean},
+		{Name: "categories", Type: ResponseFieldArray},
+		{Name: "details", Type: ResponseFieldObject},
+	}
+
+	t.Run("parses declared fields", func(t *testing.T) {
+		parsed, err := parseResponseFields(`{"confidence": 0.9, "severity": "high", "score_adjustment": -1.5,
+			"issue_count": 2, "blocking": true, "categories": ["bias"], "details": {"judge": "j1"}, "extra": 1}`, fields)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"severity":         "high",
+			"score_adjustment": -1.5,
+			"issue_count":      int64(2),
+			"blocking":         true,
+			"categories":       []any{"bias"},
+			"details":          map[string]any{"judge": "j1"},
+		}, parsed)
+	})
+
+	t.Run("optional fields may be omitted or null", func(t *testing.T) {
+		parsed, err := parseResponseFields(`{"severity": "low", "blocking": null}`, fields)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"severity": "low"}, parsed)
+	})
+
+	t.Run("no fields configured", func(t *testing.T) {
+		parsed, err := parseResponseFields(`{"severity": "low"}`, nil)
+		require.NoError(t, err)
+		assert.Nil(t, parsed)
+	})
+
+	errorCases := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{name: "missing required", json: `{"score_adjustment": 1}`, wantErr: `"severity" is required`},
+		{name: "value outside enum", json: `{"severity": "critical"}`, wantErr: "not one of"},
+		{name: "wrong type", json: `{"severity": "low", "score_adjustment": "big"}`, wantErr: "expected number"},
+		{name: "fractional integer", json: `{"severity": "low", "issue_count": 1.5}`, wantErr: "expected integer"},
+	}
+	for _, tt := range errorCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseResponseFields(tt.json, fields)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package units
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// Judge score selection policies for the VerificationUnit, applied when the
+// full set of judge scores and answers does not fit in the model's context.
+const (
+	// ScoreSelectionTruncate keeps every judge score and truncates answer
+	// content proportionally. It is the default.
+	ScoreSelectionTruncate = "truncate"
+
+	// ScoreSelectionExtremes keeps the highest, lowest, and median scores
+	// first, then the scores that diverge most from the median, since
+	// disagreement between judges is the strongest signal of a judging
+	// problem.
+	ScoreSelectionExtremes = "extremes"
+
+	// ScoreSelectionTopAnswers keeps the scores of the highest scored
+	// answers first, focusing verification on the contenders for the win.
+	ScoreSelectionTopAnswers = "top_answers"
+)
+
+// minSelectedScores is the number of judge scores kept by a selection
+// policy even when they do not fit, so the verifier can still compare
+// judgments. Answers are truncated to make room for them.
+const minSelectedScores = 2
+
+// scoreSelection is the result of selecting judge scores to fit the
+// verification prompt.
+type scoreSelection struct {
+	answers     []domain.Answer
+	judgeScores []domain.JudgeSummary
+
+	// omitted counts the judge scores left out of the prompt.
+	omitted int
+}
+
+// selectJudgeScores applies the configured selection policy when the
+// question, answers, and judge scores would exceed maxPromptTokens. Judge
+// scores are aligned with answers by index, so when both slices have the
+// same length each kept score keeps its answer and the pair stays at the
+// same position relative to the others. Otherwise only scores are dropped.
+// The truncate policy and prompts that already fit return the inputs
+// unchanged.
+func (vu *VerificationUnit) selectJudgeScores(
+	answers []domain.Answer,
+	judgeScores []domain.JudgeSummary,
+	question string,
+	maxPromptTokens int,
+) scoreSelection {
+	all := scoreSelection{answers: answers, judgeScores: judgeScores}
+	policy := vu.config.ScoreSelection
+	if policy == "" || policy == ScoreSelectionTruncate || len(judgeScores) <= minSelectedScores {
+		return all
+	}
+
+	paired := len(answers) == len(judgeScores)
+	available := maxPromptTokens - vu.estimateTokens(question) - verificationTemplateOverhead
+	if !paired {
+		for _, answer := range answers {
+			available -= vu.estimateTokens(answer.Content)
+		}
+	}
+
+	costs := make([]int, len(judgeScores))
+	total := 0
+	for i, score := range judgeScores {
+		costs[i] = vu.estimateTokens(formatJudgeScore(score))
+		if paired {
+			costs[i] += vu.estimateTokens(answers[i].Content)
+		}
+		total += costs[i]
+	}
+	if total <= available {
+		return all
+	}
+
+	var kept []int
+	used := 0
+	for _, i := range judgeScorePriority(policy, judgeScores) {
+		if len(kept) >= minSelectedScores && used+costs[i] > available {
+			continue
+		}
+		kept = append(kept, i)
+		used += costs[i]
+	}
+	slices.Sort(kept)
+
+	selection := scoreSelection{
+		judgeScores: make([]domain.JudgeSummary, len(kept)),
+		answers:     answers,
+		omitted:     len(judgeScores) - len(kept),
+	}
+	if paired {
+		selection.answers = make([]domain.Answer, len(kept))
+	}
+	for j, i := range kept {
+		selection.judgeScores[j] = judgeScores[i]
+		if paired {
+			selection.answers[j] = answers[i]
+		}
+	}
+	return selection
+}
+
+// judgeScorePriority returns the indices of scores in the order the policy
+// keeps them. Ties are broken by index so the order is deterministic.
+func judgeScorePriority(policy string, scores []domain.JudgeSummary) []int {
+	byScore := make([]int, len(scores))
+	for i := range byScore {
+		byScore[i] = i
+	}
+	slices.SortStableFunc(byScore, func(a, b int) int {
+		return cmp.Compare(scores[b].Score, scores[a].Score)
+	})
+	if policy == ScoreSelectionTopAnswers {
+		return byScore
+	}
+
+	highest, lowest := byScore[0], byScore[len(byScore)-1]
+	median := byScore[len(byScore)/2]
+	priority := []int{highest, lowest}
+	if median != highest && median != lowest {
+		priority = append(priority, median)
+	}
+
+	medianScore := scores[median].Score
+	rest := slices.DeleteFunc(slices.Clone(byScore), func(i int) bool {
+		return slices.Contains(priority, i)
+	})
+	slices.SortStableFunc(rest, func(a, b int) int {
+		return cmp.Compare(math.Abs(scores[b].Score-medianScore), math.Abs(scores[a].Score-medianScore))
+	})
+	return append(priority, rest...)
+}
+
+// formatJudgeScore renders a judge score as it appears in the prompt.
+func formatJudgeScore(score domain.JudgeSummary) string {
+	return fmt.Sprintf("Score: %.2f, Confidence: %.2f\nReasoning: %s",
+		score.Score, score.Confidence, score.Reasoning)
+}
+
+// describeScoreSelection tells the verifier that judge scores were left
+// out, so it does not treat the missing judgments as evidence. It returns
+// an empty string when nothing was omitted.
+func describeScoreSelection(policy string, shown, omitted int) string {
+	if omitted == 0 {
+		return ""
+	}
+	return fmt.Sprintf("\n\nNote: to fit the context window only %d of %d judge scores are shown, "+
+		"selected by the %q policy. Do not treat the omitted judgments as missing or as evidence of a problem.",
+		shown, shown+omitted, policy)
+}
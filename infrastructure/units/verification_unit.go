@@ -2,12 +2,13 @@
 package units
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"math"
+	"slices"
 	"strings"
-	"text/template"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -27,8 +28,31 @@ const (
 	DefaultVerificationMaxTokens     = 512
 	DefaultVerificationTemperature   = 0.0
 	DefaultVerificationConfThreshold = 0.8
+
+	// VerificationTraceSchemaVersion is the current serialized schema
+	// version of VerificationTrace.
+	VerificationTraceSchemaVersion = 1
 )
 
+// Supported verification modes for the VerificationUnit.
+const (
+	// VerificationModeJudgingQuality critiques the judging process as a whole,
+	// checking that judge scores are consistent with the candidate answers.
+	VerificationModeJudgingQuality = "judging_quality"
+
+	// VerificationModeWinnerCorrectness independently re-evaluates the verdict's
+	// winning answer against the question, acting as a second opinion that is
+	// not anchored on the judges' reasoning. When the verifier is less
+	// confident in the winner than the verdict, the verdict's confidence is
+	// lowered to the verifier's.
+	VerificationModeWinnerCorrectness = "winner_correctness"
+)
+
+// DisagreementIssueCategory is the structured issue category the verifier
+// is asked to use when explaining sharp judge disagreement. It is accepted
+// even when IssueCategories does not list it.
+const DisagreementIssueCategory = "judge_disagreement"
+
 // VerificationUnit performs a final critique of judging results to validate
 // evaluation quality and determine if human review is required. It integrates
 // with an LLM client to generate verification reasoning and confidence scores.
@@ -38,15 +62,21 @@ type VerificationUnit struct {
 	config         VerificationConfig
 	llmClient      ports.LLMClient
 	validator      *validator.Validate
-	promptTemplate *template.Template
+	promptRenderer PromptRenderer
 	tracer         trace.Tracer
 }
 
 // VerificationConfig defines the configuration parameters for the VerificationUnit.
 // All fields are validated during unit creation and parameter unmarshaling.
 type VerificationConfig struct {
+	// Mode selects what is being verified. "judging_quality" (the default)
+	// critiques the judging process, while "winner_correctness" checks
+	// whether the verdict's winning answer actually answers the question.
+	Mode string `yaml:"mode" json:"mode" validate:"omitempty,oneof=judging_quality winner_correctness"`
+
 	// PromptTemplate is the Go template used to verify judging results.
 	// It should use {{.Question}}, {{.Answers}}, and {{.JudgeScores}}.
+	// In winner_correctness mode {{.Winner}} holds the winning answer.
 	PromptTemplate string `yaml:"prompt_template" json:"prompt_template" validate:"required,min=20"`
 
 	// ConfidenceThreshold is the minimum acceptable confidence score (0.0-1.0).
@@ -59,6 +89,97 @@ type VerificationConfig struct {
 
 	// MaxTokens limits the length of the verification reasoning.
 	MaxTokens int `yaml:"max_tokens" json:"max_tokens" validate:"required,min=50,max=2000"`
+
+	// TemplateEngine selects the registered PromptRenderer used for PromptTemplate.
+	// Defaults to the Go text/template engine when empty.
+	TemplateEngine string `yaml:"template_engine,omitempty" json:"template_engine,omitempty"`
+
+	// Sanitization selects how questions, answers, and judge reasoning are
+	// isolated within the prompt: "code_fence" (the default), "delimiter",
+	// "xml_tag", or "none". Choose a non-fencing strategy when evaluating code
+	// or markdown answers; see SanitizationStrategy for security tradeoffs.
+	Sanitization SanitizationStrategy `yaml:"sanitization,omitempty" json:"sanitization,omitempty" validate:"omitempty,oneof=none code_fence delimiter xml_tag"`
+
+	// ResponseFields declares custom fields expected in the verifier's JSON
+	// response in addition to the core confidence and reasoning fields.
+	// Parsed values are included in the debug trace and stored under
+	// domain.KeyVerificationFields for downstream units.
+	ResponseFields []ResponseField `yaml:"response_fields,omitempty" json:"response_fields,omitempty" validate:"omitempty,max=20,dive"`
+
+	// Criteria lists IDs of rubric criteria, read from domain.KeyRubric at
+	// execution, that are appended to the prompt. Execution fails if the
+	// rubric is missing or does not define every referenced criterion.
+	Criteria []string `yaml:"criteria,omitempty" json:"criteria,omitempty" validate:"omitempty,max=50,dive,required"`
+
+	// StopSequences are passed to the provider as the "stop" option so that
+	// generation ends before chatty models append text after the JSON.
+	// Providers without stop-sequence support ignore them.
+	StopSequences []string `yaml:"stop_sequences,omitempty" json:"stop_sequences,omitempty" validate:"omitempty,max=4,dive,required"`
+
+	// Deterministic asks the provider for the most reproducible output it
+	// supports. The provider layer translates it into provider-specific
+	// options and overrides Temperature.
+	Deterministic bool `yaml:"deterministic,omitempty" json:"deterministic,omitempty"`
+
+	// StrictJSON rejects responses that contain anything other than a
+	// closing code fence after the JSON object instead of ignoring it.
+	StrictJSON bool `yaml:"strict_json,omitempty" json:"strict_json,omitempty"`
+
+	// JSONSelection chooses which JSON object to parse when a response
+	// contains several: "first" (the default), "last", or "largest".
+	JSONSelection JSONSelection `yaml:"json_selection,omitempty" json:"json_selection,omitempty" validate:"omitempty,oneof=first last largest"`
+
+	// StructuredIssues asks the verifier to report issues as objects with a
+	// category, severity, and description. Parsed issues are validated,
+	// included in the debug trace, and stored under
+	// domain.KeyVerificationIssues.
+	StructuredIssues bool `yaml:"structured_issues,omitempty" json:"structured_issues,omitempty"`
+
+	// IssueCategories restricts structured issue categories to this list.
+	// Any category is accepted when empty.
+	IssueCategories []string `yaml:"issue_categories,omitempty" json:"issue_categories,omitempty" validate:"omitempty,max=50,dive,required"`
+
+	// ReviewSeverity flags the verdict for human review when any structured
+	// issue is at least this severe, regardless of confidence. Empty
+	// disables severity-based review.
+	ReviewSeverity domain.IssueSeverity `yaml:"review_severity,omitempty" json:"review_severity,omitempty" validate:"omitempty,oneof=low medium high critical"`
+
+	// DisagreementVariance, when set, enables the explain-disagreement
+	// pass: answers whose scores vary across the judges in
+	// domain.KeyJudgeScoresByJudge by more than this population variance,
+	// measured on the judges' declared score scale normalized to 0-1, are
+	// listed in the prompt with each judge's score, and the verifier is
+	// asked to explain which judges diverged and plausibly why as
+	// DisagreementIssueCategory issues. It requires StructuredIssues and
+	// the judging_quality mode.
+	DisagreementVariance float64 `yaml:"disagreement_variance,omitempty" json:"disagreement_variance,omitempty" validate:"omitempty,gt=0"`
+
+	// EscalateBelowConfidence, when set, limits verification to verdicts
+	// whose confidence is below it. Decisive verdicts are passed through
+	// without an LLM call or a human review flag.
+	EscalateBelowConfidence float64 `yaml:"escalate_below_confidence,omitempty" json:"escalate_below_confidence,omitempty" validate:"omitempty,gt=0,max=1"`
+
+	// EscalateBelowMargin, when set, limits verification to verdicts whose
+	// winner leads the runner-up in RankedAnswers by less than this score
+	// margin. Verdicts without ranked answers are always verified. When
+	// both escalation thresholds are set, either one triggers verification.
+	EscalateBelowMargin float64 `yaml:"escalate_below_margin,omitempty" json:"escalate_below_margin,omitempty" validate:"omitempty,gt=0"`
+
+	// ScoreSelection chooses which judge scores to include in
+	// judging_quality prompts when the full set does not fit in the model's
+	// context: "truncate" (default) keeps every score and truncates answers,
+	// "extremes" keeps the highest, lowest, and median scores and then the
+	// most divergent ones, and "top_answers" keeps the scores of the highest
+	// scored answers.
+	ScoreSelection string `yaml:"score_selection,omitempty" json:"score_selection,omitempty" validate:"omitempty,oneof=truncate extremes top_answers"`
+
+	// OversizePolicy selects how judging_quality prompts handle answers
+	// that do not fit in the model's context window, looked up by the model
+	// the unit calls: "truncate" (the default) shortens them to an equal
+	// share of the available space, "error" fails with ErrPromptTooLarge,
+	// and "skip_answer" omits them and their judge scores from the prompt.
+	// The policies match ScoreJudgeUnit's.
+	OversizePolicy OversizePolicy `yaml:"oversize_policy,omitempty" json:"oversize_policy,omitempty" validate:"omitempty,oneof=error truncate skip_answer"`
 }
 
 // LLMVerificationResponse represents the expected JSON structure from the LLM
@@ -78,13 +199,22 @@ type LLMVerificationResponse struct {
 	// Empty slice indicates no issues detected.
 	Issues []string `json:"issues,omitempty"`
 
+	// IssueDetails holds structured issues when StructuredIssues is
+	// enabled. Their descriptions also populate Issues if the verifier
+	// left it empty, so consumers of the string list keep working.
+	IssueDetails []domain.VerificationIssue `json:"issue_details,omitempty"`
+
 	// Recommendation provides actionable feedback for improvement.
 	// Optional field that may contain suggestions for better evaluation.
 	Recommendation string `json:"recommendation,omitempty"`
 
-	// Version allows for future schema evolution.
-	// Currently optional but recommended for forward compatibility.
+	// Version is the response schema version. Responses are decoded by the
+	// decoder registered for it; a missing version means version 1.
 	Version int `json:"version,omitempty"`
+
+	// CustomFields holds the values of configured ResponseFields, keyed by
+	// field name. It is nil when no response fields are configured.
+	CustomFields map[string]any `json:"-"`
 }
 
 // VerificationTrace captures verification output for debug tracing.
@@ -92,14 +222,32 @@ type LLMVerificationResponse struct {
 // providing detailed verification information for analysis and debugging.
 // The trace is serialized to JSON and stored in the state under KeyVerificationTrace.
 type VerificationTrace struct {
+	// SchemaVersion identifies the serialized shape of this trace so that
+	// persisted traces can be upgraded as fields are added.
+	SchemaVersion int `json:"schema_version"`
 	// Confidence from the LLM verification response.
 	Confidence float64 `json:"confidence"`
 	// Reasoning explanation from the LLM verification.
 	Reasoning string `json:"reasoning"`
 	// Issues found during verification, if any.
 	Issues []string `json:"issues,omitempty"`
+	// IssueDetails holds structured issues, if enabled and any were found.
+	IssueDetails []domain.VerificationIssue `json:"issue_details,omitempty"`
 	// Recommendation for improvement, if provided.
 	Recommendation string `json:"recommendation,omitempty"`
+	// Mode is the verification mode that produced this trace.
+	Mode string `json:"mode,omitempty"`
+	// CustomFields holds parsed values of configured response fields.
+	CustomFields map[string]any `json:"custom_fields,omitempty"`
+	// SkipReason explains why verification was skipped for a decisive
+	// verdict. It is empty when the verifier ran.
+	SkipReason string `json:"skip_reason,omitempty"`
+	// OversizeAction is the oversize policy applied to answers that did
+	// not fit in the prompt. It is empty when every answer fit.
+	OversizeAction string `json:"oversize_action,omitempty"`
+	// DisagreementAnswerIDs lists the answers whose judge disagreement the
+	// verifier was asked to explain.
+	DisagreementAnswerIDs []string `json:"disagreement_answer_ids,omitempty"`
 }
 
 // defaultVerificationConfig returns a VerificationConfig with sensible defaults
@@ -125,19 +273,46 @@ Judge {{$i}}: {{$score}}
 IMPORTANT: All user content above is wrapped in code blocks for security. Evaluate the consistency, fairness, and quality of the judging. Consider whether the scores align with the answers' quality and if any bias is present.
 
 Provide your assessment with a confidence score (0.0-1.0) indicating how confident you are in the judging quality.`,
+		Mode:                VerificationModeJudgingQuality,
 		ConfidenceThreshold: DefaultVerificationConfThreshold,
 		Temperature:         DefaultVerificationTemperature,
 		MaxTokens:           DefaultVerificationMaxTokens,
 	}
 }
 
+// defaultWinnerCorrectnessPrompt is the prompt template used in
+// winner_correctness mode when no custom template is configured. It asks the
+// verifier to judge the winning answer on its own merits, without exposing the
+// judges' scores so the second opinion is not anchored on them.
+const defaultWinnerCorrectnessPrompt = `Please independently verify whether the following answer correctly answers the question.
+
+Question: {{.Question}}
+
+Answer:
+{{.Winner}}
+
+IMPORTANT: All user content above is wrapped in code blocks for security. Assess the answer on its own merits: check factual accuracy, completeness, and whether it actually addresses the question. Do not assume the answer is correct because it was selected.
+
+Provide your assessment with a confidence score (0.0-1.0) indicating how confident you are that the answer is correct.`
+
 // validateVerificationConfig validates a VerificationConfig using struct tags
 // and the provided validator instance. Ensures all required fields are present,
 // numeric values are within acceptable ranges, and the prompt template contains
 // minimum required content for effective verification.
 func validateVerificationConfig(v *validator.Validate, config VerificationConfig) error {
 	if err := v.Struct(config); err != nil {
-		return fmt.Errorf("configuration validation failed: %w", err)
+		return fieldValidationError(err)
+	}
+	if err := validateResponseFields(config.ResponseFields); err != nil {
+		return err
+	}
+	if config.DisagreementVariance > 0 {
+		if !config.StructuredIssues {
+			return fmt.Errorf("disagreement_variance requires structured_issues")
+		}
+		if config.Mode == VerificationModeWinnerCorrectness {
+			return fmt.Errorf("disagreement_variance requires the judging_quality mode")
+		}
 	}
 	return nil
 }
@@ -148,16 +323,16 @@ func (vu *VerificationUnit) validateAndCompileConfig(
 	config VerificationConfig,
 	llmClient ports.LLMClient,
 	unitName string,
-) (*template.Template, error) {
+) (PromptRenderer, error) {
 	if llmClient == nil {
 		return nil, fmt.Errorf("unit %s: LLM client cannot be nil", unitName)
 	}
 
 	if err := validateVerificationConfig(vu.validator, config); err != nil {
-		return nil, fmt.Errorf("unit %s: %w", unitName, err)
+		return nil, domain.NewConfigValidationError(unitName, err)
 	}
 
-	tmpl, err := template.New("verificationPrompt").Funcs(GetTemplateFuncMap()).Parse(config.PromptTemplate)
+	renderer, err := newPromptRenderer(config.TemplateEngine, "verificationPrompt", config.PromptTemplate)
 	if err != nil {
 		return nil, fmt.Errorf("unit %s: failed to parse prompt template: %w", unitName, err)
 	}
@@ -166,7 +341,7 @@ func (vu *VerificationUnit) validateAndCompileConfig(
 		return nil, fmt.Errorf("unit %s: LLM client model is not configured", unitName)
 	}
 
-	return tmpl, nil
+	return renderer, nil
 }
 
 // NewVerificationUnit creates a new VerificationUnit with the specified name,
@@ -189,87 +364,59 @@ func NewVerificationUnit(
 		name:      name,
 		config:    config,
 		llmClient: llmClient,
-		validator: validator.New(),
+		validator: newConfigValidator(),
 		tracer:    otel.Tracer("verification-unit"),
 	}
 
-	tmpl, err := unit.validateAndCompileConfig(config, llmClient, name)
+	renderer, err := unit.validateAndCompileConfig(config, llmClient, name)
 	if err != nil {
 		return nil, err
 	}
 
-	unit.promptTemplate = tmpl
+	unit.promptRenderer = renderer
 	return unit, nil
 }
 
 // Name returns the unique identifier for this unit instance.
 func (vu *VerificationUnit) Name() string { return vu.name }
 
-// getQuestionFromState extracts the evaluation question from the state.
-// Returns an error with unit context if the question is not found.
-func (vu *VerificationUnit) getQuestionFromState(state domain.State) (string, error) {
-	question, ok := domain.Get(state, domain.KeyQuestion)
-	if !ok {
-		return "", fmt.Errorf("unit %s: question not found in state", vu.name)
-	}
-	return question, nil
-}
-
-// getAnswersFromState extracts the candidate answers from the state.
-// Returns an error with unit context if answers are not found.
-func (vu *VerificationUnit) getAnswersFromState(state domain.State) ([]domain.Answer, error) {
-	answers, ok := domain.Get(state, domain.KeyAnswers)
-	if !ok {
-		return nil, fmt.Errorf("unit %s: answers not found in state", vu.name)
+// mode returns the configured verification mode, treating an empty value
+// as the default judging_quality mode.
+func (vu *VerificationUnit) mode() string {
+	if vu.config.Mode == "" {
+		return VerificationModeJudgingQuality
 	}
-	return answers, nil
+	return vu.config.Mode
 }
 
 // getJudgeScoresFromState extracts judge scoring results from the state.
 // Returns an error if no judge scores are found, as verification requires
 // existing judgments to analyze.
 func (vu *VerificationUnit) getJudgeScoresFromState(state domain.State) ([]domain.JudgeSummary, error) {
-	judgeScores, ok := domain.Get(state, domain.KeyJudgeScores)
+	judgeScores, ok := state.GetJudgeScores()
 	if !ok || len(judgeScores) == 0 {
 		return nil, fmt.Errorf("unit %s: no judge scores found to verify", vu.name)
 	}
 	return judgeScores, nil
 }
 
-// getVerdictFromState extracts the current verdict from the state.
-// The verdict will be updated with human review flags based on verification results.
-func (vu *VerificationUnit) getVerdictFromState(state domain.State) (*domain.Verdict, error) {
-	verdict, ok := domain.Get(state, domain.KeyVerdict)
-	if !ok {
-		return nil, fmt.Errorf("unit %s: verdict not found in state", vu.name)
-	}
-	return verdict, nil
-}
-
-// getBudgetFromState extracts the budget report from the state.
-// Returns nil if no budget tracking is configured. Used for token usage accounting.
-func (vu *VerificationUnit) getBudgetFromState(state domain.State) *domain.BudgetReport {
-	budget, _ := domain.Get(state, domain.KeyBudget)
-	return budget
-}
-
-// getTraceLevelFromState extracts the trace level setting from the state.
-// Returns empty string if not configured. Debug level enables verification tracing.
-func (vu *VerificationUnit) getTraceLevelFromState(state domain.State) string {
-	traceLevel, _ := domain.Get(state, domain.KeyTraceLevel)
-	return strings.ToLower(traceLevel)
+// debugTracing reports whether the state's trace level asks for the
+// verification trace to be recorded.
+func (vu *VerificationUnit) debugTracing(state domain.State) bool {
+	traceLevel, _ := state.GetTraceLevel()
+	return strings.EqualFold(traceLevel, "debug")
 }
 
 // extractVerificationInputs retrieves all required data from the state
 // for verification analysis. Returns the question, answers, and judge scores
 // or an error if any required component is missing.
 func (vu *VerificationUnit) extractVerificationInputs(state domain.State) (string, []domain.Answer, []domain.JudgeSummary, error) {
-	question, err := vu.getQuestionFromState(state)
+	question, err := domain.Require(state, domain.KeyQuestion, vu.name)
 	if err != nil {
 		return "", nil, nil, err
 	}
 
-	answers, err := vu.getAnswersFromState(state)
+	answers, err := domain.Require(state, domain.KeyAnswers, vu.name)
 	if err != nil {
 		return "", nil, nil, err
 	}
@@ -282,13 +429,33 @@ func (vu *VerificationUnit) extractVerificationInputs(state domain.State) (strin
 	return question, answers, judgeScores, nil
 }
 
-// sanitizeUserContent protects against prompt injection attacks by wrapping
-// user-provided content in markdown code blocks and escaping existing delimiters.
-// This security measure prevents malicious inputs from breaking out of their
-// designated content areas and injecting commands into the verification prompt.
+// extractWinnerInputs retrieves the question and the verdict's winning answer
+// for winner_correctness verification. Judge scores are intentionally not
+// required so the check stays independent of the judging process.
+func (vu *VerificationUnit) extractWinnerInputs(state domain.State) (string, *domain.Answer, error) {
+	question, err := domain.Require(state, domain.KeyQuestion, vu.name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	verdict, err := domain.Require(state, domain.KeyVerdict, vu.name)
+	if err != nil {
+		return "", nil, err
+	}
+	if verdict == nil || verdict.WinnerAnswer == nil {
+		return "", nil, fmt.Errorf("unit %s: verdict has no winning answer to verify", vu.name)
+	}
+
+	return question, verdict.WinnerAnswer, nil
+}
+
+// sanitizeUserContent protects against prompt injection attacks by isolating
+// user-provided content with the configured SanitizationStrategy. By default
+// content is wrapped in markdown code blocks with existing fences escaped,
+// preventing malicious inputs from breaking out of their designated content
+// areas and injecting commands into the verification prompt.
 func (vu *VerificationUnit) sanitizeUserContent(content string) string {
-	content = strings.ReplaceAll(content, "```", "'''")
-	return "```\n" + content + "\n```\n"
+	return sanitizeContent(vu.config.Sanitization, content)
 }
 
 // sanitizeAnswers applies security sanitization to all answer content
@@ -306,9 +473,7 @@ func (vu *VerificationUnit) sanitizeAnswers(answers []domain.Answer) []string {
 func (vu *VerificationUnit) sanitizeJudgeScores(judgeScores []domain.JudgeSummary) []string {
 	sanitized := make([]string, len(judgeScores))
 	for i, score := range judgeScores {
-		scoreText := fmt.Sprintf("Score: %.2f, Confidence: %.2f\nReasoning: %s",
-			score.Score, score.Confidence, score.Reasoning)
-		sanitized[i] = vu.sanitizeUserContent(scoreText)
+		sanitized[i] = vu.sanitizeUserContent(formatJudgeScore(score))
 	}
 	return sanitized
 }
@@ -317,34 +482,183 @@ func (vu *VerificationUnit) sanitizeJudgeScores(judgeScores []domain.JudgeSummar
 // with sanitized user content to prevent prompt injection attacks.
 // The function applies security protections to all user inputs and appends
 // JSON format instructions to ensure reliable LLM response parsing.
+// omittedScores is the number of judge scores left out by score selection.
 func (vu *VerificationUnit) buildVerificationPrompt(
 	question string,
 	answers []domain.Answer,
 	judgeScores []domain.JudgeSummary,
+	omittedScores int,
+	criteria []domain.Criterion,
+	disagreements []judgeDisagreement,
 ) (string, error) {
-	var promptBuf bytes.Buffer
-	templateData := struct {
-		Question    string
-		Answers     []string
-		JudgeScores []string
-	}{
-		Question:    vu.sanitizeUserContent(question),
-		Answers:     vu.sanitizeAnswers(answers),
-		JudgeScores: vu.sanitizeJudgeScores(judgeScores),
-	}
-
-	if err := vu.promptTemplate.Execute(&promptBuf, templateData); err != nil {
+	return vu.renderPrompt(verificationTemplateData{
+		Question:         vu.sanitizeUserContent(question),
+		Answers:          vu.sanitizeAnswers(answers),
+		JudgeScores:      vu.sanitizeJudgeScores(judgeScores),
+		selectionNote:    describeScoreSelection(vu.config.ScoreSelection, len(judgeScores), omittedScores),
+		disagreementNote: describeDisagreements(disagreements),
+	}, criteria)
+}
+
+// buildWinnerPrompt creates the winner_correctness prompt containing only the
+// question, the sanitized winning answer, and any rubric criteria.
+func (vu *VerificationUnit) buildWinnerPrompt(question string, winner *domain.Answer, criteria []domain.Criterion) (string, error) {
+	return vu.renderPrompt(verificationTemplateData{
+		Question: vu.sanitizeUserContent(question),
+		Winner:   vu.sanitizeUserContent(winner.Content),
+	}, criteria)
+}
+
+// verificationTemplateData is the data passed to the verification prompt
+// template. Fields that do not apply to the configured mode are left empty.
+type verificationTemplateData struct {
+	Question    string
+	Answers     []string
+	JudgeScores []string
+	Winner      string
+
+	// selectionNote is appended after the rendered template when judge
+	// scores were omitted. It is unexported so templates cannot reference it.
+	selectionNote string
+	// disagreementNote is appended after selectionNote when the judges
+	// disagree sharply on some answers.
+	disagreementNote string
+}
+
+// renderPrompt renders the prompt template with the provided data and
+// appends the rubric criteria and JSON response format instructions.
+func (vu *VerificationUnit) renderPrompt(templateData verificationTemplateData, criteria []domain.Criterion) (string, error) {
+	basePrompt, err := vu.promptRenderer.Render(templateData)
+	if err != nil {
 		return "", fmt.Errorf("unit %s: failed to execute prompt template: %w", vu.name, err)
 	}
 
-	basePrompt := promptBuf.String()
 	// Instruct the LLM to respond in a specific JSON format for reliable parsing.
-	prompt := basePrompt + "\n\nIMPORTANT: You must respond with valid JSON in exactly this format:\n" +
-		`{\"confidence\": <0.0-1.0>, \"reasoning\": \"<detailed explanation>\", \"issues\": [<optional list of issues>], \"recommendation\": \"<optional recommendation>\", \"version\": 1}`
+	prompt := basePrompt + templateData.selectionNote + templateData.disagreementNote + describeCriteria(criteria) + "\n\nIMPORTANT: You must respond with valid JSON in exactly this format:\n" +
+		`{\"confidence\": <0.0-1.0>, \"reasoning\": \"<detailed explanation>\", \"issues\": [<optional list of issues>], \"recommendation\": \"<optional recommendation>\", \"version\": 1}` +
+		vu.describeIssueDetails() +
+		describeResponseFields(vu.config.ResponseFields)
 
 	return prompt, nil
 }
 
+// describeIssueDetails returns the prompt instructions for structured
+// issues, or an empty string when they are disabled.
+func (vu *VerificationUnit) describeIssueDetails() string {
+	if !vu.config.StructuredIssues {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n\nAlso include an \"issue_details\" array with one object per issue: " +
+		`{"category": "<category>", "severity": "low|medium|high|critical", "description": "<what is wrong>"}.`)
+	if categories := vu.issueCategories(); len(categories) > 0 {
+		fmt.Fprintf(&b, " The category must be one of: %s.", strings.Join(categories, ", "))
+	}
+	return b.String()
+}
+
+// judgeDisagreement records an answer whose judges disagree by more than
+// the configured variance.
+type judgeDisagreement struct {
+	answerID string
+	// variance is measured on the judges' own scale.
+	variance float64
+	// judges and scores are parallel, in judge name order.
+	judges []string
+	scores []float64
+}
+
+// findDisagreements returns the answers whose scores, across the judges in
+// domain.KeyJudgeScoresByJudge that scored every answer, have a population
+// variance above DisagreementVariance once normalized by the judges' score
+// scale. It returns nil when the pass is disabled or fewer than two judges
+// scored the answers, and wraps ErrMixedScoreScales when those judges
+// declared different scales.
+func (vu *VerificationUnit) findDisagreements(state domain.State, answers []domain.Answer) ([]judgeDisagreement, error) {
+	if vu.config.DisagreementVariance == 0 {
+		return nil, nil
+	}
+	byJudge, _ := domain.Get(state, domain.KeyJudgeScoresByJudge)
+	var judges []string
+	for _, judge := range slices.Sorted(maps.Keys(byJudge)) {
+		if len(byJudge[judge]) == len(answers) {
+			judges = append(judges, judge)
+		}
+	}
+	if len(judges) < 2 {
+		return nil, nil
+	}
+	scale, err := judgesScale(state, judges)
+	if err != nil {
+		return nil, err
+	}
+	width := 1.0
+	if scale.Max > scale.Min {
+		width = scale.Max - scale.Min
+	}
+
+	var disagreements []judgeDisagreement
+	for i, answer := range answers {
+		d := judgeDisagreement{answerID: answer.ID, judges: judges, scores: make([]float64, len(judges))}
+		var mean float64
+		for j, judge := range judges {
+			d.scores[j] = byJudge[judge][i].Score
+			mean += d.scores[j]
+		}
+		mean /= float64(len(judges))
+		for _, score := range d.scores {
+			d.variance += (score - mean) * (score - mean)
+		}
+		d.variance /= float64(len(judges))
+		if d.variance/(width*width) > vu.config.DisagreementVariance {
+			disagreements = append(disagreements, d)
+		}
+	}
+	return disagreements, nil
+}
+
+// describeDisagreements returns the prompt section asking the verifier to
+// explain each disagreement, or an empty string when there are none.
+func describeDisagreements(disagreements []judgeDisagreement) string {
+	if len(disagreements) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n\nThe judges disagree sharply on the following answers:")
+	for _, d := range disagreements {
+		scores := make([]string, len(d.judges))
+		for j, judge := range d.judges {
+			scores[j] = fmt.Sprintf("%s %.2f", judge, d.scores[j])
+		}
+		fmt.Fprintf(&b, "\n- Answer %s (variance %.4f): %s", d.answerID, d.variance, strings.Join(scores, ", "))
+	}
+	fmt.Fprintf(&b, "\n\nFor each of these answers, explain which judges diverged from the others and the most plausible reason, "+
+		"such as a judge misreading the answer or weighing a different criterion. "+
+		"Report each explanation as an issue_details entry with category %q.", DisagreementIssueCategory)
+	return b.String()
+}
+
+// disagreementAnswerIDs returns the answer IDs of disagreements.
+func disagreementAnswerIDs(disagreements []judgeDisagreement) []string {
+	ids := make([]string, len(disagreements))
+	for i, d := range disagreements {
+		ids[i] = d.answerID
+	}
+	return ids
+}
+
+// issueCategories returns the accepted structured issue categories:
+// IssueCategories, plus DisagreementIssueCategory when the
+// explain-disagreement pass is enabled. It returns nil when any category
+// is accepted.
+func (vu *VerificationUnit) issueCategories() []string {
+	categories := vu.config.IssueCategories
+	if len(categories) > 0 && vu.config.DisagreementVariance > 0 && !slices.Contains(categories, DisagreementIssueCategory) {
+		categories = append(slices.Clip(categories), DisagreementIssueCategory)
+	}
+	return categories
+}
+
 // estimateTokens provides a conservative estimate of token count for text
 // using a heuristic of approximately 4 characters per token.
 // This estimation is used for context limit checking and prompt truncation.
@@ -354,82 +668,92 @@ func (vu *VerificationUnit) estimateTokens(text string) int {
 	return len(text) / 4
 }
 
-// getModelContextLimit returns a conservative context limit for the LLM model
-// based on model name heuristics. These limits are intentionally conservative
-// to prevent context overflow and ensure reliable prompt processing.
-// Production systems should expose actual context limits through the client interface.
-func (vu *VerificationUnit) getModelContextLimit() int {
-	model := strings.ToLower(vu.llmClient.GetModel())
-	switch {
-	case strings.Contains(model, "gpt-4"):
-		return 6000 // For GPT-4 variants (8K-128K context)
-	case strings.Contains(model, "gpt-3.5"):
-		return 3000 // For GPT-3.5 variants (4K-16K context)
-	case strings.Contains(model, "claude"):
-		return 8000 // For Claude variants (8K-200K context)
-	default:
-		return 2000 // Conservative default for unknown models
-	}
+// promptTokenLimit returns the largest prompt, in tokens, that fits in the
+// context window of the model the unit calls, honoring a model override
+// recorded in state, after reserving MaxTokens for the response.
+func (vu *VerificationUnit) promptTokenLimit(state domain.State) int {
+	return max(unitContextWindow(state, vu.name, vu.llmClient)-vu.config.MaxTokens, 1)
 }
 
-// truncateAnswersIfNeeded truncates answer content proportionally when
-// the complete prompt would exceed the model's context limit.
-// Preserves all answers but reduces their content length to fit within
-// available token budget after accounting for question, judge scores, and template overhead.
-func (vu *VerificationUnit) truncateAnswersIfNeeded(
-	answers []domain.Answer,
-	judgeScores []domain.JudgeSummary,
+// verificationTemplateOverhead is the estimated token cost of the prompt
+// template and response instructions, reserved when fitting content into the
+// model's context limit.
+const verificationTemplateOverhead = 500
+
+// oversizePolicy returns the configured oversize policy, applying the
+// default.
+func (vu *VerificationUnit) oversizePolicy() OversizePolicy {
+	return vu.config.OversizePolicy.orDefault()
+}
+
+// fitAnswers applies the oversize policy when the answers in selection
+// would push the prompt past maxPromptTokens after accounting for the
+// question, judge scores, and template overhead. Each answer may use an
+// equal share of the remaining space; answers over their share are
+// truncated, omitted together with their judge score when the two are
+// aligned, or rejected with ErrPromptTooLarge. Truncated answers keep their
+// ID and metadata. oversized counts the answers the policy applied to.
+func (vu *VerificationUnit) fitAnswers(
+	selection scoreSelection,
 	question string,
 	maxPromptTokens int,
-) []domain.Answer {
-	questionTokens := vu.estimateTokens(question)
-	judgeTokens := 0
-	for _, score := range judgeScores {
-		judgeTokens += vu.estimateTokens(fmt.Sprintf("Score: %.2f, Reasoning: %s", score.Score, score.Reasoning))
+) (fitted scoreSelection, oversized int, err error) {
+	baseTokens := vu.estimateTokens(question) + verificationTemplateOverhead
+	for _, score := range selection.judgeScores {
+		baseTokens += vu.estimateTokens(fmt.Sprintf("Score: %.2f, Reasoning: %s", score.Score, score.Reasoning))
 	}
-
-	// Estimate template and instruction overhead.
-	templateOverhead := 500
-	baseTokens := questionTokens + judgeTokens + templateOverhead
 	availableForAnswers := maxPromptTokens - baseTokens
-	if availableForAnswers <= 0 {
-		return []domain.Answer{}
-	}
 
-	currentAnswerTokens := 0
-	for _, answer := range answers {
-		currentAnswerTokens += vu.estimateTokens(answer.Content)
+	answerTokens := 0
+	for _, answer := range selection.answers {
+		answerTokens += vu.estimateTokens(answer.Content)
 	}
-
-	if currentAnswerTokens <= availableForAnswers {
-		return answers
+	if availableForAnswers > 0 && answerTokens <= availableForAnswers {
+		return selection, 0, nil
 	}
 
-	// Truncate answers proportionally if they exceed the available space.
-	tokensPerAnswer := availableForAnswers / len(answers)
-	maxCharsPerAnswer := tokensPerAnswer * 4
+	policy := vu.oversizePolicy()
+	if policy == OversizeError {
+		return selection, 0, fmt.Errorf("%w: answers need ~%d tokens, %d available",
+			ErrPromptTooLarge, answerTokens, max(availableForAnswers, 0))
+	}
 
-	truncatedAnswers := make([]domain.Answer, len(answers))
-	for i, answer := range answers {
-		if len(answer.Content) <= maxCharsPerAnswer {
-			truncatedAnswers[i] = answer
-		} else {
-			truncatedContent := answer.Content[:maxCharsPerAnswer] + "... [truncated]"
-			truncatedAnswers[i] = domain.Answer{Content: truncatedContent}
+	maxCharsPerAnswer := 0
+	if availableForAnswers > 0 {
+		maxCharsPerAnswer = availableForAnswers / len(selection.answers) * 4
+	}
+	paired := len(selection.answers) == len(selection.judgeScores)
+
+	fitted = scoreSelection{omitted: selection.omitted}
+	for i, answer := range selection.answers {
+		if len(answer.Content) > maxCharsPerAnswer {
+			oversized++
+			if policy == OversizeSkipAnswer {
+				continue
+			}
+			answer.Content = truncateContent(answer.Content, maxCharsPerAnswer)
+		}
+		fitted.answers = append(fitted.answers, answer)
+		if paired {
+			fitted.judgeScores = append(fitted.judgeScores, selection.judgeScores[i])
 		}
 	}
-	return truncatedAnswers
+	if !paired {
+		fitted.judgeScores = selection.judgeScores
+	}
+	return fitted, oversized, nil
 }
 
 // callVerificationLLM invokes the LLM client to perform verification analysis.
 // Configures temperature, max tokens, and JSON response format when supported.
-// Returns the response text along with input/output token counts for budget tracking.
-// Retry logic is handled by the RetryingLLMClient middleware.
-func (vu *VerificationUnit) callVerificationLLM(ctx context.Context, prompt string) (string, int, int, error) {
+// Honors a model override for this unit recorded in state.
+// Returns the response text along with input/output token counts and the
+// provider calls made, including retries, for budget tracking.
+func (vu *VerificationUnit) callVerificationLLM(ctx context.Context, prompt string, state domain.State) (string, int, int, int, error) {
 	promptTokens := vu.estimateTokens(prompt)
-	contextLimit := vu.getModelContextLimit()
+	contextLimit := vu.promptTokenLimit(state)
 	if promptTokens > contextLimit {
-		return "", 0, 0, fmt.Errorf("unit %s: prompt too large (%d tokens) for model context limit (%d)",
+		return "", 0, 0, 0, fmt.Errorf("unit %s: prompt too large (%d tokens) for model context limit (%d)",
 			vu.name, promptTokens, contextLimit)
 	}
 
@@ -440,41 +764,82 @@ func (vu *VerificationUnit) callVerificationLLM(ctx context.Context, prompt stri
 	if supportsJSONMode(vu.llmClient) {
 		options["response_format"] = map[string]string{"type": "json_object"}
 	}
+	if model, ok := domain.ModelOverride(state, vu.name); ok {
+		options["model"] = model
+	}
+	if len(vu.config.StopSequences) > 0 {
+		options["stop"] = vu.config.StopSequences
+	}
+	if vu.config.Deterministic {
+		options["deterministic"] = true
+	}
+
+	return completeMetered(ctx, vu.llmClient, prompt, options)
+}
 
-	// The retry logic is now handled by the RetryingLLMClient middleware
-	return vu.llmClient.CompleteWithUsage(ctx, prompt, options)
+// hasReviewSeverityIssue reports whether any structured issue meets the
+// configured ReviewSeverity.
+func (vu *VerificationUnit) hasReviewSeverityIssue(resp *LLMVerificationResponse) bool {
+	if vu.config.ReviewSeverity == "" {
+		return false
+	}
+	for _, issue := range resp.IssueDetails {
+		if issue.Severity.AtLeast(vu.config.ReviewSeverity) {
+			return true
+		}
+	}
+	return false
 }
 
 // updateVerdictWithVerification updates the verdict's RequiresHumanReview flag
 // based on the verification confidence score compared to the configured threshold.
+// In winner_correctness mode the verifier's confidence is its confidence
+// that the winner is correct, so the verdict's confidence is lowered to it
+// when the verifier is less sure than the judges.
 func (vu *VerificationUnit) updateVerdictWithVerification(
 	state domain.State,
 	verificationResp *LLMVerificationResponse,
 ) (domain.State, error) {
-	verdict, err := vu.getVerdictFromState(state)
+	verdict, err := domain.Require(state, domain.KeyVerdict, vu.name)
 	if err != nil {
 		return state, err
 	}
 
-	if verificationResp.Confidence < vu.config.ConfidenceThreshold {
+	if verificationResp.Confidence < vu.config.ConfidenceThreshold || vu.hasReviewSeverityIssue(verificationResp) {
 		verdict.RequiresHumanReview = true
 	}
+	if vu.mode() == VerificationModeWinnerCorrectness {
+		verdict.Confidence = min(verdict.Confidence, verificationResp.Confidence)
+	}
 
 	return domain.With(state, domain.KeyVerdict, verdict), nil
 }
 
 // addVerificationTrace adds detailed verification information to the state
 // when debug tracing is enabled. The trace is serialized to JSON for storage.
+// action is the oversize action applied to the prompt's answers, or "none".
 func (vu *VerificationUnit) addVerificationTrace(
 	state domain.State,
 	verificationResp *LLMVerificationResponse,
+	action string,
+	disagreements []judgeDisagreement,
 ) domain.State {
-	if vu.getTraceLevelFromState(state) == "debug" {
+	if vu.debugTracing(state) {
 		trace := VerificationTrace{
+			SchemaVersion:  VerificationTraceSchemaVersion,
 			Confidence:     verificationResp.Confidence,
 			Reasoning:      verificationResp.Reasoning,
 			Issues:         verificationResp.Issues,
+			IssueDetails:   verificationResp.IssueDetails,
 			Recommendation: verificationResp.Recommendation,
+			Mode:           vu.mode(),
+			CustomFields:   verificationResp.CustomFields,
+		}
+		if action != "none" {
+			trace.OversizeAction = action
+		}
+		if len(disagreements) > 0 {
+			trace.DisagreementAnswerIDs = disagreementAnswerIDs(disagreements)
 		}
 		// Serialize trace to JSON string for storage
 		traceJSON, err := json.Marshal(trace)
@@ -487,63 +852,91 @@ func (vu *VerificationUnit) addVerificationTrace(
 	return state
 }
 
-// safeAddTokens safely adds token counts with overflow protection.
-// Validates input parameters and prevents integer overflow when accumulating
-// token usage across multiple LLM calls. Returns the maximum integer value
-// if overflow would occur, ensuring budget tracking remains stable.
-func (vu *VerificationUnit) safeAddTokens(current, tokensIn, tokensOut int) int {
-	// Check for negative inputs
-	if tokensIn < 0 || tokensOut < 0 || current < 0 {
-		return current // Invalid input, return current value
+// decisiveSkipReason reports whether verification should be skipped
+// because the verdict is decisive under the configured escalation
+// thresholds, and if so why. It never skips when no threshold is set.
+func (vu *VerificationUnit) decisiveSkipReason(state domain.State) (string, bool, error) {
+	if vu.config.EscalateBelowConfidence == 0 && vu.config.EscalateBelowMargin == 0 {
+		return "", false, nil
 	}
 
-	// Check for overflow before addition
-	maxInt := int(^uint(0) >> 1)
-	if current > maxInt-tokensIn-tokensOut {
-		return maxInt // Would overflow, return max int
+	verdict, err := domain.Require(state, domain.KeyVerdict, vu.name)
+	if err != nil {
+		return "", false, err
+	}
+	if verdict == nil || verdict.WinnerAnswer == nil {
+		return "", false, nil
 	}
 
-	return current + tokensIn + tokensOut
+	var reasons []string
+	if threshold := vu.config.EscalateBelowConfidence; threshold > 0 {
+		if verdict.Confidence < threshold {
+			return "", false, nil
+		}
+		reasons = append(reasons, fmt.Sprintf("confidence %.3f >= %.3f", verdict.Confidence, threshold))
+	}
+	if threshold := vu.config.EscalateBelowMargin; threshold > 0 {
+		margin, ok := winnerMargin(verdict)
+		if !ok || margin < threshold {
+			return "", false, nil
+		}
+		reasons = append(reasons, fmt.Sprintf("winner margin %.3f >= %.3f", margin, threshold))
+	}
+	return "decisive verdict: " + strings.Join(reasons, ", "), true, nil
 }
 
-// safeIncrementCalls safely increments a call count with overflow protection.
-// Prevents integer overflow when tracking LLM API calls in long-running processes.
-// Returns the current value if incrementing would cause overflow.
-func (vu *VerificationUnit) safeIncrementCalls(current int) int {
-	maxInt := int(^uint(0) >> 1)
-	if current == maxInt {
-		return current
+// winnerMargin returns how far the verdict's winner leads the runner-up.
+// A verdict with a single ranked answer has an unbounded margin; ok is
+// false when the verdict has no ranked answers.
+func winnerMargin(verdict *domain.Verdict) (margin float64, ok bool) {
+	switch len(verdict.RankedAnswers) {
+	case 0:
+		return 0, false
+	case 1:
+		return math.Inf(1), true
+	default:
+		return verdict.RankedAnswers[0].Score - verdict.RankedAnswers[1].Score, true
 	}
-	return current + 1
 }
 
-// updateBudgetWithTokens updates the budget report with token usage
-// and call count from the verification LLM request. Uses safe arithmetic
-// to prevent integer overflow in long-running processes.
-func (vu *VerificationUnit) updateBudgetWithTokens(state domain.State, tokensIn, tokensOut int) domain.State {
-	if budget := vu.getBudgetFromState(state); budget != nil {
-		budget.TokensUsed = vu.safeAddTokens(budget.TokensUsed, tokensIn, tokensOut)
-		budget.CallsMade = vu.safeIncrementCalls(budget.CallsMade)
-		return domain.With(state, domain.KeyBudget, budget)
+// addSkipTrace records a decisive-verdict skip in the verification trace
+// when debug tracing is enabled.
+func (vu *VerificationUnit) addSkipTrace(state domain.State, reason string) domain.State {
+	if !vu.debugTracing(state) {
+		return state
 	}
-	return state
+	traceJSON, err := json.Marshal(VerificationTrace{
+		SchemaVersion: VerificationTraceSchemaVersion,
+		Mode:          vu.mode(),
+		SkipReason:    reason,
+	})
+	if err != nil {
+		return domain.With(state, domain.KeyVerificationTrace, reason)
+	}
+	return domain.With(state, domain.KeyVerificationTrace, string(traceJSON))
 }
 
 // Execute verifies the quality of judging results by analyzing them with an LLM.
 // It extracts the question, answers, and judge scores from the state, builds a
 // verification prompt with security protections, and calls the LLM for analysis.
+// In winner_correctness mode only the question and the verdict's winning answer
+// are sent, and the confidence reflects whether the winner is actually correct.
 //
 // The method updates the verdict's RequiresHumanReview flag when the LLM's
 // confidence score falls below the configured threshold. Token usage is tracked
 // in the budget, and debug traces are added when trace level is set to "debug".
+// When escalation thresholds are configured, decisive verdicts are returned
+// unchanged without calling the LLM, and the skip is recorded in the trace.
 //
 // Context cancellation is supported throughout the LLM call chain.
 // Returns an error if required state data is missing or LLM analysis fails.
 func (vu *VerificationUnit) Execute(ctx context.Context, state domain.State) (domain.State, error) {
-	_, span := vu.tracer.Start(ctx, "VerificationUnit.Execute",
+	ctx, span := vu.tracer.Start(ctx, "VerificationUnit.Execute",
 		trace.WithAttributes(
 			attribute.String("unit.type", "verification"),
 			attribute.String("unit.id", vu.name),
+			attribute.String("config.mode", vu.mode()),
+			attribute.String("config.sanitization", string(vu.config.Sanitization)),
 			attribute.Float64("config.confidence_threshold", vu.config.ConfidenceThreshold),
 			attribute.Float64("config.temperature", vu.config.Temperature),
 			attribute.Int("config.max_tokens", vu.config.MaxTokens),
@@ -553,31 +946,106 @@ func (vu *VerificationUnit) Execute(ctx context.Context, state domain.State) (do
 
 	start := time.Now()
 
-	question, answers, judgeScores, err := vu.extractVerificationInputs(state)
+	var (
+		question      string
+		answers       []domain.Answer
+		judgeScores   []domain.JudgeSummary
+		disagreements []judgeDisagreement
+		prompt        string
+		action        = "none"
+		err           error
+	)
+	criteria, err := resolveCriteria(state, vu.config.Criteria)
+	if err != nil {
+		err := fmt.Errorf("unit %s: %w", vu.name, err)
+		span.RecordError(err)
+		return state, err
+	}
+	skipReason, skip, err := vu.decisiveSkipReason(state)
 	if err != nil {
 		span.RecordError(err)
 		return state, err
 	}
+	if skip {
+		span.SetAttributes(
+			attribute.Bool("eval.skipped_decisive", true),
+			attribute.String("eval.skip_reason", skipReason),
+		)
+		return vu.addSkipTrace(state, skipReason), nil
+	}
+
+	// An item without answers has nothing to verify; the judge that found
+	// it empty already recorded a verdict saying so.
+	if hasNoAnswersVerdict(state) {
+		span.SetAttributes(attribute.Bool("eval.skipped_no_answers", true))
+		return state, nil
+	}
 
-	contextLimit := vu.getModelContextLimit()
-	truncatedAnswers := vu.truncateAnswersIfNeeded(answers, judgeScores, question, contextLimit)
+	if vu.mode() == VerificationModeWinnerCorrectness {
+		// An abstained verdict has no winner to check and is already
+		// flagged for human review, so there is nothing to verify.
+		if verdict, ok := state.GetVerdict(); ok && verdict != nil && verdict.Status == domain.VerdictAbstained {
+			span.SetAttributes(attribute.Bool("eval.skipped_abstained", true))
+			return state, nil
+		}
+
+		var winner *domain.Answer
+		question, winner, err = vu.extractWinnerInputs(state)
+		if err != nil {
+			span.RecordError(err)
+			return state, err
+		}
+		prompt, err = vu.buildWinnerPrompt(question, winner, criteria)
+	} else {
+		question, answers, judgeScores, err = vu.extractVerificationInputs(state)
+		if err != nil {
+			span.RecordError(err)
+			return state, err
+		}
 
-	prompt, err := vu.buildVerificationPrompt(question, truncatedAnswers, judgeScores)
+		contextLimit := vu.promptTokenLimit(state)
+		selection := vu.selectJudgeScores(answers, judgeScores, question, contextLimit)
+		if selection.omitted > 0 {
+			span.SetAttributes(attribute.Int("eval.judge_scores_omitted", selection.omitted))
+		}
+		var oversized int
+		selection, oversized, err = vu.fitAnswers(selection, question, contextLimit)
+		if err != nil {
+			err := fmt.Errorf("unit %s: %w", vu.name, err)
+			span.RecordError(err)
+			return state, err
+		}
+		action = oversizeAction(vu.oversizePolicy(), oversized)
+		span.SetAttributes(
+			attribute.String("eval.oversize_action", action),
+			attribute.Int("eval.answers_oversize", oversized),
+		)
+		disagreements, err = vu.findDisagreements(state, answers)
+		if err != nil {
+			err := fmt.Errorf("unit %s: %w", vu.name, err)
+			span.RecordError(err)
+			return state, err
+		}
+		if len(disagreements) > 0 {
+			span.SetAttributes(attribute.StringSlice("eval.disagreement_answers", disagreementAnswerIDs(disagreements)))
+		}
+		prompt, err = vu.buildVerificationPrompt(question, selection.answers, selection.judgeScores, selection.omitted, criteria, disagreements)
+	}
 	if err != nil {
 		span.RecordError(err)
 		return state, err
 	}
 
-	response, tokensIn, tokensOut, err := vu.callVerificationLLM(ctx, prompt)
+	response, tokensIn, tokensOut, calls, err := vu.callVerificationLLM(ctx, prompt, state)
 	if err != nil {
-		err := fmt.Errorf("unit %s: LLM call failed: %w", vu.name, err)
+		err := domain.NewLLMCallError(vu.name, "", err)
 		span.RecordError(err)
 		return state, err
 	}
 
 	verificationResp, err := vu.parseLLMResponse(response)
 	if err != nil {
-		err := fmt.Errorf("unit %s: failed to parse LLM response: %w", vu.name, err)
+		err := domain.NewResponseParseError(vu.name, "", err)
 		span.RecordError(err)
 		return state, err
 	}
@@ -588,8 +1056,14 @@ func (vu *VerificationUnit) Execute(ctx context.Context, state domain.State) (do
 		return state, err
 	}
 
-	state = vu.addVerificationTrace(state, verificationResp)
-	state = vu.updateBudgetWithTokens(state, tokensIn, tokensOut)
+	state = vu.addVerificationTrace(state, verificationResp, action, disagreements)
+	if verificationResp.CustomFields != nil {
+		state = domain.With(state, domain.KeyVerificationFields, verificationResp.CustomFields)
+	}
+	if vu.config.StructuredIssues {
+		state = domain.With(state, domain.KeyVerificationIssues, verificationResp.IssueDetails)
+	}
+	state = chargeBudget(state, tokensIn+tokensOut, calls)
 
 	latency := time.Since(start)
 	span.SetAttributes(
@@ -598,7 +1072,9 @@ func (vu *VerificationUnit) Execute(ctx context.Context, state domain.State) (do
 		attribute.Int("eval.judge_scores_count", len(judgeScores)),
 		attribute.Int("eval.question_length", len(question)),
 		attribute.Float64("eval.verification_confidence", verificationResp.Confidence),
-		attribute.Bool("eval.requires_human_review", verificationResp.Confidence < vu.config.ConfidenceThreshold),
+		attribute.Bool("eval.requires_human_review",
+			verificationResp.Confidence < vu.config.ConfidenceThreshold || vu.hasReviewSeverityIssue(verificationResp)),
+		attribute.Int("eval.structured_issues_count", len(verificationResp.IssueDetails)),
 		attribute.Int("eval.tokens_in", tokensIn),
 		attribute.Int("eval.tokens_out", tokensOut),
 		attribute.Bool("no_llm_cost", false), // LLM-based units have cost
@@ -620,23 +1096,62 @@ func (vu *VerificationUnit) Validate() error {
 // Uses extractJSON to handle various response formats (markdown blocks, plain JSON)
 // and validates the parsed structure using struct tags to ensure data integrity.
 func (vu *VerificationUnit) parseLLMResponse(response string) (*LLMVerificationResponse, error) {
-	jsonStr := extractJSON(response)
+	jsonStr, err := extractResponseJSON(response, vu.config.StrictJSON, vu.config.JSONSelection)
+	if err != nil {
+		return nil, err
+	}
 	if jsonStr == "" {
 		return nil, fmt.Errorf("no valid JSON found in LLM response (len: %d)", len(response))
 	}
 
-	var llmResponse LLMVerificationResponse
-	if err := json.Unmarshal([]byte(jsonStr), &llmResponse); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON response (len: %d): %w", len(jsonStr), err)
+	llmResponse, err := verificationResponseVersions.decode(jsonStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkFinite("confidence", llmResponse.Confidence); err != nil {
+		return nil, err
 	}
 
 	if err := vu.validator.Struct(llmResponse); err != nil {
 		return nil, fmt.Errorf("invalid response structure: %w", err)
 	}
 
+	if err := vu.validateIssueDetails(&llmResponse); err != nil {
+		return nil, fmt.Errorf("invalid response structure: %w", err)
+	}
+
+	customFields, err := parseResponseFields(jsonStr, vu.config.ResponseFields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response structure: %w", err)
+	}
+	llmResponse.CustomFields = customFields
+
 	return &llmResponse, nil
 }
 
+// validateIssueDetails validates structured issues against the configured
+// categories and backfills Issues from their descriptions. Structured
+// issues are discarded when StructuredIssues is disabled.
+func (vu *VerificationUnit) validateIssueDetails(resp *LLMVerificationResponse) error {
+	if !vu.config.StructuredIssues {
+		resp.IssueDetails = nil
+		return nil
+	}
+	categories := vu.issueCategories()
+	for i, issue := range resp.IssueDetails {
+		if err := issue.Validate(categories); err != nil {
+			return fmt.Errorf("issue %d: %w", i+1, err)
+		}
+	}
+	if len(resp.Issues) == 0 {
+		for _, issue := range resp.IssueDetails {
+			resp.Issues = append(resp.Issues, issue.Description)
+		}
+	}
+	return nil
+}
+
 // UnmarshalParameters deserializes YAML parameters and returns a new
 // VerificationUnit instance with the updated configuration.
 // This method maintains immutability and thread-safety by creating a new
@@ -648,7 +1163,7 @@ func (vu *VerificationUnit) UnmarshalParameters(params yaml.Node) (*Verification
 		return nil, fmt.Errorf("failed to decode parameters: %w", err)
 	}
 
-	tmpl, err := vu.validateAndCompileConfig(config, vu.llmClient, vu.name)
+	renderer, err := vu.validateAndCompileConfig(config, vu.llmClient, vu.name)
 	if err != nil {
 		return nil, err
 	}
@@ -658,7 +1173,7 @@ func (vu *VerificationUnit) UnmarshalParameters(params yaml.Node) (*Verification
 		config:         config,
 		llmClient:      vu.llmClient,
 		validator:      vu.validator,
-		promptTemplate: tmpl,
+		promptRenderer: renderer,
 		tracer:         otel.Tracer("verification-unit"),
 	}, nil
 }
@@ -683,5 +1198,11 @@ func NewVerificationFromConfig(id string, config map[string]any, llm ports.LLMCl
 		return nil, fmt.Errorf("parse config: %w", err)
 	}
 
+	// Winner verification needs a prompt that references {{.Winner}}; swap in
+	// the matching default unless the caller supplied their own template.
+	if _, ok := config["prompt_template"]; !ok && cfg.Mode == VerificationModeWinnerCorrectness {
+		cfg.PromptTemplate = defaultWinnerCorrectnessPrompt
+	}
+
 	return NewVerificationUnit(id, llm, cfg)
 }
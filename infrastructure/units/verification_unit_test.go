@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -867,6 +870,12 @@ func TestParseLLMResponse(t *testing.T) {
 			wantErr:  true,
 			errMsg:   "invalid response structure",
 		},
+		{
+			name:     "overflowing confidence is not finite",
+			response: `{"confidence": 1e400, "reasoning": "Confidence overflows float64"}`,
+			wantErr:  true,
+			errMsg:   "score is not a finite number: confidence is 1e400",
+		},
 	}
 
 	for _, tt := range tests {
@@ -888,6 +897,23 @@ func TestParseLLMResponse(t *testing.T) {
 	}
 }
 
+// TestParseLLMResponse_StrictJSON tests that strict verification units reject
+// responses with content after the JSON object.
+func TestParseLLMResponse_StrictJSON(t *testing.T) {
+	response := `{"confidence": 0.85, "reasoning": "Consistent", "version": 1}` + "\nHope this helps!"
+
+	lenient := &VerificationUnit{validator: testutils.NewTestValidator()}
+	_, err := lenient.parseLLMResponse(response)
+	require.NoError(t, err)
+
+	strict := &VerificationUnit{
+		validator: testutils.NewTestValidator(),
+		config:    VerificationConfig{StrictJSON: true},
+	}
+	_, err = strict.parseLLMResponse(response)
+	require.ErrorIs(t, err, ErrTrailingContent)
+}
+
 // TestDefaultVerificationConfig tests that the default configuration is created with the expected values.
 func TestDefaultVerificationConfig(t *testing.T) {
 	config := defaultVerificationConfig()
@@ -900,3 +926,768 @@ func TestDefaultVerificationConfig(t *testing.T) {
 	assert.Equal(t, DefaultVerificationTemperature, config.Temperature, "should use default temperature")
 	assert.Equal(t, DefaultVerificationMaxTokens, config.MaxTokens, "should use default max tokens")
 }
+
+// TestVerificationUnit_ExecuteWinnerCorrectness tests the winner_correctness mode.
+// It ensures the winning answer is sent to the verifier without judge scores,
+// that disagreement with the verdict triggers human review and lowers the
+// verdict's confidence, and that a missing
+// winner is reported as an error.
+func TestVerificationUnit_ExecuteWinnerCorrectness(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name              string
+		state             domain.State
+		llmResponse       string
+		expectHumanReview bool
+		expectConfidence  float64
+		wantErr           bool
+		errMsg            string
+	}{
+		{
+			name: "verifier agrees with winner",
+			state: buildState(
+				domain.KeyQuestion, "What is 2+2?",
+				domain.KeyVerdict, &domain.Verdict{
+					ID:           "v1",
+					WinnerAnswer: &domain.Answer{ID: "a1", Content: "4"},
+					Confidence:   0.8,
+				},
+			),
+			llmResponse:       `{"confidence": 0.95, "reasoning": "The answer 4 is arithmetically correct", "version": 1}`,
+			expectHumanReview: false,
+			expectConfidence:  0.8,
+		},
+		{
+			name: "verifier disagrees with winner triggers human review",
+			state: buildState(
+				domain.KeyQuestion, "What is 2+2?",
+				domain.KeyVerdict, &domain.Verdict{
+					ID:           "v1",
+					WinnerAnswer: &domain.Answer{ID: "a2", Content: "5"},
+					Confidence:   0.8,
+				},
+			),
+			llmResponse:       `{"confidence": 0.1, "reasoning": "The answer 5 is incorrect, 2+2 equals 4", "issues": ["Incorrect arithmetic"], "version": 1}`,
+			expectHumanReview: true,
+			expectConfidence:  0.1,
+		},
+		{
+			name: "abstained verdict is skipped",
+			state: buildState(
+				domain.KeyQuestion, "What is 2+2?",
+				domain.KeyVerdict, &domain.Verdict{
+					ID:                  "v1",
+					Status:              domain.VerdictAbstained,
+					RequiresHumanReview: true,
+				},
+			),
+			expectHumanReview: true,
+		},
+		{
+			name: "missing winner returns error",
+			state: buildState(
+				domain.KeyQuestion, "What is 2+2?",
+				domain.KeyVerdict, &domain.Verdict{ID: "v1"},
+			),
+			wantErr: true,
+			errMsg:  "verdict has no winning answer to verify",
+		},
+		{
+			name: "missing verdict returns error",
+			state: buildState(
+				domain.KeyQuestion, "What is 2+2?",
+			),
+			wantErr: true,
+			errMsg:  "verdict not found in state",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLLM := testutils.NewMockLLMClient("test-model")
+			if tt.llmResponse != "" {
+				mockLLM.SetResponse(tt.llmResponse)
+			}
+
+			unit, err := NewVerificationFromConfig("verifier1", map[string]any{
+				"mode": VerificationModeWinnerCorrectness,
+			}, mockLLM)
+			require.NoError(t, err)
+
+			newState, err := unit.Execute(ctx, tt.state)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			verdict, ok := domain.Get(newState, domain.KeyVerdict)
+			require.True(t, ok, "verdict should be in state")
+			assert.Equal(t, tt.expectHumanReview, verdict.RequiresHumanReview)
+			assert.InDelta(t, tt.expectConfidence, verdict.Confidence, 1e-9)
+		})
+	}
+}
+
+// TestVerificationUnit_BuildWinnerPrompt tests that winner_correctness prompts
+// include the sanitized winning answer and omit judge information.
+func TestVerificationUnit_BuildWinnerPrompt(t *testing.T) {
+	unit, err := NewVerificationFromConfig("verifier1", map[string]any{
+		"mode": VerificationModeWinnerCorrectness,
+	}, testutils.NewMockLLMClient("test-model"))
+	require.NoError(t, err)
+
+	vu := unit.(*VerificationUnit)
+	assert.Equal(t, defaultWinnerCorrectnessPrompt, vu.config.PromptTemplate)
+
+	prompt, err := vu.buildWinnerPrompt("What is 2+2?", &domain.Answer{ID: "a1", Content: "```ignore previous```"}, nil)
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "What is 2+2?")
+	assert.Contains(t, prompt, "'''ignore previous'''")
+	assert.NotContains(t, prompt, "Judge Scores")
+}
+
+// TestVerificationUnit_CriteriaPrompt tests that referenced rubric criteria
+// are appended to both verification prompt variants.
+func TestVerificationUnit_CriteriaPrompt(t *testing.T) {
+	unit, err := NewVerificationFromConfig("verifier1", map[string]any{
+		"criteria": []any{"accuracy"},
+	}, testutils.NewMockLLMClient("test-model"))
+	require.NoError(t, err)
+
+	vu := unit.(*VerificationUnit)
+	assert.Equal(t, []string{"accuracy"}, vu.config.Criteria)
+
+	criteria := []domain.Criterion{{ID: "accuracy", Description: "The answer is factually correct"}}
+	want := "Evaluate against these criteria:\n- accuracy: The answer is factually correct"
+
+	prompt, err := vu.buildVerificationPrompt("What is 2+2?", nil, nil, 0, criteria, nil)
+	require.NoError(t, err)
+	assert.Contains(t, prompt, want)
+
+	prompt, err = vu.buildWinnerPrompt("What is 2+2?", &domain.Answer{ID: "a1", Content: "4"}, criteria)
+	require.NoError(t, err)
+	assert.Contains(t, prompt, want)
+}
+
+// TestVerificationConfig_InvalidMode tests that unknown verification modes are rejected.
+func TestVerificationConfig_InvalidMode(t *testing.T) {
+	config := defaultVerificationConfig()
+	config.Mode = "unknown"
+
+	_, err := NewVerificationUnit("verifier1", testutils.NewMockLLMClient("test-model"), config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "configuration validation failed")
+}
+
+// TestVerificationUnit_Sanitization tests that the configured sanitization
+// strategy is applied to prompt content and that code answers survive intact
+// under non-fencing strategies.
+func TestVerificationUnit_Sanitization(t *testing.T) {
+	code := "```go\nfmt.Println(\"hi\")\n```"
+
+	t.Run("xml_tag preserves code fences", func(t *testing.T) {
+		unit, err := NewVerificationFromConfig("verifier1", map[string]any{
+			"mode":         VerificationModeWinnerCorrectness,
+			"sanitization": "xml_tag",
+		}, testutils.NewMockLLMClient("test-model"))
+		require.NoError(t, err)
+
+		prompt, err := unit.(*VerificationUnit).buildWinnerPrompt("Print hi", &domain.Answer{ID: "a1", Content: code}, nil)
+		require.NoError(t, err)
+		assert.Contains(t, prompt, "<content>\n"+code+"\n</content>")
+	})
+
+	t.Run("invalid strategy is rejected", func(t *testing.T) {
+		config := defaultVerificationConfig()
+		config.Sanitization = "html_escape"
+
+		_, err := NewVerificationUnit("verifier1", testutils.NewMockLLMClient("test-model"), config)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "configuration validation failed")
+	})
+}
+
+// TestVerificationUnit_ResponseFields tests that configured custom response
+// fields are requested in the prompt, validated, exposed via state, and
+// included in the debug trace.
+func TestVerificationUnit_ResponseFields(t *testing.T) {
+	config := defaultVerificationConfig()
+	config.ResponseFields = []ResponseField{
+		{Name: "severity", Type: ResponseFieldString, Required: true, Enum: []string{"low", "high"}},
+	}
+
+	state := buildState(
+		domain.KeyQuestion, "What is 2+2?",
+		domain.KeyAnswers, []domain.Answer{{ID: "a1", Content: "4"}},
+		domain.KeyJudgeScores, []domain.JudgeSummary{{Score: 10.0, Confidence: 0.95, Reasoning: "Correct answer"}},
+		domain.KeyVerdict, &domain.Verdict{ID: "v1"},
+		domain.KeyTraceLevel, "debug",
+	)
+
+	t.Run("custom fields reach state and trace", func(t *testing.T) {
+		mockLLM := testutils.NewMockLLMClient("test-model")
+		mockLLM.SetResponse(`{"confidence": 0.9, "reasoning": "The judging is consistent", "severity": "low"}`)
+		unit, err := NewVerificationUnit("verifier", mockLLM, config)
+		require.NoError(t, err)
+
+		prompt, err := unit.buildVerificationPrompt("What is 2+2?", nil, nil, 0, nil, nil)
+		require.NoError(t, err)
+		assert.Contains(t, prompt, `"severity" (string, required), one of: low, high`)
+
+		newState, err := unit.Execute(context.Background(), state)
+		require.NoError(t, err)
+
+		fields, ok := domain.Get(newState, domain.KeyVerificationFields)
+		require.True(t, ok)
+		assert.Equal(t, map[string]any{"severity": "low"}, fields)
+
+		traceJSON, ok := domain.Get(newState, domain.KeyVerificationTrace)
+		require.True(t, ok)
+		var trace VerificationTrace
+		require.NoError(t, json.Unmarshal([]byte(traceJSON), &trace))
+		assert.Equal(t, map[string]any{"severity": "low"}, trace.CustomFields)
+	})
+
+	t.Run("nulls inside arrays and objects are kept", func(t *testing.T) {
+		cfg := defaultVerificationConfig()
+		cfg.ResponseFields = []ResponseField{
+			{Name: "tags", Type: ResponseFieldArray},
+			{Name: "details", Type: ResponseFieldObject},
+		}
+		mockLLM := testutils.NewMockLLMClient("test-model")
+		mockLLM.SetResponse(`{"confidence": 0.9, "reasoning": "The judging is consistent",
+			"tags": ["x", null, {"y": null}], "details": {"source": null, "list": [null]}}`)
+		unit, err := NewVerificationUnit("verifier", mockLLM, cfg)
+		require.NoError(t, err)
+
+		newState, err := unit.Execute(context.Background(), state)
+		require.NoError(t, err)
+
+		fields, ok := domain.Get(newState, domain.KeyVerificationFields)
+		require.True(t, ok)
+		assert.Equal(t, map[string]any{
+			"tags":    []any{"x", nil, map[string]any{"y": nil}},
+			"details": map[string]any{"source": nil, "list": []any{nil}},
+		}, fields)
+	})
+
+	t.Run("missing required field fails", func(t *testing.T) {
+		mockLLM := testutils.NewMockLLMClient("test-model")
+		mockLLM.SetResponse(`{"confidence": 0.9, "reasoning": "The judging is consistent"}`)
+		unit, err := NewVerificationUnit("verifier", mockLLM, config)
+		require.NoError(t, err)
+
+		_, err = unit.Execute(context.Background(), state)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"severity" is required`)
+	})
+
+	t.Run("config shadowing core field is rejected", func(t *testing.T) {
+		bad := defaultVerificationConfig()
+		bad.ResponseFields = []ResponseField{{Name: "reasoning", Type: ResponseFieldString}}
+		_, err := NewVerificationUnit("verifier", testutils.NewMockLLMClient("test-model"), bad)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "conflicts with a core verification field")
+	})
+}
+
+// TestVerificationUnit_StructuredIssues verifies that structured issues are
+// requested, validated, stored in state and the trace, backfill the string
+// issue list, and trigger severity-based human review.
+func TestVerificationUnit_StructuredIssues(t *testing.T) {
+	config := defaultVerificationConfig()
+	config.StructuredIssues = true
+	config.IssueCategories = []string{"factual_error", "judge_bias"}
+	config.ReviewSeverity = domain.IssueSeverityHigh
+
+	newState := func() domain.State {
+		return buildState(
+			domain.KeyQuestion, "What is 2+2?",
+			domain.KeyAnswers, []domain.Answer{{ID: "a1", Content: "4"}},
+			domain.KeyJudgeScores, []domain.JudgeSummary{{Score: 10.0, Confidence: 0.95, Reasoning: "Correct answer"}},
+			domain.KeyVerdict, &domain.Verdict{ID: "v1"},
+			domain.KeyTraceLevel, "debug",
+		)
+	}
+
+	tests := []struct {
+		name         string
+		response     string
+		wantErr      string
+		wantReview   bool
+		wantIssues   []domain.VerificationIssue
+		wantStrings  []string
+		structuredOn bool
+	}{
+		{
+			name: "severe issue triggers review",
+			response: `{"confidence": 0.95, "reasoning": "The judging is mostly consistent", "issue_details": [
+				{"category": "judge_bias", "severity": "high", "description": "Judge favored longer answers"}]}`,
+			wantReview: true,
+			wantIssues: []domain.VerificationIssue{
+				{Category: "judge_bias", Severity: domain.IssueSeverityHigh, Description: "Judge favored longer answers"},
+			},
+			wantStrings:  []string{"Judge favored longer answers"},
+			structuredOn: true,
+		},
+		{
+			name: "minor issue keeps string list",
+			response: `{"confidence": 0.95, "reasoning": "The judging is consistent", "issues": ["minor wording"], "issue_details": [
+				{"category": "factual_error", "severity": "low", "description": "Slight imprecision"}]}`,
+			wantIssues: []domain.VerificationIssue{
+				{Category: "factual_error", Severity: domain.IssueSeverityLow, Description: "Slight imprecision"},
+			},
+			wantStrings:  []string{"minor wording"},
+			structuredOn: true,
+		},
+		{
+			name: "unknown category fails",
+			response: `{"confidence": 0.95, "reasoning": "The judging is consistent", "issue_details": [
+				{"category": "style", "severity": "low", "description": "Terse"}]}`,
+			wantErr:      `unknown category "style"`,
+			structuredOn: true,
+		},
+		{
+			name: "unknown severity fails",
+			response: `{"confidence": 0.95, "reasoning": "The judging is consistent", "issue_details": [
+				{"category": "judge_bias", "severity": "urgent", "description": "Bias"}]}`,
+			wantErr:      `unknown severity "urgent"`,
+			structuredOn: true,
+		},
+		{
+			name: "ignored when disabled",
+			response: `{"confidence": 0.95, "reasoning": "The judging is consistent", "issue_details": [
+				{"category": "judge_bias", "severity": "critical", "description": "Bias"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config
+			if !tt.structuredOn {
+				cfg = defaultVerificationConfig()
+			}
+			mockLLM := testutils.NewMockLLMClient("test-model")
+			mockLLM.SetResponse(tt.response)
+			unit, err := NewVerificationUnit("verifier", mockLLM, cfg)
+			require.NoError(t, err)
+
+			result, err := unit.Execute(context.Background(), newState())
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, domain.ErrInvalidIssue)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			verdict, _ := domain.Get(result, domain.KeyVerdict)
+			assert.Equal(t, tt.wantReview, verdict.RequiresHumanReview)
+
+			issues, ok := domain.Get(result, domain.KeyVerificationIssues)
+			assert.Equal(t, tt.structuredOn, ok)
+			assert.Equal(t, tt.wantIssues, issues)
+
+			traceJSON, _ := domain.Get(result, domain.KeyVerificationTrace)
+			var trace VerificationTrace
+			require.NoError(t, json.Unmarshal([]byte(traceJSON), &trace))
+			assert.Equal(t, tt.wantIssues, trace.IssueDetails)
+			assert.Equal(t, tt.wantStrings, trace.Issues)
+		})
+	}
+
+	unit, err := NewVerificationUnit("verifier", testutils.NewMockLLMClient("test-model"), config)
+	require.NoError(t, err)
+	prompt, err := unit.buildVerificationPrompt("What is 2+2?", nil, nil, 0, nil, nil)
+	require.NoError(t, err)
+	assert.Contains(t, prompt, `"issue_details"`)
+	assert.Contains(t, prompt, "The category must be one of: factual_error, judge_bias.")
+
+	bad := defaultVerificationConfig()
+	bad.ReviewSeverity = "urgent"
+	_, err = NewVerificationUnit("verifier", testutils.NewMockLLMClient("test-model"), bad)
+	require.Error(t, err)
+}
+
+// TestVerificationUnit_ExplainDisagreement verifies that answers whose
+// judges disagree beyond the variance threshold are listed in the prompt
+// and the trace, and that the verifier's disagreement issues are accepted.
+func TestVerificationUnit_ExplainDisagreement(t *testing.T) {
+	answers := []domain.Answer{{ID: "a1", Content: "4"}, {ID: "a2", Content: "5"}}
+	newState := func(byJudge map[string][]float64, scales ...domain.ScoreRange) domain.State {
+		state := buildState(
+			domain.KeyQuestion, "What is 2+2?",
+			domain.KeyAnswers, answers,
+			domain.KeyVerdict, &domain.Verdict{ID: "v1"},
+			domain.KeyTraceLevel, "debug",
+		)
+		for _, judge := range slices.Sorted(maps.Keys(byJudge)) {
+			summaries := make([]domain.JudgeSummary, len(byJudge[judge]))
+			for i, score := range byJudge[judge] {
+				summaries[i] = domain.JudgeSummary{Score: score, Confidence: 0.9, Reasoning: "Scored by " + judge}
+			}
+			state = domain.WithJudgeScores(state, judge, summaries)
+			if len(scales) > 0 {
+				state = domain.WithJudgeScoreScale(state, judge, scales[0])
+				scales = scales[1:]
+			}
+		}
+		return state
+	}
+	tenPoint := domain.ScoreRange{Min: 1, Max: 10}
+
+	config := defaultVerificationConfig()
+	config.StructuredIssues = true
+	config.IssueCategories = []string{"judge_bias"}
+	config.DisagreementVariance = 0.05
+	mockLLM := testutils.NewMockLLMClient("test-model")
+	mockLLM.SetResponse(`{"confidence": 0.6, "reasoning": "The judges split on the first answer", "issue_details": [
+		{"category": "judge_disagreement", "severity": "medium", "description": "judge_b missed that 4 is correct"}]}`)
+	unit, err := NewVerificationUnit("verifier", mockLLM, config)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		byJudge map[string][]float64
+		scales  []domain.ScoreRange
+		wantIDs []string
+		wantErr error
+	}{
+		{name: "sharp disagreement", byJudge: map[string][]float64{"judge_a": {0.9, 0.2}, "judge_b": {0.2, 0.3}}, wantIDs: []string{"a1"}},
+		{name: "judges agree", byJudge: map[string][]float64{"judge_a": {0.9, 0.2}, "judge_b": {0.8, 0.3}}},
+		{name: "single judge", byJudge: map[string][]float64{"judge_a": {0.9, 0.2}}},
+		{name: "partial judge ignored", byJudge: map[string][]float64{"judge_a": {0.9, 0.2}, "judge_b": {0.1}}},
+		{
+			name:    "one point apart on a ten point scale agree",
+			byJudge: map[string][]float64{"judge_a": {9, 2}, "judge_b": {8, 3}},
+			scales:  []domain.ScoreRange{tenPoint, tenPoint},
+		},
+		{
+			name:    "disagreement on a ten point scale",
+			byJudge: map[string][]float64{"judge_a": {9, 2}, "judge_b": {2, 3}},
+			scales:  []domain.ScoreRange{tenPoint, tenPoint},
+			wantIDs: []string{"a1"},
+		},
+		{
+			name:    "mixed scales",
+			byJudge: map[string][]float64{"judge_a": {9, 2}, "judge_b": {0.2, 0.3}},
+			scales:  []domain.ScoreRange{tenPoint, domain.UnitScoreRange},
+			wantErr: ErrMixedScoreScales,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			disagreements, err := unit.findDisagreements(newState(tt.byJudge, tt.scales...), answers)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantIDs == nil {
+				assert.Empty(t, disagreements)
+				return
+			}
+			assert.Equal(t, tt.wantIDs, disagreementAnswerIDs(disagreements))
+		})
+	}
+
+	state := newState(map[string][]float64{"judge_a": {0.9, 0.2}, "judge_b": {0.2, 0.3}})
+	disagreements, err := unit.findDisagreements(state, answers)
+	require.NoError(t, err)
+	prompt, err := unit.buildVerificationPrompt("What is 2+2?", answers, nil, 0, nil, disagreements)
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "- Answer a1 (variance 0.1225): judge_a 0.90, judge_b 0.20")
+	assert.NotContains(t, prompt, "- Answer a2")
+	assert.Contains(t, prompt, `category "judge_disagreement"`)
+	assert.Contains(t, prompt, "The category must be one of: judge_bias, judge_disagreement.")
+
+	result, err := unit.Execute(context.Background(), state)
+	require.NoError(t, err)
+	issues, _ := domain.Get(result, domain.KeyVerificationIssues)
+	require.Len(t, issues, 1)
+	assert.Equal(t, DisagreementIssueCategory, issues[0].Category)
+	traceJSON, _ := domain.Get(result, domain.KeyVerificationTrace)
+	var trace VerificationTrace
+	require.NoError(t, json.Unmarshal([]byte(traceJSON), &trace))
+	assert.Equal(t, []string{"a1"}, trace.DisagreementAnswerIDs)
+
+	for _, modify := range []func(*VerificationConfig){
+		func(c *VerificationConfig) { c.StructuredIssues = false },
+		func(c *VerificationConfig) { c.Mode = VerificationModeWinnerCorrectness },
+		func(c *VerificationConfig) { c.DisagreementVariance = -1 },
+	} {
+		bad := config
+		modify(&bad)
+		_, err := NewVerificationUnit("verifier", testutils.NewMockLLMClient("test-model"), bad)
+		assert.Error(t, err)
+	}
+}
+
+// TestVerificationUnit_DecisiveSkip verifies that escalation thresholds skip
+// the verifier for decisive verdicts and record the skip in the trace.
+func TestVerificationUnit_DecisiveSkip(t *testing.T) {
+	ranked := func(scores ...float64) []domain.RankedAnswer {
+		out := make([]domain.RankedAnswer, len(scores))
+		for i, score := range scores {
+			out[i] = domain.RankedAnswer{Answer: domain.Answer{ID: fmt.Sprintf("a%d", i+1)}, Score: score}
+		}
+		return out
+	}
+
+	tests := []struct {
+		name       string
+		confidence float64
+		margin     float64
+		verdict    *domain.Verdict
+		wantSkip   bool
+		wantReason string
+	}{
+		{
+			name:       "confident verdict skipped",
+			confidence: 0.7,
+			verdict:    &domain.Verdict{Confidence: 0.9, RankedAnswers: ranked(0.9, 0.2)},
+			wantSkip:   true,
+			wantReason: "confidence 0.900 >= 0.700",
+		},
+		{
+			name:       "low confidence escalates",
+			confidence: 0.7,
+			verdict:    &domain.Verdict{Confidence: 0.5, RankedAnswers: ranked(0.9, 0.2)},
+		},
+		{
+			name:       "wide margin skipped",
+			margin:     0.3,
+			verdict:    &domain.Verdict{Confidence: 0.5, RankedAnswers: ranked(0.9, 0.2)},
+			wantSkip:   true,
+			wantReason: "winner margin 0.700 >= 0.300",
+		},
+		{
+			name:    "narrow margin escalates",
+			margin:  0.3,
+			verdict: &domain.Verdict{Confidence: 0.9, RankedAnswers: ranked(0.9, 0.8)},
+		},
+		{
+			name:    "missing ranking escalates",
+			margin:  0.3,
+			verdict: &domain.Verdict{Confidence: 0.9},
+		},
+		{
+			name:       "either threshold escalates",
+			confidence: 0.7,
+			margin:     0.3,
+			verdict:    &domain.Verdict{Confidence: 0.9, RankedAnswers: ranked(0.9, 0.8)},
+		},
+		{
+			name:     "disabled always verifies",
+			verdict:  &domain.Verdict{Confidence: 1.0, RankedAnswers: ranked(1.0)},
+			wantSkip: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.verdict.WinnerAnswer = &domain.Answer{ID: "a1", Content: "4"}
+			state := buildState(
+				domain.KeyQuestion, "What is 2+2?",
+				domain.KeyAnswers, []domain.Answer{{ID: "a1", Content: "4"}, {ID: "a2", Content: "5"}},
+				domain.KeyJudgeScores, []domain.JudgeSummary{{Score: 0.9, Confidence: 0.9, Reasoning: "Correct answer"}},
+				domain.KeyVerdict, tt.verdict,
+				domain.KeyTraceLevel, "debug",
+			)
+
+			client := &sequenceClient{
+				MockLLMClient: testutils.NewMockLLMClient("test-model"),
+				responses:     []string{`{"confidence": 0.4, "reasoning": "The judging looks inconsistent"}`},
+			}
+			config := defaultVerificationConfig()
+			config.EscalateBelowConfidence = tt.confidence
+			config.EscalateBelowMargin = tt.margin
+			unit, err := NewVerificationUnit("verifier", client, config)
+			require.NoError(t, err)
+
+			result, err := unit.Execute(context.Background(), state)
+			require.NoError(t, err)
+
+			verdict, _ := domain.Get(result, domain.KeyVerdict)
+			traceJSON, _ := domain.Get(result, domain.KeyVerificationTrace)
+			var trace VerificationTrace
+			require.NoError(t, json.Unmarshal([]byte(traceJSON), &trace))
+
+			if tt.wantSkip {
+				assert.Zero(t, client.calls)
+				assert.False(t, verdict.RequiresHumanReview)
+				assert.Equal(t, "decisive verdict: "+tt.wantReason, trace.SkipReason)
+				return
+			}
+			assert.Equal(t, 1, client.calls)
+			assert.True(t, verdict.RequiresHumanReview)
+			assert.Empty(t, trace.SkipReason)
+		})
+	}
+}
+
+// TestVerificationUnit_ScoreSelection verifies that selection policies keep
+// the most informative judge scores, with their answers, when the full set
+// does not fit in the context limit.
+func TestVerificationUnit_ScoreSelection(t *testing.T) {
+	scores := []float64{0.5, 0.9, 0.1, 0.6, 0.4, 0.55, 0.3, 0.8}
+	answers := make([]domain.Answer, len(scores))
+	judgeScores := make([]domain.JudgeSummary, len(scores))
+	for i, score := range scores {
+		answers[i] = domain.Answer{ID: fmt.Sprintf("a%d", i), Content: "short answer"}
+		// Each judge score costs about 310 tokens, so four fit in a 2000
+		// token limit.
+		judgeScores[i] = domain.JudgeSummary{Score: score, Confidence: 0.8, Reasoning: strings.Repeat("r", 1200)}
+	}
+
+	tests := []struct {
+		name        string
+		policy      string
+		judgeScores []domain.JudgeSummary
+		wantScores  []float64
+	}{
+		{name: "truncate keeps every score", policy: ScoreSelectionTruncate, judgeScores: judgeScores, wantScores: scores},
+		{name: "extremes", policy: ScoreSelectionExtremes, judgeScores: judgeScores, wantScores: []float64{0.5, 0.9, 0.1, 0.8}},
+		{name: "top answers", policy: ScoreSelectionTopAnswers, judgeScores: judgeScores, wantScores: []float64{0.9, 0.6, 0.55, 0.8}},
+		{
+			name:        "fitting scores are kept",
+			policy:      ScoreSelectionExtremes,
+			judgeScores: judgeScores[:3],
+			wantScores:  scores[:3],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := defaultVerificationConfig()
+			config.ScoreSelection = tt.policy
+			vu, err := NewVerificationUnit("verifier", testutils.NewMockLLMClient("test-model"), config)
+			require.NoError(t, err)
+
+			selection := vu.selectJudgeScores(answers[:len(tt.judgeScores)], tt.judgeScores, "What is 2+2?", 2000)
+
+			got := make([]float64, len(selection.judgeScores))
+			for i, score := range selection.judgeScores {
+				got[i] = score.Score
+			}
+			assert.Equal(t, tt.wantScores, got)
+			assert.Equal(t, len(tt.judgeScores)-len(got), selection.omitted)
+			require.Len(t, selection.answers, len(got), "answers stay paired with their scores")
+			for i, answer := range selection.answers {
+				assert.Equal(t, fmt.Sprintf("a%d", slices.Index(scores, got[i])), answer.ID)
+			}
+
+			prompt, err := vu.buildVerificationPrompt("What is 2+2?", selection.answers, selection.judgeScores, selection.omitted, nil, nil)
+			require.NoError(t, err)
+			if selection.omitted > 0 {
+				assert.Contains(t, prompt, fmt.Sprintf("only %d of %d judge scores are shown", len(got), len(tt.judgeScores)))
+			} else {
+				assert.NotContains(t, prompt, "judge scores are shown")
+			}
+		})
+	}
+}
+
+// TestVerificationUnit_TruncateAnswersKeepsIdentity verifies that truncated
+// answers keep their ID and metadata so they can still be matched to the
+// verdict.
+func TestVerificationUnit_TruncateAnswersKeepsIdentity(t *testing.T) {
+	unit, err := NewVerificationUnit("verifier", testutils.NewMockLLMClient("test-model"), defaultVerificationConfig())
+	require.NoError(t, err)
+
+	answers := []domain.Answer{
+		{ID: "a1", Content: strings.Repeat("long answer ", 200), Metadata: map[string]string{"model": "m1"}},
+		{ID: "a2", Content: "short"},
+	}
+	fitted, oversized, err := unit.fitAnswers(scoreSelection{answers: answers}, "Question?", verificationTemplateOverhead+200)
+	require.NoError(t, err)
+	assert.Equal(t, 1, oversized)
+
+	truncated := fitted.answers
+	require.Len(t, truncated, 2)
+	assert.Equal(t, "a1", truncated[0].ID)
+	assert.Equal(t, map[string]string{"model": "m1"}, truncated[0].Metadata)
+	assert.True(t, strings.HasSuffix(truncated[0].Content, "... [truncated]"))
+	assert.Equal(t, answers[1], truncated[1])
+}
+
+// TestVerificationUnit_OversizePolicy verifies each policy for answers that
+// do not fit in the prompt and that the applied action reaches the debug
+// trace.
+func TestVerificationUnit_OversizePolicy(t *testing.T) {
+	answers := []domain.Answer{
+		{ID: "a1", Content: strings.Repeat("long answer ", 200)},
+		{ID: "a2", Content: "short"},
+	}
+	scores := []domain.JudgeSummary{
+		{Score: 0.9, Reasoning: "thorough", Confidence: 0.9},
+		{Score: 0.2, Reasoning: "too brief", Confidence: 0.9},
+	}
+	selection := scoreSelection{answers: answers, judgeScores: scores}
+	limit := verificationTemplateOverhead + 200
+
+	tests := []struct {
+		name       string
+		policy     OversizePolicy
+		wantErr    error
+		wantIDs    []string
+		wantScores int
+	}{
+		{name: "default truncates", wantIDs: []string{"a1", "a2"}, wantScores: 2},
+		{name: "truncate", policy: OversizeTruncate, wantIDs: []string{"a1", "a2"}, wantScores: 2},
+		{name: "skip_answer drops answer and score", policy: OversizeSkipAnswer, wantIDs: []string{"a2"}, wantScores: 1},
+		{name: "error", policy: OversizeError, wantErr: ErrPromptTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := defaultVerificationConfig()
+			config.OversizePolicy = tt.policy
+			unit, err := NewVerificationUnit("verifier", testutils.NewMockLLMClient("test-model"), config)
+			require.NoError(t, err)
+
+			fitted, oversized, err := unit.fitAnswers(selection, "Question?", limit)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 1, oversized)
+
+			var ids []string
+			for _, answer := range fitted.answers {
+				ids = append(ids, answer.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Len(t, fitted.judgeScores, tt.wantScores)
+			assert.Equal(t, "too brief", fitted.judgeScores[len(fitted.judgeScores)-1].Reasoning)
+		})
+	}
+
+	t.Run("answers that fit are unchanged", func(t *testing.T) {
+		unit, err := NewVerificationUnit("verifier", testutils.NewMockLLMClient("test-model"), defaultVerificationConfig())
+		require.NoError(t, err)
+		fitted, oversized, err := unit.fitAnswers(selection, "Question?", 100_000)
+		require.NoError(t, err)
+		assert.Zero(t, oversized)
+		assert.Equal(t, selection, fitted)
+	})
+
+	t.Run("trace records action", func(t *testing.T) {
+		unit, err := NewVerificationUnit("verifier", testutils.NewMockLLMClient("test-model"), defaultVerificationConfig())
+		require.NoError(t, err)
+		state := unit.addVerificationTrace(domain.With(domain.NewState(), domain.KeyTraceLevel, "debug"),
+			&LLMVerificationResponse{Confidence: 0.9, Reasoning: "Judging looks consistent"}, string(OversizeSkipAnswer), nil)
+		trace, ok := domain.Get(state, domain.KeyVerificationTrace)
+		require.True(t, ok)
+		assert.Contains(t, trace, `"oversize_action":"skip_answer"`)
+
+		state = unit.addVerificationTrace(domain.With(domain.NewState(), domain.KeyTraceLevel, "debug"),
+			&LLMVerificationResponse{Confidence: 0.9, Reasoning: "Judging looks consistent"}, "none", nil)
+		trace, _ = domain.Get(state, domain.KeyVerificationTrace)
+		assert.NotContains(t, trace, "oversize_action")
+	})
+}
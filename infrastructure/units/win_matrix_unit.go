@@ -0,0 +1,589 @@
+package units
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+var _ ports.Unit = (*WinMatrixUnit)(nil)
+
+// Rating methods WinMatrixUnit derives answer ratings with.
+const (
+	// RatingElo replays the comparisons as a sequence of Elo matches.
+	RatingElo = "elo"
+	// RatingBradleyTerry fits a Bradley-Terry model to the win matrix.
+	RatingBradleyTerry = "bradley_terry"
+)
+
+// Win matrix defaults and rating constants.
+const (
+	// DefaultWinMatrixMaxAnswers is the default cap on the answers compared,
+	// bounding the quadratic number of LLM calls.
+	DefaultWinMatrixMaxAnswers = 10
+	// DefaultWinMatrixMaxTokens is the default token limit for each
+	// comparison.
+	DefaultWinMatrixMaxTokens = 300
+	// DefaultEloK is the default Elo K-factor, the largest rating change a
+	// single comparison can cause.
+	DefaultEloK = 32
+
+	// baseRating is the rating of an answer with an even record. Both
+	// methods report ratings on the Elo scale, where a 400 point lead
+	// means ten-to-one odds of winning a comparison.
+	baseRating = 1000
+	// eloScale is the rating difference at which the stronger answer is
+	// expected to win ten times as often as it loses.
+	eloScale = 400
+
+	// bradleyTerryIterations and bradleyTerryTolerance bound the
+	// Bradley-Terry fit.
+	bradleyTerryIterations = 1000
+	bradleyTerryTolerance  = 1e-9
+)
+
+// Comparison outcomes a win matrix judge may return.
+const (
+	comparisonFirst  = "A"
+	comparisonSecond = "B"
+	comparisonTie    = "tie"
+)
+
+// defaultWinMatrixPrompt asks the LLM which of two answers is better.
+const defaultWinMatrixPrompt = `Compare the two answers to the question below and decide which one is better. Consider accuracy, completeness, and clarity. Judge the content only: neither the order in which the answers are shown nor their length should affect your decision.
+
+{{.QuestionBlock}}
+
+{{.AnswerABlock}}
+
+{{.AnswerBBlock}}`
+
+// winMatrixResponseFormat is appended to every comparison prompt.
+const winMatrixResponseFormat = "\n\nIMPORTANT: You must respond with valid JSON in exactly this format:\n" +
+	`{"winner": "A", "reasoning": "<one or two sentences>"}` + "\n" +
+	`where "winner" is "A", "B", or "tie".`
+
+// WinMatrixUnit ranks answers the way chatbot arenas rank models: an LLM
+// compares every pair of answers, the outcomes are collected in an N×N
+// win/loss/tie matrix, and each answer receives a rating derived from the
+// matrix with Elo or Bradley-Terry. The answer with the highest rating
+// wins.
+//
+// To remove position bias, every pair is compared in both orders, so an LLM
+// that always prefers the first answer records one win for each side and
+// the pair nets out as even. Comparisons runs each order several times.
+// The unit makes N×(N-1)×Comparisons LLM calls, so MaxAnswers bounds N.
+//
+// The verdict's RankedAnswers carry the ratings as scores, AggregateScore
+// is the winner's rating, and the matrix is stored in Verdict.WinMatrix.
+// Confidence is how much likelier the winner is to beat the runner-up than
+// to lose to it under the ratings, from 0 for equal ratings to 1.
+//
+// The unit is stateless and thread-safe if its LLM client is.
+type WinMatrixUnit struct {
+	name           string
+	config         WinMatrixConfig
+	llmClient      ports.LLMClient
+	promptRenderer PromptRenderer
+	tracer         trace.Tracer
+}
+
+// WinMatrixConfig defines how a WinMatrixUnit compares answers and rates
+// them.
+type WinMatrixConfig struct {
+	// RatingMethod derives ratings from the comparisons: "bradley_terry"
+	// (the default), which fits all comparisons at once and does not
+	// depend on their order, or "elo".
+	RatingMethod string `yaml:"rating_method" json:"rating_method" validate:"required,oneof=elo bradley_terry"`
+
+	// Comparisons is how many times each pair of answers is compared in
+	// each order. Repeated comparisons only differ with a temperature
+	// above zero.
+	Comparisons int `yaml:"comparisons" json:"comparisons" validate:"min=1,max=10"`
+
+	// EloK is the Elo K-factor. Elo ratings depend on the order of the
+	// matches; the unit replays them pair by pair in input order.
+	EloK float64 `yaml:"elo_k" json:"elo_k" validate:"gt=0,max=400"`
+
+	// MaxAnswers is the most answers the unit compares. Items with more
+	// answers fail rather than incur the cost of every pairing.
+	MaxAnswers int `yaml:"max_answers" json:"max_answers" validate:"min=2,max=50"`
+
+	// PromptTemplate is the comparison prompt. It receives .Question,
+	// .AnswerA, and .AnswerB, and the labeled blocks .QuestionBlock,
+	// .AnswerABlock, and .AnswerBBlock. Only the blocks are delimited with
+	// PromptQuestionTag and PromptAnswerTag; the raw fields are substituted
+	// verbatim. The JSON response format is appended to it.
+	PromptTemplate string `yaml:"prompt_template,omitempty" json:"prompt_template,omitempty"`
+
+	// TemplateEngine selects the registered PromptRenderer used for
+	// PromptTemplate. Empty selects the built-in "go" engine.
+	TemplateEngine string `yaml:"template_engine,omitempty" json:"template_engine,omitempty"`
+
+	// Temperature controls randomness in the comparison LLM calls.
+	Temperature float64 `yaml:"temperature" json:"temperature" validate:"min=0.0,max=1.0"`
+
+	// MaxTokens limits each comparison's response length.
+	MaxTokens int `yaml:"max_tokens" json:"max_tokens" validate:"min=50,max=2000"`
+
+	// MaxConcurrency limits concurrent comparison calls.
+	MaxConcurrency int `yaml:"max_concurrency" json:"max_concurrency" validate:"min=1,max=20"`
+
+	// TieBreaker resolves answers with equal ratings: "first",
+	// "lowest_id", or "error".
+	TieBreaker TieBreaker `yaml:"tie_breaker" json:"tie_breaker" validate:"required,oneof=first error lowest_id"`
+
+	// AbstainThreshold makes the unit abstain instead of picking a winner
+	// when the verdict's confidence falls below it. Zero disables
+	// abstention.
+	AbstainThreshold float64 `yaml:"abstain_threshold" json:"abstain_threshold" validate:"min=0.0,max=1.0"`
+}
+
+// DefaultWinMatrixConfig returns a WinMatrixConfig that compares each pair
+// once in each order and rates answers with Bradley-Terry.
+func DefaultWinMatrixConfig() WinMatrixConfig {
+	return WinMatrixConfig{
+		RatingMethod:   RatingBradleyTerry,
+		Comparisons:    1,
+		EloK:           DefaultEloK,
+		MaxAnswers:     DefaultWinMatrixMaxAnswers,
+		MaxTokens:      DefaultWinMatrixMaxTokens,
+		MaxConcurrency: DefaultMaxConcurrency,
+		TieBreaker:     TieFirst,
+	}
+}
+
+// NewWinMatrixUnit creates a WinMatrixUnit that compares answers with
+// llmClient. Returns ErrEmptyUnitName if name is empty, ErrLLMClientNil if
+// llmClient is nil, or a configuration validation error.
+func NewWinMatrixUnit(name string, llmClient ports.LLMClient, config WinMatrixConfig) (*WinMatrixUnit, error) {
+	if name == "" {
+		return nil, ErrEmptyUnitName
+	}
+	if llmClient == nil {
+		return nil, ErrLLMClientNil
+	}
+	if err := validate.Struct(config); err != nil {
+		return nil, domain.NewConfigValidationError(name, fieldValidationError(err))
+	}
+
+	template := config.PromptTemplate
+	if template == "" {
+		template = defaultWinMatrixPrompt
+	}
+	renderer, err := newPromptRenderer(config.TemplateEngine, "winMatrixPrompt", template)
+	if err != nil {
+		return nil, fmt.Errorf("unit %s: failed to parse prompt template: %w", name, err)
+	}
+
+	return &WinMatrixUnit{
+		name:           name,
+		config:         config,
+		llmClient:      llmClient,
+		promptRenderer: renderer,
+		tracer:         otel.Tracer("win-matrix-unit"),
+	}, nil
+}
+
+// Name returns the unique identifier for this unit instance.
+func (wmu *WinMatrixUnit) Name() string { return wmu.name }
+
+// comparison is one LLM call comparing answers first and second, shown in
+// that order.
+type comparison struct {
+	first, second int
+	outcome       string
+}
+
+// Execute compares every pair of answers in both orders, rates the answers
+// from the resulting win matrix, and stores the verdict under
+// domain.KeyVerdict. Token usage of every comparison is charged to the
+// budget.
+//
+// Returns an error if the question or answers are missing, there are fewer
+// than two or more than MaxAnswers answers, a comparison fails or cannot be
+// parsed, or answers tie under the "error" tie breaker.
+func (wmu *WinMatrixUnit) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	ctx, span := wmu.tracer.Start(ctx, "WinMatrixUnit.Execute",
+		trace.WithAttributes(
+			attribute.String("unit.type", "win_matrix"),
+			attribute.String("unit.id", wmu.name),
+			attribute.String("config.rating_method", wmu.config.RatingMethod),
+			attribute.Int("config.comparisons", wmu.config.Comparisons),
+			attribute.String("config.tie_breaker", string(wmu.config.TieBreaker)),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+
+	question, err := domain.Require(state, domain.KeyQuestion, wmu.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+	answers, err := domain.Require(state, domain.KeyAnswers, wmu.name)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+	if err := checkMinAnswers(wmu.name, len(answers), 2); err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+	if len(answers) > wmu.config.MaxAnswers {
+		err := fmt.Errorf("unit %s: %d answers exceed max_answers %d", wmu.name, len(answers), wmu.config.MaxAnswers)
+		span.RecordError(err)
+		return state, err
+	}
+
+	comparisons, tokens, calls, err := wmu.compare(ctx, state, question, answers)
+	if err != nil {
+		span.RecordError(err)
+		return state, err
+	}
+	state = chargeBudget(state, tokens, calls)
+
+	ids := make([]string, len(answers))
+	for i, answer := range answers {
+		ids[i] = answer.ID
+	}
+	matrix := domain.NewWinMatrix(ids)
+	matrix.RatingMethod = wmu.config.RatingMethod
+	for _, c := range comparisons {
+		switch c.outcome {
+		case comparisonFirst:
+			matrix.Wins[c.first][c.second]++
+		case comparisonSecond:
+			matrix.Wins[c.second][c.first]++
+		default:
+			matrix.Ties[c.first][c.second]++
+			matrix.Ties[c.second][c.first]++
+		}
+	}
+
+	var ratings []float64
+	if wmu.config.RatingMethod == RatingElo {
+		ratings = eloRatings(len(answers), comparisons, wmu.config.EloK)
+	} else {
+		ratings = bradleyTerryRatings(matrix)
+	}
+	// Round away floating-point noise so that answers with identical
+	// records tie exactly and reach the tie breaker.
+	for i, rating := range ratings {
+		ratings[i] = math.Round(rating*1e6) / 1e6
+	}
+
+	winnerIdx, tieBreak, err := wmu.selectWinner(ratings, answers)
+	if err != nil {
+		err := fmt.Errorf("aggregation failed: %w", err)
+		span.RecordError(err)
+		return state, err
+	}
+	winner := answers[winnerIdx]
+
+	rank := func(score float64) float64 { return score }
+	verdict := domain.Verdict{
+		SchemaVersion:      domain.VerdictSchemaVersion,
+		ID:                 fmt.Sprintf("%s_verdict", wmu.name),
+		WinnerAnswer:       &winner,
+		AggregateScore:     ratings[winnerIdx],
+		DuplicateAnswerIDs: duplicateAnswerIDs(winner, answers),
+		RankedAnswers:      rankAnswers(answers, ratings, winner, wmu.config.TieBreaker, rank),
+		TieBreak:           tieBreak,
+		WinMatrix:          matrix,
+		// Participating units are stamped by the graph executor.
+		Provenance: &domain.Provenance{AggregationMethod: "win_matrix_" + wmu.config.RatingMethod},
+		CreatedAt:  time.Now(),
+	}
+	margin := verdict.RankedAnswers[0].Score - verdict.RankedAnswers[1].Score
+	verdict.Confidence = min(max(2*expectedWinRate(margin)-1, 0), 1)
+	applyAbstention(&verdict, wmu.config.AbstainThreshold)
+
+	span.SetAttributes(
+		attribute.Int64("eval.latency_ms", time.Since(start).Milliseconds()),
+		attribute.Int("eval.answers_count", len(answers)),
+		attribute.Int("eval.comparisons", len(comparisons)),
+		attribute.Float64("eval.aggregate_score", verdict.AggregateScore),
+		attribute.String("eval.winner_id", winner.ID),
+		attribute.Float64("eval.confidence", verdict.Confidence),
+		attribute.String("eval.status", string(verdict.Status)),
+	)
+
+	return domain.With(state, domain.KeyVerdict, &verdict), nil
+}
+
+// compare runs every comparison concurrently and returns them in a fixed
+// order, pair by pair in input order with both orders of a pair adjacent,
+// together with the tokens and provider calls they used.
+func (wmu *WinMatrixUnit) compare(
+	ctx context.Context,
+	state domain.State,
+	question string,
+	answers []domain.Answer,
+) ([]comparison, int, int, error) {
+	var comparisons []comparison
+	for i := range answers {
+		for j := i + 1; j < len(answers); j++ {
+			for range wmu.config.Comparisons {
+				comparisons = append(comparisons, comparison{first: i, second: j}, comparison{first: j, second: i})
+			}
+		}
+	}
+
+	options := map[string]any{
+		"temperature": wmu.config.Temperature,
+		"max_tokens":  wmu.config.MaxTokens,
+	}
+	if model, ok := domain.ModelOverride(state, wmu.name); ok {
+		options["model"] = model
+	}
+
+	tokens := make([]int, len(comparisons))
+	calls := make([]int, len(comparisons))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(wmu.config.MaxConcurrency)
+	for k := range comparisons {
+		g.Go(func() error {
+			c := &comparisons[k]
+			first, second := answers[c.first], answers[c.second]
+			prompt, err := wmu.renderPrompt(question, first.Content, second.Content)
+			if err != nil {
+				return fmt.Errorf("unit %s: %w", wmu.name, err)
+			}
+			detail := fmt.Sprintf("comparing %s with %s", first.ID, second.ID)
+			response, tokensIn, tokensOut, made, err := completeMetered(gctx, wmu.llmClient, prompt, options)
+			if err != nil {
+				return domain.NewLLMCallError(wmu.name, detail, err)
+			}
+			tokens[k], calls[k] = tokensIn+tokensOut, made
+			c.outcome, err = parseComparison(response)
+			if err != nil {
+				return domain.NewResponseParseError(wmu.name, detail, err)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, 0, 0, err
+	}
+
+	var totalTokens, totalCalls int
+	for k := range comparisons {
+		totalTokens += tokens[k]
+		totalCalls += calls[k]
+	}
+	return comparisons, totalTokens, totalCalls, nil
+}
+
+// renderPrompt renders the comparison prompt for one ordered pair and
+// appends the JSON response format.
+func (wmu *WinMatrixUnit) renderPrompt(question, answerA, answerB string) (string, error) {
+	prompt, err := wmu.promptRenderer.Render(struct {
+		Question      string
+		AnswerA       string
+		AnswerB       string
+		QuestionBlock string
+		AnswerABlock  string
+		AnswerBBlock  string
+	}{
+		Question:      question,
+		AnswerA:       answerA,
+		AnswerB:       answerB,
+		QuestionBlock: promptBlock("Question", PromptQuestionTag, question),
+		AnswerABlock:  promptBlock("Answer A", PromptAnswerTag, answerA),
+		AnswerBBlock:  promptBlock("Answer B", PromptAnswerTag, answerB),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to execute prompt template: %w", err)
+	}
+	return prompt + winMatrixResponseFormat, nil
+}
+
+// parseComparison returns the outcome of a comparison response: "A", "B",
+// or "tie".
+func parseComparison(response string) (string, error) {
+	jsonStr := extractJSON(response)
+	if jsonStr == "" {
+		return "", fmt.Errorf("no valid JSON found (response length: %d chars)", len(response))
+	}
+	var parsed struct {
+		Winner string `json:"winner"`
+	}
+	if err := json.Unmarshal([]byte(jsonStr), &parsed); err != nil {
+		return "", err
+	}
+	switch winner := strings.TrimSpace(parsed.Winner); {
+	case strings.EqualFold(winner, comparisonFirst):
+		return comparisonFirst, nil
+	case strings.EqualFold(winner, comparisonSecond):
+		return comparisonSecond, nil
+	case strings.EqualFold(winner, comparisonTie):
+		return comparisonTie, nil
+	default:
+		return "", fmt.Errorf("winner %q is not A, B, or tie", parsed.Winner)
+	}
+}
+
+// expectedWinRate returns the share of comparisons an answer is expected to
+// win against one rated margin points lower.
+func expectedWinRate(margin float64) float64 {
+	return 1 / (1 + math.Pow(10, -margin/eloScale))
+}
+
+// eloRatings replays the comparisons in order as Elo matches, starting every
+// answer at baseRating.
+func eloRatings(n int, comparisons []comparison, k float64) []float64 {
+	ratings := make([]float64, n)
+	for i := range ratings {
+		ratings[i] = baseRating
+	}
+	for _, c := range comparisons {
+		var result float64
+		switch c.outcome {
+		case comparisonFirst:
+			result = 1
+		case comparisonSecond:
+			result = 0
+		default:
+			result = 0.5
+		}
+		delta := k * (result - expectedWinRate(ratings[c.first]-ratings[c.second]))
+		ratings[c.first] += delta
+		ratings[c.second] -= delta
+	}
+	return ratings
+}
+
+// bradleyTerryRatings fits Bradley-Terry strengths to the win matrix with
+// the minorization-maximization algorithm, counting ties as half a win for
+// each side, and reports them on the Elo scale around baseRating. One
+// virtual tie is added to every compared pair so that answers that never
+// won, or never lost, still receive finite ratings.
+func bradleyTerryRatings(m *domain.WinMatrix) []float64 {
+	n := len(m.AnswerIDs)
+	games := make([][]float64, n)
+	wins := make([]float64, n)
+	for i := range n {
+		games[i] = make([]float64, n)
+		for j := range n {
+			played := float64(m.Wins[i][j] + m.Wins[j][i] + m.Ties[i][j])
+			if i == j || played == 0 {
+				continue
+			}
+			games[i][j] = played + 1
+			wins[i] += float64(m.Wins[i][j]) + float64(m.Ties[i][j]+1)/2
+		}
+	}
+
+	strengths := make([]float64, n)
+	for i := range strengths {
+		strengths[i] = 1
+	}
+	next := make([]float64, n)
+	for range bradleyTerryIterations {
+		for i := range n {
+			var denominator float64
+			for j := range n {
+				if games[i][j] > 0 {
+					denominator += games[i][j] / (strengths[i] + strengths[j])
+				}
+			}
+			next[i] = strengths[i]
+			if denominator > 0 {
+				next[i] = wins[i] / denominator
+			}
+		}
+
+		// Strengths are only defined up to a common factor; fix their
+		// geometric mean at one so the ratings center on baseRating.
+		var logSum float64
+		for _, s := range next {
+			logSum += math.Log(s)
+		}
+		norm := math.Exp(logSum / float64(n))
+		var change float64
+		for i := range n {
+			next[i] /= norm
+			change = max(change, math.Abs(next[i]-strengths[i]))
+		}
+		copy(strengths, next)
+		if change < bradleyTerryTolerance {
+			break
+		}
+	}
+
+	ratings := make([]float64, n)
+	for i, s := range strengths {
+		ratings[i] = baseRating + eloScale*math.Log10(s)
+	}
+	return ratings
+}
+
+// selectWinner returns the index of the answer with the highest rating,
+// resolving ties with the configured tie breaker.
+func (wmu *WinMatrixUnit) selectWinner(ratings []float64, answers []domain.Answer) (int, *domain.TieBreak, error) {
+	best := slices.Max(ratings)
+	tied := tiedIndices(ratings, best)
+	if len(tied) == 1 {
+		return tied[0], nil, nil
+	}
+
+	var winnerIdx int
+	var reason string
+	switch wmu.config.TieBreaker {
+	case TieError:
+		return 0, nil, fmt.Errorf("%w: %d answers with rating %.1f", ErrTie, len(tied), best)
+	case TieLowestID:
+		winnerIdx = lowestIDIndex(answers, tied)
+		reason = fmt.Sprintf("lowest answer ID %q among tied candidates", answers[winnerIdx].ID)
+	default:
+		winnerIdx = tied[0]
+		reason = "first tied candidate in input order"
+	}
+	return winnerIdx, &domain.TieBreak{
+		Strategy:         string(wmu.config.TieBreaker),
+		CandidateIndices: tied,
+		WinnerIndex:      winnerIdx,
+		Reason:           fmt.Sprintf("%d candidates with rating %.1f; %s", len(tied), best, reason),
+	}, nil
+}
+
+// Validate checks if the unit is properly configured and ready for
+// execution.
+func (wmu *WinMatrixUnit) Validate() error {
+	if wmu.llmClient == nil {
+		return fmt.Errorf("LLM client is not configured")
+	}
+	if err := validate.Struct(wmu.config); err != nil {
+		return domain.NewConfigValidationError(wmu.name, fieldValidationError(err))
+	}
+	return nil
+}
+
+// NewWinMatrixFromConfig creates a WinMatrixUnit from a configuration map.
+// This is the boundary adapter for YAML/JSON configuration.
+func NewWinMatrixFromConfig(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error) {
+	if llm == nil {
+		return nil, ErrLLMClientNil
+	}
+
+	cfg := DefaultWinMatrixConfig()
+	if err := overlayYAML(config, &cfg); err != nil {
+		return nil, err
+	}
+
+	return NewWinMatrixUnit(id, llm, cfg)
+}
@@ -0,0 +1,176 @@
+package units
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/testutils"
+)
+
+// preferenceClient compares two answers by their position in preferred,
+// earlier being better. With firstWins set it always prefers answer A,
+// modelling a judge with maximal position bias.
+type preferenceClient struct {
+	*testutils.MockLLMClient
+	preferred []string
+	firstWins bool
+	response  string
+
+	mu    sync.Mutex
+	calls int
+}
+
+// CompleteWithUsage returns the winner of the compared pair with 10 input
+// and 5 output tokens.
+func (c *preferenceClient) CompleteWithUsage(ctx context.Context, prompt string, options map[string]any) (string, int, int, error) {
+	c.mu.Lock()
+	c.calls++
+	c.mu.Unlock()
+
+	if c.response != "" {
+		return c.response, 10, 5, nil
+	}
+	if c.firstWins {
+		return `{"winner": "A", "reasoning": "The first answer is better."}`, 10, 5, nil
+	}
+	a := strings.Index(prompt, "Answer A:")
+	b := strings.Index(prompt, "Answer B:")
+	rankOf := func(block string) int {
+		for i, content := range c.preferred {
+			if strings.Contains(block, content) {
+				return i
+			}
+		}
+		return len(c.preferred)
+	}
+	if rankOf(prompt[a:b]) < rankOf(prompt[b:]) {
+		return `{"winner": "A", "reasoning": "A is better."}`, 10, 5, nil
+	}
+	return `{"winner": "b", "reasoning": "B is better."}`, 10, 5, nil
+}
+
+// winMatrixState returns a state with three answers to a capital question.
+func winMatrixState(t *testing.T) domain.State {
+	t.Helper()
+	return testutils.EvaluationState(t, "What is the capital of France?", []domain.Answer{
+		{ID: "a1", Content: "Lyon"},
+		{ID: "a2", Content: "Paris"},
+		{ID: "a3", Content: "Marseille"},
+	})
+}
+
+// TestWinMatrixUnit_Execute tests that pairwise comparisons in both orders
+// fill the win matrix and rank the answers with each rating method.
+func TestWinMatrixUnit_Execute(t *testing.T) {
+	for _, method := range []string{RatingBradleyTerry, RatingElo} {
+		t.Run(method, func(t *testing.T) {
+			client := &preferenceClient{
+				MockLLMClient: testutils.NewMockLLMClient("test-model"),
+				preferred:     []string{"Paris", "Lyon", "Marseille"},
+			}
+			unit, err := NewWinMatrixFromConfig("arena", map[string]any{"rating_method": method}, client)
+			require.NoError(t, err)
+
+			result, err := unit.Execute(context.Background(), winMatrixState(t))
+			require.NoError(t, err)
+			assert.Equal(t, 6, client.calls, "every pair is compared in both orders")
+			assert.Equal(t, int64(6), result.GetBudgetUsage().Calls)
+			assert.Equal(t, int64(90), result.GetBudgetUsage().Tokens)
+
+			verdict, ok := result.GetVerdict()
+			require.True(t, ok)
+			require.NotNil(t, verdict.WinnerAnswer)
+			assert.Equal(t, "a2", verdict.WinnerAnswer.ID)
+			require.Len(t, verdict.RankedAnswers, 3)
+			assert.Equal(t, "a1", verdict.RankedAnswers[1].Answer.ID)
+			assert.Equal(t, "a3", verdict.RankedAnswers[2].Answer.ID)
+			assert.Equal(t, verdict.RankedAnswers[0].Score, verdict.AggregateScore)
+			assert.Greater(t, verdict.Confidence, 0.0)
+			assert.Equal(t, "win_matrix_"+method, verdict.Provenance.AggregationMethod)
+
+			matrix := verdict.WinMatrix
+			require.NotNil(t, matrix)
+			assert.Equal(t, []string{"a1", "a2", "a3"}, matrix.AnswerIDs)
+			assert.Equal(t, [][]int{{0, 0, 2}, {2, 0, 2}, {0, 0, 0}}, matrix.Wins)
+			assert.Equal(t, method, matrix.RatingMethod)
+			assert.InDelta(t, 1.0, matrix.WinRate(1), 1e-9)
+		})
+	}
+}
+
+// TestWinMatrixUnit_PositionBias tests that a judge that always prefers the
+// first answer shown produces an even record for every answer.
+func TestWinMatrixUnit_PositionBias(t *testing.T) {
+	client := &preferenceClient{MockLLMClient: testutils.NewMockLLMClient("test-model"), firstWins: true}
+	unit, err := NewWinMatrixUnit("arena", client, DefaultWinMatrixConfig())
+	require.NoError(t, err)
+
+	result, err := unit.Execute(context.Background(), winMatrixState(t))
+	require.NoError(t, err)
+
+	verdict, _ := result.GetVerdict()
+	for i := range verdict.WinMatrix.AnswerIDs {
+		assert.InDelta(t, 0.5, verdict.WinMatrix.WinRate(i), 1e-9)
+		assert.InDelta(t, 1000.0, verdict.RankedAnswers[i].Score, 1e-6)
+	}
+	assert.Zero(t, verdict.Confidence)
+	require.NotNil(t, verdict.TieBreak)
+	assert.Equal(t, "a1", verdict.WinnerAnswer.ID)
+}
+
+// TestRatings tests the Elo update and the Bradley-Terry fit on a
+// two-answer matrix.
+func TestRatings(t *testing.T) {
+	elo := eloRatings(2, []comparison{{first: 0, second: 1, outcome: comparisonFirst}}, DefaultEloK)
+	assert.InDeltaSlice(t, []float64{1016, 984}, elo, 1e-9)
+
+	m := domain.NewWinMatrix([]string{"a1", "a2"})
+	m.Wins[0][1] = 3
+	bt := bradleyTerryRatings(m)
+	// With the virtual tie a1 has 3.5 wins of 4 games, odds of 7 to 1.
+	assert.InDelta(t, 400*math.Log10(7), bt[0]-bt[1], 1e-6)
+	assert.InDelta(t, 2000.0, bt[0]+bt[1], 1e-6)
+}
+
+// TestWinMatrixUnit_Errors tests answer-count limits, unparseable
+// comparisons, and configuration errors.
+func TestWinMatrixUnit_Errors(t *testing.T) {
+	client := &preferenceClient{MockLLMClient: testutils.NewMockLLMClient("test-model")}
+
+	unit, err := NewWinMatrixFromConfig("arena", map[string]any{"max_answers": 2}, client)
+	require.NoError(t, err)
+	_, err = unit.Execute(context.Background(), winMatrixState(t))
+	assert.ErrorContains(t, err, "exceed max_answers")
+
+	single := testutils.EvaluationState(t, "Capital?", []domain.Answer{{ID: "a1", Content: "Paris"}})
+	_, err = unit.Execute(context.Background(), single)
+	assert.ErrorIs(t, err, ErrTooFewAnswers)
+
+	bad := &preferenceClient{MockLLMClient: testutils.NewMockLLMClient("test-model"), response: `{"winner": "both"}`}
+	unit, err = NewWinMatrixFromConfig("arena", map[string]any{}, bad)
+	require.NoError(t, err)
+	_, err = unit.Execute(context.Background(), winMatrixState(t))
+	assert.ErrorIs(t, err, domain.ErrResponseParse)
+
+	for _, config := range []map[string]any{
+		{"rating_method": "glicko"},
+		{"comparisons": 0},
+		{"max_answers": 1},
+		{"tie_breaker": "random"},
+		{"prompt_template": "{{.Missing"},
+	} {
+		_, err := NewWinMatrixFromConfig("arena", config, client)
+		assert.Error(t, err, config)
+	}
+	_, err = NewWinMatrixFromConfig("arena", map[string]any{}, nil)
+	assert.ErrorIs(t, err, ErrLLMClientNil)
+	_, err = NewWinMatrixUnit("", client, DefaultWinMatrixConfig())
+	assert.ErrorIs(t, err, ErrEmptyUnitName)
+}
@@ -0,0 +1,239 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+// batchBudgetUnit is the unit ID reported in budget errors raised by
+// EvaluateBatch.
+const batchBudgetUnit = "batch"
+
+// BatchResult is the outcome of evaluating one item of a batch.
+type BatchResult struct {
+	// State is the item's final state. When the item failed it is the
+	// state the graph returned with the error, or the input state if the
+	// item never ran.
+	State domain.State
+
+	// Verdict is the item's verdict, or nil if the item failed or the
+	// graph produced none.
+	Verdict *domain.Verdict
+
+	// Usage is the tokens and calls the item consumed, as recorded in its
+	// State. A failed item reports the usage of the units that completed
+	// before the failure.
+	Usage domain.Usage
+
+	// Err is why the item failed; nil when it succeeded.
+	Err error
+
+	// EmitErr is why the batch's result sink rejected the item's verdict;
+	// nil when the verdict was emitted or there is no sink.
+	EmitErr error
+}
+
+// BatchOption configures EvaluateBatch.
+type BatchOption func(*batchConfig)
+
+// batchConfig holds the options of one EvaluateBatch call.
+type batchConfig struct {
+	// budget caps the tokens and calls of the whole batch. Zero fields
+	// are unlimited.
+	budget domain.Usage
+
+	// itemTimeout is the latency budget of each item, measured from when
+	// the item starts. Zero means no per-item deadline.
+	itemTimeout time.Duration
+
+	// sink receives each item's verdict as it completes. Nil means
+	// verdicts are only returned.
+	sink ports.ResultSink
+}
+
+// WithBatchBudget caps the total tokens and calls the batch may consume.
+// Zero means unlimited. Once the items that have finished exceed either
+// limit, items that have not started fail with an error wrapping
+// domain.ErrBudgetExceeded instead of running; items already in flight
+// complete.
+func WithBatchBudget(maxTokens, maxCalls int64) BatchOption {
+	return func(c *batchConfig) {
+		c.budget = domain.Usage{Tokens: maxTokens, Calls: maxCalls}
+	}
+}
+
+// WithItemTimeout gives every item timeout to finish, measured from when it
+// starts rather than from when the batch does. The deadline is recorded in
+// the item's State under domain.KeyDeadline, where the graph enforces it
+// across all of its units; an item that runs out of time fails with an
+// error wrapping domain.ErrDeadlineExceeded. An item that already carries
+// an earlier deadline keeps it. Zero means no per-item deadline.
+func WithItemTimeout(timeout time.Duration) BatchOption {
+	return func(c *batchConfig) {
+		c.itemTimeout = timeout
+	}
+}
+
+// WithResultSink streams each item's verdict to sink as soon as the item
+// completes, from the goroutine that evaluated it. Items are identified by
+// domain.KeyItemID, or by their index in the batch when it is unset. Items
+// that fail or produce no verdict are not emitted. To keep large runs from
+// holding every verdict in memory, the results of emitted items keep only
+// their Usage; State and Verdict are released. A sink error is reported
+// in the item's EmitErr and leaves State and Verdict in place.
+func WithResultSink(sink ports.ResultSink) BatchOption {
+	return func(c *batchConfig) {
+		c.sink = sink
+	}
+}
+
+// EvaluateBatch runs graph on every item with at most concurrency items in
+// flight and returns one BatchResult per item, in input order. Items are
+// independent: each runs on its own immutable State, so a failing item
+// does not affect the others. Values of concurrency below 1 are treated as
+// 1.
+//
+// When ctx is done, items that have not started fail with ctx.Err() and
+// in-flight items see the cancellation through their context. The returned
+// error reports invalid arguments only; per-item failures are in the
+// results.
+func EvaluateBatch(
+	ctx context.Context,
+	graph *Graph,
+	items []domain.State,
+	concurrency int,
+	opts ...BatchOption,
+) ([]BatchResult, error) {
+	if graph == nil {
+		return nil, errors.New("batch: graph cannot be nil")
+	}
+	var config batchConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if config.budget.Tokens < 0 || config.budget.Calls < 0 {
+		return nil, fmt.Errorf("batch: budget limits cannot be negative, got %d tokens and %d calls",
+			config.budget.Tokens, config.budget.Calls)
+	}
+	if config.itemTimeout < 0 {
+		return nil, fmt.Errorf("batch: item timeout cannot be negative, got %s", config.itemTimeout)
+	}
+
+	results := make([]BatchResult, len(items))
+	budget := &batchBudget{limit: config.budget}
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for range min(max(concurrency, 1), len(items)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				results[i] = evaluateItem(ctx, graph, items[i], budget, config.itemTimeout)
+				if config.sink != nil {
+					results[i] = emitResult(ctx, config.sink, i, results[i])
+				}
+			}
+		}()
+	}
+
+	next := 0
+feed:
+	for ; next < len(items); next++ {
+		select {
+		case indices <- next:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	for i := next; i < len(items); i++ {
+		results[i] = BatchResult{State: items[i], Err: ctx.Err()}
+	}
+	return results, nil
+}
+
+// evaluateItem runs graph on item unless the batch budget is already spent
+// or ctx is done, and charges the item's usage to the budget. A positive
+// timeout sets the item's deadline from now.
+func evaluateItem(ctx context.Context, graph *Graph, item domain.State, budget *batchBudget, timeout time.Duration) BatchResult {
+	if err := ctx.Err(); err != nil {
+		return BatchResult{State: item, Err: err}
+	}
+	if err := budget.check(); err != nil {
+		return BatchResult{State: item, Err: err}
+	}
+
+	input := item
+	if timeout > 0 {
+		input = domain.WithDeadline(item, time.Now().Add(timeout))
+	}
+	final, err := graph.Execute(ctx, input)
+	before, after := item.GetBudgetUsage(), final.GetBudgetUsage()
+	usage := domain.Usage{Tokens: after.Tokens - before.Tokens, Calls: after.Calls - before.Calls}
+	budget.charge(usage)
+
+	result := BatchResult{State: final, Usage: usage, Err: err}
+	if err == nil {
+		result.Verdict, _ = final.GetVerdict()
+	}
+	return result
+}
+
+// emitResult sends result's verdict to sink under the item's ID, or its
+// index when it has none, and releases the emitted state. Results without
+// a verdict are returned unchanged.
+func emitResult(ctx context.Context, sink ports.ResultSink, index int, result BatchResult) BatchResult {
+	if result.Err != nil || result.Verdict == nil {
+		return result
+	}
+	itemID, ok := result.State.GetItemID()
+	if !ok || itemID == "" {
+		itemID = strconv.Itoa(index)
+	}
+	if err := sink.Emit(ctx, itemID, result.Verdict); err != nil {
+		result.EmitErr = fmt.Errorf("batch: emit item %s: %w", itemID, err)
+		return result
+	}
+	return BatchResult{Usage: result.Usage}
+}
+
+// batchBudget tracks the usage of finished items against the batch limit.
+// It is safe for concurrent use.
+type batchBudget struct {
+	limit domain.Usage
+
+	mu   sync.Mutex
+	used domain.Usage
+}
+
+// check returns a *domain.BudgetExceededError if the usage so far exceeds
+// either limit.
+func (b *batchBudget) check() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.limit.Tokens > 0 && b.used.Tokens > b.limit.Tokens {
+		return domain.NewBudgetExceededError("tokens", int(b.limit.Tokens), int(b.used.Tokens), batchBudgetUnit)
+	}
+	if b.limit.Calls > 0 && b.used.Calls > b.limit.Calls {
+		return domain.NewBudgetExceededError("calls", int(b.limit.Calls), int(b.used.Calls), batchBudgetUnit)
+	}
+	return nil
+}
+
+// charge adds usage to the batch total.
+func (b *batchBudget) charge(usage domain.Usage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.used.Tokens += usage.Tokens
+	b.used.Calls += usage.Calls
+}
@@ -0,0 +1,227 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// recordingSink records the winning answer ID of each emitted verdict by
+// item ID, failing items listed in fail.
+type recordingSink struct {
+	fail map[string]error
+
+	mu      sync.Mutex
+	winners map[string]string
+}
+
+// Emit records the verdict's winner under itemID.
+func (s *recordingSink) Emit(ctx context.Context, itemID string, verdict *domain.Verdict) error {
+	if err := s.fail[itemID]; err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.winners == nil {
+		s.winners = map[string]string{}
+	}
+	s.winners[itemID] = verdict.WinnerAnswer.ID
+	return nil
+}
+
+// TestEvaluateBatch tests ordering, per-item failures, the concurrency
+// bound, cancellation, and the overall budget.
+func TestEvaluateBatch(t *testing.T) {
+	failErr := errors.New("judge unavailable")
+
+	// newBatchGraph returns a graph whose single node charges 10 tokens and
+	// one call, then declares the first answer the winner, failing for
+	// questions listed in fail. before runs first in every execution.
+	newBatchGraph := func(t *testing.T, fail map[string]bool, before func(ctx context.Context)) *Graph {
+		t.Helper()
+		g := NewGraph()
+		require.NoError(t, g.AddNode(&mockExecutable{
+			id: "judge",
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				if before != nil {
+					before(ctx)
+				}
+				state = state.UpdateBudgetUsage(10, 1)
+				question, _ := domain.Get(state, domain.KeyQuestion)
+				if fail[question] {
+					return state, failErr
+				}
+				answers, _ := domain.Get(state, domain.KeyAnswers)
+				return domain.With(state, domain.KeyVerdict, &domain.Verdict{WinnerAnswer: &answers[0]}), nil
+			},
+		}))
+		return g
+	}
+
+	newItems := func(t *testing.T, n int) []domain.State {
+		items := make([]domain.State, n)
+		for i := range items {
+			state, err := domain.NewEvaluationState(fmt.Sprintf("q%d", i),
+				[]domain.Answer{{ID: fmt.Sprintf("a%d", i), Content: "answer"}})
+			require.NoError(t, err)
+			items[i] = state
+		}
+		return items
+	}
+
+	t.Run("results in input order with per-item errors", func(t *testing.T) {
+		g := newBatchGraph(t, map[string]bool{"q2": true}, nil)
+
+		results, err := EvaluateBatch(context.Background(), g, newItems(t, 5), 3)
+		require.NoError(t, err)
+		require.Len(t, results, 5)
+		for i, result := range results {
+			if i == 2 {
+				assert.ErrorIs(t, result.Err, failErr)
+				assert.Nil(t, result.Verdict)
+				continue
+			}
+			require.NoError(t, result.Err)
+			assert.Equal(t, domain.Usage{Tokens: 10, Calls: 1}, result.Usage)
+			require.NotNil(t, result.Verdict)
+			assert.Equal(t, fmt.Sprintf("a%d", i), result.Verdict.WinnerAnswer.ID)
+		}
+	})
+
+	t.Run("bounds items in flight", func(t *testing.T) {
+		var inFlight, peak atomic.Int32
+		g := newBatchGraph(t, nil, func(context.Context) {
+			n := inFlight.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			inFlight.Add(-1)
+		})
+
+		results, err := EvaluateBatch(context.Background(), g, newItems(t, 12), 3)
+		require.NoError(t, err)
+		for _, result := range results {
+			require.NoError(t, result.Err)
+		}
+		assert.LessOrEqual(t, peak.Load(), int32(3))
+	})
+
+	t.Run("cancellation fails items not started", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var runs atomic.Int32
+		g := newBatchGraph(t, nil, func(context.Context) {
+			if runs.Add(1) == 2 {
+				cancel()
+			}
+		})
+
+		results, err := EvaluateBatch(ctx, g, newItems(t, 5), 1)
+		require.NoError(t, err)
+		require.NoError(t, results[0].Err)
+		for _, result := range results[2:] {
+			assert.ErrorIs(t, result.Err, context.Canceled)
+		}
+		assert.Equal(t, int32(2), runs.Load())
+	})
+
+	t.Run("budget stops remaining items", func(t *testing.T) {
+		g := newBatchGraph(t, nil, nil)
+
+		results, err := EvaluateBatch(context.Background(), g, newItems(t, 4), 1, WithBatchBudget(15, 0))
+		require.NoError(t, err)
+		require.NoError(t, results[0].Err)
+		require.NoError(t, results[1].Err)
+		for _, result := range results[2:] {
+			assert.ErrorIs(t, result.Err, domain.ErrBudgetExceeded)
+			assert.Zero(t, result.Usage)
+		}
+	})
+
+	t.Run("budget applies to a judge and verification graph", func(t *testing.T) {
+		g := newJudgeVerifyGraph(t)
+
+		// Each item makes one judge call and one verification call.
+		results, err := EvaluateBatch(context.Background(), g, newItems(t, 4), 1, WithBatchBudget(0, 3))
+		require.NoError(t, err)
+		for _, result := range results[:2] {
+			require.NoError(t, result.Err)
+			assert.Equal(t, int64(2), result.Usage.Calls)
+			assert.Positive(t, result.Usage.Tokens)
+		}
+		for _, result := range results[2:] {
+			assert.ErrorIs(t, result.Err, domain.ErrBudgetExceeded)
+			assert.Zero(t, result.Usage)
+		}
+	})
+
+	t.Run("item timeout starts with each item", func(t *testing.T) {
+		g := NewGraph()
+		require.NoError(t, g.AddNode(&mockExecutable{
+			id: "judge",
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				if question, _ := domain.Get(state, domain.KeyQuestion); question == "q0" {
+					<-ctx.Done()
+					return state, ctx.Err()
+				}
+				return state, nil
+			},
+		}))
+
+		results, err := EvaluateBatch(context.Background(), g, newItems(t, 2), 1, WithItemTimeout(20*time.Millisecond))
+		require.NoError(t, err)
+		assert.ErrorIs(t, results[0].Err, domain.ErrDeadlineExceeded)
+		assert.NoError(t, results[1].Err, "the second item gets its own latency budget")
+	})
+
+	t.Run("result sink receives verdicts as items complete", func(t *testing.T) {
+		g := newBatchGraph(t, map[string]bool{"q1": true}, nil)
+		items := newItems(t, 4)
+		items[0] = domain.With(items[0], domain.KeyItemID, "first")
+		errSink := errors.New("queue unavailable")
+		sink := &recordingSink{fail: map[string]error{"3": errSink}}
+
+		results, err := EvaluateBatch(context.Background(), g, items, 2, WithResultSink(sink))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"first": "a0", "2": "a2"}, sink.winners)
+
+		assert.Nil(t, results[0].Verdict, "emitted verdicts are released")
+		assert.Equal(t, domain.Usage{Tokens: 10, Calls: 1}, results[0].Usage)
+		assert.NoError(t, results[0].EmitErr)
+		assert.ErrorIs(t, results[1].Err, failErr)
+		assert.NoError(t, results[1].EmitErr, "failed items are not emitted")
+		assert.ErrorIs(t, results[3].EmitErr, errSink)
+		require.NotNil(t, results[3].Verdict, "unemitted verdicts are kept")
+		assert.Equal(t, "a3", results[3].Verdict.WinnerAnswer.ID)
+	})
+
+	t.Run("zero concurrency runs serially", func(t *testing.T) {
+		results, err := EvaluateBatch(context.Background(), newBatchGraph(t, nil, nil), newItems(t, 2), 0)
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		_, err := EvaluateBatch(context.Background(), nil, newItems(t, 1), 1)
+		assert.Error(t, err)
+
+		_, err = EvaluateBatch(context.Background(), newBatchGraph(t, nil, nil), newItems(t, 1), 1, WithBatchBudget(-1, 0))
+		assert.Error(t, err)
+
+		_, err = EvaluateBatch(context.Background(), newBatchGraph(t, nil, nil), newItems(t, 1), 1, WithItemTimeout(-time.Second))
+		assert.Error(t, err)
+	})
+}
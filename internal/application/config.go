@@ -1,7 +1,14 @@
 package application
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
 	"gopkg.in/yaml.v3"
+
+	"github.com/ahrav/go-gavel/internal/domain"
 )
 
 // GraphConfig defines the complete specification for an evaluation graph
@@ -21,6 +28,14 @@ type GraphConfig struct {
 	// Graph specifies the execution topology that determines how units
 	// are connected and the order in which they execute.
 	Graph GraphTopology `yaml:"graph" validate:"required"`
+	// Rubric optionally defines the evaluation criteria once for the whole
+	// graph. Units reference criteria by ID through their "criteria"
+	// parameter, and the rubric is seeded into state before execution.
+	Rubric *domain.Rubric `yaml:"rubric,omitempty"`
+	// TimeoutSeconds optionally bounds one execution of the whole graph.
+	// When it expires, running units are cancelled and execution returns
+	// the partial state with a *domain.TimeoutError.
+	TimeoutSeconds int `yaml:"timeout_seconds,omitempty" validate:"omitempty,min=1,max=3600"`
 }
 
 // Metadata provides descriptive information about an evaluation graph
@@ -53,7 +68,9 @@ type UnitConfig struct {
 	ID string `yaml:"id" validate:"required,alphanum,min=1,max=100"`
 	// Type specifies the evaluation unit implementation to instantiate,
 	// determining the available parameters and execution behavior.
-	Type string `yaml:"type" validate:"required,oneof=answerer score_judge verification arithmetic_mean max_pool median_pool exact_match fuzzy_match custom"`
+	// It must name a built-in unit type or one added with
+	// Registry.RegisterUnitType; this is checked during semantic validation.
+	Type string `yaml:"type" validate:"required"`
 	// Model specifies the LLM provider and model to use for this unit
 	// in the format "provider/model" or "provider/model@version".
 	// When omitted, the unit will use the default provider configured
@@ -71,6 +88,11 @@ type UnitConfig struct {
 	// Timeout defines execution time limits and graceful shutdown
 	// behavior to prevent units from consuming excessive resources.
 	Timeout TimeoutConfig `yaml:"timeout"`
+	// EnforceJSON wraps the unit's LLM client so that every response is a
+	// single valid JSON object, re-prompting once when a response has none.
+	// The corrective call is charged to the budget. It suits units that
+	// parse JSON responses, such as score_judge and verification.
+	EnforceJSON bool `yaml:"enforce_json,omitempty"`
 }
 
 // BudgetConfig establishes resource consumption limits for evaluation units
@@ -196,3 +218,60 @@ type ConditionConfig struct {
 	// YAML that will be validated according to the condition type.
 	Parameters yaml.Node `yaml:"parameters"` // Flexible for condition-specific validation
 }
+
+// Fingerprint returns a stable SHA-256 fingerprint of the configuration's
+// structure and parameters, encoded as lowercase hex. The configuration is
+// canonicalized before hashing: map keys are sorted, scalars are normalized
+// to their decoded values (so 1 and 1.0, or quoted and unquoted strings, are
+// equivalent), and YAML comments, styles, and formatting are discarded.
+// List order is preserved because it is significant, e.g., for pipelines.
+// Two semantically identical configurations therefore share a fingerprint,
+// which makes it suitable for cache keys and detecting configuration drift.
+func (c *GraphConfig) Fingerprint() (string, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode config for fingerprinting: %w", err)
+	}
+
+	var decoded any
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		return "", fmt.Errorf("failed to decode config for fingerprinting: %w", err)
+	}
+
+	// encoding/json sorts map keys, giving a canonical byte representation.
+	canonical, err := json.Marshal(canonicalizeValue(decoded))
+	if err != nil {
+		return "", fmt.Errorf("failed to canonicalize config: %w", err)
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalizeValue converts decoded YAML into values that encoding/json can
+// marshal deterministically: maps with non-string keys are re-keyed by their
+// string form.
+func canonicalizeValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			out[k] = canonicalizeValue(item)
+		}
+		return out
+	case map[any]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			out[fmt.Sprint(k)] = canonicalizeValue(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = canonicalizeValue(item)
+		}
+		return out
+	default:
+		return val
+	}
+}
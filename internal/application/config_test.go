@@ -3,6 +3,7 @@
 package application
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -357,3 +358,76 @@ func TestBudgetConfig_Validation(t *testing.T) {
 		})
 	}
 }
+
+// TestGraphConfig_Fingerprint tests that fingerprints ignore formatting,
+// comments, key order, and scalar styles while changing whenever the graph's
+// structure or parameters change.
+func TestGraphConfig_Fingerprint(t *testing.T) {
+	const base = `
+version: "1.0.0"
+metadata:
+  name: fingerprint-test
+units:
+  - id: judge
+    type: score_judge
+    budget:
+      max_tokens: 1000
+    parameters:
+      judge_prompt: "Rate the answer"
+      temperature: 0.5
+      max_tokens: 256
+graph:
+  pipelines:
+    - id: main
+      units: [judge]
+`
+	fingerprint := func(t *testing.T, doc string) string {
+		t.Helper()
+		var config GraphConfig
+		require.NoError(t, yaml.Unmarshal([]byte(doc), &config))
+		fp, err := config.Fingerprint()
+		require.NoError(t, err)
+		return fp
+	}
+
+	baseFP := fingerprint(t, base)
+	assert.Len(t, baseFP, 64)
+	assert.Equal(t, baseFP, fingerprint(t, base), "fingerprint must be deterministic")
+
+	t.Run("equivalent formatting", func(t *testing.T) {
+		reformatted := `
+# Same graph, different formatting.
+metadata: {name: 'fingerprint-test'}
+version: '1.0.0'
+graph:
+  pipelines:
+  - units:
+    - judge
+    id: main
+units:
+- type: score_judge
+  id: judge
+  parameters: {max_tokens: 256.0, temperature: 0.50, judge_prompt: Rate the answer}
+  budget: {max_tokens: 1000}
+`
+		assert.Equal(t, baseFP, fingerprint(t, reformatted))
+	})
+
+	changes := []struct {
+		name string
+		old  string
+		new  string
+	}{
+		{name: "parameter value", old: "temperature: 0.5", new: "temperature: 0.7"},
+		{name: "added parameter", old: "max_tokens: 256", new: "max_tokens: 256\n      min_confidence: 0.5"},
+		{name: "budget", old: "max_tokens: 1000", new: "max_tokens: 2000"},
+		{name: "metadata", old: "name: fingerprint-test", new: "name: other"},
+	}
+	for _, tt := range changes {
+		t.Run("detects changed "+tt.name, func(t *testing.T) {
+			changed := strings.Replace(base, tt.old, tt.new, 1)
+			require.NotEqual(t, base, changed)
+			assert.NotEqual(t, baseFP, fingerprint(t, changed))
+		})
+	}
+}
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"slices"
 	"sync"
+	"time"
 
+	"github.com/ahrav/go-gavel/infrastructure/units"
 	"github.com/ahrav/go-gavel/internal/domain"
 	"github.com/ahrav/go-gavel/internal/ports"
 )
@@ -47,6 +50,8 @@ func NewPipeline(id string) *Pipeline {
 // context is cancelled between executable runs.
 // Execute returns an error if any executable fails, including the
 // executable ID in the error message for debugging.
+// Execute stops early, without error, once the state holds a
+// domain.KeyConclusive conclusion.
 func (p *Pipeline) Execute(ctx context.Context, state domain.State) (domain.State, error) {
 	p.mu.RLock()
 	executables := make([]ports.Executable, len(p.executables))
@@ -55,6 +60,9 @@ func (p *Pipeline) Execute(ctx context.Context, state domain.State) (domain.Stat
 
 	currentState := state
 	for _, exec := range executables {
+		if concluded(currentState) {
+			break
+		}
 		select {
 		case <-ctx.Done():
 			return currentState, ctx.Err()
@@ -355,6 +363,18 @@ type Graph struct {
 	// inDegree tracks the number of incoming edges for each node,
 	// used for efficient topological sorting algorithms.
 	inDegree map[string]int // for topological sort.
+	// fingerprint is the GraphConfig fingerprint of a loaded graph, or
+	// empty for graphs constructed programmatically.
+	fingerprint string
+	// rubric is the graph-level rubric seeded into state before execution,
+	// or nil if the configuration defined none.
+	rubric *domain.Rubric
+	// info describes the configuration a loaded graph was built from, or
+	// nil for graphs constructed programmatically.
+	info *domain.GraphInfo
+	// timeout bounds one execution of the whole graph; zero means no
+	// graph-level timeout.
+	timeout time.Duration
 	// mu provides thread-safe access to all graph data structures
 	// during concurrent operations.
 	mu sync.RWMutex
@@ -373,6 +393,26 @@ func NewGraph() *Graph {
 	}
 }
 
+// SetTimeout bounds every execution of the graph to timeout, as a
+// backstop above per-unit and per-call timeouts. When it expires, in-flight
+// units are cancelled and Execute returns the partial state along with a
+// *domain.TimeoutError. Zero disables the timeout.
+func (g *Graph) SetTimeout(timeout time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.timeout = timeout
+}
+
+// Fingerprint returns the fingerprint of the configuration the graph was
+// loaded from (see GraphConfig.Fingerprint). It is empty for graphs that
+// were not created by a GraphLoader.
+func (g *Graph) Fingerprint() string { return g.fingerprint }
+
+// Info returns the metadata of the configuration the graph was loaded
+// from, which Execute stamps onto verdicts. It is nil for graphs that were
+// not created by a GraphLoader. Callers must not modify it.
+func (g *Graph) Info() *domain.GraphInfo { return g.info }
+
 // AddNode registers an executable component as a node in this graph.
 // The executable's ID must be unique within the graph scope.
 // AddNode initializes the node's adjacency list and in-degree counter
@@ -462,6 +502,8 @@ func (g *Graph) TopologicalSort() ([]ports.Executable, error) {
 			queue = append(queue, id)
 		}
 	}
+	// Sort roots so independent nodes are ordered consistently across runs.
+	slices.Sort(queue)
 
 	result := make([]ports.Executable, 0, len(g.nodes))
 
@@ -491,6 +533,215 @@ func (g *Graph) TopologicalSort() ([]ports.Executable, error) {
 	return result, nil
 }
 
+// Execute runs every node in the graph in topological order, passing the
+// output state of each node as the input to the next so that dependents
+// observe the results of their dependencies.
+// Execute respects context cancellation between nodes and returns an error
+// identifying the failing node if any node fails.
+// If the final state contains a verdict, Execute stamps the units that ran
+// onto its Provenance, preserving any aggregation method set by the
+// aggregator, and records when the verdict was finalized and how long the
+// graph took to produce it. The finalized verdict is sent to the unit
+// event logger installed with units.SetEventLogger.
+// A graph loaded with a rubric seeds domain.KeyRubric unless the caller's
+// state already provides one. Likewise a loaded graph seeds its metadata
+// under domain.KeyGraphInfo, and its name under domain.KeyGraphID, and the
+// metadata in state is stamped onto the verdict. Answers without an ID are assigned a stable
+// one on entry, so ID-based winner selection never sees an empty ID.
+// Once a node records a domain.KeyConclusive conclusion, Execute runs no
+// further nodes; see concludeEarly for the verdict it then reports.
+// An item deadline in domain.KeyDeadline bounds the whole execution: every
+// node runs with what remains of it, and once it passes Execute fails with
+// an error wrapping domain.ErrDeadlineExceeded without starting further
+// nodes. A graph timeout set with SetTimeout works the same way but fails
+// with a *domain.TimeoutError naming the stalled node. In both cases the
+// returned state holds the output of the nodes that completed.
+func (g *Graph) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	order, err := g.TopologicalSort()
+	if err != nil {
+		return state, err
+	}
+
+	g.mu.RLock()
+	timeout := g.timeout
+	g.mu.RUnlock()
+	parent := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	// timedOut builds the error for a graph timeout at stage, or returns
+	// nil if the graph's own timeout has not expired.
+	var completed []string
+	timedOut := func(stage string) error {
+		if timeout <= 0 || ctx.Err() == nil || parent.Err() != nil {
+			return nil
+		}
+		return &domain.TimeoutError{Timeout: timeout, Stage: stage, Completed: completed}
+	}
+
+	start := time.Now()
+	currentState := domain.WithAnswerIDs(state)
+	if g.rubric != nil {
+		if _, ok := currentState.GetRubric(); !ok {
+			currentState = domain.With(currentState, domain.KeyRubric, g.rubric)
+		}
+	}
+	if g.info != nil {
+		if _, ok := domain.Get(currentState, domain.KeyGraphInfo); !ok {
+			currentState = domain.With(currentState, domain.KeyGraphInfo, g.info)
+		}
+		if _, ok := domain.Get(currentState, domain.KeyGraphID); !ok {
+			currentState = domain.With(currentState, domain.KeyGraphID, g.info.Name)
+		}
+	}
+	ctx, cancel := withItemDeadline(ctx, currentState)
+	defer cancel()
+
+	var participants []domain.UnitProvenance
+	for k, exec := range order {
+		if concluded(currentState) {
+			currentState = concludeEarly(currentState, order[k:])
+			break
+		}
+		if itemDeadlinePassed(currentState) {
+			return currentState, fmt.Errorf("graph: deadline passed before %s: %w", exec.ID(), domain.ErrDeadlineExceeded)
+		}
+		if err := timedOut(exec.ID()); err != nil {
+			return currentState, err
+		}
+		if err := ctx.Err(); err != nil {
+			return currentState, err
+		}
+
+		newState, err := exec.Execute(ctx, currentState)
+		if err != nil {
+			if !itemDeadlinePassed(currentState) {
+				if timeoutErr := timedOut(exec.ID()); timeoutErr != nil {
+					return currentState, timeoutErr
+				}
+			}
+			return currentState, fmt.Errorf("graph: execution failed at %s: %w", exec.ID(), deadlineError(currentState, err))
+		}
+		currentState = newState
+		completed = append(completed, exec.ID())
+		participants = appendProvenance(participants, exec)
+	}
+
+	currentState = finalizeVerdict(currentState, participants, start)
+	units.LogVerdictFinalized(ctx, currentState)
+	return currentState, nil
+}
+
+// appendProvenance appends the provenance of every unit contained in exec,
+// descending into pipelines and layers. Executables that are not unit
+// adapters or containers are recorded by ID only.
+func appendProvenance(dst []domain.UnitProvenance, exec ports.Executable) []domain.UnitProvenance {
+	switch e := exec.(type) {
+	case *UnitAdapter:
+		return append(dst, e.Provenance())
+	case interface{ Executables() []ports.Executable }:
+		// Pipelines and layers.
+		for _, child := range e.Executables() {
+			dst = appendProvenance(dst, child)
+		}
+		return dst
+	default:
+		return append(dst, domain.UnitProvenance{Name: exec.ID()})
+	}
+}
+
+// concluded reports whether state holds a domain.KeyConclusive conclusion.
+func concluded(state domain.State) bool {
+	conclusion, ok := domain.Get(state, domain.KeyConclusive)
+	return ok && conclusion != nil
+}
+
+// concludeEarly records on the verdict in state that the evaluation ended
+// with the state's conclusion before skipped ran. Without a verdict, because
+// the skipped nodes included the aggregator, it reports one whose winner is
+// the answer the conclusion names, scored with the concluding unit's score
+// and full confidence.
+func concludeEarly(state domain.State, skipped []ports.Executable) domain.State {
+	conclusion, _ := domain.Get(state, domain.KeyConclusive)
+	var skippedUnits []domain.UnitProvenance
+	for _, exec := range skipped {
+		skippedUnits = appendProvenance(skippedUnits, exec)
+	}
+	earlyExit := &domain.EarlyExit{Conclusion: *conclusion}
+	for _, unit := range skippedUnits {
+		earlyExit.SkippedUnits = append(earlyExit.SkippedUnits, unit.Name)
+	}
+
+	verdict, ok := state.GetVerdict()
+	if !ok || verdict == nil {
+		verdict = &domain.Verdict{
+			SchemaVersion:  domain.VerdictSchemaVersion,
+			ID:             conclusion.Unit + "_verdict",
+			AggregateScore: conclusion.Score,
+			Status:         domain.VerdictDecided,
+			Confidence:     1,
+			Provenance:     &domain.Provenance{AggregationMethod: "early_exit"},
+		}
+		answers, _ := state.GetAnswers()
+		for _, answer := range answers {
+			if answer.ID == conclusion.AnswerID {
+				verdict.WinnerAnswer = &answer
+				verdict.RankedAnswers = []domain.RankedAnswer{{Answer: answer, Score: conclusion.Score}}
+				break
+			}
+		}
+	}
+	verdict.EarlyExit = earlyExit
+	return domain.With(state, domain.KeyVerdict, verdict)
+}
+
+// finalizeVerdict records participants, timing, graph metadata, budget
+// usage, and any answer sample on the verdict in state, if any. Units that
+// ran with a model override report the override as their model.
+func finalizeVerdict(state domain.State, participants []domain.UnitProvenance, start time.Time) domain.State {
+	verdict, ok := state.GetVerdict()
+	if !ok || verdict == nil {
+		return state
+	}
+	for i := range participants {
+		if model, ok := domain.ModelOverride(state, participants[i].Name); ok {
+			participants[i].Model = model
+		}
+	}
+	if verdict.Provenance == nil {
+		verdict.Provenance = &domain.Provenance{}
+	}
+	verdict.Provenance.Units = participants
+	if info, ok := domain.Get(state, domain.KeyGraphInfo); ok && info != nil {
+		verdict.Graph = info
+	}
+	if sample, ok := domain.Get(state, domain.KeySample); ok && sample != nil {
+		verdict.Sample = sample
+	}
+	verdict.Budget = budgetReport(state)
+	verdict.CreatedAt = time.Now()
+	verdict.Duration = verdict.CreatedAt.Sub(start)
+	return domain.With(state, domain.KeyVerdict, verdict)
+}
+
+// budgetReport returns the resources consumed so far: a copy of the
+// caller's report when one was seeded with domain.WithBudget, otherwise a
+// report built from the usage units charged. It is nil when nothing was
+// charged.
+func budgetReport(state domain.State) *domain.BudgetReport {
+	if report, ok := state.GetBudget(); ok && report != nil {
+		budget := *report
+		return &budget
+	}
+	usage := state.GetBudgetUsage()
+	if usage.Calls == 0 && usage.Tokens == 0 {
+		return nil
+	}
+	return &domain.BudgetReport{TokensUsed: int(usage.Tokens), CallsMade: int(usage.Calls)}
+}
+
 // HasCycle performs cycle detection to determine if the graph
 // contains any circular dependencies that would prevent valid
 // topological ordering and execution.
@@ -647,3 +647,426 @@ func TestGraph_TopologicalSort(t *testing.T) {
 		})
 	}
 }
+
+// TestGraph_Execute tests that graph execution threads state through nodes in
+// dependency order and reports the failing node on error.
+func TestGraph_Execute(t *testing.T) {
+	orderKey := domain.NewKey[[]string]("order")
+	record := func(id string) *mockExecutable {
+		return &mockExecutable{
+			id: id,
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				order, _ := domain.Get(state, orderKey)
+				return domain.With(state, orderKey, append(order, id)), nil
+			},
+		}
+	}
+
+	t.Run("executes nodes in dependency order", func(t *testing.T) {
+		g := NewGraph()
+		for _, id := range []string{"judge", "answer", "pool"} {
+			require.NoError(t, g.AddNode(record(id)))
+		}
+		require.NoError(t, g.AddEdge("answer", "judge"))
+		require.NoError(t, g.AddEdge("judge", "pool"))
+
+		state, err := g.Execute(context.Background(), domain.NewState())
+		require.NoError(t, err)
+
+		order, ok := domain.Get(state, orderKey)
+		require.True(t, ok)
+		assert.Equal(t, []string{"answer", "judge", "pool"}, order)
+	})
+
+	t.Run("stops at failing node", func(t *testing.T) {
+		g := NewGraph()
+		failing := &mockExecutable{
+			id: "broken",
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				return state, errors.New("boom")
+			},
+		}
+		after := record("after")
+		require.NoError(t, g.AddNode(failing))
+		require.NoError(t, g.AddNode(after))
+		require.NoError(t, g.AddEdge("broken", "after"))
+
+		_, err := g.Execute(context.Background(), domain.NewState())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "execution failed at broken")
+		assert.False(t, after.wasExecuted())
+	})
+
+	t.Run("respects cancelled context", func(t *testing.T) {
+		g := NewGraph()
+		node := record("node")
+		require.NoError(t, g.AddNode(node))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := g.Execute(ctx, domain.NewState())
+		require.ErrorIs(t, err, context.Canceled)
+		assert.False(t, node.wasExecuted())
+	})
+
+	t.Run("assigns missing answer IDs on entry", func(t *testing.T) {
+		var seen []domain.Answer
+		node := &mockExecutable{
+			id: "judge",
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				seen, _ = domain.Get(state, domain.KeyAnswers)
+				return state, nil
+			},
+		}
+		g := NewGraph()
+		require.NoError(t, g.AddNode(node))
+
+		input := domain.With(domain.NewState(), domain.KeyAnswers, []domain.Answer{{ID: "kept", Content: "A"}, {Content: "B"}})
+		_, err := g.Execute(context.Background(), input)
+		require.NoError(t, err)
+
+		require.Len(t, seen, 2)
+		assert.Equal(t, "kept", seen[0].ID)
+		assert.NotEmpty(t, seen[1].ID)
+		original, _ := domain.Get(input, domain.KeyAnswers)
+		assert.Empty(t, original[1].ID, "input state must not be mutated")
+	})
+
+	t.Run("stamps participating units onto verdict provenance", func(t *testing.T) {
+		judges := NewLayer("judges")
+		require.NoError(t, judges.Add(NewUnitAdapterWithProvenance(&mockUnit{id: "judge_a"}, "judge_a", "score_judge", "openai/gpt-4")))
+		require.NoError(t, judges.Add(NewUnitAdapterWithProvenance(&mockUnit{id: "judge_b"}, "judge_b", "exact_match", "")))
+
+		pool := &mockExecutable{
+			id: "pool",
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				return domain.With(state, domain.KeyVerdict, &domain.Verdict{
+					ID:         "v1",
+					Provenance: &domain.Provenance{AggregationMethod: "max_pool"},
+				}), nil
+			},
+		}
+
+		g := NewGraph()
+		require.NoError(t, g.AddNode(judges))
+		require.NoError(t, g.AddNode(pool))
+		require.NoError(t, g.AddEdge("judges", "pool"))
+
+		state, err := g.Execute(context.Background(), domain.NewState())
+		require.NoError(t, err)
+
+		verdict, ok := domain.Get(state, domain.KeyVerdict)
+		require.True(t, ok)
+		require.NotNil(t, verdict.Provenance)
+		assert.Equal(t, "max_pool", verdict.Provenance.AggregationMethod)
+		assert.Equal(t, []domain.UnitProvenance{
+			{Name: "judge_a", Type: "score_judge", Model: "openai/gpt-4"},
+			{Name: "judge_b", Type: "exact_match"},
+			{Name: "pool"},
+		}, verdict.Provenance.Units)
+	})
+
+	t.Run("reports model overrides in verdict provenance", func(t *testing.T) {
+		judge := NewUnitAdapterWithProvenance(&mockUnit{id: "judge_a"}, "judge_a", "score_judge", "gpt-4")
+		judge.modelGuard = func(string) error { return nil }
+		pool := &mockExecutable{
+			id: "pool",
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				return domain.With(state, domain.KeyVerdict, &domain.Verdict{ID: "v1"}), nil
+			},
+		}
+
+		g := NewGraph()
+		require.NoError(t, g.AddNode(judge))
+		require.NoError(t, g.AddNode(pool))
+		require.NoError(t, g.AddEdge("judge_a", "pool"))
+
+		state, err := g.Execute(context.Background(), domain.WithModelOverride(domain.NewState(), "judge_a", "gpt-4.1-mini"))
+		require.NoError(t, err)
+
+		verdict, ok := domain.Get(state, domain.KeyVerdict)
+		require.True(t, ok)
+		require.NotNil(t, verdict.Provenance)
+		assert.Equal(t, "gpt-4.1-mini", verdict.Provenance.Units[0].Model)
+	})
+
+	t.Run("notes answer sample on verdict", func(t *testing.T) {
+		sample := &domain.Sample{Unit: "sample", PoolSize: 100, SampleSize: 10}
+		pool := &mockExecutable{
+			id: "pool",
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				return domain.With(state, domain.KeyVerdict, &domain.Verdict{ID: "v1"}), nil
+			},
+		}
+		g := NewGraph()
+		require.NoError(t, g.AddNode(pool))
+
+		state, err := g.Execute(context.Background(), domain.With(domain.NewState(), domain.KeySample, sample))
+		require.NoError(t, err)
+
+		verdict, ok := domain.Get(state, domain.KeyVerdict)
+		require.True(t, ok)
+		assert.Equal(t, sample, verdict.Sample)
+	})
+
+	t.Run("records verdict finalization time and duration", func(t *testing.T) {
+		slow := &mockExecutable{
+			id: "slow",
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				time.Sleep(5 * time.Millisecond)
+				return state, nil
+			},
+		}
+		pool := &mockExecutable{
+			id: "pool",
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				return domain.With(state, domain.KeyVerdict, &domain.Verdict{ID: "v1"}), nil
+			},
+		}
+
+		g := NewGraph()
+		require.NoError(t, g.AddNode(slow))
+		require.NoError(t, g.AddNode(pool))
+		require.NoError(t, g.AddEdge("slow", "pool"))
+
+		before := time.Now()
+		state, err := g.Execute(context.Background(), domain.NewState())
+		require.NoError(t, err)
+
+		verdict, ok := domain.Get(state, domain.KeyVerdict)
+		require.True(t, ok)
+		assert.GreaterOrEqual(t, verdict.Duration, 5*time.Millisecond)
+		assert.False(t, verdict.CreatedAt.Before(before))
+		assert.WithinDuration(t, time.Now(), verdict.CreatedAt, time.Second)
+	})
+
+	t.Run("skips remaining nodes after a conclusion", func(t *testing.T) {
+		gate := &mockExecutable{
+			id: "exact",
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				return domain.With(state, domain.KeyConclusive, &domain.Conclusion{
+					Unit: "exact", AnswerID: "a2", Score: 1, Reason: "exact match",
+				}), nil
+			},
+		}
+		judge, pool := record("judge"), record("pool")
+
+		g := NewGraph()
+		for _, node := range []*mockExecutable{gate, judge, pool} {
+			require.NoError(t, g.AddNode(node))
+		}
+		require.NoError(t, g.AddEdge("exact", "judge"))
+		require.NoError(t, g.AddEdge("judge", "pool"))
+
+		input := domain.With(domain.NewState(), domain.KeyAnswers, []domain.Answer{{ID: "a1", Content: "Lyon"}, {ID: "a2", Content: "Paris"}})
+		state, err := g.Execute(context.Background(), input)
+		require.NoError(t, err)
+		assert.False(t, judge.wasExecuted())
+		assert.False(t, pool.wasExecuted())
+
+		verdict, ok := state.GetVerdict()
+		require.True(t, ok)
+		require.NotNil(t, verdict.WinnerAnswer)
+		assert.Equal(t, "a2", verdict.WinnerAnswer.ID)
+		assert.Equal(t, 1.0, verdict.AggregateScore)
+		assert.Equal(t, domain.VerdictDecided, verdict.Status)
+		require.NotNil(t, verdict.EarlyExit)
+		assert.Equal(t, "exact", verdict.EarlyExit.Conclusion.Unit)
+		assert.Equal(t, []string{"judge", "pool"}, verdict.EarlyExit.SkippedUnits)
+		assert.Equal(t, []domain.UnitProvenance{{Name: "exact"}}, verdict.Provenance.Units)
+	})
+
+	t.Run("pipeline stops after a conclusion", func(t *testing.T) {
+		gate := &mockExecutable{
+			id: "exact",
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				return domain.With(state, domain.KeyConclusive, &domain.Conclusion{Unit: "exact"}), nil
+			},
+		}
+		judge := record("judge")
+		p := NewPipeline("cascade")
+		require.NoError(t, p.Add(gate))
+		require.NoError(t, p.Add(judge))
+
+		_, err := p.Execute(context.Background(), domain.NewState())
+		require.NoError(t, err)
+		assert.False(t, judge.wasExecuted())
+	})
+
+	t.Run("leaves state without verdict unchanged", func(t *testing.T) {
+		g := NewGraph()
+		require.NoError(t, g.AddNode(record("node")))
+
+		state, err := g.Execute(context.Background(), domain.NewState())
+		require.NoError(t, err)
+
+		_, ok := domain.Get(state, domain.KeyVerdict)
+		assert.False(t, ok)
+	})
+}
+
+// blockingUnit is a ports.Unit that waits until its context is done, as an
+// LLM call does when the provider is slow.
+type blockingUnit struct{}
+
+// Name returns the unit's fixed name.
+func (blockingUnit) Name() string { return "slow" }
+
+// Execute blocks until ctx is done and returns its error.
+func (blockingUnit) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	<-ctx.Done()
+	return state, fmt.Errorf("LLM call failed: %w", ctx.Err())
+}
+
+// Validate is a no-op.
+func (blockingUnit) Validate() error { return nil }
+
+// TestGraph_ExecuteDeadline tests that the item deadline in State bounds
+// every unit and fails the item with domain.ErrDeadlineExceeded.
+func TestGraph_ExecuteDeadline(t *testing.T) {
+	t.Run("slow unit runs out the deadline", func(t *testing.T) {
+		next := &mockExecutable{id: "next"}
+		g := NewGraph()
+		require.NoError(t, g.AddNode(NewUnitAdapter(blockingUnit{}, "slow")))
+		require.NoError(t, g.AddNode(next))
+		require.NoError(t, g.AddEdge("slow", "next"))
+
+		input := domain.WithDeadline(domain.NewState(), time.Now().Add(20*time.Millisecond))
+		start := time.Now()
+		_, err := g.Execute(context.Background(), input)
+		require.ErrorIs(t, err, domain.ErrDeadlineExceeded)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "execution failed at slow")
+		assert.Less(t, time.Since(start), time.Second)
+		assert.False(t, next.wasExecuted())
+	})
+
+	t.Run("slow stage leaves no time for later stages", func(t *testing.T) {
+		first := &mockExecutable{
+			id: "first",
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				time.Sleep(30 * time.Millisecond)
+				return state, nil
+			},
+		}
+		second := &mockExecutable{id: "second"}
+		g := NewGraph()
+		require.NoError(t, g.AddNode(first))
+		require.NoError(t, g.AddNode(second))
+		require.NoError(t, g.AddEdge("first", "second"))
+
+		input := domain.WithDeadline(domain.NewState(), time.Now().Add(10*time.Millisecond))
+		_, err := g.Execute(context.Background(), input)
+		require.ErrorIs(t, err, domain.ErrDeadlineExceeded)
+		assert.ErrorContains(t, err, "deadline passed before second")
+		assert.False(t, second.wasExecuted())
+	})
+
+	t.Run("adapter does not start a unit past the deadline", func(t *testing.T) {
+		adapter := NewUnitAdapter(&mockUnit{id: "judge"}, "judge")
+		input := domain.WithDeadline(domain.NewState(), time.Now().Add(-time.Second))
+		state, err := adapter.Execute(context.Background(), input)
+		require.ErrorIs(t, err, domain.ErrDeadlineExceeded)
+		_, executed := domain.Get(state, domain.NewKey[bool]("executed_judge"))
+		assert.False(t, executed)
+	})
+
+	t.Run("a later deadline does not extend an earlier one", func(t *testing.T) {
+		soon := time.Now().Add(time.Minute)
+		state := domain.WithDeadline(domain.WithDeadline(domain.NewState(), soon), soon.Add(time.Hour))
+		deadline, ok := domain.Deadline(state)
+		require.True(t, ok)
+		assert.Equal(t, soon, deadline)
+	})
+}
+
+// TestGraph_ExecuteTimeout tests that the graph timeout cancels the running
+// unit and returns the partial state with a *domain.TimeoutError.
+func TestGraph_ExecuteTimeout(t *testing.T) {
+	first := &mockExecutable{
+		id: "first",
+		executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+			return domain.With(state, domain.NewKey[bool]("executed_first"), true), nil
+		},
+	}
+	last := &mockExecutable{id: "last"}
+	g := NewGraph()
+	require.NoError(t, g.AddNode(first))
+	require.NoError(t, g.AddNode(NewUnitAdapter(blockingUnit{}, "slow")))
+	require.NoError(t, g.AddNode(last))
+	require.NoError(t, g.AddEdge("first", "slow"))
+	require.NoError(t, g.AddEdge("slow", "last"))
+	g.SetTimeout(20 * time.Millisecond)
+
+	start := time.Now()
+	state, err := g.Execute(context.Background(), domain.NewState())
+	assert.Less(t, time.Since(start), time.Second)
+
+	var timeoutErr *domain.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "slow", timeoutErr.Stage)
+	assert.Equal(t, []string{"first"}, timeoutErr.Completed)
+	assert.Equal(t, "graph timed out after 20ms at slow (completed: first)", err.Error())
+
+	_, ok := domain.Get(state, domain.NewKey[bool]("executed_first"))
+	assert.True(t, ok, "partial state keeps completed stages' output")
+	assert.False(t, last.wasExecuted())
+
+	t.Run("cancelled parent is not a timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := g.Execute(ctx, domain.NewState())
+		require.ErrorIs(t, err, context.Canceled)
+		assert.False(t, errors.As(err, &timeoutErr))
+	})
+}
+
+// TestUnitAdapter_ModelOverride tests that model overrides are validated by
+// the adapter's guard before the unit runs, and rejected for units without
+// an LLM provider.
+func TestUnitAdapter_ModelOverride(t *testing.T) {
+	errUnknown := errors.New("model not supported")
+	guard := func(model string) error {
+		if model != "gpt-4.1-mini" {
+			return errUnknown
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name     string
+		guard    func(string) error
+		override string
+		wantErr  string
+	}{
+		{name: "no override runs unit", guard: nil},
+		{name: "known model runs unit", guard: guard, override: "gpt-4.1-mini"},
+		{name: "unknown model is rejected", guard: guard, override: "gpt-9", wantErr: "model not supported"},
+		{name: "unit without provider is rejected", guard: nil, override: "gpt-4.1-mini", wantErr: "no LLM provider"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := NewUnitAdapterWithProvenance(&mockUnit{id: "judge"}, "judge", "score_judge", "gpt-4")
+			adapter.modelGuard = tt.guard
+
+			state := domain.NewState()
+			if tt.override != "" {
+				state = domain.WithModelOverride(state, "judge", tt.override)
+			}
+
+			newState, err := adapter.Execute(context.Background(), state)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				_, executed := domain.Get(newState, domain.NewKey[bool]("executed_judge"))
+				assert.False(t, executed, "unit should not run with an invalid override")
+				return
+			}
+			require.NoError(t, err)
+			_, executed := domain.Get(newState, domain.NewKey[bool]("executed_judge"))
+			assert.True(t, executed)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// withItemDeadline returns ctx bounded by the item deadline in state, if
+// any. A deadline already on ctx that is earlier still applies.
+func withItemDeadline(ctx context.Context, state domain.State) (context.Context, context.CancelFunc) {
+	if deadline, ok := domain.Deadline(state); ok {
+		return context.WithDeadline(ctx, deadline)
+	}
+	return ctx, func() {}
+}
+
+// itemDeadlinePassed reports whether the item deadline in state has passed.
+func itemDeadlinePassed(state domain.State) bool {
+	deadline, ok := domain.Deadline(state)
+	return ok && !time.Now().Before(deadline)
+}
+
+// deadlineError marks err as caused by the item deadline when the deadline
+// in state has passed, so callers can tell an exhausted latency budget
+// from a failing unit with errors.Is(err, domain.ErrDeadlineExceeded).
+// Other errors are returned unchanged.
+func deadlineError(state domain.State, err error) error {
+	if err == nil || errors.Is(err, domain.ErrDeadlineExceeded) || !itemDeadlinePassed(state) {
+		return err
+	}
+	if err == context.DeadlineExceeded {
+		return domain.ErrDeadlineExceeded
+	}
+	return fmt.Errorf("%w: %w", domain.ErrDeadlineExceeded, err)
+}
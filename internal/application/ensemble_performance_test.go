@@ -104,8 +104,8 @@ func runSingleJudgeBenchmark(
 	// Create a single score judge unit.
 	scoreJudge, err := units.NewScoreJudgeUnit("single_judge", llmClient, units.ScoreJudgeConfig{
 		JudgePrompt: `Rate this answer to the question on a scale from 0.0 to 1.0:
-Question: {{.Question}}
-Answer: {{.Answer}}
+{{.QuestionBlock}}
+{{.AnswerBlock}}
 
 Consider accuracy, completeness, and relevance. Provide a score and brief reasoning.`,
 		ScoreScale:     "0.0-1.0",
@@ -148,7 +148,7 @@ Consider accuracy, completeness, and relevance. Provide a score and brief reason
 		require.NotNil(t, verdict.WinnerAnswer, "No winner selected for question %d", i)
 
 		// Check if the prediction is correct.
-		if verdict.WinnerAnswer.ID == question.GroundTruthID {
+		if question.IsCorrect(verdict.WinnerAnswer.ID) {
 			correctPredictions++
 		}
 
@@ -181,8 +181,8 @@ func runEnsembleBenchmark(
 	// Judge 1: Conservative, focuses on accuracy.
 	judge1, err := units.NewScoreJudgeUnit("conservative_judge", mocks["conservative"], units.ScoreJudgeConfig{
 		JudgePrompt: `Evaluate this answer conservatively, prioritizing factual accuracy:
-Question: {{.Question}}
-Answer: {{.Answer}}
+{{.QuestionBlock}}
+{{.AnswerBlock}}
 
 Score from 0.0 to 1.0 based primarily on correctness and accuracy.`,
 		ScoreScale:     "0.0-1.0",
@@ -196,8 +196,8 @@ Score from 0.0 to 1.0 based primarily on correctness and accuracy.`,
 	// Judge 2: Comprehensive, considers multiple factors.
 	judge2, err := units.NewScoreJudgeUnit("comprehensive_judge", mocks["comprehensive"], units.ScoreJudgeConfig{
 		JudgePrompt: `Evaluate this answer comprehensively:
-Question: {{.Question}}
-Answer: {{.Answer}}
+{{.QuestionBlock}}
+{{.AnswerBlock}}
 
 Score from 0.0 to 1.0 considering accuracy, completeness, clarity, and relevance.`,
 		ScoreScale:     "0.0-1.0",
@@ -211,8 +211,8 @@ Score from 0.0 to 1.0 considering accuracy, completeness, clarity, and relevance
 	// Judge 3: Analytical, focuses on reasoning.
 	judge3, err := units.NewScoreJudgeUnit("analytical_judge", mocks["analytical"], units.ScoreJudgeConfig{
 		JudgePrompt: `Analyze this answer with focus on logical reasoning:
-Question: {{.Question}}
-Answer: {{.Answer}}
+{{.QuestionBlock}}
+{{.AnswerBlock}}
 
 Score from 0.0 to 1.0 based on logical coherence and reasoning quality.`,
 		ScoreScale:     "0.0-1.0",
@@ -295,7 +295,7 @@ Score from 0.0 to 1.0 based on logical coherence and reasoning quality.`,
 		require.NotNil(t, verdict.WinnerAnswer)
 
 		// Check if the prediction is correct.
-		if verdict.WinnerAnswer.ID == question.GroundTruthID {
+		if question.IsCorrect(verdict.WinnerAnswer.ID) {
 			correctPredictions++
 		}
 
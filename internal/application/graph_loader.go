@@ -3,19 +3,22 @@ package application
 import (
 	"bytes"
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"golang.org/x/sync/singleflight"
 	"gopkg.in/yaml.v3"
 
 	"github.com/ahrav/go-gavel/infrastructure/llm"
+	"github.com/ahrav/go-gavel/internal/domain"
 	"github.com/ahrav/go-gavel/internal/ports"
 )
 
@@ -34,6 +37,9 @@ type GraphLoader struct {
 	// providerRegistry manages provider-specific LLM clients and injects
 	// the correct client based on the unit's model field.
 	providerRegistry *llm.Registry
+	// prompts resolves the prompt_ref parameter of units. It is nil until
+	// SetPromptLibrary is called, in which case prompt references fail.
+	prompts *PromptLibrary
 	// cache stores compiled graphs indexed by SHA256 hash of source YAML
 	// to avoid recompilation of identical configurations.
 	// WARNING: Cached graphs MUST NOT be mutated. The Graph methods
@@ -71,6 +77,14 @@ func NewGraphLoader(unitRegistry ports.UnitRegistry, providerRegistry *llm.Regis
 	}, nil
 }
 
+// SetPromptLibrary sets the library that units' prompt_ref parameters are
+// resolved against. Call it before loading graphs that reference prompts.
+// Graphs are cached by their resolved prompts, so changing the library
+// never returns a graph built with a stale prompt.
+func (gl *GraphLoader) SetPromptLibrary(library *PromptLibrary) {
+	gl.prompts = library
+}
+
 // load is the common implementation for loading graphs from byte data,
 // utilizing singleflight to prevent duplicate compilation and SHA256-based
 // caching for efficiency.
@@ -84,6 +98,12 @@ func (gl *GraphLoader) load(ctx context.Context, data []byte) (*Graph, error) {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
+	// Resolve prompt references before hashing so the cache key covers the
+	// prompt text itself.
+	if err := gl.resolvePromptRefs(config); err != nil {
+		return nil, fmt.Errorf("failed to resolve prompts: %w", err)
+	}
+
 	// Calculate hash based on normalized config, not raw bytes.
 	hash, err := gl.calculateConfigHash(config)
 	if err != nil {
@@ -108,6 +128,14 @@ func (gl *GraphLoader) load(ctx context.Context, data []byte) (*Graph, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to build graph: %w", err)
 		}
+		graph.fingerprint = hash
+		graph.info = &domain.GraphInfo{
+			Name:        config.Metadata.Name,
+			Version:     config.Version,
+			Fingerprint: hash,
+			Tags:        slices.Clone(config.Metadata.Tags),
+			Labels:      maps.Clone(config.Metadata.Labels),
+		}
 
 		gl.cacheGraph(hash, graph)
 
@@ -191,12 +219,31 @@ func (gl *GraphLoader) validateConfig(config *GraphConfig) error {
 	return nil
 }
 
+// customUnitTypes is implemented by unit registries that accept unit types
+// registered at runtime, such as Registry.
+type customUnitTypes interface {
+	IsCustomUnitType(unitType string) bool
+}
+
+// isCustomUnitType reports whether the unit registry knows unitType as a
+// runtime-registered type whose parameters its factory validates.
+func (gl *GraphLoader) isCustomUnitType(unitType string) bool {
+	registry, ok := gl.unitRegistry.(customUnitTypes)
+	return ok && registry.IsCustomUnitType(unitType)
+}
+
 // validateSemantics performs domain-specific validation rules that
 // cannot be expressed through struct tags, including uniqueness
 // constraints, reference integrity, and parameter validation.
 // validateSemantics ensures all node IDs are globally unique across
 // units, pipelines, and layers to prevent ambiguous edge references.
 func (gl *GraphLoader) validateSemantics(config *GraphConfig) error {
+	if config.Rubric != nil {
+		if err := config.Rubric.Validate(); err != nil {
+			return fmt.Errorf("invalid rubric: %w", err)
+		}
+	}
+
 	// Track all node IDs globally to ensure uniqueness across categories.
 	allNodeIDs := make(map[string]string) // ID -> node type for better error messages.
 	unitIDs := make(map[string]struct{})
@@ -209,8 +256,14 @@ func (gl *GraphLoader) validateSemantics(config *GraphConfig) error {
 		allNodeIDs[unit.ID] = "unit"
 		unitIDs[unit.ID] = struct{}{}
 
-		if err := ValidateUnitParameters(unit.Type, unit.Parameters); err != nil {
-			return fmt.Errorf("unit %s parameter validation failed: %w", unit.ID, err)
+		if !gl.isCustomUnitType(unit.Type) {
+			if err := ValidateUnitParameters(unit.Type, unit.Parameters); err != nil {
+				return fmt.Errorf("unit %s parameter validation failed: %w", unit.ID, err)
+			}
+		}
+
+		if err := validateCriteriaReferences(config.Rubric, unit.Parameters); err != nil {
+			return fmt.Errorf("unit %s: %w", unit.ID, err)
 		}
 
 		if unit.Model != "" {
@@ -271,6 +324,27 @@ func (gl *GraphLoader) validateSemantics(config *GraphConfig) error {
 	return nil
 }
 
+// validateCriteriaReferences checks that every criterion ID listed in a
+// unit's "criteria" parameter is defined by the graph rubric.
+func validateCriteriaReferences(rubric *domain.Rubric, params yaml.Node) error {
+	var refs struct {
+		Criteria []string `yaml:"criteria"`
+	}
+	if err := params.Decode(&refs); err != nil {
+		return fmt.Errorf("criteria must be a list of criterion IDs: %w", err)
+	}
+	if len(refs.Criteria) == 0 {
+		return nil
+	}
+	if rubric == nil {
+		return fmt.Errorf("references criteria but the graph defines no rubric: %w", domain.ErrUnknownCriterion)
+	}
+	if _, err := rubric.Select(refs.Criteria); err != nil {
+		return err
+	}
+	return nil
+}
+
 // buildGraph constructs an executable graph from a validated configuration,
 // creating units, pipelines, layers, and their dependency relationships.
 // buildGraph instantiates units through the unit registry, wraps them
@@ -279,14 +353,35 @@ func (gl *GraphLoader) validateSemantics(config *GraphConfig) error {
 // or cycle detection fails.
 func (gl *GraphLoader) buildGraph(ctx context.Context, config *GraphConfig) (*Graph, error) {
 	graph := NewGraph()
+	graph.rubric = config.Rubric
+	graph.timeout = time.Duration(config.TimeoutSeconds) * time.Second
 
 	units := make(map[string]ports.Unit)
+	provenance := make(map[string]domain.UnitProvenance)
+	// unitProviders records the provider of each LLM-backed unit so model
+	// overrides can be validated against it at execution time.
+	unitProviders := make(map[string]string)
 	for _, unitConfig := range config.Units {
-		unit, err := gl.createUnit(unitConfig)
+		unit, unitProvenance, err := gl.createUnit(unitConfig)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create unit %s: %w", unitConfig.ID, err)
 		}
 		units[unitConfig.ID] = unit
+		provenance[unitConfig.ID] = unitProvenance
+		if unitConfig.Model != "" {
+			unitProviders[unitConfig.ID], _, _ = strings.Cut(unitConfig.Model, "/")
+		}
+	}
+	newAdapter := func(unitID string) *UnitAdapter {
+		p := provenance[unitID]
+		adapter := NewUnitAdapterWithProvenance(units[unitID], unitID, p.Type, p.Model)
+		adapter.promptRef, adapter.promptFingerprint = p.PromptRef, p.PromptFingerprint
+		if provider, ok := unitProviders[unitID]; ok {
+			adapter.modelGuard = func(model string) error {
+				return gl.providerRegistry.ValidateModel(provider, model)
+			}
+		}
+		return adapter
 	}
 
 	pipelines := make(map[string]ports.Pipeline)
@@ -294,11 +389,10 @@ func (gl *GraphLoader) buildGraph(ctx context.Context, config *GraphConfig) (*Gr
 		pipeline := NewPipeline(pipelineConfig.ID)
 
 		for _, unitID := range pipelineConfig.Units {
-			unit, ok := units[unitID]
-			if !ok {
+			if _, ok := units[unitID]; !ok {
 				return nil, fmt.Errorf("unit %s not found for pipeline %s", unitID, pipelineConfig.ID)
 			}
-			executable := NewUnitAdapter(unit, unitID)
+			executable := newAdapter(unitID)
 			if err := pipeline.Add(executable); err != nil {
 				return nil, fmt.Errorf("failed to add unit to pipeline: %w", err)
 			}
@@ -315,12 +409,11 @@ func (gl *GraphLoader) buildGraph(ctx context.Context, config *GraphConfig) (*Gr
 		layer := NewLayer(layerConfig.ID)
 
 		for _, unitID := range layerConfig.Units {
-			unit, ok := units[unitID]
-			if !ok {
+			if _, ok := units[unitID]; !ok {
 				return nil, fmt.Errorf("unit %s not found for layer %s", unitID, layerConfig.ID)
 			}
 			// Wrap unit in adapter to implement Executable.
-			executable := NewUnitAdapter(unit, unitID)
+			executable := newAdapter(unitID)
 			if err := layer.Add(executable); err != nil {
 				return nil, fmt.Errorf("failed to add unit to layer: %w", err)
 			}
@@ -350,10 +443,10 @@ func (gl *GraphLoader) buildGraph(ctx context.Context, config *GraphConfig) (*Gr
 	}
 
 	// Add standalone units to graph (not part of any pipeline or layer).
-	for id, unit := range units {
+	for id := range units {
 		if _, isPlaced := placedUnits[id]; !isPlaced {
 			// Wrap unit in adapter to implement Executable.
-			executable := NewUnitAdapter(unit, id)
+			executable := newAdapter(id)
 			if err := graph.AddNode(executable); err != nil {
 				return nil, fmt.Errorf("failed to add unit to graph: %w", err)
 			}
@@ -379,12 +472,15 @@ func (gl *GraphLoader) buildGraph(ctx context.Context, config *GraphConfig) (*Gr
 // merging YAML parameters with budget, retry, and timeout settings.
 // createUnit delegates to the unit registry for type-specific creation
 // while handling parameter decoding and configuration merging.
+// createUnit also returns the unit's provenance: its type, the model of the
+// resolved LLM client (or the configured model if no client was resolved),
+// and the reference and fingerprint of its prompt.
 // createUnit returns an error if parameter decoding or unit creation fails.
-func (gl *GraphLoader) createUnit(config UnitConfig) (ports.Unit, error) {
+func (gl *GraphLoader) createUnit(config UnitConfig) (ports.Unit, domain.UnitProvenance, error) {
 	// Convert yaml.Node parameters to map[string]any.
 	var params map[string]any
 	if err := config.Parameters.Decode(&params); err != nil {
-		return nil, fmt.Errorf("failed to decode parameters: %w", err)
+		return nil, domain.UnitProvenance{}, fmt.Errorf("failed to decode parameters: %w", err)
 	}
 
 	// Merge parameters with other configuration.
@@ -398,27 +494,36 @@ func (gl *GraphLoader) createUnit(config UnitConfig) (ports.Unit, error) {
 	for k, v := range params {
 		unitConfig[k] = v
 	}
+	if config.EnforceJSON {
+		unitConfig[enforceJSONKey] = true
+	}
 
 	// Get the appropriate LLMClient based on the model field
+	model := config.Model
 	if config.Model != "" || gl.isLLMUnit(config.Type) {
 		// Provider registry is required for units that need LLM clients.
 		if gl.providerRegistry == nil {
-			return nil, fmt.Errorf("provider registry is required for unit %q with model %q", config.ID, config.Model)
+			return nil, domain.UnitProvenance{}, fmt.Errorf("provider registry is required for unit %q with model %q", config.ID, config.Model)
 		}
 		llmClient, err := gl.providerRegistry.GetClient(config.Model)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get LLM client for model %q: %w", config.Model, err)
+			return nil, domain.UnitProvenance{}, fmt.Errorf("failed to get LLM client for model %q: %w", config.Model, err)
 		}
 		unitConfig["llmClient"] = llmClient
+		if clientModel := llmClient.GetModel(); clientModel != "" {
+			model = clientModel
+		}
 	}
 
 	// Use the unit registry to create the unit.
 	unit, err := gl.unitRegistry.CreateUnit(config.Type, config.ID, unitConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create unit: %w", err)
+		return nil, domain.UnitProvenance{}, fmt.Errorf("failed to create unit: %w", err)
 	}
 
-	return unit, nil
+	provenance := domain.UnitProvenance{Name: config.ID, Type: config.Type, Model: model}
+	provenance.PromptRef, provenance.PromptFingerprint = unitPrompt(config, params)
+	return unit, provenance, nil
 }
 
 // isLLMUnit checks if a unit type requires an LLM client.
@@ -428,22 +533,12 @@ func (gl *GraphLoader) isLLMUnit(unitType string) bool {
 	return unitType == "llm_judge"
 }
 
-// calculateConfigHash computes the SHA256 hash of a normalized GraphConfig
-// for cache indexing, ensuring semantically identical configurations produce
-// the same hash regardless of whitespace or key ordering differences.
+// calculateConfigHash computes the cache key for a GraphConfig using its
+// Fingerprint, so semantically identical configurations share a cache entry
+// regardless of formatting, comments, or key ordering differences.
 // calculateConfigHash returns a hexadecimal string representation of the hash.
 func (gl *GraphLoader) calculateConfigHash(config *GraphConfig) (string, error) {
-	// Normalize the config by re-encoding it with consistent formatting.
-	var buf bytes.Buffer
-	encoder := yaml.NewEncoder(&buf)
-	encoder.SetIndent(2) // Use consistent 2-space indentation.
-
-	if err := encoder.Encode(config); err != nil {
-		return "", fmt.Errorf("failed to encode config for hashing: %w", err)
-	}
-
-	hash := sha256.Sum256(buf.Bytes())
-	return hex.EncodeToString(hash[:]), nil
+	return config.Fingerprint()
 }
 
 // getCachedGraph attempts to retrieve a previously compiled graph
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -101,6 +102,20 @@ func (m *mockUnit) Validate() error {
 	return nil
 }
 
+// verdictUnit is a ports.Unit that writes an empty verdict to state.
+type verdictUnit struct{}
+
+// Name returns the unit's fixed name.
+func (verdictUnit) Name() string { return "agg1" }
+
+// Execute stores an empty verdict under domain.KeyVerdict.
+func (verdictUnit) Execute(ctx context.Context, state domain.State) (domain.State, error) {
+	return domain.With(state, domain.KeyVerdict, &domain.Verdict{ID: "v1"}), nil
+}
+
+// Validate is a no-op.
+func (verdictUnit) Validate() error { return nil }
+
 // TestGraphLoader_LoadFromReader tests the loading of a graph from a YAML configuration.
 // It covers various scenarios, including simple graphs, pipelines, layers, and error
 // conditions like cyclic dependencies and invalid configurations.
@@ -139,6 +154,32 @@ graph:
 				assert.NotNil(t, node)
 			},
 		},
+		{
+			name: "passes enforce_json to the unit registry",
+			yaml: `
+version: "1.0.0"
+metadata:
+  name: "json-graph"
+units:
+  - id: unit1
+    type: score_judge
+    budget:
+      max_tokens: 1000
+    enforce_json: true
+    parameters:
+      judge_prompt: "Test prompt"
+      score_scale: "0.0-1.0"
+graph:
+  edges: []
+`,
+			setupMock: func(m *mockUnitRegistry) {},
+			verify: func(t *testing.T, graph ports.Graph) {
+				node, exists := graph.GetNode("unit1")
+				require.True(t, exists)
+				unit := node.(*UnitAdapter).unit.(*mockUnit)
+				assert.Equal(t, true, unit.config[enforceJSONKey])
+			},
+		},
 		{
 			name: "loads pipeline graph",
 			yaml: `
@@ -364,6 +405,200 @@ graph:
 				assert.NotNil(t, graph)
 			},
 		},
+		{
+			name: "seeds graph rubric referenced by units",
+			yaml: `
+version: "1.0.0"
+metadata:
+  name: "rubric-graph"
+rubric:
+  criteria:
+    - id: accuracy
+      description: "The answer is factually correct"
+      weight: 2
+    - id: clarity
+      description: "The answer is easy to follow"
+units:
+  - id: judge1
+    type: score_judge
+    budget:
+      max_tokens: 1000
+    parameters:
+      judge_prompt: "Test prompt"
+      score_scale: "0.0-1.0"
+      criteria: ["accuracy", "clarity"]
+graph:
+  edges: []
+`,
+			setupMock: func(m *mockUnitRegistry) {},
+			wantErr:   false,
+			verify: func(t *testing.T, graph ports.Graph) {
+				g, ok := graph.(*Graph)
+				require.True(t, ok)
+				state, err := g.Execute(context.Background(), domain.NewState())
+				require.NoError(t, err)
+				rubric, ok := domain.Get(state, domain.KeyRubric)
+				require.True(t, ok)
+				assert.Len(t, rubric.Criteria, 2)
+			},
+		},
+		{
+			name: "sets graph timeout",
+			yaml: `
+version: "1.0.0"
+metadata:
+  name: "timed-graph"
+timeout_seconds: 30
+units:
+  - id: unit1
+    type: custom
+    budget:
+      max_tokens: 1000
+    parameters: {}
+graph:
+  edges: []
+`,
+			setupMock: func(m *mockUnitRegistry) {},
+			wantErr:   false,
+			verify: func(t *testing.T, graph ports.Graph) {
+				g, ok := graph.(*Graph)
+				require.True(t, ok)
+				assert.Equal(t, 30*time.Second, g.timeout)
+			},
+		},
+		{
+			name: "rejects out-of-range graph timeout",
+			yaml: `
+version: "1.0.0"
+metadata:
+  name: "timed-graph"
+timeout_seconds: 7200
+units:
+  - id: unit1
+    type: custom
+    budget:
+      max_tokens: 1000
+    parameters: {}
+graph:
+  edges: []
+`,
+			setupMock: func(m *mockUnitRegistry) {},
+			wantErr:   true,
+			errMsg:    "TimeoutSeconds",
+		},
+		{
+			name: "stamps graph metadata onto verdict",
+			yaml: `
+version: "1.2.0"
+metadata:
+  name: "experiment-graph"
+  tags: ["prompt-v2", "nightly"]
+  labels:
+    team: "evals"
+units:
+  - id: agg1
+    type: custom
+    budget:
+      max_tokens: 1000
+    parameters: {}
+graph:
+  edges: []
+`,
+			setupMock: func(m *mockUnitRegistry) {
+				m.units["agg1"] = verdictUnit{}
+			},
+			wantErr: false,
+			verify: func(t *testing.T, graph ports.Graph) {
+				g, ok := graph.(*Graph)
+				require.True(t, ok)
+				state, err := g.Execute(context.Background(), domain.NewState())
+				require.NoError(t, err)
+
+				graphID, _ := domain.Get(state, domain.KeyGraphID)
+				assert.Equal(t, "experiment-graph", graphID)
+
+				verdict, ok := domain.Get(state, domain.KeyVerdict)
+				require.True(t, ok)
+				require.NotNil(t, verdict.Graph)
+				assert.Equal(t, "experiment-graph", verdict.Graph.Name)
+				assert.Equal(t, "1.2.0", verdict.Graph.Version)
+				assert.Equal(t, g.Fingerprint(), verdict.Graph.Fingerprint)
+				assert.Equal(t, []string{"prompt-v2", "nightly"}, verdict.Graph.Tags)
+				assert.Equal(t, map[string]string{"team": "evals"}, verdict.Graph.Labels)
+			},
+		},
+		{
+			name: "rejects reference to undefined criterion",
+			yaml: `
+version: "1.0.0"
+metadata:
+  name: "rubric-graph"
+rubric:
+  criteria:
+    - id: accuracy
+      description: "The answer is factually correct"
+units:
+  - id: judge1
+    type: score_judge
+    budget:
+      max_tokens: 1000
+    parameters:
+      judge_prompt: "Test prompt"
+      score_scale: "0.0-1.0"
+      criteria: ["accuracy", "style"]
+graph:
+  edges: []
+`,
+			setupMock: func(m *mockUnitRegistry) {},
+			wantErr:   true,
+			errMsg:    "unknown criterion: style",
+		},
+		{
+			name: "rejects criteria without a rubric",
+			yaml: `
+version: "1.0.0"
+metadata:
+  name: "rubric-graph"
+units:
+  - id: judge1
+    type: score_judge
+    budget:
+      max_tokens: 1000
+    parameters:
+      judge_prompt: "Test prompt"
+      score_scale: "0.0-1.0"
+      criteria: ["accuracy"]
+graph:
+  edges: []
+`,
+			setupMock: func(m *mockUnitRegistry) {},
+			wantErr:   true,
+			errMsg:    "graph defines no rubric",
+		},
+		{
+			name: "rejects invalid rubric",
+			yaml: `
+version: "1.0.0"
+metadata:
+  name: "rubric-graph"
+rubric:
+  criteria:
+    - id: accuracy
+units:
+  - id: judge1
+    type: score_judge
+    budget:
+      max_tokens: 1000
+    parameters:
+      judge_prompt: "Test prompt"
+      score_scale: "0.0-1.0"
+graph:
+  edges: []
+`,
+			setupMock: func(m *mockUnitRegistry) {},
+			wantErr:   true,
+			errMsg:    "invalid rubric",
+		},
 	}
 
 	for _, tt := range tests {
@@ -3,7 +3,11 @@
 package application
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
 	"testing"
 	"time"
 
@@ -203,3 +207,108 @@ func TestDeterministicBehavior(t *testing.T) {
 	tokens2, _ := client2.EstimateTokens("test text")
 	assert.Equal(t, tokens1, tokens2, "Token estimates should be consistent")
 }
+
+// newJudgeVerifyGraph returns a graph that scores answers with a score
+// judge, picks the winner by arithmetic mean, and verifies the judging, each
+// LLM unit backed by its own mock client.
+func newJudgeVerifyGraph(t *testing.T) *Graph {
+	t.Helper()
+	judgeClient := testutils.NewMockLLMClient("judge-model")
+	verifyClient := testutils.NewMockLLMClient("verify-model")
+	verifyClient.SetResponse(`{"confidence": 0.9, "reasoning": "Sound judging", "version": 1}`)
+
+	judge, err := units.NewScoreJudgeUnit("judge", judgeClient, units.ScoreJudgeConfig{
+		JudgePrompt:    "Rate this answer to '%s': %s (Provide score and reasoning)",
+		ScoreScale:     "0.0-1.0",
+		Temperature:    0.5,
+		MaxTokens:      150,
+		MaxConcurrency: 5,
+	})
+	require.NoError(t, err)
+	mean, err := units.NewArithmeticMeanUnit("mean", units.ArithmeticMeanConfig{TieBreaker: units.TieFirst})
+	require.NoError(t, err)
+	verify, err := units.NewVerificationUnit("verify", verifyClient, units.VerificationConfig{
+		PromptTemplate:      "Verify the judging of {{.Question}}: {{.Answers}} {{.JudgeScores}}",
+		ConfidenceThreshold: 0.7,
+		MaxTokens:           200,
+	})
+	require.NoError(t, err)
+
+	g := NewGraph()
+	require.NoError(t, g.AddNode(NewUnitAdapter(judge, "judge")))
+	require.NoError(t, g.AddNode(NewUnitAdapter(mean, "mean")))
+	require.NoError(t, g.AddNode(NewUnitAdapter(verify, "verify")))
+	require.NoError(t, g.AddEdge("judge", "mean"))
+	require.NoError(t, g.AddEdge("mean", "verify"))
+	return g
+}
+
+// TestGraph_BudgetReport verifies that a plain judge, aggregate, and verify
+// graph charges every LLM call and reports the usage on the verdict.
+func TestGraph_BudgetReport(t *testing.T) {
+	g := newJudgeVerifyGraph(t)
+	state := testutils.EvaluationState(t, "What is the capital of France?", []domain.Answer{
+		{ID: "a1", Content: "Paris"},
+		{ID: "a2", Content: "Lyon"},
+	})
+
+	t.Run("usage without a seeded report", func(t *testing.T) {
+		result, err := g.Execute(context.Background(), state)
+		require.NoError(t, err)
+
+		usage := result.GetBudgetUsage()
+		assert.Equal(t, int64(3), usage.Calls, "two judge calls and one verification call")
+		assert.Positive(t, usage.Tokens)
+
+		verdict, ok := result.GetVerdict()
+		require.True(t, ok)
+		require.NotNil(t, verdict.Budget)
+		assert.Equal(t, 3, verdict.Budget.CallsMade)
+		assert.Equal(t, int(usage.Tokens), verdict.Budget.TokensUsed)
+	})
+
+	t.Run("seeded report accumulates", func(t *testing.T) {
+		seeded := domain.WithBudget(domain.BudgetReport{TokensUsed: 100, CallsMade: 1})(state)
+		result, err := g.Execute(context.Background(), seeded)
+		require.NoError(t, err)
+
+		report, ok := result.GetBudget()
+		require.True(t, ok)
+		assert.Equal(t, 4, report.CallsMade)
+		assert.Equal(t, 100+int(result.GetBudgetUsage().Tokens), report.TokensUsed)
+
+		verdict, _ := result.GetVerdict()
+		assert.Equal(t, *report, *verdict.Budget)
+	})
+}
+
+// TestGraph_EventLog verifies that graph execution logs the start and end
+// of every unit and the finalized verdict to the unit event logger.
+func TestGraph_EventLog(t *testing.T) {
+	var buf bytes.Buffer
+	units.SetEventLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { units.SetEventLogger(nil) })
+
+	g := newJudgeVerifyGraph(t)
+	state := testutils.EvaluationState(t, "What is the capital of France?", []domain.Answer{
+		{ID: "a1", Content: "Paris"},
+		{ID: "a2", Content: "Lyon"},
+	})
+	state = domain.With(state, domain.KeyTraceLevel, "debug")
+	_, err := g.Execute(context.Background(), state)
+	require.NoError(t, err)
+
+	var events []string
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var record map[string]any
+		require.NoError(t, dec.Decode(&record))
+		events = append(events, fmt.Sprintf("%v %v", record["msg"], record["unit.id"]))
+	}
+	assert.Equal(t, []string{
+		"unit started judge", "unit completed judge",
+		"unit started mean", "unit completed mean",
+		"unit started verify", "unit completed verify",
+		"verdict finalized ",
+	}, events)
+}
@@ -0,0 +1,99 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ahrav/go-gavel/infrastructure/llm"
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// ItemRetryPolicy configures whole-item retries for Graph.ExecuteWithRetry.
+// Per-call retries in the LLM client recover from isolated request failures;
+// item retries recover from failures that surface mid-pipeline by
+// re-running the entire graph from the original input state.
+type ItemRetryPolicy struct {
+	// MaxAttempts is the total number of executions including the first.
+	// Values below 1 are treated as 1, which disables retries.
+	MaxAttempts int
+	// Backoff is the delay between attempts. Zero retries immediately.
+	Backoff time.Duration
+	// Retryable reports whether a failed execution should be retried.
+	// If nil, IsRetryableItemError is used.
+	Retryable func(error) bool
+}
+
+// IsRetryableItemError reports whether err is a transient failure worth
+// re-running an item for. Provider errors are retryable when the provider
+// classifies them as such (rate limits, server, network, and timeout
+// errors), and per-request deadlines are retryable. Cancellation, budget,
+// configuration, and item deadline errors are never retried; the item
+// deadline lives in the input state, so a retry would fail at once.
+func IsRetryableItemError(err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, domain.ErrDeadlineExceeded),
+		errors.Is(err, domain.ErrBudgetExceeded),
+		errors.Is(err, domain.ErrInvalidConfiguration):
+		return false
+	}
+
+	var providerErr *llm.ProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr.IsRetryable()
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// ExecuteWithRetry runs the graph like Execute, re-running it from the
+// original state when an attempt fails with a retryable error. Because State
+// is immutable, every attempt starts from the same clean input and partial
+// results from a failed attempt are discarded, making retries idempotent.
+// ExecuteWithRetry stops early if ctx is done and returns the state and
+// error from the final attempt, so the partial results and budget usage of
+// that attempt remain available to the caller.
+func (g *Graph) ExecuteWithRetry(ctx context.Context, state domain.State, policy ItemRetryPolicy) (domain.State, error) {
+	maxAttempts := max(policy.MaxAttempts, 1)
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsRetryableItemError
+	}
+
+	var (
+		lastState domain.State
+		lastErr   error
+		attempts  int
+	)
+	for attempts < maxAttempts {
+		attempts++
+		finalState, err := g.Execute(ctx, state)
+		if err == nil {
+			return finalState, nil
+		}
+		lastState, lastErr = finalState, err
+
+		// The caller's deadline or cancellation applies to all attempts.
+		if ctx.Err() != nil || !retryable(err) || attempts == maxAttempts {
+			break
+		}
+
+		if policy.Backoff > 0 {
+			timer := time.NewTimer(policy.Backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return lastState, fmt.Errorf("graph: item failed after %d attempts: %w", attempts, lastErr)
+			case <-timer.C:
+			}
+		}
+	}
+
+	if attempts > 1 {
+		return lastState, fmt.Errorf("graph: item failed after %d attempts: %w", attempts, lastErr)
+	}
+	return lastState, lastErr
+}
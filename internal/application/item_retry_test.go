@@ -0,0 +1,146 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/infrastructure/llm"
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// TestIsRetryableItemError tests classification of transient and permanent
+// failures for whole-item retries.
+func TestIsRetryableItemError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "rate limit", err: llm.NewProviderError("openai", llm.ErrorTypeRateLimit, 429, "slow down", nil), want: true},
+		{name: "wrapped server error", err: fmt.Errorf("unit failed: %w", llm.NewProviderError("openai", llm.ErrorTypeServerError, 503, "", nil)), want: true},
+		{name: "authentication", err: llm.NewProviderError("openai", llm.ErrorTypeAuthentication, 401, "bad key", nil), want: false},
+		{name: "request deadline", err: fmt.Errorf("call: %w", context.DeadlineExceeded), want: true},
+		{name: "cancelled", err: context.Canceled, want: false},
+		{name: "item deadline", err: fmt.Errorf("graph: %w", domain.ErrDeadlineExceeded), want: false},
+		{name: "budget exceeded", err: &domain.BudgetExceededError{LimitType: "tokens", Limit: 10, Used: 20}, want: false},
+		{name: "plain error", err: errors.New("parse failure"), want: false},
+		{name: "LLM call error", err: domain.NewLLMCallError("judge", "", llm.NewProviderError("openai", llm.ErrorTypeRateLimit, 429, "", nil)), want: true},
+		{name: "config validation error", err: domain.NewConfigValidationError("judge", errors.New("bad")), want: false},
+		{name: "missing state", err: domain.NewMissingStateError("judge", "answers"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetryableItemError(tt.err))
+		})
+	}
+}
+
+// TestGraph_ExecuteWithRetry tests that failed items are re-run from the
+// original state up to the configured limit and only for retryable errors.
+func TestGraph_ExecuteWithRetry(t *testing.T) {
+	markerKey := domain.NewKey[int]("marker")
+	transient := llm.NewProviderError("openai", llm.ErrorTypeServerError, 500, "unavailable", nil)
+
+	// newFlakyGraph returns a graph whose first node writes a marker and whose
+	// second node fails with failErr for the first failures executions.
+	newFlakyGraph := func(failures int32, failErr error) (*Graph, *atomic.Int32) {
+		var calls atomic.Int32
+		writer := &mockExecutable{
+			id: "writer",
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				marker, _ := domain.Get(state, markerKey)
+				return domain.With(state, markerKey, marker+1), nil
+			},
+		}
+		flaky := &mockExecutable{
+			id: "flaky",
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				if calls.Add(1) <= failures {
+					return state, failErr
+				}
+				return state, nil
+			},
+		}
+		g := NewGraph()
+		require.NoError(t, g.AddNode(writer))
+		require.NoError(t, g.AddNode(flaky))
+		require.NoError(t, g.AddEdge("writer", "flaky"))
+		return g, &calls
+	}
+
+	t.Run("retries transient failure from clean state", func(t *testing.T) {
+		g, calls := newFlakyGraph(2, transient)
+
+		state, err := g.ExecuteWithRetry(context.Background(), domain.NewState(), ItemRetryPolicy{MaxAttempts: 3})
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), calls.Load())
+
+		// Partial results from failed attempts must not leak into the retry.
+		marker, ok := domain.Get(state, markerKey)
+		require.True(t, ok)
+		assert.Equal(t, 1, marker)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		g, calls := newFlakyGraph(5, transient)
+
+		state, err := g.ExecuteWithRetry(context.Background(), domain.NewState(), ItemRetryPolicy{MaxAttempts: 2})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "item failed after 2 attempts")
+		assert.ErrorIs(t, err, transient)
+		assert.Equal(t, int32(2), calls.Load())
+
+		// The final attempt's partial results are returned with the error.
+		marker, ok := domain.Get(state, markerKey)
+		require.True(t, ok)
+		assert.Equal(t, 1, marker)
+	})
+
+	t.Run("does not retry permanent failure", func(t *testing.T) {
+		g, calls := newFlakyGraph(5, errors.New("invalid response"))
+
+		_, err := g.ExecuteWithRetry(context.Background(), domain.NewState(), ItemRetryPolicy{MaxAttempts: 3})
+		require.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("zero attempts runs once", func(t *testing.T) {
+		g, calls := newFlakyGraph(5, transient)
+
+		_, err := g.ExecuteWithRetry(context.Background(), domain.NewState(), ItemRetryPolicy{})
+		require.ErrorIs(t, err, transient)
+		assert.NotContains(t, err.Error(), "attempts")
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("custom classifier", func(t *testing.T) {
+		permanent := errors.New("flaky parser")
+		g, calls := newFlakyGraph(1, permanent)
+
+		_, err := g.ExecuteWithRetry(context.Background(), domain.NewState(), ItemRetryPolicy{
+			MaxAttempts: 2,
+			Retryable:   func(err error) bool { return errors.Is(err, permanent) },
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("stops waiting when context is cancelled", func(t *testing.T) {
+		g, calls := newFlakyGraph(5, transient)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := g.ExecuteWithRetry(ctx, domain.NewState(), ItemRetryPolicy{MaxAttempts: 5, Backoff: time.Minute})
+		require.ErrorIs(t, err, transient)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
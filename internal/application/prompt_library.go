@@ -0,0 +1,236 @@
+package application
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// promptRefParameter is the unit parameter that references a prompt in the
+// loader's PromptLibrary instead of embedding the prompt inline.
+const promptRefParameter = "prompt_ref"
+
+// promptParameters maps each built-in unit type that takes a prompt to the
+// parameter holding it. A prompt_ref on these units resolves into that
+// parameter.
+var promptParameters = map[string]string{
+	"answerer":      "prompt",
+	"score_judge":   "judge_prompt",
+	"verification":  "prompt_template",
+	"decomposition": "prompt_template",
+	"win_matrix":    "prompt_template",
+}
+
+var (
+	// ErrUnknownPrompt is returned when a prompt reference names a prompt
+	// version that is not registered.
+	ErrUnknownPrompt = errors.New("unknown prompt")
+	// ErrPromptConflict is returned when a prompt version is registered
+	// again with a different template.
+	ErrPromptConflict = errors.New("prompt version already registered with a different template")
+)
+
+// PromptTemplate is one version of a named prompt.
+type PromptTemplate struct {
+	// Name identifies the prompt across versions, e.g. "accuracy_judge".
+	Name string `yaml:"name"`
+	// Version distinguishes revisions of the prompt, e.g. "v2".
+	Version string `yaml:"version"`
+	// Template is the prompt text, in the template syntax of the units
+	// that use it.
+	Template string `yaml:"template"`
+}
+
+// Ref returns the reference units use for the prompt: "name@version".
+func (p PromptTemplate) Ref() string { return p.Name + "@" + p.Version }
+
+// Fingerprint returns the hex-encoded SHA256 of the template.
+func (p PromptTemplate) Fingerprint() string { return PromptFingerprint(p.Template) }
+
+// PromptFingerprint returns the hex-encoded SHA256 of a prompt template,
+// identifying the exact prompt text a unit was built with.
+func PromptFingerprint(template string) string {
+	sum := sha256.Sum256([]byte(template))
+	return hex.EncodeToString(sum[:])
+}
+
+// PromptLibrary holds named, versioned prompt templates that graph units
+// reference with prompt_ref: "name@version" instead of embedding the prompt
+// in their parameters. Keeping prompts in one place stops them drifting
+// between graphs, and the reference plus the prompt's fingerprint is
+// reported in verdict provenance so results can be compared by prompt
+// version.
+//
+// A registered version is immutable: references always pin a version, so a
+// graph keeps its prompt until its configuration is changed to use another.
+// PromptLibrary is safe for concurrent use.
+type PromptLibrary struct {
+	mu      sync.RWMutex
+	prompts map[string]PromptTemplate // ref -> prompt
+}
+
+// NewPromptLibrary creates an empty prompt library.
+func NewPromptLibrary() *PromptLibrary {
+	return &PromptLibrary{prompts: make(map[string]PromptTemplate)}
+}
+
+// Register adds a prompt version to the library. Registering an identical
+// version again is a no-op. Register returns an error if the name, version,
+// or template is empty, the name or version contains "@", or the version is
+// already registered with a different template.
+func (pl *PromptLibrary) Register(prompt PromptTemplate) error {
+	if prompt.Name == "" || prompt.Version == "" {
+		return fmt.Errorf("prompt name and version are required")
+	}
+	if strings.Contains(prompt.Name, "@") || strings.Contains(prompt.Version, "@") {
+		return fmt.Errorf("prompt %q: name and version cannot contain '@'", prompt.Ref())
+	}
+	if prompt.Template == "" {
+		return fmt.Errorf("prompt %q: template cannot be empty", prompt.Ref())
+	}
+
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	if existing, ok := pl.prompts[prompt.Ref()]; ok && existing.Template != prompt.Template {
+		return fmt.Errorf("prompt %q: %w", prompt.Ref(), ErrPromptConflict)
+	}
+	pl.prompts[prompt.Ref()] = prompt
+	return nil
+}
+
+// Resolve returns the prompt named by ref, which must have the form
+// "name@version". Resolve returns ErrUnknownPrompt if no such version is
+// registered.
+func (pl *PromptLibrary) Resolve(ref string) (PromptTemplate, error) {
+	name, version, ok := strings.Cut(ref, "@")
+	if !ok || name == "" || version == "" {
+		return PromptTemplate{}, fmt.Errorf("prompt reference %q must have the form name@version", ref)
+	}
+
+	pl.mu.RLock()
+	defer pl.mu.RUnlock()
+	prompt, ok := pl.prompts[ref]
+	if !ok {
+		return PromptTemplate{}, fmt.Errorf("%w: %s", ErrUnknownPrompt, ref)
+	}
+	return prompt, nil
+}
+
+// promptLibraryFile is the YAML layout read by LoadPromptLibrary.
+type promptLibraryFile struct {
+	Prompts []PromptTemplate `yaml:"prompts"`
+}
+
+// LoadPromptLibrary reads prompt templates from YAML of the form
+//
+//	prompts:
+//	  - name: accuracy_judge
+//	    version: v2
+//	    template: |
+//	      Score the answer ...
+//
+// and registers them in a new library. It returns an error if the YAML is
+// malformed or any prompt fails to register.
+func LoadPromptLibrary(r io.Reader) (*PromptLibrary, error) {
+	var file promptLibraryFile
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse prompt library: %w", err)
+	}
+
+	library := NewPromptLibrary()
+	for _, prompt := range file.Prompts {
+		if err := library.Register(prompt); err != nil {
+			return nil, err
+		}
+	}
+	return library, nil
+}
+
+// LoadPromptLibraryFile reads a prompt library from the YAML file at path.
+func LoadPromptLibraryFile(path string) (*PromptLibrary, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is supplied by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to open prompt library: %w", err)
+	}
+	defer f.Close()
+	return LoadPromptLibrary(f)
+}
+
+// resolvePromptRefs replaces each unit's prompt_ref with the referenced
+// template, written to the parameter the unit type reads its prompt from.
+// The prompt_ref parameter is kept so that it is reported in provenance and
+// distinguishes otherwise identical graphs in the cache. resolvePromptRefs
+// returns an error if a reference cannot be resolved, the unit type takes
+// no prompt, or the unit also sets the prompt inline.
+func (gl *GraphLoader) resolvePromptRefs(config *GraphConfig) error {
+	for i := range config.Units {
+		unit := &config.Units[i]
+		params := &unit.Parameters
+		if params.Kind != yaml.MappingNode {
+			continue
+		}
+		refNode := mappingValue(params, promptRefParameter)
+		if refNode == nil {
+			continue
+		}
+
+		param, ok := promptParameters[unit.Type]
+		if !ok {
+			return fmt.Errorf("unit %s: unit type %s does not take a prompt", unit.ID, unit.Type)
+		}
+		if refNode.Kind != yaml.ScalarNode || refNode.Tag != "!!str" {
+			return fmt.Errorf("unit %s: %s must be a string", unit.ID, promptRefParameter)
+		}
+		if mappingValue(params, param) != nil {
+			return fmt.Errorf("unit %s: set either %s or %s, not both", unit.ID, promptRefParameter, param)
+		}
+		if gl.prompts == nil {
+			return fmt.Errorf("unit %s: %s %q requires a prompt library", unit.ID, promptRefParameter, refNode.Value)
+		}
+
+		prompt, err := gl.prompts.Resolve(refNode.Value)
+		if err != nil {
+			return fmt.Errorf("unit %s: %w", unit.ID, err)
+		}
+		params.Content = append(params.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: param},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: prompt.Template},
+		)
+	}
+	return nil
+}
+
+// mappingValue returns the value node for key in a YAML mapping node, or
+// nil if the key is absent.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// unitPrompt returns the prompt reference and the fingerprint of the prompt
+// a unit is built with, for provenance. Both are empty for unit types that
+// take no prompt; the reference is empty for inline prompts.
+func unitPrompt(config UnitConfig, params map[string]any) (ref, fingerprint string) {
+	param, ok := promptParameters[config.Type]
+	if !ok {
+		return "", ""
+	}
+	ref, _ = params[promptRefParameter].(string)
+	if prompt, ok := params[param].(string); ok && prompt != "" {
+		fingerprint = PromptFingerprint(prompt)
+	}
+	return ref, fingerprint
+}
@@ -0,0 +1,229 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	accuracyPromptV1 = "Score the answer for accuracy.\n\n{{.QuestionBlock}}\n\n{{.AnswerBlock}}"
+	accuracyPromptV2 = "Score the answer for factual accuracy only.\n\n{{.QuestionBlock}}\n\n{{.AnswerBlock}}"
+)
+
+// testPromptLibrary returns a library holding two versions of
+// accuracy_judge.
+func testPromptLibrary(t *testing.T) *PromptLibrary {
+	t.Helper()
+	library := NewPromptLibrary()
+	require.NoError(t, library.Register(PromptTemplate{Name: "accuracy_judge", Version: "v1", Template: accuracyPromptV1}))
+	require.NoError(t, library.Register(PromptTemplate{Name: "accuracy_judge", Version: "v2", Template: accuracyPromptV2}))
+	return library
+}
+
+// TestPromptLibrary_Register tests that registration validates prompts and
+// keeps registered versions immutable.
+func TestPromptLibrary_Register(t *testing.T) {
+	library := testPromptLibrary(t)
+
+	tests := []struct {
+		name    string
+		prompt  PromptTemplate
+		wantErr bool
+		errIs   error
+	}{
+		{name: "identical version again", prompt: PromptTemplate{Name: "accuracy_judge", Version: "v1", Template: accuracyPromptV1}},
+		{name: "changed template", prompt: PromptTemplate{Name: "accuracy_judge", Version: "v1", Template: accuracyPromptV2}, wantErr: true, errIs: ErrPromptConflict},
+		{name: "missing version", prompt: PromptTemplate{Name: "accuracy_judge", Template: accuracyPromptV1}, wantErr: true},
+		{name: "at sign in name", prompt: PromptTemplate{Name: "a@b", Version: "v1", Template: accuracyPromptV1}, wantErr: true},
+		{name: "empty template", prompt: PromptTemplate{Name: "accuracy_judge", Version: "v3"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := library.Register(tt.prompt)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+		})
+	}
+
+	prompt, err := library.Resolve("accuracy_judge@v1")
+	require.NoError(t, err)
+	assert.Equal(t, accuracyPromptV1, prompt.Template)
+}
+
+// TestPromptLibrary_Resolve tests resolving references by name and version.
+func TestPromptLibrary_Resolve(t *testing.T) {
+	library := testPromptLibrary(t)
+
+	prompt, err := library.Resolve("accuracy_judge@v2")
+	require.NoError(t, err)
+	assert.Equal(t, "accuracy_judge@v2", prompt.Ref())
+	assert.Equal(t, accuracyPromptV2, prompt.Template)
+	assert.Equal(t, PromptFingerprint(accuracyPromptV2), prompt.Fingerprint())
+	assert.NotEqual(t, PromptFingerprint(accuracyPromptV1), prompt.Fingerprint())
+
+	_, err = library.Resolve("accuracy_judge@v9")
+	assert.ErrorIs(t, err, ErrUnknownPrompt)
+
+	for _, ref := range []string{"accuracy_judge", "@v1", "accuracy_judge@"} {
+		_, err := library.Resolve(ref)
+		assert.Error(t, err, ref)
+	}
+}
+
+// TestLoadPromptLibrary tests reading a library from YAML.
+func TestLoadPromptLibrary(t *testing.T) {
+	library, err := LoadPromptLibrary(strings.NewReader(`
+prompts:
+  - name: accuracy_judge
+    version: v1
+    template: "Score the answer."
+  - name: accuracy_judge
+    version: v2
+    template: "Score the answer strictly."
+`))
+	require.NoError(t, err)
+	prompt, err := library.Resolve("accuracy_judge@v2")
+	require.NoError(t, err)
+	assert.Equal(t, "Score the answer strictly.", prompt.Template)
+
+	_, err = LoadPromptLibrary(strings.NewReader("prompts:\n  - name: x\n    version: v1\n    text: y\n"))
+	assert.Error(t, err, "unknown fields are rejected")
+
+	_, err = LoadPromptLibrary(strings.NewReader("prompts:\n  - name: x\n    version: v1\n"))
+	assert.Error(t, err, "prompts need a template")
+}
+
+// promptGraph returns a graph with one unit of unitType whose parameters
+// are params.
+func promptGraph(unitType, params string) string {
+	return `
+version: "1.0.0"
+metadata:
+  name: "prompt-ref"
+units:
+  - id: judge
+    type: ` + unitType + `
+    budget: {}
+    parameters:
+      score_scale: "1-10"
+` + params + `
+graph:
+  edges: []
+`
+}
+
+// TestGraphLoader_PromptRef tests that prompt references resolve into the
+// unit's prompt parameter at load time and are reported in provenance.
+func TestGraphLoader_PromptRef(t *testing.T) {
+	load := func(t *testing.T, library *PromptLibrary, yaml string) (*UnitAdapter, error) {
+		t.Helper()
+		loader, err := NewGraphLoader(newMockUnitRegistry(), nil)
+		require.NoError(t, err)
+		if library != nil {
+			loader.SetPromptLibrary(library)
+		}
+		graph, err := loader.LoadFromReader(context.Background(), strings.NewReader(yaml))
+		if err != nil {
+			return nil, err
+		}
+		node, ok := graph.GetNode("judge")
+		require.True(t, ok)
+		adapter, ok := node.(*UnitAdapter)
+		require.True(t, ok)
+		return adapter, nil
+	}
+
+	t.Run("reference resolves to the library prompt", func(t *testing.T) {
+		adapter, err := load(t, testPromptLibrary(t), promptGraph("score_judge", `      prompt_ref: "accuracy_judge@v2"`))
+		require.NoError(t, err)
+
+		config := adapter.unit.(*mockUnit).config
+		assert.Equal(t, accuracyPromptV2, config["judge_prompt"])
+
+		provenance := adapter.Provenance()
+		assert.Equal(t, "accuracy_judge@v2", provenance.PromptRef)
+		assert.Equal(t, PromptFingerprint(accuracyPromptV2), provenance.PromptFingerprint)
+	})
+
+	t.Run("inline prompt is fingerprinted", func(t *testing.T) {
+		adapter, err := load(t, nil, promptGraph("score_judge", `      judge_prompt: "`+strings.ReplaceAll(accuracyPromptV1, "\n", `\n`)+`"`))
+		require.NoError(t, err)
+
+		provenance := adapter.Provenance()
+		assert.Empty(t, provenance.PromptRef)
+		assert.Equal(t, PromptFingerprint(accuracyPromptV1), provenance.PromptFingerprint)
+	})
+
+	t.Run("versions are cached separately", func(t *testing.T) {
+		library := testPromptLibrary(t)
+		loader, err := NewGraphLoader(newMockUnitRegistry(), nil)
+		require.NoError(t, err)
+		loader.SetPromptLibrary(library)
+
+		v1, err := loader.LoadFromReader(context.Background(), strings.NewReader(promptGraph("score_judge", `      prompt_ref: "accuracy_judge@v1"`)))
+		require.NoError(t, err)
+		v2, err := loader.LoadFromReader(context.Background(), strings.NewReader(promptGraph("score_judge", `      prompt_ref: "accuracy_judge@v2"`)))
+		require.NoError(t, err)
+		assert.NotEqual(t, v1.Fingerprint(), v2.Fingerprint())
+	})
+
+	tests := []struct {
+		name     string
+		library  *PromptLibrary
+		unitType string
+		params   string
+		wantErr  string
+	}{
+		{
+			name:     "unknown version",
+			library:  testPromptLibrary(t),
+			unitType: "score_judge",
+			params:   `      prompt_ref: "accuracy_judge@v3"`,
+			wantErr:  "unknown prompt",
+		},
+		{
+			name:     "no library",
+			unitType: "score_judge",
+			params:   `      prompt_ref: "accuracy_judge@v1"`,
+			wantErr:  "requires a prompt library",
+		},
+		{
+			name:     "reference and inline prompt",
+			library:  testPromptLibrary(t),
+			unitType: "score_judge",
+			params:   "      prompt_ref: \"accuracy_judge@v1\"\n      judge_prompt: \"Score this answer from one to ten.\"",
+			wantErr:  "not both",
+		},
+		{
+			name:     "unit type without a prompt",
+			library:  testPromptLibrary(t),
+			unitType: "max_pool",
+			params:   `      prompt_ref: "accuracy_judge@v1"`,
+			wantErr:  "does not take a prompt",
+		},
+		{
+			name:     "non-string reference",
+			library:  testPromptLibrary(t),
+			unitType: "score_judge",
+			params:   `      prompt_ref: 2`,
+			wantErr:  "must be a string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := load(t, tt.library, promptGraph(tt.unitType, tt.params))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
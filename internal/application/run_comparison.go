@@ -0,0 +1,206 @@
+package application
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// DefaultConfidenceShift is the confidence change at or above which
+// CompareRuns reports an item whose winner did not change.
+const DefaultConfidenceShift = 0.1
+
+// RunComparisonOptions configures CompareRuns.
+type RunComparisonOptions struct {
+	// ConfidenceShift is the absolute confidence change at or above which
+	// an item is reported as a confidence shift. Zero uses
+	// DefaultConfidenceShift.
+	ConfidenceShift float64
+
+	// Expected maps item IDs to the answer ID that should win. Accuracy is
+	// only computed when it is non-empty, over items present in both runs.
+	Expected map[string]string
+}
+
+// VerdictChange describes how one item's verdict differs between runs.
+// Winner IDs are empty when the run selected no winner.
+type VerdictChange struct {
+	ItemID              string
+	BaselineWinner      string
+	CandidateWinner     string
+	BaselineConfidence  float64
+	CandidateConfidence float64
+}
+
+// ConfidenceDelta returns the candidate confidence minus the baseline's.
+func (c VerdictChange) ConfidenceDelta() float64 {
+	return c.CandidateConfidence - c.BaselineConfidence
+}
+
+// RunComparison is the diff between a baseline and a candidate evaluation
+// run, used to spot regressions after changing a prompt or model. All item
+// lists are sorted by item ID.
+type RunComparison struct {
+	// ItemsCompared counts items with verdicts in both runs.
+	ItemsCompared int
+
+	// WinnerChanges lists items whose winning answer changed, including
+	// items where one run abstained.
+	WinnerChanges []VerdictChange
+
+	// ConfidenceShifts lists items with the same winner whose confidence
+	// moved by at least the configured shift.
+	ConfidenceShifts []VerdictChange
+
+	// OnlyInBaseline and OnlyInCandidate list items missing from the other
+	// run, which are excluded from every other statistic.
+	OnlyInBaseline  []string
+	OnlyInCandidate []string
+
+	// AccuracyItems counts compared items with an expected winner.
+	// Accuracy fields are zero when it is zero.
+	AccuracyItems int
+
+	// BaselineAccuracy and CandidateAccuracy are the fractions of
+	// AccuracyItems whose winner matched the expected answer.
+	BaselineAccuracy  float64
+	CandidateAccuracy float64
+}
+
+// AccuracyDelta returns the candidate accuracy minus the baseline's.
+func (c *RunComparison) AccuracyDelta() float64 {
+	return c.CandidateAccuracy - c.BaselineAccuracy
+}
+
+// CompareRuns diffs two runs' verdicts, each keyed by item ID. Nil verdicts
+// are treated as abstentions.
+func CompareRuns(baseline, candidate map[string]*domain.Verdict, opts RunComparisonOptions) *RunComparison {
+	shift := opts.ConfidenceShift
+	if shift == 0 {
+		shift = DefaultConfidenceShift
+	}
+
+	result := &RunComparison{}
+	var baselineCorrect, candidateCorrect int
+
+	for _, itemID := range slices.Sorted(maps.Keys(baseline)) {
+		after, ok := candidate[itemID]
+		if !ok {
+			result.OnlyInBaseline = append(result.OnlyInBaseline, itemID)
+			continue
+		}
+		before := baseline[itemID]
+		result.ItemsCompared++
+
+		change := VerdictChange{
+			ItemID:              itemID,
+			BaselineWinner:      winnerID(before),
+			CandidateWinner:     winnerID(after),
+			BaselineConfidence:  verdictConfidence(before),
+			CandidateConfidence: verdictConfidence(after),
+		}
+		switch {
+		case change.BaselineWinner != change.CandidateWinner:
+			result.WinnerChanges = append(result.WinnerChanges, change)
+		case math.Abs(change.ConfidenceDelta()) >= shift:
+			result.ConfidenceShifts = append(result.ConfidenceShifts, change)
+		}
+
+		if expected, ok := opts.Expected[itemID]; ok {
+			result.AccuracyItems++
+			if change.BaselineWinner == expected {
+				baselineCorrect++
+			}
+			if change.CandidateWinner == expected {
+				candidateCorrect++
+			}
+		}
+	}
+
+	for _, itemID := range slices.Sorted(maps.Keys(candidate)) {
+		if _, ok := baseline[itemID]; !ok {
+			result.OnlyInCandidate = append(result.OnlyInCandidate, itemID)
+		}
+	}
+
+	if result.AccuracyItems > 0 {
+		result.BaselineAccuracy = float64(baselineCorrect) / float64(result.AccuracyItems)
+		result.CandidateAccuracy = float64(candidateCorrect) / float64(result.AccuracyItems)
+	}
+	return result
+}
+
+// winnerID returns the verdict's winning answer ID, or "" if it has none.
+func winnerID(v *domain.Verdict) string {
+	if v == nil || v.WinnerAnswer == nil {
+		return ""
+	}
+	return v.WinnerAnswer.ID
+}
+
+// verdictConfidence returns the verdict's confidence, or 0 for nil.
+func verdictConfidence(v *domain.Verdict) float64 {
+	if v == nil {
+		return 0
+	}
+	return v.Confidence
+}
+
+// Markdown renders the comparison as a Markdown report with a summary
+// followed by tables of winner changes and confidence shifts.
+func (c *RunComparison) Markdown() string {
+	var b strings.Builder
+
+	b.WriteString("# Run comparison\n\n")
+	fmt.Fprintf(&b, "- Items compared: %d\n", c.ItemsCompared)
+	fmt.Fprintf(&b, "- Winner changes: %d\n", len(c.WinnerChanges))
+	fmt.Fprintf(&b, "- Confidence shifts: %d\n", len(c.ConfidenceShifts))
+	if c.AccuracyItems > 0 {
+		fmt.Fprintf(&b, "- Accuracy: %.1f%% → %.1f%% (%+.1f pts over %d items)\n",
+			c.BaselineAccuracy*100, c.CandidateAccuracy*100, c.AccuracyDelta()*100, c.AccuracyItems)
+	}
+	if len(c.OnlyInBaseline) > 0 {
+		fmt.Fprintf(&b, "- Only in baseline: %s\n", strings.Join(c.OnlyInBaseline, ", "))
+	}
+	if len(c.OnlyInCandidate) > 0 {
+		fmt.Fprintf(&b, "- Only in candidate: %s\n", strings.Join(c.OnlyInCandidate, ", "))
+	}
+
+	writeChangeTable(&b, "Winner changes", c.WinnerChanges)
+	writeChangeTable(&b, "Confidence shifts", c.ConfidenceShifts)
+	return b.String()
+}
+
+// writeChangeTable appends a Markdown section listing changes, or nothing
+// when there are none.
+func writeChangeTable(b *strings.Builder, title string, changes []VerdictChange) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n", title)
+	b.WriteString("| Item | Baseline winner | Candidate winner | Baseline confidence | Candidate confidence | Δ confidence |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+	for _, change := range changes {
+		fmt.Fprintf(b, "| %s | %s | %s | %.2f | %.2f | %+.2f |\n",
+			markdownCell(change.ItemID), markdownWinner(change.BaselineWinner), markdownWinner(change.CandidateWinner),
+			change.BaselineConfidence, change.CandidateConfidence, change.ConfidenceDelta())
+	}
+}
+
+// markdownWinner formats a winner ID for a table cell.
+func markdownWinner(id string) string {
+	if id == "" {
+		return "_none_"
+	}
+	return markdownCell(id)
+}
+
+// markdownCell escapes pipes and newlines so value stays in one table cell.
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.ReplaceAll(value, "\n", " ")
+}
@@ -0,0 +1,85 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// verdictFor builds a verdict won by answerID, or an abstention when
+// answerID is empty.
+func verdictFor(answerID string, confidence float64) *domain.Verdict {
+	v := &domain.Verdict{Confidence: confidence}
+	if answerID != "" {
+		v.WinnerAnswer = &domain.Answer{ID: answerID}
+	}
+	return v
+}
+
+// TestCompareRuns tests winner changes, confidence shifts, unmatched items,
+// and accuracy between two runs.
+func TestCompareRuns(t *testing.T) {
+	baseline := map[string]*domain.Verdict{
+		"q1": verdictFor("a1", 0.9),
+		"q2": verdictFor("a1", 0.8),
+		"q3": verdictFor("a2", 0.5),
+		"q4": verdictFor("a1", 0.7),
+		"q5": verdictFor("a1", 0.6),
+	}
+	candidate := map[string]*domain.Verdict{
+		"q1": verdictFor("a1", 0.88),
+		"q2": verdictFor("a2", 0.6),
+		"q3": verdictFor("a2", 0.9),
+		"q4": verdictFor("", 0.1),
+		"q6": verdictFor("a1", 0.9),
+	}
+	expected := map[string]string{"q1": "a1", "q2": "a2", "q3": "a2", "q4": "a1"}
+
+	result := CompareRuns(baseline, candidate, RunComparisonOptions{Expected: expected})
+
+	assert.Equal(t, 4, result.ItemsCompared)
+	require.Len(t, result.WinnerChanges, 2)
+	assert.Equal(t, "q2", result.WinnerChanges[0].ItemID)
+	assert.Equal(t, "a1", result.WinnerChanges[0].BaselineWinner)
+	assert.Equal(t, "a2", result.WinnerChanges[0].CandidateWinner)
+	assert.Equal(t, "q4", result.WinnerChanges[1].ItemID)
+	assert.Empty(t, result.WinnerChanges[1].CandidateWinner)
+
+	require.Len(t, result.ConfidenceShifts, 1)
+	assert.Equal(t, "q3", result.ConfidenceShifts[0].ItemID)
+	assert.InDelta(t, 0.4, result.ConfidenceShifts[0].ConfidenceDelta(), 1e-9)
+
+	assert.Equal(t, []string{"q5"}, result.OnlyInBaseline)
+	assert.Equal(t, []string{"q6"}, result.OnlyInCandidate)
+
+	assert.Equal(t, 4, result.AccuracyItems)
+	assert.Equal(t, 0.75, result.BaselineAccuracy)
+	assert.Equal(t, 0.75, result.CandidateAccuracy)
+	assert.Zero(t, result.AccuracyDelta())
+
+	t.Run("custom confidence shift", func(t *testing.T) {
+		result := CompareRuns(baseline, candidate, RunComparisonOptions{ConfidenceShift: 0.5})
+		assert.Empty(t, result.ConfidenceShifts)
+		assert.Zero(t, result.AccuracyItems)
+	})
+}
+
+// TestRunComparison_Markdown tests the rendered report.
+func TestRunComparison_Markdown(t *testing.T) {
+	result := CompareRuns(
+		map[string]*domain.Verdict{"q|1": verdictFor("a1", 0.9), "q2": verdictFor("a1", 0.9)},
+		map[string]*domain.Verdict{"q|1": verdictFor("", 0.2), "q2": verdictFor("a1", 0.9)},
+		RunComparisonOptions{Expected: map[string]string{"q|1": "a1", "q2": "a1"}},
+	)
+
+	report := result.Markdown()
+	assert.Contains(t, report, "# Run comparison")
+	assert.Contains(t, report, "- Items compared: 2")
+	assert.Contains(t, report, "- Accuracy: 100.0% → 50.0% (-50.0 pts over 2 items)")
+	assert.Contains(t, report, "## Winner changes")
+	assert.Contains(t, report, `| q\|1 | a1 | _none_ | 0.90 | 0.20 | -0.70 |`)
+	assert.NotContains(t, report, "## Confidence shifts")
+}
@@ -0,0 +1,144 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// ScoreStats summarizes a set of scores observed across repeated runs.
+// Variance is the population variance.
+type ScoreStats struct {
+	Count    int     `json:"count"`
+	Mean     float64 `json:"mean"`
+	Variance float64 `json:"variance"`
+	Min      float64 `json:"min"`
+	Max      float64 `json:"max"`
+}
+
+// StdDev returns the standard deviation of the scores.
+func (s ScoreStats) StdDev() float64 { return math.Sqrt(s.Variance) }
+
+// newScoreStats computes statistics over values, returning the zero value
+// when there are none.
+func newScoreStats(values []float64) ScoreStats {
+	if len(values) == 0 {
+		return ScoreStats{}
+	}
+	stats := ScoreStats{Count: len(values), Min: values[0], Max: values[0]}
+	var sum float64
+	for _, v := range values {
+		sum += v
+		stats.Min = min(stats.Min, v)
+		stats.Max = max(stats.Max, v)
+	}
+	stats.Mean = sum / float64(len(values))
+	for _, v := range values {
+		stats.Variance += (v - stats.Mean) * (v - stats.Mean)
+	}
+	stats.Variance /= float64(len(values))
+	return stats
+}
+
+// StabilityReport describes how consistently a graph judged the same input
+// across repeated runs, used to detect non-deterministic judges and choose
+// confidence thresholds.
+type StabilityReport struct {
+	// Runs is the number of runs the report covers.
+	Runs int `json:"runs"`
+
+	// WinnerCounts maps each winning answer ID to the number of runs that
+	// selected it. Runs without a winner, including abstentions, are
+	// counted under the empty ID.
+	WinnerCounts map[string]int `json:"winner_counts"`
+
+	// ModalWinner is the answer ID selected most often, with ties broken
+	// by the smallest ID. It is empty when abstaining was most common.
+	ModalWinner string `json:"modal_winner"`
+
+	// WinnerAgreement is the fraction of runs that selected ModalWinner.
+	// 1.0 means every run agreed.
+	WinnerAgreement float64 `json:"winner_agreement"`
+
+	// AggregateScore and Confidence summarize the verdicts' aggregate
+	// scores and confidences over runs that produced a verdict.
+	AggregateScore ScoreStats `json:"aggregate_score"`
+	Confidence     ScoreStats `json:"confidence"`
+
+	// AnswerScores summarizes each answer's ranked score, keyed by answer
+	// ID, over the runs that ranked it.
+	AnswerScores map[string]ScoreStats `json:"answer_scores,omitempty"`
+}
+
+// NewStabilityReport aggregates the verdicts of repeated runs over the same
+// input. Nil verdicts are treated as abstentions.
+func NewStabilityReport(verdicts []*domain.Verdict) *StabilityReport {
+	report := &StabilityReport{
+		Runs:         len(verdicts),
+		WinnerCounts: make(map[string]int),
+	}
+	if len(verdicts) == 0 {
+		return report
+	}
+
+	var aggregates, confidences []float64
+	answerScores := make(map[string][]float64)
+	for _, v := range verdicts {
+		report.WinnerCounts[winnerID(v)]++
+		if v == nil {
+			continue
+		}
+		aggregates = append(aggregates, v.AggregateScore)
+		confidences = append(confidences, v.Confidence)
+		for _, ranked := range v.RankedAnswers {
+			answerScores[ranked.Answer.ID] = append(answerScores[ranked.Answer.ID], ranked.Score)
+		}
+	}
+
+	modalCount := 0
+	for _, id := range slices.Sorted(maps.Keys(report.WinnerCounts)) {
+		if count := report.WinnerCounts[id]; count > modalCount {
+			report.ModalWinner, modalCount = id, count
+		}
+	}
+	report.WinnerAgreement = float64(modalCount) / float64(len(verdicts))
+	report.AggregateScore = newScoreStats(aggregates)
+	report.Confidence = newScoreStats(confidences)
+
+	if len(answerScores) > 0 {
+		report.AnswerScores = make(map[string]ScoreStats, len(answerScores))
+		for id, scores := range answerScores {
+			report.AnswerScores[id] = newScoreStats(scores)
+		}
+	}
+	return report
+}
+
+// ExecuteRepeated runs the graph runs times on the same input state and
+// reports how stable its verdicts were. Because State is immutable, every
+// run starts from the same clean input and is independent of the others.
+// Runs execute sequentially; ExecuteRepeated stops at the first failed run
+// or when ctx is done and returns that error.
+func (g *Graph) ExecuteRepeated(ctx context.Context, state domain.State, runs int) (*StabilityReport, error) {
+	if runs < 1 {
+		return nil, fmt.Errorf("graph: runs must be at least 1, got %d", runs)
+	}
+
+	verdicts := make([]*domain.Verdict, 0, runs)
+	for run := 1; run <= runs; run++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		finalState, err := g.Execute(ctx, state)
+		if err != nil {
+			return nil, fmt.Errorf("graph: run %d of %d failed: %w", run, runs, err)
+		}
+		verdict, _ := finalState.GetVerdict()
+		verdicts = append(verdicts, verdict)
+	}
+	return NewStabilityReport(verdicts), nil
+}
@@ -0,0 +1,146 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// rankedVerdict returns a decided verdict whose ranking lists the given
+// answer IDs best-first with the given scores.
+func rankedVerdict(confidence float64, ids []string, scores []float64) *domain.Verdict {
+	v := &domain.Verdict{Confidence: confidence, Status: domain.VerdictDecided}
+	for i, id := range ids {
+		v.RankedAnswers = append(v.RankedAnswers, domain.RankedAnswer{Answer: domain.Answer{ID: id}, Score: scores[i]})
+	}
+	v.WinnerAnswer = &v.RankedAnswers[0].Answer
+	v.AggregateScore = scores[0]
+	return v
+}
+
+// TestNewStabilityReport tests winner agreement and score statistics over
+// repeated verdicts, including abstentions.
+func TestNewStabilityReport(t *testing.T) {
+	report := NewStabilityReport([]*domain.Verdict{
+		rankedVerdict(0.9, []string{"a", "b"}, []float64{0.8, 0.4}),
+		rankedVerdict(0.7, []string{"a", "b"}, []float64{0.6, 0.5}),
+		rankedVerdict(0.5, []string{"b", "a"}, []float64{0.7, 0.4}),
+		nil,
+	})
+
+	assert.Equal(t, 4, report.Runs)
+	assert.Equal(t, map[string]int{"a": 2, "b": 1, "": 1}, report.WinnerCounts)
+	assert.Equal(t, "a", report.ModalWinner)
+	assert.InDelta(t, 0.5, report.WinnerAgreement, 1e-9)
+
+	assert.Equal(t, 3, report.Confidence.Count)
+	assert.InDelta(t, 0.7, report.Confidence.Mean, 1e-9)
+	assert.InDelta(t, 0.08/3, report.Confidence.Variance, 1e-9)
+	assert.InDelta(t, 0.5, report.Confidence.Min, 1e-9)
+	assert.InDelta(t, 0.9, report.Confidence.Max, 1e-9)
+	assert.InDelta(t, 0.7, report.AggregateScore.Mean, 1e-9)
+
+	require.Contains(t, report.AnswerScores, "a")
+	assert.InDelta(t, 0.6, report.AnswerScores["a"].Mean, 1e-9)
+	assert.InDelta(t, 0.4, report.AnswerScores["a"].Min, 1e-9)
+	assert.InDelta(t, 0.8, report.AnswerScores["a"].Max, 1e-9)
+	assert.InDelta(t, 0.14/9, report.AnswerScores["b"].Variance, 1e-9)
+}
+
+// TestNewStabilityReport_Edges tests agreement ties and empty input.
+func TestNewStabilityReport_Edges(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		report := NewStabilityReport(nil)
+		assert.Zero(t, report.Runs)
+		assert.Zero(t, report.WinnerAgreement)
+		assert.Empty(t, report.ModalWinner)
+	})
+
+	t.Run("tie picks smallest ID", func(t *testing.T) {
+		report := NewStabilityReport([]*domain.Verdict{
+			rankedVerdict(1, []string{"b"}, []float64{1}),
+			rankedVerdict(1, []string{"a"}, []float64{1}),
+		})
+		assert.Equal(t, "a", report.ModalWinner)
+		assert.InDelta(t, 0.5, report.WinnerAgreement, 1e-9)
+	})
+
+	t.Run("unanimous", func(t *testing.T) {
+		report := NewStabilityReport([]*domain.Verdict{
+			rankedVerdict(0.8, []string{"a"}, []float64{0.9}),
+			rankedVerdict(0.8, []string{"a"}, []float64{0.9}),
+		})
+		assert.Equal(t, 1.0, report.WinnerAgreement)
+		assert.Zero(t, report.Confidence.Variance)
+		assert.Zero(t, report.Confidence.StdDev())
+	})
+}
+
+// TestGraph_ExecuteRepeated tests that every run starts from the original
+// state and that run failures stop the measurement.
+func TestGraph_ExecuteRepeated(t *testing.T) {
+	markerKey := domain.NewKey[int]("marker")
+
+	newGraph := func(judge func(run int) (*domain.Verdict, error)) *Graph {
+		run := 0
+		node := &mockExecutable{
+			id: "judge",
+			executeFunc: func(ctx context.Context, state domain.State) (domain.State, error) {
+				marker, _ := domain.Get(state, markerKey)
+				if marker != 0 {
+					return state, errors.New("state leaked between runs")
+				}
+				run++
+				verdict, err := judge(run)
+				if err != nil {
+					return state, err
+				}
+				state = domain.With(state, markerKey, run)
+				return domain.With(state, domain.KeyVerdict, verdict), nil
+			},
+		}
+		g := NewGraph()
+		require.NoError(t, g.AddNode(node))
+		return g
+	}
+
+	t.Run("aggregates independent runs", func(t *testing.T) {
+		g := newGraph(func(run int) (*domain.Verdict, error) {
+			if run%3 == 0 {
+				return rankedVerdict(0.6, []string{"b", "a"}, []float64{0.7, 0.5}), nil
+			}
+			return rankedVerdict(0.9, []string{"a", "b"}, []float64{0.8, 0.3}), nil
+		})
+
+		report, err := g.ExecuteRepeated(context.Background(), domain.NewState(), 6)
+		require.NoError(t, err)
+		assert.Equal(t, 6, report.Runs)
+		assert.Equal(t, "a", report.ModalWinner)
+		assert.InDelta(t, 4.0/6, report.WinnerAgreement, 1e-9)
+		assert.Equal(t, 6, report.AnswerScores["a"].Count)
+	})
+
+	t.Run("run failure", func(t *testing.T) {
+		boom := errors.New("judge failed")
+		g := newGraph(func(run int) (*domain.Verdict, error) {
+			if run == 2 {
+				return nil, boom
+			}
+			return rankedVerdict(1, []string{"a"}, []float64{1}), nil
+		})
+
+		_, err := g.ExecuteRepeated(context.Background(), domain.NewState(), 3)
+		require.ErrorIs(t, err, boom)
+		assert.Contains(t, err.Error(), "run 2 of 3")
+	})
+
+	t.Run("invalid runs", func(t *testing.T) {
+		_, err := NewGraph().ExecuteRepeated(context.Background(), domain.NewState(), 0)
+		assert.Error(t, err)
+	})
+}
@@ -2,7 +2,9 @@ package application
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/ahrav/go-gavel/infrastructure/units"
 	"github.com/ahrav/go-gavel/internal/domain"
 	"github.com/ahrav/go-gavel/internal/ports"
 )
@@ -19,6 +21,18 @@ type UnitAdapter struct {
 	// id is the unique identifier for this adapter within the graph
 	// scope, used for referencing and error reporting.
 	id string
+	// unitType and model describe how the unit was configured and are
+	// reported in verdict provenance.
+	unitType string
+	model    string
+	// promptRef and promptFingerprint identify the unit's prompt and are
+	// reported in verdict provenance.
+	promptRef         string
+	promptFingerprint string
+	// modelGuard validates per-execution model overrides against the
+	// provider registry. A nil guard means the unit has no LLM provider,
+	// so overrides for it are rejected.
+	modelGuard func(model string) error
 }
 
 // NewUnitAdapter creates a new adapter that wraps a ports.Unit to
@@ -33,15 +47,71 @@ func NewUnitAdapter(unit ports.Unit, id string) *UnitAdapter {
 	}
 }
 
+// NewUnitAdapterWithProvenance creates an adapter like NewUnitAdapter and
+// additionally records the unit's registered type and LLM model so that
+// graph execution can report them in the verdict's provenance.
+func NewUnitAdapterWithProvenance(unit ports.Unit, id, unitType, model string) *UnitAdapter {
+	return &UnitAdapter{
+		unit:     unit,
+		id:       id,
+		unitType: unitType,
+		model:    model,
+	}
+}
+
 // Execute delegates to the underlying unit's Execute method,
 // providing transparent pass-through of context, state, and results.
 // Execute maintains the same semantics as the wrapped unit,
 // including error handling and context cancellation support.
+// A model override for this unit in domain.KeyModelOverrides is checked
+// against the provider registry before the unit runs.
+// The unit's context is bounded by the item deadline in domain.KeyDeadline,
+// and a unit that runs past it fails with domain.ErrDeadlineExceeded; once
+// the deadline has passed the unit is not started at all.
+// The start and end of every execution are sent to the unit event logger
+// installed with units.SetEventLogger, and LLM calls the unit makes with
+// its context are logged against it.
 func (ua *UnitAdapter) Execute(ctx context.Context, state domain.State) (domain.State, error) {
-	return ua.unit.Execute(ctx, state)
+	ctx, finished := units.StartUnitEvents(ctx, state, ua.unitType, ua.id)
+	newState, err := ua.execute(ctx, state)
+	finished(err)
+	return newState, err
+}
+
+// execute checks the item deadline and any model override, then runs the
+// unit within the item deadline.
+func (ua *UnitAdapter) execute(ctx context.Context, state domain.State) (domain.State, error) {
+	if itemDeadlinePassed(state) {
+		return state, fmt.Errorf("unit %s: %w", ua.id, domain.ErrDeadlineExceeded)
+	}
+	if model, ok := domain.ModelOverride(state, ua.id); ok {
+		if ua.modelGuard == nil {
+			return state, fmt.Errorf("unit %s: model override %q: unit has no LLM provider", ua.id, model)
+		}
+		if err := ua.modelGuard(model); err != nil {
+			return state, fmt.Errorf("unit %s: model override %q: %w", ua.id, model, err)
+		}
+	}
+
+	ctx, cancel := withItemDeadline(ctx, state)
+	defer cancel()
+	newState, err := ua.unit.Execute(ctx, state)
+	return newState, deadlineError(state, err)
 }
 
 // ID returns the unique string identifier for this adapter.
 // The ID is used for referencing in graph topologies, error reporting,
 // and debugging, and remains constant throughout the adapter's lifetime.
 func (ua *UnitAdapter) ID() string { return ua.id }
+
+// Provenance describes the wrapped unit for inclusion in a verdict's
+// provenance record.
+func (ua *UnitAdapter) Provenance() domain.UnitProvenance {
+	return domain.UnitProvenance{
+		Name:              ua.id,
+		Type:              ua.unitType,
+		Model:             ua.model,
+		PromptRef:         ua.promptRef,
+		PromptFingerprint: ua.promptFingerprint,
+	}
+}
@@ -3,7 +3,9 @@
 package application
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"sync"
 
 	"github.com/ahrav/go-gavel/infrastructure/units"
@@ -16,6 +18,15 @@ import (
 // for invalid inputs.
 type FactoryFunc func(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error)
 
+// enforceJSONKey is the configuration key that, when true, makes
+// CreateUnit wrap the unit's LLM client in a units.JSONEnforcingLLMClient.
+// The GraphLoader sets it from UnitConfig.EnforceJSON.
+const enforceJSONKey = "enforce_json"
+
+// ErrUnitTypeRegistered indicates that RegisterUnitType was called with a
+// name that already has a factory, either built-in or custom.
+var ErrUnitTypeRegistered = errors.New("unit type already registered")
+
 // Registry manages unit factories and dependencies.
 // It provides thread-safe registration and creation of evaluation units,
 // implementing the ports.UnitRegistry interface for the GraphLoader.
@@ -23,6 +34,9 @@ type FactoryFunc func(id string, config map[string]any, llm ports.LLMClient) (po
 type Registry struct {
 	mu        sync.RWMutex
 	factories map[string]FactoryFunc
+	// custom holds the unit types added through RegisterUnitType, whose
+	// parameters are validated by their factories rather than the loader.
+	custom    map[string]struct{}
 	llmClient ports.LLMClient
 }
 
@@ -33,6 +47,7 @@ type Registry struct {
 func NewRegistry(llmClient ports.LLMClient) *Registry {
 	return &Registry{
 		factories: make(map[string]FactoryFunc),
+		custom:    make(map[string]struct{}),
 		llmClient: llmClient,
 	}
 }
@@ -52,10 +67,51 @@ func (r *Registry) Register(unitType string, factory FactoryFunc) {
 	r.factories[unitType] = factory
 }
 
+// RegisterUnitType adds a factory for a custom unit type so it can be
+// referenced by name from graph YAML. Unlike Register, it reports problems
+// as errors rather than panicking, since custom types are typically
+// registered by code outside this package. It returns ErrUnitTypeRegistered
+// if name collides with a built-in or previously registered type.
+//
+// Custom types skip the loader's built-in parameter validation; their
+// factories receive the raw parameters map and must validate it themselves.
+func (r *Registry) RegisterUnitType(name string, factory FactoryFunc) error {
+	if name == "" {
+		return fmt.Errorf("unit type name cannot be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("unit type %q: factory cannot be nil", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.factories[name]; exists {
+		return fmt.Errorf("%w: %q", ErrUnitTypeRegistered, name)
+	}
+
+	r.factories[name] = factory
+	r.custom[name] = struct{}{}
+	return nil
+}
+
+// IsCustomUnitType reports whether unitType was added with RegisterUnitType.
+func (r *Registry) IsCustomUnitType(unitType string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.custom[unitType]
+	return ok
+}
+
 // CreateUnit creates a unit instance using the registered factory.
 // Returns an error if the unit type is unknown or the ID is empty.
 // The factory receives the registry's LLM client, which may be nil.
-// Configuration validation is delegated to the factory implementation.
+// When config sets enforce_json, the client is wrapped in a
+// units.JSONEnforcingLLMClient keeping the object chosen by the unit's
+// json_selection parameter, and the key is removed from the config the
+// factory receives. Configuration validation is delegated to the factory
+// implementation.
 func (r *Registry) CreateUnit(unitType string, id string, config map[string]any) (ports.Unit, error) {
 	if id == "" {
 		return nil, fmt.Errorf("unit ID cannot be empty")
@@ -70,6 +126,16 @@ func (r *Registry) CreateUnit(unitType string, id string, config map[string]any)
 		return nil, fmt.Errorf("unknown unit type: %s", unitType)
 	}
 
+	if enforce, _ := config[enforceJSONKey].(bool); enforce {
+		if llm == nil {
+			return nil, fmt.Errorf("unit %s: %s requires an LLM client", id, enforceJSONKey)
+		}
+		selection, _ := config["json_selection"].(string)
+		llm = units.NewJSONEnforcingLLMClient(llm, units.JSONSelection(selection))
+		config = maps.Clone(config)
+		delete(config, enforceJSONKey)
+	}
+
 	return factory(id, config, llm)
 }
 
@@ -88,7 +154,10 @@ func (r *Registry) GetSupportedTypes() []string {
 
 // RegisterBuiltinUnits registers all built-in evaluation units.
 // Registers: answerer, score_judge, verification, exact_match,
-// fuzzy_match, arithmetic_mean, max_pool, and median_pool.
+// fuzzy_match, top_k_selection, normalize_scores, calibration,
+// arithmetic_mean, max_pool, median_pool, decomposition,
+// format_validation, diverse_selection, hybrid_judge, answer_extraction,
+// adaptive_aggregator, win_matrix, lcs_step, and sampling.
 // Call this once during initialization to enable core functionality.
 func (r *Registry) RegisterBuiltinUnits() {
 	r.Register("answerer", units.NewAnswererFromConfig)
@@ -96,7 +165,19 @@ func (r *Registry) RegisterBuiltinUnits() {
 	r.Register("verification", units.NewVerificationFromConfig)
 	r.Register("exact_match", units.NewExactMatchFromConfig)
 	r.Register("fuzzy_match", units.NewFuzzyMatchFromConfig)
+	r.Register("top_k_selection", units.NewTopKSelectionFromConfig)
+	r.Register("normalize_scores", units.NewNormalizeScoresFromConfig)
+	r.Register("calibration", units.NewCalibrationFromConfig)
 	r.Register("arithmetic_mean", units.NewArithmeticMeanFromConfig)
 	r.Register("max_pool", units.NewMaxPoolFromConfig)
 	r.Register("median_pool", units.NewMedianPoolFromConfig)
+	r.Register("decomposition", units.NewDecompositionFromConfig)
+	r.Register("format_validation", units.NewFormatValidationFromConfig)
+	r.Register("diverse_selection", units.NewDiverseSelectionFromConfig)
+	r.Register("hybrid_judge", units.NewHybridJudgeFromConfig)
+	r.Register("answer_extraction", units.NewAnswerExtractionFromConfig)
+	r.Register("adaptive_aggregator", units.NewAdaptiveAggregatorFromConfig)
+	r.Register("win_matrix", units.NewWinMatrixFromConfig)
+	r.Register("lcs_step", units.NewLCSStepFromConfig)
+	r.Register("sampling", units.NewSamplingFromConfig)
 }
@@ -5,12 +5,14 @@ package application
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ahrav/go-gavel/infrastructure/units"
 	"github.com/ahrav/go-gavel/internal/domain"
 	"github.com/ahrav/go-gavel/internal/ports"
 )
@@ -90,17 +92,29 @@ func TestRegistry_RegisterBuiltinUnits(t *testing.T) {
 		// Register builtin units
 		registry.RegisterBuiltinUnits()
 
-		// All 8 core units should now be registered
+		// All 20 core units should now be registered
 		supportedTypes := registry.GetSupportedTypes()
-		assert.Len(t, supportedTypes, 8)
+		assert.Len(t, supportedTypes, 20)
 		assert.Contains(t, supportedTypes, "score_judge")
 		assert.Contains(t, supportedTypes, "answerer")
 		assert.Contains(t, supportedTypes, "verification")
 		assert.Contains(t, supportedTypes, "exact_match")
 		assert.Contains(t, supportedTypes, "fuzzy_match")
+		assert.Contains(t, supportedTypes, "top_k_selection")
+		assert.Contains(t, supportedTypes, "normalize_scores")
+		assert.Contains(t, supportedTypes, "calibration")
 		assert.Contains(t, supportedTypes, "arithmetic_mean")
 		assert.Contains(t, supportedTypes, "max_pool")
 		assert.Contains(t, supportedTypes, "median_pool")
+		assert.Contains(t, supportedTypes, "decomposition")
+		assert.Contains(t, supportedTypes, "format_validation")
+		assert.Contains(t, supportedTypes, "diverse_selection")
+		assert.Contains(t, supportedTypes, "hybrid_judge")
+		assert.Contains(t, supportedTypes, "answer_extraction")
+		assert.Contains(t, supportedTypes, "adaptive_aggregator")
+		assert.Contains(t, supportedTypes, "win_matrix")
+		assert.Contains(t, supportedTypes, "lcs_step")
+		assert.Contains(t, supportedTypes, "sampling")
 	})
 }
 
@@ -133,6 +147,85 @@ func TestRegistry_Register(t *testing.T) {
 	})
 }
 
+// TestRegistry_RegisterUnitType tests runtime registration of custom unit
+// types, including collision handling and loading them from graph YAML.
+func TestRegistry_RegisterUnitType(t *testing.T) {
+	factory := func(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error) {
+		if _, ok := config["max_words"]; !ok {
+			return nil, fmt.Errorf("max_words is required")
+		}
+		return &testMockUnit{name: id}, nil
+	}
+
+	t.Run("registers custom type", func(t *testing.T) {
+		registry := NewRegistry(nil)
+		require.NoError(t, registry.RegisterUnitType("word_count", factory))
+
+		assert.True(t, registry.IsCustomUnitType("word_count"))
+		assert.Contains(t, registry.GetSupportedTypes(), "word_count")
+
+		unit, err := registry.CreateUnit("word_count", "wc", map[string]any{"max_words": 10})
+		require.NoError(t, err)
+		assert.Equal(t, "wc", unit.Name())
+	})
+
+	t.Run("rejects invalid registrations", func(t *testing.T) {
+		registry := NewRegistry(nil)
+		registry.RegisterBuiltinUnits()
+		require.NoError(t, registry.RegisterUnitType("word_count", factory))
+
+		err := registry.RegisterUnitType("score_judge", factory)
+		assert.ErrorIs(t, err, ErrUnitTypeRegistered)
+		assert.False(t, registry.IsCustomUnitType("score_judge"))
+
+		err = registry.RegisterUnitType("word_count", factory)
+		assert.ErrorIs(t, err, ErrUnitTypeRegistered)
+
+		assert.ErrorContains(t, registry.RegisterUnitType("", factory), "name cannot be empty")
+		assert.ErrorContains(t, registry.RegisterUnitType("other", nil), "factory cannot be nil")
+	})
+
+	t.Run("custom type is usable from yaml", func(t *testing.T) {
+		registry := NewRegistry(nil)
+		registry.RegisterBuiltinUnits()
+		require.NoError(t, registry.RegisterUnitType("word_count", factory))
+
+		loader, err := NewGraphLoader(registry, nil)
+		require.NoError(t, err)
+
+		graph, err := loader.LoadFromReader(context.Background(), strings.NewReader(`
+version: "1.0.0"
+metadata:
+  name: "custom-unit"
+units:
+  - id: wc
+    type: word_count
+    budget: {}
+    parameters:
+      max_words: 10
+graph:
+  edges: []
+`))
+		require.NoError(t, err)
+		_, exists := graph.GetNode("wc")
+		assert.True(t, exists)
+
+		_, err = loader.LoadFromReader(context.Background(), strings.NewReader(`
+version: "1.0.0"
+metadata:
+  name: "unknown-unit"
+units:
+  - id: wc
+    type: char_count
+    budget: {}
+graph:
+  edges: []
+`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown unit type: char_count")
+	})
+}
+
 func TestRegistry_CreateUnit(t *testing.T) {
 	mockClient := &mockLLMClient{model: "test-model"}
 
@@ -196,6 +289,28 @@ func TestRegistry_CreateUnit(t *testing.T) {
 		assert.NotNil(t, unit)
 	})
 
+	t.Run("enforce_json wraps the LLM client", func(t *testing.T) {
+		registry := NewRegistry(mockClient)
+
+		customFactory := func(id string, config map[string]any, llm ports.LLMClient) (ports.Unit, error) {
+			assert.IsType(t, &units.JSONEnforcingLLMClient{}, llm)
+			assert.Equal(t, "test-model", llm.GetModel())
+			assert.Equal(t, map[string]any{"json_selection": "last"}, config)
+			return &testMockUnit{name: id}, nil
+		}
+		registry.Register("custom", customFactory)
+
+		config := map[string]any{enforceJSONKey: true, "json_selection": "last"}
+		_, err := registry.CreateUnit("custom", "test-unit", config)
+		require.NoError(t, err)
+		assert.Contains(t, config, enforceJSONKey, "the caller's config is not modified")
+
+		nilRegistry := NewRegistry(nil)
+		nilRegistry.Register("custom", customFactory)
+		_, err = nilRegistry.CreateUnit("custom", "test-unit", map[string]any{enforceJSONKey: true})
+		assert.ErrorContains(t, err, "enforce_json requires an LLM client")
+	})
+
 	t.Run("factory error is propagated", func(t *testing.T) {
 		registry := NewRegistry(mockClient)
 
@@ -2,11 +2,14 @@ package application
 
 import (
 	"fmt"
+	"regexp"
 	"slices"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"gopkg.in/yaml.v3"
+
+	"github.com/ahrav/go-gavel/internal/domain"
 )
 
 // ValidateUnitParameters validates the parameters for a specific unit type,
@@ -37,6 +40,30 @@ func ValidateUnitParameters(unitType string, params yaml.Node) error {
 		return validateExactMatchParams(paramMap)
 	case "fuzzy_match":
 		return validateFuzzyMatchParams(paramMap)
+	case "top_k_selection":
+		return validateTopKSelectionParams(paramMap)
+	case "normalize_scores":
+		return validateNormalizeScoresParams(paramMap)
+	case "calibration":
+		return validateCalibrationParams(paramMap)
+	case "decomposition":
+		return validateDecompositionParams(paramMap)
+	case "format_validation":
+		return validateFormatValidationParams(paramMap)
+	case "diverse_selection":
+		return validateDiverseSelectionParams(paramMap)
+	case "sampling":
+		return validateSamplingParams(paramMap)
+	case "hybrid_judge":
+		return validateHybridJudgeParams(paramMap)
+	case "answer_extraction":
+		return validateAnswerExtractionParams(paramMap)
+	case "adaptive_aggregator":
+		return validateAdaptiveAggregatorParams(paramMap)
+	case "win_matrix":
+		return validateWinMatrixParams(paramMap)
+	case "lcs_step":
+		return validateLCSStepParams(paramMap)
 	case "custom":
 		// Custom units have flexible validation
 		return nil
@@ -95,6 +122,296 @@ func validateScoreJudgeParams(params map[string]any) error {
 		}
 	}
 
+	// Optional question and answer block labels
+	for _, key := range []string{"question_label", "answer_label"} {
+		if label, ok := params[key]; ok {
+			if _, ok := label.(string); !ok {
+				return fmt.Errorf("%s must be a string", key)
+			}
+		}
+	}
+
+	if include, ok := params["include_images"]; ok {
+		if _, ok := include.(bool); !ok {
+			return fmt.Errorf("include_images must be a boolean")
+		}
+	}
+
+	// Optional metadata field selection
+	if fields, ok := params["metadata_fields"]; ok {
+		switch fields.(type) {
+		case []any, []string:
+		default:
+			return fmt.Errorf("metadata_fields must be a list of metadata keys")
+		}
+	}
+
+	if err := validateStopParams(params); err != nil {
+		return err
+	}
+
+	if processors, ok := params["post_processors"]; ok {
+		list, ok := processors.([]any)
+		if !ok {
+			return fmt.Errorf("post_processors must be a list of post-processor names")
+		}
+		for _, item := range list {
+			if s, ok := item.(string); !ok || s == "" {
+				return fmt.Errorf("post_processors must be a list of post-processor names")
+			}
+		}
+	}
+
+	if err := validateAnchorsParam(params); err != nil {
+		return err
+	}
+
+	// Optional pre-flight prompt budget
+	if budget, ok := params["prompt_budget"]; ok {
+		var v float64
+		switch b := budget.(type) {
+		case float64:
+			v = b
+		case int:
+			v = float64(b)
+		default:
+			return fmt.Errorf("prompt_budget must be a number")
+		}
+		if v <= 0 || v > 1 {
+			return fmt.Errorf("prompt_budget must be greater than 0 and at most 1")
+		}
+	}
+	if err := validateOversizePolicyParam(params); err != nil {
+		return err
+	}
+
+	if err := validateQualityParams(params); err != nil {
+		return err
+	}
+
+	// Optional self-consistency sampling
+	if sc, ok := params["self_consistency"]; ok {
+		scMap, ok := sc.(map[string]any)
+		if !ok {
+			return fmt.Errorf("self_consistency must be a mapping")
+		}
+		samples, ok := scMap["samples"].(int)
+		if !ok {
+			return fmt.Errorf("self_consistency requires an integer 'samples' parameter")
+		}
+		if samples < 2 || samples > 15 {
+			return fmt.Errorf("self_consistency samples must be between 2 and 15")
+		}
+	}
+
+	if cc, ok := params["consistency_check"]; ok {
+		ccMap, ok := cc.(map[string]any)
+		if !ok {
+			return fmt.Errorf("consistency_check must be a mapping")
+		}
+		for _, key := range []string{"similarity", "divergence"} {
+			value, ok := ccMap[key]
+			if !ok {
+				continue
+			}
+			var f float64
+			switch v := value.(type) {
+			case int:
+				f = float64(v)
+			case float64:
+				f = v
+			default:
+				return fmt.Errorf("consistency_check %s must be a number", key)
+			}
+			if f < 0 || f > 1 {
+				return fmt.Errorf("consistency_check %s must be between 0 and 1", key)
+			}
+		}
+		if rescore, ok := ccMap["rescore"]; ok {
+			if _, ok := rescore.(bool); !ok {
+				return fmt.Errorf("consistency_check rescore must be a boolean")
+			}
+		}
+	}
+
+	if err := validateReasoningQualityParam(params); err != nil {
+		return err
+	}
+	if err := validateMinAnswersParam(params); err != nil {
+		return err
+	}
+	return validateEmptyAnswerParams(params)
+}
+
+// validateReasoningQualityParam checks the optional
+// require_reasoning_quality mapping of score judge units.
+func validateReasoningQualityParam(params map[string]any) error {
+	gate, ok := params["require_reasoning_quality"]
+	if !ok {
+		return nil
+	}
+	m, ok := gate.(map[string]any)
+	if !ok {
+		return fmt.Errorf("require_reasoning_quality must be a mapping")
+	}
+	if minWords, ok := m["min_words"]; ok {
+		n, ok := minWords.(int)
+		if !ok || n < 0 || n > 500 {
+			return fmt.Errorf("require_reasoning_quality min_words must be an integer between 0 and 500")
+		}
+	}
+	if cite, ok := m["must_cite_answer"]; ok {
+		if _, ok := cite.(bool); !ok {
+			return fmt.Errorf("require_reasoning_quality must_cite_answer must be a boolean")
+		}
+	}
+	if phrases, ok := m["template_phrases"]; ok {
+		if _, ok := phrases.([]any); !ok {
+			return fmt.Errorf("require_reasoning_quality template_phrases must be a list")
+		}
+	}
+	if onFailure, ok := m["on_failure"]; ok {
+		if action, ok := onFailure.(string); !ok || (action != "reprompt" && action != "lower_confidence") {
+			return fmt.Errorf("require_reasoning_quality on_failure must be 'reprompt' or 'lower_confidence'")
+		}
+	}
+	if factor, ok := m["confidence_factor"]; ok {
+		var f float64
+		switch v := factor.(type) {
+		case int:
+			f = float64(v)
+		case float64:
+			f = v
+		default:
+			return fmt.Errorf("require_reasoning_quality confidence_factor must be a number")
+		}
+		if f < 0 || f > 1 {
+			return fmt.Errorf("require_reasoning_quality confidence_factor must be between 0 and 1")
+		}
+	}
+	return nil
+}
+
+// validateQualityParams checks the reference-free quality mode parameters
+// of score_judge.
+func validateQualityParams(params map[string]any) error {
+	mode := "score"
+	if m, ok := params["mode"]; ok {
+		s, ok := m.(string)
+		if !ok || (s != "score" && s != "quality") {
+			return fmt.Errorf("mode must be 'score' or 'quality'")
+		}
+		mode = s
+	}
+
+	dims, ok := params["dimensions"]
+	if !ok {
+		return nil
+	}
+	if mode != "quality" {
+		return fmt.Errorf("dimensions require mode 'quality'")
+	}
+	list, ok := dims.([]any)
+	if !ok {
+		return fmt.Errorf("dimensions must be a list of mappings")
+	}
+	for i, item := range list {
+		dim, ok := item.(map[string]any)
+		if !ok {
+			return fmt.Errorf("dimensions[%d] must be a mapping", i)
+		}
+		if name, ok := dim["name"].(string); !ok || name == "" {
+			return fmt.Errorf("dimensions[%d] requires a non-empty 'name'", i)
+		}
+		if weight, ok := dim["weight"]; ok {
+			var w float64
+			switch v := weight.(type) {
+			case int:
+				w = float64(v)
+			case float64:
+				w = v
+			default:
+				return fmt.Errorf("dimensions[%d] weight must be a number", i)
+			}
+			if w < 0 {
+				return fmt.Errorf("dimensions[%d] weight cannot be negative", i)
+			}
+		}
+	}
+	return nil
+}
+
+// validateStopParams checks the response termination and determinism
+// parameters shared by LLM-backed judge and verification units.
+func validateStopParams(params map[string]any) error {
+	if stops, ok := params["stop_sequences"]; ok {
+		list, ok := stops.([]any)
+		if !ok {
+			return fmt.Errorf("stop_sequences must be a list of strings")
+		}
+		if len(list) > 4 {
+			return fmt.Errorf("stop_sequences cannot have more than 4 entries")
+		}
+		for _, item := range list {
+			if s, ok := item.(string); !ok || s == "" {
+				return fmt.Errorf("stop_sequences must be a list of non-empty strings")
+			}
+		}
+	}
+	if strict, ok := params["strict_json"]; ok {
+		if _, ok := strict.(bool); !ok {
+			return fmt.Errorf("strict_json must be a boolean")
+		}
+	}
+	if selection, ok := params["json_selection"]; ok {
+		s, ok := selection.(string)
+		if !ok || (s != "first" && s != "last" && s != "largest") {
+			return fmt.Errorf("json_selection must be 'first', 'last', or 'largest'")
+		}
+	}
+	if deterministic, ok := params["deterministic"]; ok {
+		if _, ok := deterministic.(bool); !ok {
+			return fmt.Errorf("deterministic must be a boolean")
+		}
+	}
+	return nil
+}
+
+// validateMinAnswersParam checks the optional min_answers parameter shared
+// by judge and aggregator units.
+func validateMinAnswersParam(params map[string]any) error {
+	if minAnswers, ok := params["min_answers"]; ok {
+		if v, ok := minAnswers.(int); !ok || v < 0 {
+			return fmt.Errorf("min_answers must be a non-negative integer")
+		}
+	}
+	return nil
+}
+
+// validateEmptyAnswerParams checks the empty answer and no-answers handling
+// parameters shared by judge units.
+func validateEmptyAnswerParams(params map[string]any) error {
+	if policy, ok := params["empty_answer_policy"]; ok {
+		p, ok := policy.(string)
+		if !ok {
+			return fmt.Errorf("empty_answer_policy must be a string")
+		}
+		if p != "skip" && p != "send" && p != "reject" {
+			return fmt.Errorf("empty_answer_policy must be 'skip', 'send', or 'reject'")
+		}
+	}
+	if score, ok := params["empty_answer_score"]; ok {
+		switch score.(type) {
+		case float64, int:
+		default:
+			return fmt.Errorf("empty_answer_score must be a number")
+		}
+	}
+	if policy, ok := params["on_no_answers"]; ok {
+		if p, ok := policy.(string); !ok || (p != "error" && p != "abstain") {
+			return fmt.Errorf("on_no_answers must be 'error' or 'abstain'")
+		}
+	}
 	return nil
 }
 
@@ -278,6 +595,173 @@ func validateVerificationParams(params map[string]any) error {
 	if _, ok := params["prompt"]; !ok {
 		return fmt.Errorf("verification requires 'prompt' parameter")
 	}
+	if mode, ok := params["mode"]; ok {
+		m, ok := mode.(string)
+		if !ok {
+			return fmt.Errorf("mode must be a string")
+		}
+		if m != "judging_quality" && m != "winner_correctness" {
+			return fmt.Errorf("verification mode must be 'judging_quality' or 'winner_correctness'")
+		}
+	}
+	if sanitization, ok := params["sanitization"]; ok {
+		s, ok := sanitization.(string)
+		if !ok {
+			return fmt.Errorf("sanitization must be a string")
+		}
+		switch s {
+		case "none", "code_fence", "delimiter", "xml_tag":
+		default:
+			return fmt.Errorf("sanitization must be one of 'none', 'code_fence', 'delimiter', or 'xml_tag'")
+		}
+	}
+	if fields, ok := params["response_fields"]; ok {
+		if _, ok := fields.([]any); !ok {
+			return fmt.Errorf("response_fields must be a list of field definitions")
+		}
+	}
+	if severity, ok := params["review_severity"]; ok {
+		s, ok := severity.(string)
+		if !ok {
+			return fmt.Errorf("review_severity must be a string")
+		}
+		if domain.IssueSeverity(s).Rank() < 0 {
+			return fmt.Errorf("review_severity must be one of 'low', 'medium', 'high', or 'critical'")
+		}
+	}
+	if selection, ok := params["score_selection"]; ok {
+		s, ok := selection.(string)
+		if !ok {
+			return fmt.Errorf("score_selection must be a string")
+		}
+		switch s {
+		case "truncate", "extremes", "top_answers":
+		default:
+			return fmt.Errorf("score_selection must be one of 'truncate', 'extremes', or 'top_answers'")
+		}
+	}
+	if v, ok := params["disagreement_variance"]; ok {
+		variance, ok := numberParam(v)
+		if !ok || variance <= 0 {
+			return fmt.Errorf("disagreement_variance must be a positive number")
+		}
+	}
+	if err := validateOversizePolicyParam(params); err != nil {
+		return err
+	}
+	return validateStopParams(params)
+}
+
+// validateOversizePolicyParam checks the optional oversize_policy shared by
+// score_judge and verification units.
+func validateOversizePolicyParam(params map[string]any) error {
+	policy, ok := params["oversize_policy"]
+	if !ok {
+		return nil
+	}
+	switch p, _ := policy.(string); p {
+	case "error", "truncate", "skip_answer":
+		return nil
+	default:
+		return fmt.Errorf("oversize_policy must be one of 'error', 'truncate', or 'skip_answer'")
+	}
+}
+
+// validateDecompositionParams validates parameters for decomposition units,
+// including the nested score_judge parameters used for each sub-question.
+func validateDecompositionParams(params map[string]any) error {
+	judge, ok := params["judge"].(map[string]any)
+	if !ok {
+		return fmt.Errorf("decomposition requires 'judge' parameters for a score_judge unit")
+	}
+	if err := validateScoreJudgeParams(judge); err != nil {
+		return fmt.Errorf("judge: %w", err)
+	}
+	if subQuestions, ok := params["sub_questions"]; ok {
+		if _, ok := subQuestions.([]any); !ok {
+			return fmt.Errorf("sub_questions must be a list of strings")
+		}
+		if _, ok := params["split_pattern"]; ok {
+			return fmt.Errorf("sub_questions and split_pattern cannot both be set")
+		}
+	}
+	if weights, ok := params["weights"]; ok {
+		list, ok := weights.([]any)
+		if !ok {
+			return fmt.Errorf("weights must be a list of numbers")
+		}
+		for _, w := range list {
+			switch v := w.(type) {
+			case int:
+				if v <= 0 {
+					return fmt.Errorf("weights must be positive")
+				}
+			case float64:
+				if v <= 0 {
+					return fmt.Errorf("weights must be positive")
+				}
+			default:
+				return fmt.Errorf("weights must be a list of numbers")
+			}
+		}
+	}
+	return nil
+}
+
+// validateHybridJudgeParams validates parameters for hybrid judge units,
+// including the nested score_judge and fuzzy_match parameters, the
+// ambiguous band, and the blend weights.
+func validateHybridJudgeParams(params map[string]any) error {
+	judge, ok := params["judge"].(map[string]any)
+	if !ok {
+		return fmt.Errorf("hybrid_judge requires 'judge' parameters for a score_judge unit")
+	}
+	if err := validateScoreJudgeParams(judge); err != nil {
+		return fmt.Errorf("judge: %w", err)
+	}
+	if fuzzy, ok := params["fuzzy"]; ok {
+		m, ok := fuzzy.(map[string]any)
+		if !ok {
+			return fmt.Errorf("fuzzy must be a mapping of fuzzy_match parameters")
+		}
+		if err := validateFuzzyMatchParams(m); err != nil {
+			return fmt.Errorf("fuzzy: %w", err)
+		}
+	}
+
+	numbers := make(map[string]float64)
+	for _, key := range []string{"ambiguous_min", "ambiguous_max", "fuzzy_weight", "llm_weight"} {
+		value, ok := params[key]
+		if !ok {
+			continue
+		}
+		switch v := value.(type) {
+		case int:
+			numbers[key] = float64(v)
+		case float64:
+			numbers[key] = v
+		default:
+			return fmt.Errorf("%s must be a number", key)
+		}
+		if numbers[key] < 0 {
+			return fmt.Errorf("%s cannot be negative", key)
+		}
+	}
+	for _, key := range []string{"ambiguous_min", "ambiguous_max"} {
+		if numbers[key] > 1 {
+			return fmt.Errorf("%s must be between 0 and 1", key)
+		}
+	}
+	minimum, hasMin := numbers["ambiguous_min"]
+	maximum, hasMax := numbers["ambiguous_max"]
+	if hasMin && hasMax && minimum > maximum {
+		return fmt.Errorf("ambiguous_min cannot exceed ambiguous_max")
+	}
+	fuzzyWeight, hasFuzzy := numbers["fuzzy_weight"]
+	llmWeight, hasLLM := numbers["llm_weight"]
+	if hasFuzzy && hasLLM && fuzzyWeight+llmWeight == 0 {
+		return fmt.Errorf("fuzzy_weight and llm_weight cannot both be zero")
+	}
 	return nil
 }
 
@@ -285,6 +769,180 @@ func validateVerificationParams(params map[string]any) error {
 func validatePoolParams(params map[string]any) error {
 	// Pool units typically don't have required parameters
 	// They work with scores from previous units
+	if expected, ok := params["expected_judges"]; ok {
+		switch expected.(type) {
+		case []any, []string:
+		default:
+			return fmt.Errorf("expected_judges must be a list of judge unit IDs")
+		}
+	}
+	if threshold, ok := params["abstain_threshold"]; ok {
+		switch v := threshold.(type) {
+		case float64:
+			if v < 0 || v > 1 {
+				return fmt.Errorf("abstain_threshold must be between 0 and 1")
+			}
+		case int:
+			if v < 0 || v > 1 {
+				return fmt.Errorf("abstain_threshold must be between 0 and 1")
+			}
+		default:
+			return fmt.Errorf("abstain_threshold must be a number")
+		}
+	}
+	if err := validateOutputScaleParam(params); err != nil {
+		return err
+	}
+	if err := validateReferenceSignalParam(params); err != nil {
+		return err
+	}
+	return validateMinAnswersParam(params)
+}
+
+// validateAdaptiveAggregatorParams validates parameters for adaptive
+// aggregator units: the variance threshold, robust method, and trim
+// fraction, plus the options shared with pool units.
+func validateAdaptiveAggregatorParams(params map[string]any) error {
+	if v, ok := params["variance_threshold"]; ok {
+		threshold, ok := numberParam(v)
+		if !ok || threshold < 0 {
+			return fmt.Errorf("variance_threshold must be a non-negative number")
+		}
+	}
+	if v, ok := params["robust_method"]; ok {
+		method, ok := v.(string)
+		if !ok || (method != "median" && method != "trimmed_mean") {
+			return fmt.Errorf("robust_method must be one of median, trimmed_mean")
+		}
+	}
+	if v, ok := params["trim_fraction"]; ok {
+		fraction, ok := numberParam(v)
+		if !ok || fraction < 0 || fraction > 0.5 {
+			return fmt.Errorf("trim_fraction must be between 0 and 0.5")
+		}
+	}
+	if judges, ok := params["judges"]; ok {
+		if _, ok := judges.([]any); !ok {
+			return fmt.Errorf("judges must be a list of judge unit IDs")
+		}
+	}
+	if v, ok := params["tie_breaker"]; ok {
+		tieBreaker, ok := v.(string)
+		if !ok || !slices.Contains([]string{"first", "lowest_id", "error"}, tieBreaker) {
+			return fmt.Errorf("tie_breaker must be one of first, lowest_id, error")
+		}
+	}
+	if _, ok := params["reference_signal"]; ok {
+		return fmt.Errorf("adaptive_aggregator does not support reference_signal")
+	}
+	return validatePoolParams(params)
+}
+
+// validateWinMatrixParams validates parameters for win matrix units: the
+// rating method, comparison count, answer cap, and tie breaker.
+func validateWinMatrixParams(params map[string]any) error {
+	if v, ok := params["rating_method"]; ok {
+		method, ok := v.(string)
+		if !ok || (method != "elo" && method != "bradley_terry") {
+			return fmt.Errorf("rating_method must be one of elo, bradley_terry")
+		}
+	}
+	if v, ok := params["comparisons"]; ok {
+		comparisons, ok := v.(int)
+		if !ok || comparisons < 1 || comparisons > 10 {
+			return fmt.Errorf("comparisons must be an integer between 1 and 10")
+		}
+	}
+	if v, ok := params["max_answers"]; ok {
+		maxAnswers, ok := v.(int)
+		if !ok || maxAnswers < 2 || maxAnswers > 50 {
+			return fmt.Errorf("max_answers must be an integer between 2 and 50")
+		}
+	}
+	if v, ok := params["elo_k"]; ok {
+		k, ok := numberParam(v)
+		if !ok || k <= 0 || k > 400 {
+			return fmt.Errorf("elo_k must be greater than 0 and at most 400")
+		}
+	}
+	if v, ok := params["prompt_template"]; ok {
+		if template, ok := v.(string); !ok || template == "" {
+			return fmt.Errorf("prompt_template must be a non-empty string")
+		}
+	}
+	if v, ok := params["tie_breaker"]; ok {
+		tieBreaker, ok := v.(string)
+		if !ok || !slices.Contains([]string{"first", "lowest_id", "error"}, tieBreaker) {
+			return fmt.Errorf("tie_breaker must be one of first, lowest_id, error")
+		}
+	}
+	return nil
+}
+
+// numberParam returns v as a float64 if it is a YAML integer or float.
+func numberParam(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+// validateReferenceSignalParam checks the optional reference_signal mapping
+// of aggregator units, requiring a unit and a weight in (0, 1].
+func validateReferenceSignalParam(params map[string]any) error {
+	signal, ok := params["reference_signal"]
+	if !ok {
+		return nil
+	}
+	m, ok := signal.(map[string]any)
+	if !ok {
+		return fmt.Errorf("reference_signal must be a mapping")
+	}
+	if unit, ok := m["unit"].(string); !ok || unit == "" {
+		return fmt.Errorf("reference_signal requires a 'unit' parameter")
+	}
+	var w float64
+	switch v := m["weight"].(type) {
+	case int:
+		w = float64(v)
+	case float64:
+		w = v
+	default:
+		return fmt.Errorf("reference_signal weight must be a number")
+	}
+	if w <= 0 || w > 1 {
+		return fmt.Errorf("reference_signal weight must be greater than 0 and at most 1")
+	}
+	return nil
+}
+
+// validateOutputScaleParam checks the optional output_scale mapping of
+// aggregator units, requiring a known preset when one is given.
+func validateOutputScaleParam(params map[string]any) error {
+	scale, ok := params["output_scale"]
+	if !ok {
+		return nil
+	}
+	m, ok := scale.(map[string]any)
+	if !ok {
+		return fmt.Errorf("output_scale must be a mapping")
+	}
+	if preset, ok := m["preset"]; ok {
+		switch preset {
+		case "percentage", "stars", "letter":
+		default:
+			return fmt.Errorf("output_scale preset must be 'percentage', 'stars', or 'letter'")
+		}
+	}
+	if labels, ok := m["labels"]; ok {
+		if _, ok := labels.([]any); !ok {
+			return fmt.Errorf("output_scale labels must be a list")
+		}
+	}
 	return nil
 }
 
@@ -301,6 +959,129 @@ func validateExactMatchParams(params map[string]any) error {
 			return fmt.Errorf("trim_whitespace must be a boolean")
 		}
 	}
+	if err := validateConclusiveParam(params); err != nil {
+		return err
+	}
+	return validateStopwordParams(params)
+}
+
+// validateLCSStepParams validates parameters for step-order matching units.
+func validateLCSStepParams(params map[string]any) error {
+	if v, ok := params["delimiter"]; ok {
+		if s, ok := v.(string); !ok || s == "" {
+			return fmt.Errorf("delimiter must be a non-empty string")
+		}
+	}
+	for _, key := range []string{"case_sensitive", "strip_markers"} {
+		if v, ok := params[key]; ok {
+			if _, ok := v.(bool); !ok {
+				return fmt.Errorf("%s must be a boolean", key)
+			}
+		}
+	}
+	return validateConclusiveParam(params)
+}
+
+// validateConclusiveParam checks the optional conclusive mapping of
+// deterministic units, whose min_score must be a number in [0, 1].
+func validateConclusiveParam(params map[string]any) error {
+	conclusive, ok := params["conclusive"]
+	if !ok {
+		return nil
+	}
+	m, ok := conclusive.(map[string]any)
+	if !ok {
+		return fmt.Errorf("conclusive must be a mapping")
+	}
+	if v, ok := m["min_score"]; ok {
+		minScore, ok := numberParam(v)
+		if !ok || minScore < 0 || minScore > 1 {
+			return fmt.Errorf("conclusive min_score must be between 0 and 1")
+		}
+	}
+	return nil
+}
+
+// validateFormatValidationParams validates parameters for format validation
+// units, requiring a known type and a list of values for enums.
+func validateFormatValidationParams(params map[string]any) error {
+	formatType, ok := params["type"].(string)
+	if !ok {
+		return fmt.Errorf("format_validation requires 'type' parameter")
+	}
+	switch formatType {
+	case "integer", "float", "date", "url", "email":
+	case "enum":
+		values, ok := params["values"].([]any)
+		if !ok || len(values) == 0 {
+			return fmt.Errorf("enum format requires a non-empty 'values' list")
+		}
+	default:
+		return fmt.Errorf("type must be one of integer, float, date, enum, url, email")
+	}
+	if dateFormat, ok := params["date_format"]; ok {
+		if _, ok := dateFormat.(string); !ok {
+			return fmt.Errorf("date_format must be a string")
+		}
+	}
+	for _, key := range []string{"case_sensitive", "trim_whitespace"} {
+		if v, ok := params[key]; ok {
+			if _, ok := v.(bool); !ok {
+				return fmt.Errorf("%s must be a boolean", key)
+			}
+		}
+	}
+	return nil
+}
+
+// validateAnswerExtractionParams validates parameters for answer extraction
+// units, checking the strategy and that it has what it needs to run.
+func validateAnswerExtractionParams(params map[string]any) error {
+	strategy := "marker"
+	if v, ok := params["strategy"]; ok {
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("strategy must be a string")
+		}
+		strategy = s
+	}
+	switch strategy {
+	case "marker":
+		if markers, ok := params["markers"]; ok {
+			list, ok := markers.([]any)
+			if !ok || len(list) == 0 {
+				return fmt.Errorf("markers must be a non-empty list")
+			}
+			for _, marker := range list {
+				if s, ok := marker.(string); !ok || s == "" {
+					return fmt.Errorf("markers must be non-empty strings")
+				}
+			}
+		}
+	case "last_line":
+	case "code_fence":
+		if v, ok := params["language"]; ok {
+			if s, ok := v.(string); !ok || s == "" {
+				return fmt.Errorf("language must be a non-empty string")
+			}
+		}
+	case "regex":
+		pattern, ok := params["pattern"].(string)
+		if !ok || pattern == "" {
+			return fmt.Errorf("regex strategy requires a 'pattern' string")
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	default:
+		return fmt.Errorf("strategy must be one of marker, last_line, regex, code_fence")
+	}
+	if v, ok := params["on_no_match"]; ok {
+		policy, ok := v.(string)
+		if !ok || (policy != "keep" && policy != "empty" && policy != "error") {
+			return fmt.Errorf("on_no_match must be one of keep, empty, error")
+		}
+	}
 	return nil
 }
 
@@ -334,5 +1115,301 @@ func validateFuzzyMatchParams(params map[string]any) error {
 			return fmt.Errorf("case_sensitive must be a boolean")
 		}
 	}
+	if matchMode, ok := params["match_mode"]; ok {
+		mode, ok := matchMode.(string)
+		if !ok {
+			return fmt.Errorf("match_mode must be a string")
+		}
+		if mode != "full" && mode != "best_substring" {
+			return fmt.Errorf("fuzzy_match match_mode must be 'full' or 'best_substring'")
+		}
+	}
+	if confidenceMode, ok := params["confidence_mode"]; ok {
+		mode, ok := confidenceMode.(string)
+		if !ok {
+			return fmt.Errorf("confidence_mode must be a string")
+		}
+		if mode != "fixed" && mode != "margin" && mode != "similarity" {
+			return fmt.Errorf("fuzzy_match confidence_mode must be 'fixed', 'margin', or 'similarity'")
+		}
+	}
+	if delimiter, ok := params["unordered_delimiter"]; ok {
+		if _, ok := delimiter.(string); !ok {
+			return fmt.Errorf("unordered_delimiter must be a string")
+		}
+	}
+	if weights, ok := params["edit_weights"]; ok {
+		if _, ok := weights.(map[string]any); !ok {
+			return fmt.Errorf("edit_weights must be a mapping of insertion, deletion, and substitution costs")
+		}
+	}
+	if floor, ok := params["length_floor"]; ok {
+		if v, ok := floor.(int); !ok || v < 0 {
+			return fmt.Errorf("length_floor must be a non-negative integer")
+		}
+	}
+	if err := validateTokenizerParam(params); err != nil {
+		return err
+	}
+	if err := validateConclusiveParam(params); err != nil {
+		return err
+	}
+	if err := validateStopwordParams(params); err != nil {
+		return err
+	}
+	return validateEmptyAnswerParams(params)
+}
+
+// validateStopwordParams validates the optional remove_stopwords flag and
+// stopwords list shared by the deterministic text units.
+func validateStopwordParams(params map[string]any) error {
+	if remove, ok := params["remove_stopwords"]; ok {
+		if _, ok := remove.(bool); !ok {
+			return fmt.Errorf("remove_stopwords must be a boolean")
+		}
+	}
+	stopwords, ok := params["stopwords"]
+	if !ok {
+		return nil
+	}
+	words, ok := stopwords.([]any)
+	if !ok {
+		return fmt.Errorf("stopwords must be a list of strings")
+	}
+	for _, word := range words {
+		if w, ok := word.(string); !ok || w == "" {
+			return fmt.Errorf("stopwords must be a list of non-empty strings")
+		}
+	}
+	return nil
+}
+
+// validateTokenizerParam validates the optional tokenizer parameter shared
+// by the deterministic text units.
+func validateTokenizerParam(params map[string]any) error {
+	tokenizer, ok := params["tokenizer"]
+	if !ok {
+		return nil
+	}
+	name, ok := tokenizer.(string)
+	if !ok {
+		return fmt.Errorf("tokenizer must be a string")
+	}
+	if name != "character" && name != "whitespace" && name != "subword" {
+		return fmt.Errorf("tokenizer must be 'character', 'whitespace', or 'subword'")
+	}
+	return nil
+}
+
+// validateTopKSelectionParams validates parameters for top-k selection units.
+func validateTopKSelectionParams(params map[string]any) error {
+	k, ok := params["k"]
+	if !ok {
+		return fmt.Errorf("top_k_selection requires 'k' parameter")
+	}
+	if v, ok := k.(int); !ok || v < 1 {
+		return fmt.Errorf("k must be a positive integer")
+	}
+	if err := validateMinAnswersParam(params); err != nil {
+		return err
+	}
+	if source, ok := params["source"]; ok {
+		s, ok := source.(string)
+		if !ok {
+			return fmt.Errorf("source must be a string")
+		}
+		if s != "fuzzy_match" && s != "length" && s != "judge_scores" {
+			return fmt.Errorf("top_k_selection source must be 'fuzzy_match', 'length', or 'judge_scores'")
+		}
+	}
+	for _, key := range []string{"case_sensitive", "prefer_shorter"} {
+		if value, ok := params[key]; ok {
+			if _, ok := value.(bool); !ok {
+				return fmt.Errorf("%s must be a boolean", key)
+			}
+		}
+	}
+	return validateTokenizerParam(params)
+}
+
+// validateDiverseSelectionParams validates parameters for diverse selection
+// units. Embedding similarity needs an embedding client, which units built
+// from configuration do not have, so only lexical similarity is accepted.
+func validateDiverseSelectionParams(params map[string]any) error {
+	if k, ok := params["k"]; ok {
+		if v, ok := k.(int); !ok || v < 1 {
+			return fmt.Errorf("k must be a positive integer")
+		}
+	}
+	if lambda, ok := params["lambda"]; ok {
+		var l float64
+		switch v := lambda.(type) {
+		case int:
+			l = float64(v)
+		case float64:
+			l = v
+		default:
+			return fmt.Errorf("lambda must be a number")
+		}
+		if l < 0 || l > 10 {
+			return fmt.Errorf("lambda must be between 0 and 10")
+		}
+	}
+	if similarity, ok := params["similarity"]; ok {
+		if s, ok := similarity.(string); !ok || s != "lexical" {
+			return fmt.Errorf("diverse_selection similarity must be 'lexical' when configured from YAML")
+		}
+	}
+	return validateMinAnswersParam(params)
+}
+
+// validateSamplingParams validates parameters for sampling units, which
+// need a positive size or a fraction in (0, 1].
+func validateSamplingParams(params map[string]any) error {
+	if size, ok := params["size"]; ok {
+		if v, ok := size.(int); !ok || v < 1 {
+			return fmt.Errorf("size must be a positive integer")
+		}
+	}
+	if fraction, ok := params["fraction"]; ok {
+		f, ok := numberParam(fraction)
+		if !ok || f <= 0 || f > 1 {
+			return fmt.Errorf("fraction must be greater than 0 and at most 1")
+		}
+	}
+	for _, key := range []string{"seed", "salt"} {
+		if value, ok := params[key]; ok {
+			if _, ok := value.(int); !ok {
+				return fmt.Errorf("%s must be an integer", key)
+			}
+		}
+	}
+	return validateMinAnswersParam(params)
+}
+
+// validateCalibrationParams validates parameters for calibration units,
+// requiring inline parameters or a parameters file and checking that every
+// inline correction has a positive scale and a valid confidence range.
+func validateCalibrationParams(params map[string]any) error {
+	inline, hasInline := params["parameters"]
+	file, hasFile := params["parameters_file"]
+	if !hasInline && !hasFile {
+		return fmt.Errorf("calibration requires 'parameters' or 'parameters_file'")
+	}
+	if hasFile {
+		if path, ok := file.(string); !ok || path == "" {
+			return fmt.Errorf("parameters_file must be a non-empty string")
+		}
+	}
+	if hasInline {
+		judges, ok := inline.(map[string]any)
+		if !ok {
+			return fmt.Errorf("parameters must be a map of judge IDs to corrections")
+		}
+		for judgeID, value := range judges {
+			correction, ok := value.(map[string]any)
+			if !ok {
+				return fmt.Errorf("parameters for judge %q must be a map", judgeID)
+			}
+			switch scale := correction["scale"].(type) {
+			case float64:
+				if scale <= 0 {
+					return fmt.Errorf("scale for judge %q must be positive", judgeID)
+				}
+			case int:
+				if scale <= 0 {
+					return fmt.Errorf("scale for judge %q must be positive", judgeID)
+				}
+			default:
+				return fmt.Errorf("parameters for judge %q require a numeric 'scale'", judgeID)
+			}
+			if offset, ok := correction["offset"]; ok {
+				switch offset.(type) {
+				case float64, int:
+				default:
+					return fmt.Errorf("offset for judge %q must be a number", judgeID)
+				}
+			}
+			if confidence, ok := correction["confidence"]; ok {
+				if err := validateConfidenceRange(confidence); err != nil {
+					return fmt.Errorf("confidence for judge %q: %w", judgeID, err)
+				}
+			}
+		}
+	}
+	if require, ok := params["require_all_judges"]; ok {
+		if _, ok := require.(bool); !ok {
+			return fmt.Errorf("require_all_judges must be a boolean")
+		}
+	}
+	return nil
+}
+
+// validateConfidenceRange checks that value is a map with numeric min and
+// max confidences in [0, 1], min below max.
+func validateConfidenceRange(value any) error {
+	bounds, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("must be a map with 'min' and 'max'")
+	}
+	var limits [2]float64
+	for i, key := range []string{"min", "max"} {
+		switch v := bounds[key].(type) {
+		case float64:
+			limits[i] = v
+		case int:
+			limits[i] = float64(v)
+		default:
+			return fmt.Errorf("%s must be a number", key)
+		}
+		if limits[i] < 0 || limits[i] > 1 {
+			return fmt.Errorf("%s must be between 0 and 1", key)
+		}
+	}
+	if limits[0] >= limits[1] {
+		return fmt.Errorf("min must be less than max")
+	}
+	return nil
+}
+
+// validateNormalizeScoresParams validates parameters for score normalization units.
+func validateNormalizeScoresParams(params map[string]any) error {
+	if require, ok := params["require_declared_scale"]; ok {
+		if _, ok := require.(bool); !ok {
+			return fmt.Errorf("require_declared_scale must be a boolean")
+		}
+	}
+	return nil
+}
+
+// validateAnchorsParam checks that the optional anchors parameter maps
+// numeric score points to non-empty descriptions. Whether the points lie on
+// the unit's score scale is checked when the unit is built.
+func validateAnchorsParam(params map[string]any) error {
+	anchors, ok := params["anchors"]
+	if !ok {
+		return nil
+	}
+	entries := make(map[any]any)
+	switch m := anchors.(type) {
+	case map[any]any:
+		entries = m
+	case map[string]any:
+		for k, v := range m {
+			entries[k] = v
+		}
+	default:
+		return fmt.Errorf("anchors must be a mapping of score points to descriptions")
+	}
+	for point, description := range entries {
+		switch point.(type) {
+		case int, float64:
+		default:
+			return fmt.Errorf("anchor score point %v must be a number", point)
+		}
+		if text, ok := description.(string); !ok || strings.TrimSpace(text) == "" {
+			return fmt.Errorf("anchor %v must have a non-empty description", point)
+		}
+	}
 	return nil
 }
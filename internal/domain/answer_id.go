@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strconv"
+)
+
+// answerIDPrefix prefixes IDs assigned by AssignAnswerIDs.
+const answerIDPrefix = "answer_"
+
+// answerIDHashLength is the number of hex digits of the content hash used
+// in assigned IDs.
+const answerIDHashLength = 12
+
+// AssignAnswerIDs returns answers with an ID assigned to every answer that
+// lacks one, leaving existing IDs untouched. Assigned IDs derive from the
+// answer's content, so the same answers receive the same IDs on every run
+// regardless of their order. Answers with identical content, or whose
+// derived ID is already taken, are disambiguated with a numeric suffix in
+// slice order. The input slice is not modified; when every answer already
+// has an ID it is returned as is, which makes the operation idempotent.
+func AssignAnswerIDs(answers []Answer) []Answer {
+	missing := false
+	taken := make(map[string]bool, len(answers))
+	for _, answer := range answers {
+		if answer.ID == "" {
+			missing = true
+			continue
+		}
+		taken[answer.ID] = true
+	}
+	if !missing {
+		return answers
+	}
+
+	assigned := make([]Answer, len(answers))
+	copy(assigned, answers)
+	for i := range assigned {
+		if assigned[i].ID != "" {
+			continue
+		}
+		sum := sha256.Sum256([]byte(assigned[i].Content))
+		base := answerIDPrefix + hex.EncodeToString(sum[:])[:answerIDHashLength]
+		id := base
+		for n := 2; taken[id]; n++ {
+			id = base + "_" + strconv.Itoa(n)
+		}
+		taken[id] = true
+		assigned[i].ID = id
+	}
+	return assigned
+}
+
+// WithAnswerIDs returns state with AssignAnswerIDs applied to the answers
+// under KeyAnswers. State without answers, or whose answers all have IDs,
+// is returned unchanged.
+func WithAnswerIDs(state State) State {
+	answers, ok := Get(state, KeyAnswers)
+	if !ok || !slices.ContainsFunc(answers, func(a Answer) bool { return a.ID == "" }) {
+		return state
+	}
+	return With(state, KeyAnswers, AssignAnswerIDs(answers))
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAssignAnswerIDs verifies that missing IDs are derived from content,
+// disambiguated, and stable, while existing IDs are preserved.
+func TestAssignAnswerIDs(t *testing.T) {
+	answers := []Answer{
+		{Content: "Paris"},
+		{ID: "a2", Content: "London"},
+		{Content: "Paris"},
+		{Content: "Berlin"},
+	}
+
+	assigned := AssignAnswerIDs(answers)
+	require.Len(t, assigned, 4)
+	assert.Regexp(t, `^answer_[0-9a-f]{12}$`, assigned[0].ID)
+	assert.Equal(t, "a2", assigned[1].ID)
+	assert.Equal(t, assigned[0].ID+"_2", assigned[2].ID, "identical content is disambiguated")
+	assert.NotEqual(t, assigned[0].ID, assigned[3].ID)
+	assert.Empty(t, answers[0].ID, "input must not be modified")
+
+	assert.Equal(t, assigned, AssignAnswerIDs(assigned), "assignment is idempotent")
+
+	reordered := AssignAnswerIDs([]Answer{{Content: "Berlin"}, {Content: "Paris"}})
+	assert.Equal(t, assigned[3].ID, reordered[0].ID, "IDs do not depend on order")
+	assert.Equal(t, assigned[0].ID, reordered[1].ID)
+
+	taken := AssignAnswerIDs([]Answer{{ID: assigned[0].ID, Content: "Rome"}, {Content: "Paris"}})
+	assert.Equal(t, assigned[0].ID+"_2", taken[1].ID, "existing IDs are never reused")
+}
+
+// TestWithAnswerIDs verifies that state is only rewritten when an answer
+// lacks an ID.
+func TestWithAnswerIDs(t *testing.T) {
+	empty := NewState()
+	assert.Equal(t, empty, WithAnswerIDs(empty))
+
+	complete := With(NewState(), KeyAnswers, []Answer{{ID: "a1", Content: "Paris"}})
+	assert.Equal(t, complete, WithAnswerIDs(complete))
+
+	missing := With(NewState(), KeyAnswers, []Answer{{Content: "Paris"}})
+	answers, ok := Get(WithAnswerIDs(missing), KeyAnswers)
+	require.True(t, ok)
+	assert.NotEmpty(t, answers[0].ID)
+}
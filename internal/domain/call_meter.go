@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// CallMeter counts the provider calls an LLM client makes beyond the one
+// a unit asked for, such as retries and corrective re-prompts, so that
+// units charge the budget for every call made on their behalf. A nil
+// *CallMeter reports a single call. CallMeter is safe for concurrent use.
+type CallMeter struct {
+	extra atomic.Int64
+}
+
+// callMeterContextKey is the context key of a call's CallMeter.
+type callMeterContextKey struct{}
+
+// WithCallMeter returns a context carrying a new CallMeter for one LLM
+// call, replacing any meter ctx already carries.
+func WithCallMeter(ctx context.Context) (context.Context, *CallMeter) {
+	meter := &CallMeter{}
+	return context.WithValue(ctx, callMeterContextKey{}, meter), meter
+}
+
+// RecordExtraCalls adds n provider calls to the CallMeter carried by ctx,
+// if any. Client middleware calls it for every call it makes beyond the
+// one it was asked for.
+func RecordExtraCalls(ctx context.Context, n int) {
+	if meter, ok := ctx.Value(callMeterContextKey{}).(*CallMeter); ok && n > 0 {
+		meter.extra.Add(int64(n))
+	}
+}
+
+// Calls returns the provider calls made: the one asked for plus the extra
+// calls recorded.
+func (m *CallMeter) Calls() int {
+	if m == nil {
+		return 1
+	}
+	return 1 + int(m.extra.Load())
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCallMeter verifies that extra calls reach the innermost meter of the
+// context and that a missing meter ignores them.
+func TestCallMeter(t *testing.T) {
+	RecordExtraCalls(context.Background(), 2)
+
+	var unset *CallMeter
+	assert.Equal(t, 1, unset.Calls())
+
+	ctx, outer := WithCallMeter(context.Background())
+	RecordExtraCalls(ctx, 2)
+	RecordExtraCalls(ctx, 0)
+	assert.Equal(t, 3, outer.Calls())
+
+	inner, meter := WithCallMeter(ctx)
+	RecordExtraCalls(inner, 1)
+	assert.Equal(t, 2, meter.Calls())
+	assert.Equal(t, 3, outer.Calls(), "an inner meter replaces the outer one")
+}
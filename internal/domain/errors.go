@@ -1,8 +1,11 @@
 package domain
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
 // Common domain errors that can occur during evaluation operations.
@@ -24,6 +27,26 @@ var (
 
 	// ErrBudgetExceeded indicates that a budget limit has been exceeded.
 	ErrBudgetExceeded = errors.New("budget exceeded")
+
+	// ErrUnsupportedSchemaVersion indicates that persisted data uses a schema
+	// version that cannot be migrated to the current version.
+	ErrUnsupportedSchemaVersion = errors.New("unsupported schema version")
+
+	// ErrLLMCallFailed indicates that a request to an LLM provider failed.
+	ErrLLMCallFailed = errors.New("LLM call failed")
+
+	// ErrInvalidImage indicates that an answer image is malformed or cannot
+	// be sent to an LLM provider.
+	ErrInvalidImage = errors.New("invalid image")
+
+	// ErrResponseParse indicates that an LLM response could not be parsed
+	// into the structure a unit expects.
+	ErrResponseParse = errors.New("failed to parse LLM response")
+
+	// ErrDeadlineExceeded indicates that an item did not finish by the
+	// deadline in its State. It wraps context.DeadlineExceeded, so checks
+	// for either match.
+	ErrDeadlineExceeded = fmt.Errorf("item deadline exceeded: %w", context.DeadlineExceeded)
 )
 
 // StateError represents an error that occurred during State operations.
@@ -56,14 +79,48 @@ func NewStateError(key string, operation string, err error) *StateError {
 	}
 }
 
+// FieldError describes a single failed validation rule on a configuration
+// field, in a form callers can use to render per-field messages.
+type FieldError struct {
+	// Field is the dotted path of the field as written in configuration,
+	// e.g. "tie_breaker" or "expected_judges[0]".
+	Field string
+
+	// Rule is the name of the validation rule that failed, e.g. "required",
+	// "min", or "oneof".
+	Rule string
+
+	// Param is the rule's parameter, e.g. "1" for min=1 or the allowed
+	// values for oneof. It is empty for rules without parameters.
+	Param string
+
+	// Value is the rejected value.
+	Value any
+}
+
+// String returns a human-readable description of the failed rule.
+func (fe FieldError) String() string {
+	rule := fe.Rule
+	if fe.Param != "" {
+		rule += "=" + fe.Param
+	}
+	return fmt.Sprintf("%s failed rule %s (value: %v)", fe.Field, rule, fe.Value)
+}
+
 // ValidationError represents an error that occurred during validation.
-// It can contain multiple validation failures.
+// It can contain multiple validation failures. Failures produced by struct
+// validation also carry structured details in Fields; use errors.As to
+// retrieve them from a wrapped error.
 type ValidationError struct {
 	// Entity is the name of the entity that failed validation.
 	Entity string
 
 	// Errors contains the list of validation error messages.
 	Errors []string
+
+	// Fields contains structured details for field-level failures, in the
+	// same order as their messages in Errors.
+	Fields []FieldError
 }
 
 // Error implements the error interface for ValidationError.
@@ -77,6 +134,22 @@ func (e *ValidationError) Error() string {
 // AddError adds a new error message to the validation error.
 func (e *ValidationError) AddError(msg string) { e.Errors = append(e.Errors, msg) }
 
+// AddFieldError records a field-level failure and its message.
+func (e *ValidationError) AddFieldError(fe FieldError) {
+	e.Fields = append(e.Fields, fe)
+	e.AddError(fe.String())
+}
+
+// Field returns the first failure recorded for the named field, if any.
+func (e *ValidationError) Field(name string) (FieldError, bool) {
+	for _, fe := range e.Fields {
+		if fe.Field == name {
+			return fe, true
+		}
+	}
+	return FieldError{}, false
+}
+
 // HasErrors returns true if there are any validation errors.
 func (e *ValidationError) HasErrors() bool { return len(e.Errors) > 0 }
 
@@ -125,3 +198,168 @@ func NewBudgetExceededError(limitType string, limit, used int, unitID string) *B
 		UnitID:    unitID,
 	}
 }
+
+// TimeoutError reports that a graph did not finish within its overall
+// execution timeout. The graph returns it together with the partial state
+// the completed stages produced, so the stage that stalled can be found.
+// It matches context.DeadlineExceeded with errors.Is.
+type TimeoutError struct {
+	// Timeout is the graph's configured execution timeout.
+	Timeout time.Duration
+
+	// Stage is the ID of the node that was running, or due to run next,
+	// when the timeout expired.
+	Stage string
+
+	// Completed lists the IDs of the nodes that finished, in order.
+	Completed []string
+}
+
+// Error implements the error interface for TimeoutError.
+func (e *TimeoutError) Error() string {
+	msg := fmt.Sprintf("graph timed out after %s at %s", e.Timeout, e.Stage)
+	if len(e.Completed) > 0 {
+		msg += " (completed: " + strings.Join(e.Completed, ", ") + ")"
+	}
+	return msg
+}
+
+// Unwrap returns context.DeadlineExceeded.
+func (e *TimeoutError) Unwrap() error { return context.DeadlineExceeded }
+
+// MissingStateError reports that a unit could not find a required key in
+// State. It matches ErrKeyNotFound with errors.Is.
+type MissingStateError struct {
+	// Unit is the name of the unit that required the key. It may be empty.
+	Unit string
+
+	// Key is the name of the missing state key, e.g. "answers".
+	Key string
+}
+
+// Error implements the error interface for MissingStateError.
+func (e *MissingStateError) Error() string {
+	return unitPrefix(e.Unit) + e.Key + " not found in state"
+}
+
+// Is implements error comparison for Go 1.13+ error handling.
+func (e *MissingStateError) Is(target error) bool {
+	return target == ErrKeyNotFound
+}
+
+// NewMissingStateError creates a MissingStateError for the named key.
+func NewMissingStateError(unit, key string) *MissingStateError {
+	return &MissingStateError{Unit: unit, Key: key}
+}
+
+// LLMCallError reports that a unit's request to an LLM provider failed.
+// It matches ErrLLMCallFailed with errors.Is and unwraps to the provider
+// error, so callers can inspect provider-specific details with errors.As.
+type LLMCallError struct {
+	// Unit is the name of the unit that made the call.
+	Unit string
+
+	// Detail optionally locates the failed call, e.g. "for answer 2".
+	Detail string
+
+	// Err is the error returned by the provider.
+	Err error
+}
+
+// Error implements the error interface for LLMCallError.
+func (e *LLMCallError) Error() string {
+	return fmt.Sprintf("%s%s%s: %v", unitPrefix(e.Unit), ErrLLMCallFailed, detailSuffix(e.Detail), e.Err)
+}
+
+// Unwrap returns the provider error.
+func (e *LLMCallError) Unwrap() error { return e.Err }
+
+// Is implements error comparison for Go 1.13+ error handling.
+func (e *LLMCallError) Is(target error) bool {
+	return target == ErrLLMCallFailed
+}
+
+// NewLLMCallError creates an LLMCallError wrapping the provider error.
+func NewLLMCallError(unit, detail string, err error) *LLMCallError {
+	return &LLMCallError{Unit: unit, Detail: detail, Err: err}
+}
+
+// ResponseParseError reports that a unit received an LLM response it could
+// not parse. It matches ErrResponseParse with errors.Is and unwraps to the
+// underlying parse error.
+type ResponseParseError struct {
+	// Unit is the name of the unit that received the response.
+	Unit string
+
+	// Detail optionally locates the response, e.g. "for answer 2".
+	Detail string
+
+	// Err is the underlying parse error.
+	Err error
+}
+
+// Error implements the error interface for ResponseParseError.
+func (e *ResponseParseError) Error() string {
+	return fmt.Sprintf("%s%s%s: %v", unitPrefix(e.Unit), ErrResponseParse, detailSuffix(e.Detail), e.Err)
+}
+
+// Unwrap returns the underlying parse error.
+func (e *ResponseParseError) Unwrap() error { return e.Err }
+
+// Is implements error comparison for Go 1.13+ error handling.
+func (e *ResponseParseError) Is(target error) bool {
+	return target == ErrResponseParse
+}
+
+// NewResponseParseError creates a ResponseParseError wrapping the parse error.
+func NewResponseParseError(unit, detail string, err error) *ResponseParseError {
+	return &ResponseParseError{Unit: unit, Detail: detail, Err: err}
+}
+
+// ConfigValidationError reports that a unit's configuration is invalid.
+// It matches ErrInvalidConfiguration with errors.Is and unwraps to the
+// underlying error, which is a *ValidationError for struct validation
+// failures.
+type ConfigValidationError struct {
+	// Unit is the name of the unit being configured. It is empty when the
+	// configuration is validated before a unit exists.
+	Unit string
+
+	// Err is the underlying validation error.
+	Err error
+}
+
+// Error implements the error interface for ConfigValidationError.
+func (e *ConfigValidationError) Error() string {
+	return fmt.Sprintf("%sconfiguration validation failed: %v", unitPrefix(e.Unit), e.Err)
+}
+
+// Unwrap returns the underlying validation error.
+func (e *ConfigValidationError) Unwrap() error { return e.Err }
+
+// Is implements error comparison for Go 1.13+ error handling.
+func (e *ConfigValidationError) Is(target error) bool {
+	return target == ErrInvalidConfiguration
+}
+
+// NewConfigValidationError creates a ConfigValidationError wrapping err.
+func NewConfigValidationError(unit string, err error) *ConfigValidationError {
+	return &ConfigValidationError{Unit: unit, Err: err}
+}
+
+// unitPrefix returns the "unit <name>: " prefix for unit error messages, or
+// "" when the unit is unknown.
+func unitPrefix(unit string) string {
+	if unit == "" {
+		return ""
+	}
+	return "unit " + unit + ": "
+}
+
+// detailSuffix returns detail preceded by a space, or "" when it is empty.
+func detailSuffix(detail string) string {
+	if detail == "" {
+		return ""
+	}
+	return " " + detail
+}
@@ -2,9 +2,11 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestStateError verifies the creation and behavior of StateError.
@@ -69,6 +71,25 @@ func TestValidationError(t *testing.T) {
 		assert.Len(t, err.Errors, 3, "Should have three errors.")
 	})
 
+	t.Run("field errors", func(t *testing.T) {
+		err := NewValidationError("MaxPoolConfig")
+		err.AddFieldError(FieldError{Field: "tie_breaker", Rule: "oneof", Param: "first random error", Value: "last"})
+		err.AddFieldError(FieldError{Field: "expected_judges[0]", Rule: "required", Value: ""})
+
+		assert.Equal(t, []string{
+			"tie_breaker failed rule oneof=first random error (value: last)",
+			"expected_judges[0] failed rule required (value: )",
+		}, err.Errors)
+
+		fe, ok := err.Field("tie_breaker")
+		assert.True(t, ok)
+		assert.Equal(t, "oneof", fe.Rule)
+		assert.Equal(t, "last", fe.Value)
+
+		_, ok = err.Field("k")
+		assert.False(t, ok)
+	})
+
 	t.Run("no errors", func(t *testing.T) {
 		err := NewValidationError("Config")
 
@@ -113,6 +134,84 @@ func TestErrorWrapping(t *testing.T) {
 	assert.True(t, errors.Is(wrappedErr, ErrKeyNotFound), "Should match a domain error.")
 }
 
+// TestUnitErrors verifies the typed unit execution errors' messages, their
+// matching sentinels, and unwrapping to the underlying cause.
+func TestUnitErrors(t *testing.T) {
+	cause := errors.New("boom")
+
+	tests := []struct {
+		name     string
+		err      error
+		sentinel error
+		message  string
+		cause    error
+	}{
+		{
+			name:     "missing state",
+			err:      NewMissingStateError("judge", KeyAnswers.Name()),
+			sentinel: ErrKeyNotFound,
+			message:  "unit judge: answers not found in state",
+		},
+		{
+			name:     "missing state without unit",
+			err:      NewMissingStateError("", KeyVerdict.Name()),
+			sentinel: ErrKeyNotFound,
+			message:  "verdict not found in state",
+		},
+		{
+			name:     "LLM call",
+			err:      NewLLMCallError("judge", "for answer 2", cause),
+			sentinel: ErrLLMCallFailed,
+			message:  "unit judge: LLM call failed for answer 2: boom",
+			cause:    cause,
+		},
+		{
+			name:     "response parse",
+			err:      NewResponseParseError("verifier", "", cause),
+			sentinel: ErrResponseParse,
+			message:  "unit verifier: failed to parse LLM response: boom",
+			cause:    cause,
+		},
+		{
+			name:     "config validation",
+			err:      NewConfigValidationError("pool", cause),
+			sentinel: ErrInvalidConfiguration,
+			message:  "unit pool: configuration validation failed: boom",
+			cause:    cause,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("execute: %w", tt.err)
+			assert.Equal(t, tt.message, tt.err.Error())
+			assert.ErrorIs(t, wrapped, tt.sentinel)
+			if tt.cause != nil {
+				assert.ErrorIs(t, wrapped, tt.cause)
+			}
+		})
+	}
+
+	t.Run("errors.As recovers details", func(t *testing.T) {
+		validation := NewValidationError("MaxPoolConfig")
+		validation.AddFieldError(FieldError{Field: "tie_breaker", Rule: "oneof"})
+		err := fmt.Errorf("load: %w", NewConfigValidationError("pool", validation))
+
+		var configErr *ConfigValidationError
+		require.ErrorAs(t, err, &configErr)
+		assert.Equal(t, "pool", configErr.Unit)
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		_, ok := validationErr.Field("tie_breaker")
+		assert.True(t, ok)
+
+		var missing *MissingStateError
+		require.ErrorAs(t, fmt.Errorf("run: %w", NewMissingStateError("judge", "question")), &missing)
+		assert.Equal(t, "question", missing.Key)
+	})
+}
+
 // TestValidationErrorAccumulation verifies that errors are correctly
 // accumulated in a ValidationError instance.
 func TestValidationErrorAccumulation(t *testing.T) {
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tags that delimit the question and an answer in judge prompts. Judge
+// units wrap their labeled prompt blocks in them, and code that reads judge
+// prompts back, such as mock LLM clients, finds the content between them.
+const (
+	PromptQuestionTag = "question"
+	PromptAnswerTag   = "answer"
+)
+
+// EvaluationOption sets an optional part of the State built by
+// NewEvaluationState.
+type EvaluationOption func(State) State
+
+// WithReferenceAnswer stores the ground truth answer under
+// KeyReferenceAnswer for reference-based units such as ExactMatchUnit and
+// FuzzyMatchUnit. An empty reference is ignored.
+func WithReferenceAnswer(reference string) EvaluationOption {
+	return func(s State) State {
+		if reference == "" {
+			return s
+		}
+		return With(s, KeyReferenceAnswer, reference)
+	}
+}
+
+// WithExecution records execution context metadata and zeroes the budget
+// counters, as State.WithExecutionContext does.
+func WithExecution(ctx ExecutionContext) EvaluationOption {
+	return func(s State) State {
+		return s.WithExecutionContext(ctx)
+	}
+}
+
+// WithBudget stores a copy of report under KeyBudget so units charge their
+// usage to it.
+func WithBudget(report BudgetReport) EvaluationOption {
+	return func(s State) State {
+		return With(s, KeyBudget, &report)
+	}
+}
+
+// WithTraceLevel stores the trace level under KeyTraceLevel. An empty
+// level is ignored.
+func WithTraceLevel(level string) EvaluationOption {
+	return func(s State) State {
+		if level == "" {
+			return s
+		}
+		return With(s, KeyTraceLevel, level)
+	}
+}
+
+// WithItemID stores the dataset item's identifier under KeyItemID. An
+// empty ID is ignored.
+func WithItemID(id string) EvaluationOption {
+	return func(s State) State {
+		if id == "" {
+			return s
+		}
+		return With(s, KeyItemID, id)
+	}
+}
+
+// NewEvaluationState returns the starting State for evaluating answers to
+// question, with opts applied in order. A valid starting state always holds
+// a non-blank question under KeyQuestion. Answers are stored under
+// KeyAnswers when any are given; graphs that generate their own answers,
+// such as those beginning with an answerer unit, pass none. Answers without
+// IDs are assigned them when the graph executes.
+//
+// Returns an error wrapping ErrEmptyValue if question is blank.
+func NewEvaluationState(question string, answers []Answer, opts ...EvaluationOption) (State, error) {
+	if strings.TrimSpace(question) == "" {
+		return State{}, fmt.Errorf("%w: question is required", ErrEmptyValue)
+	}
+
+	state := With(NewState(), KeyQuestion, question)
+	if len(answers) > 0 {
+		state = With(state, KeyAnswers, answers)
+	}
+	for _, opt := range opts {
+		state = opt(state)
+	}
+	return state, nil
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewEvaluationState verifies the standard keys and that each option
+// sets only its own part of the state.
+func TestNewEvaluationState(t *testing.T) {
+	answers := []Answer{{ID: "a1", Content: "Paris"}, {ID: "a2", Content: "London"}}
+
+	t.Run("question and answers", func(t *testing.T) {
+		state, err := NewEvaluationState("What is the capital of France?", answers)
+		require.NoError(t, err)
+
+		question, _ := Get(state, KeyQuestion)
+		assert.Equal(t, "What is the capital of France?", question)
+		got, ok := Get(state, KeyAnswers)
+		require.True(t, ok)
+		assert.Equal(t, answers, got)
+		assert.ElementsMatch(t, []string{KeyQuestion.Name(), KeyAnswers.Name()}, state.Keys())
+	})
+
+	t.Run("answers are optional", func(t *testing.T) {
+		state, err := NewEvaluationState("Write a haiku", nil)
+		require.NoError(t, err)
+		_, ok := Get(state, KeyAnswers)
+		assert.False(t, ok)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		state, err := NewEvaluationState("Q?", answers,
+			WithReferenceAnswer("Paris"),
+			WithExecution(ExecutionContext{GraphID: "g1", EvaluationType: "scoring", ExecutionID: "e1"}),
+			WithBudget(BudgetReport{TokensUsed: 10}),
+			WithTraceLevel("debug"),
+		)
+		require.NoError(t, err)
+
+		reference, _ := Get(state, KeyReferenceAnswer)
+		assert.Equal(t, "Paris", reference)
+		execCtx, ok := state.GetExecutionContext()
+		require.True(t, ok)
+		assert.Equal(t, "g1", execCtx.GraphID)
+		assert.Equal(t, Usage{}, state.GetBudgetUsage())
+		budget, ok := Get(state, KeyBudget)
+		require.True(t, ok)
+		assert.Equal(t, 10, budget.TokensUsed)
+		level, _ := Get(state, KeyTraceLevel)
+		assert.Equal(t, "debug", level)
+	})
+
+	t.Run("empty options are ignored", func(t *testing.T) {
+		state, err := NewEvaluationState("Q?", answers, WithReferenceAnswer(""), WithTraceLevel(""))
+		require.NoError(t, err)
+		_, ok := Get(state, KeyReferenceAnswer)
+		assert.False(t, ok)
+		_, ok = Get(state, KeyTraceLevel)
+		assert.False(t, ok)
+	})
+
+	t.Run("blank question", func(t *testing.T) {
+		_, err := NewEvaluationState("  ", answers)
+		assert.ErrorIs(t, err, ErrEmptyValue)
+	})
+}
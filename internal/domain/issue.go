@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrInvalidIssue indicates that a structured verification issue is
+// missing a required field or uses an unknown severity or category.
+var ErrInvalidIssue = errors.New("invalid verification issue")
+
+// IssueSeverity ranks how serious a verification issue is.
+type IssueSeverity string
+
+// Issue severities in ascending order of seriousness.
+const (
+	IssueSeverityLow      IssueSeverity = "low"
+	IssueSeverityMedium   IssueSeverity = "medium"
+	IssueSeverityHigh     IssueSeverity = "high"
+	IssueSeverityCritical IssueSeverity = "critical"
+)
+
+// issueSeverityOrder lists severities from least to most serious.
+var issueSeverityOrder = []IssueSeverity{
+	IssueSeverityLow,
+	IssueSeverityMedium,
+	IssueSeverityHigh,
+	IssueSeverityCritical,
+}
+
+// Rank returns the severity's position from 0 (low) to 3 (critical), or -1
+// for an unknown severity.
+func (s IssueSeverity) Rank() int {
+	return slices.Index(issueSeverityOrder, s)
+}
+
+// AtLeast reports whether s is known and at least as serious as other.
+func (s IssueSeverity) AtLeast(other IssueSeverity) bool {
+	return s.Rank() >= 0 && s.Rank() >= other.Rank()
+}
+
+// VerificationIssue is a machine-actionable problem reported by a
+// verification unit, so downstream routing can key off its category and
+// severity instead of parsing free text.
+type VerificationIssue struct {
+	// Category groups the issue, e.g. "factual_error" or "judge_bias".
+	Category string `json:"category"`
+
+	// Severity ranks how serious the issue is.
+	Severity IssueSeverity `json:"severity"`
+
+	// Description explains the issue in prose.
+	Description string `json:"description"`
+}
+
+// Validate checks that the issue has a category, a known severity, and a
+// description, and, when categories is non-empty, that its category is one
+// of them.
+func (i VerificationIssue) Validate(categories []string) error {
+	if i.Category == "" {
+		return fmt.Errorf("%w: category is required", ErrInvalidIssue)
+	}
+	if len(categories) > 0 && !slices.Contains(categories, i.Category) {
+		return fmt.Errorf("%w: unknown category %q", ErrInvalidIssue, i.Category)
+	}
+	if i.Severity.Rank() < 0 {
+		return fmt.Errorf("%w: unknown severity %q", ErrInvalidIssue, i.Severity)
+	}
+	if i.Description == "" {
+		return fmt.Errorf("%w: description is required", ErrInvalidIssue)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIssueSeverity_AtLeast tests severity ordering, including unknown
+// severities which never meet a threshold.
+func TestIssueSeverity_AtLeast(t *testing.T) {
+	assert.True(t, IssueSeverityCritical.AtLeast(IssueSeverityHigh))
+	assert.True(t, IssueSeverityHigh.AtLeast(IssueSeverityHigh))
+	assert.False(t, IssueSeverityMedium.AtLeast(IssueSeverityHigh))
+	assert.False(t, IssueSeverity("urgent").AtLeast(IssueSeverityLow))
+	assert.Equal(t, -1, IssueSeverity("urgent").Rank())
+}
+
+// TestVerificationIssue_Validate tests required fields and category
+// restrictions.
+func TestVerificationIssue_Validate(t *testing.T) {
+	valid := VerificationIssue{Category: "bias", Severity: IssueSeverityLow, Description: "Prefers long answers"}
+	require.NoError(t, valid.Validate(nil))
+	require.NoError(t, valid.Validate([]string{"bias"}))
+
+	tests := []struct {
+		name       string
+		issue      VerificationIssue
+		categories []string
+		errMsg     string
+	}{
+		{name: "missing category", issue: VerificationIssue{Severity: IssueSeverityLow, Description: "x"}, errMsg: "category is required"},
+		{name: "disallowed category", issue: valid, categories: []string{"factual_error"}, errMsg: `unknown category "bias"`},
+		{name: "unknown severity", issue: VerificationIssue{Category: "bias", Severity: "urgent", Description: "x"}, errMsg: `unknown severity "urgent"`},
+		{name: "missing description", issue: VerificationIssue{Category: "bias", Severity: IssueSeverityLow}, errMsg: "description is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.issue.Validate(tt.categories)
+			require.ErrorIs(t, err, ErrInvalidIssue)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownCriterion indicates that a unit referenced a criterion that is
+// not defined in the rubric.
+var ErrUnknownCriterion = errors.New("unknown criterion")
+
+// Criterion is a single named standard that answers are judged against.
+type Criterion struct {
+	// ID uniquely identifies the criterion within its rubric and is how
+	// units reference it.
+	ID string `yaml:"id" json:"id"`
+
+	// Description explains what the criterion requires and is shown to
+	// LLM-backed judges and verifiers.
+	Description string `yaml:"description" json:"description"`
+
+	// Weight is the criterion's relative importance. Zero means unweighted.
+	Weight float64 `yaml:"weight,omitempty" json:"weight,omitempty"`
+}
+
+// Rubric is a set of criteria defined once per evaluation and shared by
+// every unit that judges or verifies against it, so multi-stage
+// evaluations apply the same standards.
+type Rubric struct {
+	// Criteria lists the rubric's criteria in presentation order.
+	Criteria []Criterion `yaml:"criteria" json:"criteria"`
+}
+
+// Validate checks that the rubric has at least one criterion, that every
+// criterion has a unique non-empty ID and a description, and that weights
+// are non-negative.
+func (r *Rubric) Validate() error {
+	if r == nil || len(r.Criteria) == 0 {
+		return fmt.Errorf("rubric must define at least one criterion")
+	}
+
+	seen := make(map[string]struct{}, len(r.Criteria))
+	for i, c := range r.Criteria {
+		if strings.TrimSpace(c.ID) == "" {
+			return fmt.Errorf("rubric criterion %d has no id", i)
+		}
+		if _, dup := seen[c.ID]; dup {
+			return fmt.Errorf("rubric criterion %q is defined more than once", c.ID)
+		}
+		seen[c.ID] = struct{}{}
+		if strings.TrimSpace(c.Description) == "" {
+			return fmt.Errorf("rubric criterion %q has no description", c.ID)
+		}
+		if c.Weight < 0 {
+			return fmt.Errorf("rubric criterion %q has negative weight %.2f", c.ID, c.Weight)
+		}
+	}
+	return nil
+}
+
+// Select returns the criteria with the given IDs in the requested order.
+// Every ID must exist in the rubric; missing IDs are reported together and
+// wrap ErrUnknownCriterion.
+func (r *Rubric) Select(ids []string) ([]Criterion, error) {
+	byID := make(map[string]Criterion)
+	if r != nil {
+		for _, c := range r.Criteria {
+			byID[c.ID] = c
+		}
+	}
+
+	selected := make([]Criterion, 0, len(ids))
+	var missing []string
+	for _, id := range ids {
+		c, ok := byID[id]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		selected = append(selected, c)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCriterion, strings.Join(missing, ", "))
+	}
+	return selected, nil
+}
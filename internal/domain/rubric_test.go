@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRubric_Validate tests rubric validation rules.
+func TestRubric_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rubric  *Rubric
+		wantErr string
+	}{
+		{
+			name: "valid rubric",
+			rubric: &Rubric{Criteria: []Criterion{
+				{ID: "accuracy", Description: "Correct", Weight: 2},
+				{ID: "clarity", Description: "Clear"},
+			}},
+		},
+		{name: "nil rubric", rubric: nil, wantErr: "at least one criterion"},
+		{name: "no criteria", rubric: &Rubric{}, wantErr: "at least one criterion"},
+		{
+			name:    "missing id",
+			rubric:  &Rubric{Criteria: []Criterion{{Description: "Correct"}}},
+			wantErr: "has no id",
+		},
+		{
+			name: "duplicate id",
+			rubric: &Rubric{Criteria: []Criterion{
+				{ID: "accuracy", Description: "Correct"},
+				{ID: "accuracy", Description: "Also correct"},
+			}},
+			wantErr: "defined more than once",
+		},
+		{
+			name:    "missing description",
+			rubric:  &Rubric{Criteria: []Criterion{{ID: "accuracy"}}},
+			wantErr: "has no description",
+		},
+		{
+			name:    "negative weight",
+			rubric:  &Rubric{Criteria: []Criterion{{ID: "accuracy", Description: "Correct", Weight: -1}}},
+			wantErr: "negative weight",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rubric.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestRubric_Select verifies criteria are returned in the requested order
+// and that every unknown ID is reported.
+func TestRubric_Select(t *testing.T) {
+	rubric := &Rubric{Criteria: []Criterion{
+		{ID: "accuracy", Description: "Correct"},
+		{ID: "clarity", Description: "Clear"},
+	}}
+
+	selected, err := rubric.Select([]string{"clarity", "accuracy"})
+	require.NoError(t, err)
+	assert.Equal(t, []Criterion{rubric.Criteria[1], rubric.Criteria[0]}, selected)
+
+	_, err = rubric.Select([]string{"style", "accuracy", "tone"})
+	require.ErrorIs(t, err, ErrUnknownCriterion)
+	assert.Contains(t, err.Error(), "style, tone")
+
+	var empty *Rubric
+	_, err = empty.Select([]string{"accuracy"})
+	require.ErrorIs(t, err, ErrUnknownCriterion)
+}
@@ -3,9 +3,13 @@
 package domain
 
 import (
+	"encoding/binary"
 	"fmt"
+	"hash/fnv"
 	"maps"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -20,6 +24,27 @@ func NewKey[T any](name string) Key[T] {
 	return Key[T]{name: name}
 }
 
+// Name returns the key's name as stored in State.
+func (k Key[T]) Name() string { return k.name }
+
+// NamespaceSeparator separates a namespace from the key names stored under
+// it, e.g. "fast/judge_scores".
+const NamespaceSeparator = "/"
+
+// In returns the key for the same value stored under namespace, letting a
+// parent read a sub-graph's output explicitly:
+//
+//	scores, ok := Get(state, KeyJudgeScores.In("fast"))
+//
+// Namespaces nest from the inside out, so k.In("inner").In("outer") names
+// "outer/inner/<name>". An empty namespace returns k.
+func (k Key[T]) In(namespace string) Key[T] {
+	if namespace == "" {
+		return k
+	}
+	return Key[T]{name: namespace + NamespaceSeparator + k.name}
+}
+
 // Predefined state keys used throughout the evaluation process.
 // Each key is strongly typed to ensure type safety at compile time.
 var (
@@ -29,18 +54,56 @@ var (
 	// KeyAnswers stores the candidate answers being evaluated.
 	KeyAnswers = Key[[]Answer]{"answers"}
 
+	// KeyDroppedAnswers stores the candidate answers removed by a selection
+	// unit such as TopKSelectionUnit, so filtered candidates remain auditable.
+	KeyDroppedAnswers = Key[[]Answer]{"dropped_answers"}
+
 	// KeyJudgeScores stores individual judge scoring results.
 	KeyJudgeScores = Key[[]JudgeSummary]{"judge_scores"}
 
+	// KeyJudgeScoresByJudge stores each judge unit's scores keyed by the
+	// producing unit's name. Unlike KeyJudgeScores, which holds only the most
+	// recent judge's output, entries from multiple judges accumulate here so
+	// aggregators can verify that every expected judge scored every answer.
+	KeyJudgeScoresByJudge = Key[map[string][]JudgeSummary]{"judge_scores_by_judge"}
+
+	// KeyLatestJudge stores the name of the judge whose scores are currently
+	// held in KeyJudgeScores.
+	KeyLatestJudge = Key[string]{"latest_judge"}
+
+	// KeyJudgeScoreScales stores the score range each judge declared, keyed
+	// by judge name, so scores on different scales can be normalized before
+	// aggregation. Judges without an entry score on the 0.0-1.0 range.
+	KeyJudgeScoreScales = Key[map[string]ScoreRange]{"judge_score_scales"}
+
+	// KeyRubric stores the rubric shared by every unit that judges or
+	// verifies against named criteria. Units reference criteria by ID.
+	KeyRubric = Key[*Rubric]{"rubric"}
+
 	// KeyVerdict stores the final verdict from aggregation.
 	KeyVerdict = Key[*Verdict]{"verdict"}
 
+	// KeyConclusive stores the conclusion of a unit that settled the item
+	// on its own, such as an exact match against the reference. Once it is
+	// set, graphs and pipelines run no further units, so cheap
+	// deterministic checks can spare an item the LLM stages that follow
+	// them. The verdict records the early exit.
+	KeyConclusive = Key[*Conclusion]{"conclusive"}
+
+	// KeySample stores how a sampling unit subset the candidate answers, so
+	// the verdict can report that it rests on a sample of the pool.
+	KeySample = Key[*Sample]{"sample"}
+
 	// Execution context keys for tracking metadata across graph traversal.
 
 	// KeyGraphID stores the unique identifier of the evaluation graph being
 	// executed, used for tracking and observability.
 	KeyGraphID = Key[string]{"execution.graph_id"}
 
+	// KeyGraphInfo stores the metadata of the evaluation graph being
+	// executed, which the graph stamps onto the final verdict.
+	KeyGraphInfo = Key[*GraphInfo]{"execution.graph_info"}
+
 	// KeyEvaluationType stores the type of evaluation being performed
 	// (e.g., "comparison", "scoring", "classification").
 	KeyEvaluationType = Key[string]{"execution.evaluation_type"}
@@ -61,14 +124,40 @@ var (
 	// It determines what level of detail to include in execution traces.
 	KeyTraceLevel = Key[string]{"execution.trace_level"}
 
+	// KeyModelOverrides maps unit IDs to the model each LLM-backed unit
+	// should use for this execution instead of its configured model. The
+	// override names a model of the unit's own provider, enabling model
+	// comparisons within one graph run without duplicating unit config.
+	KeyModelOverrides = Key[map[string]string]{"execution.model_overrides"}
+
+	// KeyDeadline stores the time by which the whole item must finish.
+	// Executors bound every unit's context by it, so time spent by early
+	// stages leaves less for later ones, and fail the item with
+	// ErrDeadlineExceeded once it passes. Set it with WithDeadline.
+	KeyDeadline = Key[time.Time]{"execution.deadline"}
+
 	// KeyVerificationTrace stores the verification unit's output when the
 	// trace level is set to debug.
 	KeyVerificationTrace = Key[string]{"verification_trace"}
 
+	// KeyVerificationFields stores the custom fields parsed from the
+	// verification unit's response when response fields are configured.
+	KeyVerificationFields = Key[map[string]any]{"verification_fields"}
+
+	// KeyVerificationIssues stores the structured issues reported by the
+	// verification unit when structured issues are enabled.
+	KeyVerificationIssues = Key[[]VerificationIssue]{"verification_issues"}
+
 	// KeyBudget stores the complete budget report object for tracking
 	// resource consumption.
 	KeyBudget = Key[*BudgetReport]{"budget"}
 
+	// KeyItemID stores the identifier of the dataset item being evaluated.
+	// Units that need per-item reproducibility, such as answer shuffling,
+	// derive their randomness from it so reruns of an item agree while
+	// neighbouring items differ.
+	KeyItemID = Key[string]{"item_id"}
+
 	// KeyReferenceAnswer stores the ground truth reference answer for
 	// deterministic evaluation units (ExactMatchUnit, FuzzyMatchUnit).
 	// This enables evaluation against known correct answers without LLM involvement.
@@ -91,18 +180,24 @@ func deepCopyValue(value any) any {
 	v := reflect.ValueOf(value)
 	switch v.Kind() {
 	case reflect.Slice:
+		if v.IsNil() {
+			return value
+		}
 		newSlice := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
 		for i := 0; i < v.Len(); i++ {
-			newSlice.Index(i).Set(reflect.ValueOf(deepCopyValue(v.Index(i).Interface())))
+			newSlice.Index(i).Set(deepCopyAs(v.Index(i).Interface(), v.Type().Elem()))
 		}
 		return newSlice.Interface()
 
 	case reflect.Map:
+		if v.IsNil() {
+			return value
+		}
 		newMap := reflect.MakeMap(v.Type())
 		for _, key := range v.MapKeys() {
-			copiedKey := deepCopyValue(key.Interface())
-			copiedValue := deepCopyValue(v.MapIndex(key).Interface())
-			newMap.SetMapIndex(reflect.ValueOf(copiedKey), reflect.ValueOf(copiedValue))
+			newMap.SetMapIndex(
+				deepCopyAs(key.Interface(), v.Type().Key()),
+				deepCopyAs(v.MapIndex(key).Interface(), v.Type().Elem()))
 		}
 		return newMap.Interface()
 
@@ -111,7 +206,7 @@ func deepCopyValue(value any) any {
 			return v.Interface()
 		}
 		newPtr := reflect.New(v.Elem().Type())
-		newPtr.Elem().Set(reflect.ValueOf(deepCopyValue(v.Elem().Interface())))
+		newPtr.Elem().Set(deepCopyAs(v.Elem().Interface(), v.Elem().Type()))
 		return newPtr.Interface()
 
 	case reflect.Struct:
@@ -120,7 +215,7 @@ func deepCopyValue(value any) any {
 		newStruct := reflect.New(v.Type()).Elem()
 		for i := 0; i < v.NumField(); i++ {
 			if newStruct.Field(i).CanSet() {
-				newStruct.Field(i).Set(reflect.ValueOf(deepCopyValue(v.Field(i).Interface())))
+				newStruct.Field(i).Set(deepCopyAs(v.Field(i).Interface(), v.Type().Field(i).Type))
 			}
 		}
 		return newStruct.Interface()
@@ -131,6 +226,18 @@ func deepCopyValue(value any) any {
 	}
 }
 
+// deepCopyAs deep copies value for storage in a slot of type typ. A nil
+// interface value, such as a JSON null decoded into []any or
+// map[string]any, becomes the zero value of typ instead of an invalid
+// reflect.Value, which would panic in Set or silently delete a map entry.
+func deepCopyAs(value any, typ reflect.Type) reflect.Value {
+	copied := deepCopyValue(value)
+	if copied == nil {
+		return reflect.Zero(typ)
+	}
+	return reflect.ValueOf(copied)
+}
+
 // State represents an immutable collection of evaluation data that flows
 // through the pipeline. It uses copy-on-write semantics to ensure
 // thread-safety and prevent unintended mutations. State is the primary
@@ -139,6 +246,24 @@ type State struct {
 	// data holds the key-value pairs that make up the state.
 	// It is unexported to maintain immutability guarantees.
 	data map[string]any
+
+	// written records the keys set since Scope, so MergeNamespace copies
+	// what a sub-graph wrote even when it equals the parent's value. It is
+	// nil outside a scope.
+	written map[string]struct{}
+}
+
+// derive returns a State holding data that carries s's written keys plus
+// keys when s is scoped.
+func (s State) derive(data map[string]any, keys ...string) State {
+	if s.written == nil {
+		return State{data: data}
+	}
+	written := maps.Clone(s.written)
+	for _, k := range keys {
+		written[k] = struct{}{}
+	}
+	return State{data: data, written: written}
 }
 
 // NewState creates a new empty State.
@@ -184,6 +309,54 @@ func (s State) GetRaw(keyName string) (any, bool) {
 	return deepCopyValue(value), true
 }
 
+// Require retrieves a value like Get but reports a missing value as a
+// *MissingStateError naming unit and the key, so units that cannot run
+// without an input fail with the same error.
+//
+// Example:
+//
+//	answers, err := Require(state, KeyAnswers, "exact-match")
+//	if err != nil {
+//	    return state, err
+//	}
+func Require[T any](s State, key Key[T], unit string) (T, error) {
+	value, ok := Get(s, key)
+	if !ok {
+		return value, NewMissingStateError(unit, key.name)
+	}
+	return value, nil
+}
+
+// GetQuestion returns the value of KeyQuestion.
+func (s State) GetQuestion() (string, bool) { return Get(s, KeyQuestion) }
+
+// GetAnswers returns the value of KeyAnswers.
+func (s State) GetAnswers() ([]Answer, bool) { return Get(s, KeyAnswers) }
+
+// GetDroppedAnswers returns the value of KeyDroppedAnswers.
+func (s State) GetDroppedAnswers() ([]Answer, bool) { return Get(s, KeyDroppedAnswers) }
+
+// GetJudgeScores returns the value of KeyJudgeScores.
+func (s State) GetJudgeScores() ([]JudgeSummary, bool) { return Get(s, KeyJudgeScores) }
+
+// GetRubric returns the value of KeyRubric.
+func (s State) GetRubric() (*Rubric, bool) { return Get(s, KeyRubric) }
+
+// GetVerdict returns the value of KeyVerdict.
+func (s State) GetVerdict() (*Verdict, bool) { return Get(s, KeyVerdict) }
+
+// GetBudget returns the value of KeyBudget.
+func (s State) GetBudget() (*BudgetReport, bool) { return Get(s, KeyBudget) }
+
+// GetReferenceAnswer returns the value of KeyReferenceAnswer.
+func (s State) GetReferenceAnswer() (string, bool) { return Get(s, KeyReferenceAnswer) }
+
+// GetItemID returns the value of KeyItemID.
+func (s State) GetItemID() (string, bool) { return Get(s, KeyItemID) }
+
+// GetTraceLevel returns the value of KeyTraceLevel.
+func (s State) GetTraceLevel() (string, bool) { return Get(s, KeyTraceLevel) }
+
 // With creates a new State with the specified key-value pair added or
 // updated. It implements copy-on-write semantics, returning a new State
 // instance while leaving the original unchanged. This function is the
@@ -195,7 +368,7 @@ func (s State) GetRaw(keyName string) (any, bool) {
 func With[T any](s State, key Key[T], value T) State {
 	newData := maps.Clone(s.data)
 	newData[key.name] = deepCopyValue(value)
-	return State{data: newData}
+	return s.derive(newData, key.name)
 }
 
 // WithRaw is a method version of With that uses a string key and allows
@@ -203,7 +376,7 @@ func With[T any](s State, key Key[T], value T) State {
 func (s State) WithRaw(keyName string, value any) State {
 	newData := maps.Clone(s.data)
 	newData[keyName] = deepCopyValue(value)
-	return State{data: newData}
+	return s.derive(newData, keyName)
 }
 
 // WithMultiple creates a new State with multiple key-value pairs added
@@ -223,7 +396,7 @@ func (s State) WithMultiple(updates map[string]any) State {
 	for k, v := range updates {
 		newData[k] = deepCopyValue(v)
 	}
-	return State{data: newData}
+	return s.derive(newData, slices.Collect(maps.Keys(updates))...)
 }
 
 // Keys returns all keys present in the State.
@@ -237,6 +410,55 @@ func (s State) Keys() []string {
 	return keys
 }
 
+// Scope returns the State a sub-graph running in namespace sees: every value
+// of s, with the values stored under namespace shadowing the unnamespaced
+// values of the same name. Units in the sub-graph read and write plain keys
+// such as KeyJudgeScores; MergeNamespace moves what they write back under
+// the namespace. The returned State records the keys written to it from
+// here on; an empty namespace shadows nothing.
+func (s State) Scope(namespace string) State {
+	written := make(map[string]struct{})
+	if namespace == "" {
+		return State{data: s.data, written: written}
+	}
+	prefix := namespace + NamespaceSeparator
+	newData := maps.Clone(s.data)
+	for k, v := range s.data {
+		if name, ok := strings.CutPrefix(k, prefix); ok {
+			newData[name] = v
+		}
+	}
+	return State{data: newData, written: written}
+}
+
+// MergeNamespace returns parent with the values sub wrote since it was
+// derived from parent.Scope(namespace), stored under namespace. A value
+// the sub-graph wrote is copied even when it equals the parent's, such as
+// judge scores recomputed to the same numbers. Values it only passed
+// through, such as the question, are not copied, and parent's unnamespaced
+// values are left unchanged, so sibling sub-graphs writing the same keys no
+// longer overwrite each other. When sub was not derived from Scope, every
+// value that differs from parent's is copied. Read the merged values with
+// Key.In. Like With, it returns a new State.
+func MergeNamespace(parent State, namespace string, sub State) State {
+	scoped := parent.Scope(namespace)
+	updates := make(map[string]any)
+	for k, v := range sub.data {
+		if sub.written != nil {
+			if _, ok := sub.written[k]; !ok {
+				continue
+			}
+		} else if prev, ok := scoped.data[k]; ok && reflect.DeepEqual(prev, v) {
+			continue
+		}
+		if namespace != "" {
+			k = namespace + NamespaceSeparator + k
+		}
+		updates[k] = v
+	}
+	return parent.WithMultiple(updates)
+}
+
 // String returns a string representation of the State for debugging purposes.
 func (s State) String() string {
 	return fmt.Sprintf("State%v", s.data)
@@ -256,6 +478,9 @@ type ExecutionContext struct {
 	// ExecutionID is a unique identifier for this specific execution instance,
 	// useful for tracing and correlation across distributed systems.
 	ExecutionID string
+
+	// Graph describes the evaluation graph being executed. It is optional.
+	Graph *GraphInfo
 }
 
 // WithExecutionContext creates a new State with execution context metadata
@@ -269,12 +494,16 @@ func (s State) WithExecutionContext(ctx ExecutionContext) State {
 		KeyBudgetTokensUsed.name: int64(0),
 		KeyBudgetCallsMade.name:  int64(0),
 	}
+	if ctx.Graph != nil {
+		updates[KeyGraphInfo.name] = ctx.Graph
+	}
 	return s.WithMultiple(updates)
 }
 
 // GetExecutionContext extracts execution context metadata from the State.
 // It returns the execution context and a boolean indicating whether all
-// required context fields are present and valid.
+// required context fields are present and valid. The optional Graph field
+// is nil when no graph metadata is present.
 func (s State) GetExecutionContext() (ExecutionContext, bool) {
 	graphID, ok1 := Get(s, KeyGraphID)
 	evaluationType, ok2 := Get(s, KeyEvaluationType)
@@ -284,10 +513,12 @@ func (s State) GetExecutionContext() (ExecutionContext, bool) {
 		return ExecutionContext{}, false
 	}
 
+	graph, _ := Get(s, KeyGraphInfo)
 	return ExecutionContext{
 		GraphID:        graphID,
 		EvaluationType: evaluationType,
 		ExecutionID:    executionID,
+		Graph:          graph,
 	}, true
 }
 
@@ -328,3 +559,116 @@ func (s State) GetBudgetUsage() Usage {
 		Calls:  calls,
 	}
 }
+
+// WithJudgeScores records scores produced by the named judge. It sets
+// KeyJudgeScores for consumers of the latest judge's output and adds the
+// scores under judgeID in KeyJudgeScoresByJudge, preserving entries from
+// other judges. Like With, it returns a new State and leaves s unchanged.
+func WithJudgeScores(s State, judgeID string, scores []JudgeSummary) State {
+	byJudge, ok := Get(s, KeyJudgeScoresByJudge)
+	if !ok || byJudge == nil {
+		byJudge = make(map[string][]JudgeSummary)
+	}
+	byJudge[judgeID] = scores
+
+	s = With(s, KeyJudgeScores, scores)
+	s = With(s, KeyLatestJudge, judgeID)
+	return With(s, KeyJudgeScoresByJudge, byJudge)
+}
+
+// ScoreRange is the inclusive range of scores a judge can assign.
+type ScoreRange struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// UnitScoreRange is the native range of deterministic judges and the
+// target range of score normalization.
+var UnitScoreRange = ScoreRange{Min: 0, Max: 1}
+
+// Normalize maps score from r onto the 0.0-1.0 range, clamping scores that
+// fall outside r. A degenerate range maps every score to 0.
+func (r ScoreRange) Normalize(score float64) float64 {
+	if r.Max <= r.Min {
+		return 0
+	}
+	n := (score - r.Min) / (r.Max - r.Min)
+	return min(max(n, 0), 1)
+}
+
+// WithJudgeScoreScale records the score range declared by the named judge
+// in KeyJudgeScoreScales, preserving entries from other judges.
+func WithJudgeScoreScale(s State, judgeID string, scale ScoreRange) State {
+	scales, ok := Get(s, KeyJudgeScoreScales)
+	if !ok || scales == nil {
+		scales = make(map[string]ScoreRange)
+	}
+	scales[judgeID] = scale
+	return With(s, KeyJudgeScoreScales, scales)
+}
+
+// WithModelOverride records that the named unit should use model for this
+// execution, preserving overrides for other units.
+func WithModelOverride(s State, unitID, model string) State {
+	overrides, ok := Get(s, KeyModelOverrides)
+	if !ok || overrides == nil {
+		overrides = make(map[string]string)
+	}
+	overrides[unitID] = model
+	return With(s, KeyModelOverrides, overrides)
+}
+
+// WithDeadline returns a copy of s whose item must finish by deadline. An
+// earlier deadline already in s is kept, so a deadline can be tightened but
+// never extended.
+func WithDeadline(s State, deadline time.Time) State {
+	if current, ok := Deadline(s); ok && current.Before(deadline) {
+		return s
+	}
+	return With(s, KeyDeadline, deadline)
+}
+
+// Deadline returns the item's deadline, if one is set. A zero time means no
+// deadline.
+func Deadline(s State) (time.Time, bool) {
+	deadline, _ := Get(s, KeyDeadline)
+	return deadline, !deadline.IsZero()
+}
+
+// ItemSeed derives a deterministic seed for the item held in s from
+// KeyItemID, falling back to KeyQuestion, mixed with salt. The same item
+// and salt always yield the same seed, and distinct items yield unrelated
+// seeds, so per-item randomness is reproducible without being correlated
+// across a dataset.
+func ItemSeed(s State, salt int64) int64 {
+	h := fnv.New64a()
+	if id, ok := s.GetItemID(); ok {
+		_, _ = h.Write([]byte("item:" + id))
+	} else {
+		question, _ := s.GetQuestion()
+		_, _ = h.Write([]byte("question:" + question))
+	}
+	var saltBytes [8]byte
+	binary.LittleEndian.PutUint64(saltBytes[:], uint64(salt))
+	_, _ = h.Write(saltBytes[:])
+	return int64(h.Sum64())
+}
+
+// ModelOverride returns the model override for the named unit, if any.
+// Empty overrides are ignored.
+func ModelOverride(s State, unitID string) (string, bool) {
+	overrides, _ := Get(s, KeyModelOverrides)
+	model := overrides[unitID]
+	return model, model != ""
+}
+
+// JudgeScoreScale returns the score range declared by the named judge,
+// defaulting to UnitScoreRange when none was recorded.
+func JudgeScoreScale(s State, judgeID string) (ScoreRange, bool) {
+	scales, _ := Get(s, KeyJudgeScoreScales)
+	scale, ok := scales[judgeID]
+	if !ok {
+		return UnitScoreRange, false
+	}
+	return scale, true
+}
@@ -120,6 +120,198 @@ func TestState_With(t *testing.T) {
 	assert.Equal(t, newValue, v2, "With() returned an incorrect updated value.")
 }
 
+// TestState_TypedAccessors tests that the typed accessors read their keys
+// and report missing values, and that Require names the unit and key.
+func TestState_TypedAccessors(t *testing.T) {
+	empty := NewState()
+	_, ok := empty.GetAnswers()
+	assert.False(t, ok)
+	_, ok = empty.GetVerdict()
+	assert.False(t, ok)
+
+	answers := []Answer{{ID: "a1", Content: "Paris"}}
+	scores := []JudgeSummary{{Score: 0.9}}
+	verdict := &Verdict{ID: "v1"}
+	budget := &BudgetReport{TokensUsed: 10}
+	state := With(NewState(), KeyQuestion, "Capital?")
+	state = With(state, KeyAnswers, answers)
+	state = With(state, KeyJudgeScores, scores)
+	state = With(state, KeyVerdict, verdict)
+	state = With(state, KeyBudget, budget)
+	state = With(state, KeyReferenceAnswer, "Paris")
+	state = With(state, KeyTraceLevel, "debug")
+
+	question, ok := state.GetQuestion()
+	assert.True(t, ok)
+	assert.Equal(t, "Capital?", question)
+	gotAnswers, ok := state.GetAnswers()
+	assert.True(t, ok)
+	assert.Equal(t, answers, gotAnswers)
+	gotScores, ok := state.GetJudgeScores()
+	assert.True(t, ok)
+	assert.Equal(t, scores, gotScores)
+	gotVerdict, ok := state.GetVerdict()
+	assert.True(t, ok)
+	assert.Equal(t, verdict, gotVerdict)
+	gotBudget, ok := state.GetBudget()
+	assert.True(t, ok)
+	assert.Equal(t, budget, gotBudget)
+	reference, ok := state.GetReferenceAnswer()
+	assert.True(t, ok)
+	assert.Equal(t, "Paris", reference)
+	traceLevel, ok := state.GetTraceLevel()
+	assert.True(t, ok)
+	assert.Equal(t, "debug", traceLevel)
+
+	gotAnswers, err := Require(state, KeyAnswers, "judge")
+	require.NoError(t, err)
+	assert.Equal(t, answers, gotAnswers)
+
+	_, err = Require(empty, KeyAnswers, "judge")
+	var missing *MissingStateError
+	require.ErrorAs(t, err, &missing)
+	assert.Equal(t, "judge", missing.Unit)
+	assert.Equal(t, KeyAnswers.Name(), missing.Key)
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+// TestWithJudgeScores tests that scores from multiple judges accumulate under
+// their judge IDs without clobbering each other, while KeyJudgeScores keeps
+// the most recent judge's output and earlier states remain unchanged.
+func TestWithJudgeScores(t *testing.T) {
+	first := []JudgeSummary{{Score: 0.8}, {Score: 0.6}}
+	second := []JudgeSummary{{Score: 0.5}, {Score: 0.9}}
+
+	s1 := WithJudgeScores(NewState(), "judge_a", first)
+	s2 := WithJudgeScores(s1, "judge_b", second)
+
+	byJudge, ok := Get(s2, KeyJudgeScoresByJudge)
+	require.True(t, ok)
+	assert.Equal(t, map[string][]JudgeSummary{"judge_a": first, "judge_b": second}, byJudge)
+
+	latest, ok := Get(s2, KeyJudgeScores)
+	require.True(t, ok)
+	assert.Equal(t, second, latest)
+
+	latestJudge, ok := Get(s2, KeyLatestJudge)
+	require.True(t, ok)
+	assert.Equal(t, "judge_b", latestJudge)
+
+	earlier, _ := Get(s1, KeyJudgeScoresByJudge)
+	assert.Len(t, earlier, 1, "WithJudgeScores() should not modify the previous state.")
+}
+
+// TestState_Namespaces tests that two sub-graphs writing the same keys keep
+// separate outputs under their namespaces, readable by the parent with
+// Key.In and visible again when the namespace is re-entered.
+func TestState_Namespaces(t *testing.T) {
+	parent := With(NewState(), KeyQuestion, "What is the capital of France?")
+	parent = With(parent, KeyJudgeScores, []JudgeSummary{{Score: 0.1}})
+
+	fast := WithJudgeScores(parent.Scope("fast"), "judge", []JudgeSummary{{Score: 0.7}})
+	slow := WithJudgeScores(parent.Scope("slow"), "judge", []JudgeSummary{{Score: 0.9}})
+	merged := MergeNamespace(MergeNamespace(parent, "fast", fast), "slow", slow)
+
+	scores, ok := Get(merged, KeyJudgeScores.In("fast"))
+	require.True(t, ok)
+	assert.Equal(t, []JudgeSummary{{Score: 0.7}}, scores)
+	scores, ok = Get(merged, KeyJudgeScores.In("slow"))
+	require.True(t, ok)
+	assert.Equal(t, []JudgeSummary{{Score: 0.9}}, scores)
+
+	scores, _ = merged.GetJudgeScores()
+	assert.Equal(t, []JudgeSummary{{Score: 0.1}}, scores, "the parent's own scores are unchanged")
+	_, ok = Get(merged, KeyQuestion.In("fast"))
+	assert.False(t, ok, "values passed through unchanged are not copied")
+
+	scores, _ = merged.Scope("slow").GetJudgeScores()
+	assert.Equal(t, []JudgeSummary{{Score: 0.9}}, scores)
+	question, _ := merged.Scope("slow").GetQuestion()
+	assert.Equal(t, "What is the capital of France?", question)
+
+	_, ok = Get(parent, KeyJudgeScores.In("fast"))
+	assert.False(t, ok, "MergeNamespace() should not modify the parent state.")
+
+	assert.Equal(t, "outer/inner/judge_scores", KeyJudgeScores.In("inner").In("outer").Name())
+	assert.Equal(t, KeyJudgeScores, KeyJudgeScores.In(""))
+}
+
+// TestState_NamespacesUnchangedWrites tests that MergeNamespace copies a
+// value the sub-graph wrote even when it equals the parent's, including
+// through nested namespaces.
+func TestState_NamespacesUnchangedWrites(t *testing.T) {
+	scores := []JudgeSummary{{Score: 0.7, Confidence: 0.9}}
+	parent := With(NewState(), KeyQuestion, "What is the capital of France?")
+	parent = With(parent, KeyJudgeScores, scores)
+
+	recomputed := With(parent.Scope("rejudge"), KeyJudgeScores, scores)
+	merged := MergeNamespace(parent, "rejudge", recomputed)
+	got, ok := Get(merged, KeyJudgeScores.In("rejudge"))
+	require.True(t, ok, "a recomputed value equal to the parent's is merged")
+	assert.Equal(t, scores, got)
+	_, ok = Get(merged, KeyQuestion.In("rejudge"))
+	assert.False(t, ok)
+
+	outer := parent.Scope("outer")
+	inner := With(outer.Scope("inner"), KeyJudgeScores, scores)
+	outer = MergeNamespace(outer, "inner", inner)
+	merged = MergeNamespace(parent, "outer", outer)
+	_, ok = Get(merged, KeyJudgeScores.In("inner").In("outer"))
+	assert.True(t, ok, "a nested sub-graph's writes reach the outermost parent")
+	_, ok = Get(merged, KeyJudgeScores.In("outer"))
+	assert.False(t, ok)
+
+	unscoped := With(parent, KeyJudgeScores, scores)
+	_, ok = Get(MergeNamespace(parent, "plain", unscoped), KeyJudgeScores.In("plain"))
+	assert.False(t, ok, "without Scope only changed values are merged")
+}
+
+// TestModelOverride tests recording per-unit model overrides without
+// affecting earlier states, and that empty overrides are ignored.
+func TestModelOverride(t *testing.T) {
+	s1 := WithModelOverride(NewState(), "judge_a", "gpt-4.1-mini")
+	s2 := WithModelOverride(s1, "judge_b", "")
+
+	model, ok := ModelOverride(s2, "judge_a")
+	assert.True(t, ok)
+	assert.Equal(t, "gpt-4.1-mini", model)
+
+	_, ok = ModelOverride(s2, "judge_b")
+	assert.False(t, ok, "empty overrides should be ignored")
+
+	_, ok = ModelOverride(NewState(), "judge_a")
+	assert.False(t, ok)
+
+	earlier, _ := Get(s1, KeyModelOverrides)
+	assert.Len(t, earlier, 1, "WithModelOverride() should not modify the previous state.")
+}
+
+// TestJudgeScoreScale tests recording judge scales, the 0.0-1.0 default for
+// undeclared judges, and normalization with clamping.
+func TestJudgeScoreScale(t *testing.T) {
+	s1 := WithJudgeScoreScale(NewState(), "judge_a", ScoreRange{Min: 1, Max: 10})
+	s2 := WithJudgeScoreScale(s1, "judge_b", UnitScoreRange)
+
+	scale, declared := JudgeScoreScale(s2, "judge_a")
+	assert.True(t, declared)
+	assert.Equal(t, ScoreRange{Min: 1, Max: 10}, scale)
+
+	scale, declared = JudgeScoreScale(s2, "judge_c")
+	assert.False(t, declared)
+	assert.Equal(t, UnitScoreRange, scale)
+
+	earlier, _ := Get(s1, KeyJudgeScoreScales)
+	assert.Len(t, earlier, 1, "WithJudgeScoreScale() should not modify the previous state.")
+
+	r := ScoreRange{Min: 1, Max: 10}
+	assert.InDelta(t, 0.0, r.Normalize(1), 1e-9)
+	assert.InDelta(t, 0.5, r.Normalize(5.5), 1e-9)
+	assert.InDelta(t, 1.0, r.Normalize(10), 1e-9)
+	assert.InDelta(t, 1.0, r.Normalize(12), 1e-9, "scores above the range clamp to 1")
+	assert.InDelta(t, 0.0, r.Normalize(-3), 1e-9, "scores below the range clamp to 0")
+	assert.Zero(t, ScoreRange{Min: 2, Max: 2}.Normalize(2))
+}
+
 // TestState_WithMultiple tests the batch update functionality of a State instance.
 // It ensures that multiple key-value pairs are added immutably and correctly.
 func TestState_WithMultiple(t *testing.T) {
@@ -411,6 +603,46 @@ func TestState_DeepCopy(t *testing.T) {
 	}
 }
 
+// TestState_DeepCopyNilElements ensures that nil interface values inside
+// slices, maps, and structs, such as JSON nulls, survive a copy instead of
+// panicking or being dropped.
+func TestState_DeepCopyNilElements(t *testing.T) {
+	type holder struct {
+		Value any
+		Items []any
+	}
+	keyAny := Key[any]{"any"}
+	keyHolder := Key[holder]{"holder"}
+
+	value := map[string]any{
+		"tags":   []any{"x", nil},
+		"source": nil,
+		"nested": map[string]any{"list": []any{nil, map[string]any{"y": nil}}},
+	}
+	var state State
+	require.NotPanics(t, func() { state = With(NewState(), keyAny, any(value)) })
+	got, ok := Get(state, keyAny)
+	require.True(t, ok)
+	assert.Equal(t, value, got)
+
+	require.NotPanics(t, func() { state = With(state, keyHolder, holder{Items: []any{nil}}) })
+	gotHolder, _ := Get(state, keyHolder)
+	assert.Equal(t, holder{Items: []any{nil}}, gotHolder)
+}
+
+// TestState_DeepCopyPreservesNil verifies that nil slices and maps nested in
+// stored values stay nil rather than becoming empty.
+func TestState_DeepCopyPreservesNil(t *testing.T) {
+	state := With(NewState(), KeyJudgeScores, []JudgeSummary{{Score: 1}})
+	scores, ok := Get(state, KeyJudgeScores)
+	require.True(t, ok)
+	assert.Nil(t, scores[0].Dimensions)
+
+	state = With(state, KeyAnswers, []Answer{{ID: "a1"}})
+	answers, _ := Get(state, KeyAnswers)
+	assert.Nil(t, answers[0].Metadata)
+}
+
 // TestState_String tests the string representation of a State instance.
 func TestState_String(t *testing.T) {
 	state := With(NewState(), KeyQuestion, "test")
@@ -546,6 +778,11 @@ func TestState_ExecutionContext(t *testing.T) {
 	emptyState := NewState()
 	_, ok = emptyState.GetExecutionContext()
 	assert.False(t, ok, "Should not retrieve a context from an empty state.")
+
+	ctx.Graph = &GraphInfo{Name: "experiment", Tags: []string{"nightly"}}
+	retrievedCtx, ok = NewState().WithExecutionContext(ctx).GetExecutionContext()
+	require.True(t, ok, "Should retrieve the execution context with graph metadata.")
+	assert.Equal(t, ctx, retrievedCtx, "Graph metadata should round-trip.")
 }
 
 // TestState_BudgetUsage verifies the tracking of budget usage within a State instance.
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -12,6 +14,323 @@ type Answer struct {
 
 	// Content contains the actual answer text or data.
 	Content string `json:"content"`
+
+	// Metadata holds optional attributes of the answer, such as its source
+	// or the model that generated it. Units carry it through unchanged so it
+	// is available on the verdict's WinnerAnswer and RankedAnswers.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Images are images that belong to the answer, such as charts or
+	// diagrams. Judges that support multimodal input attach them to their
+	// LLM calls when configured to; text-only units ignore them.
+	Images []Image `json:"images,omitempty"`
+}
+
+// Image is an image that belongs to an answer, either referenced by URL or
+// carried inline. Exactly one of URL and Data is set.
+type Image struct {
+	// URL locates the image. The provider fetches it, so it must be
+	// reachable from the provider's side.
+	URL string `json:"url,omitempty"`
+
+	// Data holds the encoded image, such as the bytes of a PNG file.
+	Data []byte `json:"data,omitempty"`
+
+	// MediaType is the image's MIME type, such as "image/png". It is
+	// required with Data and optional with URL.
+	MediaType string `json:"media_type,omitempty"`
+}
+
+// Validate returns an error wrapping ErrInvalidImage unless exactly one of
+// URL and Data is set and MediaType, when present, names an image type.
+func (img Image) Validate() error {
+	switch {
+	case img.URL == "" && len(img.Data) == 0:
+		return fmt.Errorf("%w: either url or data is required", ErrInvalidImage)
+	case img.URL != "" && len(img.Data) > 0:
+		return fmt.Errorf("%w: url and data are mutually exclusive", ErrInvalidImage)
+	case len(img.Data) > 0 && img.MediaType == "":
+		return fmt.Errorf("%w: media type is required with inline data", ErrInvalidImage)
+	case img.MediaType != "" && !strings.HasPrefix(img.MediaType, "image/"):
+		return fmt.Errorf("%w: media type %q is not an image type", ErrInvalidImage, img.MediaType)
+	}
+	return nil
+}
+
+// Well-known Answer.Metadata keys set by units that generate or rewrite
+// answers.
+const (
+	// AnswerMetadataGenerator names the unit that generated the answer.
+	AnswerMetadataGenerator = "generator"
+
+	// AnswerMetadataModel names the LLM model that generated the answer.
+	AnswerMetadataModel = "model"
+
+	// AnswerMetadataOriginalContent holds an answer's content as it was
+	// before a unit replaced it, such as the full response an extraction
+	// unit reduced to its final answer.
+	AnswerMetadataOriginalContent = "original_content"
+)
+
+// RankedAnswer pairs a candidate answer with the score it was ranked by.
+// Aggregators emit these in best-first order so callers can display the
+// full ordering or select the top-k answers.
+type RankedAnswer struct {
+	// Answer is the ranked candidate.
+	Answer Answer `json:"answer"`
+
+	// Score is the answer's score as seen by the aggregator that ranked it.
+	Score float64 `json:"score"`
+}
+
+// ScoreDisplay presents a verdict's scores on a consumer-facing scale, such
+// as a percentage or letter grade. It is derived from AggregateScore and
+// RankedAnswers, which keep the aggregator's internal scale.
+type ScoreDisplay struct {
+	// Scale names the output scale (e.g., "percentage", "letter").
+	Scale string `json:"scale"`
+
+	// AggregateScore is the verdict's AggregateScore on the output scale.
+	AggregateScore float64 `json:"aggregate_score"`
+
+	// AggregateLabel is the label for AggregateScore, such as a letter
+	// grade. It is empty for scales without labels.
+	AggregateLabel string `json:"aggregate_label,omitempty"`
+
+	// RankedScores holds each ranked answer's score on the output scale,
+	// in the same order as RankedAnswers.
+	RankedScores []DisplayScore `json:"ranked_scores,omitempty"`
+}
+
+// DisplayScore is one ranked answer's score on an output scale.
+type DisplayScore struct {
+	// AnswerID identifies the ranked answer.
+	AnswerID string `json:"answer_id"`
+
+	// Score is the answer's score on the output scale.
+	Score float64 `json:"score"`
+
+	// Label is the label for Score. It is empty for scales without labels.
+	Label string `json:"label,omitempty"`
+}
+
+// UnitProvenance identifies a unit that participated in producing a
+// verdict. Type and Model are empty when the unit was not built from a graph
+// configuration or does not use an LLM.
+type UnitProvenance struct {
+	// Name is the unit's identifier within the graph.
+	Name string `json:"name"`
+
+	// Type is the registered unit type (e.g., "score_judge", "max_pool").
+	Type string `json:"type,omitempty"`
+
+	// Model is the LLM model the unit was configured with.
+	Model string `json:"model,omitempty"`
+
+	// PromptRef is the "name@version" library prompt the unit was
+	// configured with, empty for inline prompts.
+	PromptRef string `json:"prompt_ref,omitempty"`
+
+	// PromptFingerprint is the hex-encoded SHA256 of the unit's prompt
+	// template, so verdicts from different prompts can be told apart.
+	PromptFingerprint string `json:"prompt_fingerprint,omitempty"`
+}
+
+// Provenance records which units and aggregation method produced a verdict.
+// It supports auditing and explaining differences between configuration
+// versions.
+type Provenance struct {
+	// Units lists every unit that executed, in execution order.
+	Units []UnitProvenance `json:"units,omitempty"`
+
+	// AggregationMethod names the aggregator that selected the winner.
+	AggregationMethod string `json:"aggregation_method,omitempty"`
+
+	// MedianSelection records how a median aggregator derived its median:
+	// "middle" for an odd number of scores, or the configured even-count
+	// method ("interpolate", "lower", or "upper"). Empty for other methods.
+	MedianSelection string `json:"median_selection,omitempty"`
+
+	// ReferenceSignal identifies the deterministic unit whose scores were
+	// blended with the judges' scores as an extra voter. It is nil when
+	// only judge scores were aggregated.
+	ReferenceSignal *ReferenceSignal `json:"reference_signal,omitempty"`
+
+	// AnswerAggregations records, per answer, how an adaptive aggregator
+	// combined the judges' scores. It is empty for other methods.
+	AnswerAggregations []AnswerAggregation `json:"answer_aggregations,omitempty"`
+}
+
+// AnswerAggregation records the method an adaptive aggregator chose for one
+// answer and the judge disagreement that led to the choice.
+type AnswerAggregation struct {
+	// AnswerID identifies the answer.
+	AnswerID string `json:"answer_id"`
+
+	// Method is the method used to combine the judges' scores for the
+	// answer: "mean", "median", or "trimmed_mean".
+	Method string `json:"method"`
+
+	// Variance is the population variance of the judges' scores.
+	Variance float64 `json:"variance"`
+}
+
+// ReferenceSignal records a deterministic score source, such as a fuzzy
+// match against a reference answer, that took part in aggregation
+// alongside LLM judges.
+type ReferenceSignal struct {
+	// Unit is the ID of the deterministic unit that produced the scores.
+	Unit string `json:"unit"`
+
+	// Weight is the share of each aggregated score taken from the unit.
+	Weight float64 `json:"weight"`
+}
+
+// GraphInfo identifies the evaluation graph that produced a verdict, taken
+// from the graph configuration's metadata, so that stored results can be
+// traced back to their graph and filtered or grouped by experiment.
+type GraphInfo struct {
+	// Name is the graph's metadata name.
+	Name string `json:"name"`
+
+	// Version is the graph configuration's version.
+	Version string `json:"version,omitempty"`
+
+	// Fingerprint identifies the exact configuration the graph was loaded
+	// from, distinguishing edits that kept the same name and version.
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// Tags are the graph's metadata tags.
+	Tags []string `json:"tags,omitempty"`
+
+	// Labels are the graph's metadata labels.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// TieBreak records how an aggregator chose among candidates that ranked
+// equally, so that order-dependent outcomes can be audited.
+type TieBreak struct {
+	// Strategy is the tie-breaking strategy that was applied.
+	Strategy string `json:"strategy"`
+
+	// CandidateIndices lists the input indices of the tied candidates.
+	CandidateIndices []int `json:"candidate_indices"`
+
+	// WinnerIndex is the input index of the candidate that won the tie.
+	WinnerIndex int `json:"winner_index"`
+
+	// Reason explains why the winner was chosen.
+	Reason string `json:"reason"`
+}
+
+// WinMatrix records the outcomes of pairwise comparisons among candidate
+// answers, as used by leaderboard-style evaluations. Row and column i refer
+// to the answer AnswerIDs[i].
+type WinMatrix struct {
+	// AnswerIDs identifies the answer of each row and column, in input
+	// order.
+	AnswerIDs []string `json:"answer_ids"`
+
+	// Wins[i][j] counts the comparisons answer i won against answer j. The
+	// comparisons answer i lost against answer j are Wins[j][i].
+	Wins [][]int `json:"wins"`
+
+	// Ties[i][j] counts the comparisons between answers i and j judged a
+	// tie. It is symmetric.
+	Ties [][]int `json:"ties"`
+
+	// RatingMethod names how the ratings in RankedAnswers were derived from
+	// the matrix, e.g. "elo" or "bradley_terry".
+	RatingMethod string `json:"rating_method"`
+}
+
+// NewWinMatrix returns an empty WinMatrix for the answers with the given
+// IDs.
+func NewWinMatrix(answerIDs []string) *WinMatrix {
+	m := &WinMatrix{
+		AnswerIDs: answerIDs,
+		Wins:      make([][]int, len(answerIDs)),
+		Ties:      make([][]int, len(answerIDs)),
+	}
+	for i := range answerIDs {
+		m.Wins[i] = make([]int, len(answerIDs))
+		m.Ties[i] = make([]int, len(answerIDs))
+	}
+	return m
+}
+
+// WinRate returns the share of answer i's comparisons that it won, counting
+// ties as half a win. It returns 0 for an answer that was never compared.
+func (m *WinMatrix) WinRate(i int) float64 {
+	var won float64
+	var played int
+	for j := range m.AnswerIDs {
+		won += float64(m.Wins[i][j]) + float64(m.Ties[i][j])/2
+		played += m.Wins[i][j] + m.Wins[j][i] + m.Ties[i][j]
+	}
+	if played == 0 {
+		return 0
+	}
+	return won / float64(played)
+}
+
+// Conclusion records that a unit settled an item conclusively, so the
+// remaining units of the evaluation need not run.
+type Conclusion struct {
+	// Unit is the ID of the unit that reached the conclusion.
+	Unit string `json:"unit"`
+
+	// AnswerID identifies the answer the unit found conclusive.
+	AnswerID string `json:"answer_id"`
+
+	// Score is the unit's score for that answer.
+	Score float64 `json:"score"`
+
+	// Reason explains why the unit considered the item settled.
+	Reason string `json:"reason"`
+}
+
+// Sample records that an evaluation judged a random subset of the
+// candidate answers rather than all of them. A verdict based on a sample is
+// an approximation: its winner is the best sampled answer, which need not
+// be the best in the pool.
+type Sample struct {
+	// Unit is the ID of the unit that drew the sample.
+	Unit string `json:"unit"`
+
+	// Seed is the seed the sample was drawn with; drawing again with the
+	// same seed from the same pool selects the same answers.
+	Seed int64 `json:"seed"`
+
+	// PoolSize is the number of candidate answers sampled from.
+	PoolSize int `json:"pool_size"`
+
+	// SampleSize is the number of answers kept for evaluation.
+	SampleSize int `json:"sample_size"`
+
+	// UnsampledIDs lists the IDs of the answers left out of the sample, in
+	// their original order.
+	UnsampledIDs []string `json:"unsampled_ids,omitempty"`
+}
+
+// Fraction returns the share of the pool that was sampled, or 0 for an
+// empty pool.
+func (s *Sample) Fraction() float64 {
+	if s.PoolSize == 0 {
+		return 0
+	}
+	return float64(s.SampleSize) / float64(s.PoolSize)
+}
+
+// EarlyExit records that an evaluation stopped before every unit ran
+// because a unit reached a Conclusion.
+type EarlyExit struct {
+	// Conclusion is the conclusion that ended the evaluation.
+	Conclusion Conclusion `json:"conclusion"`
+
+	// SkippedUnits lists, in execution order, the graph's units that did
+	// not run.
+	SkippedUnits []string `json:"skipped_units,omitempty"`
 }
 
 // TraceMeta captures detailed execution metadata for a single judge's
@@ -44,9 +363,27 @@ type JudgeSummary struct {
 	// (0.0 to 1.0).
 	Confidence float64 `json:"confidence"`
 
+	// RawConfidence is the confidence the judge reported before it was
+	// calibrated onto the shared confidence scale; nil when Confidence is
+	// uncalibrated.
+	RawConfidence *float64 `json:"raw_confidence,omitempty"`
+
 	// Score is the numerical score assigned by this judge.
 	// This field tracks individual judge scores for aggregation patterns.
 	Score float64 `json:"score"`
+
+	// Samples is the number of judge completions combined into this
+	// summary when self-consistency sampling is used; zero otherwise.
+	Samples int `json:"samples,omitempty"`
+
+	// Spread is the difference between the highest and lowest sampled
+	// scores, indicating how much the samples disagreed.
+	Spread float64 `json:"spread,omitempty"`
+
+	// Dimensions breaks Score down by quality dimension when the judge
+	// scored answers along several dimensions, keyed by dimension name.
+	// Score is the weighted mean of these values.
+	Dimensions map[string]float64 `json:"dimensions,omitempty"`
 }
 
 // BudgetReport tracks resource consumption across the entire evaluation.
@@ -62,20 +399,86 @@ type BudgetReport struct {
 	CallsMade int `json:"calls_made"`
 }
 
+// VerdictStatus describes the outcome of aggregation.
+type VerdictStatus string
+
+// Verdict statuses set by aggregator units and by judges configured to
+// tolerate items without answers.
+const (
+	// VerdictDecided means the aggregator selected a winner.
+	VerdictDecided VerdictStatus = "decided"
+
+	// VerdictAbstained means the verdict's confidence fell below the
+	// aggregator's abstain threshold, so no winner was selected.
+	VerdictAbstained VerdictStatus = "abstained"
+
+	// VerdictNoAnswers means the item had no candidate answers, so nothing
+	// was judged and there is no winner.
+	VerdictNoAnswers VerdictStatus = "no_answers"
+)
+
 // Verdict represents the final outcome of an evaluation process.
 // It contains the winning answer, aggregate scores, and detailed
 // execution traces.
 type Verdict struct {
+	// SchemaVersion identifies the serialized shape of this verdict.
+	// Producers set it to VerdictSchemaVersion; UnmarshalVerdict uses it to
+	// upgrade older persisted verdicts. Zero denotes pre-versioning data.
+	SchemaVersion int `json:"schema_version"`
+
 	// ID uniquely identifies this verdict (typically a UUID).
 	ID string `json:"id"`
 
 	// WinnerAnswer is the answer that scored highest in the evaluation.
-	// It may be nil if no clear winner could be determined.
+	// It is nil if no clear winner could be determined, including when the
+	// aggregator abstained.
 	WinnerAnswer *Answer `json:"winner_answer,omitempty"`
 
 	// AggregateScore is the final computed score for the winning answer.
 	AggregateScore float64 `json:"aggregate_score"`
 
+	// DuplicateAnswerIDs lists the IDs of all candidates whose content is
+	// identical to the winner's, including the winner itself. It is only
+	// populated when the winner was chosen among content-identical
+	// candidates, signalling that the selection between them was arbitrary.
+	DuplicateAnswerIDs []string `json:"duplicate_answer_ids,omitempty"`
+
+	// RankedAnswers lists every aggregated candidate best-first. The first
+	// entry is always the WinnerAnswer, which is retained for callers that
+	// only need the top result. It is omitted from JSON when empty.
+	RankedAnswers []RankedAnswer `json:"ranked_answers,omitempty"`
+
+	// TieBreak describes how the winner was chosen among equally ranked
+	// candidates. It is nil when there was no tie or the aggregator does
+	// not report tie-breaks.
+	TieBreak *TieBreak `json:"tie_break,omitempty"`
+
+	// WinMatrix records the pairwise comparisons the candidates were ranked
+	// by. It is nil for verdicts from aggregators that score answers
+	// individually.
+	WinMatrix *WinMatrix `json:"win_matrix,omitempty"`
+
+	// EarlyExit records the conclusion that stopped the evaluation before
+	// every unit ran. It is nil when all units ran.
+	EarlyExit *EarlyExit `json:"early_exit,omitempty"`
+
+	// Sample records that the verdict is based on a random subset of the
+	// candidate answers. It is nil when every answer was evaluated.
+	Sample *Sample `json:"sample,omitempty"`
+
+	// Display presents AggregateScore and RankedAnswers on the output scale
+	// configured for the aggregator. It is nil when no output scale is
+	// configured.
+	Display *ScoreDisplay `json:"display,omitempty"`
+
+	// Status reports whether the aggregator decided on a winner or
+	// abstained. It is empty for verdicts from producers that predate it.
+	Status VerdictStatus `json:"status,omitempty"`
+
+	// Confidence measures how decisively the winner was chosen, from 0.0
+	// (a tie) to 1.0. See the aggregator units for how it is computed.
+	Confidence float64 `json:"confidence"`
+
 	// RequiresHumanReview indicates whether the evaluation requires human
 	// review based on confidence thresholds from verification units.
 	// It is omitted from JSON when false to reduce payload size.
@@ -89,8 +492,23 @@ type Verdict struct {
 	// It is omitted from JSON when nil to reduce payload size.
 	Budget *BudgetReport `json:"budget,omitempty"`
 
-	// Timestamp records when this verdict was created.
-	Timestamp time.Time `json:"timestamp"`
+	// Provenance records the units and aggregation method that produced
+	// this verdict. It is omitted from JSON when nil.
+	Provenance *Provenance `json:"provenance,omitempty"`
+
+	// Graph identifies the evaluation graph that produced this verdict. It
+	// is nil for verdicts from graphs not loaded from a configuration.
+	Graph *GraphInfo `json:"graph,omitempty"`
+
+	// CreatedAt records when this verdict was finalized. Aggregators set it
+	// when they produce the verdict and the graph executor updates it once
+	// every unit has run.
+	CreatedAt time.Time `json:"created_at"`
+
+	// Duration is the wall-clock time the graph took to produce this
+	// verdict, serialized in nanoseconds. It is zero for verdicts not
+	// produced by a graph executor.
+	Duration time.Duration `json:"duration,omitempty"`
 }
 
 // ErrorVerdict represents a verdict that indicates an error occurred
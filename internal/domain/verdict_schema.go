@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// VerdictSchemaVersion is the current serialized schema version of Verdict.
+// Increment it whenever the persisted shape changes and register a
+// VerdictMigration from the previous version.
+const VerdictSchemaVersion = 2
+
+// VerdictMigration upgrades a raw, decoded verdict document by exactly one
+// schema version. It receives the JSON object as a generic map and returns
+// the upgraded document; it must not set schema_version itself.
+type VerdictMigration func(doc map[string]any) (map[string]any, error)
+
+var (
+	verdictMigrationsMu sync.RWMutex
+	// verdictMigrations maps a source schema version to the migration that
+	// upgrades it to the next version.
+	verdictMigrations = map[int]VerdictMigration{
+		// Version 0 verdicts predate schema versioning and share the v1 shape.
+		0: func(doc map[string]any) (map[string]any, error) { return doc, nil },
+		// Version 2 renamed "timestamp" to "created_at" and added "duration".
+		1: func(doc map[string]any) (map[string]any, error) {
+			if timestamp, ok := doc["timestamp"]; ok {
+				if _, exists := doc["created_at"]; !exists {
+					doc["created_at"] = timestamp
+				}
+				delete(doc, "timestamp")
+			}
+			return doc, nil
+		},
+	}
+)
+
+// RegisterVerdictMigration registers the migration that upgrades verdicts
+// from fromVersion to fromVersion+1. Registering a version twice replaces the
+// previous migration.
+func RegisterVerdictMigration(fromVersion int, migration VerdictMigration) {
+	verdictMigrationsMu.Lock()
+	defer verdictMigrationsMu.Unlock()
+	verdictMigrations[fromVersion] = migration
+}
+
+// UnmarshalVerdict decodes a persisted verdict, applying registered migrations
+// in sequence to bring older documents up to VerdictSchemaVersion.
+// It returns ErrUnsupportedSchemaVersion if the document is newer than the
+// current schema or if a migration step is missing.
+func UnmarshalVerdict(data []byte) (*Verdict, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode verdict: %w", err)
+	}
+
+	doc, err := MigrateVerdictDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encode migrated verdict: %w", err)
+	}
+
+	var verdict Verdict
+	if err := json.Unmarshal(migrated, &verdict); err != nil {
+		return nil, fmt.Errorf("failed to decode migrated verdict: %w", err)
+	}
+	return &verdict, nil
+}
+
+// MigrateVerdictDocument upgrades a generic verdict document to
+// VerdictSchemaVersion and stamps the resulting schema_version.
+// Documents without a schema_version are treated as version 0. A nil
+// document, such as a decoded JSON null, is rejected, as is a migration
+// that returns one.
+func MigrateVerdictDocument(doc map[string]any) (map[string]any, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("verdict document is null")
+	}
+	version, err := documentSchemaVersion(doc)
+	if err != nil {
+		return nil, err
+	}
+	if version > VerdictSchemaVersion {
+		return nil, fmt.Errorf("%w: verdict version %d is newer than supported version %d",
+			ErrUnsupportedSchemaVersion, version, VerdictSchemaVersion)
+	}
+
+	verdictMigrationsMu.RLock()
+	defer verdictMigrationsMu.RUnlock()
+
+	for v := version; v < VerdictSchemaVersion; v++ {
+		migrate, ok := verdictMigrations[v]
+		if !ok {
+			return nil, fmt.Errorf("%w: no migration registered from verdict version %d",
+				ErrUnsupportedSchemaVersion, v)
+		}
+		if doc, err = migrate(doc); err != nil {
+			return nil, fmt.Errorf("failed to migrate verdict from version %d: %w", v, err)
+		}
+		if doc == nil {
+			return nil, fmt.Errorf("failed to migrate verdict from version %d: migration returned a null document", v)
+		}
+	}
+
+	doc["schema_version"] = VerdictSchemaVersion
+	return doc, nil
+}
+
+// documentSchemaVersion reads the schema_version field from a decoded JSON
+// document, defaulting to 0 when absent.
+func documentSchemaVersion(doc map[string]any) (int, error) {
+	raw, ok := doc["schema_version"]
+	if !ok || raw == nil {
+		return 0, nil
+	}
+	n, ok := raw.(float64)
+	if !ok || n < 0 || n != float64(int(n)) {
+		return 0, fmt.Errorf("%w: invalid schema_version %v", ErrUnsupportedSchemaVersion, raw)
+	}
+	return int(n), nil
+}
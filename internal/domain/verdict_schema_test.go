@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUnmarshalVerdict_CurrentVersion verifies that a verdict serialized with
+// the current schema version round-trips unchanged.
+func TestUnmarshalVerdict_CurrentVersion(t *testing.T) {
+	verdict := Verdict{
+		SchemaVersion:  VerdictSchemaVersion,
+		ID:             "verdict-1",
+		WinnerAnswer:   &Answer{ID: "a1", Content: "4"},
+		AggregateScore: 0.9,
+		CreatedAt:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	data, err := json.Marshal(verdict)
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalVerdict(data)
+	require.NoError(t, err)
+	assert.Equal(t, verdict, *decoded)
+}
+
+// TestUnmarshalVerdict_LegacyVersion verifies that verdicts persisted before
+// schema versioning are upgraded and stamped with the current version.
+func TestUnmarshalVerdict_LegacyVersion(t *testing.T) {
+	legacy := []byte(`{"id":"verdict-old","winner_answer":{"id":"a1","content":"x"},"aggregate_score":0.5,"timestamp":"2024-01-01T00:00:00Z"}`)
+
+	decoded, err := UnmarshalVerdict(legacy)
+	require.NoError(t, err)
+	assert.Equal(t, VerdictSchemaVersion, decoded.SchemaVersion)
+	assert.Equal(t, "verdict-old", decoded.ID)
+	assert.Equal(t, 0.5, decoded.AggregateScore)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), decoded.CreatedAt.UTC())
+}
+
+// TestUnmarshalVerdict_Version1 verifies that version 1 verdicts have their
+// "timestamp" field migrated to CreatedAt.
+func TestUnmarshalVerdict_Version1(t *testing.T) {
+	v1 := []byte(`{"schema_version":1,"id":"verdict-v1","aggregate_score":0.5,"timestamp":"2024-06-01T12:00:00Z"}`)
+
+	decoded, err := UnmarshalVerdict(v1)
+	require.NoError(t, err)
+	assert.Equal(t, VerdictSchemaVersion, decoded.SchemaVersion)
+	assert.Equal(t, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), decoded.CreatedAt.UTC())
+	assert.Zero(t, decoded.Duration)
+}
+
+// TestUnmarshalVerdict_Errors verifies that unsupported or malformed schema
+// versions are rejected with ErrUnsupportedSchemaVersion.
+func TestUnmarshalVerdict_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "future version", data: `{"schema_version": 99, "id": "v"}`},
+		{name: "negative version", data: `{"schema_version": -1, "id": "v"}`},
+		{name: "fractional version", data: `{"schema_version": 1.5, "id": "v"}`},
+		{name: "non-numeric version", data: `{"schema_version": "1", "id": "v"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnmarshalVerdict([]byte(tt.data))
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrUnsupportedSchemaVersion)
+		})
+	}
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := UnmarshalVerdict([]byte(`{`))
+		require.Error(t, err)
+	})
+
+	t.Run("null document", func(t *testing.T) {
+		_, err := UnmarshalVerdict([]byte(`null`))
+		require.ErrorContains(t, err, "verdict document is null")
+	})
+}
+
+// TestRegisterVerdictMigration verifies that registered migrations are applied
+// to documents of the matching source version.
+func TestRegisterVerdictMigration(t *testing.T) {
+	verdictMigrationsMu.RLock()
+	original := verdictMigrations[0]
+	verdictMigrationsMu.RUnlock()
+	t.Cleanup(func() { RegisterVerdictMigration(0, original) })
+
+	// Simulate a legacy field rename: "score" became "aggregate_score".
+	RegisterVerdictMigration(0, func(doc map[string]any) (map[string]any, error) {
+		if score, ok := doc["score"]; ok {
+			doc["aggregate_score"] = score
+			delete(doc, "score")
+		}
+		return doc, nil
+	})
+
+	decoded, err := UnmarshalVerdict([]byte(`{"id":"v","score":0.75}`))
+	require.NoError(t, err)
+	assert.Equal(t, 0.75, decoded.AggregateScore)
+	assert.Equal(t, VerdictSchemaVersion, decoded.SchemaVersion)
+}
+
+// TestMigrateVerdictDocument_NilMigration verifies that a migration returning
+// a nil document fails instead of panicking.
+func TestMigrateVerdictDocument_NilMigration(t *testing.T) {
+	verdictMigrationsMu.RLock()
+	original := verdictMigrations[0]
+	verdictMigrationsMu.RUnlock()
+	t.Cleanup(func() { RegisterVerdictMigration(0, original) })
+
+	RegisterVerdictMigration(0, func(map[string]any) (map[string]any, error) { return nil, nil })
+
+	_, err := MigrateVerdictDocument(map[string]any{"id": "v"})
+	require.ErrorContains(t, err, "migration returned a null document")
+}
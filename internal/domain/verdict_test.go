@@ -28,6 +28,58 @@ func TestAnswer_JSON(t *testing.T) {
 	assert.Equal(t, answer.Content, decoded.Content, "Answer Content mismatch.")
 }
 
+// TestImage_Validate verifies that an image needs exactly one of a URL and
+// inline data, and an image media type with inline data.
+func TestImage_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		image   Image
+		wantErr bool
+	}{
+		{name: "url", image: Image{URL: "https://example.com/chart.png"}},
+		{name: "url with media type", image: Image{URL: "https://example.com/chart", MediaType: "image/png"}},
+		{name: "inline data", image: Image{Data: []byte{0x89, 'P', 'N', 'G'}, MediaType: "image/png"}},
+		{name: "empty", image: Image{}, wantErr: true},
+		{name: "url and data", image: Image{URL: "https://example.com/a.png", Data: []byte{1}, MediaType: "image/png"}, wantErr: true},
+		{name: "data without media type", image: Image{Data: []byte{1}}, wantErr: true},
+		{name: "non-image media type", image: Image{Data: []byte{1}, MediaType: "application/pdf"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.image.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidImage)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// TestAnswer_ImagesJSON verifies that answer images round-trip through
+// JSON and are omitted when absent.
+func TestAnswer_ImagesJSON(t *testing.T) {
+	answer := Answer{
+		ID:      "answer-1",
+		Content: "See the chart.",
+		Images: []Image{
+			{URL: "https://example.com/chart.png"},
+			{Data: []byte{0x89, 'P', 'N', 'G'}, MediaType: "image/png"},
+		},
+	}
+
+	data, err := json.Marshal(answer)
+	require.NoError(t, err)
+	var decoded Answer
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, answer, decoded)
+
+	data, err = json.Marshal(Answer{ID: "answer-2", Content: "text"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "images")
+}
+
 // TestTraceMeta_JSON verifies that the TraceMeta struct is correctly
 // handled during JSON serialization and deserialization, including field name conventions.
 func TestTraceMeta_JSON(t *testing.T) {
@@ -133,7 +185,7 @@ func TestVerdict_JSON(t *testing.T) {
 			TokensUsed: 1700,
 			CallsMade:  2,
 		},
-		Timestamp: now,
+		CreatedAt: now,
 	}
 
 	data, err := json.Marshal(verdict)
@@ -154,7 +206,7 @@ func TestVerdict_JSON(t *testing.T) {
 	require.NotNil(t, decoded.Budget, "Verdict Budget should not be nil.")
 	assert.Equal(t, verdict.Budget.TotalSpent, decoded.Budget.TotalSpent, "Verdict Budget TotalSpent mismatch.")
 
-	assert.Equal(t, now.Unix(), decoded.Timestamp.Unix(), "Verdict Timestamp mismatch.")
+	assert.Equal(t, now.Unix(), decoded.CreatedAt.Unix(), "Verdict CreatedAt mismatch.")
 }
 
 // TestVerdict_OmitEmpty verifies that optional fields in the Verdict struct
@@ -166,7 +218,7 @@ func TestVerdict_OmitEmpty(t *testing.T) {
 		AggregateScore: 0.0,
 		Trace:          nil,
 		Budget:         nil,
-		Timestamp:      time.Now(),
+		CreatedAt:      time.Now(),
 	}
 
 	data, err := json.Marshal(verdict)
@@ -184,6 +236,9 @@ func TestVerdict_OmitEmpty(t *testing.T) {
 
 	_, exists = jsonMap["budget"]
 	assert.False(t, exists, "Verdict JSON should omit a nil budget.")
+
+	_, exists = jsonMap["duration"]
+	assert.False(t, exists, "Verdict JSON should omit a zero duration.")
 }
 
 // TestJudgeSummary_Validation verifies the validation logic for the Confidence
@@ -0,0 +1,126 @@
+// Package latency records per-call latencies and reports percentile
+// summaries. It backs the p50/p95/p99 assertions in unit benchmarks and can
+// be enabled at runtime to observe provider call latency.
+package latency
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Recorder collects latency samples. It is safe for concurrent use; the
+// zero value is ready to use.
+type Recorder struct {
+	mu      sync.Mutex
+	samples []time.Duration
+}
+
+// NewRecorder returns a Recorder with capacity preallocated for sizeHint
+// samples, avoiding reallocation inside tight benchmark loops.
+func NewRecorder(sizeHint int) *Recorder {
+	return &Recorder{samples: make([]time.Duration, 0, max(sizeHint, 0))}
+}
+
+// Record adds a single latency sample.
+func (r *Recorder) Record(d time.Duration) {
+	r.mu.Lock()
+	r.samples = append(r.samples, d)
+	r.mu.Unlock()
+}
+
+// Time runs fn, records how long it took, and returns fn's error.
+func (r *Recorder) Time(fn func() error) error {
+	start := time.Now()
+	err := fn()
+	r.Record(time.Since(start))
+	return err
+}
+
+// Count returns the number of recorded samples.
+func (r *Recorder) Count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.samples)
+}
+
+// Reset discards all recorded samples.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.samples = r.samples[:0]
+	r.mu.Unlock()
+}
+
+// Percentile returns the p-th percentile (0-100] of the recorded samples
+// using the nearest-rank method. It returns zero when nothing is recorded.
+func (r *Recorder) Percentile(p float64) time.Duration {
+	return Percentile(r.sorted(), p)
+}
+
+// Summary returns the distribution of the recorded samples.
+func (r *Recorder) Summary() Summary {
+	sorted := r.sorted()
+	if len(sorted) == 0 {
+		return Summary{}
+	}
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	return Summary{
+		Count: len(sorted),
+		Mean:  total / time.Duration(len(sorted)),
+		P50:   Percentile(sorted, 50),
+		P95:   Percentile(sorted, 95),
+		P99:   Percentile(sorted, 99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// CheckSLO returns an error if the p-th percentile exceeds limit, so
+// benchmarks and CI checks can assert latency objectives.
+func (r *Recorder) CheckSLO(p float64, limit time.Duration) error {
+	if got := r.Percentile(p); got > limit {
+		return fmt.Errorf("p%g latency %v exceeds %v", p, got, limit)
+	}
+	return nil
+}
+
+// sorted returns a sorted copy of the samples.
+func (r *Recorder) sorted() []time.Duration {
+	r.mu.Lock()
+	sorted := slices.Clone(r.samples)
+	r.mu.Unlock()
+	slices.Sort(sorted)
+	return sorted
+}
+
+// Percentile returns the p-th percentile (0-100] of sorted, which must be in
+// ascending order, using the nearest-rank method: the smallest sample such
+// that at least p percent of samples are less than or equal to it.
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	rank = min(max(rank, 1), len(sorted))
+	return sorted[rank-1]
+}
+
+// Summary describes a latency distribution.
+type Summary struct {
+	Count int
+	Mean  time.Duration
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+// String formats the summary on a single line for logs and benchmark output.
+func (s Summary) String() string {
+	return fmt.Sprintf("n=%d mean=%v p50=%v p95=%v p99=%v max=%v", s.Count, s.Mean, s.P50, s.P95, s.P99, s.Max)
+}
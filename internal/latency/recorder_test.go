@@ -0,0 +1,101 @@
+package latency
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPercentile tests nearest-rank percentiles, including bounds and
+// empty input.
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	tests := []struct {
+		name     string
+		samples  []time.Duration
+		p        float64
+		expected time.Duration
+	}{
+		{name: "p50", samples: sorted, p: 50, expected: 50 * time.Millisecond},
+		{name: "p95", samples: sorted, p: 95, expected: 95 * time.Millisecond},
+		{name: "p99", samples: sorted, p: 99, expected: 99 * time.Millisecond},
+		{name: "p100", samples: sorted, p: 100, expected: 100 * time.Millisecond},
+		{name: "p0 clamps to minimum", samples: sorted, p: 0, expected: time.Millisecond},
+		{name: "single sample", samples: sorted[:1], p: 95, expected: time.Millisecond},
+		{name: "small set rounds up", samples: sorted[:10], p: 95, expected: 10 * time.Millisecond},
+		{name: "empty", samples: nil, p: 95, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Percentile(tt.samples, tt.p))
+		})
+	}
+}
+
+// TestRecorder_Summary tests that unordered samples are sorted before
+// percentiles are computed and that the summary reports the distribution.
+func TestRecorder_Summary(t *testing.T) {
+	r := NewRecorder(4)
+	assert.Equal(t, Summary{}, r.Summary())
+
+	for _, ms := range []int{40, 10, 30, 20} {
+		r.Record(time.Duration(ms) * time.Millisecond)
+	}
+
+	s := r.Summary()
+	assert.Equal(t, 4, s.Count)
+	assert.Equal(t, 25*time.Millisecond, s.Mean)
+	assert.Equal(t, 20*time.Millisecond, s.P50)
+	assert.Equal(t, 40*time.Millisecond, s.P95)
+	assert.Equal(t, 40*time.Millisecond, s.P99)
+	assert.Equal(t, 40*time.Millisecond, s.Max)
+	assert.Contains(t, s.String(), "n=4")
+	assert.Contains(t, s.String(), "p95=40ms")
+
+	r.Reset()
+	assert.Zero(t, r.Count())
+}
+
+// TestRecorder_TimeAndSLO tests timing a function, error propagation, and
+// SLO checks.
+func TestRecorder_TimeAndSLO(t *testing.T) {
+	var r Recorder
+	want := errors.New("boom")
+	err := r.Time(func() error {
+		time.Sleep(2 * time.Millisecond)
+		return want
+	})
+	require.ErrorIs(t, err, want)
+	require.Equal(t, 1, r.Count())
+	assert.GreaterOrEqual(t, r.Percentile(95), 2*time.Millisecond)
+
+	assert.NoError(t, r.CheckSLO(95, time.Second))
+	assert.ErrorContains(t, r.CheckSLO(95, time.Millisecond), "p95 latency")
+}
+
+// TestRecorder_Concurrent tests that samples recorded from multiple
+// goroutines are all retained.
+func TestRecorder_Concurrent(t *testing.T) {
+	r := NewRecorder(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				r.Record(time.Microsecond)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 800, r.Count())
+}
@@ -3,6 +3,8 @@ package ports
 import (
 	"context"
 	"time"
+
+	"github.com/ahrav/go-gavel/internal/domain"
 )
 
 // LLMClient defines the interface for interacting with Large Language
@@ -24,6 +26,7 @@ type LLMClient interface {
 	//   - "temperature": float64 (0.0-1.0)
 	//   - "max_tokens": int
 	//   - "model": string (specific model version)
+	//   - "images": []domain.Image (attached to the prompt for multimodal models)
 	Complete(ctx context.Context, prompt string, options map[string]any) (string, error)
 
 	// CompleteWithUsage sends a completion request to the LLM provider and
@@ -55,6 +58,25 @@ type LLMClient interface {
 	GetModel() string
 }
 
+// EmbeddingClient defines the interface for providers that convert text into
+// dense vector embeddings.
+// Implementations should handle provider-specific batching limits,
+// authentication, and retries.
+type EmbeddingClient interface {
+	// Embed returns one embedding vector per input text, in input order.
+	// All vectors returned by a single client must share the same
+	// dimensionality so they can be compared with each other.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation and deadline propagation
+	//   - texts: The batch of texts to embed in a single request
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+
+	// GetModel returns the embedding model identifier being used.
+	// Embeddings from different models are not comparable.
+	GetModel() string
+}
+
 // CacheStore defines the interface for caching evaluation results.
 // Implementations could use Redis, Memcached, or in-memory storage.
 // Caching is optional but can significantly reduce costs for repeated
@@ -103,6 +125,17 @@ type MetricsCollector interface {
 	RecordHistogram(metric string, value float64, labels map[string]string)
 }
 
+// ResultSink receives verdicts as batch evaluation completes them, so runs
+// can stream results to a database or message queue instead of holding them
+// in memory. Implementations could write JSON lines, insert rows, or publish
+// to Kafka. Emit may be called concurrently from several goroutines.
+type ResultSink interface {
+	// Emit delivers the verdict of the item identified by itemID.
+	// A returned error is reported with the item's result; it does not
+	// stop the batch.
+	Emit(ctx context.Context, itemID string, verdict *domain.Verdict) error
+}
+
 // ConfigLoader defines the interface for loading configuration.
 // Implementations could read from files, environment variables,
 // remote configuration services, or a combination of sources.
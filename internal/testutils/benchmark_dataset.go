@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/ahrav/go-gavel/internal/domain"
@@ -22,7 +23,7 @@ type BenchmarkDataset struct {
 }
 
 // BenchmarkQuestion represents a single question in the benchmark dataset
-// with multiple candidate answers and a known correct answer.
+// with multiple candidate answers and one or more known correct answers.
 type BenchmarkQuestion struct {
 	// ID uniquely identifies this question in the dataset.
 	ID string `json:"id"`
@@ -36,6 +37,11 @@ type BenchmarkQuestion struct {
 	// GroundTruthID identifies which answer ID is correct.
 	GroundTruthID string `json:"ground_truth_answer_id"`
 
+	// AcceptableIDs lists further answer IDs that are also correct, such
+	// as paraphrases of the ground truth. A prediction of any of them, or
+	// of GroundTruthID, counts as correct.
+	AcceptableIDs []string `json:"acceptable_answer_ids,omitempty"`
+
 	// Domain categorizes the question (e.g., "science", "history").
 	Domain string `json:"domain,omitempty"`
 
@@ -43,6 +49,12 @@ type BenchmarkQuestion struct {
 	Difficulty string `json:"difficulty,omitempty"`
 }
 
+// IsCorrect reports whether answerID is the ground truth or one of the
+// acceptable answers of q.
+func (q *BenchmarkQuestion) IsCorrect(answerID string) bool {
+	return answerID != "" && (answerID == q.GroundTruthID || slices.Contains(q.AcceptableIDs, answerID))
+}
+
 // DatasetMetadata contains information about the benchmark dataset itself,
 // including licensing and provenance information.
 type DatasetMetadata struct {
@@ -128,24 +140,8 @@ func ValidateBenchmarkDataset(dataset *BenchmarkDataset) error {
 		}
 		seenIDs[q.ID] = true
 
-		// Validate ground truth exists in answers
-		found := false
-		for _, ans := range q.Answers {
-			if ans.ID == q.GroundTruthID {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return fmt.Errorf("question %s: ground truth ID %s not found in answers", q.ID, q.GroundTruthID)
-		}
-
-		seenContent := make(map[string]bool)
-		for _, ans := range q.Answers {
-			if seenContent[ans.Content] {
-				return fmt.Errorf("question %s has duplicate answer content: %s", q.ID, ans.Content)
-			}
-			seenContent[ans.Content] = true
+		if err := validateQuestionAnswers(&q); err != nil {
+			return err
 		}
 	}
 
@@ -224,6 +220,33 @@ func validateQuestion(q *BenchmarkQuestion, index int) error {
 	return nil
 }
 
+// validateQuestionAnswers checks that a question's ground truth and
+// acceptable answers exist among its answers and that no two answers share
+// the same content.
+func validateQuestionAnswers(q *BenchmarkQuestion) error {
+	hasAnswer := func(id string) bool {
+		return slices.ContainsFunc(q.Answers, func(ans domain.Answer) bool { return ans.ID == id })
+	}
+	if !hasAnswer(q.GroundTruthID) {
+		return fmt.Errorf("question %s: ground truth ID %s not found in answers", q.ID, q.GroundTruthID)
+	}
+	for _, id := range q.AcceptableIDs {
+		if !hasAnswer(id) {
+			return fmt.Errorf("question %s: acceptable answer ID %s not found in answers", q.ID, id)
+		}
+	}
+
+	seenContent := make(map[string]bool)
+	for _, ans := range q.Answers {
+		if seenContent[ans.Content] {
+			return fmt.Errorf("question %s has duplicate answer content: %s", q.ID, ans.Content)
+		}
+		seenContent[ans.Content] = true
+	}
+
+	return nil
+}
+
 // DatasetStatistics provides summary statistics about a benchmark dataset.
 type DatasetStatistics struct {
 	// TotalQuestions is the number of questions in the dataset.
@@ -0,0 +1,265 @@
+package testutils
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DatasetFormat identifies the on-disk encoding of a benchmark dataset.
+type DatasetFormat int
+
+const (
+	// DatasetFormatJSON is the single-document format written by
+	// SaveBenchmarkDataset: an object with "questions" and "metadata" fields.
+	DatasetFormatJSON DatasetFormat = iota
+
+	// DatasetFormatJSONL stores one BenchmarkQuestion per line (JSON Lines).
+	// It has no metadata and can be appended to incrementally.
+	DatasetFormatJSONL
+)
+
+// BenchmarkDatasetStream reads benchmark questions one at a time so that
+// large datasets can be evaluated with bounded memory. Each question is
+// validated as it is read; dataset-wide checks that require every question
+// (minimum size, answer distribution) are not performed.
+// A stream is not safe for concurrent use.
+type BenchmarkDatasetStream struct {
+	dec    *json.Decoder
+	closer io.Closer
+	format DatasetFormat
+
+	// started and inQuestions track the position within a
+	// DatasetFormatJSON document; inQuestions is true while positioned
+	// inside its "questions" array.
+	started     bool
+	inQuestions bool
+	done        bool
+
+	metadata    DatasetMetadata
+	hasMetadata bool
+
+	// count is the number of questions returned so far.
+	count int
+	// seenIDs detects duplicate question IDs across the stream. Only IDs
+	// are retained, so memory grows far slower than the dataset itself.
+	seenIDs map[string]struct{}
+}
+
+// OpenBenchmarkDatasetStream opens the dataset at path for streaming.
+// Files ending in ".jsonl" or ".ndjson" are read as JSON Lines; all others
+// are read as the standard JSON document format. Callers must Close the
+// returned stream.
+func OpenBenchmarkDatasetStream(path string) (*BenchmarkDatasetStream, error) {
+	f, err := os.Open(path) //nolint:gosec // Controlled file access in test utilities
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dataset file: %w", err)
+	}
+
+	format := DatasetFormatJSON
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		format = DatasetFormatJSONL
+	}
+
+	stream := NewBenchmarkDatasetStream(f, format)
+	stream.closer = f
+	return stream, nil
+}
+
+// NewBenchmarkDatasetStream creates a stream that reads questions in the
+// given format from r. The caller retains ownership of r.
+func NewBenchmarkDatasetStream(r io.Reader, format DatasetFormat) *BenchmarkDatasetStream {
+	return &BenchmarkDatasetStream{
+		dec:     json.NewDecoder(bufio.NewReader(r)),
+		format:  format,
+		seenIDs: make(map[string]struct{}),
+	}
+}
+
+// Next returns the next validated question in the dataset.
+// It returns io.EOF once every question has been read.
+func (s *BenchmarkDatasetStream) Next() (BenchmarkQuestion, error) {
+	if s.done {
+		return BenchmarkQuestion{}, io.EOF
+	}
+
+	var (
+		q   BenchmarkQuestion
+		err error
+	)
+	switch s.format {
+	case DatasetFormatJSONL:
+		err = s.dec.Decode(&q)
+	default:
+		q, err = s.nextFromDocument()
+	}
+	if errors.Is(err, io.EOF) {
+		s.done = true
+		return BenchmarkQuestion{}, io.EOF
+	}
+	if err != nil {
+		return BenchmarkQuestion{}, fmt.Errorf("failed to parse question %d: %w", s.count, err)
+	}
+
+	if err := s.validate(&q); err != nil {
+		return BenchmarkQuestion{}, fmt.Errorf("question %d validation failed: %w", s.count, err)
+	}
+	s.count++
+	return q, nil
+}
+
+// Skip discards the next n questions, validating them as they are read.
+// It is used to resume an interrupted evaluation from a known position.
+// Skip returns io.EOF if the dataset ends before n questions are skipped.
+func (s *BenchmarkDatasetStream) Skip(n int) error {
+	for i := 0; i < n; i++ {
+		if _, err := s.Next(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Count returns the number of questions read so far, including skipped
+// questions. It can be recorded as a checkpoint for resuming with Skip.
+func (s *BenchmarkDatasetStream) Count() int { return s.count }
+
+// Metadata returns the dataset metadata if it has been read.
+// For DatasetFormatJSON, metadata that follows the questions array is only
+// available once the stream is exhausted. JSON Lines datasets have none.
+func (s *BenchmarkDatasetStream) Metadata() (DatasetMetadata, bool) {
+	return s.metadata, s.hasMetadata
+}
+
+// Close releases the underlying file when the stream was created with
+// OpenBenchmarkDatasetStream. It is a no-op otherwise.
+func (s *BenchmarkDatasetStream) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// validate applies the per-question checks used by ValidateBenchmarkDataset
+// and rejects IDs already seen earlier in the stream.
+func (s *BenchmarkDatasetStream) validate(q *BenchmarkQuestion) error {
+	if err := validateQuestion(q, s.count); err != nil {
+		return err
+	}
+	if _, seen := s.seenIDs[q.ID]; seen {
+		return fmt.Errorf("duplicate question ID: %s", q.ID)
+	}
+	s.seenIDs[q.ID] = struct{}{}
+	return validateQuestionAnswers(q)
+}
+
+// nextFromDocument advances through a DatasetFormatJSON document token by
+// token, decoding metadata when encountered and returning the next element
+// of the "questions" array. Unknown top-level fields are skipped.
+func (s *BenchmarkDatasetStream) nextFromDocument() (BenchmarkQuestion, error) {
+	var q BenchmarkQuestion
+
+	if !s.started {
+		if err := expectDelim(s.dec, '{'); err != nil {
+			return q, err
+		}
+		s.started = true
+	}
+
+	for {
+		if s.inQuestions {
+			if s.dec.More() {
+				err := s.dec.Decode(&q)
+				return q, err
+			}
+			// Consume the closing ']' of the questions array.
+			if err := expectDelim(s.dec, ']'); err != nil {
+				return q, err
+			}
+			s.inQuestions = false
+		}
+
+		if !s.dec.More() {
+			// Consume the closing '}' of the document.
+			if err := expectDelim(s.dec, '}'); err != nil {
+				return q, err
+			}
+			return q, io.EOF
+		}
+
+		tok, err := s.dec.Token()
+		if err != nil {
+			return q, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return q, fmt.Errorf("unexpected token %v in dataset document", tok)
+		}
+
+		switch key {
+		case "questions":
+			if err := expectDelim(s.dec, '['); err != nil {
+				return q, err
+			}
+			s.inQuestions = true
+		case "metadata":
+			if err := s.dec.Decode(&s.metadata); err != nil {
+				return q, fmt.Errorf("failed to parse metadata: %w", err)
+			}
+			s.hasMetadata = true
+		default:
+			var skip json.RawMessage
+			if err := s.dec.Decode(&skip); err != nil {
+				return q, err
+			}
+		}
+	}
+}
+
+// expectDelim reads the next token and verifies it is the given delimiter.
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expected %q in dataset document, found %v", want, tok)
+	}
+	return nil
+}
+
+// SaveBenchmarkDatasetJSONL writes the dataset's questions to path in JSON
+// Lines format, one question per line. Metadata is not written.
+func SaveBenchmarkDatasetJSONL(dataset *BenchmarkDataset, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600) //nolint:gosec // Controlled file access in test utilities
+	if err != nil {
+		return fmt.Errorf("failed to create dataset file: %w", err)
+	}
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for i := range dataset.Questions {
+		if err := enc.Encode(&dataset.Questions[i]); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("failed to write question %d: %w", i, err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write dataset file: %w", err)
+	}
+	return f.Close()
+}
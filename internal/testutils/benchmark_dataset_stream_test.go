@@ -0,0 +1,156 @@
+package testutils
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drainStream reads every question from the stream and returns their IDs.
+func drainStream(t *testing.T, stream *BenchmarkDatasetStream) []string {
+	t.Helper()
+	var ids []string
+	for {
+		q, err := stream.Next()
+		if errors.Is(err, io.EOF) {
+			return ids
+		}
+		require.NoError(t, err)
+		ids = append(ids, q.ID)
+	}
+}
+
+// TestBenchmarkDatasetStream_Formats tests that both the JSON document and
+// JSON Lines formats stream the same questions in order.
+func TestBenchmarkDatasetStream_Formats(t *testing.T) {
+	dataset := &BenchmarkDataset{
+		Questions: createValidQuestions(5),
+		Metadata: DatasetMetadata{
+			Name: "Stream Test", Version: "1.0", License: "MIT", Source: "unit test", Size: 5,
+		},
+	}
+	dir := t.TempDir()
+	want := []string{"q0", "q1", "q2", "q3", "q4"}
+
+	t.Run("json document", func(t *testing.T) {
+		path := filepath.Join(dir, "dataset.json")
+		require.NoError(t, SaveBenchmarkDataset(dataset, path))
+
+		stream, err := OpenBenchmarkDatasetStream(path)
+		require.NoError(t, err)
+		defer stream.Close()
+
+		assert.Equal(t, want, drainStream(t, stream))
+		assert.Equal(t, 5, stream.Count())
+
+		meta, ok := stream.Metadata()
+		require.True(t, ok, "metadata should be available after the stream is exhausted")
+		assert.Equal(t, dataset.Metadata, meta)
+	})
+
+	t.Run("json lines", func(t *testing.T) {
+		path := filepath.Join(dir, "dataset.jsonl")
+		require.NoError(t, SaveBenchmarkDatasetJSONL(dataset, path))
+
+		stream, err := OpenBenchmarkDatasetStream(path)
+		require.NoError(t, err)
+		defer stream.Close()
+
+		assert.Equal(t, want, drainStream(t, stream))
+		_, ok := stream.Metadata()
+		assert.False(t, ok)
+	})
+
+	t.Run("metadata before questions", func(t *testing.T) {
+		doc := `{"metadata": {"name": "m"}, "extra": [1, 2], "questions": [` +
+			`{"id": "q1", "question": "Q", "ground_truth_answer_id": "a1",` +
+			` "candidate_answers": [{"id": "a1", "content": "x"}, {"id": "a2", "content": "y"}]}]}`
+		stream := NewBenchmarkDatasetStream(strings.NewReader(doc), DatasetFormatJSON)
+
+		q, err := stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "q1", q.ID)
+
+		meta, ok := stream.Metadata()
+		require.True(t, ok)
+		assert.Equal(t, "m", meta.Name)
+
+		_, err = stream.Next()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+}
+
+// TestBenchmarkDatasetStream_Skip tests resuming from a checkpoint by
+// skipping questions that were already evaluated.
+func TestBenchmarkDatasetStream_Skip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dataset.jsonl")
+	require.NoError(t, SaveBenchmarkDatasetJSONL(&BenchmarkDataset{Questions: createValidQuestions(4)}, path))
+
+	stream, err := OpenBenchmarkDatasetStream(path)
+	require.NoError(t, err)
+	defer stream.Close()
+
+	require.NoError(t, stream.Skip(2))
+	assert.Equal(t, 2, stream.Count())
+	assert.Equal(t, []string{"q2", "q3"}, drainStream(t, stream))
+
+	assert.ErrorIs(t, stream.Skip(1), io.EOF)
+}
+
+// TestBenchmarkDatasetStream_Validation tests that invalid questions are
+// reported with their position as they are read.
+func TestBenchmarkDatasetStream_Validation(t *testing.T) {
+	tests := []struct {
+		name      string
+		questions []BenchmarkQuestion
+		errorMsg  string
+	}{
+		{
+			name:      "duplicate question ID",
+			questions: createQuestionsWithDuplicateID(3),
+			errorMsg:  "question 1 validation failed: duplicate question ID: q0",
+		},
+		{
+			name:      "invalid ground truth",
+			questions: createQuestionsWithInvalidGroundTruth(2),
+			errorMsg:  "ground truth ID invalid not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "dataset.jsonl")
+			require.NoError(t, SaveBenchmarkDatasetJSONL(&BenchmarkDataset{Questions: tt.questions}, path))
+
+			stream, err := OpenBenchmarkDatasetStream(path)
+			require.NoError(t, err)
+			defer stream.Close()
+
+			for {
+				_, err = stream.Next()
+				if err != nil {
+					break
+				}
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+
+	t.Run("malformed document", func(t *testing.T) {
+		stream := NewBenchmarkDatasetStream(strings.NewReader(`{"questions": [{"id": `), DatasetFormatJSON)
+		_, err := stream.Next()
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := OpenBenchmarkDatasetStream(filepath.Join(t.TempDir(), "missing.jsonl"))
+		require.Error(t, err)
+	})
+}
@@ -541,6 +541,34 @@ func TestDuplicateAnswerContentValidation(t *testing.T) {
 	assert.Contains(t, err.Error(), "duplicate answer content")
 }
 
+// TestAcceptableAnswers verifies that any acceptable answer counts as
+// correct and that acceptable IDs must name candidate answers.
+func TestAcceptableAnswers(t *testing.T) {
+	question := BenchmarkQuestion{GroundTruthID: "a1", AcceptableIDs: []string{"a3"}}
+	assert.True(t, question.IsCorrect("a1"))
+	assert.True(t, question.IsCorrect("a3"))
+	assert.False(t, question.IsCorrect("a2"))
+	assert.False(t, question.IsCorrect(""))
+
+	dataset := &BenchmarkDataset{
+		Metadata: DatasetMetadata{
+			Name:    "Test Dataset",
+			Version: "1.0",
+			License: "MIT",
+			Source:  "test",
+			Size:    500,
+		},
+		Questions: createValidQuestions(500),
+	}
+	dataset.Questions[0].AcceptableIDs = []string{dataset.Questions[0].Answers[1].ID}
+	require.NoError(t, ValidateBenchmarkDataset(dataset))
+
+	dataset.Questions[0].AcceptableIDs = []string{"missing"}
+	err := ValidateBenchmarkDataset(dataset)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "acceptable answer ID missing not found")
+}
+
 // TestAnswerDistributionValidation verifies that questions have a valid number of answers.
 func TestAnswerDistributionValidation(t *testing.T) {
 	dataset := &BenchmarkDataset{
@@ -5,10 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
-	"regexp"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/ahrav/go-gavel/internal/domain"
 )
 
 // BenchmarkMockLLMClient is a specialized mock LLM client for benchmark testing.
@@ -16,8 +17,9 @@ import (
 type BenchmarkMockLLMClient struct {
 	*MockLLMClient
 
-	// groundTruthMap maps question IDs to their correct answer IDs
-	groundTruthMap map[string]string
+	// questions maps question IDs to their questions, which know the
+	// correct answer IDs
+	questions map[string]*BenchmarkQuestion
 
 	// questionAnswerMap maps question content to answer content and IDs
 	questionAnswerMap map[string]map[string]string // question -> answer content -> answer ID
@@ -79,13 +81,13 @@ const (
 func NewBenchmarkMockLLMClient(
 	model string, dataset *BenchmarkDataset, personality JudgePersonality,
 ) *BenchmarkMockLLMClient {
-	groundTruthMap := make(map[string]string)
+	questions := make(map[string]*BenchmarkQuestion, len(dataset.Questions))
 	questionAnswerMap := make(map[string]map[string]string)
 	answerToQuestion := make(map[string]string)
 	questionContentToID := make(map[string]string)
 
-	for _, q := range dataset.Questions {
-		groundTruthMap[q.ID] = q.GroundTruthID
+	for i, q := range dataset.Questions {
+		questions[q.ID] = &dataset.Questions[i]
 		questionContentToID[q.Question] = q.ID
 
 		answerMap := make(map[string]string)
@@ -106,7 +108,7 @@ func NewBenchmarkMockLLMClient(
 
 	return &BenchmarkMockLLMClient{
 		MockLLMClient:       NewMockLLMClient(model),
-		groundTruthMap:      groundTruthMap,
+		questions:           questions,
 		questionAnswerMap:   questionAnswerMap,
 		answerToQuestion:    answerToQuestion,
 		questionContentToID: questionContentToID,
@@ -245,41 +247,26 @@ func (m *BenchmarkMockLLMClient) CompleteWithUsage(
 	return response, tokensIn, tokensOut, nil
 }
 
-// extractQuestionAndAnswer uses regex to robustly extract question and answer from prompt text.
+// extractQuestionAndAnswer reads the question and answer from the
+// domain.PromptQuestionTag and domain.PromptAnswerTag blocks that judge
+// prompts delimit them with. Either is empty when the prompt lacks its
+// block.
 func (m *BenchmarkMockLLMClient) extractQuestionAndAnswer(prompt string) (questionContent, answerContent string) {
-	// Define regex patterns for more flexible matching
-	// These patterns handle various formats and are case-insensitive
-	questionRegex := regexp.MustCompile(`(?is)question:\s*(.+?)(?:\n\s*answer:|$)`)
-	answerRegex := regexp.MustCompile(`(?is)answer:\s*(.+?)(?:\n\n|consider|score|provide|evaluate|$)`)
-
-	// Extract question content
-	if matches := questionRegex.FindStringSubmatch(prompt); len(matches) > 1 {
-		questionContent = strings.TrimSpace(matches[1])
-	}
-
-	// Extract answer content
-	if matches := answerRegex.FindStringSubmatch(prompt); len(matches) > 1 {
-		answerContent = strings.TrimSpace(matches[1])
-	}
+	return taggedBlock(prompt, domain.PromptQuestionTag), taggedBlock(prompt, domain.PromptAnswerTag)
+}
 
-	// Fallback to simpler patterns if needed
-	if questionContent == "" {
-		// Try simpler pattern for question
-		simpleQuestionRegex := regexp.MustCompile(`(?i)(?:q:|question:)\s*([^\n]+)`)
-		if matches := simpleQuestionRegex.FindStringSubmatch(prompt); len(matches) > 1 {
-			questionContent = strings.TrimSpace(matches[1])
-		}
+// taggedBlock returns the trimmed text between the first <tag> in prompt
+// and the </tag> that follows it, or "" if there is no such block.
+func taggedBlock(prompt, tag string) string {
+	_, rest, ok := strings.Cut(prompt, "<"+tag+">")
+	if !ok {
+		return ""
 	}
-
-	if answerContent == "" {
-		// Try simpler pattern for answer
-		simpleAnswerRegex := regexp.MustCompile(`(?i)(?:a:|answer:)\s*([^\n]+)`)
-		if matches := simpleAnswerRegex.FindStringSubmatch(prompt); len(matches) > 1 {
-			answerContent = strings.TrimSpace(matches[1])
-		}
+	content, _, ok := strings.Cut(rest, "</"+tag+">")
+	if !ok {
+		return ""
 	}
-
-	return questionContent, answerContent
+	return strings.TrimSpace(content)
 }
 
 // calculateBaseScore returns a base score based on whether the answer is correct.
@@ -296,16 +283,16 @@ func (m *BenchmarkMockLLMClient) calculateBaseScore(questionContent, answerConte
 		return 0.35
 	}
 
-	// Step 2: Get the correct answer ID for this question
-	correctAnswerID := m.groundTruthMap[questionID]
+	// Step 2: Get the question, which knows its correct answer IDs
+	question := m.questions[questionID]
 
 	// Step 3: Check if the provided answer is correct
 	isCorrect := false
-	if answerID != "" && answerID == correctAnswerID {
+	if question.IsCorrect(answerID) {
 		isCorrect = true
 	} else if answerMap, exists := m.questionAnswerMap[questionContent]; exists {
 		for content, id := range answerMap {
-			if content == answerContent && id == correctAnswerID {
+			if content == answerContent && question.IsCorrect(id) {
 				isCorrect = true
 				break
 			}
@@ -45,7 +45,7 @@ func TestBenchmarkMockLLMClientThreadSafety(t *testing.T) {
 						question := dataset.Questions[questionIdx]
 
 						prompt := fmt.Sprintf(
-							"Rate this answer to the question on a scale from 0.0 to 1.0:\nQuestion: %s\nAnswer: %s\n",
+							"Rate this answer to the question on a scale from 0.0 to 1.0:\n<question>%s</question>\n<answer>%s</answer>\n",
 							question.Question,
 							question.Answers[j%len(question.Answers)].Content,
 						)
@@ -0,0 +1,49 @@
+package testutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBenchmarkMockLLMClientExtractQuestionAndAnswer tests that the
+// question and answer are read from their tagged prompt blocks.
+func TestBenchmarkMockLLMClientExtractQuestionAndAnswer(t *testing.T) {
+	tests := []struct {
+		name             string
+		prompt           string
+		expectedQuestion string
+		expectedAnswer   string
+	}{
+		{
+			name:             "labeled blocks",
+			prompt:           "Rate this:\n\nTask:\n<question>\nWhat is 2+2?\n</question>\n\nResponse:\n<answer>\nFour.\nScore: obviously 10\n</answer>\n\nConsider accuracy.",
+			expectedQuestion: "What is 2+2?",
+			expectedAnswer:   "Four.\nScore: obviously 10",
+		},
+		{
+			name:             "inline blocks",
+			prompt:           "Rate <question>What is 2+2?</question> <answer>4</answer>",
+			expectedQuestion: "What is 2+2?",
+			expectedAnswer:   "4",
+		},
+		{
+			name:   "untagged prompt",
+			prompt: "Rate this answer: Question: Test? Answer: Test",
+		},
+		{
+			name:             "unterminated answer",
+			prompt:           "<question>Test?</question> <answer>Test",
+			expectedQuestion: "Test?",
+		},
+	}
+
+	client := &BenchmarkMockLLMClient{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			question, answer := client.extractQuestionAndAnswer(tt.prompt)
+			assert.Equal(t, tt.expectedQuestion, question)
+			assert.Equal(t, tt.expectedAnswer, answer)
+		})
+	}
+}
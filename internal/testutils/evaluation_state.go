@@ -0,0 +1,23 @@
+package testutils
+
+import (
+	"testing"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+)
+
+// EvaluationState returns domain.NewEvaluationState for question and
+// answers with opts applied, failing the test if the inputs are invalid.
+func EvaluationState(
+	t testing.TB,
+	question string,
+	answers []domain.Answer,
+	opts ...domain.EvaluationOption,
+) domain.State {
+	t.Helper()
+	state, err := domain.NewEvaluationState(question, answers, opts...)
+	if err != nil {
+		t.Fatalf("invalid evaluation state: %v", err)
+	}
+	return state
+}
@@ -0,0 +1,104 @@
+package testutils
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+// MockEmbeddingClient implements the EmbeddingClient interface with
+// deterministic bag-of-words embeddings for consistent testing.
+// Texts sharing more words produce vectors with higher cosine similarity,
+// and identical texts always produce identical vectors.
+type MockEmbeddingClient struct {
+	// model is the mock model identifier.
+	model string
+	// dimensions is the length of every returned vector.
+	dimensions int
+
+	mu sync.Mutex
+	// calls counts Embed invocations for verifying batching and caching.
+	calls int
+	// textsEmbedded counts the total number of texts embedded.
+	textsEmbedded int
+	// overrideError is used to force an error response.
+	overrideError error
+}
+
+// NewMockEmbeddingClient creates a new MockEmbeddingClient producing vectors
+// of the given dimensionality (64 if dimensions is not positive).
+func NewMockEmbeddingClient(model string, dimensions int) *MockEmbeddingClient {
+	if dimensions <= 0 {
+		dimensions = 64
+	}
+	return &MockEmbeddingClient{model: model, dimensions: dimensions}
+}
+
+// Embed implements the EmbeddingClient.Embed method by hashing each
+// lowercase word into a bucket and L2-normalizing the resulting counts.
+func (m *MockEmbeddingClient) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.overrideError != nil {
+		return nil, m.overrideError
+	}
+	m.calls++
+	m.textsEmbedded += len(texts)
+
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vec := make([]float64, m.dimensions)
+		for _, word := range strings.Fields(strings.ToLower(text)) {
+			h := fnv.New32a()
+			_, _ = h.Write([]byte(word))
+			vec[int(h.Sum32())%m.dimensions]++
+		}
+		var norm float64
+		for _, v := range vec {
+			norm += v * v
+		}
+		if norm > 0 {
+			norm = math.Sqrt(norm)
+			for j := range vec {
+				vec[j] /= norm
+			}
+		}
+		vectors[i] = vec
+	}
+	return vectors, nil
+}
+
+// GetModel implements the EmbeddingClient.GetModel method.
+func (m *MockEmbeddingClient) GetModel() string { return m.model }
+
+// SetError sets a specific error that will be returned for all requests.
+func (m *MockEmbeddingClient) SetError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.overrideError = err
+}
+
+// Calls returns the number of Embed invocations made so far.
+func (m *MockEmbeddingClient) Calls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
+
+// TextsEmbedded returns the total number of texts embedded so far.
+func (m *MockEmbeddingClient) TextsEmbedded() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.textsEmbedded
+}
+
+// Verify interface compliance at compile time.
+var _ ports.EmbeddingClient = (*MockEmbeddingClient)(nil)
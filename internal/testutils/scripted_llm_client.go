@@ -0,0 +1,108 @@
+package testutils
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+// ScriptedLLMClient implements the LLMClient interface by returning a fixed
+// sequence of responses, one per call, in order. Unlike MockLLMClient it
+// does not match prompts, so tests control exactly what each call returns
+// and can inspect the prompts a unit sent. Calls beyond the script fail,
+// surfacing unexpected LLM usage.
+type ScriptedLLMClient struct {
+	// model is the mock model identifier.
+	model string
+
+	mu sync.Mutex
+	// responses are returned in order, one per call.
+	responses []string
+	// prompts records every prompt received, in call order.
+	prompts []string
+	// overrideError is used to force an error response.
+	overrideError error
+}
+
+// NewScriptedLLMClient creates a ScriptedLLMClient that returns responses
+// in order.
+func NewScriptedLLMClient(model string, responses ...string) *ScriptedLLMClient {
+	return &ScriptedLLMClient{model: model, responses: responses}
+}
+
+// Complete implements the LLMClient.Complete method by returning the next
+// scripted response.
+func (s *ScriptedLLMClient) Complete(ctx context.Context, prompt string, options map[string]any) (string, error) {
+	response, _, _, err := s.CompleteWithUsage(ctx, prompt, options)
+	return response, err
+}
+
+// CompleteWithUsage implements the LLMClient.CompleteWithUsage method by
+// returning the next scripted response with token counts estimated from
+// the prompt and response lengths.
+func (s *ScriptedLLMClient) CompleteWithUsage(ctx context.Context, prompt string, options map[string]any) (string, int, int, error) {
+	if ctx.Err() != nil {
+		return "", 0, 0, ctx.Err()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	call := len(s.prompts)
+	s.prompts = append(s.prompts, prompt)
+	tokensIn, _ := s.EstimateTokens(prompt)
+
+	if s.overrideError != nil {
+		return "", tokensIn, 0, s.overrideError
+	}
+	if call >= len(s.responses) {
+		return "", tokensIn, 0, fmt.Errorf("scripted client: no response for call %d (script has %d)", call+1, len(s.responses))
+	}
+
+	response := s.responses[call]
+	tokensOut, _ := s.EstimateTokens(response)
+	return response, tokensIn, tokensOut, nil
+}
+
+// EstimateTokens implements the LLMClient.EstimateTokens method as one
+// token per four characters, with a minimum of one.
+func (s *ScriptedLLMClient) EstimateTokens(text string) (int, error) {
+	return max(len(text)/4, 1), nil
+}
+
+// GetModel implements the LLMClient.GetModel method returning the mock
+// model identifier.
+func (s *ScriptedLLMClient) GetModel() string { return s.model }
+
+// SetError sets an error returned by every call.
+func (s *ScriptedLLMClient) SetError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overrideError = err
+}
+
+// Prompts returns the prompts received so far, in call order.
+func (s *ScriptedLLMClient) Prompts() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.prompts...)
+}
+
+// Calls returns the number of calls received so far.
+func (s *ScriptedLLMClient) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.prompts)
+}
+
+// Remaining returns the number of scripted responses not yet returned.
+func (s *ScriptedLLMClient) Remaining() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return max(len(s.responses)-len(s.prompts), 0)
+}
+
+// Verify interface compliance at compile time.
+var _ ports.LLMClient = (*ScriptedLLMClient)(nil)
@@ -0,0 +1,45 @@
+package testutils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestScriptedLLMClient tests that responses are returned in order, prompts
+// are recorded, and calls beyond the script or with a forced error fail.
+func TestScriptedLLMClient(t *testing.T) {
+	ctx := context.Background()
+	client := NewScriptedLLMClient("scripted", "first", "second")
+
+	response, err := client.Complete(ctx, "prompt one", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "first", response)
+
+	response, tokensIn, tokensOut, err := client.CompleteWithUsage(ctx, "prompt two", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "second", response)
+	assert.Equal(t, 2, tokensIn)
+	assert.Equal(t, 1, tokensOut)
+
+	assert.Equal(t, []string{"prompt one", "prompt two"}, client.Prompts())
+	assert.Equal(t, 0, client.Remaining())
+
+	_, err = client.Complete(ctx, "prompt three", nil)
+	assert.ErrorContains(t, err, "no response for call 3")
+	assert.Equal(t, 3, client.Calls())
+
+	boom := errors.New("provider down")
+	client.SetError(boom)
+	_, err = client.Complete(ctx, "prompt four", nil)
+	assert.ErrorIs(t, err, boom)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = NewScriptedLLMClient("scripted", "unused").Complete(cancelled, "prompt", nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, "scripted", client.GetModel())
+}
@@ -0,0 +1,124 @@
+package testutils
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ahrav/go-gavel/internal/domain"
+	"github.com/ahrav/go-gavel/internal/ports"
+)
+
+// UnitFactory builds the unit under test around llm. Deterministic units
+// ignore llm.
+type UnitFactory func(llm ports.LLMClient) (ports.Unit, error)
+
+// UnitFixture describes one scenario for RunUnitFixtures: the input state,
+// the LLM responses the unit will receive, and the expected outcome.
+type UnitFixture struct {
+	// Name identifies the fixture as a subtest.
+	Name string
+
+	// State is the unit's input. A zero State is replaced by an empty one.
+	State domain.State
+
+	// Responses are returned to the unit's LLM calls in order. Every
+	// response must be consumed, and calls beyond them fail. Leave empty
+	// for deterministic units.
+	Responses []string
+
+	// LLMError, when set, is returned by every LLM call instead.
+	LLMError error
+
+	// WantErr, when set, must match the execution error via errors.Is.
+	WantErr error
+
+	// WantErrContains, when set, must appear in the execution error.
+	WantErrContains string
+
+	// Check inspects the output state of a successful execution. For
+	// failing fixtures it receives the state the unit returned.
+	Check func(t *testing.T, state domain.State)
+
+	// CheckClient inspects the scripted client after execution, for
+	// example to assert on the prompts the unit sent.
+	CheckClient func(t *testing.T, client *ScriptedLLMClient)
+}
+
+// RunUnitFixtures runs each fixture as a subtest against a fresh unit from
+// newUnit backed by a ScriptedLLMClient. Beyond the fixture's own
+// expectations, every run verifies the contract shared by all units:
+//   - the unit validates and keeps the name it was given;
+//   - execution fails if and only if the fixture expects an error;
+//   - a failing unit returns its input state unchanged;
+//   - the input state is not modified;
+//   - every scripted LLM response is consumed.
+func RunUnitFixtures(t *testing.T, newUnit UnitFactory, fixtures []UnitFixture) {
+	t.Helper()
+
+	for _, fixture := range fixtures {
+		t.Run(fixture.Name, func(t *testing.T) {
+			client := NewScriptedLLMClient("fixture-model", fixture.Responses...)
+			if fixture.LLMError != nil {
+				client.SetError(fixture.LLMError)
+			}
+
+			unit, err := newUnit(client)
+			if err != nil {
+				t.Fatalf("creating unit: %v", err)
+			}
+			if err := unit.Validate(); err != nil {
+				t.Fatalf("unit %s failed validation: %v", unit.Name(), err)
+			}
+
+			input := fixture.State
+			if reflect.ValueOf(input).IsZero() {
+				input = domain.NewState()
+			}
+			inputKeys := sortedKeys(input)
+
+			output, err := unit.Execute(context.Background(), input)
+
+			wantErr := fixture.WantErr != nil || fixture.WantErrContains != ""
+			switch {
+			case wantErr && err == nil:
+				t.Fatalf("expected an error, got none")
+			case !wantErr && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fixture.WantErr != nil && !errors.Is(err, fixture.WantErr) {
+				t.Errorf("error %v does not match %v", err, fixture.WantErr)
+			}
+			if fixture.WantErrContains != "" && !strings.Contains(err.Error(), fixture.WantErrContains) {
+				t.Errorf("error %q does not contain %q", err, fixture.WantErrContains)
+			}
+			if keys := sortedKeys(output); err != nil && !slices.Equal(keys, inputKeys) {
+				t.Errorf("failing unit returned modified state: keys %v, want %v", keys, inputKeys)
+			}
+			if keys := sortedKeys(input); !slices.Equal(keys, inputKeys) {
+				t.Errorf("unit modified its input state: keys %v, want %v", keys, inputKeys)
+			}
+
+			if fixture.LLMError == nil && client.Remaining() > 0 {
+				t.Errorf("%d of %d scripted responses were not consumed", client.Remaining(), len(fixture.Responses))
+			}
+
+			if fixture.Check != nil {
+				fixture.Check(t, output)
+			}
+			if fixture.CheckClient != nil {
+				fixture.CheckClient(t, client)
+			}
+		})
+	}
+}
+
+// sortedKeys returns the keys of state in sorted order.
+func sortedKeys(state domain.State) []string {
+	keys := state.Keys()
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,127 @@
+// Package textnorm implements the text normalization policy shared by the
+// deterministic text units: Unicode case folding and optional stopword
+// removal. Every unit that compares text normalizes it here, so a metric
+// added later folds "İstanbul" or "straße" exactly as the existing ones do.
+//
+// # Folding policy
+//
+// Fold applies Unicode full case folding (the C and F mappings of
+// CaseFolding.txt) with no language-specific tailoring. In particular:
+//
+//   - "ß" folds to "ss", so "straße" and "STRASSE" compare equal.
+//   - "İ" (U+0130) folds to "i̇", an "i" followed by U+0307 COMBINING DOT
+//     ABOVE, so "İstanbul" is one rune longer than "istanbul" rather than
+//     equal to it. Turkic dotted and dotless i rules are not applied.
+//   - Final sigma "ς" folds to "σ", so word-final and medial forms match.
+//   - Characters without case, such as CJK ideographs and emoji, are left
+//     unchanged.
+//
+// Folding does not apply Unicode normalization (NFC or NFKC), so
+// precomposed and decomposed forms of the same character remain distinct.
+// Folded text is intended for comparison only and should never be shown in
+// place of the original.
+package textnorm
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+)
+
+// foldCaser is shared by all callers. The full case folder keeps no state
+// between calls, so it is safe for concurrent use and avoids allocating a
+// new caser per string.
+var foldCaser = cases.Fold()
+
+// englishStopwords is the default stopword list: common English function
+// words that carry little meaning on their own.
+var englishStopwords = []string{
+	"a", "an", "and", "are", "as", "at", "be", "but", "by", "for", "from",
+	"has", "have", "he", "her", "his", "i", "in", "is", "it", "its", "of",
+	"on", "or", "she", "so", "that", "the", "their", "them", "then", "there",
+	"these", "they", "this", "to", "was", "were", "will", "with", "you",
+}
+
+// Fold returns s with Unicode full case folding applied, as described in
+// the package documentation.
+func Fold(s string) string {
+	return foldCaser.String(s)
+}
+
+// DefaultStopwords returns a copy of the English stopword list used when a
+// Policy removes stopwords without supplying its own list.
+func DefaultStopwords() []string {
+	return append([]string(nil), englishStopwords...)
+}
+
+// Policy configures how a Normalizer prepares text for comparison.
+type Policy struct {
+	// CaseSensitive disables case folding.
+	CaseSensitive bool
+
+	// RemoveStopwords drops stopwords from the text. The text is split on
+	// whitespace and the remaining words are rejoined with single spaces.
+	RemoveStopwords bool
+
+	// Stopwords replaces the default English list when RemoveStopwords is
+	// set. Words are matched against it after folding, whatever the value
+	// of CaseSensitive, and must match a stopword exactly, so "the," with
+	// trailing punctuation is kept.
+	Stopwords []string
+}
+
+// Normalizer applies a Policy. It is immutable after construction and safe
+// for concurrent use.
+type Normalizer struct {
+	policy    Policy
+	stopwords map[string]struct{}
+}
+
+// New returns a Normalizer for policy, folding the stopword list once up
+// front.
+func New(policy Policy) *Normalizer {
+	n := &Normalizer{policy: policy}
+	if !policy.RemoveStopwords {
+		return n
+	}
+
+	words := policy.Stopwords
+	if words == nil {
+		words = englishStopwords
+	}
+	n.stopwords = make(map[string]struct{}, len(words))
+	for _, word := range words {
+		n.stopwords[Fold(word)] = struct{}{}
+	}
+	return n
+}
+
+// Normalize folds s unless the policy is case sensitive, then removes
+// stopwords when the policy asks for it.
+func (n *Normalizer) Normalize(s string) string {
+	if !n.policy.CaseSensitive {
+		s = Fold(s)
+	}
+	if n.stopwords == nil {
+		return s
+	}
+
+	fields := strings.Fields(s)
+	kept := fields[:0]
+	for _, field := range fields {
+		if !n.IsStopword(field) {
+			kept = append(kept, field)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// IsStopword reports whether word is one of the policy's stopwords after
+// folding. It always reports false when stopword removal is disabled.
+func (n *Normalizer) IsStopword(word string) bool {
+	if n.stopwords == nil {
+		return false
+	}
+	_, ok := n.stopwords[Fold(word)]
+	return ok
+}
@@ -0,0 +1,137 @@
+package textnorm
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFold tests the documented folding policy, including the Unicode edge
+// cases the text units depend on.
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "ASCII", input: "Hello World", expected: "hello world"},
+		{name: "German ß folds to ss", input: "straße", expected: "strasse"},
+		{name: "uppercase SS", input: "STRASSE", expected: "strasse"},
+		{name: "Turkish İ keeps combining dot", input: "İstanbul", expected: "i̇stanbul"},
+		{name: "final sigma", input: "ΣΊΣΥΦΟΣ", expected: "σίσυφοσ"},
+		{name: "word-final ς", input: "λόγος", expected: "λόγοσ"},
+		{name: "Chinese unchanged", input: "你好世界", expected: "你好世界"},
+		{name: "emoji unchanged", input: "Hello 👋 World", expected: "hello 👋 world"},
+		{name: "mixed scripts", input: "Hello世界", expected: "hello世界"},
+		{name: "no normalization", input: "é", expected: "é"},
+		{name: "empty", input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Fold(tt.input))
+		})
+	}
+
+	t.Run("precomposed and decomposed stay distinct", func(t *testing.T) {
+		assert.NotEqual(t, Fold("é"), Fold("é"))
+	})
+}
+
+// TestNormalizer_Normalize tests case folding and stopword removal under
+// each policy.
+func TestNormalizer_Normalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   Policy
+		input    string
+		expected string
+	}{
+		{
+			name:     "zero policy folds only",
+			input:    "The  Capital of FRANCE",
+			expected: "the  capital of france",
+		},
+		{
+			name:     "case sensitive leaves text unchanged",
+			policy:   Policy{CaseSensitive: true},
+			input:    "The Capital",
+			expected: "The Capital",
+		},
+		{
+			name:     "default stopwords",
+			policy:   Policy{RemoveStopwords: true},
+			input:    "The capital of  France is Paris",
+			expected: "capital france paris",
+		},
+		{
+			name:     "stopwords matched case-insensitively when case sensitive",
+			policy:   Policy{CaseSensitive: true, RemoveStopwords: true},
+			input:    "The Capital of France",
+			expected: "Capital France",
+		},
+		{
+			name:     "custom stopwords replace defaults",
+			policy:   Policy{RemoveStopwords: true, Stopwords: []string{"capital", "STRASSE"}},
+			input:    "The capital straße",
+			expected: "the",
+		},
+		{
+			name:     "punctuation keeps word",
+			policy:   Policy{RemoveStopwords: true},
+			input:    "the, end",
+			expected: "the, end",
+		},
+		{
+			name:     "all stopwords",
+			policy:   Policy{RemoveStopwords: true},
+			input:    "it is the",
+			expected: "",
+		},
+		{
+			name:     "empty custom list removes nothing but collapses spaces",
+			policy:   Policy{RemoveStopwords: true, Stopwords: []string{}},
+			input:    "the  end",
+			expected: "the end",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, New(tt.policy).Normalize(tt.input))
+		})
+	}
+}
+
+// TestNormalizer_IsStopword tests stopword lookups with and without
+// removal enabled.
+func TestNormalizer_IsStopword(t *testing.T) {
+	n := New(Policy{RemoveStopwords: true})
+	assert.True(t, n.IsStopword("THE"))
+	assert.False(t, n.IsStopword("paris"))
+	assert.False(t, New(Policy{}).IsStopword("the"))
+}
+
+// TestDefaultStopwords tests that callers cannot modify the default list.
+func TestDefaultStopwords(t *testing.T) {
+	words := DefaultStopwords()
+	assert.Contains(t, words, "the")
+	words[0] = "paris"
+	assert.False(t, New(Policy{RemoveStopwords: true}).IsStopword("paris"))
+}
+
+// TestNormalizer_Concurrent tests that a Normalizer can be shared across
+// goroutines.
+func TestNormalizer_Concurrent(t *testing.T) {
+	n := New(Policy{RemoveStopwords: true})
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "strasse i̇stanbul", n.Normalize("The Straße in İstanbul"))
+		}()
+	}
+	wg.Wait()
+}